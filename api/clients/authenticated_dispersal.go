@@ -0,0 +1,147 @@
+package clients
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/proto"
+)
+
+var (
+	// ErrSigningFailed is returned when the signer fails to provide the account ID or to sign the challenge
+	ErrSigningFailed = errors.New("failed to sign authenticated dispersal")
+	// ErrAuthenticationFailed is returned when the disperser rejects the signed challenge
+	ErrAuthenticationFailed = errors.New("disperser rejected the dispersal authentication")
+	// ErrUnexpectedReply is returned when the disperser does not follow the authentication handshake
+	ErrUnexpectedReply = errors.New("unexpected reply from the disperser")
+	// ErrHandshakeTimeout is returned when the authenticated dispersal does not complete before the timeout
+	ErrHandshakeTimeout = errors.New("authenticated dispersal timed out")
+)
+
+// DispersalSigner signs the challenges of authenticated dispersals on behalf of an account.
+// It abstracts over where the key of the account is held (a local key, a KMS, a hardware wallet),
+// and takes a context because remote signers can be slow or may need to be cancelled.
+type DispersalSigner interface {
+	// AccountID returns the hex encoded public key of the account
+	AccountID(ctx context.Context) (string, error)
+	// SignChallenge signs the challenge of the disperser, which is set as the nonce of the header
+	SignChallenge(ctx context.Context, header core.BlobAuthHeader) ([]byte, error)
+}
+
+type blobRequestSignerAdapter struct {
+	signer core.BlobRequestSigner
+}
+
+// NewDispersalSigner returns a DispersalSigner backed by a core.BlobRequestSigner such as auth.LocalBlobRequestSigner.
+func NewDispersalSigner(signer core.BlobRequestSigner) DispersalSigner {
+	return &blobRequestSignerAdapter{signer: signer}
+}
+
+func (a *blobRequestSignerAdapter) AccountID(ctx context.Context) (string, error) {
+	return a.signer.GetAccountID()
+}
+
+func (a *blobRequestSignerAdapter) SignChallenge(ctx context.Context, header core.BlobAuthHeader) ([]byte, error) {
+	return a.signer.SignBlobRequest(header)
+}
+
+// DisperseBlobAuthenticated disperses a blob through the DisperseBlobAuthenticated endpoint: it opens the stream,
+// sends a copy of the request on behalf of the signer's account, signs the challenge returned by the disperser and
+// waits for the dispersal reply. The whole handshake, including the signing, must complete within the timeout.
+//
+// Failures are mapped to ErrSigningFailed, ErrAuthenticationFailed, ErrUnexpectedReply and ErrHandshakeTimeout.
+// The underlying error, e.g. the grpc status returned by the disperser, is wrapped as well.
+func DisperseBlobAuthenticated(
+	ctx context.Context,
+	client disperser_rpc.DisperserClient,
+	signer DispersalSigner,
+	request *disperser_rpc.DisperseBlobRequest,
+	timeout time.Duration,
+) (*disperser_rpc.DisperseBlobReply, error) {
+	ctxTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+
+	accountId, err := signer.AccountID(ctxTimeout)
+	if err != nil {
+		return nil, mapHandshakeError(ctxTimeout, fmt.Errorf("%w: failed to get account ID: %w", ErrSigningFailed, err))
+	}
+	// The request of the caller is left as is, as it may be reused with another signer
+	request = proto.Clone(request).(*disperser_rpc.DisperseBlobRequest)
+	request.AccountId = accountId
+
+	stream, err := client.DisperseBlobAuthenticated(ctxTimeout)
+	if err != nil {
+		return nil, mapHandshakeError(ctxTimeout, fmt.Errorf("failed to open DisperseBlobAuthenticated stream: %w", err))
+	}
+	defer func() { _ = stream.CloseSend() }()
+
+	err = stream.Send(&disperser_rpc.AuthenticatedRequest{Payload: &disperser_rpc.AuthenticatedRequest_DisperseRequest{
+		DisperseRequest: request,
+	}})
+	if err != nil {
+		return nil, mapHandshakeError(ctxTimeout, fmt.Errorf("failed to send request: %w", err))
+	}
+
+	reply, err := stream.Recv()
+	if err != nil {
+		return nil, mapHandshakeError(ctxTimeout, fmt.Errorf("failed to receive challenge: %w", err))
+	}
+	challenge, ok := reply.GetPayload().(*disperser_rpc.AuthenticatedReply_BlobAuthHeader)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected challenge, got %T", ErrUnexpectedReply, reply.GetPayload())
+	}
+
+	authData, err := signer.SignChallenge(ctxTimeout, core.BlobAuthHeader{
+		BlobCommitments: encoding.BlobCommitments{},
+		AccountID:       accountId,
+		Nonce:           challenge.BlobAuthHeader.GetChallengeParameter(),
+	})
+	if err != nil {
+		return nil, mapHandshakeError(ctxTimeout, fmt.Errorf("%w: %w", ErrSigningFailed, err))
+	}
+
+	err = stream.Send(&disperser_rpc.AuthenticatedRequest{Payload: &disperser_rpc.AuthenticatedRequest_AuthenticationData{
+		AuthenticationData: &disperser_rpc.AuthenticationData{
+			AuthenticationData: authData,
+		},
+	}})
+	if err != nil {
+		return nil, mapHandshakeError(ctxTimeout, fmt.Errorf("failed to send challenge reply: %w", err))
+	}
+
+	reply, err = stream.Recv()
+	if err != nil {
+		err = mapHandshakeError(ctxTimeout, fmt.Errorf("failed to receive dispersal reply: %w", err))
+		if errors.Is(err, ErrHandshakeTimeout) {
+			return nil, err
+		}
+		// The request itself was validated before the challenge was issued, so an invalid argument
+		// at this point means that the signature was rejected.
+		switch status.Code(err) {
+		case codes.InvalidArgument, codes.Unauthenticated, codes.PermissionDenied:
+			return nil, fmt.Errorf("%w: %w", ErrAuthenticationFailed, err)
+		}
+		return nil, err
+	}
+	disperseReply, ok := reply.GetPayload().(*disperser_rpc.AuthenticatedReply_DisperseReply)
+	if !ok {
+		return nil, fmt.Errorf("%w: expected dispersal reply, got %T", ErrUnexpectedReply, reply.GetPayload())
+	}
+
+	return disperseReply.DisperseReply, nil
+}
+
+// mapHandshakeError wraps the error with ErrHandshakeTimeout if the handshake ran out of time
+func mapHandshakeError(ctx context.Context, err error) error {
+	if errors.Is(ctx.Err(), context.DeadlineExceeded) || status.Code(err) == codes.DeadlineExceeded {
+		return fmt.Errorf("%w: %w", ErrHandshakeTimeout, err)
+	}
+	return err
+}
@@ -0,0 +1,211 @@
+package clients_test
+
+import (
+	"context"
+	"errors"
+	"net"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+const testSignerKey = "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+
+// mockAuthenticatedDisperser runs the server side of the handshake, customizable with the challenge
+// and the final reply hooks
+type mockAuthenticatedDisperser struct {
+	disperser_rpc.UnimplementedDisperserServer
+
+	challenge func(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer) error
+	reply     func(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer, header core.BlobAuthHeader) error
+}
+
+func (s *mockAuthenticatedDisperser) DisperseBlobAuthenticated(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer) error {
+	in, err := stream.Recv()
+	if err != nil {
+		return err
+	}
+	request := in.GetDisperseRequest()
+	if request == nil {
+		return api.NewInvalidArgError("missing DisperseBlobRequest")
+	}
+
+	if s.challenge != nil {
+		if err := s.challenge(stream); err != nil {
+			return err
+		}
+	}
+	header := core.BlobAuthHeader{
+		AccountID: request.GetAccountId(),
+		Nonce:     42,
+	}
+	err = stream.Send(&disperser_rpc.AuthenticatedReply{Payload: &disperser_rpc.AuthenticatedReply_BlobAuthHeader{
+		BlobAuthHeader: &disperser_rpc.BlobAuthHeader{ChallengeParameter: header.Nonce},
+	}})
+	if err != nil {
+		return err
+	}
+
+	in, err = stream.Recv()
+	if err != nil {
+		return err
+	}
+	header.AuthenticationData = in.GetAuthenticationData().GetAuthenticationData()
+	return s.reply(stream, header)
+}
+
+func startMockAuthenticatedDisperser(t *testing.T, server *mockAuthenticatedDisperser) disperser_rpc.DisperserClient {
+	return startMockDisperser(t, server)
+}
+
+func startMockDisperser(t *testing.T, server disperser_rpc.DisperserServer) disperser_rpc.DisperserClient {
+	listener := bufconn.Listen(1024 * 1024)
+	grpcServer := grpc.NewServer()
+	disperser_rpc.RegisterDisperserServer(grpcServer, server)
+	go func() { _ = grpcServer.Serve(listener) }()
+	t.Cleanup(grpcServer.Stop)
+
+	conn, err := grpc.Dial("bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) {
+			return listener.DialContext(ctx)
+		}),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	assert.NoError(t, err)
+	t.Cleanup(func() { _ = conn.Close() })
+	return disperser_rpc.NewDisperserClient(conn)
+}
+
+// authenticatingReply authenticates the signed challenge like the disperser does before dispersing the blob
+func authenticatingReply(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer, header core.BlobAuthHeader) error {
+	if err := auth.NewAuthenticator(auth.AuthConfig{}).AuthenticateBlobRequest(header); err != nil {
+		return api.NewInvalidArgError(err.Error())
+	}
+	return stream.Send(&disperser_rpc.AuthenticatedReply{Payload: &disperser_rpc.AuthenticatedReply_DisperseReply{
+		DisperseReply: &disperser_rpc.DisperseBlobReply{
+			Result:    disperser_rpc.BlobStatus_PROCESSING,
+			RequestId: []byte("request-id"),
+		},
+	}})
+}
+
+type slowSigner struct {
+	clients.DispersalSigner
+}
+
+func (s *slowSigner) SignChallenge(ctx context.Context, header core.BlobAuthHeader) ([]byte, error) {
+	<-ctx.Done()
+	return nil, ctx.Err()
+}
+
+type wrongKeySigner struct {
+	clients.DispersalSigner
+	other clients.DispersalSigner
+}
+
+func (s *wrongKeySigner) SignChallenge(ctx context.Context, header core.BlobAuthHeader) ([]byte, error) {
+	return s.other.SignChallenge(ctx, header)
+}
+
+func TestDisperseBlobAuthenticated(t *testing.T) {
+	client := startMockAuthenticatedDisperser(t, &mockAuthenticatedDisperser{reply: authenticatingReply})
+	signer := clients.NewDispersalSigner(auth.NewLocalBlobRequestSigner(testSignerKey))
+
+	request := &disperser_rpc.DisperseBlobRequest{Data: []byte{0, 1, 2}}
+	reply, err := clients.DisperseBlobAuthenticated(context.Background(), client, signer, request, time.Second)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser_rpc.BlobStatus_PROCESSING, reply.GetResult())
+	assert.Equal(t, []byte("request-id"), reply.GetRequestId())
+
+	// The request is sent on behalf of the account of the signer, without modifying the request of the caller
+	assert.Empty(t, request.GetAccountId())
+}
+
+func TestDisperseBlobAuthenticatedErrors(t *testing.T) {
+	signer := clients.NewDispersalSigner(auth.NewLocalBlobRequestSigner(testSignerKey))
+	otherSigner := clients.NewDispersalSigner(auth.NewLocalBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcded"))
+
+	tests := []struct {
+		name        string
+		server      *mockAuthenticatedDisperser
+		signer      clients.DispersalSigner
+		expectedErr error
+	}{
+		{
+			name:        "signature rejected",
+			server:      &mockAuthenticatedDisperser{reply: authenticatingReply},
+			signer:      &wrongKeySigner{DispersalSigner: signer, other: otherSigner},
+			expectedErr: clients.ErrAuthenticationFailed,
+		},
+		{
+			name:        "signer cannot provide account",
+			server:      &mockAuthenticatedDisperser{reply: authenticatingReply},
+			signer:      clients.NewDispersalSigner(auth.NewLocalNoopSigner()),
+			expectedErr: clients.ErrSigningFailed,
+		},
+		{
+			name:        "signing outlasts the timeout",
+			server:      &mockAuthenticatedDisperser{reply: authenticatingReply},
+			signer:      &slowSigner{DispersalSigner: signer},
+			expectedErr: clients.ErrHandshakeTimeout,
+		},
+		{
+			name: "challenge never sent",
+			server: &mockAuthenticatedDisperser{
+				challenge: func(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer) error {
+					<-stream.Context().Done()
+					return stream.Context().Err()
+				},
+				reply: authenticatingReply,
+			},
+			signer:      signer,
+			expectedErr: clients.ErrHandshakeTimeout,
+		},
+		{
+			name: "dispersal reply replaced by a challenge",
+			server: &mockAuthenticatedDisperser{
+				reply: func(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer, header core.BlobAuthHeader) error {
+					return stream.Send(&disperser_rpc.AuthenticatedReply{Payload: &disperser_rpc.AuthenticatedReply_BlobAuthHeader{
+						BlobAuthHeader: &disperser_rpc.BlobAuthHeader{ChallengeParameter: 1},
+					}})
+				},
+			},
+			signer:      signer,
+			expectedErr: clients.ErrUnexpectedReply,
+		},
+		{
+			name: "dispersal rate limited",
+			server: &mockAuthenticatedDisperser{
+				reply: func(stream disperser_rpc.Disperser_DisperseBlobAuthenticatedServer, header core.BlobAuthHeader) error {
+					return api.NewResourceExhaustedError("request ratelimited")
+				},
+			},
+			signer: signer,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client := startMockAuthenticatedDisperser(t, tt.server)
+			request := &disperser_rpc.DisperseBlobRequest{Data: []byte{0, 1, 2}}
+			_, err := clients.DisperseBlobAuthenticated(context.Background(), client, tt.signer, request, 100*time.Millisecond)
+			assert.Error(t, err)
+			if tt.expectedErr != nil {
+				assert.ErrorIs(t, err, tt.expectedErr)
+				return
+			}
+			for _, sentinel := range []error{clients.ErrAuthenticationFailed, clients.ErrSigningFailed, clients.ErrHandshakeTimeout, clients.ErrUnexpectedReply} {
+				assert.False(t, errors.Is(err, sentinel), "unexpected %v", sentinel)
+			}
+		})
+	}
+}
@@ -40,6 +40,10 @@ type EigenDAClientConfig struct {
 
 	// If true, will wait for the blob to finalize, if false, will wait only for the blob to confirm.
 	WaitForFinalization bool
+
+	// If true, only the account of SignerPrivateKeyHex can retrieve the blobs written by this client from the
+	// disperser. The blobs are still stored by the operators and can be retrieved from them by anyone.
+	PrivateRetrieval bool
 }
 
 func (c *EigenDAClientConfig) CheckAndSetDefaults() error {
@@ -55,6 +59,9 @@ func (c *EigenDAClientConfig) CheckAndSetDefaults() error {
 	if len(c.SignerPrivateKeyHex) > 0 && len(c.SignerPrivateKeyHex) != 64 {
 		return fmt.Errorf("a valid length SignerPrivateKeyHex needs to have 64 bytes")
 	}
+	if c.PrivateRetrieval && len(c.SignerPrivateKeyHex) == 0 {
+		return fmt.Errorf("PrivateRetrieval requires a SignerPrivateKeyHex")
+	}
 
 	if len(c.RPC) == 0 {
 		return fmt.Errorf("EigenDAClientConfig.RPC not set")
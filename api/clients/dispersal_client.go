@@ -0,0 +1,237 @@
+package clients
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"math/rand"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/encoding/rs"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/status"
+)
+
+var (
+	// ErrDispersalFailed is returned when the disperser fails to disperse a blob, e.g. because its status is FAILED or
+	// INSUFFICIENT_SIGNATURES
+	ErrDispersalFailed = errors.New("blob dispersal failed")
+)
+
+type DispersalClientConfig struct {
+	// Disperser is the host:port of the disperser
+	Disperser string
+	// Whether to disable TLS for an insecure connection, e.g. to a local disperser
+	DisableTLS bool
+	// The timeout of each request to the disperser, including the authentication handshake
+	RequestTimeout time.Duration
+	// The number of times a request is retried after failing with Unavailable or ResourceExhausted
+	MaxRetries int
+	// The delay before the first retry, which doubles on each retry up to MaxBackoff
+	InitialBackoff time.Duration
+	MaxBackoff     time.Duration
+	// The interval between the status requests while waiting for a blob to be finalized
+	StatusPollInterval time.Duration
+}
+
+func (c *DispersalClientConfig) CheckAndSetDefaults() error {
+	if len(c.Disperser) == 0 {
+		return fmt.Errorf("DispersalClientConfig.Disperser not set")
+	}
+	if c.RequestTimeout == 0 {
+		c.RequestTimeout = 30 * time.Second
+	}
+	if c.MaxRetries < 0 {
+		return fmt.Errorf("DispersalClientConfig.MaxRetries must not be negative")
+	}
+	if c.InitialBackoff == 0 {
+		c.InitialBackoff = time.Second
+	}
+	if c.MaxBackoff == 0 {
+		c.MaxBackoff = 30 * time.Second
+	}
+	if c.MaxBackoff < c.InitialBackoff {
+		return fmt.Errorf("DispersalClientConfig.MaxBackoff must not be less than InitialBackoff")
+	}
+	if c.StatusPollInterval == 0 {
+		c.StatusPollInterval = 5 * time.Second
+	}
+	return nil
+}
+
+// DispersalClient is a high level client of the disperser. It keeps a connection to the disperser, retries the
+// requests failing with Unavailable or ResourceExhausted with an exponential backoff, runs the authentication
+// handshake of DisperseBlobAuthenticated if it has a signer, and waits for the dispersed blobs to be finalized.
+type DispersalClient struct {
+	config DispersalClientConfig
+	conn   *grpc.ClientConn
+	client disperser_rpc.DisperserClient
+	// signer is nil if the blobs are dispersed without authentication
+	signer DispersalSigner
+}
+
+// NewDispersalClient returns a client connected to the disperser, which disperses the blobs with authentication if
+// the signer isn't nil. The client must be closed once it is no longer used.
+func NewDispersalClient(config DispersalClientConfig, signer DispersalSigner) (*DispersalClient, error) {
+	if err := config.CheckAndSetDefaults(); err != nil {
+		return nil, err
+	}
+	credential := credentials.NewTLS(&tls.Config{})
+	if config.DisableTLS {
+		credential = insecure.NewCredentials()
+	}
+	conn, err := grpc.Dial(config.Disperser, grpc.WithTransportCredentials(credential))
+	if err != nil {
+		return nil, fmt.Errorf("failed to dial disperser %s: %w", config.Disperser, err)
+	}
+	return &DispersalClient{
+		config: config,
+		conn:   conn,
+		client: disperser_rpc.NewDisperserClient(conn),
+		signer: signer,
+	}, nil
+}
+
+// NewDispersalClientWithRPC returns a client sending its requests through the given disperser RPC client, whose
+// connection is managed by the caller
+func NewDispersalClientWithRPC(config DispersalClientConfig, client disperser_rpc.DisperserClient, signer DispersalSigner) (*DispersalClient, error) {
+	if err := config.CheckAndSetDefaults(); err != nil {
+		return nil, err
+	}
+	return &DispersalClient{
+		config: config,
+		client: client,
+		signer: signer,
+	}, nil
+}
+
+// Close closes the connection to the disperser
+func (c *DispersalClient) Close() error {
+	if c.conn == nil {
+		return nil
+	}
+	return c.conn.Close()
+}
+
+// DisperseBlob disperses the blob to the required quorums and to the custom quorums, and returns its request ID
+func (c *DispersalClient) DisperseBlob(ctx context.Context, data []byte, customQuorums []uint8) ([]byte, error) {
+	// check every 32 bytes of data are within the valid range for a bn254 field element
+	if _, err := rs.ToFrArray(data); err != nil {
+		return nil, fmt.Errorf("encountered an error to convert a 32-bytes into a valid field element, please use the correct format where every 32bytes(big-endian) is less than 21888242871839275222246405745257275088548364400416034343698204186575808495617, %w", err)
+	}
+	quorumNumbers := make([]uint32, len(customQuorums))
+	for i, q := range customQuorums {
+		quorumNumbers[i] = uint32(q)
+	}
+
+	var reply *disperser_rpc.DisperseBlobReply
+	err := c.retry(ctx, func(ctx context.Context) error {
+		request := &disperser_rpc.DisperseBlobRequest{
+			Data:                data,
+			CustomQuorumNumbers: quorumNumbers,
+			BlobHeaderVersions:  supportedBlobHeaderVersions(),
+		}
+		var err error
+		if c.signer != nil {
+			reply, err = DisperseBlobAuthenticated(ctx, c.client, c.signer, request, c.config.RequestTimeout)
+			return err
+		}
+		ctxTimeout, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+		defer cancel()
+		reply, err = c.client.DisperseBlob(ctxTimeout, request)
+		return err
+	})
+	if err != nil {
+		return nil, err
+	}
+	if err := checkBlobHeaderVersion(reply); err != nil {
+		return nil, err
+	}
+	if reply.GetResult() == disperser_rpc.BlobStatus_FAILED {
+		return nil, fmt.Errorf("%w: the disperser failed to accept the blob", ErrDispersalFailed)
+	}
+	return reply.GetRequestId(), nil
+}
+
+// GetBlobStatus returns the status of the blob with the request ID
+func (c *DispersalClient) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	var reply *disperser_rpc.BlobStatusReply
+	err := c.retry(ctx, func(ctx context.Context) error {
+		ctxTimeout, cancel := context.WithTimeout(ctx, c.config.RequestTimeout)
+		defer cancel()
+		var err error
+		reply, err = c.client.GetBlobStatus(ctxTimeout, &disperser_rpc.BlobStatusRequest{RequestId: requestID})
+		return err
+	})
+	return reply, err
+}
+
+// WaitForFinalization polls the status of the blob with the request ID until it is finalized, and returns the blob
+// info to verify it onchain. It returns an error wrapping ErrDispersalFailed if the dispersal of the blob fails, and
+// the error of the context once it is done.
+func (c *DispersalClient) WaitForFinalization(ctx context.Context, requestID []byte) (*disperser_rpc.BlobInfo, error) {
+	ticker := time.NewTicker(c.config.StatusPollInterval)
+	defer ticker.Stop()
+
+	for {
+		reply, err := c.GetBlobStatus(ctx, requestID)
+		if ctx.Err() != nil {
+			return nil, ctx.Err()
+		}
+		if err != nil {
+			return nil, err
+		}
+		switch reply.GetStatus() {
+		case disperser_rpc.BlobStatus_FINALIZED:
+			return reply.GetInfo(), nil
+		case disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+			return nil, fmt.Errorf("%w: status %s", ErrDispersalFailed, reply.GetStatus())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+// retry calls fn until it succeeds, fails with an error which isn't transient, or fails MaxRetries+1 times.
+// The delay between the calls grows exponentially, with jitter so that clients don't retry in lockstep, and
+// follows the retry delay of the error if it is longer, up to MaxBackoff.
+func (c *DispersalClient) retry(ctx context.Context, fn func(ctx context.Context) error) error {
+	backoff := c.config.InitialBackoff
+	for attempt := 0; ; attempt++ {
+		err := fn(ctx)
+		if err == nil || attempt >= c.config.MaxRetries || !isTransientError(err) {
+			return err
+		}
+
+		// jittered in [backoff/2, backoff], but no less than the delay hinted by a rate limited disperser
+		delay := backoff/2 + time.Duration(rand.Int63n(int64(backoff/2)+1))
+		if retryDelay, ok := api.RetryDelay(err); ok && retryDelay > delay {
+			delay = min(retryDelay, c.config.MaxBackoff)
+		}
+		select {
+		case <-ctx.Done():
+			return fmt.Errorf("%w (last error: %w)", ctx.Err(), err)
+		case <-time.After(delay):
+		}
+		backoff = min(2*backoff, c.config.MaxBackoff)
+	}
+}
+
+// isTransientError returns whether the request may succeed if it is retried later
+func isTransientError(err error) bool {
+	switch status.Code(err) {
+	case codes.Unavailable, codes.ResourceExhausted:
+		return true
+	}
+	return false
+}
@@ -0,0 +1,133 @@
+package clients_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/clients"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// mockDisperser fails the first dispersals with the given errors, and reports the given statuses in turn
+type mockDisperser struct {
+	*mockAuthenticatedDisperser
+
+	mu             sync.Mutex
+	disperseErrors []error
+	disperseCalls  int
+	statuses       []disperser_rpc.BlobStatus
+	statusCalls    int
+}
+
+func (s *mockDisperser) DisperseBlob(ctx context.Context, req *disperser_rpc.DisperseBlobRequest) (*disperser_rpc.DisperseBlobReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.disperseCalls++
+	if len(s.disperseErrors) > 0 {
+		err := s.disperseErrors[0]
+		s.disperseErrors = s.disperseErrors[1:]
+		return nil, err
+	}
+	return &disperser_rpc.DisperseBlobReply{
+		Result:    disperser_rpc.BlobStatus_PROCESSING,
+		RequestId: []byte("request-id"),
+	}, nil
+}
+
+func (s *mockDisperser) GetBlobStatus(ctx context.Context, req *disperser_rpc.BlobStatusRequest) (*disperser_rpc.BlobStatusReply, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	status := s.statuses[min(s.statusCalls, len(s.statuses)-1)]
+	s.statusCalls++
+	reply := &disperser_rpc.BlobStatusReply{Status: status}
+	if status == disperser_rpc.BlobStatus_FINALIZED {
+		reply.Info = &disperser_rpc.BlobInfo{BlobVerificationProof: &disperser_rpc.BlobVerificationProof{BatchId: 7}}
+	}
+	return reply, nil
+}
+
+func newTestDispersalClient(t *testing.T, server *mockDisperser, signer clients.DispersalSigner) *clients.DispersalClient {
+	client, err := clients.NewDispersalClientWithRPC(clients.DispersalClientConfig{
+		Disperser:          "bufnet",
+		RequestTimeout:     time.Second,
+		MaxRetries:         2,
+		InitialBackoff:     time.Millisecond,
+		MaxBackoff:         4 * time.Millisecond,
+		StatusPollInterval: time.Millisecond,
+	}, startMockDisperser(t, server), signer)
+	assert.NoError(t, err)
+	return client
+}
+
+func TestDispersalClientRetries(t *testing.T) {
+	server := &mockDisperser{disperseErrors: []error{
+		status.Error(codes.Unavailable, "disperser unavailable"),
+		api.NewResourceExhaustedError("request ratelimited"),
+	}}
+	client := newTestDispersalClient(t, server, nil)
+	requestID, err := client.DisperseBlob(context.Background(), []byte{0, 1, 2}, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("request-id"), requestID)
+	assert.Equal(t, 3, server.disperseCalls)
+
+	// Not retried beyond MaxRetries
+	server = &mockDisperser{disperseErrors: []error{
+		status.Error(codes.Unavailable, "disperser unavailable"),
+		status.Error(codes.Unavailable, "disperser unavailable"),
+		status.Error(codes.Unavailable, "disperser unavailable"),
+	}}
+	client = newTestDispersalClient(t, server, nil)
+	_, err = client.DisperseBlob(context.Background(), []byte{0, 1, 2}, nil)
+	assert.Equal(t, codes.Unavailable, status.Code(err))
+	assert.Equal(t, 3, server.disperseCalls)
+
+	// Errors which aren't transient aren't retried
+	server = &mockDisperser{disperseErrors: []error{api.NewInvalidArgError("invalid blob")}}
+	client = newTestDispersalClient(t, server, nil)
+	_, err = client.DisperseBlob(context.Background(), []byte{0, 1, 2}, nil)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Equal(t, 1, server.disperseCalls)
+}
+
+func TestDispersalClientAuthenticated(t *testing.T) {
+	server := &mockDisperser{mockAuthenticatedDisperser: &mockAuthenticatedDisperser{reply: authenticatingReply}}
+	signer := clients.NewDispersalSigner(auth.NewLocalBlobRequestSigner(testSignerKey))
+	client := newTestDispersalClient(t, server, signer)
+	requestID, err := client.DisperseBlob(context.Background(), []byte{0, 1, 2}, []uint8{2})
+	assert.NoError(t, err)
+	assert.Equal(t, []byte("request-id"), requestID)
+	// The blob went through the authenticated endpoint
+	assert.Equal(t, 0, server.disperseCalls)
+}
+
+func TestDispersalClientWaitForFinalization(t *testing.T) {
+	server := &mockDisperser{statuses: []disperser_rpc.BlobStatus{
+		disperser_rpc.BlobStatus_PROCESSING,
+		disperser_rpc.BlobStatus_CONFIRMED,
+		disperser_rpc.BlobStatus_FINALIZED,
+	}}
+	client := newTestDispersalClient(t, server, nil)
+	info, err := client.WaitForFinalization(context.Background(), []byte("request-id"))
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(7), info.GetBlobVerificationProof().GetBatchId())
+	assert.Equal(t, 3, server.statusCalls)
+
+	server = &mockDisperser{statuses: []disperser_rpc.BlobStatus{disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES}}
+	client = newTestDispersalClient(t, server, nil)
+	_, err = client.WaitForFinalization(context.Background(), []byte("request-id"))
+	assert.ErrorIs(t, err, clients.ErrDispersalFailed)
+
+	server = &mockDisperser{statuses: []disperser_rpc.BlobStatus{disperser_rpc.BlobStatus_PROCESSING}}
+	client = newTestDispersalClient(t, server, nil)
+	ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+	defer cancel()
+	_, err = client.WaitForFinalization(ctx, []byte("request-id"))
+	assert.ErrorIs(t, err, context.DeadlineExceeded)
+}
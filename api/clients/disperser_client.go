@@ -3,14 +3,12 @@ package clients
 import (
 	"context"
 	"crypto/tls"
-	"errors"
 	"fmt"
 	"time"
 
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
-	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/rs"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/credentials"
@@ -22,6 +20,9 @@ type Config struct {
 	Port              string
 	Timeout           time.Duration
 	UseSecureGrpcFlag bool
+	// PrivateRetrieval disperses the blobs of DisperseBlobAuthenticated so that only the account of the signer can
+	// retrieve them from the disperser, and signs the RetrieveBlob requests with the signer
+	PrivateRetrieval bool
 }
 
 func NewConfig(hostname, port string, timeout time.Duration, useSecureGrpcFlag bool) *Config {
@@ -91,12 +92,16 @@ func (c *disperserClient) DisperseBlob(ctx context.Context, data []byte, quorums
 	request := &disperser_rpc.DisperseBlobRequest{
 		Data:                data,
 		CustomQuorumNumbers: quorumNumbers,
+		BlobHeaderVersions:  supportedBlobHeaderVersions(),
 	}
 
 	reply, err := disperserClient.DisperseBlob(ctxTimeout, request)
 	if err != nil {
 		return nil, nil, err
 	}
+	if err := checkBlobHeaderVersion(reply); err != nil {
+		return nil, nil, err
+	}
 
 	blobStatus, err := disperser.FromBlobStatusProto(reply.GetResult())
 	if err != nil {
@@ -132,6 +137,8 @@ func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, data []
 		Data:                data,
 		CustomQuorumNumbers: quorumNumbers,
 		AccountId:           accountId,
+		BlobHeaderVersions:  supportedBlobHeaderVersions(),
+		PrivateRetrieval:    c.config.PrivateRetrieval,
 	}
 
 	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
@@ -145,70 +152,38 @@ func (c *disperserClient) DisperseBlobAuthenticated(ctx context.Context, data []
 	defer func() { _ = conn.Close() }()
 
 	disperserClient := disperser_rpc.NewDisperserClient(conn)
-	ctxTimeout, cancel := context.WithTimeout(ctx, c.config.Timeout)
-
-	defer cancel()
-
-	stream, err := disperserClient.DisperseBlobAuthenticated(ctxTimeout)
-	if err != nil {
-		return nil, nil, fmt.Errorf("error while calling DisperseBlobAuthenticated: %w", err)
-	}
-
-	// Send the initial request
-	err = stream.Send(&disperser_rpc.AuthenticatedRequest{Payload: &disperser_rpc.AuthenticatedRequest_DisperseRequest{
-		DisperseRequest: request,
-	}})
-
+	reply, err := DisperseBlobAuthenticated(ctx, disperserClient, NewDispersalSigner(c.signer), request, c.config.Timeout)
 	if err != nil {
-		return nil, nil, fmt.Errorf("failed to send request: %w", err)
-	}
-
-	// Get the Challenge
-	reply, err := stream.Recv()
-	if err != nil {
-		return nil, nil, fmt.Errorf("error while receiving: %w", err)
-	}
-	authHeaderReply, ok := reply.Payload.(*disperser_rpc.AuthenticatedReply_BlobAuthHeader)
-	if !ok {
-		return nil, nil, errors.New("expected challenge")
+		return nil, nil, err
 	}
-
-	authHeader := core.BlobAuthHeader{
-		BlobCommitments: encoding.BlobCommitments{},
-		AccountID:       "",
-		Nonce:           authHeaderReply.BlobAuthHeader.ChallengeParameter,
+	if err := checkBlobHeaderVersion(reply); err != nil {
+		return nil, nil, err
 	}
 
-	authData, err := c.signer.SignBlobRequest(authHeader)
+	blobStatus, err := disperser.FromBlobStatusProto(reply.GetResult())
 	if err != nil {
-		return nil, nil, errors.New("error signing blob request")
+		return nil, nil, err
 	}
 
-	// Process challenge and send back challenge_reply
-	err = stream.Send(&disperser_rpc.AuthenticatedRequest{Payload: &disperser_rpc.AuthenticatedRequest_AuthenticationData{
-		AuthenticationData: &disperser_rpc.AuthenticationData{
-			AuthenticationData: authData,
-		},
-	}})
-	if err != nil {
-		return nil, nil, fmt.Errorf("failed to send challenge reply: %w", err)
-	}
+	return blobStatus, reply.GetRequestId(), nil
+}
 
-	reply, err = stream.Recv()
-	if err != nil {
-		return nil, nil, fmt.Errorf("error while receiving final reply: %w", err)
-	}
-	disperseReply, ok := reply.Payload.(*disperser_rpc.AuthenticatedReply_DisperseReply) // Process the final disperse_reply
-	if !ok {
-		return nil, nil, errors.New("expected DisperseReply")
+// supportedBlobHeaderVersions returns the blob header versions that the client declares in its dispersal requests
+func supportedBlobHeaderVersions() []uint32 {
+	versions := make([]uint32, len(core.SupportedBlobHeaderVersions))
+	for i, version := range core.SupportedBlobHeaderVersions {
+		versions[i] = uint32(version)
 	}
+	return versions
+}
 
-	blobStatus, err := disperser.FromBlobStatusProto(disperseReply.DisperseReply.GetResult())
-	if err != nil {
-		return nil, nil, err
+// checkBlobHeaderVersion checks that the disperser chose one of the blob header versions declared by the client
+func checkBlobHeaderVersion(reply *disperser_rpc.DisperseBlobReply) error {
+	version := core.BlobHeaderVersion(reply.GetBlobHeaderVersion())
+	if !core.IsBlobHeaderVersionSupported(version, core.SupportedBlobHeaderVersions) {
+		return fmt.Errorf("disperser chose blob header version %d, which is not supported by the client", version)
 	}
-
-	return blobStatus, disperseReply.DisperseReply.GetRequestId(), nil
+	return nil
 }
 
 func (c *disperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
@@ -236,6 +211,22 @@ func (c *disperserClient) GetBlobStatus(ctx context.Context, requestID []byte) (
 }
 
 func (c *disperserClient) RetrieveBlob(ctx context.Context, batchHeaderHash []byte, blobIndex uint32) ([]byte, error) {
+	request := &disperser_rpc.RetrieveBlobRequest{
+		BatchHeaderHash: batchHeaderHash,
+		BlobIndex:       blobIndex,
+	}
+	if c.config.PrivateRetrieval {
+		if c.signer == nil {
+			return nil, fmt.Errorf("uninitialized signer for private retrieval")
+		}
+		request.Timestamp = uint64(time.Now().Unix())
+		sig, err := c.signer.SignRetrievalRequest(batchHeaderHash, blobIndex, request.Timestamp)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sign retrieval request: %w", err)
+		}
+		request.AuthenticationData = sig
+	}
+
 	addr := fmt.Sprintf("%v:%v", c.config.Hostname, c.config.Port)
 
 	options := c.getDialOptions()
@@ -248,10 +239,7 @@ func (c *disperserClient) RetrieveBlob(ctx context.Context, batchHeaderHash []by
 	disperserClient := disperser_rpc.NewDisperserClient(conn)
 	ctxTimeout, cancel := context.WithTimeout(ctx, time.Second*60)
 	defer cancel()
-	reply, err := disperserClient.RetrieveBlob(ctxTimeout, &disperser_rpc.RetrieveBlobRequest{
-		BatchHeaderHash: batchHeaderHash,
-		BlobIndex:       blobIndex,
-	})
+	reply, err := disperserClient.RetrieveBlob(ctxTimeout, request)
 	if err != nil {
 		return nil, err
 	}
@@ -52,6 +52,7 @@ func NewEigenDAClient(log log.Logger, config EigenDAClientConfig) (*EigenDAClien
 	}
 
 	llConfig := NewConfig(host, port, config.ResponseTimeout, !config.DisableTLS)
+	llConfig.PrivateRetrieval = config.PrivateRetrieval
 	llClient := NewDisperserClient(llConfig, signer)
 
 	lowLevelCodec, err := codecs.BlobEncodingVersionToCodec(config.PutBlobEncodingVersion)
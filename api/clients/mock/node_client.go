@@ -61,7 +61,7 @@ func (c *MockNodeClient) GetChunks(
 			Err:        err,
 			Chunks:     nil,
 		}
-
+		return
 	}
 	chunksChan <- clients.RetrievedChunks{
 		OperatorID: opID,
@@ -0,0 +1,112 @@
+package clients
+
+import (
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// maxErrorRate caps the error rate of an operator when scoring it, so that an operator which only ever failed still
+// has a finite score and remains a last resort
+const maxErrorRate = 0.99
+
+// OperatorSelectionPolicy decides the order in which the chunks of a blob are requested from the operators of a
+// quorum. The retrieval client reports the outcome of every request back to the policy, so that it can adapt the
+// order to the operators' behavior. Implementations must be safe for concurrent use.
+type OperatorSelectionPolicy interface {
+	// Order returns the operators in the order their chunks should be requested
+	Order(operators map[core.OperatorID]*core.OperatorInfo) []core.OperatorID
+	// Observe records how long a request to the operator took and its error, nil if the operator served valid chunks
+	Observe(operatorID core.OperatorID, latency time.Duration, err error)
+}
+
+type stakeWeightedPolicy struct{}
+
+var _ OperatorSelectionPolicy = stakeWeightedPolicy{}
+
+// NewStakeWeightedSelectionPolicy returns a policy which requests the operators in a stake-weighted random order,
+// regardless of how they performed previously
+func NewStakeWeightedSelectionPolicy() OperatorSelectionPolicy {
+	return stakeWeightedPolicy{}
+}
+
+func (stakeWeightedPolicy) Order(operators map[core.OperatorID]*core.OperatorInfo) []core.OperatorID {
+	return stakeWeightedOrder(operators)
+}
+
+func (stakeWeightedPolicy) Observe(core.OperatorID, time.Duration, error) {}
+
+// operatorStats are the moving averages of the latency and error rate of the requests to an operator
+type operatorStats struct {
+	latency   time.Duration
+	errorRate float64
+}
+
+type adaptivePolicy struct {
+	mu        sync.Mutex
+	smoothing float64
+	stats     map[core.OperatorID]*operatorStats
+}
+
+var _ OperatorSelectionPolicy = (*adaptivePolicy)(nil)
+
+// NewAdaptiveSelectionPolicy returns a policy which tracks the latency and error rate of each operator as
+// exponentially weighted moving averages, and requests the operators expected to serve valid chunks the fastest
+// first. The smoothing, in (0, 1], is the weight of the latest request in the averages. Operators without any
+// request yet are requested first, in stake-weighted random order, so that every operator gets measured.
+func NewAdaptiveSelectionPolicy(smoothing float64) OperatorSelectionPolicy {
+	return &adaptivePolicy{
+		smoothing: smoothing,
+		stats:     make(map[core.OperatorID]*operatorStats),
+	}
+}
+
+func (p *adaptivePolicy) Order(operators map[core.OperatorID]*core.OperatorInfo) []core.OperatorID {
+	order := stakeWeightedOrder(operators)
+
+	p.mu.Lock()
+	scores := make(map[core.OperatorID]float64, len(order))
+	for _, opID := range order {
+		if stats, ok := p.stats[opID]; ok {
+			scores[opID] = stats.score()
+		}
+	}
+	p.mu.Unlock()
+
+	// The sort is stable so that the operators with the same score, such as the ones never requested, remain in
+	// stake-weighted order
+	sort.SliceStable(order, func(i, j int) bool {
+		return scores[order[i]] < scores[order[j]]
+	})
+	return order
+}
+
+func (p *adaptivePolicy) Observe(operatorID core.OperatorID, latency time.Duration, err error) {
+	errorRate := 0.0
+	if err != nil {
+		errorRate = 1
+	}
+
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	stats, ok := p.stats[operatorID]
+	if !ok {
+		p.stats[operatorID] = &operatorStats{latency: latency, errorRate: errorRate}
+		return
+	}
+	stats.latency = time.Duration(p.smoothing*float64(latency) + (1-p.smoothing)*float64(stats.latency))
+	stats.errorRate = p.smoothing*errorRate + (1-p.smoothing)*stats.errorRate
+}
+
+// score is the expected time to get valid chunks from the operator, retrying it until it serves them
+func (s *operatorStats) score() float64 {
+	errorRate := s.errorRate
+	if errorRate > maxErrorRate {
+		errorRate = maxErrorRate
+	}
+	// The operators never requested, which have no score, rank first even if others respond instantly
+	latency := float64(s.latency) + 1
+	return latency / (1 - errorRate)
+}
@@ -0,0 +1,58 @@
+package clients_test
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeOperators(n int) (map[core.OperatorID]*core.OperatorInfo, []core.OperatorID) {
+	operators := make(map[core.OperatorID]*core.OperatorInfo, n)
+	ids := make([]core.OperatorID, n)
+	for i := 0; i < n; i++ {
+		ids[i] = core.OperatorID{byte(i + 1)}
+		operators[ids[i]] = &core.OperatorInfo{Stake: big.NewInt(100), Index: core.OperatorIndex(i)}
+	}
+	return operators, ids
+}
+
+func TestStakeWeightedSelectionPolicy(t *testing.T) {
+	operators, _ := makeOperators(5)
+	policy := clients.NewStakeWeightedSelectionPolicy()
+	order := policy.Order(operators)
+	assert.Len(t, order, 5)
+	for _, opID := range order {
+		assert.Contains(t, operators, opID)
+	}
+}
+
+func TestAdaptiveSelectionPolicy(t *testing.T) {
+	operators, ids := makeOperators(4)
+	policy := clients.NewAdaptiveSelectionPolicy(0.5)
+
+	policy.Observe(ids[0], 300*time.Millisecond, nil)
+	policy.Observe(ids[1], 100*time.Millisecond, nil)
+	policy.Observe(ids[2], 50*time.Millisecond, errors.New("timeout"))
+
+	// The operator never requested comes first, then the fastest, while the failing one is a last resort
+	order := policy.Order(operators)
+	assert.Equal(t, []core.OperatorID{ids[3], ids[1], ids[0], ids[2]}, order)
+
+	// The latency is averaged over the requests
+	policy.Observe(ids[1], 900*time.Millisecond, nil)
+	policy.Observe(ids[3], 200*time.Millisecond, nil)
+	order = policy.Order(operators)
+	assert.Equal(t, []core.OperatorID{ids[3], ids[0], ids[1], ids[2]}, order)
+
+	// An operator recovers once it serves chunks again
+	for i := 0; i < 10; i++ {
+		policy.Observe(ids[2], 50*time.Millisecond, nil)
+	}
+	order = policy.Order(operators)
+	assert.Equal(t, ids[2], order[0])
+}
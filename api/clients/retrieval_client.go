@@ -4,7 +4,10 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/sampling"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/wealdtech/go-merkletree/v2"
@@ -13,6 +16,14 @@ import (
 	"github.com/wealdtech/go-merkletree/v2/keccak256"
 )
 
+var (
+	// ErrBlobHeaderNotFound is returned when no operator serves a valid header for the blob,
+	// e.g. because the blob index is beyond the number of blobs in the batch
+	ErrBlobHeaderNotFound = errors.New("failed to get blob header from all operators")
+	// ErrQuorumNotInBlob is returned when the blob is not dispersed to the requested quorum
+	ErrQuorumNotInBlob = errors.New("no quorum header for quorum")
+)
+
 // RetrievalClient is an object that can retrieve blobs from the network.
 type RetrievalClient interface {
 
@@ -57,16 +68,23 @@ type retrievalClient struct {
 	nodeClient            NodeClient
 	verifier              encoding.Verifier
 	numConnections        int
+	selectionPolicy       OperatorSelectionPolicy
 }
 
-// NewRetrievalClient creates a new retrieval client.
+// NewRetrievalClient creates a new retrieval client. The selection policy decides which operators the chunks are
+// requested from first. If it is nil, the operators are requested in stake-weighted random order.
 func NewRetrievalClient(
 	logger logging.Logger,
 	chainState core.IndexedChainState,
 	assignmentCoordinator core.AssignmentCoordinator,
 	nodeClient NodeClient,
 	verifier encoding.Verifier,
-	numConnections int) (RetrievalClient, error) {
+	numConnections int,
+	selectionPolicy OperatorSelectionPolicy) (RetrievalClient, error) {
+
+	if selectionPolicy == nil {
+		selectionPolicy = NewStakeWeightedSelectionPolicy()
+	}
 
 	return &retrievalClient{
 		logger:                logger.With("component", "RetrievalClient"),
@@ -75,10 +93,12 @@ func NewRetrievalClient(
 		nodeClient:            nodeClient,
 		verifier:              verifier,
 		numConnections:        numConnections,
+		selectionPolicy:       selectionPolicy,
 	}, nil
 }
 
-// RetrieveBlob retrieves a blob from the network.
+// RetrieveBlob retrieves a blob from the network. The chunks are only requested from as many operators as needed to
+// recombine the blob, in the order of the selection policy.
 func (r *retrievalClient) RetrieveBlob(
 	ctx context.Context,
 	batchHeaderHash [32]byte,
@@ -87,7 +107,7 @@ func (r *retrievalClient) RetrieveBlob(
 	batchRoot [32]byte,
 	quorumID core.QuorumID) ([]byte, error) {
 
-	chunks, err := r.RetrieveBlobChunks(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID)
+	chunks, err := r.retrieveBlobChunks(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID, true)
 	if err != nil {
 		return nil, err
 	}
@@ -95,7 +115,7 @@ func (r *retrievalClient) RetrieveBlob(
 	return r.CombineChunks(chunks)
 }
 
-// RetrieveBlobChunks retrieves the chunks of a blob from the network but does not recombine them.
+// RetrieveBlobChunks retrieves the chunks of a blob from all the operators of the quorum but does not recombine them.
 func (r *retrievalClient) RetrieveBlobChunks(ctx context.Context,
 	batchHeaderHash [32]byte,
 	blobIndex uint32,
@@ -103,6 +123,19 @@ func (r *retrievalClient) RetrieveBlobChunks(ctx context.Context,
 	batchRoot [32]byte,
 	quorumID core.QuorumID) (*BlobChunks, error) {
 
+	return r.retrieveBlobChunks(ctx, batchHeaderHash, blobIndex, referenceBlockNumber, batchRoot, quorumID, false)
+}
+
+// retrieveBlobChunks retrieves the chunks of a blob from the operators of the quorum. If untilRecoverable is true,
+// it stops requesting chunks once it retrieved enough to recombine the blob.
+func (r *retrievalClient) retrieveBlobChunks(ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	referenceBlockNumber uint,
+	batchRoot [32]byte,
+	quorumID core.QuorumID,
+	untilRecoverable bool) (*BlobChunks, error) {
+
 	indexedOperatorState, err := r.indexedChainState.GetIndexedOperatorState(ctx, referenceBlockNumber, []core.QuorumID{quorumID})
 	if err != nil {
 		return nil, err
@@ -116,7 +149,8 @@ func (r *retrievalClient) RetrieveBlobChunks(ctx context.Context,
 	var blobHeader *core.BlobHeader
 	var proof *merkletree.Proof
 	var proofVerified bool
-	for opID := range operators {
+	order := r.selectionPolicy.Order(operators)
+	for _, opID := range order {
 		opInfo := indexedOperatorState.IndexedOperators[opID]
 		blobHeader, proof, err = r.nodeClient.GetBlobHeader(ctx, opInfo.Socket, batchHeaderHash, blobIndex)
 		if err != nil {
@@ -143,7 +177,7 @@ func (r *retrievalClient) RetrieveBlobChunks(ctx context.Context,
 		break
 	}
 	if blobHeader == nil || proof == nil || !proofVerified {
-		return nil, fmt.Errorf("failed to get blob header from all operators (header hash: %s, index: %d)", batchHeaderHash, blobIndex)
+		return nil, fmt.Errorf("%w (header hash: %s, index: %d)", ErrBlobHeaderNotFound, batchHeaderHash, blobIndex)
 	}
 
 	var quorumHeader *core.BlobQuorumInfo
@@ -155,7 +189,7 @@ func (r *retrievalClient) RetrieveBlobChunks(ctx context.Context,
 	}
 
 	if quorumHeader == nil {
-		return nil, fmt.Errorf("no quorum header for quorum %d", quorumID)
+		return nil, fmt.Errorf("%w %d", ErrQuorumNotInBlob, quorumID)
 	}
 
 	// Validate the blob length
@@ -176,43 +210,66 @@ func (r *retrievalClient) RetrieveBlobChunks(ctx context.Context,
 		return nil, errors.New("failed to get assignments")
 	}
 
-	// Fetch chunks from all operators
-	chunksChan := make(chan RetrievedChunks, len(operators))
+	encodingParams := encoding.ParamsFromMins(quorumHeader.ChunkLength, info.TotalChunks)
+	numRequired := (uint64(blobHeader.Length) + encodingParams.ChunkLength - 1) / encodingParams.ChunkLength
+
+	// Request the operators in the order of the selection policy. Unless all the chunks are wanted, the next operators
+	// are only requested when the chunks pending from the previous ones are not enough to recombine the blob, e.g.
+	// because some of them failed.
+	fetchCtx, cancel := context.WithCancel(ctx)
 	pool := workerpool.New(r.numConnections)
-	for opID := range operators {
-		opID := opID
-		opInfo := indexedOperatorState.IndexedOperators[opID]
-		pool.Submit(func() {
-			r.nodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
-		})
+	defer func() {
+		cancel()
+		pool.Stop()
+	}()
+	repliesChan := make(chan timedChunks, len(order))
+	var numRetrieved, numPending uint64
+	next, numRequested := 0, 0
+	requestMore := func() {
+		for next < len(order) && (!untilRecoverable || numRetrieved+numPending < numRequired) {
+			opID := order[next]
+			next++
+			assignment, ok := assignments[opID]
+			if !ok || assignment.NumChunks == 0 {
+				continue
+			}
+			opInfo := indexedOperatorState.IndexedOperators[opID]
+			numPending += uint64(assignment.NumChunks)
+			numRequested++
+			pool.Submit(func() {
+				repliesChan <- r.getChunks(fetchCtx, opID, opInfo, batchHeaderHash, blobIndex, quorumID)
+			})
+		}
 	}
-
-	encodingParams := encoding.ParamsFromMins(quorumHeader.ChunkLength, info.TotalChunks)
+	requestMore()
 
 	var chunks []*encoding.Frame
 	var indices []encoding.ChunkNumber
-	// TODO(ian-shim): if we gathered enough chunks, cancel remaining RPC calls
-	for i := 0; i < len(operators); i++ {
-		reply := <-chunksChan
-		if reply.Err != nil {
-			r.logger.Error("failed to get chunks from operator", "operator", reply.OperatorID.Hex(), "err", reply.Err)
-			continue
-		}
-		assignment, ok := assignments[reply.OperatorID]
-		if !ok {
-			return nil, fmt.Errorf("no assignment to operator %s", reply.OperatorID.Hex())
-		}
-
-		err = r.verifier.VerifyFrames(reply.Chunks, assignment.GetIndices(), blobHeader.BlobCommitments, encodingParams)
+	for i := 0; i < numRequested; i++ {
+		reply := <-repliesChan
+		assignment := assignments[reply.OperatorID]
+		numPending -= uint64(assignment.NumChunks)
+		err := reply.Err
 		if err != nil {
+			r.logger.Error("failed to get chunks from operator", "operator", reply.OperatorID.Hex(), "err", err)
+		} else if err = r.verifier.VerifyFrames(reply.Chunks, assignment.GetIndices(), blobHeader.BlobCommitments, encodingParams); err != nil {
 			r.logger.Error("failed to verify chunks from operator", "operator", reply.OperatorID.Hex(), "err", err)
-			continue
 		} else {
 			r.logger.Info("verified chunks from operator", "operator", reply.OperatorID.Hex())
 		}
+		r.selectionPolicy.Observe(reply.OperatorID, reply.latency, err)
+		if err != nil {
+			// Fall back to the next operators
+			requestMore()
+			continue
+		}
 
 		chunks = append(chunks, reply.Chunks...)
 		indices = append(indices, assignment.GetIndices()...)
+		numRetrieved += uint64(assignment.NumChunks)
+		if untilRecoverable && numRetrieved >= numRequired {
+			break
+		}
 	}
 
 	return &BlobChunks{
@@ -225,6 +282,51 @@ func (r *retrievalClient) RetrieveBlobChunks(ctx context.Context,
 	}, nil
 }
 
+// timedChunks are the chunks retrieved from an operator along with how long the request took
+type timedChunks struct {
+	RetrievedChunks
+	latency time.Duration
+}
+
+// getChunks requests the chunks of the blob from the operator and times the request
+func (r *retrievalClient) getChunks(
+	ctx context.Context,
+	opID core.OperatorID,
+	opInfo *core.IndexedOperatorInfo,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	quorumID core.QuorumID) timedChunks {
+
+	chunksChan := make(chan RetrievedChunks, 1)
+	start := time.Now()
+	r.nodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+	return timedChunks{
+		RetrievedChunks: <-chunksChan,
+		latency:         time.Since(start),
+	}
+}
+
+// stakeWeightedOrder returns the operators in a stake-weighted random order, so that the operators with the most stake
+// are tried first while the requests are spread between them. The operators without stake come last.
+func stakeWeightedOrder(operators map[core.OperatorID]*core.OperatorInfo) []core.OperatorID {
+	order := make([]core.OperatorID, 0, len(operators))
+	if sampler, err := sampling.NewSampler(operators, sampling.NewRand()); err == nil {
+		if shuffled, err := sampler.Shuffle(); err == nil {
+			order = append(order, shuffled...)
+		}
+	}
+	ordered := make(map[core.OperatorID]struct{}, len(order))
+	for _, opID := range order {
+		ordered[opID] = struct{}{}
+	}
+	for opID := range operators {
+		if _, ok := ordered[opID]; !ok {
+			order = append(order, opID)
+		}
+	}
+	return order
+}
+
 // CombineChunks recombines the chunks into the original blob.
 func (r *retrievalClient) CombineChunks(chunks *BlobChunks) ([]byte, error) {
 	return r.verifier.Decode(
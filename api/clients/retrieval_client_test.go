@@ -3,8 +3,12 @@ package clients_test
 import (
 	"bytes"
 	"context"
+	"errors"
 	"runtime"
+	"sort"
+	"sync"
 	"testing"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/api/clients"
 	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
@@ -58,6 +62,8 @@ var (
 	nodeClient        *clientsmock.MockNodeClient
 	coordinator       *core.StdAssignmentCoordinator
 	retrievalClient   clients.RetrievalClient
+	selectionPolicy   *recordingPolicy
+	failingOperators  map[core.OperatorID]bool
 	blobHeader        *core.BlobHeader
 	encodedBlob       core.EncodedBlob = core.EncodedBlob{
 		BlobHeader:               nil,
@@ -104,7 +110,10 @@ func setup(t *testing.T) {
 		panic("failed to create a new indexed chain state")
 	}
 
-	retrievalClient, err = clients.NewRetrievalClient(logger, ics, coordinator, nodeClient, v, 2)
+	selectionPolicy = &recordingPolicy{observed: make(map[core.OperatorID]error)}
+	failingOperators = make(map[core.OperatorID]bool)
+	failingClient := &failingNodeClient{MockNodeClient: nodeClient, failing: failingOperators}
+	retrievalClient, err = clients.NewRetrievalClient(logger, ics, coordinator, failingClient, v, 2, selectionPolicy)
 	if err != nil {
 		panic("failed to create a new retrieval client")
 	}
@@ -207,6 +216,55 @@ func setup(t *testing.T) {
 
 }
 
+// recordingPolicy requests the operators in ascending order of ID and records the outcome of the requests
+type recordingPolicy struct {
+	mu       sync.Mutex
+	observed map[core.OperatorID]error
+}
+
+func (p *recordingPolicy) Order(operators map[core.OperatorID]*core.OperatorInfo) []core.OperatorID {
+	return sortedOperatorIDs(operators)
+}
+
+func (p *recordingPolicy) Observe(operatorID core.OperatorID, latency time.Duration, err error) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	p.observed[operatorID] = err
+}
+
+// failingNodeClient fails the requests for the chunks of the failing operators
+type failingNodeClient struct {
+	*clientsmock.MockNodeClient
+	failing map[core.OperatorID]bool
+}
+
+func (c *failingNodeClient) GetChunks(
+	ctx context.Context,
+	opID core.OperatorID,
+	opInfo *core.IndexedOperatorInfo,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	quorumID core.QuorumID,
+	chunksChan chan clients.RetrievedChunks,
+) {
+	if c.failing[opID] {
+		chunksChan <- clients.RetrievedChunks{OperatorID: opID, Err: errors.New("operator unavailable")}
+		return
+	}
+	c.MockNodeClient.GetChunks(ctx, opID, opInfo, batchHeaderHash, blobIndex, quorumID, chunksChan)
+}
+
+func sortedOperatorIDs(operators map[core.OperatorID]*core.OperatorInfo) []core.OperatorID {
+	ids := make([]core.OperatorID, 0, len(operators))
+	for id := range operators {
+		ids = append(ids, id)
+	}
+	sort.Slice(ids, func(i, j int) bool {
+		return bytes.Compare(ids[i][:], ids[j][:]) < 0
+	})
+	return ids
+}
+
 func mustMakeOpertatorPubKeysPair(t *testing.T) *coreindexer.OperatorPubKeys {
 	operators := make(map[core.OperatorID]coreindexer.OperatorPubKeysPair, len(operatorState.Operators))
 	for operatorId := range operatorState.Operators[0] {
@@ -285,3 +343,64 @@ func TestValidBlobHeader(t *testing.T) {
 	assert.Equal(t, gettysburgAddressBytes, restored[:len(gettysburgAddressBytes)])
 
 }
+
+func TestRetrieveBlobFromEnoughOperators(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	indexer.On("GetObject", mock.Anything, 0).Return(mustMakeOpertatorPubKeysPair(t), nil)
+	indexer.On("GetObject", mock.Anything, 1).Return(musMakeOperatorSocket(t), nil)
+
+	data, err := retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	restored := bytes.TrimRight(codec.RemoveEmptyByteFromPaddedBytes(data), "\x00")
+	assert.Equal(t, gettysburgAddressBytes, restored)
+
+	// The chunks of the first operators are enough to recombine the blob
+	assert.Less(t, len(selectionPolicy.observed), numOperators)
+	for _, err := range selectionPolicy.observed {
+		assert.NoError(t, err)
+	}
+
+	// The chunks of all the operators are retrieved when they are wanted individually
+	chunks, err := retrievalClient.RetrieveBlobChunks(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	assert.Len(t, selectionPolicy.observed, numOperators)
+	assert.Len(t, chunks.Indices, int(chunks.AssignmentInfo.TotalChunks))
+}
+
+func TestRetrieveBlobFallsBackOnFailures(t *testing.T) {
+
+	setup(t)
+
+	nodeClient.On("GetBlobHeader", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(blobHeader, [][]byte{}, uint64(0), nil)
+	nodeClient.
+		On("GetChunks", mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything, mock.Anything).
+		Return(encodedBlob)
+
+	indexer.On("GetObject", mock.Anything, 0).Return(mustMakeOpertatorPubKeysPair(t), nil)
+	indexer.On("GetObject", mock.Anything, 1).Return(musMakeOperatorSocket(t), nil)
+
+	// The operators requested first all fail
+	order := sortedOperatorIDs(operatorState.Operators[0])
+	numFailing := numOperators / 2
+	for _, opID := range order[:numFailing] {
+		failingOperators[opID] = true
+	}
+
+	data, err := retrievalClient.RetrieveBlob(context.Background(), batchHeaderHash, 0, 0, batchRoot, 0)
+	assert.NoError(t, err)
+	restored := bytes.TrimRight(codec.RemoveEmptyByteFromPaddedBytes(data), "\x00")
+	assert.Equal(t, gettysburgAddressBytes, restored)
+
+	for _, opID := range order[:numFailing] {
+		assert.Error(t, selectionPolicy.observed[opID])
+	}
+	assert.Contains(t, selectionPolicy.observed, order[numFailing])
+	assert.NoError(t, selectionPolicy.observed[order[numFailing]])
+}
@@ -0,0 +1,270 @@
+// Package conversion converts the protobuf messages of the node API to the core types and back. The conversions from
+// protobuf validate the messages, as they are received from untrusted peers.
+package conversion
+
+import (
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/Layr-Labs/eigenda/api"
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+)
+
+// FieldElementFromBytes returns the field element of the big-endian bytes. The bytes must be at most fp.Bytes long
+// and encode an integer smaller than the modulus, so that each element has a single encoding.
+func FieldElementFromBytes(b []byte) (fp.Element, error) {
+	var e fp.Element
+	if len(b) > fp.Bytes {
+		return e, fmt.Errorf("field element is %d bytes long, must be at most %d", len(b), fp.Bytes)
+	}
+	var padded [fp.Bytes]byte
+	copy(padded[fp.Bytes-len(b):], b)
+	if err := e.SetBytesCanonical(padded[:]); err != nil {
+		return e, fmt.Errorf("invalid field element: %w", err)
+	}
+	return e, nil
+}
+
+// G1CommitmentFromProto returns the G1 commitment of the proto, which must be a point of the G1 subgroup
+func G1CommitmentFromProto(c *commonpb.G1Commitment) (*encoding.G1Commitment, error) {
+	if c == nil {
+		return nil, errors.New("G1 commitment is nil")
+	}
+	x, err := FieldElementFromBytes(c.GetX())
+	if err != nil {
+		return nil, fmt.Errorf("invalid X of G1 commitment: %w", err)
+	}
+	y, err := FieldElementFromBytes(c.GetY())
+	if err != nil {
+		return nil, fmt.Errorf("invalid Y of G1 commitment: %w", err)
+	}
+	commitment := &encoding.G1Commitment{X: x, Y: y}
+	if !(*bn254.G1Affine)(commitment).IsInSubGroup() {
+		return nil, errors.New("G1 commitment is not in the subgroup")
+	}
+	return commitment, nil
+}
+
+// G1CommitmentToProto returns the proto of the G1 commitment
+func G1CommitmentToProto(c *encoding.G1Commitment) (*commonpb.G1Commitment, error) {
+	if c == nil {
+		return nil, errors.New("G1 commitment is nil")
+	}
+	return &commonpb.G1Commitment{
+		X: c.X.Marshal(),
+		Y: c.Y.Marshal(),
+	}, nil
+}
+
+// G2CommitmentFromProto returns the G2 commitment of the proto, which must be a point of the G2 subgroup. A nil proto
+// is the point at infinity.
+func G2CommitmentFromProto(c *pb.G2Commitment) (*encoding.G2Commitment, error) {
+	var commitment encoding.G2Commitment
+	if c == nil {
+		return &commitment, nil
+	}
+	for _, coordinate := range []struct {
+		name  string
+		bytes []byte
+		value *fp.Element
+	}{
+		{"XA0", c.GetXA0(), &commitment.X.A0},
+		{"XA1", c.GetXA1(), &commitment.X.A1},
+		{"YA0", c.GetYA0(), &commitment.Y.A0},
+		{"YA1", c.GetYA1(), &commitment.Y.A1},
+	} {
+		e, err := FieldElementFromBytes(coordinate.bytes)
+		if err != nil {
+			return nil, fmt.Errorf("invalid %s of G2 commitment: %w", coordinate.name, err)
+		}
+		*coordinate.value = e
+	}
+	if !(*bn254.G2Affine)(&commitment).IsInSubGroup() {
+		return nil, errors.New("G2 commitment is not in the subgroup")
+	}
+	return &commitment, nil
+}
+
+// G2CommitmentToProto returns the proto of the G2 commitment. A nil commitment is the point at infinity.
+func G2CommitmentToProto(c *encoding.G2Commitment) *pb.G2Commitment {
+	if c == nil {
+		return &pb.G2Commitment{}
+	}
+	return &pb.G2Commitment{
+		XA0: c.X.A0.Marshal(),
+		XA1: c.X.A1.Marshal(),
+		YA0: c.Y.A0.Marshal(),
+		YA1: c.Y.A1.Marshal(),
+	}
+}
+
+// BlobQuorumInfoFromProto returns the quorum info of the proto, whose quorum ID and security params must be valid
+func BlobQuorumInfoFromProto(q *pb.BlobQuorumInfo) (*core.BlobQuorumInfo, error) {
+	if q == nil {
+		return nil, api.NewInvalidArgError("quorum header is nil")
+	}
+	if q.GetQuorumId() > core.MaxQuorumID {
+		return nil, api.NewInvalidArgError(fmt.Sprintf("quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, q.GetQuorumId()))
+	}
+	// The thresholds are at most 100 once validated, so they fit in their uint8
+	if err := core.ValidateSecurityParam(q.GetConfirmationThreshold(), q.GetAdversaryThreshold()); err != nil {
+		return nil, api.NewInvalidArgError(err.Error())
+	}
+	return &core.BlobQuorumInfo{
+		SecurityParam: core.SecurityParam{
+			QuorumID:              core.QuorumID(q.GetQuorumId()),
+			AdversaryThreshold:    uint8(q.GetAdversaryThreshold()),
+			ConfirmationThreshold: uint8(q.GetConfirmationThreshold()),
+			QuorumRate:            q.GetRatelimit(),
+		},
+		ChunkLength: uint(q.GetChunkLength()),
+	}, nil
+}
+
+// BlobQuorumInfoToProto returns the proto of the quorum info
+func BlobQuorumInfoToProto(q *core.BlobQuorumInfo) (*pb.BlobQuorumInfo, error) {
+	if q == nil {
+		return nil, errors.New("quorum info is nil")
+	}
+	if q.ChunkLength > math.MaxUint32 {
+		return nil, fmt.Errorf("chunk length %d of quorum %d overflows uint32", q.ChunkLength, q.QuorumID)
+	}
+	return &pb.BlobQuorumInfo{
+		QuorumId:              uint32(q.QuorumID),
+		AdversaryThreshold:    uint32(q.AdversaryThreshold),
+		ConfirmationThreshold: uint32(q.ConfirmationThreshold),
+		ChunkLength:           uint32(q.ChunkLength),
+		Ratelimit:             q.QuorumRate,
+	}, nil
+}
+
+// BlobHeaderFromProto returns the blob header of the proto. Its commitments must be points of their subgroups, and its
+// quorums must be valid and distinct.
+func BlobHeaderFromProto(h *pb.BlobHeader) (*core.BlobHeader, error) {
+	if h == nil {
+		return nil, api.NewInvalidArgError("blob header is nil")
+	}
+	commitment, err := G1CommitmentFromProto(h.GetCommitment())
+	if err != nil {
+		return nil, api.NewInvalidArgError(fmt.Sprintf("invalid commitment: %v", err))
+	}
+	lengthCommitment, err := G2CommitmentFromProto(h.GetLengthCommitment())
+	if err != nil {
+		return nil, api.NewInvalidArgError(fmt.Sprintf("invalid length commitment: %v", err))
+	}
+	lengthProof, err := G2CommitmentFromProto(h.GetLengthProof())
+	if err != nil {
+		return nil, api.NewInvalidArgError(fmt.Sprintf("invalid length proof: %v", err))
+	}
+
+	quorumInfos := make([]*core.BlobQuorumInfo, len(h.GetQuorumHeaders()))
+	seen := make(map[core.QuorumID]struct{}, len(h.GetQuorumHeaders()))
+	for i, header := range h.GetQuorumHeaders() {
+		quorumInfos[i], err = BlobQuorumInfoFromProto(header)
+		if err != nil {
+			return nil, err
+		}
+		if _, ok := seen[quorumInfos[i].QuorumID]; ok {
+			return nil, api.NewInvalidArgError(fmt.Sprintf("duplicate quorum %d in blob header", quorumInfos[i].QuorumID))
+		}
+		seen[quorumInfos[i].QuorumID] = struct{}{}
+	}
+
+	return &core.BlobHeader{
+		BlobCommitments: encoding.BlobCommitments{
+			Commitment:       commitment,
+			LengthCommitment: lengthCommitment,
+			LengthProof:      lengthProof,
+			Length:           uint(h.GetLength()),
+		},
+		QuorumInfos: quorumInfos,
+		AccountID:   h.GetAccountId(),
+	}, nil
+}
+
+// BlobHeaderToProto returns the proto of the blob header
+func BlobHeaderToProto(h *core.BlobHeader) (*pb.BlobHeader, error) {
+	if h == nil {
+		return nil, errors.New("blob header is nil")
+	}
+	commitment, err := G1CommitmentToProto(h.Commitment)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob header commitment: %w", err)
+	}
+	if h.Length > math.MaxUint32 {
+		return nil, fmt.Errorf("blob length %d overflows uint32", h.Length)
+	}
+	quorumHeaders := make([]*pb.BlobQuorumInfo, len(h.QuorumInfos))
+	for i, quorumInfo := range h.QuorumInfos {
+		quorumHeaders[i], err = BlobQuorumInfoToProto(quorumInfo)
+		if err != nil {
+			return nil, err
+		}
+	}
+	return &pb.BlobHeader{
+		Commitment:       commitment,
+		LengthCommitment: G2CommitmentToProto(h.LengthCommitment),
+		LengthProof:      G2CommitmentToProto(h.LengthProof),
+		Length:           uint32(h.Length),
+		QuorumHeaders:    quorumHeaders,
+		AccountId:        h.AccountID,
+	}, nil
+}
+
+// BatchHeaderFromProto returns the batch header of the proto, whose batch root must be 32 bytes long
+func BatchHeaderFromProto(h *pb.BatchHeader) (*core.BatchHeader, error) {
+	if h == nil || len(h.GetBatchRoot()) == 0 {
+		return nil, api.NewInvalidArgError("batch header is nil or empty")
+	}
+	var batchRoot [32]byte
+	if len(h.GetBatchRoot()) != len(batchRoot) {
+		return nil, api.NewInvalidArgError(fmt.Sprintf("batch root is %d bytes long, must be %d", len(h.GetBatchRoot()), len(batchRoot)))
+	}
+	copy(batchRoot[:], h.GetBatchRoot())
+	return &core.BatchHeader{
+		ReferenceBlockNumber: uint(h.GetReferenceBlockNumber()),
+		BatchRoot:            batchRoot,
+	}, nil
+}
+
+// BatchHeaderToProto returns the proto of the batch header
+func BatchHeaderToProto(h *core.BatchHeader) (*pb.BatchHeader, error) {
+	if h == nil {
+		return nil, errors.New("batch header is nil")
+	}
+	if h.ReferenceBlockNumber > math.MaxUint32 {
+		return nil, fmt.Errorf("reference block number %d overflows uint32", h.ReferenceBlockNumber)
+	}
+	batchRoot := h.BatchRoot
+	return &pb.BatchHeader{
+		BatchRoot:            batchRoot[:],
+		ReferenceBlockNumber: uint32(h.ReferenceBlockNumber),
+	}, nil
+}
+
+// SignatureFromProto returns the signature of the bytes of an attestation, which must be a compressed or an
+// uncompressed G1 point with no trailing bytes
+func SignatureFromProto(sig []byte) (*core.Signature, error) {
+	if len(sig) != bn254.SizeOfG1AffineCompressed && len(sig) != bn254.SizeOfG1AffineUncompressed {
+		return nil, fmt.Errorf("signature is %d bytes long, must be %d or %d", len(sig), bn254.SizeOfG1AffineCompressed, bn254.SizeOfG1AffineUncompressed)
+	}
+	point, err := new(core.G1Point).Deserialize(sig)
+	if err != nil {
+		return nil, fmt.Errorf("invalid signature: %w", err)
+	}
+	return &core.Signature{G1Point: point}, nil
+}
+
+// SignatureToProto returns the bytes of the signature in an attestation
+func SignatureToProto(sig *core.Signature) ([]byte, error) {
+	if sig == nil || sig.G1Point == nil {
+		return nil, errors.New("signature is nil")
+	}
+	return sig.Serialize(), nil
+}
@@ -0,0 +1,302 @@
+package conversion_test
+
+import (
+	"math"
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/conversion"
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func makeG1Commitment(scalar uint64) *encoding.G1Commitment {
+	var point bn254.G1Affine
+	point.ScalarMultiplicationBase(new(big.Int).SetUint64(scalar))
+	return (*encoding.G1Commitment)(&point)
+}
+
+func makeG2Commitment(scalar uint64) *encoding.G2Commitment {
+	_, _, _, g2 := bn254.Generators()
+	var point bn254.G2Affine
+	point.ScalarMultiplication(&g2, new(big.Int).SetUint64(scalar))
+	return (*encoding.G2Commitment)(&point)
+}
+
+func makeBlobHeader(g1Scalar, g2Scalar uint64, length uint32, quorumID uint8, adversaryThreshold uint8, chunkLength uint32, accountID string) *core.BlobHeader {
+	adversaryThreshold = adversaryThreshold%90 + 1
+	return &core.BlobHeader{
+		BlobCommitments: encoding.BlobCommitments{
+			Commitment:       makeG1Commitment(g1Scalar),
+			LengthCommitment: makeG2Commitment(g2Scalar),
+			LengthProof:      makeG2Commitment(g2Scalar + 1),
+			Length:           uint(length),
+		},
+		QuorumInfos: []*core.BlobQuorumInfo{
+			{
+				SecurityParam: core.SecurityParam{
+					QuorumID:              quorumID % (core.MaxQuorumID + 1),
+					AdversaryThreshold:    adversaryThreshold,
+					ConfirmationThreshold: adversaryThreshold + 10,
+					QuorumRate:            uint32(length),
+				},
+				ChunkLength: uint(chunkLength),
+			},
+		},
+		AccountID: accountID,
+	}
+}
+
+// nonCanonical returns the encoding of the element plus the modulus, which has the same value modulo the modulus
+func nonCanonical(e fp.Element) []byte {
+	var value big.Int
+	e.BigInt(&value)
+	return value.Add(&value, fp.Modulus()).FillBytes(make([]byte, fp.Bytes))
+}
+
+func FuzzBlobHeaderRoundTrip(f *testing.F) {
+	f.Add(uint64(1), uint64(1), uint32(1024), uint8(0), uint8(33), uint32(8), "account")
+	f.Add(uint64(0), uint64(0), uint32(0), uint8(254), uint8(89), uint32(0), "")
+	f.Add(uint64(math.MaxUint64), uint64(12345), uint32(math.MaxUint32), uint8(255), uint8(0), uint32(math.MaxUint32), "0x1234")
+
+	f.Fuzz(func(t *testing.T, g1Scalar, g2Scalar uint64, length uint32, quorumID uint8, adversaryThreshold uint8, chunkLength uint32, accountID string) {
+		header := makeBlobHeader(g1Scalar, g2Scalar, length, quorumID, adversaryThreshold, chunkLength, accountID)
+
+		message, err := conversion.BlobHeaderToProto(header)
+		require.NoError(t, err)
+		converted, err := conversion.BlobHeaderFromProto(message)
+		require.NoError(t, err)
+		assert.Equal(t, header, converted)
+
+		hash, err := header.GetBlobHeaderHash()
+		require.NoError(t, err)
+		convertedHash, err := converted.GetBlobHeaderHash()
+		require.NoError(t, err)
+		assert.Equal(t, hash, convertedHash)
+	})
+}
+
+func FuzzBlobHeaderFromProto(f *testing.F) {
+	g1 := makeG1Commitment(3)
+	f.Add(g1.X.Marshal(), g1.Y.Marshal(), uint32(0), uint32(33), uint32(43))
+	f.Add([]byte{}, []byte{}, uint32(1), uint32(50), uint32(60))
+	f.Add(nonCanonical(g1.X), g1.Y.Marshal(), uint32(255), uint32(0), uint32(101))
+
+	f.Fuzz(func(t *testing.T, x, y []byte, quorumID, adversaryThreshold, confirmationThreshold uint32) {
+		message := &pb.BlobHeader{
+			Commitment: &commonpb.G1Commitment{X: x, Y: y},
+			Length:     16,
+			QuorumHeaders: []*pb.BlobQuorumInfo{
+				{
+					QuorumId:              quorumID,
+					AdversaryThreshold:    adversaryThreshold,
+					ConfirmationThreshold: confirmationThreshold,
+					ChunkLength:           4,
+				},
+			},
+		}
+		header, err := conversion.BlobHeaderFromProto(message)
+		if err != nil {
+			return
+		}
+
+		// A header accepted from untrusted bytes holds valid values, which convert back to the same header
+		assert.True(t, (*bn254.G1Affine)(header.Commitment).IsInSubGroup())
+		assert.LessOrEqual(t, header.QuorumInfos[0].QuorumID, uint8(core.MaxQuorumID))
+		assert.NoError(t, header.QuorumInfos[0].Validate())
+		reencoded, err := conversion.BlobHeaderToProto(header)
+		require.NoError(t, err)
+		converted, err := conversion.BlobHeaderFromProto(reencoded)
+		require.NoError(t, err)
+		assert.Equal(t, header, converted)
+	})
+}
+
+func TestBlobHeaderFromProtoRejectsInvalidHeaders(t *testing.T) {
+	header := makeBlobHeader(5, 7, 1024, 1, 33, 8, "account")
+	valid := func() *pb.BlobHeader {
+		message, err := conversion.BlobHeaderToProto(header)
+		require.NoError(t, err)
+		return message
+	}
+	_, err := conversion.BlobHeaderFromProto(valid())
+	require.NoError(t, err)
+
+	for name, tc := range map[string]struct {
+		modify func(*pb.BlobHeader)
+		err    string
+	}{
+		"nil commitment": {
+			modify: func(h *pb.BlobHeader) { h.Commitment = nil },
+			err:    "G1 commitment is nil",
+		},
+		"oversized coordinate": {
+			modify: func(h *pb.BlobHeader) { h.Commitment.X = append([]byte{0}, h.Commitment.X...) },
+			err:    "33 bytes long",
+		},
+		"non canonical coordinate": {
+			modify: func(h *pb.BlobHeader) { h.Commitment.Y = nonCanonical(header.Commitment.Y) },
+			err:    "invalid Y of G1 commitment",
+		},
+		"point not on the curve": {
+			modify: func(h *pb.BlobHeader) { h.Commitment.Y = h.Commitment.X },
+			err:    "not in the subgroup",
+		},
+		"non canonical length commitment": {
+			modify: func(h *pb.BlobHeader) { h.LengthCommitment.XA1 = nonCanonical(header.LengthCommitment.X.A1) },
+			err:    "invalid XA1 of G2 commitment",
+		},
+		"length proof not on the curve": {
+			modify: func(h *pb.BlobHeader) { h.LengthProof.YA0 = h.LengthProof.XA0 },
+			err:    "invalid length proof",
+		},
+		"nil quorum header": {
+			modify: func(h *pb.BlobHeader) { h.QuorumHeaders = append(h.QuorumHeaders, nil) },
+			err:    "quorum header is nil",
+		},
+		"quorum out of range": {
+			modify: func(h *pb.BlobHeader) { h.QuorumHeaders[0].QuorumId = 256 },
+			err:    "quorum ID must be in range [0, 254], but found 256",
+		},
+		"threshold overflowing uint8": {
+			modify: func(h *pb.BlobHeader) { h.QuorumHeaders[0].ConfirmationThreshold = 256 + 50 },
+			err:    "threshold exceeds 100",
+		},
+		"zero adversary threshold": {
+			modify: func(h *pb.BlobHeader) { h.QuorumHeaders[0].AdversaryThreshold = 0 },
+			err:    "adversary threshold equals 0",
+		},
+		"duplicate quorum": {
+			modify: func(h *pb.BlobHeader) { h.QuorumHeaders = append(h.QuorumHeaders, h.QuorumHeaders[0]) },
+			err:    "duplicate quorum 1",
+		},
+	} {
+		t.Run(name, func(t *testing.T) {
+			message := valid()
+			tc.modify(message)
+			_, err := conversion.BlobHeaderFromProto(message)
+			assert.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	_, err = conversion.BlobHeaderFromProto(nil)
+	assert.ErrorContains(t, err, "blob header is nil")
+}
+
+func TestBlobHeaderFromProtoWithoutLengthCommitments(t *testing.T) {
+	message, err := conversion.BlobHeaderToProto(makeBlobHeader(5, 7, 1024, 1, 33, 8, "account"))
+	require.NoError(t, err)
+	message.LengthCommitment = nil
+	message.LengthProof = nil
+
+	// The missing length commitments are the point at infinity
+	header, err := conversion.BlobHeaderFromProto(message)
+	require.NoError(t, err)
+	assert.True(t, (*bn254.G2Affine)(header.LengthCommitment).IsInfinity())
+	assert.True(t, (*bn254.G2Affine)(header.LengthProof).IsInfinity())
+}
+
+func TestBlobHeaderToProtoRejectsOverflows(t *testing.T) {
+	header := makeBlobHeader(5, 7, 1024, 1, 33, 8, "account")
+	header.Length = math.MaxUint32 + 1
+	_, err := conversion.BlobHeaderToProto(header)
+	assert.ErrorContains(t, err, "overflows uint32")
+
+	header = makeBlobHeader(5, 7, 1024, 1, 33, 8, "account")
+	header.QuorumInfos[0].ChunkLength = math.MaxUint32 + 1
+	_, err = conversion.BlobHeaderToProto(header)
+	assert.ErrorContains(t, err, "overflows uint32")
+
+	header.Commitment = nil
+	_, err = conversion.BlobHeaderToProto(header)
+	assert.ErrorContains(t, err, "G1 commitment is nil")
+}
+
+func FuzzBatchHeaderRoundTrip(f *testing.F) {
+	f.Add([]byte{1, 2, 3}, uint32(100))
+	f.Add(make([]byte, 32), uint32(0))
+	f.Add(make([]byte, 33), uint32(math.MaxUint32))
+
+	f.Fuzz(func(t *testing.T, batchRoot []byte, referenceBlockNumber uint32) {
+		header, err := conversion.BatchHeaderFromProto(&pb.BatchHeader{
+			BatchRoot:            batchRoot,
+			ReferenceBlockNumber: referenceBlockNumber,
+		})
+		if len(batchRoot) != 32 {
+			assert.Error(t, err)
+			return
+		}
+		require.NoError(t, err)
+		assert.Equal(t, batchRoot, header.BatchRoot[:])
+		assert.Equal(t, uint(referenceBlockNumber), header.ReferenceBlockNumber)
+
+		message, err := conversion.BatchHeaderToProto(header)
+		require.NoError(t, err)
+		converted, err := conversion.BatchHeaderFromProto(message)
+		require.NoError(t, err)
+		assert.Equal(t, header, converted)
+	})
+}
+
+func TestBatchHeaderConversionRejectsInvalidHeaders(t *testing.T) {
+	_, err := conversion.BatchHeaderFromProto(nil)
+	assert.ErrorContains(t, err, "batch header is nil or empty")
+	_, err = conversion.BatchHeaderFromProto(&pb.BatchHeader{BatchRoot: []byte{1}})
+	assert.ErrorContains(t, err, "batch root is 1 bytes long, must be 32")
+
+	_, err = conversion.BatchHeaderToProto(&core.BatchHeader{ReferenceBlockNumber: math.MaxUint32 + 1})
+	assert.ErrorContains(t, err, "overflows uint32")
+}
+
+func FuzzSignatureRoundTrip(f *testing.F) {
+	f.Add(uint64(1))
+	f.Add(uint64(0))
+	f.Add(uint64(math.MaxUint64))
+
+	f.Fuzz(func(t *testing.T, scalar uint64) {
+		var point bn254.G1Affine
+		point.ScalarMultiplicationBase(new(big.Int).SetUint64(scalar))
+		sig := &core.Signature{G1Point: &core.G1Point{G1Affine: &point}}
+
+		sigBytes, err := conversion.SignatureToProto(sig)
+		require.NoError(t, err)
+		converted, err := conversion.SignatureFromProto(sigBytes)
+		require.NoError(t, err)
+		assert.True(t, point.Equal(converted.G1Affine))
+
+		// The nodes may also return the compressed point
+		compressed := point.Bytes()
+		converted, err = conversion.SignatureFromProto(compressed[:])
+		require.NoError(t, err)
+		assert.True(t, point.Equal(converted.G1Affine))
+	})
+}
+
+func TestSignatureFromProtoRejectsInvalidSignatures(t *testing.T) {
+	var point bn254.G1Affine
+	point.ScalarMultiplicationBase(big.NewInt(7))
+	sigBytes := point.RawBytes()
+
+	_, err := conversion.SignatureFromProto(nil)
+	assert.ErrorContains(t, err, "signature is 0 bytes long")
+	compressed := point.Bytes()
+	_, err = conversion.SignatureFromProto(append(compressed[:], 0))
+	assert.ErrorContains(t, err, "signature is 33 bytes long, must be 32 or 64")
+	_, err = conversion.SignatureFromProto(append(sigBytes[:], 0))
+	assert.ErrorContains(t, err, "signature is 65 bytes long")
+
+	// The coordinates are those of a point which isn't on the curve
+	notOnCurve := sigBytes
+	notOnCurve[63] ^= 1
+	_, err = conversion.SignatureFromProto(notOnCurve[:])
+	assert.ErrorContains(t, err, "invalid signature")
+
+	_, err = conversion.SignatureToProto(nil)
+	assert.ErrorContains(t, err, "signature is nil")
+}
@@ -1,8 +1,13 @@
 package api
 
 import (
+	"fmt"
+	"time"
+
+	"google.golang.org/genproto/googleapis/rpc/errdetails"
 	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/durationpb"
 )
 
 // The canonical errors from the EigenDA gRPC API endpoints.
@@ -23,6 +28,11 @@ func NewInvalidArgError(msg string) error {
 	return NewGRPCError(codes.InvalidArgument, msg)
 }
 
+// HTTP Mapping: 401 Unauthorized
+func NewUnauthenticatedError(msg string) error {
+	return NewGRPCError(codes.Unauthenticated, msg)
+}
+
 // HTTP Mapping: 404 Not Found
 func NewNotFoundError(msg string) error {
 	return NewGRPCError(codes.NotFound, msg)
@@ -33,6 +43,34 @@ func NewResourceExhaustedError(msg string) error {
 	return NewGRPCError(codes.ResourceExhausted, msg)
 }
 
+// HTTP Mapping: 429 Too Many Requests
+// The delay after which the request may succeed is added to the message and as a RetryInfo detail.
+func NewResourceExhaustedErrorWithRetry(msg string, retryAfter time.Duration) error {
+	if retryAfter <= 0 {
+		return NewResourceExhaustedError(msg)
+	}
+	st, err := status.New(codes.ResourceExhausted, fmt.Sprintf("%s, retry after %s", msg, retryAfter)).
+		WithDetails(&errdetails.RetryInfo{RetryDelay: durationpb.New(retryAfter)})
+	if err != nil {
+		return NewResourceExhaustedError(msg)
+	}
+	return st.Err()
+}
+
+// RetryDelay returns the delay of the RetryInfo detail of the error, if any
+func RetryDelay(err error) (time.Duration, bool) {
+	st, ok := status.FromError(err)
+	if !ok {
+		return 0, false
+	}
+	for _, detail := range st.Details() {
+		if info, ok := detail.(*errdetails.RetryInfo); ok {
+			return info.GetRetryDelay().AsDuration(), true
+		}
+	}
+	return 0, false
+}
+
 // HTTP Mapping: 500 Internal Server Error
 func NewInternalError(msg string) error {
 	return NewGRPCError(codes.Internal, msg)
@@ -24,6 +24,11 @@ const (
 	Disperser_GetBlobStatus_FullMethodName             = "/disperser.Disperser/GetBlobStatus"
 	Disperser_RetrieveBlob_FullMethodName              = "/disperser.Disperser/RetrieveBlob"
 	Disperser_GetChunk_FullMethodName                  = "/disperser.Disperser/GetChunk"
+	Disperser_SubscribeBlobStatus_FullMethodName       = "/disperser.Disperser/SubscribeBlobStatus"
+	Disperser_DisperseBlobs_FullMethodName             = "/disperser.Disperser/DisperseBlobs"
+	Disperser_DisperseBlobStream_FullMethodName        = "/disperser.Disperser/DisperseBlobStream"
+	Disperser_GetBlobUploadURL_FullMethodName          = "/disperser.Disperser/GetBlobUploadURL"
+	Disperser_DisperseUploadedBlob_FullMethodName      = "/disperser.Disperser/DisperseUploadedBlob"
 )
 
 // DisperserClient is the client API for Disperser service.
@@ -51,10 +56,38 @@ type DisperserClient interface {
 	// from the DA Nodes (see detail about this approach in
 	// api/proto/retriever/retriever.proto).
 	// The blob should have been initially dispersed via this Disperser service
-	// for this API to work.
+	// for this API to work. The blobs dispersed with private retrieval can only
+	// be retrieved with a request signed by the account which dispersed them.
 	RetrieveBlob(ctx context.Context, in *RetrieveBlobRequest, opts ...grpc.CallOption) (*RetrieveBlobReply, error)
 	// Retrieves the requested chunk from the Disperser's backend.
 	GetChunk(ctx context.Context, in *GetChunkRequest, opts ...grpc.CallOption) (*GetChunkReply, error)
+	// SubscribeBlobStatus streams the status updates of the blobs with the given request IDs, as an
+	// alternative to polling GetBlobStatus. The current status of each blob is sent first, then each
+	// status change observed by the disperser. The stream ends once all the blobs reach a terminal
+	// state. If there is no update to send for a while, a keepalive update without request ID is sent.
+	// A client reconnecting after a broken stream can pass the resume token of the last update it
+	// received, so that the statuses it already received aren't sent again.
+	SubscribeBlobStatus(ctx context.Context, in *SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error)
+	// DisperseBlobs accepts several blobs to disperse in a single call, and returns the reply of
+	// each blob in the same order. All the blobs are validated and checked against the rate limits
+	// before any of them is stored, so that no blob is dispersed if the request fails on one of them.
+	// The disperser limits the number of blobs per call.
+	DisperseBlobs(ctx context.Context, in *DisperseBlobsRequest, opts ...grpc.CallOption) (*DisperseBlobsReply, error)
+	// DisperseBlobStream is similar to DisperseBlob, except that the blob data is streamed in chunks,
+	// to disperse blobs larger than the maximum gRPC message size of the client or of a proxy. The
+	// client first sends the parameters of the blob in a header message, then the chunks of the data,
+	// and last a commit message with the total size of the blob. The disperser reassembles the blob
+	// and validates it as in DisperseBlob before accepting it.
+	DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error)
+	// GetBlobUploadURL returns a presigned URL to upload a blob to the object store of the disperser,
+	// to disperse blobs too large to be sent through gRPC. The URL expires after a short time and
+	// only accepts an upload of the requested size. It is only available if the disperser is
+	// configured with an upload bucket.
+	GetBlobUploadURL(ctx context.Context, in *BlobUploadURLRequest, opts ...grpc.CallOption) (*BlobUploadURLReply, error)
+	// DisperseUploadedBlob disperses a blob uploaded with a URL from GetBlobUploadURL. The disperser
+	// checks the size and the hash of the uploaded object, then validates and accepts the blob as in
+	// DisperseBlob. The upload can only be dispersed once.
+	DisperseUploadedBlob(ctx context.Context, in *DisperseUploadedBlobRequest, opts ...grpc.CallOption) (*DisperseBlobReply, error)
 }
 
 type disperserClient struct {
@@ -132,6 +165,99 @@ func (c *disperserClient) GetChunk(ctx context.Context, in *GetChunkRequest, opt
 	return out, nil
 }
 
+func (c *disperserClient) SubscribeBlobStatus(ctx context.Context, in *SubscribeBlobStatusRequest, opts ...grpc.CallOption) (Disperser_SubscribeBlobStatusClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[1], Disperser_SubscribeBlobStatus_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserSubscribeBlobStatusClient{stream}
+	if err := x.ClientStream.SendMsg(in); err != nil {
+		return nil, err
+	}
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	return x, nil
+}
+
+type Disperser_SubscribeBlobStatusClient interface {
+	Recv() (*BlobStatusUpdate, error)
+	grpc.ClientStream
+}
+
+type disperserSubscribeBlobStatusClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserSubscribeBlobStatusClient) Recv() (*BlobStatusUpdate, error) {
+	m := new(BlobStatusUpdate)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disperserClient) DisperseBlobs(ctx context.Context, in *DisperseBlobsRequest, opts ...grpc.CallOption) (*DisperseBlobsReply, error) {
+	out := new(DisperseBlobsReply)
+	err := c.cc.Invoke(ctx, Disperser_DisperseBlobs_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) DisperseBlobStream(ctx context.Context, opts ...grpc.CallOption) (Disperser_DisperseBlobStreamClient, error) {
+	stream, err := c.cc.NewStream(ctx, &Disperser_ServiceDesc.Streams[2], Disperser_DisperseBlobStream_FullMethodName, opts...)
+	if err != nil {
+		return nil, err
+	}
+	x := &disperserDisperseBlobStreamClient{stream}
+	return x, nil
+}
+
+type Disperser_DisperseBlobStreamClient interface {
+	Send(*DisperseBlobStreamRequest) error
+	CloseAndRecv() (*DisperseBlobReply, error)
+	grpc.ClientStream
+}
+
+type disperserDisperseBlobStreamClient struct {
+	grpc.ClientStream
+}
+
+func (x *disperserDisperseBlobStreamClient) Send(m *DisperseBlobStreamRequest) error {
+	return x.ClientStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamClient) CloseAndRecv() (*DisperseBlobReply, error) {
+	if err := x.ClientStream.CloseSend(); err != nil {
+		return nil, err
+	}
+	m := new(DisperseBlobReply)
+	if err := x.ClientStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func (c *disperserClient) GetBlobUploadURL(ctx context.Context, in *BlobUploadURLRequest, opts ...grpc.CallOption) (*BlobUploadURLReply, error) {
+	out := new(BlobUploadURLReply)
+	err := c.cc.Invoke(ctx, Disperser_GetBlobUploadURL_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+func (c *disperserClient) DisperseUploadedBlob(ctx context.Context, in *DisperseUploadedBlobRequest, opts ...grpc.CallOption) (*DisperseBlobReply, error) {
+	out := new(DisperseBlobReply)
+	err := c.cc.Invoke(ctx, Disperser_DisperseUploadedBlob_FullMethodName, in, out, opts...)
+	if err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
 // DisperserServer is the server API for Disperser service.
 // All implementations must embed UnimplementedDisperserServer
 // for forward compatibility
@@ -157,10 +283,38 @@ type DisperserServer interface {
 	// from the DA Nodes (see detail about this approach in
 	// api/proto/retriever/retriever.proto).
 	// The blob should have been initially dispersed via this Disperser service
-	// for this API to work.
+	// for this API to work. The blobs dispersed with private retrieval can only
+	// be retrieved with a request signed by the account which dispersed them.
 	RetrieveBlob(context.Context, *RetrieveBlobRequest) (*RetrieveBlobReply, error)
 	// Retrieves the requested chunk from the Disperser's backend.
 	GetChunk(context.Context, *GetChunkRequest) (*GetChunkReply, error)
+	// SubscribeBlobStatus streams the status updates of the blobs with the given request IDs, as an
+	// alternative to polling GetBlobStatus. The current status of each blob is sent first, then each
+	// status change observed by the disperser. The stream ends once all the blobs reach a terminal
+	// state. If there is no update to send for a while, a keepalive update without request ID is sent.
+	// A client reconnecting after a broken stream can pass the resume token of the last update it
+	// received, so that the statuses it already received aren't sent again.
+	SubscribeBlobStatus(*SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error
+	// DisperseBlobs accepts several blobs to disperse in a single call, and returns the reply of
+	// each blob in the same order. All the blobs are validated and checked against the rate limits
+	// before any of them is stored, so that no blob is dispersed if the request fails on one of them.
+	// The disperser limits the number of blobs per call.
+	DisperseBlobs(context.Context, *DisperseBlobsRequest) (*DisperseBlobsReply, error)
+	// DisperseBlobStream is similar to DisperseBlob, except that the blob data is streamed in chunks,
+	// to disperse blobs larger than the maximum gRPC message size of the client or of a proxy. The
+	// client first sends the parameters of the blob in a header message, then the chunks of the data,
+	// and last a commit message with the total size of the blob. The disperser reassembles the blob
+	// and validates it as in DisperseBlob before accepting it.
+	DisperseBlobStream(Disperser_DisperseBlobStreamServer) error
+	// GetBlobUploadURL returns a presigned URL to upload a blob to the object store of the disperser,
+	// to disperse blobs too large to be sent through gRPC. The URL expires after a short time and
+	// only accepts an upload of the requested size. It is only available if the disperser is
+	// configured with an upload bucket.
+	GetBlobUploadURL(context.Context, *BlobUploadURLRequest) (*BlobUploadURLReply, error)
+	// DisperseUploadedBlob disperses a blob uploaded with a URL from GetBlobUploadURL. The disperser
+	// checks the size and the hash of the uploaded object, then validates and accepts the blob as in
+	// DisperseBlob. The upload can only be dispersed once.
+	DisperseUploadedBlob(context.Context, *DisperseUploadedBlobRequest) (*DisperseBlobReply, error)
 	mustEmbedUnimplementedDisperserServer()
 }
 
@@ -183,6 +337,21 @@ func (UnimplementedDisperserServer) RetrieveBlob(context.Context, *RetrieveBlobR
 func (UnimplementedDisperserServer) GetChunk(context.Context, *GetChunkRequest) (*GetChunkReply, error) {
 	return nil, status.Errorf(codes.Unimplemented, "method GetChunk not implemented")
 }
+func (UnimplementedDisperserServer) SubscribeBlobStatus(*SubscribeBlobStatusRequest, Disperser_SubscribeBlobStatusServer) error {
+	return status.Errorf(codes.Unimplemented, "method SubscribeBlobStatus not implemented")
+}
+func (UnimplementedDisperserServer) DisperseBlobs(context.Context, *DisperseBlobsRequest) (*DisperseBlobsReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisperseBlobs not implemented")
+}
+func (UnimplementedDisperserServer) DisperseBlobStream(Disperser_DisperseBlobStreamServer) error {
+	return status.Errorf(codes.Unimplemented, "method DisperseBlobStream not implemented")
+}
+func (UnimplementedDisperserServer) GetBlobUploadURL(context.Context, *BlobUploadURLRequest) (*BlobUploadURLReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method GetBlobUploadURL not implemented")
+}
+func (UnimplementedDisperserServer) DisperseUploadedBlob(context.Context, *DisperseUploadedBlobRequest) (*DisperseBlobReply, error) {
+	return nil, status.Errorf(codes.Unimplemented, "method DisperseUploadedBlob not implemented")
+}
 func (UnimplementedDisperserServer) mustEmbedUnimplementedDisperserServer() {}
 
 // UnsafeDisperserServer may be embedded to opt out of forward compatibility for this service.
@@ -294,6 +463,107 @@ func _Disperser_GetChunk_Handler(srv interface{}, ctx context.Context, dec func(
 	return interceptor(ctx, in, info, handler)
 }
 
+func _Disperser_SubscribeBlobStatus_Handler(srv interface{}, stream grpc.ServerStream) error {
+	m := new(SubscribeBlobStatusRequest)
+	if err := stream.RecvMsg(m); err != nil {
+		return err
+	}
+	return srv.(DisperserServer).SubscribeBlobStatus(m, &disperserSubscribeBlobStatusServer{stream})
+}
+
+type Disperser_SubscribeBlobStatusServer interface {
+	Send(*BlobStatusUpdate) error
+	grpc.ServerStream
+}
+
+type disperserSubscribeBlobStatusServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserSubscribeBlobStatusServer) Send(m *BlobStatusUpdate) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func _Disperser_DisperseBlobs_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisperseBlobsRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).DisperseBlobs(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_DisperseBlobs_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).DisperseBlobs(ctx, req.(*DisperseBlobsRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_DisperseBlobStream_Handler(srv interface{}, stream grpc.ServerStream) error {
+	return srv.(DisperserServer).DisperseBlobStream(&disperserDisperseBlobStreamServer{stream})
+}
+
+type Disperser_DisperseBlobStreamServer interface {
+	SendAndClose(*DisperseBlobReply) error
+	Recv() (*DisperseBlobStreamRequest, error)
+	grpc.ServerStream
+}
+
+type disperserDisperseBlobStreamServer struct {
+	grpc.ServerStream
+}
+
+func (x *disperserDisperseBlobStreamServer) SendAndClose(m *DisperseBlobReply) error {
+	return x.ServerStream.SendMsg(m)
+}
+
+func (x *disperserDisperseBlobStreamServer) Recv() (*DisperseBlobStreamRequest, error) {
+	m := new(DisperseBlobStreamRequest)
+	if err := x.ServerStream.RecvMsg(m); err != nil {
+		return nil, err
+	}
+	return m, nil
+}
+
+func _Disperser_GetBlobUploadURL_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(BlobUploadURLRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).GetBlobUploadURL(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_GetBlobUploadURL_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).GetBlobUploadURL(ctx, req.(*BlobUploadURLRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
+func _Disperser_DisperseUploadedBlob_Handler(srv interface{}, ctx context.Context, dec func(interface{}) error, interceptor grpc.UnaryServerInterceptor) (interface{}, error) {
+	in := new(DisperseUploadedBlobRequest)
+	if err := dec(in); err != nil {
+		return nil, err
+	}
+	if interceptor == nil {
+		return srv.(DisperserServer).DisperseUploadedBlob(ctx, in)
+	}
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: Disperser_DisperseUploadedBlob_FullMethodName,
+	}
+	handler := func(ctx context.Context, req interface{}) (interface{}, error) {
+		return srv.(DisperserServer).DisperseUploadedBlob(ctx, req.(*DisperseUploadedBlobRequest))
+	}
+	return interceptor(ctx, in, info, handler)
+}
+
 // Disperser_ServiceDesc is the grpc.ServiceDesc for Disperser service.
 // It's only intended for direct use with grpc.RegisterService,
 // and not to be introspected or modified (even as a copy)
@@ -317,6 +587,18 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			MethodName: "GetChunk",
 			Handler:    _Disperser_GetChunk_Handler,
 		},
+		{
+			MethodName: "DisperseBlobs",
+			Handler:    _Disperser_DisperseBlobs_Handler,
+		},
+		{
+			MethodName: "GetBlobUploadURL",
+			Handler:    _Disperser_GetBlobUploadURL_Handler,
+		},
+		{
+			MethodName: "DisperseUploadedBlob",
+			Handler:    _Disperser_DisperseUploadedBlob_Handler,
+		},
 	},
 	Streams: []grpc.StreamDesc{
 		{
@@ -325,6 +607,16 @@ var Disperser_ServiceDesc = grpc.ServiceDesc{
 			ServerStreams: true,
 			ClientStreams: true,
 		},
+		{
+			StreamName:    "SubscribeBlobStatus",
+			Handler:       _Disperser_SubscribeBlobStatus_Handler,
+			ServerStreams: true,
+		},
+		{
+			StreamName:    "DisperseBlobStream",
+			Handler:       _Disperser_DisperseBlobStream_Handler,
+			ClientStreams: true,
+		},
 	},
 	Metadata: "disperser/disperser.proto",
 }
@@ -2,11 +2,13 @@ package dynamodb
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"math"
 	"sync"
 
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
@@ -21,6 +23,8 @@ const (
 	dynamoBatchWriteLimit = 25
 	// dynamoBatchReadLimit is the maximum number of items that can be read in a single batch
 	dynamoBatchReadLimit = 100
+	// unhealthyAfterFailures is the number of requests in a row that fail before the client is unhealthy
+	unhealthyAfterFailures = 10
 )
 
 type batchOperation uint
@@ -35,6 +39,9 @@ var (
 	clientRef *Client
 )
 
+// ErrConditionFailed is returned when the condition of a conditional write isn't satisfied by the item
+var ErrConditionFailed = errors.New("condition of the write is not satisfied")
+
 type Item = map[string]types.AttributeValue
 type Key = map[string]types.AttributeValue
 type ExpresseionValues = map[string]types.AttributeValue
@@ -46,9 +53,12 @@ type QueryResult struct {
 
 type Client struct {
 	dynamoClient *dynamodb.Client
+	health       *healthcheck.Tracker
 	logger       logging.Logger
 }
 
+var _ healthcheck.HealthReporter = (*Client)(nil)
+
 func NewClient(cfg commonaws.ClientConfig, logger logging.Logger) (*Client, error) {
 	var err error
 	once.Do(func() {
@@ -81,12 +91,27 @@ func NewClient(cfg commonaws.ClientConfig, logger logging.Logger) (*Client, erro
 			err = errCfg
 			return
 		}
-		dynamoClient := dynamodb.NewFromConfig(awsConfig)
-		clientRef = &Client{dynamoClient: dynamoClient, logger: logger.With("component", "DynamodbClient")}
+		health := healthcheck.NewTracker("DynamodbClient", unhealthyAfterFailures)
+		dynamoClient := dynamodb.NewFromConfig(awsConfig, func(o *dynamodb.Options) {
+			o.APIOptions = append(o.APIOptions, commonaws.HealthTrackingAPIOption(health, isConditionFailure))
+		})
+		clientRef = &Client{dynamoClient: dynamoClient, health: health, logger: logger.With("component", "DynamodbClient")}
 	})
 	return clientRef, err
 }
 
+// Health returns the health of the client, which is degraded once requests to DynamoDB fail
+func (c *Client) Health() healthcheck.ComponentHealth {
+	return c.health.Health()
+}
+
+// isConditionFailure returns true if the request failed because a condition of the request wasn't met, which is
+// expected and doesn't mean that DynamoDB is failing
+func isConditionFailure(err error) bool {
+	var conditionalCheckFailed *types.ConditionalCheckFailedException
+	return errors.As(err, &conditionalCheckFailed)
+}
+
 func (c *Client) DeleteTable(ctx context.Context, tableName string) error {
 	_, err := c.dynamoClient.DeleteTable(ctx, &dynamodb.DeleteTableInput{
 		TableName: aws.String(tableName)})
@@ -156,6 +181,59 @@ func (c *Client) UpdateItem(ctx context.Context, tableName string, key Key, item
 	return resp.Attributes, err
 }
 
+// IncrementItem atomically adds the increments to the numeric attributes of the item and sets the attributes of
+// item, creating the item if it doesn't exist. The missing numeric attributes are incremented from 0.
+func (c *Client) IncrementItem(ctx context.Context, tableName string, key Key, increments map[string]int64, item Item) (Item, error) {
+	return c.incrementItem(ctx, tableName, key, increments, item, nil)
+}
+
+// IncrementItemWithCondition is IncrementItem applied only if the item satisfies the condition before the increments,
+// otherwise it returns ErrConditionFailed and leaves the item unchanged
+func (c *Client) IncrementItemWithCondition(ctx context.Context, tableName string, key Key, increments map[string]int64, item Item, condition expression.ConditionBuilder) (Item, error) {
+	return c.incrementItem(ctx, tableName, key, increments, item, &condition)
+}
+
+func (c *Client) incrementItem(ctx context.Context, tableName string, key Key, increments map[string]int64, item Item, condition *expression.ConditionBuilder) (Item, error) {
+	update := expression.UpdateBuilder{}
+	for itemKey, increment := range increments {
+		update = update.Add(expression.Name(itemKey), expression.Value(increment))
+	}
+	for itemKey, itemValue := range item {
+		if _, ok := key[itemKey]; ok {
+			// Cannot update the key
+			continue
+		}
+		update = update.Set(expression.Name(itemKey), expression.Value(itemValue))
+	}
+
+	builder := expression.NewBuilder().WithUpdate(update)
+	if condition != nil {
+		builder = builder.WithCondition(*condition)
+	}
+	expr, err := builder.Build()
+	if err != nil {
+		return nil, err
+	}
+
+	resp, err := c.dynamoClient.UpdateItem(ctx, &dynamodb.UpdateItemInput{
+		TableName:                 aws.String(tableName),
+		Key:                       key,
+		ExpressionAttributeNames:  expr.Names(),
+		ExpressionAttributeValues: expr.Values(),
+		UpdateExpression:          expr.Update(),
+		ConditionExpression:       expr.Condition(),
+		ReturnValues:              types.ReturnValueUpdatedNew,
+	})
+	if isConditionFailure(err) {
+		return nil, ErrConditionFailed
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	return resp.Attributes, nil
+}
+
 func (c *Client) GetItem(ctx context.Context, tableName string, key Key) (Item, error) {
 	resp, err := c.dynamoClient.GetItem(ctx, &dynamodb.GetItemInput{Key: key, TableName: aws.String(tableName)})
 	if err != nil {
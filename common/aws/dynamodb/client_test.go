@@ -12,22 +12,20 @@ import (
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
 	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
-	"github.com/Layr-Labs/eigenda/inabox/deploy"
+	"github.com/Layr-Labs/eigenda/common/aws/localstack"
 	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
 	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
-	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
-	dockertestPool     *dockertest.Pool
-	dockertestResource *dockertest.Resource
-	dynamoClient       *commondynamodb.Client
-	clientConfig       commonaws.ClientConfig
+	harness      *localstack.Harness
+	dynamoClient *commondynamodb.Client
+	clientConfig commonaws.ClientConfig
 
-	deployLocalStack bool
-	localStackPort   = "4567"
+	localStackPort = "4567"
 )
 
 func TestMain(m *testing.M) {
@@ -38,19 +36,10 @@ func TestMain(m *testing.M) {
 }
 
 func setup(m *testing.M) {
-
-	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
-	if !deployLocalStack {
-		localStackPort = os.Getenv("LOCALSTACK_PORT")
-	}
-
-	if deployLocalStack {
-		var err error
-		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
-		if err != nil {
-			teardown()
-			panic("failed to start localstack container")
-		}
+	var err error
+	harness, err = localstack.Start(localStackPort)
+	if err != nil {
+		panic("failed to start localstack: " + err.Error())
 	}
 
 	loggerConfig := common.DefaultLoggerConfig()
@@ -60,13 +49,7 @@ func setup(m *testing.M) {
 		panic("failed to create logger")
 	}
 
-	clientConfig = commonaws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
-	}
-
+	clientConfig = harness.Config
 	dynamoClient, err = commondynamodb.NewClient(clientConfig, logger)
 	if err != nil {
 		teardown()
@@ -75,8 +58,8 @@ func setup(m *testing.M) {
 }
 
 func teardown() {
-	if deployLocalStack {
-		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+	if err := harness.Stop(); err != nil {
+		panic(err)
 	}
 }
 
@@ -135,6 +118,33 @@ func createTable(t *testing.T, tableName string) {
 	assert.NotNil(t, tableDescription)
 }
 
+func TestCreateOnDemandTable(t *testing.T) {
+	ctx := context.Background()
+	tableName := "OnDemandTable"
+	tableDescription, err := harness.CreateOnDemandTable(ctx, tableName, &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("Key"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("Key"),
+				KeyType:       types.KeyTypeHash,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(10),
+			WriteCapacityUnits: aws.Int64(10),
+		},
+	})
+	assert.NoError(t, err)
+	assert.NotNil(t, tableDescription.BillingModeSummary)
+	assert.Equal(t, types.BillingModePayPerRequest, tableDescription.BillingModeSummary.BillingMode)
+}
+
 func TestBasicOperations(t *testing.T) {
 	tableName := "Processing"
 	createTable(t, tableName)
@@ -218,6 +228,57 @@ func TestBasicOperations(t *testing.T) {
 	assert.NoError(t, err)
 }
 
+func TestIncrementItem(t *testing.T) {
+	tableName := "Increments"
+	createTable(t, tableName)
+
+	ctx := context.Background()
+	key := commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	}
+	for i := 0; i < 2; i++ {
+		_, err := dynamoClient.IncrementItem(ctx, tableName, key, map[string]int64{"NumProbes": 1, "NumOnline": int64(i)}, commondynamodb.Item{
+			"Expiry": &types.AttributeValueMemberN{Value: fmt.Sprint(100 + i)},
+		})
+		assert.NoError(t, err)
+	}
+
+	item, err := dynamoClient.GetItem(ctx, tableName, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "2", item["NumProbes"].(*types.AttributeValueMemberN).Value)
+	assert.Equal(t, "1", item["NumOnline"].(*types.AttributeValueMemberN).Value)
+	assert.Equal(t, "101", item["Expiry"].(*types.AttributeValueMemberN).Value)
+
+	err = dynamoClient.DeleteTable(ctx, tableName)
+	assert.NoError(t, err)
+}
+
+func TestIncrementItemWithCondition(t *testing.T) {
+	tableName := "ConditionalIncrements"
+	createTable(t, tableName)
+
+	ctx := context.Background()
+	key := commondynamodb.Key{
+		"MetadataKey": &types.AttributeValueMemberS{Value: "key"},
+	}
+	// Consumed can't exceed 10
+	condition := expression.Or(
+		expression.AttributeNotExists(expression.Name("Consumed")),
+		expression.LessThanEqual(expression.Name("Consumed"), expression.Value(10-6)),
+	)
+	_, err := dynamoClient.IncrementItemWithCondition(ctx, tableName, key, map[string]int64{"Consumed": 6}, nil, condition)
+	assert.NoError(t, err)
+	_, err = dynamoClient.IncrementItemWithCondition(ctx, tableName, key, map[string]int64{"Consumed": 6}, nil, condition)
+	assert.ErrorIs(t, err, commondynamodb.ErrConditionFailed)
+
+	item, err := dynamoClient.GetItem(ctx, tableName, key)
+	assert.NoError(t, err)
+	assert.Equal(t, "6", item["Consumed"].(*types.AttributeValueMemberN).Value)
+
+	err = dynamoClient.DeleteTable(ctx, tableName)
+	assert.NoError(t, err)
+}
+
 func TestBatchOperations(t *testing.T) {
 	tableName := "Processing"
 	createTable(t, tableName)
@@ -38,6 +38,22 @@ func CreateTable(ctx context.Context, cfg commonaws.ClientConfig, name string, i
 	return table.TableDescription, nil
 }
 
+// CreateOnDemandTable creates a table with on-demand capacity, so that the schemas generated for provisioned
+// capacity can be used as is. The provisioned throughput of the table and of its indexes is ignored.
+func CreateOnDemandTable(ctx context.Context, cfg commonaws.ClientConfig, name string, input *dynamodb.CreateTableInput) (*types.TableDescription, error) {
+	onDemandInput := *input
+	onDemandInput.BillingMode = types.BillingModePayPerRequest
+	onDemandInput.ProvisionedThroughput = nil
+	if len(input.GlobalSecondaryIndexes) > 0 {
+		onDemandInput.GlobalSecondaryIndexes = make([]types.GlobalSecondaryIndex, len(input.GlobalSecondaryIndexes))
+		for i, index := range input.GlobalSecondaryIndexes {
+			index.ProvisionedThroughput = nil
+			onDemandInput.GlobalSecondaryIndexes[i] = index
+		}
+	}
+	return CreateTable(ctx, cfg, name, &onDemandInput)
+}
+
 func getClient(clientConfig commonaws.ClientConfig) (*dynamodb.Client, error) {
 	createClient := func(service, region string, options ...interface{}) (aws.Endpoint, error) {
 		if clientConfig.EndpointURL != "" {
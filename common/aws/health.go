@@ -0,0 +1,26 @@
+package aws
+
+import (
+	"context"
+	"errors"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/aws/smithy-go/middleware"
+)
+
+// HealthTrackingAPIOption returns an API option of the AWS clients which records the outcome of every request with the
+// tracker. Errors for which isExpected returns true, such as missing items, don't mean that the service is failing and
+// aren't recorded, nor are cancelled requests.
+func HealthTrackingAPIOption(tracker *healthcheck.Tracker, isExpected func(error) bool) func(*middleware.Stack) error {
+	return func(stack *middleware.Stack) error {
+		return stack.Initialize.Add(middleware.InitializeMiddlewareFunc("HealthTracking", func(
+			ctx context.Context, in middleware.InitializeInput, next middleware.InitializeHandler,
+		) (middleware.InitializeOutput, middleware.Metadata, error) {
+			out, metadata, err := next.HandleInitialize(ctx, in)
+			if err == nil || !(isExpected(err) || errors.Is(err, context.Canceled)) {
+				tracker.Record(err)
+			}
+			return out, metadata, err
+		}), middleware.After)
+	}
+}
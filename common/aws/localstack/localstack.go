@@ -0,0 +1,172 @@
+package localstack
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"time"
+
+	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/aws/aws-sdk-go-v2/service/s3"
+	s3types "github.com/aws/aws-sdk-go-v2/service/s3/types"
+	"github.com/ory/dockertest/v3"
+	"github.com/ory/dockertest/v3/docker"
+)
+
+const (
+	// DeployLocalstackEnvVar disables the deployment of the container when set to "false",
+	// in which case the localstack instance listening on LocalstackPortEnvVar is used instead
+	DeployLocalstackEnvVar = "DEPLOY_LOCALSTACK"
+	LocalstackPortEnvVar   = "LOCALSTACK_PORT"
+
+	region = "us-east-1"
+	// startupTimeout is how long to wait for localstack to accept requests
+	startupTimeout = 10 * time.Second
+)
+
+// Harness is a localstack instance that the integration tests of the packages depending on
+// DynamoDB and S3 run against, so that they do not need AWS credentials.
+type Harness struct {
+	pool     *dockertest.Pool
+	resource *dockertest.Resource
+
+	// Config is the client config of the AWS clients under test
+	Config commonaws.ClientConfig
+}
+
+// Start returns a harness backed by a new localstack container listening on the given port.
+// If DEPLOY_LOCALSTACK is "false", the instance already listening on LOCALSTACK_PORT is used instead.
+// Each package should use its own port, since go test runs the packages in parallel.
+func Start(port string) (*Harness, error) {
+	h := &Harness{}
+	if os.Getenv(DeployLocalstackEnvVar) == "false" {
+		port = os.Getenv(LocalstackPortEnvVar)
+	} else {
+		var err error
+		h.pool, h.resource, err = StartContainer(port)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	h.Config = commonaws.ClientConfig{
+		Region:          region,
+		AccessKey:       "localstack",
+		SecretAccessKey: "localstack",
+		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", port),
+	}
+	return h, nil
+}
+
+// Stop removes the localstack container, if the harness started one
+func (h *Harness) Stop() error {
+	if h == nil || h.resource == nil {
+		return nil
+	}
+	return PurgeContainer(h.pool, h.resource)
+}
+
+// CreateTable creates a DynamoDB table and waits until it is active
+func (h *Harness) CreateTable(ctx context.Context, name string, input *dynamodb.CreateTableInput) (*types.TableDescription, error) {
+	return test_utils.CreateTable(ctx, h.Config, name, input)
+}
+
+// CreateOnDemandTable creates a DynamoDB table with on-demand capacity and waits until it is active
+func (h *Harness) CreateOnDemandTable(ctx context.Context, name string, input *dynamodb.CreateTableInput) (*types.TableDescription, error) {
+	return test_utils.CreateOnDemandTable(ctx, h.Config, name, input)
+}
+
+// CreateBucket creates an S3 bucket, if it does not exist yet
+func (h *Harness) CreateBucket(ctx context.Context, name string) error {
+	awsConfig, err := config.LoadDefaultConfig(ctx,
+		config.WithRegion(h.Config.Region),
+		config.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(h.Config.AccessKey, h.Config.SecretAccessKey, "")),
+	)
+	if err != nil {
+		return err
+	}
+	client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
+		o.BaseEndpoint = aws.String(h.Config.EndpointURL)
+		o.UsePathStyle = true
+	})
+
+	_, err = client.CreateBucket(ctx, &s3.CreateBucketInput{Bucket: aws.String(name)})
+	var alreadyOwned *s3types.BucketAlreadyOwnedByYou
+	if err != nil && !errors.As(err, &alreadyOwned) {
+		return fmt.Errorf("failed to create bucket %s: %w", name, err)
+	}
+	return nil
+}
+
+// StartContainer starts a localstack container listening on the given port and waits until it accepts requests
+func StartContainer(port string) (*dockertest.Pool, *dockertest.Resource, error) {
+	pool, err := dockertest.NewPool("")
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not construct docker pool: %w", err)
+	}
+	if err = pool.Client.Ping(); err != nil {
+		return nil, nil, fmt.Errorf("could not connect to docker: %w", err)
+	}
+
+	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
+		Repository: "localstack/localstack",
+		Tag:        "latest",
+		// The container name contains the port so that the packages tested in parallel do not collide
+		Name:         fmt.Sprintf("localstack-test-%s", port),
+		ExposedPorts: []string{port},
+		PortBindings: map[docker.Port][]docker.PortBinding{
+			docker.Port(port): {
+				{HostIP: "0.0.0.0", HostPort: port},
+			},
+		},
+		Env: []string{
+			fmt.Sprintf("GATEWAY_LISTEN=0.0.0.0:%s", port),
+			fmt.Sprintf("LOCALSTACK_HOST=localhost.localstack.cloud:%s", port),
+		},
+	}, func(config *docker.HostConfig) {
+		// set AutoRemove to true so that stopped container goes away by itself
+		config.AutoRemove = true
+		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
+	})
+	if err != nil {
+		return nil, nil, fmt.Errorf("could not start localstack container: %w", err)
+	}
+
+	pool.MaxWait = startupTimeout
+	err = pool.Retry(func() error {
+		resp, err := http.Get(fmt.Sprintf("http://0.0.0.0:%s", port))
+		if err != nil {
+			return err
+		}
+		defer resp.Body.Close()
+		if resp.StatusCode != http.StatusOK {
+			return fmt.Errorf("localstack returned status %s", resp.Status)
+		}
+		return nil
+	})
+	if err != nil {
+		_ = PurgeContainer(pool, resource)
+		return nil, nil, fmt.Errorf("could not connect to localstack: %w", err)
+	}
+
+	return pool, resource, nil
+}
+
+// PurgeContainer stops and removes the localstack container
+func PurgeContainer(pool *dockertest.Pool, resource *dockertest.Resource) error {
+	if err := resource.Expire(1); err != nil {
+		return fmt.Errorf("could not expire localstack container: %w", err)
+	}
+	if err := pool.Purge(resource); err != nil {
+		return fmt.Errorf("could not purge localstack container: %w", err)
+	}
+	return nil
+}
@@ -5,16 +5,22 @@ import (
 	"context"
 	"errors"
 	"sync"
+	"time"
 
 	commonaws "github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/aws/aws-sdk-go-v2/aws"
 	"github.com/aws/aws-sdk-go-v2/config"
 	"github.com/aws/aws-sdk-go-v2/credentials"
 	"github.com/aws/aws-sdk-go-v2/feature/s3/manager"
 	"github.com/aws/aws-sdk-go-v2/service/s3"
+	"github.com/aws/aws-sdk-go-v2/service/s3/types"
 )
 
+// unhealthyAfterFailures is the number of requests in a row that fail before the client is unhealthy
+const unhealthyAfterFailures = 10
+
 var (
 	once              sync.Once
 	ref               *client
@@ -28,10 +34,13 @@ type Object struct {
 
 type client struct {
 	s3Client *s3.Client
+	health   *healthcheck.Tracker
 	logger   logging.Logger
 }
 
 var _ Client = (*client)(nil)
+var _ Presigner = (*client)(nil)
+var _ healthcheck.HealthReporter = (*client)(nil)
 
 func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.Logger) (*client, error) {
 	var err error
@@ -64,10 +73,12 @@ func NewClient(ctx context.Context, cfg commonaws.ClientConfig, logger logging.L
 			err = errCfg
 			return
 		}
+		health := healthcheck.NewTracker("S3Client", unhealthyAfterFailures)
 		s3Client := s3.NewFromConfig(awsConfig, func(o *s3.Options) {
 			o.UsePathStyle = true
+			o.APIOptions = append(o.APIOptions, commonaws.HealthTrackingAPIOption(health, isMissingObject))
 		})
-		ref = &client{s3Client: s3Client, logger: logger.With("component", "S3Client")}
+		ref = &client{s3Client: s3Client, health: health, logger: logger.With("component", "S3Client")}
 	})
 	return ref, err
 }
@@ -84,6 +95,9 @@ func (s *client) DownloadObject(ctx context.Context, bucket string, key string)
 		Bucket: aws.String(bucket),
 		Key:    aws.String(key),
 	})
+	if isMissingObject(err) {
+		return nil, ErrObjectNotFound
+	}
 	if err != nil {
 		return nil, err
 	}
@@ -148,3 +162,26 @@ func (s *client) ListObjects(ctx context.Context, bucket string, prefix string)
 	}
 	return objects, nil
 }
+
+func (s *client) PresignPutObject(ctx context.Context, bucket string, key string, size int64, expiry time.Duration) (string, error) {
+	request, err := s3.NewPresignClient(s.s3Client).PresignPutObject(ctx, &s3.PutObjectInput{
+		Bucket:        aws.String(bucket),
+		Key:           aws.String(key),
+		ContentLength: aws.Int64(size),
+	}, s3.WithPresignExpires(expiry))
+	if err != nil {
+		return "", err
+	}
+	return request.URL, nil
+}
+
+// Health returns the health of the client, which is degraded once requests to S3 fail
+func (s *client) Health() healthcheck.ComponentHealth {
+	return s.health.Health()
+}
+
+func isMissingObject(err error) bool {
+	var noSuchKey *types.NoSuchKey
+	var notFound *types.NotFound
+	return errors.As(err, &noSuchKey) || errors.As(err, &notFound)
+}
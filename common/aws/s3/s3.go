@@ -1,6 +1,9 @@
 package s3
 
-import "context"
+import (
+	"context"
+	"time"
+)
 
 type Client interface {
 	DownloadObject(ctx context.Context, bucket string, key string) ([]byte, error)
@@ -8,3 +11,10 @@ type Client interface {
 	DeleteObject(ctx context.Context, bucket string, key string) error
 	ListObjects(ctx context.Context, bucket string, prefix string) ([]Object, error)
 }
+
+// Presigner issues presigned URLs, with which clients without AWS credentials access an object
+type Presigner interface {
+	// PresignPutObject returns a URL to upload the object with an HTTP PUT request of exactly size bytes, which
+	// expires after the expiry
+	PresignPutObject(ctx context.Context, bucket string, key string, size int64, expiry time.Duration) (string, error)
+}
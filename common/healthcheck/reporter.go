@@ -0,0 +1,206 @@
+package healthcheck
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/health"
+	"google.golang.org/grpc/health/grpc_health_v1"
+)
+
+// Status is the health status of a component
+type Status uint8
+
+const (
+	// StatusHealthy means that the operations of the component succeed
+	StatusHealthy Status = iota
+	// StatusDegraded means that some of the recent operations of the component failed
+	StatusDegraded
+	// StatusUnhealthy means that the component keeps failing
+	StatusUnhealthy
+)
+
+func (s Status) String() string {
+	switch s {
+	case StatusHealthy:
+		return "healthy"
+	case StatusDegraded:
+		return "degraded"
+	case StatusUnhealthy:
+		return "unhealthy"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(s))
+	}
+}
+
+func (s Status) MarshalText() ([]byte, error) {
+	return []byte(s.String()), nil
+}
+
+// ComponentHealth is the health of a component as reported by its HealthReporter
+type ComponentHealth struct {
+	Component string            `json:"component"`
+	Status    Status            `json:"status"`
+	Details   map[string]string `json:"details,omitempty"`
+	LastError string            `json:"lastError,omitempty"`
+	// LastErrorAt is nil if the component never failed
+	LastErrorAt *time.Time `json:"lastErrorAt,omitempty"`
+}
+
+// HealthReporter is implemented by the long-running components, so that each binary reports the health of its
+// components in the same way
+type HealthReporter interface {
+	Health() ComponentHealth
+}
+
+// Tracker tracks the outcome of the recurring operations of a component to report its health. The component is
+// degraded once an operation fails, and unhealthy once unhealthyAfter operations failed in a row. It is healthy again
+// as soon as an operation succeeds.
+type Tracker struct {
+	component      string
+	unhealthyAfter int
+
+	mu                  sync.Mutex
+	consecutiveFailures int
+	lastSuccessAt       time.Time
+	lastErr             error
+	lastErrAt           time.Time
+}
+
+func NewTracker(component string, unhealthyAfter int) *Tracker {
+	return &Tracker{
+		component:      component,
+		unhealthyAfter: unhealthyAfter,
+	}
+}
+
+// Record records the outcome of an operation, which succeeded if err is nil
+func (t *Tracker) Record(err error) {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	if err == nil {
+		t.consecutiveFailures = 0
+		t.lastSuccessAt = time.Now()
+		return
+	}
+	t.consecutiveFailures++
+	t.lastErr = err
+	t.lastErrAt = time.Now()
+}
+
+func (t *Tracker) Health() ComponentHealth {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	h := ComponentHealth{
+		Component: t.component,
+		Status:    StatusHealthy,
+		Details: map[string]string{
+			"consecutiveFailures": fmt.Sprint(t.consecutiveFailures),
+		},
+	}
+	if !t.lastSuccessAt.IsZero() {
+		h.Details["lastSuccessAt"] = t.lastSuccessAt.UTC().Format(time.RFC3339)
+	}
+	if t.lastErr != nil {
+		h.LastError = t.lastErr.Error()
+		lastErrAt := t.lastErrAt
+		h.LastErrorAt = &lastErrAt
+	}
+	if t.consecutiveFailures >= t.unhealthyAfter {
+		h.Status = StatusUnhealthy
+	} else if t.consecutiveFailures > 0 {
+		h.Status = StatusDegraded
+	}
+	return h
+}
+
+// Aggregator aggregates the health of the components of a binary
+type Aggregator struct {
+	mu        sync.RWMutex
+	reporters []HealthReporter
+}
+
+func NewAggregator(reporters ...HealthReporter) *Aggregator {
+	return &Aggregator{
+		reporters: reporters,
+	}
+}
+
+// Register adds components whose health is aggregated
+func (a *Aggregator) Register(reporters ...HealthReporter) {
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	a.reporters = append(a.reporters, reporters...)
+}
+
+// Health returns the health of each component, sorted by component name, and the overall status, which is the worst
+// status of the components
+func (a *Aggregator) Health() (Status, []ComponentHealth) {
+	a.mu.RLock()
+	defer a.mu.RUnlock()
+
+	status := StatusHealthy
+	components := make([]ComponentHealth, 0, len(a.reporters))
+	for _, reporter := range a.reporters {
+		h := reporter.Health()
+		status = max(status, h.Status)
+		components = append(components, h)
+	}
+	sort.SliceStable(components, func(i, j int) bool { return components[i].Component < components[j].Component })
+	return status, components
+}
+
+type healthResponse struct {
+	Status     Status            `json:"status"`
+	Components []ComponentHealth `json:"components"`
+}
+
+// ServeHTTP writes the health of the components as JSON. The response status is 503 if any component is unhealthy.
+func (a *Aggregator) ServeHTTP(w http.ResponseWriter, _ *http.Request) {
+	status, components := a.Health()
+	w.Header().Set("Content-Type", "application/json")
+	if status == StatusUnhealthy {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	} else {
+		w.WriteHeader(http.StatusOK)
+	}
+	_ = json.NewEncoder(w).Encode(healthResponse{Status: status, Components: components})
+}
+
+// RegisterAggregatedHealthServer registers a gRPC health check server with the given gRPC server, whose serving status
+// follows the health of the components of the aggregator: the service is not serving while any component is unhealthy.
+// The health of the components is checked at the given interval until the context is done.
+func RegisterAggregatedHealthServer(ctx context.Context, name string, server *grpc.Server, aggregator *Aggregator, interval time.Duration) {
+	healthServer := health.NewServer()
+	update := func() {
+		status, _ := aggregator.Health()
+		servingStatus := grpc_health_v1.HealthCheckResponse_SERVING
+		if status == StatusUnhealthy {
+			servingStatus = grpc_health_v1.HealthCheckResponse_NOT_SERVING
+		}
+		healthServer.SetServingStatus(name, servingStatus)
+	}
+	update()
+	grpc_health_v1.RegisterHealthServer(server, healthServer)
+
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				update()
+			}
+		}
+	}()
+}
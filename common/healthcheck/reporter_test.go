@@ -0,0 +1,80 @@
+package healthcheck_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTracker(t *testing.T) {
+	tracker := healthcheck.NewTracker("Component", 2)
+	h := tracker.Health()
+	assert.Equal(t, "Component", h.Component)
+	assert.Equal(t, healthcheck.StatusHealthy, h.Status)
+	assert.Nil(t, h.LastErrorAt)
+
+	tracker.Record(errors.New("failure"))
+	h = tracker.Health()
+	assert.Equal(t, healthcheck.StatusDegraded, h.Status)
+	assert.Equal(t, "failure", h.LastError)
+	assert.NotNil(t, h.LastErrorAt)
+	assert.Equal(t, "1", h.Details["consecutiveFailures"])
+
+	tracker.Record(errors.New("another failure"))
+	h = tracker.Health()
+	assert.Equal(t, healthcheck.StatusUnhealthy, h.Status)
+	assert.Equal(t, "another failure", h.LastError)
+
+	// The last error is still reported once the component recovers
+	tracker.Record(nil)
+	h = tracker.Health()
+	assert.Equal(t, healthcheck.StatusHealthy, h.Status)
+	assert.Equal(t, "another failure", h.LastError)
+	assert.Equal(t, "0", h.Details["consecutiveFailures"])
+	assert.Contains(t, h.Details, "lastSuccessAt")
+}
+
+func TestAggregator(t *testing.T) {
+	healthy := healthcheck.NewTracker("B", 1)
+	failing := healthcheck.NewTracker("A", 2)
+	aggregator := healthcheck.NewAggregator(healthy)
+	aggregator.Register(failing)
+
+	status, components := aggregator.Health()
+	assert.Equal(t, healthcheck.StatusHealthy, status)
+	assert.Len(t, components, 2)
+	assert.Equal(t, "A", components[0].Component)
+	assert.Equal(t, "B", components[1].Component)
+
+	failing.Record(errors.New("failure"))
+	status, _ = aggregator.Health()
+	assert.Equal(t, healthcheck.StatusDegraded, status)
+	w := httptest.NewRecorder()
+	aggregator.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusOK, w.Code)
+
+	failing.Record(errors.New("failure"))
+	w = httptest.NewRecorder()
+	aggregator.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/health", nil))
+	assert.Equal(t, http.StatusServiceUnavailable, w.Code)
+
+	var response struct {
+		Status     string `json:"status"`
+		Components []struct {
+			Component string `json:"component"`
+			Status    string `json:"status"`
+			LastError string `json:"lastError"`
+		} `json:"components"`
+	}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, "unhealthy", response.Status)
+	assert.Len(t, response.Components, 2)
+	assert.Equal(t, "unhealthy", response.Components[0].Status)
+	assert.Equal(t, "failure", response.Components[0].LastError)
+	assert.Equal(t, "healthy", response.Components[1].Status)
+}
@@ -13,6 +13,7 @@ import (
 )
 
 var _ kvstore.Store = &levelDBStore{}
+var _ kvstore.Compactor = &levelDBStore{}
 
 // levelDBStore implements kvstore.Store interfaces with levelDB as the backend engine.
 type levelDBStore struct {
@@ -84,6 +85,11 @@ func (store *levelDBStore) WriteBatch(keys [][]byte, values [][]byte) error {
 	return store.db.Write(batch, nil)
 }
 
+// Compact compacts the whole key range of the database.
+func (store *levelDBStore) Compact() error {
+	return store.db.CompactRange(util.Range{})
+}
+
 // Shutdown shuts down the store.
 //
 // Warning: it is not thread safe to call this method concurrently with other methods on this class,
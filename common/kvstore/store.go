@@ -46,5 +46,11 @@ type Store interface {
 	Destroy() error
 }
 
+// Compactor is implemented by the stores which can compact their data to reclaim the space of the deleted entries.
+type Compactor interface {
+	// Compact compacts the whole store. It blocks until the compaction completes.
+	Compact() error
+}
+
 // ErrNotFound is returned when a key is not found in the database.
 var ErrNotFound = errors.New("not found")
@@ -14,3 +14,7 @@ var _ common.RateLimiter = &NoopRatelimiter{}
 func (r *NoopRatelimiter) AllowRequest(ctx context.Context, params []common.RequestParams) (bool, *common.RequestParams, error) {
 	return true, nil, nil
 }
+
+func (r *NoopRatelimiter) GetUtilization(ctx context.Context, requesterID common.RequesterID) float64 {
+	return 0
+}
@@ -2,7 +2,9 @@ package mock
 
 import (
 	"context"
+	"fmt"
 	"strings"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 )
@@ -12,6 +14,7 @@ type S3Client struct {
 }
 
 var _ s3.Client = (*S3Client)(nil)
+var _ s3.Presigner = (*S3Client)(nil)
 
 func NewS3Client() *S3Client {
 	return &S3Client{bucket: make(map[string][]byte)}
@@ -44,3 +47,7 @@ func (s *S3Client) ListObjects(ctx context.Context, bucket string, prefix string
 	}
 	return objects, nil
 }
+
+func (s *S3Client) PresignPutObject(ctx context.Context, bucket string, key string, size int64, expiry time.Duration) (string, error) {
+	return fmt.Sprintf("https://%s.s3.localhost/%s?size=%d", bucket, key, size), nil
+}
@@ -38,6 +38,10 @@ type RateLimiter interface {
 	// If CountFailed is set to false, the rate limiter will stop processing requests as soon as it encounters a request that
 	// is not allowed.
 	AllowRequest(ctx context.Context, params []RequestParams) (bool, *RequestParams, error)
+	// GetUtilization returns the fraction, between 0 and 1, of the requester's buckets which is used, i.e. the highest
+	// utilization across the time scales once the buckets are refilled for the time elapsed since the last request.
+	// A requester is rate limited as its utilization reaches 1, and the utilization of a requester without any request is 0.
+	GetUtilization(ctx context.Context, requesterID RequesterID) float64
 }
 
 type GlobalRateParams struct {
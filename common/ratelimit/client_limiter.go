@@ -0,0 +1,73 @@
+package ratelimit
+
+import (
+	"math"
+	"sync"
+	"time"
+
+	"golang.org/x/time/rate"
+)
+
+// clientLimiterSweepInterval is the interval at which the buckets of the clients which stopped making requests are
+// dropped
+const clientLimiterSweepInterval = time.Minute
+
+// ClientRateLimiter is an in-memory token bucket per client, e.g. per IP, for the HTTP APIs which have no notion of
+// accounts. It is safe for concurrent use.
+type ClientRateLimiter struct {
+	mu        sync.Mutex
+	limit     rate.Limit
+	burst     int
+	clients   map[string]*rate.Limiter
+	lastSeen  map[string]time.Time
+	lastSweep time.Time
+}
+
+// NewClientRateLimiter returns a limiter allowing each client limit requests per second on average, with bursts of up
+// to burst requests. The burst defaults to the limit rounded up if it is not positive.
+func NewClientRateLimiter(limit float64, burst int) *ClientRateLimiter {
+	if burst <= 0 {
+		burst = int(math.Ceil(limit))
+	}
+	return &ClientRateLimiter{
+		limit:     rate.Limit(limit),
+		burst:     burst,
+		clients:   make(map[string]*rate.Limiter),
+		lastSeen:  make(map[string]time.Time),
+		lastSweep: time.Now(),
+	}
+}
+
+// Reserve takes a token of the client, and returns whether it was available, otherwise how long until it is
+func (l *ClientRateLimiter) Reserve(client string, now time.Time) (time.Duration, bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	// The bucket of a client idle for longer than it takes to refill is full, so it can be dropped and recreated
+	// without changing the limit of the client
+	if now.Sub(l.lastSweep) >= clientLimiterSweepInterval {
+		refill := time.Duration(float64(l.burst) / float64(l.limit) * float64(time.Second))
+		for c, seen := range l.lastSeen {
+			if now.Sub(seen) > refill {
+				delete(l.clients, c)
+				delete(l.lastSeen, c)
+			}
+		}
+		l.lastSweep = now
+	}
+
+	limiter, ok := l.clients[client]
+	if !ok {
+		limiter = rate.NewLimiter(l.limit, l.burst)
+		l.clients[client] = limiter
+	}
+	l.lastSeen[client] = now
+
+	reservation := limiter.ReserveN(now, 1)
+	delay := reservation.DelayFrom(now)
+	if delay > 0 {
+		reservation.CancelAt(now)
+		return delay, false
+	}
+	return 0, true
+}
@@ -137,6 +137,25 @@ func (d *rateLimiter) checkAllowed(ctx context.Context, params common.RequestPar
 
 }
 
+// GetUtilization returns the highest fraction of the requester's buckets which is used, without updating the buckets.
+func (d *rateLimiter) GetUtilization(ctx context.Context, requesterID common.RequesterID) float64 {
+	bucketParams, err := d.bucketStore.GetItem(ctx, requesterID)
+	if err != nil {
+		return 0
+	}
+
+	interval := time.Since(bucketParams.LastRequestTime)
+	utilization := 0.0
+	for i, size := range d.globalRateParams.BucketSizes {
+		if size <= 0 || i >= len(bucketParams.BucketLevels) {
+			continue
+		}
+		level := getBucketLevel(bucketParams.BucketLevels[i], size, interval, 0)
+		utilization = max(utilization, 1-float64(level)/float64(size))
+	}
+	return utilization
+}
+
 func getBucketLevel(bucketLevel, bucketSize, interval, deduction time.Duration) time.Duration {
 
 	newLevel := bucketLevel + interval - deduction
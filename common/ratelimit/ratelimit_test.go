@@ -59,3 +59,66 @@ func TestRatelimit(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, false, allow)
 }
+
+func TestRatelimitUtilization(t *testing.T) {
+
+	ratelimiter, err := makeTestRatelimiter()
+	assert.NoError(t, err)
+
+	ctx := context.Background()
+
+	retreiverID := "testRetriever"
+	assert.Equal(t, 0.0, ratelimiter.GetUtilization(ctx, retreiverID))
+
+	params := []common.RequestParams{
+		{
+			RequesterID: retreiverID,
+			BlobSize:    60,
+			Rate:        100,
+		},
+	}
+
+	// 600ms of the one second bucket are used
+	allow, _, err := ratelimiter.AllowRequest(ctx, params)
+	assert.NoError(t, err)
+	assert.True(t, allow)
+	assert.InDelta(t, 0.6, ratelimiter.GetUtilization(ctx, retreiverID), 0.05)
+
+	allow, _, err = ratelimiter.AllowRequest(ctx, params)
+	assert.NoError(t, err)
+	assert.False(t, allow)
+	assert.InDelta(t, 1, ratelimiter.GetUtilization(ctx, retreiverID), 0.05)
+
+	// The one second bucket refills
+	time.Sleep(500 * time.Millisecond)
+	assert.InDelta(t, 0.5, ratelimiter.GetUtilization(ctx, retreiverID), 0.1)
+}
+
+func TestClientRateLimiter(t *testing.T) {
+	limiter := ratelimit.NewClientRateLimiter(1, 2)
+	now := time.Now()
+
+	_, ok := limiter.Reserve("a", now)
+	assert.True(t, ok)
+	_, ok = limiter.Reserve("a", now)
+	assert.True(t, ok)
+	delay, ok := limiter.Reserve("a", now)
+	assert.False(t, ok)
+	assert.Equal(t, time.Second, delay)
+
+	// The clients are limited separately
+	_, ok = limiter.Reserve("b", now)
+	assert.True(t, ok)
+
+	// The bucket refills over time, and the rejected requests don't take tokens
+	_, ok = limiter.Reserve("a", now.Add(time.Second))
+	assert.True(t, ok)
+	_, ok = limiter.Reserve("a", now.Add(time.Second))
+	assert.False(t, ok)
+
+	// The buckets of the idle clients are dropped full
+	_, ok = limiter.Reserve("a", now.Add(2*time.Minute))
+	assert.True(t, ok)
+	_, ok = limiter.Reserve("a", now.Add(2*time.Minute))
+	assert.True(t, ok)
+}
@@ -2,30 +2,23 @@ package store_test
 
 import (
 	"context"
-	"fmt"
 	"os"
 	"testing"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
-	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
-	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/Layr-Labs/eigenda/common/aws/localstack"
 	"github.com/Layr-Labs/eigenda/common/store"
-	"github.com/Layr-Labs/eigenda/inabox/deploy"
 	"github.com/Layr-Labs/eigensdk-go/logging"
-	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
 )
 
 var (
 	logger = logging.NewNoopLogger()
 
-	dockertestPool     *dockertest.Pool
-	dockertestResource *dockertest.Resource
-
-	deployLocalStack bool
-	localStackPort   = "4566"
+	harness        *localstack.Harness
+	localStackPort = "4566"
 
 	dynamoClient     *dynamodb.Client
 	dynamoParamStore common.KVStore[common.RateBucketParams]
@@ -40,35 +33,19 @@ func TestMain(m *testing.M) {
 }
 
 func setup(m *testing.M) {
-
-	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
-	if !deployLocalStack {
-		localStackPort = os.Getenv("LOCALSTACK_PORT")
-	}
-
-	if deployLocalStack {
-		var err error
-		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
-		if err != nil {
-			teardown()
-			panic("failed to start localstack container")
-		}
-	}
-
-	cfg := aws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
+	var err error
+	harness, err = localstack.Start(localStackPort)
+	if err != nil {
+		panic("failed to start localstack: " + err.Error())
 	}
 
-	_, err := test_utils.CreateTable(context.Background(), cfg, bucketTableName, store.GenerateTableSchema(10, 10, bucketTableName))
+	_, err = harness.CreateTable(context.Background(), bucketTableName, store.GenerateTableSchema(10, 10, bucketTableName))
 	if err != nil {
 		teardown()
 		panic("failed to create dynamodb table: " + err.Error())
 	}
 
-	dynamoClient, err = dynamodb.NewClient(cfg, logger)
+	dynamoClient, err = dynamodb.NewClient(harness.Config, logger)
 	if err != nil {
 		teardown()
 		panic("failed to create dynamodb client: " + err.Error())
@@ -78,8 +55,8 @@ func setup(m *testing.M) {
 }
 
 func teardown() {
-	if deployLocalStack {
-		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+	if err := harness.Stop(); err != nil {
+		panic(err)
 	}
 }
 
@@ -29,6 +29,9 @@ type SigningMessage struct {
 	Signature       *Signature
 	Operator        OperatorID
 	BatchHeaderHash [32]byte
+	// ExcludedBlobs are the blobs the operator excluded from its attestation. If not empty, the signature
+	// is on the message returned by GetAttestationMessage and does not count towards the batch.
+	ExcludedBlobs BlobExclusionBitmap
 	// Undefined if this value <= 0.
 	AttestationLatencyMs float64
 	Err                  error
@@ -49,6 +52,9 @@ type QuorumAttestation struct {
 	QuorumResults map[QuorumID]*QuorumResult
 	// SignerMap contains the operator IDs that signed the message
 	SignerMap map[OperatorID]bool
+	// ExcludedBlobs contains the blobs excluded by the operators that validly signed a partial attestation.
+	// These operators are not signers of the message.
+	ExcludedBlobs map[OperatorID]BlobExclusionBitmap
 }
 
 // SignatureAggregation contains the results of aggregating signatures from a set of operators across multiple quorums
@@ -126,6 +132,7 @@ func (a *StdSignatureAggregator) ReceiveSignatures(ctx context.Context, state *I
 	aggSigs := make(map[QuorumID]*Signature, len(quorumIDs))
 	aggPubKeys := make(map[QuorumID]*G2Point, len(quorumIDs))
 	signerMap := make(map[OperatorID]bool)
+	excludedBlobs := make(map[OperatorID]BlobExclusionBitmap)
 
 	// Aggregate Signatures
 	numOperators := len(state.IndexedOperators)
@@ -165,6 +172,15 @@ func (a *StdSignatureAggregator) ReceiveSignatures(ctx context.Context, state *I
 
 		// Verify Signature
 		sig := r.Signature
+		if !r.ExcludedBlobs.IsEmpty() {
+			if !sig.Verify(op.PubkeyG2, GetAttestationMessage(message, r.ExcludedBlobs)) {
+				a.Logger.Error("partial attestation signature is not valid", "operatorID", operatorIDHex, "operatorAddress", operatorAddr, "socket", socket, "pubkey", hexutil.Encode(op.PubkeyG2.Serialize()))
+				continue
+			}
+			excludedBlobs[r.Operator] = r.ExcludedBlobs
+			a.Logger.Warn("operator excluded blobs from its attestation", "operatorID", operatorIDHex, "operatorAddress", operatorAddr, "socket", socket, "batchHeaderHash", batchHeaderHashHex, "excludedBlobIndices", fmt.Sprint(r.ExcludedBlobs.ExcludedBlobIndices()))
+			continue
+		}
 		ok = sig.Verify(op.PubkeyG2, message)
 		if !ok {
 			a.Logger.Error("signature is not valid", "operatorID", operatorIDHex, "operatorAddress", operatorAddr, "socket", socket, "pubkey", hexutil.Encode(op.PubkeyG2.Serialize()))
@@ -266,6 +282,7 @@ func (a *StdSignatureAggregator) ReceiveSignatures(ctx context.Context, state *I
 		AggSignature:     aggSigs,
 		QuorumResults:    quorumResults,
 		SignerMap:        signerMap,
+		ExcludedBlobs:    excludedBlobs,
 	}, nil
 }
 
@@ -328,3 +328,47 @@ func TestFilterQuorums(t *testing.T) {
 	assert.Equal(t, sigAgg.QuorumResults[1].QuorumID, core.QuorumID(1))
 	assert.Equal(t, sigAgg.QuorumResults[1].PercentSigned, core.QuorumID(50))
 }
+
+func TestReceivePartialSignatures(t *testing.T) {
+	state := dat.GetTotalOperatorState(context.Background(), 0)
+
+	update := make(chan core.SigningMessage)
+	message := [32]byte{1, 2, 3, 4, 5, 6}
+
+	excluded := core.NewBlobExclusionBitmap(3)
+	excluded.Exclude(1)
+	assert.Equal(t, []int{1}, excluded.ExcludedBlobIndices())
+	assert.Equal(t, message, core.GetAttestationMessage(message, core.NewBlobExclusionBitmap(3)))
+	assert.NotEqual(t, message, core.GetAttestationMessage(message, excluded))
+
+	partialSigner := mock.MakeOperatorId(0)
+	go func() {
+		for i := 0; i < len(state.PrivateOperators); i++ {
+			id := mock.MakeOperatorId(i)
+			op := state.PrivateOperators[id]
+			if id == partialSigner {
+				update <- core.SigningMessage{
+					Signature:     op.KeyPair.SignMessage(core.GetAttestationMessage(message, excluded)),
+					Operator:      id,
+					ExcludedBlobs: excluded,
+				}
+				continue
+			}
+			update <- core.SigningMessage{
+				Signature: op.KeyPair.SignMessage(message),
+				Operator:  id,
+			}
+		}
+	}()
+
+	aq, err := agg.ReceiveSignatures(context.Background(), state.IndexedOperatorState, message, update)
+	assert.NoError(t, err)
+	assert.Len(t, aq.SignerMap, len(state.IndexedOperators)-1)
+	assert.NotContains(t, aq.SignerMap, partialSigner)
+	assert.Equal(t, map[core.OperatorID]core.BlobExclusionBitmap{partialSigner: excluded}, aq.ExcludedBlobs)
+
+	sigAgg, err := agg.AggregateSignatures(context.Background(), dat, 0, aq, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assert.Equal(t, []*core.G1Point{state.IndexedOperatorState.IndexedOperators[partialSigner].PubkeyG1}, sigAgg.NonSigners)
+	assert.True(t, sigAgg.AggSignature.Verify(sigAgg.AggPubKey, message))
+}
@@ -1,5 +1,7 @@
 package core
 
+import "context"
+
 type BlobRequestAuthenticator interface {
 	AuthenticateBlobRequest(header BlobAuthHeader) error
 }
@@ -7,4 +9,16 @@ type BlobRequestAuthenticator interface {
 type BlobRequestSigner interface {
 	SignBlobRequest(header BlobAuthHeader) ([]byte, error)
 	GetAccountID() (string, error)
+	// SignRetrievalRequest signs a request to retrieve a blob dispersed with private retrieval from the disperser
+	SignRetrievalRequest(batchHeaderHash []byte, blobIndex uint32, timestamp uint64) ([]byte, error)
+}
+
+// DispersalRequestSigner signs the requests sent by the disperser to the operators' dispersal service.
+type DispersalRequestSigner interface {
+	SignDispersalRequest(requestDigest [32]byte, timestamp uint64) ([]byte, error)
+}
+
+// DispersalRequestAuthenticator verifies that a request to the dispersal service was sent by an authorized disperser.
+type DispersalRequestAuthenticator interface {
+	AuthenticateDispersalRequest(ctx context.Context, requestDigest [32]byte, timestamp uint64, signature []byte) error
 }
@@ -7,6 +7,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
 )
 
@@ -69,8 +70,38 @@ func TestAuthenticationFail(t *testing.T) {
 	testHeader.AuthenticationData = signature
 
 	err = authenticator.AuthenticateBlobRequest(testHeader)
-	assert.Error(t, err)
+	assert.ErrorIs(t, err, auth.ErrAccountMismatch)
 
+	// Malformed signature
+	testHeader.AuthenticationData = signature[:64]
+	err = authenticator.AuthenticateBlobRequest(testHeader)
+	assert.ErrorIs(t, err, auth.ErrInvalidSignature)
+
+	// Malformed account ID
+	testHeader.AuthenticationData = signature
+	testHeader.AccountID = "0x1234"
+	err = authenticator.AuthenticateBlobRequest(testHeader)
+	assert.ErrorIs(t, err, auth.ErrInvalidAccountID)
+}
+
+func TestRetrievalRequestAuthentication(t *testing.T) {
+	signer := auth.NewLocalBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	batchHeaderHash := make([]byte, 32)
+	batchHeaderHash[0] = 1
+
+	signature, err := signer.SignRetrievalRequest(batchHeaderHash, 2, 1700000000)
+	assert.NoError(t, err)
+	account, err := auth.RetrievalRequestAccount(batchHeaderHash, 2, 1700000000, signature)
+	assert.NoError(t, err)
+	assert.Equal(t, crypto.PubkeyToAddress(signer.PrivateKey.PublicKey), account)
+
+	// A signature of another request recovers another account
+	account, err = auth.RetrievalRequestAccount(batchHeaderHash, 3, 1700000000, signature)
+	assert.NoError(t, err)
+	assert.NotEqual(t, crypto.PubkeyToAddress(signer.PrivateKey.PublicKey), account)
+
+	_, err = auth.RetrievalRequestAccount(batchHeaderHash, 2, 1700000000, signature[:64])
+	assert.ErrorIs(t, err, auth.ErrInvalidSignature)
 }
 
 func TestNoopSignerFail(t *testing.T) {
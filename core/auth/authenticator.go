@@ -12,6 +12,16 @@ import (
 	"github.com/ethereum/go-ethereum/crypto"
 )
 
+var (
+	// ErrInvalidAccountID is returned when the account ID of a request isn't a hex encoded ECDSA public key
+	ErrInvalidAccountID = errors.New("invalid account ID")
+	// ErrInvalidSignature is returned when the authentication data of a request isn't a valid signature
+	ErrInvalidSignature = errors.New("invalid signature")
+	// ErrAccountMismatch is returned when the authentication data is a valid signature by another account than the
+	// account ID of the request
+	ErrAccountMismatch = errors.New("signature doesn't match with provided public key")
+)
+
 type AuthConfig struct {
 }
 
@@ -31,7 +41,7 @@ func (*authenticator) AuthenticateBlobRequest(header core.BlobAuthHeader) error
 
 	// Ensure the signature is 65 bytes (Recovery ID is the last byte)
 	if len(sig) != 65 {
-		return fmt.Errorf("signature length is unexpected: %d", len(sig))
+		return fmt.Errorf("%w: signature length is unexpected: %d", ErrInvalidSignature, len(sig))
 	}
 
 	buf := make([]byte, 4)
@@ -40,23 +50,23 @@ func (*authenticator) AuthenticateBlobRequest(header core.BlobAuthHeader) error
 
 	publicKeyBytes, err := hexutil.Decode(header.AccountID)
 	if err != nil {
-		return fmt.Errorf("failed to decode public key (%v): %v", header.AccountID, err)
+		return fmt.Errorf("%w: failed to decode public key (%v): %v", ErrInvalidAccountID, header.AccountID, err)
 	}
 
 	// Decode public key
 	pubKey, err := crypto.UnmarshalPubkey(publicKeyBytes)
 	if err != nil {
-		return fmt.Errorf("failed to decode public key (%v): %v", header.AccountID, err)
+		return fmt.Errorf("%w: failed to decode public key (%v): %v", ErrInvalidAccountID, header.AccountID, err)
 	}
 
 	// Verify the signature
 	sigPublicKeyECDSA, err := crypto.SigToPub(hash, sig)
 	if err != nil {
-		return fmt.Errorf("failed to recover public key from signature: %v", err)
+		return fmt.Errorf("%w: failed to recover public key from signature: %v", ErrInvalidSignature, err)
 	}
 
 	if !bytes.Equal(pubKey.X.Bytes(), sigPublicKeyECDSA.X.Bytes()) || !bytes.Equal(pubKey.Y.Bytes(), sigPublicKeyECDSA.Y.Bytes()) {
-		return errors.New("signature doesn't match with provided public key")
+		return ErrAccountMismatch
 	}
 
 	return nil
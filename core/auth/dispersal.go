@@ -0,0 +1,204 @@
+package auth
+
+import (
+	"context"
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	// DispersalSignatureMetadataKey is the grpc metadata key carrying the disperser's signature of a dispersal request
+	DispersalSignatureMetadataKey = "eigenda-dispersal-signature"
+	// DispersalTimestampMetadataKey is the grpc metadata key carrying the unix time (in seconds) at which the request was signed
+	DispersalTimestampMetadataKey = "eigenda-dispersal-timestamp"
+
+	dispersalRequestDomain = "EIGENDA_DISPERSAL_REQUEST"
+	// batchConfirmerCacheTTL is how long an on-chain batch confirmer lookup is trusted before being refreshed
+	batchConfirmerCacheTTL = 10 * time.Minute
+)
+
+var ErrUnauthorizedDisperser = errors.New("dispersal request is not signed by an authorized disperser")
+
+// dispersalRequestHash returns the hash signed by the disperser: keccak256(domain || requestDigest || timestamp)
+func dispersalRequestHash(requestDigest [32]byte, timestamp uint64) []byte {
+	buf := make([]byte, 0, len(dispersalRequestDomain)+len(requestDigest)+8)
+	buf = append(buf, dispersalRequestDomain...)
+	buf = append(buf, requestDigest[:]...)
+	buf = binary.BigEndian.AppendUint64(buf, timestamp)
+	return crypto.Keccak256(buf)
+}
+
+type LocalDispersalRequestSigner struct {
+	PrivateKey *ecdsa.PrivateKey
+}
+
+var _ core.DispersalRequestSigner = &LocalDispersalRequestSigner{}
+
+func NewLocalDispersalRequestSigner(privateKeyHex string) (*LocalDispersalRequestSigner, error) {
+	privateKey, err := crypto.ToECDSA(gethcommon.FromHex(privateKeyHex))
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse private key: %w", err)
+	}
+
+	return &LocalDispersalRequestSigner{
+		PrivateKey: privateKey,
+	}, nil
+}
+
+func (s *LocalDispersalRequestSigner) SignDispersalRequest(requestDigest [32]byte, timestamp uint64) ([]byte, error) {
+	sig, err := crypto.Sign(dispersalRequestHash(requestDigest, timestamp), s.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign dispersal request: %w", err)
+	}
+	return sig, nil
+}
+
+// Address returns the address that the node operators need to authorize.
+func (s *LocalDispersalRequestSigner) Address() gethcommon.Address {
+	return crypto.PubkeyToAddress(s.PrivateKey.PublicKey)
+}
+
+type dispersalRequestAuthenticator struct {
+	authorizedDispersers map[gethcommon.Address]struct{}
+	transactor           core.Transactor
+	maxClockSkew         time.Duration
+
+	mu sync.Mutex
+	// batchConfirmers caches the expiry of positive on-chain batch confirmer lookups
+	batchConfirmers map[gethcommon.Address]time.Time
+	now             func() time.Time
+}
+
+var _ core.DispersalRequestAuthenticator = &dispersalRequestAuthenticator{}
+
+// NewDispersalRequestAuthenticator returns an authenticator accepting requests signed by one of the given addresses.
+// If no address is given, requests are accepted from any address registered as a batch confirmer on the
+// EigenDAServiceManager contract, and all requests are rejected if the transactor is nil.
+// Requests signed more than maxClockSkew away from the local time are rejected.
+func NewDispersalRequestAuthenticator(authorizedDispersers []gethcommon.Address, transactor core.Transactor, maxClockSkew time.Duration) core.DispersalRequestAuthenticator {
+	authorized := make(map[gethcommon.Address]struct{}, len(authorizedDispersers))
+	for _, addr := range authorizedDispersers {
+		authorized[addr] = struct{}{}
+	}
+	return &dispersalRequestAuthenticator{
+		authorizedDispersers: authorized,
+		transactor:           transactor,
+		maxClockSkew:         maxClockSkew,
+		batchConfirmers:      make(map[gethcommon.Address]time.Time),
+		now:                  time.Now,
+	}
+}
+
+func (a *dispersalRequestAuthenticator) AuthenticateDispersalRequest(ctx context.Context, requestDigest [32]byte, timestamp uint64, signature []byte) error {
+	if len(signature) != 65 {
+		return fmt.Errorf("signature length is unexpected: %d", len(signature))
+	}
+
+	now := a.now()
+	signedAt := time.Unix(int64(timestamp), 0)
+	if signedAt.Before(now.Add(-a.maxClockSkew)) || signedAt.After(now.Add(a.maxClockSkew)) {
+		return fmt.Errorf("dispersal request timestamp %d is outside of the allowed clock skew %v", timestamp, a.maxClockSkew)
+	}
+
+	pubKey, err := crypto.SigToPub(dispersalRequestHash(requestDigest, timestamp), signature)
+	if err != nil {
+		return fmt.Errorf("failed to recover public key from signature: %w", err)
+	}
+	addr := crypto.PubkeyToAddress(*pubKey)
+
+	if len(a.authorizedDispersers) > 0 {
+		if _, ok := a.authorizedDispersers[addr]; !ok {
+			return fmt.Errorf("%w: %s", ErrUnauthorizedDisperser, addr.Hex())
+		}
+		return nil
+	}
+
+	return a.checkBatchConfirmer(ctx, addr, now)
+}
+
+func (a *dispersalRequestAuthenticator) checkBatchConfirmer(ctx context.Context, addr gethcommon.Address, now time.Time) error {
+	a.mu.Lock()
+	expiry, ok := a.batchConfirmers[addr]
+	a.mu.Unlock()
+	if ok && now.Before(expiry) {
+		return nil
+	}
+	if a.transactor == nil {
+		return fmt.Errorf("%w: %s", ErrUnauthorizedDisperser, addr.Hex())
+	}
+
+	isConfirmer, err := a.transactor.IsBatchConfirmer(ctx, addr)
+	if err != nil {
+		return fmt.Errorf("failed to check whether %s is a batch confirmer: %w", addr.Hex(), err)
+	}
+	if !isConfirmer {
+		return fmt.Errorf("%w: %s", ErrUnauthorizedDisperser, addr.Hex())
+	}
+
+	a.mu.Lock()
+	a.batchConfirmers[addr] = now.Add(batchConfirmerCacheTTL)
+	a.mu.Unlock()
+	return nil
+}
+
+// AppendDispersalSignatureToOutgoingContext signs the request digest and attaches the signature to the
+// outgoing grpc metadata of the context.
+func AppendDispersalSignatureToOutgoingContext(ctx context.Context, signer core.DispersalRequestSigner, requestDigest [32]byte) (context.Context, error) {
+	timestamp := uint64(time.Now().Unix())
+	sig, err := signer.SignDispersalRequest(requestDigest, timestamp)
+	if err != nil {
+		return nil, err
+	}
+	return metadata.AppendToOutgoingContext(ctx,
+		DispersalSignatureMetadataKey, hexutil.Encode(sig),
+		DispersalTimestampMetadataKey, strconv.FormatUint(timestamp, 10),
+	), nil
+}
+
+// AuthenticateIncomingDispersalRequest authenticates the signature attached to the incoming grpc metadata
+// of the context against the request digest.
+func AuthenticateIncomingDispersalRequest(ctx context.Context, authenticator core.DispersalRequestAuthenticator, requestDigest [32]byte) error {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return errors.New("missing request metadata")
+	}
+	sigValues := md.Get(DispersalSignatureMetadataKey)
+	timestampValues := md.Get(DispersalTimestampMetadataKey)
+	if len(sigValues) != 1 || len(timestampValues) != 1 {
+		return errors.New("missing dispersal request signature")
+	}
+
+	sig, err := hexutil.Decode(sigValues[0])
+	if err != nil {
+		return fmt.Errorf("invalid dispersal request signature: %w", err)
+	}
+	timestamp, err := strconv.ParseUint(timestampValues[0], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid dispersal request timestamp: %w", err)
+	}
+
+	return authenticator.AuthenticateDispersalRequest(ctx, requestDigest, timestamp, sig)
+}
+
+// StoreBlobsRequestDigest returns the digest signed for a StoreBlobs request, which does not carry a batch header:
+// keccak256(referenceBlockNumber || blobHeaderHash...). It commits to the headers of the blobs in the order of the
+// request, so that a captured signature can't be replayed with other blobs.
+func StoreBlobsRequestDigest(referenceBlockNumber uint32, blobHeaderHashes [][32]byte) [32]byte {
+	buf := make([]byte, 0, 4+32*len(blobHeaderHashes))
+	buf = binary.BigEndian.AppendUint32(buf, referenceBlockNumber)
+	for _, hash := range blobHeaderHashes {
+		buf = append(buf, hash[:]...)
+	}
+	return crypto.Keccak256Hash(buf)
+}
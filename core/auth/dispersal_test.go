@@ -0,0 +1,95 @@
+package auth_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core/auth"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/metadata"
+)
+
+const (
+	disperserKeyHex = "0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef"
+	attackerKeyHex  = "0xfedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210"
+)
+
+func TestDispersalAuthentication(t *testing.T) {
+	signer, err := auth.NewLocalDispersalRequestSigner(disperserKeyHex)
+	assert.NoError(t, err)
+	attacker, err := auth.NewLocalDispersalRequestSigner(attackerKeyHex)
+	assert.NoError(t, err)
+
+	authenticator := auth.NewDispersalRequestAuthenticator([]gethcommon.Address{signer.Address()}, nil, time.Minute)
+
+	digest := [32]byte{1, 2, 3}
+	timestamp := uint64(time.Now().Unix())
+	sig, err := signer.SignDispersalRequest(digest, timestamp)
+	assert.NoError(t, err)
+	assert.NoError(t, authenticator.AuthenticateDispersalRequest(context.Background(), digest, timestamp, sig))
+
+	// Signature over another digest
+	assert.ErrorIs(t, authenticator.AuthenticateDispersalRequest(context.Background(), [32]byte{4}, timestamp, sig), auth.ErrUnauthorizedDisperser)
+
+	// Signature by an unknown key
+	sig, err = attacker.SignDispersalRequest(digest, timestamp)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, authenticator.AuthenticateDispersalRequest(context.Background(), digest, timestamp, sig), auth.ErrUnauthorizedDisperser)
+
+	// Stale signature
+	stale := uint64(time.Now().Add(-2 * time.Minute).Unix())
+	sig, err = signer.SignDispersalRequest(digest, stale)
+	assert.NoError(t, err)
+	assert.ErrorContains(t, authenticator.AuthenticateDispersalRequest(context.Background(), digest, stale, sig), "clock skew")
+}
+
+func TestDispersalAuthenticationWithBatchConfirmer(t *testing.T) {
+	signer, err := auth.NewLocalDispersalRequestSigner(disperserKeyHex)
+	assert.NoError(t, err)
+	attacker, err := auth.NewLocalDispersalRequestSigner(attackerKeyHex)
+	assert.NoError(t, err)
+
+	tx := &coremock.MockTransactor{}
+	tx.On("IsBatchConfirmer", signer.Address()).Return(true, nil).Once()
+	tx.On("IsBatchConfirmer", attacker.Address()).Return(false, nil)
+
+	authenticator := auth.NewDispersalRequestAuthenticator(nil, tx, time.Minute)
+
+	digest := [32]byte{1, 2, 3}
+	timestamp := uint64(time.Now().Unix())
+	sig, err := signer.SignDispersalRequest(digest, timestamp)
+	assert.NoError(t, err)
+	// Without a transactor nobody is authorized
+	assert.ErrorIs(t, auth.NewDispersalRequestAuthenticator(nil, nil, time.Minute).AuthenticateDispersalRequest(context.Background(), digest, timestamp, sig), auth.ErrUnauthorizedDisperser)
+	// The second lookup is served from the cache
+	assert.NoError(t, authenticator.AuthenticateDispersalRequest(context.Background(), digest, timestamp, sig))
+	assert.NoError(t, authenticator.AuthenticateDispersalRequest(context.Background(), digest, timestamp, sig))
+
+	sig, err = attacker.SignDispersalRequest(digest, timestamp)
+	assert.NoError(t, err)
+	assert.ErrorIs(t, authenticator.AuthenticateDispersalRequest(context.Background(), digest, timestamp, sig), auth.ErrUnauthorizedDisperser)
+	tx.AssertExpectations(t)
+}
+
+func TestDispersalAuthenticationMetadata(t *testing.T) {
+	signer, err := auth.NewLocalDispersalRequestSigner(disperserKeyHex)
+	assert.NoError(t, err)
+	authenticator := auth.NewDispersalRequestAuthenticator([]gethcommon.Address{signer.Address()}, nil, time.Minute)
+
+	digest := auth.StoreBlobsRequestDigest(100, [][32]byte{{1}, {2}})
+	ctx, err := auth.AppendDispersalSignatureToOutgoingContext(context.Background(), signer, digest)
+	assert.NoError(t, err)
+	md, ok := metadata.FromOutgoingContext(ctx)
+	assert.True(t, ok)
+
+	incoming := metadata.NewIncomingContext(context.Background(), md)
+	assert.NoError(t, auth.AuthenticateIncomingDispersalRequest(incoming, authenticator, digest))
+	assert.Error(t, auth.AuthenticateIncomingDispersalRequest(incoming, authenticator, auth.StoreBlobsRequestDigest(101, [][32]byte{{1}, {2}})))
+	// The signature doesn't cover other blobs
+	assert.Error(t, auth.AuthenticateIncomingDispersalRequest(incoming, authenticator, auth.StoreBlobsRequestDigest(100, [][32]byte{{1}, {3}})))
+	assert.Error(t, auth.AuthenticateIncomingDispersalRequest(incoming, authenticator, auth.StoreBlobsRequestDigest(100, [][32]byte{{1}})))
+	assert.ErrorContains(t, auth.AuthenticateIncomingDispersalRequest(context.Background(), authenticator, digest), "missing")
+}
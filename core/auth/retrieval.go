@@ -0,0 +1,35 @@
+package auth
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const retrievalRequestDomain = "EIGENDA_RETRIEVAL_REQUEST"
+
+// retrievalRequestHash returns the hash signed by the account retrieving a blob dispersed with private retrieval:
+// keccak256(domain || batchHeaderHash || blobIndex || timestamp)
+func retrievalRequestHash(batchHeaderHash []byte, blobIndex uint32, timestamp uint64) []byte {
+	buf := make([]byte, 0, len(retrievalRequestDomain)+len(batchHeaderHash)+12)
+	buf = append(buf, retrievalRequestDomain...)
+	buf = append(buf, batchHeaderHash...)
+	buf = binary.BigEndian.AppendUint32(buf, blobIndex)
+	buf = binary.BigEndian.AppendUint64(buf, timestamp)
+	return crypto.Keccak256(buf)
+}
+
+// RetrievalRequestAccount returns the address of the account which signed the retrieval request. The caller checks
+// that it is the account allowed to retrieve the blob and that the timestamp is recent.
+func RetrievalRequestAccount(batchHeaderHash []byte, blobIndex uint32, timestamp uint64, signature []byte) (gethcommon.Address, error) {
+	if len(signature) != 65 {
+		return gethcommon.Address{}, fmt.Errorf("%w: signature length is unexpected: %d", ErrInvalidSignature, len(signature))
+	}
+	pubKey, err := crypto.SigToPub(retrievalRequestHash(batchHeaderHash, blobIndex, timestamp), signature)
+	if err != nil {
+		return gethcommon.Address{}, fmt.Errorf("%w: failed to recover public key from signature: %v", ErrInvalidSignature, err)
+	}
+	return crypto.PubkeyToAddress(*pubKey), nil
+}
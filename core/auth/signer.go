@@ -47,6 +47,14 @@ func (s *LocalBlobRequestSigner) SignBlobRequest(header core.BlobAuthHeader) ([]
 	return sig, nil
 }
 
+func (s *LocalBlobRequestSigner) SignRetrievalRequest(batchHeaderHash []byte, blobIndex uint32, timestamp uint64) ([]byte, error) {
+	sig, err := crypto.Sign(retrievalRequestHash(batchHeaderHash, blobIndex, timestamp), s.PrivateKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign retrieval request: %w", err)
+	}
+	return sig, nil
+}
+
 func (s *LocalBlobRequestSigner) GetAccountID() (string, error) {
 
 	publicKeyBytes := crypto.FromECDSAPub(&s.PrivateKey.PublicKey)
@@ -67,3 +75,7 @@ func (s *LocalNoopSigner) SignBlobRequest(header core.BlobAuthHeader) ([]byte, e
 func (s *LocalNoopSigner) GetAccountID() (string, error) {
 	return "", fmt.Errorf("noop signer cannot get accountID")
 }
+
+func (s *LocalNoopSigner) SignRetrievalRequest(batchHeaderHash []byte, blobIndex uint32, timestamp uint64) ([]byte, error) {
+	return nil, fmt.Errorf("noop signer cannot sign retrieval request")
+}
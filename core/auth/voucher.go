@@ -0,0 +1,81 @@
+package auth
+
+import (
+	"crypto/ecdsa"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"time"
+
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+const voucherDomain = "EIGENDA_DISPERSAL_VOUCHER"
+
+var (
+	ErrVoucherExpired         = errors.New("voucher expired")
+	ErrVoucherAccountMismatch = errors.New("voucher is issued to another account")
+)
+
+// Voucher is issued by the disperser operator to let an account disperse up to ByteBudget bytes of blobs until
+// Expiry without the rate limits of its account, e.g. for trials and partners
+type Voucher struct {
+	// Account is the address of the account the voucher is issued to
+	Account    gethcommon.Address
+	ByteBudget uint64
+	// Expiry is the unix time in seconds after which the voucher is rejected
+	Expiry uint64
+	// Signature is the signature of the hash of the voucher by the issuer
+	Signature []byte
+}
+
+// Hash returns the hash signed by the issuer of the voucher: keccak256(domain || account || byteBudget || expiry). It
+// identifies the voucher, so the vouchers issued to the same account with the same budget and expiry share the
+// budget.
+func (v *Voucher) Hash() [32]byte {
+	buf := make([]byte, 0, len(voucherDomain)+gethcommon.AddressLength+16)
+	buf = append(buf, voucherDomain...)
+	buf = append(buf, v.Account.Bytes()...)
+	buf = binary.BigEndian.AppendUint64(buf, v.ByteBudget)
+	buf = binary.BigEndian.AppendUint64(buf, v.Expiry)
+	return crypto.Keccak256Hash(buf)
+}
+
+// SignVoucher issues a voucher to the account, signed with the key of the issuer
+func SignVoucher(issuerKey *ecdsa.PrivateKey, account gethcommon.Address, byteBudget uint64, expiry time.Time) (*Voucher, error) {
+	voucher := &Voucher{
+		Account:    account,
+		ByteBudget: byteBudget,
+		Expiry:     uint64(expiry.Unix()),
+	}
+	hash := voucher.Hash()
+	sig, err := crypto.Sign(hash[:], issuerKey)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign voucher: %w", err)
+	}
+	voucher.Signature = sig
+	return voucher, nil
+}
+
+// VerifyVoucher checks that the voucher is signed by the issuer, is issued to the account and isn't expired at now
+func VerifyVoucher(voucher *Voucher, issuer gethcommon.Address, account gethcommon.Address, now time.Time) error {
+	if len(voucher.Signature) != 65 {
+		return fmt.Errorf("%w: signature length is unexpected: %d", ErrInvalidSignature, len(voucher.Signature))
+	}
+	hash := voucher.Hash()
+	pubKey, err := crypto.SigToPub(hash[:], voucher.Signature)
+	if err != nil {
+		return fmt.Errorf("%w: failed to recover public key from signature: %v", ErrInvalidSignature, err)
+	}
+	if signer := crypto.PubkeyToAddress(*pubKey); signer != issuer {
+		return fmt.Errorf("%w: voucher is signed by %s instead of the issuer", ErrInvalidSignature, signer.Hex())
+	}
+	if voucher.Account != account {
+		return fmt.Errorf("%w: issued to %s", ErrVoucherAccountMismatch, voucher.Account.Hex())
+	}
+	if uint64(now.Unix()) > voucher.Expiry {
+		return fmt.Errorf("%w at %s", ErrVoucherExpired, time.Unix(int64(voucher.Expiry), 0).UTC().Format(time.RFC3339))
+	}
+	return nil
+}
@@ -0,0 +1,45 @@
+package auth_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core/auth"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestVoucher(t *testing.T) {
+	issuerKey, err := crypto.HexToECDSA(disperserKeyHex[2:])
+	require.NoError(t, err)
+	attackerKey, err := crypto.HexToECDSA(attackerKeyHex[2:])
+	require.NoError(t, err)
+	issuer := crypto.PubkeyToAddress(issuerKey.PublicKey)
+	account := gethcommon.HexToAddress("0x1aa8226f6d354380dDE75eE6B634875c4203e522")
+	now := time.Now()
+
+	voucher, err := auth.SignVoucher(issuerKey, account, 1024, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.NoError(t, auth.VerifyVoucher(voucher, issuer, account, now))
+
+	// Presented by another account
+	assert.ErrorIs(t, auth.VerifyVoucher(voucher, issuer, issuer, now), auth.ErrVoucherAccountMismatch)
+
+	// Presented after its expiry
+	assert.ErrorIs(t, auth.VerifyVoucher(voucher, issuer, account, now.Add(2*time.Hour)), auth.ErrVoucherExpired)
+
+	// The budget is covered by the signature
+	tampered := *voucher
+	tampered.ByteBudget = 1 << 30
+	assert.ErrorIs(t, auth.VerifyVoucher(&tampered, issuer, account, now), auth.ErrInvalidSignature)
+
+	// Signed by a key other than the issuer
+	forged, err := auth.SignVoucher(attackerKey, account, 1024, now.Add(time.Hour))
+	require.NoError(t, err)
+	assert.ErrorIs(t, auth.VerifyVoucher(forged, issuer, account, now), auth.ErrInvalidSignature)
+
+	forged.Signature = forged.Signature[:64]
+	assert.ErrorIs(t, auth.VerifyVoucher(forged, issuer, account, now), auth.ErrInvalidSignature)
+}
@@ -241,6 +241,8 @@ type BlobRequestHeader struct {
 	BlobAuthHeader `json:"blob_auth_header"`
 	// For a blob to be accepted by EigenDA, it satisfy the AdversaryThreshold of each quorum contained in SecurityParams
 	SecurityParams []*SecurityParam `json:"security_params"`
+	// Version is the blob header version negotiated with the client
+	Version BlobHeaderVersion `json:"version"`
 }
 
 func ValidateSecurityParam(confirmationThreshold, adversaryThreshold uint32) error {
@@ -553,6 +553,32 @@ func (t *Transactor) ConfirmBatch(ctx context.Context, batchHeader *core.BatchHe
 	return receipt, nil
 }
 
+// GetBatchConfirmation returns the confirmation of the batch with the given batch header hash, searching from the given block
+// number onwards. It returns nil if the batch hasn't been confirmed.
+func (t *Transactor) GetBatchConfirmation(ctx context.Context, batchHeaderHash [32]byte, fromBlock uint32) (*core.BatchConfirmation, error) {
+	it, err := t.Bindings.EigenDAServiceManager.FilterBatchConfirmed(&bind.FilterOpts{
+		Start:   uint64(fromBlock),
+		Context: ctx,
+	}, [][32]byte{batchHeaderHash})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter BatchConfirmed events: %w", err)
+	}
+	defer it.Close()
+
+	for it.Next() {
+		// The log was reverted by a reorg
+		if it.Event.Raw.Removed {
+			continue
+		}
+		return &core.BatchConfirmation{
+			BatchID:     it.Event.BatchId,
+			TxnHash:     it.Event.Raw.TxHash,
+			BlockNumber: uint32(it.Event.Raw.BlockNumber),
+		}, nil
+	}
+	return nil, it.Error()
+}
+
 func (t *Transactor) StakeRegistry(ctx context.Context) (gethcommon.Address, error) {
 	return t.Bindings.RegistryCoordinator.StakeRegistry(&bind.CallOpts{
 		Context: ctx,
@@ -763,6 +789,12 @@ func (t *Transactor) GetRequiredQuorumNumbers(ctx context.Context, blockNumber u
 	return requiredQuorums, nil
 }
 
+func (t *Transactor) IsBatchConfirmer(ctx context.Context, address gethcommon.Address) (bool, error) {
+	return t.Bindings.EigenDAServiceManager.IsBatchConfirmer(&bind.CallOpts{
+		Context: ctx,
+	}, address)
+}
+
 func (t *Transactor) updateContractBindings(blsOperatorStateRetrieverAddr, eigenDAServiceManagerAddr gethcommon.Address) error {
 
 	contractEigenDAServiceManager, err := eigendasrvmg.NewContractEigenDAServiceManager(eigenDAServiceManagerAddr, t.EthClient)
@@ -0,0 +1,57 @@
+package core
+
+import (
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// BlobExclusionBitmap marks the blobs of a batch that an operator excluded from its attestation
+// because their chunks failed verification. Bit i%8 of byte i/8 is set if the blob at index i is excluded.
+type BlobExclusionBitmap []byte
+
+func NewBlobExclusionBitmap(numBlobs int) BlobExclusionBitmap {
+	return make(BlobExclusionBitmap, (numBlobs+7)/8)
+}
+
+// Exclude marks the blob at the given index as excluded. The bitmap must be large enough to hold the index.
+func (b BlobExclusionBitmap) Exclude(blobIndex int) {
+	b[blobIndex/8] |= 1 << (blobIndex % 8)
+}
+
+func (b BlobExclusionBitmap) IsExcluded(blobIndex int) bool {
+	if blobIndex < 0 || blobIndex/8 >= len(b) {
+		return false
+	}
+	return b[blobIndex/8]&(1<<(blobIndex%8)) != 0
+}
+
+// IsEmpty returns true if no blob is excluded
+func (b BlobExclusionBitmap) IsEmpty() bool {
+	for _, v := range b {
+		if v != 0 {
+			return false
+		}
+	}
+	return true
+}
+
+// ExcludedBlobIndices returns the indices of the excluded blobs in increasing order
+func (b BlobExclusionBitmap) ExcludedBlobIndices() []int {
+	indices := make([]int, 0)
+	for i := 0; i < len(b)*8; i++ {
+		if b.IsExcluded(i) {
+			indices = append(indices, i)
+		}
+	}
+	return indices
+}
+
+// GetAttestationMessage returns the message an operator signs to attest to a batch.
+// If no blob is excluded, this is the batch header hash, which is what the EigenDAServiceManager verifies.
+// Otherwise the message commits to the excluded blobs as keccak256(batchHeaderHash || exclusionBitmap),
+// so that a partial attestation can never be counted as an attestation of the full batch.
+func GetAttestationMessage(batchHeaderHash [32]byte, excluded BlobExclusionBitmap) [32]byte {
+	if excluded.IsEmpty() {
+		return batchHeaderHash
+	}
+	return crypto.Keccak256Hash(batchHeaderHash[:], excluded)
+}
@@ -0,0 +1,83 @@
+package indexer
+
+import (
+	blsapkreg "github.com/Layr-Labs/eigenda/contracts/bindings/BLSApkRegistry"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/indexer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+type OperatorEventType string
+
+const (
+	OperatorRegistered    OperatorEventType = "operator_registered"
+	OperatorDeregistered  OperatorEventType = "operator_deregistered"
+	OperatorSocketUpdated OperatorEventType = "operator_socket_updated"
+)
+
+// OperatorEvent is an event of the operators indexed by the indexer. QuorumIDs are only set for the registrations and
+// the deregistrations, and Socket only for the updates of the sockets.
+type OperatorEvent struct {
+	Type        OperatorEventType
+	BlockNumber uint64
+	Finalized   bool
+	OperatorID  core.OperatorID
+	Operator    gethcommon.Address
+	QuorumIDs   []core.QuorumID
+	Socket      string
+}
+
+// SubscribeOperatorEvents returns a subscription to the registrations, the deregistrations and the updates of the
+// sockets of the operators, which are converted with NewOperatorEvent
+func (ics *IndexedChainState) SubscribeOperatorEvents(bufferSize int) *indexer.Subscription {
+	return ics.Indexer.Subscribe(bufferSize, PubKeyAddedToQuorums, PubKeyRemovedFromQuorums, OperatorSocketUpdate)
+}
+
+// NewOperatorEvent converts an event handled by the indexer into an event of the operators. It returns false if the
+// event isn't an event of the operators.
+func NewOperatorEvent(e indexer.IndexedEvent) (*OperatorEvent, bool) {
+	event := &OperatorEvent{
+		BlockNumber: e.Header.Number,
+		Finalized:   e.Header.Finalized,
+	}
+	switch e.Event.Type {
+	case PubKeyAddedToQuorums:
+		payload, ok := e.Event.Payload.(PubKeyAddedEvent)
+		if !ok || payload.AddedEvent == nil {
+			return nil, false
+		}
+		event.Type = OperatorRegistered
+		event.OperatorID = payload.AddedEvent.OperatorId
+		event.Operator = payload.AddedEvent.Operator
+		event.QuorumIDs = toQuorumIDs(payload.AddedEvent.QuorumNumbers)
+	case PubKeyRemovedFromQuorums:
+		payload, ok := e.Event.Payload.(*blsapkreg.ContractBLSApkRegistryOperatorRemovedFromQuorums)
+		if !ok {
+			return nil, false
+		}
+		event.Type = OperatorDeregistered
+		event.OperatorID = payload.OperatorId
+		event.Operator = payload.Operator
+		event.QuorumIDs = toQuorumIDs(payload.QuorumNumbers)
+	case OperatorSocketUpdate:
+		payload, ok := e.Event.Payload.(*regcoord.ContractRegistryCoordinatorOperatorSocketUpdate)
+		if !ok {
+			return nil, false
+		}
+		event.Type = OperatorSocketUpdated
+		event.OperatorID = payload.OperatorId
+		event.Socket = payload.Socket
+	default:
+		return nil, false
+	}
+	return event, true
+}
+
+func toQuorumIDs(quorumNumbers []byte) []core.QuorumID {
+	quorumIDs := make([]core.QuorumID, len(quorumNumbers))
+	for i, quorumNumber := range quorumNumbers {
+		quorumIDs[i] = core.QuorumID(quorumNumber)
+	}
+	return quorumIDs
+}
@@ -0,0 +1,62 @@
+package indexer_test
+
+import (
+	"testing"
+
+	blsapkreg "github.com/Layr-Labs/eigenda/contracts/bindings/BLSApkRegistry"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigenda/core"
+	coreindexer "github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/indexer"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNewOperatorEvent(t *testing.T) {
+	header := &indexer.Header{Number: 10, Finalized: true}
+	operator := gethcommon.HexToAddress("0x1aa8226f6d354380dDE75eE6B634875c4203e522")
+	operatorID := core.OperatorID{1}
+
+	event, ok := coreindexer.NewOperatorEvent(indexer.IndexedEvent{Header: header, Event: indexer.Event{
+		Type: coreindexer.PubKeyAddedToQuorums,
+		Payload: coreindexer.PubKeyAddedEvent{
+			AddedEvent: &blsapkreg.ContractBLSApkRegistryOperatorAddedToQuorums{Operator: operator, OperatorId: operatorID, QuorumNumbers: []byte{0, 1}},
+		},
+	}})
+	assert.True(t, ok)
+	assert.Equal(t, &coreindexer.OperatorEvent{
+		Type:        coreindexer.OperatorRegistered,
+		BlockNumber: 10,
+		Finalized:   true,
+		OperatorID:  operatorID,
+		Operator:    operator,
+		QuorumIDs:   []core.QuorumID{0, 1},
+	}, event)
+
+	event, ok = coreindexer.NewOperatorEvent(indexer.IndexedEvent{Header: header, Event: indexer.Event{
+		Type:    coreindexer.PubKeyRemovedFromQuorums,
+		Payload: &blsapkreg.ContractBLSApkRegistryOperatorRemovedFromQuorums{Operator: operator, OperatorId: operatorID, QuorumNumbers: []byte{1}},
+	}})
+	assert.True(t, ok)
+	assert.Equal(t, coreindexer.OperatorDeregistered, event.Type)
+	assert.Equal(t, []core.QuorumID{1}, event.QuorumIDs)
+
+	event, ok = coreindexer.NewOperatorEvent(indexer.IndexedEvent{Header: header, Event: indexer.Event{
+		Type:    coreindexer.OperatorSocketUpdate,
+		Payload: &regcoord.ContractRegistryCoordinatorOperatorSocketUpdate{OperatorId: operatorID, Socket: "localhost:32005;32006"},
+	}})
+	assert.True(t, ok)
+	assert.Equal(t, coreindexer.OperatorSocketUpdated, event.Type)
+	assert.Equal(t, operatorID, event.OperatorID)
+	assert.Equal(t, "localhost:32005;32006", event.Socket)
+
+	// The payload doesn't match the type of the event
+	_, ok = coreindexer.NewOperatorEvent(indexer.IndexedEvent{Header: header, Event: indexer.Event{
+		Type:    coreindexer.OperatorSocketUpdate,
+		Payload: "localhost:32005;32006",
+	}})
+	assert.False(t, ok)
+
+	_, ok = coreindexer.NewOperatorEvent(indexer.IndexedEvent{Header: header, Event: indexer.Event{Type: coreindexer.NewPubKeyRegistration}})
+	assert.False(t, ok)
+}
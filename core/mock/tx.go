@@ -25,6 +25,10 @@ func (t *MockTransactor) GetBlockStaleMeasure(ctx context.Context) (uint32, erro
 
 func (t *MockTransactor) GetStoreDurationBlocks(ctx context.Context) (uint32, error) {
 	args := t.Called()
+	// The store duration is optional, so that the tests which only need the call to succeed can return nil
+	if len(args) == 2 {
+		return args.Get(0).(uint32), args.Error(1)
+	}
 	return *new(uint32), args.Error(0)
 }
 
@@ -104,6 +108,15 @@ func (t *MockTransactor) ConfirmBatch(ctx context.Context, batchHeader *core.Bat
 	return receipt, args.Error(1)
 }
 
+func (t *MockTransactor) GetBatchConfirmation(ctx context.Context, batchHeaderHash [32]byte, fromBlock uint32) (*core.BatchConfirmation, error) {
+	args := t.Called(batchHeaderHash)
+	var confirmation *core.BatchConfirmation
+	if args.Get(0) != nil {
+		confirmation = args.Get(0).(*core.BatchConfirmation)
+	}
+	return confirmation, args.Error(1)
+}
+
 func (t *MockTransactor) StakeRegistry(ctx context.Context) (gethcommon.Address, error) {
 	args := t.Called()
 	result := args.Get(0)
@@ -195,6 +208,12 @@ func (t *MockTransactor) GetRequiredQuorumNumbers(ctx context.Context, blockNumb
 	return result.([]uint8), args.Error(1)
 }
 
+func (t *MockTransactor) IsBatchConfirmer(ctx context.Context, address gethcommon.Address) (bool, error) {
+	args := t.Called(address)
+	result := args.Get(0)
+	return result.(bool), args.Error(1)
+}
+
 func (t *MockTransactor) PubkeyHashToOperator(ctx context.Context, operatorId core.OperatorID) (gethcommon.Address, error) {
 	args := t.Called()
 	result := args.Get(0)
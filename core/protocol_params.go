@@ -0,0 +1,172 @@
+package core
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"os"
+	"slices"
+	"sort"
+	"strconv"
+)
+
+// QuorumParams are the parameters of a quorum
+type QuorumParams struct {
+	AdversaryThreshold    uint8 `json:"adversaryThreshold"`
+	ConfirmationThreshold uint8 `json:"confirmationThreshold"`
+	// OperatorSetParams is nil if the parameters of the operator set of the quorum are unknown
+	OperatorSetParams *OperatorSetParam `json:"operatorSetParams,omitempty"`
+}
+
+// ProtocolParams are the parameters of the protocol in an environment, as read onchain or from the configuration of
+// a service
+type ProtocolParams struct {
+	// BlockNumber is the block the parameters were read at, 0 if they weren't read onchain
+	BlockNumber         uint32                     `json:"blockNumber,omitempty"`
+	QuorumCount         uint8                      `json:"quorumCount"`
+	RequiredQuorums     []QuorumID                 `json:"requiredQuorums"`
+	BlockStaleMeasure   uint32                     `json:"blockStaleMeasure"`
+	StoreDurationBlocks uint32                     `json:"storeDurationBlocks"`
+	Quorums             map[QuorumID]*QuorumParams `json:"quorums"`
+}
+
+// ProtocolParamDiff is a parameter which differs between two environments. From or To is empty if the parameter is
+// missing from the corresponding environment.
+type ProtocolParamDiff struct {
+	// Param is the path of the parameter, e.g. quorums.1.confirmationThreshold
+	Param string `json:"param"`
+	From  string `json:"from"`
+	To    string `json:"to"`
+}
+
+// ReadProtocolParams reads the parameters of the protocol onchain at the given block
+func ReadProtocolParams(ctx context.Context, tx Transactor, blockNumber uint32) (*ProtocolParams, error) {
+	quorumCount, err := tx.GetQuorumCount(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum count: %w", err)
+	}
+	requiredQuorums, err := tx.GetRequiredQuorumNumbers(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required quorums: %w", err)
+	}
+	securityParams, err := tx.GetQuorumSecurityParams(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum security params: %w", err)
+	}
+	blockStaleMeasure, err := tx.GetBlockStaleMeasure(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
+	}
+	storeDurationBlocks, err := tx.GetStoreDurationBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get STORE_DURATION_BLOCKS: %w", err)
+	}
+
+	params := &ProtocolParams{
+		BlockNumber:         blockNumber,
+		QuorumCount:         quorumCount,
+		RequiredQuorums:     requiredQuorums,
+		BlockStaleMeasure:   blockStaleMeasure,
+		StoreDurationBlocks: storeDurationBlocks,
+		Quorums:             make(map[QuorumID]*QuorumParams, len(securityParams)),
+	}
+	for _, securityParam := range securityParams {
+		operatorSetParams, err := tx.GetOperatorSetParams(ctx, securityParam.QuorumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get operator set params of quorum %d: %w", securityParam.QuorumID, err)
+		}
+		params.Quorums[securityParam.QuorumID] = &QuorumParams{
+			AdversaryThreshold:    securityParam.AdversaryThreshold,
+			ConfirmationThreshold: securityParam.ConfirmationThreshold,
+			OperatorSetParams:     operatorSetParams,
+		}
+	}
+	return params, nil
+}
+
+// ReadProtocolParamsFromFile reads the parameters of the protocol from a JSON file in the format of ProtocolParams,
+// such as the parameters a service is configured with
+func ReadProtocolParamsFromFile(path string) (*ProtocolParams, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol params file: %w", err)
+	}
+	params := &ProtocolParams{}
+	if err := json.Unmarshal(data, params); err != nil {
+		return nil, fmt.Errorf("failed to parse protocol params file %s: %w", path, err)
+	}
+	return params, nil
+}
+
+// DiffProtocolParams returns the parameters which differ from one environment to the other, sorted by parameter. The
+// block numbers the parameters were read at aren't compared, nor the parameters of the operator set of a quorum
+// unknown to either environment.
+func DiffProtocolParams(from, to *ProtocolParams) []ProtocolParamDiff {
+	diffs := make([]ProtocolParamDiff, 0)
+	compare := func(param string, from, to string) {
+		if from != to {
+			diffs = append(diffs, ProtocolParamDiff{Param: param, From: from, To: to})
+		}
+	}
+
+	compare("quorumCount", strconv.Itoa(int(from.QuorumCount)), strconv.Itoa(int(to.QuorumCount)))
+	compare("requiredQuorums", formatQuorumIDs(from.RequiredQuorums), formatQuorumIDs(to.RequiredQuorums))
+	compare("blockStaleMeasure", strconv.FormatUint(uint64(from.BlockStaleMeasure), 10), strconv.FormatUint(uint64(to.BlockStaleMeasure), 10))
+	compare("storeDurationBlocks", strconv.FormatUint(uint64(from.StoreDurationBlocks), 10), strconv.FormatUint(uint64(to.StoreDurationBlocks), 10))
+
+	quorums := make(map[QuorumID]struct{})
+	for quorumID := range from.Quorums {
+		quorums[quorumID] = struct{}{}
+	}
+	for quorumID := range to.Quorums {
+		quorums[quorumID] = struct{}{}
+	}
+	for quorumID := range quorums {
+		fromQuorum, fromOk := from.Quorums[quorumID]
+		toQuorum, toOk := to.Quorums[quorumID]
+		fromFields := fromQuorum.fields()
+		toFields := toQuorum.fields()
+		for field, fromValue := range fromFields {
+			toValue, ok := toFields[field]
+			// The parameters of a quorum present in both environments are only compared if both know them
+			if !ok && toOk {
+				continue
+			}
+			compare(fmt.Sprintf("quorums.%d.%s", quorumID, field), fromValue, toValue)
+		}
+		if !fromOk {
+			for field, toValue := range toFields {
+				compare(fmt.Sprintf("quorums.%d.%s", quorumID, field), "", toValue)
+			}
+		}
+	}
+
+	sort.Slice(diffs, func(i, j int) bool {
+		return diffs[i].Param < diffs[j].Param
+	})
+	return diffs
+}
+
+// fields returns the parameters of the quorum by name, none if the quorum is nil. The parameters of the operator set
+// are omitted if they are unknown.
+func (p *QuorumParams) fields() map[string]string {
+	fields := make(map[string]string)
+	if p == nil {
+		return fields
+	}
+	fields["adversaryThreshold"] = strconv.Itoa(int(p.AdversaryThreshold))
+	fields["confirmationThreshold"] = strconv.Itoa(int(p.ConfirmationThreshold))
+	if p.OperatorSetParams != nil {
+		fields["maxOperatorCount"] = strconv.FormatUint(uint64(p.OperatorSetParams.MaxOperatorCount), 10)
+		fields["churnBIPsOfOperatorStake"] = strconv.Itoa(int(p.OperatorSetParams.ChurnBIPsOfOperatorStake))
+		fields["churnBIPsOfTotalStake"] = strconv.Itoa(int(p.OperatorSetParams.ChurnBIPsOfTotalStake))
+	}
+	return fields
+}
+
+// formatQuorumIDs formats the quorums in ascending order, so that the order they are listed in doesn't matter
+func formatQuorumIDs(quorums []QuorumID) string {
+	sorted := slices.Clone(quorums)
+	slices.Sort(sorted)
+	return fmt.Sprint(sorted)
+}
@@ -0,0 +1,101 @@
+package core_test
+
+import (
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadProtocolParams(t *testing.T) {
+	tx := &coremock.MockTransactor{}
+	tx.On("GetQuorumCount").Return(uint8(2), nil)
+	tx.On("GetRequiredQuorumNumbers").Return([]uint8{0, 1}, nil)
+	tx.On("GetQuorumSecurityParams").Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 40, ConfirmationThreshold: 60},
+	}, nil)
+	tx.On("GetBlockStaleMeasure").Return(nil)
+	tx.On("GetStoreDurationBlocks").Return(uint32(100800), nil)
+	tx.On("GetOperatorSetParams", mock.Anything, core.QuorumID(0)).Return(&core.OperatorSetParam{MaxOperatorCount: 200}, nil)
+	tx.On("GetOperatorSetParams", mock.Anything, core.QuorumID(1)).Return(&core.OperatorSetParam{MaxOperatorCount: 100}, nil)
+
+	params, err := core.ReadProtocolParams(context.Background(), tx, 1234)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(1234), params.BlockNumber)
+	assert.Equal(t, uint8(2), params.QuorumCount)
+	assert.Equal(t, []core.QuorumID{0, 1}, params.RequiredQuorums)
+	assert.Equal(t, uint32(100800), params.StoreDurationBlocks)
+	assert.Len(t, params.Quorums, 2)
+	assert.Equal(t, uint8(60), params.Quorums[1].ConfirmationThreshold)
+	assert.Equal(t, uint32(100), params.Quorums[1].OperatorSetParams.MaxOperatorCount)
+}
+
+func TestDiffProtocolParams(t *testing.T) {
+	from := &core.ProtocolParams{
+		BlockNumber:         10,
+		QuorumCount:         2,
+		RequiredQuorums:     []core.QuorumID{0, 1},
+		BlockStaleMeasure:   300,
+		StoreDurationBlocks: 100800,
+		Quorums: map[core.QuorumID]*core.QuorumParams{
+			0: {AdversaryThreshold: 33, ConfirmationThreshold: 55, OperatorSetParams: &core.OperatorSetParam{MaxOperatorCount: 200}},
+			1: {AdversaryThreshold: 33, ConfirmationThreshold: 55, OperatorSetParams: &core.OperatorSetParam{MaxOperatorCount: 200}},
+		},
+	}
+	to := &core.ProtocolParams{
+		BlockNumber:         20,
+		QuorumCount:         3,
+		RequiredQuorums:     []core.QuorumID{1, 0},
+		BlockStaleMeasure:   300,
+		StoreDurationBlocks: 100800,
+		Quorums: map[core.QuorumID]*core.QuorumParams{
+			// The operator set of quorum 0 is unknown, so it isn't compared
+			0: {AdversaryThreshold: 33, ConfirmationThreshold: 55},
+			1: {AdversaryThreshold: 40, ConfirmationThreshold: 55, OperatorSetParams: &core.OperatorSetParam{MaxOperatorCount: 150}},
+			2: {AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		},
+	}
+
+	diffs := core.DiffProtocolParams(from, to)
+	assert.Equal(t, []core.ProtocolParamDiff{
+		{Param: "quorumCount", From: "2", To: "3"},
+		{Param: "quorums.1.adversaryThreshold", From: "33", To: "40"},
+		{Param: "quorums.1.maxOperatorCount", From: "200", To: "150"},
+		{Param: "quorums.2.adversaryThreshold", From: "", To: "33"},
+		{Param: "quorums.2.confirmationThreshold", From: "", To: "55"},
+	}, diffs)
+
+	// The diff is symmetric
+	diffs = core.DiffProtocolParams(to, from)
+	assert.Len(t, diffs, 5)
+	assert.Equal(t, core.ProtocolParamDiff{Param: "quorums.2.adversaryThreshold", From: "33", To: ""}, diffs[3])
+
+	assert.Empty(t, core.DiffProtocolParams(from, from))
+}
+
+func TestReadProtocolParamsFromFile(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "params.json")
+	err := os.WriteFile(path, []byte(`{
+		"quorumCount": 2,
+		"requiredQuorums": [0, 1],
+		"blockStaleMeasure": 300,
+		"storeDurationBlocks": 100800,
+		"quorums": {"0": {"adversaryThreshold": 33, "confirmationThreshold": 55}}
+	}`), 0644)
+	assert.NoError(t, err)
+
+	params, err := core.ReadProtocolParamsFromFile(path)
+	assert.NoError(t, err)
+	assert.Equal(t, uint8(2), params.QuorumCount)
+	assert.Equal(t, uint8(55), params.Quorums[0].ConfirmationThreshold)
+	assert.Nil(t, params.Quorums[0].OperatorSetParams)
+
+	_, err = core.ReadProtocolParamsFromFile(filepath.Join(t.TempDir(), "missing.json"))
+	assert.Error(t, err)
+}
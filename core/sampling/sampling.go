@@ -0,0 +1,128 @@
+// Package sampling samples operators at random with a probability proportional to their stake, e.g. to pick the
+// operators to fetch chunks from, favoring the ones holding the most chunks.
+package sampling
+
+import (
+	"bytes"
+	"crypto/rand"
+	"errors"
+	"io"
+	"math/big"
+	mrand "math/rand"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+var ErrNoStake = errors.New("no operator with stake to sample")
+
+// Sampler samples operators with a probability proportional to their stake. The operators without stake are never
+// sampled. A Sampler isn't safe for concurrent use.
+type Sampler struct {
+	rng        io.Reader
+	operators  []core.OperatorID
+	stakes     []*big.Int
+	totalStake *big.Int
+}
+
+// NewSampler returns a sampler of the operators drawing its randomness from rng. The samples only depend on the
+// stakes and on the rng, so a rng with a fixed seed, see NewSeededRand, makes them deterministic.
+func NewSampler(operators map[core.OperatorID]*core.OperatorInfo, rng io.Reader) (*Sampler, error) {
+	s := &Sampler{
+		rng:        rng,
+		operators:  make([]core.OperatorID, 0, len(operators)),
+		stakes:     make([]*big.Int, 0, len(operators)),
+		totalStake: big.NewInt(0),
+	}
+	for id, info := range operators {
+		if info == nil || info.Stake == nil || info.Stake.Sign() <= 0 {
+			continue
+		}
+		s.operators = append(s.operators, id)
+	}
+	if len(s.operators) == 0 {
+		return nil, ErrNoStake
+	}
+	// Sorted so that the samples don't depend on the iteration order of the map
+	sort.Slice(s.operators, func(i, j int) bool {
+		return bytes.Compare(s.operators[i][:], s.operators[j][:]) < 0
+	})
+	for _, id := range s.operators {
+		stake := new(big.Int).Set(operators[id].Stake)
+		s.stakes = append(s.stakes, stake)
+		s.totalStake.Add(s.totalStake, stake)
+	}
+	return s, nil
+}
+
+// NewQuorumSampler returns a sampler of the operators registered in the quorum
+func NewQuorumSampler(state *core.OperatorState, quorumID core.QuorumID, rng io.Reader) (*Sampler, error) {
+	operators, ok := state.Operators[quorumID]
+	if !ok {
+		return nil, ErrNoStake
+	}
+	return NewSampler(operators, rng)
+}
+
+// NewSeededRand returns a deterministic source of randomness for the samplers, e.g. for tests
+func NewSeededRand(seed int64) io.Reader {
+	return mrand.New(mrand.NewSource(seed))
+}
+
+// NewRand returns a source of randomness for the samplers which can't be predicted
+func NewRand() io.Reader {
+	return rand.Reader
+}
+
+// Sample returns an operator, with replacement
+func (s *Sampler) Sample() (core.OperatorID, error) {
+	i, err := s.pick(s.stakes, s.totalStake)
+	if err != nil {
+		return core.OperatorID{}, err
+	}
+	return s.operators[i], nil
+}
+
+// SampleN returns n distinct operators, or all the operators with stake if there are fewer. Each operator is picked
+// with a probability proportional to its stake among the operators not picked yet.
+func (s *Sampler) SampleN(n int) ([]core.OperatorID, error) {
+	n = min(n, len(s.operators))
+	stakes := make([]*big.Int, len(s.stakes))
+	copy(stakes, s.stakes)
+	remaining := new(big.Int).Set(s.totalStake)
+
+	sampled := make([]core.OperatorID, 0, n)
+	for len(sampled) < n {
+		i, err := s.pick(stakes, remaining)
+		if err != nil {
+			return nil, err
+		}
+		sampled = append(sampled, s.operators[i])
+		remaining.Sub(remaining, stakes[i])
+		// A picked operator has no more stake, so it can't be picked again
+		stakes[i] = big.NewInt(0)
+	}
+	return sampled, nil
+}
+
+// Shuffle returns all the operators with stake in a stake-weighted random order, where the operators with the most
+// stake tend to come first
+func (s *Sampler) Shuffle() ([]core.OperatorID, error) {
+	return s.SampleN(len(s.operators))
+}
+
+// pick returns the index of an operator picked with a probability proportional to its stake
+func (s *Sampler) pick(stakes []*big.Int, totalStake *big.Int) (int, error) {
+	r, err := rand.Int(s.rng, totalStake)
+	if err != nil {
+		return 0, err
+	}
+	for i, stake := range stakes {
+		if r.Cmp(stake) < 0 {
+			return i, nil
+		}
+		r.Sub(r, stake)
+	}
+	// unreachable, since r is less than the total stake
+	return len(stakes) - 1, nil
+}
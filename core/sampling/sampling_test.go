@@ -0,0 +1,89 @@
+package sampling_test
+
+import (
+	"math/big"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/sampling"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeOperators(stakes ...int64) map[core.OperatorID]*core.OperatorInfo {
+	operators := make(map[core.OperatorID]*core.OperatorInfo, len(stakes))
+	for i, stake := range stakes {
+		operators[core.OperatorID{byte(i)}] = &core.OperatorInfo{Stake: big.NewInt(stake), Index: core.OperatorIndex(i)}
+	}
+	return operators
+}
+
+func TestSample(t *testing.T) {
+	operators := makeOperators(1, 3, 0)
+
+	sampler, err := sampling.NewSampler(operators, sampling.NewSeededRand(1))
+	assert.NoError(t, err)
+	counts := make(map[core.OperatorID]int)
+	for i := 0; i < 10000; i++ {
+		id, err := sampler.Sample()
+		assert.NoError(t, err)
+		counts[id]++
+	}
+	// The operator without stake is never sampled, and the others in proportion to their stake
+	assert.Zero(t, counts[core.OperatorID{2}])
+	assert.InDelta(t, 2500, counts[core.OperatorID{0}], 200)
+	assert.InDelta(t, 7500, counts[core.OperatorID{1}], 200)
+
+	_, err = sampling.NewSampler(makeOperators(0, 0), sampling.NewSeededRand(1))
+	assert.ErrorIs(t, err, sampling.ErrNoStake)
+	_, err = sampling.NewQuorumSampler(&core.OperatorState{Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{0: operators}}, 1, sampling.NewRand())
+	assert.ErrorIs(t, err, sampling.ErrNoStake)
+}
+
+func TestSampleDeterministic(t *testing.T) {
+	operators := makeOperators(5, 10, 20, 40, 80)
+
+	sample := func(seed int64) []core.OperatorID {
+		sampler, err := sampling.NewSampler(operators, sampling.NewSeededRand(seed))
+		assert.NoError(t, err)
+		ids := make([]core.OperatorID, 0, 20)
+		for i := 0; i < 20; i++ {
+			id, err := sampler.Sample()
+			assert.NoError(t, err)
+			ids = append(ids, id)
+		}
+		return ids
+	}
+	assert.Equal(t, sample(42), sample(42))
+	assert.NotEqual(t, sample(42), sample(43))
+}
+
+func TestSampleN(t *testing.T) {
+	operators := makeOperators(1, 1000, 2, 0)
+	sampler, err := sampling.NewSampler(operators, sampling.NewSeededRand(7))
+	assert.NoError(t, err)
+
+	ids, err := sampler.SampleN(2)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 2)
+	assert.NotEqual(t, ids[0], ids[1])
+
+	// Only the operators with stake are shuffled
+	ids, err = sampler.Shuffle()
+	assert.NoError(t, err)
+	assert.ElementsMatch(t, []core.OperatorID{{0}, {1}, {2}}, ids)
+
+	// The operator with the most stake comes first most of the time
+	first := 0
+	for i := 0; i < 1000; i++ {
+		ids, err := sampler.Shuffle()
+		assert.NoError(t, err)
+		if ids[0] == (core.OperatorID{1}) {
+			first++
+		}
+	}
+	assert.Greater(t, first, 990)
+
+	ids, err = sampler.SampleN(10)
+	assert.NoError(t, err)
+	assert.Len(t, ids, 3)
+}
@@ -7,10 +7,11 @@ import (
 	"errors"
 	"fmt"
 	"math/big"
-	"regexp"
+	"net"
 	"slices"
 
 	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/consensys/gnark-crypto/ecc/bn254"
 
 	"github.com/ethereum/go-ethereum/accounts/abi"
@@ -71,10 +72,29 @@ func (h *BatchHeader) SetBatchRootFromBlobHeaderHashes(blobHeaderHashes [][32]by
 	return tree, nil
 }
 
-func (h *BatchHeader) Encode() ([]byte, error) {
-	// The order here has to match the field ordering of ReducedBatchHeader defined in IEigenDAServiceManager.sol
-	// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L43
-	batchHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
+// The ABI types of the structs defined in IEigenDAServiceManager.sol, shared by their encodings and decodings
+// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol
+var (
+	quorumBlobParamsComponents = []abi.ArgumentMarshaling{
+		{
+			Name: "quorumNumber",
+			Type: "uint8",
+		},
+		{
+			Name: "adversaryThresholdPercentage",
+			Type: "uint8",
+		},
+		{
+			Name: "quorumThresholdPercentage",
+			Type: "uint8",
+		},
+		{
+			Name: "chunkLength",
+			Type: "uint32",
+		},
+	}
+
+	reducedBatchHeaderArguments = mustABIArguments("tuple", []abi.ArgumentMarshaling{
 		{
 			Name: "blobHeadersRoot",
 			Type: "bytes32",
@@ -84,25 +104,109 @@ func (h *BatchHeader) Encode() ([]byte, error) {
 			Type: "uint32",
 		},
 	})
+
+	batchHeaderArguments = mustABIArguments("tuple", []abi.ArgumentMarshaling{
+		{
+			Name: "batchRoot",
+			Type: "bytes32",
+		},
+		{
+			Name: "quorumNumbers",
+			Type: "bytes",
+		},
+		{
+			Name: "confirmationThresholdPercentages",
+			Type: "bytes",
+		},
+		{
+			Name: "referenceBlockNumber",
+			Type: "uint32",
+		},
+	})
+
+	quorumBlobParamsArguments = mustABIArguments("tuple[]", quorumBlobParamsComponents)
+
+	blobHeaderArguments = mustABIArguments("tuple", []abi.ArgumentMarshaling{
+		{
+			Name: "commitment",
+			Type: "tuple",
+			Components: []abi.ArgumentMarshaling{
+				{
+					Name: "X",
+					Type: "uint256",
+				},
+				{
+					Name: "Y",
+					Type: "uint256",
+				},
+			},
+		},
+		{
+			Name: "dataLength",
+			Type: "uint32",
+		},
+		{
+			Name:       "quorumBlobParams",
+			Type:       "tuple[]",
+			Components: quorumBlobParamsComponents,
+		},
+	})
+)
+
+func mustABIArguments(t string, components []abi.ArgumentMarshaling) abi.Arguments {
+	abiType, err := abi.NewType(t, "", components)
 	if err != nil {
-		return nil, err
+		panic(fmt.Sprintf("invalid ABI type: %v", err))
 	}
-
-	arguments := abi.Arguments{
+	return abi.Arguments{
 		{
-			Type: batchHeaderType,
+			Type: abiType,
 		},
 	}
+}
 
-	s := struct {
+// The Go structs the ABI types are packed from and unpacked into
+type (
+	abiReducedBatchHeader struct {
 		BlobHeadersRoot      [32]byte
 		ReferenceBlockNumber uint32
-	}{
+	}
+
+	abiBatchHeader struct {
+		BatchRoot                        [32]byte
+		QuorumNumbers                    []byte
+		ConfirmationThresholdPercentages []byte
+		ReferenceBlockNumber             uint32
+	}
+
+	abiQuorumBlobParams struct {
+		QuorumNumber                 uint8
+		AdversaryThresholdPercentage uint8
+		QuorumThresholdPercentage    uint8
+		ChunkLength                  uint32
+	}
+
+	abiCommitment struct {
+		X *big.Int
+		Y *big.Int
+	}
+
+	abiBlobHeader struct {
+		Commitment       abiCommitment
+		DataLength       uint32
+		QuorumBlobParams []abiQuorumBlobParams
+	}
+)
+
+func (h *BatchHeader) Encode() ([]byte, error) {
+	// The order here has to match the field ordering of ReducedBatchHeader defined in IEigenDAServiceManager.sol
+	// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/interfaces/IEigenDAServiceManager.sol#L43
+	s := abiReducedBatchHeader{
 		BlobHeadersRoot:      h.BatchRoot,
 		ReferenceBlockNumber: uint32(h.ReferenceBlockNumber),
 	}
 
-	bytes, err := arguments.Pack(s)
+	bytes, err := reducedBatchHeaderArguments.Pack(s)
 	if err != nil {
 		return nil, err
 	}
@@ -110,6 +214,17 @@ func (h *BatchHeader) Encode() ([]byte, error) {
 	return bytes, nil
 }
 
+// Decode sets the BatchHeader to the reduced BatchHeader of the ABI encoding returned by Encode
+func (h *BatchHeader) Decode(data []byte) error {
+	var s abiReducedBatchHeader
+	if err := unpackABI(reducedBatchHeaderArguments, data, &s); err != nil {
+		return err
+	}
+	h.BatchRoot = s.BlobHeadersRoot
+	h.ReferenceBlockNumber = uint(s.ReferenceBlockNumber)
+	return nil
+}
+
 // GetBatchHeaderHash returns the hash of the reduced BatchHeader that is used to sign the Batch
 // ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/libraries/EigenDAHasher.sol#L65
 func (h BatchHeader) GetBatchHeaderHash() ([32]byte, error) {
@@ -129,58 +244,44 @@ func (h BatchHeader) GetBatchHeaderHash() ([32]byte, error) {
 // HashBatchHeader returns the hash of the BatchHeader that is used to emit the BatchConfirmed event
 // ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/libraries/EigenDAHasher.sol#L57
 func HashBatchHeader(batchHeader binding.IEigenDAServiceManagerBatchHeader) ([32]byte, error) {
-	// The order here has to match the field ordering of BatchHeader defined in IEigenDAServiceManager.sol
-	batchHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
-		{
-			Name: "batchRoot",
-			Type: "bytes32",
-		},
-		{
-			Name: "quorumNumbers",
-			Type: "bytes",
-		},
-		{
-			Name: "confirmationThresholdPercentages",
-			Type: "bytes",
-		},
-		{
-			Name: "referenceBlockNumber",
-			Type: "uint32",
-		},
-	})
+	bytes, err := EncodeBatchHeader(batchHeader)
 	if err != nil {
 		return [32]byte{}, err
 	}
 
-	arguments := abi.Arguments{
-		{
-			Type: batchHeaderType,
-		},
-	}
+	var headerHash [32]byte
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(bytes)
+	copy(headerHash[:], hasher.Sum(nil)[:32])
 
-	s := struct {
-		BatchRoot                        [32]byte
-		QuorumNumbers                    []byte
-		ConfirmationThresholdPercentages []byte
-		ReferenceBlockNumber             uint32
-	}{
+	return headerHash, nil
+}
+
+// EncodeBatchHeader returns the ABI encoding of the BatchHeader submitted in confirmBatch
+func EncodeBatchHeader(batchHeader binding.IEigenDAServiceManagerBatchHeader) ([]byte, error) {
+	// The order here has to match the field ordering of BatchHeader defined in IEigenDAServiceManager.sol
+	s := abiBatchHeader{
 		BatchRoot:                        batchHeader.BlobHeadersRoot,
 		QuorumNumbers:                    batchHeader.QuorumNumbers,
 		ConfirmationThresholdPercentages: batchHeader.SignedStakeForQuorums,
 		ReferenceBlockNumber:             uint32(batchHeader.ReferenceBlockNumber),
 	}
 
-	bytes, err := arguments.Pack(s)
-	if err != nil {
-		return [32]byte{}, err
-	}
-
-	var headerHash [32]byte
-	hasher := sha3.NewLegacyKeccak256()
-	hasher.Write(bytes)
-	copy(headerHash[:], hasher.Sum(nil)[:32])
+	return batchHeaderArguments.Pack(s)
+}
 
-	return headerHash, nil
+// DecodeBatchHeader returns the BatchHeader of the ABI encoding returned by EncodeBatchHeader
+func DecodeBatchHeader(data []byte) (binding.IEigenDAServiceManagerBatchHeader, error) {
+	var s abiBatchHeader
+	if err := unpackABI(batchHeaderArguments, data, &s); err != nil {
+		return binding.IEigenDAServiceManagerBatchHeader{}, err
+	}
+	return binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:       s.BatchRoot,
+		QuorumNumbers:         s.QuorumNumbers,
+		SignedStakeForQuorums: s.ConfirmationThresholdPercentages,
+		ReferenceBlockNumber:  s.ReferenceBlockNumber,
+	}, nil
 }
 
 // GetBlobHeaderHash returns the hash of the BlobHeader that is used to sign the Blob
@@ -199,45 +300,9 @@ func (h BlobHeader) GetBlobHeaderHash() ([32]byte, error) {
 }
 
 func (h *BlobHeader) GetQuorumBlobParamsHash() ([32]byte, error) {
-	quorumBlobParamsType, err := abi.NewType("tuple[]", "", []abi.ArgumentMarshaling{
-		{
-			Name: "quorumNumber",
-			Type: "uint8",
-		},
-		{
-			Name: "adversaryThresholdPercentage",
-			Type: "uint8",
-		},
-		{
-			Name: "quorumThresholdPercentage",
-			Type: "uint8",
-		},
-		{
-			Name: "chunkLength",
-			Type: "uint32",
-		},
-	})
-
-	if err != nil {
-		return [32]byte{}, err
-	}
-
-	arguments := abi.Arguments{
-		{
-			Type: quorumBlobParamsType,
-		},
-	}
-
-	type quorumBlobParams struct {
-		QuorumNumber                 uint8
-		AdversaryThresholdPercentage uint8
-		QuorumThresholdPercentage    uint8
-		ChunkLength                  uint32
-	}
-
-	qbp := make([]quorumBlobParams, len(h.QuorumInfos))
+	qbp := make([]abiQuorumBlobParams, len(h.QuorumInfos))
 	for i, q := range h.QuorumInfos {
-		qbp[i] = quorumBlobParams{
+		qbp[i] = abiQuorumBlobParams{
 			QuorumNumber:                 q.QuorumID,
 			AdversaryThresholdPercentage: q.AdversaryThreshold,
 			QuorumThresholdPercentage:    q.ConfirmationThreshold,
@@ -245,7 +310,7 @@ func (h *BlobHeader) GetQuorumBlobParamsHash() ([32]byte, error) {
 		}
 	}
 
-	bytes, err := arguments.Pack(qbp)
+	bytes, err := quorumBlobParamsArguments.Pack(qbp)
 	if err != nil {
 		return [32]byte{}, err
 	}
@@ -264,89 +329,21 @@ func (h *BlobHeader) Encode() ([]byte, error) {
 	}
 
 	// The order here has to match the field ordering of BlobHeader defined in IEigenDAServiceManager.sol
-	blobHeaderType, err := abi.NewType("tuple", "", []abi.ArgumentMarshaling{
-		{
-			Name: "commitment",
-			Type: "tuple",
-			Components: []abi.ArgumentMarshaling{
-				{
-					Name: "X",
-					Type: "uint256",
-				},
-				{
-					Name: "Y",
-					Type: "uint256",
-				},
-			},
-		},
-		{
-			Name: "dataLength",
-			Type: "uint32",
-		},
-		{
-			Name: "quorumBlobParams",
-			Type: "tuple[]",
-			Components: []abi.ArgumentMarshaling{
-				{
-					Name: "quorumNumber",
-					Type: "uint8",
-				},
-				{
-					Name: "adversaryThresholdPercentage",
-					Type: "uint8",
-				},
-				{
-					Name: "quorumThresholdPercentage",
-					Type: "uint8",
-				},
-				{
-					Name: "chunkLength",
-					Type: "uint32",
-				},
-			},
-		},
-	})
-	if err != nil {
-		return nil, err
-	}
-
-	arguments := abi.Arguments{
-		{
-			Type: blobHeaderType,
-		},
-	}
-
-	type quorumBlobParams struct {
-		QuorumNumber                 uint8
-		AdversaryThresholdPercentage uint8
-		QuorumThresholdPercentage    uint8
-		ChunkLength                  uint32
-	}
-
-	type commitment struct {
-		X *big.Int
-		Y *big.Int
-	}
-
-	qbp := make([]quorumBlobParams, len(h.QuorumInfos))
+	qbp := make([]abiQuorumBlobParams, len(h.QuorumInfos))
 	for i, q := range h.QuorumInfos {
-		qbp[i] = quorumBlobParams{
+		qbp[i] = abiQuorumBlobParams{
 			QuorumNumber:                 q.QuorumID,
 			AdversaryThresholdPercentage: q.AdversaryThreshold,
 			QuorumThresholdPercentage:    q.ConfirmationThreshold,
 			ChunkLength:                  uint32(q.ChunkLength),
 		}
 	}
-	slices.SortStableFunc[[]quorumBlobParams](qbp, func(a, b quorumBlobParams) int {
+	slices.SortStableFunc[[]abiQuorumBlobParams](qbp, func(a, b abiQuorumBlobParams) int {
 		return int(a.QuorumNumber) - int(b.QuorumNumber)
 	})
 
-	s := struct {
-		Commitment       commitment
-		DataLength       uint32
-		QuorumBlobParams []quorumBlobParams
-	}{
-		Commitment: commitment{
+	s := abiBlobHeader{
+		Commitment: abiCommitment{
 			X: h.Commitment.X.BigInt(new(big.Int)),
 			Y: h.Commitment.Y.BigInt(new(big.Int)),
 		},
@@ -354,7 +351,7 @@ func (h *BlobHeader) Encode() ([]byte, error) {
 		QuorumBlobParams: qbp,
 	}
 
-	bytes, err := arguments.Pack(s)
+	bytes, err := blobHeaderArguments.Pack(s)
 	if err != nil {
 		return nil, err
 	}
@@ -362,6 +359,49 @@ func (h *BlobHeader) Encode() ([]byte, error) {
 	return bytes, nil
 }
 
+// Decode sets the BlobHeader to the BlobHeader of the ABI encoding returned by Encode. The length commitment and
+// the length proof aren't part of the encoding, so they are left unset, and the commitment isn't checked to be on
+// the curve.
+func (h *BlobHeader) Decode(data []byte) error {
+	var s abiBlobHeader
+	if err := unpackABI(blobHeaderArguments, data, &s); err != nil {
+		return err
+	}
+
+	var commitment bn254.G1Affine
+	commitment.X.SetBigInt(s.Commitment.X)
+	commitment.Y.SetBigInt(s.Commitment.Y)
+
+	quorumInfos := make([]*BlobQuorumInfo, len(s.QuorumBlobParams))
+	for i, p := range s.QuorumBlobParams {
+		quorumInfos[i] = &BlobQuorumInfo{
+			SecurityParam: SecurityParam{
+				QuorumID:              p.QuorumNumber,
+				AdversaryThreshold:    p.AdversaryThresholdPercentage,
+				ConfirmationThreshold: p.QuorumThresholdPercentage,
+			},
+			ChunkLength: uint(p.ChunkLength),
+		}
+	}
+
+	h.BlobCommitments = encoding.BlobCommitments{
+		Commitment: (*encoding.G1Commitment)(&commitment),
+		Length:     uint(s.DataLength),
+	}
+	h.QuorumInfos = quorumInfos
+	return nil
+}
+
+// unpackABI unpacks the ABI encoding of the single struct of args into out
+func unpackABI(args abi.Arguments, data []byte, out any) error {
+	values, err := args.Unpack(data)
+	if err != nil {
+		return err
+	}
+	abi.ConvertType(values[0], out)
+	return nil
+}
+
 func (h *BatchHeader) Serialize() ([]byte, error) {
 	return encode(h)
 }
@@ -414,32 +454,17 @@ func decode(data []byte, obj any) error {
 }
 
 func (s OperatorSocket) GetDispersalSocket() string {
-	ip, port1, _, err := extractIPAndPorts(string(s))
-	if err != nil {
+	host, dispersalPort, _, err := ParseOperatorSocket(string(s))
+	if err != nil || host == "" || dispersalPort == "" {
 		return ""
 	}
-	return fmt.Sprintf("%s:%s", ip, port1)
+	return net.JoinHostPort(host, dispersalPort)
 }
 
 func (s OperatorSocket) GetRetrievalSocket() string {
-	ip, _, port2, err := extractIPAndPorts(string(s))
-	if err != nil {
+	host, _, retrievalPort, err := ParseOperatorSocket(string(s))
+	if err != nil || host == "" || retrievalPort == "" {
 		return ""
 	}
-	return fmt.Sprintf("%s:%s", ip, port2)
-}
-
-func extractIPAndPorts(s string) (string, string, string, error) {
-	regex := regexp.MustCompile(`^([^:]+):([^;]+);([^;]+)$`)
-	matches := regex.FindStringSubmatch(s)
-
-	if len(matches) != 4 {
-		return "", "", "", errors.New("input string does not match expected format")
-	}
-
-	ip := matches[1]
-	port1 := matches[2]
-	port2 := matches[3]
-
-	return ip, port1, port2, nil
+	return net.JoinHostPort(host, retrievalPort)
 }
@@ -0,0 +1,158 @@
+// Package serialization defines the canonical byte encodings of the EigenDA
+// structures that are hashed or signed: the reduced BatchHeader signed by operators,
+// the BatchHeader confirmed onchain, the BlobHeader, and the signatory record
+// of an attestation.
+//
+// All encodings except the signatory record are Solidity ABI encodings of the
+// structs defined in IEigenDAServiceManager.sol, as implemented by the core package,
+// and all hashes are keccak256 of the encoding. Clients in other languages can
+// reproduce every hash by following the same rules; the vectors in
+// testdata/vectors.json pin the expected outputs.
+//
+// Only the encodings which are hashed or signed are canonical. The package defines
+// no JSON encoding of the structures: the JSON of the vectors only describes their
+// inputs, and the JSON returned by the APIs isn't hashed.
+package serialization
+
+import (
+	"bytes"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/crypto"
+)
+
+// signatoryRecordHeaderSize is the size of the big-endian reference block number
+// that prefixes the operator IDs in a signatory record.
+const signatoryRecordHeaderSize = 4
+
+var (
+	ErrInvalidLength        = errors.New("invalid encoding length")
+	ErrNonCanonical         = errors.New("encoding is not canonical")
+	ErrCommitmentNotOnCurve = errors.New("commitment is not on the bn254 curve")
+)
+
+// EncodeBatchHeader returns the canonical encoding of the reduced BatchHeader, which is
+// the message operators sign for a batch.
+func EncodeBatchHeader(h *core.BatchHeader) ([]byte, error) {
+	return h.Encode()
+}
+
+// DecodeBatchHeader is the inverse of EncodeBatchHeader.
+func DecodeBatchHeader(data []byte) (*core.BatchHeader, error) {
+	h := &core.BatchHeader{}
+	if err := h.Decode(data); err != nil {
+		return nil, fmt.Errorf("failed to decode batch header: %w", err)
+	}
+	if err := checkCanonical(data, h.Encode); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// HashBatchHeader returns the keccak256 hash of the canonical encoding of the reduced BatchHeader.
+func HashBatchHeader(h *core.BatchHeader) ([32]byte, error) {
+	return h.GetBatchHeaderHash()
+}
+
+// EncodeOnchainBatchHeader returns the canonical encoding of the BatchHeader submitted in confirmBatch.
+func EncodeOnchainBatchHeader(h binding.IEigenDAServiceManagerBatchHeader) ([]byte, error) {
+	return core.EncodeBatchHeader(h)
+}
+
+// DecodeOnchainBatchHeader is the inverse of EncodeOnchainBatchHeader.
+func DecodeOnchainBatchHeader(data []byte) (binding.IEigenDAServiceManagerBatchHeader, error) {
+	h, err := core.DecodeBatchHeader(data)
+	if err != nil {
+		return binding.IEigenDAServiceManagerBatchHeader{}, fmt.Errorf("failed to decode onchain batch header: %w", err)
+	}
+	err = checkCanonical(data, func() ([]byte, error) { return EncodeOnchainBatchHeader(h) })
+	if err != nil {
+		return binding.IEigenDAServiceManagerBatchHeader{}, err
+	}
+	return h, nil
+}
+
+// HashOnchainBatchHeader returns the keccak256 hash of the canonical encoding of the onchain BatchHeader.
+// This is the hash emitted in the BatchConfirmed event.
+func HashOnchainBatchHeader(h binding.IEigenDAServiceManagerBatchHeader) ([32]byte, error) {
+	return core.HashBatchHeader(h)
+}
+
+// EncodeBlobHeader returns the canonical encoding of the BlobHeader. The quorum params are
+// always encoded in ascending order of quorum ID, regardless of their order in the header.
+func EncodeBlobHeader(h *core.BlobHeader) ([]byte, error) {
+	return h.Encode()
+}
+
+// DecodeBlobHeader is the inverse of EncodeBlobHeader. The length commitment and length proof
+// are not part of the canonical encoding and are left unset on the returned header.
+// The quorum params must be sorted by quorum ID, as produced by EncodeBlobHeader.
+func DecodeBlobHeader(data []byte) (*core.BlobHeader, error) {
+	h := &core.BlobHeader{}
+	if err := h.Decode(data); err != nil {
+		return nil, fmt.Errorf("failed to decode blob header: %w", err)
+	}
+	if !(*bn254.G1Affine)(h.Commitment).IsOnCurve() {
+		return nil, ErrCommitmentNotOnCurve
+	}
+	if err := checkCanonical(data, h.Encode); err != nil {
+		return nil, err
+	}
+	return h, nil
+}
+
+// HashBlobHeader returns the keccak256 hash of the canonical encoding of the BlobHeader.
+func HashBlobHeader(h *core.BlobHeader) ([32]byte, error) {
+	return h.GetBlobHeaderHash()
+}
+
+// EncodeSignatoryRecord returns the canonical encoding of the signatory record of an attestation:
+// the reference block number as a big-endian uint32 followed by the IDs of the non-signing operators.
+func EncodeSignatoryRecord(referenceBlockNumber uint32, nonSignerIDs []core.OperatorID) []byte {
+	buf := make([]byte, signatoryRecordHeaderSize, signatoryRecordHeaderSize+len(nonSignerIDs)*len(core.OperatorID{}))
+	binary.BigEndian.PutUint32(buf, referenceBlockNumber)
+	for _, id := range nonSignerIDs {
+		buf = append(buf, id[:]...)
+	}
+	return buf
+}
+
+// DecodeSignatoryRecord is the inverse of EncodeSignatoryRecord.
+func DecodeSignatoryRecord(data []byte) (uint32, []core.OperatorID, error) {
+	idSize := len(core.OperatorID{})
+	if len(data) < signatoryRecordHeaderSize || (len(data)-signatoryRecordHeaderSize)%idSize != 0 {
+		return 0, nil, fmt.Errorf("%w: signatory record of %d bytes", ErrInvalidLength, len(data))
+	}
+
+	referenceBlockNumber := binary.BigEndian.Uint32(data[:signatoryRecordHeaderSize])
+	ids := make([]core.OperatorID, (len(data)-signatoryRecordHeaderSize)/idSize)
+	for i := range ids {
+		copy(ids[i][:], data[signatoryRecordHeaderSize+i*idSize:])
+	}
+	return referenceBlockNumber, ids, nil
+}
+
+// HashSignatoryRecord returns the keccak256 hash of the canonical encoding of the signatory record.
+// It matches core.ComputeSignatoryRecordHash for the operator IDs of the same non-signer keys.
+func HashSignatoryRecord(referenceBlockNumber uint32, nonSignerIDs []core.OperatorID) [32]byte {
+	return crypto.Keccak256Hash(EncodeSignatoryRecord(referenceBlockNumber, nonSignerIDs))
+}
+
+// checkCanonical rejects inputs that decode successfully but differ from the canonical
+// encoding of the decoded value, e.g. unsorted quorums, out of range field elements or
+// trailing bytes. This keeps decode-then-hash consistent with hashing the input directly.
+func checkCanonical(data []byte, encode func() ([]byte, error)) error {
+	canonical, err := encode()
+	if err != nil {
+		return err
+	}
+	if !bytes.Equal(data, canonical) {
+		return ErrNonCanonical
+	}
+	return nil
+}
@@ -0,0 +1,242 @@
+package serialization_test
+
+import (
+	"encoding/json"
+	"math/big"
+	"os"
+	"testing"
+
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/serialization"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type vectors struct {
+	BatchHeaders []struct {
+		Name                 string `json:"name"`
+		BatchRoot            string `json:"batch_root"`
+		ReferenceBlockNumber uint   `json:"reference_block_number"`
+		Encoded              string `json:"encoded"`
+		Hash                 string `json:"hash"`
+	} `json:"batch_headers"`
+	OnchainBatchHeaders []struct {
+		Name                  string `json:"name"`
+		BatchRoot             string `json:"batch_root"`
+		QuorumNumbers         string `json:"quorum_numbers"`
+		SignedStakeForQuorums string `json:"signed_stake_for_quorums"`
+		ReferenceBlockNumber  uint32 `json:"reference_block_number"`
+		Encoded               string `json:"encoded"`
+		Hash                  string `json:"hash"`
+	} `json:"onchain_batch_headers"`
+	BlobHeaders []struct {
+		Name             string `json:"name"`
+		CommitmentX      string `json:"commitment_x"`
+		CommitmentY      string `json:"commitment_y"`
+		DataLength       uint   `json:"data_length"`
+		QuorumBlobParams []struct {
+			QuorumNumber                    uint8 `json:"quorum_number"`
+			AdversaryThresholdPercentage    uint8 `json:"adversary_threshold_percentage"`
+			ConfirmationThresholdPercentage uint8 `json:"confirmation_threshold_percentage"`
+			ChunkLength                     uint  `json:"chunk_length"`
+		} `json:"quorum_blob_params"`
+		Encoded string `json:"encoded"`
+		Hash    string `json:"hash"`
+	} `json:"blob_headers"`
+	SignatoryRecords []struct {
+		Name                 string   `json:"name"`
+		ReferenceBlockNumber uint32   `json:"reference_block_number"`
+		NonSignerIDs         []string `json:"non_signer_ids"`
+		Encoded              string   `json:"encoded"`
+		Hash                 string   `json:"hash"`
+	} `json:"signatory_records"`
+}
+
+func loadVectors(t *testing.T) *vectors {
+	data, err := os.ReadFile("testdata/vectors.json")
+	require.NoError(t, err)
+	var v vectors
+	require.NoError(t, json.Unmarshal(data, &v))
+	return &v
+}
+
+func decodeHex32(t *testing.T, s string) [32]byte {
+	var res [32]byte
+	b := hexutil.MustDecode(s)
+	require.Len(t, b, 32)
+	copy(res[:], b)
+	return res
+}
+
+func TestBatchHeaderVectors(t *testing.T) {
+	for _, v := range loadVectors(t).BatchHeaders {
+		t.Run(v.Name, func(t *testing.T) {
+			h := &core.BatchHeader{
+				BatchRoot:            decodeHex32(t, v.BatchRoot),
+				ReferenceBlockNumber: v.ReferenceBlockNumber,
+			}
+			encoded, err := serialization.EncodeBatchHeader(h)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Encoded, hexutil.Encode(encoded))
+
+			hash, err := serialization.HashBatchHeader(h)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Hash, hexutil.Encode(hash[:]))
+
+			decoded, err := serialization.DecodeBatchHeader(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, h, decoded)
+		})
+	}
+}
+
+func TestOnchainBatchHeaderVectors(t *testing.T) {
+	for _, v := range loadVectors(t).OnchainBatchHeaders {
+		t.Run(v.Name, func(t *testing.T) {
+			h := binding.IEigenDAServiceManagerBatchHeader{
+				BlobHeadersRoot:       decodeHex32(t, v.BatchRoot),
+				QuorumNumbers:         hexutil.MustDecode(v.QuorumNumbers),
+				SignedStakeForQuorums: hexutil.MustDecode(v.SignedStakeForQuorums),
+				ReferenceBlockNumber:  v.ReferenceBlockNumber,
+			}
+			encoded, err := serialization.EncodeOnchainBatchHeader(h)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Encoded, hexutil.Encode(encoded))
+
+			hash, err := serialization.HashOnchainBatchHeader(h)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Hash, hexutil.Encode(hash[:]))
+
+			decoded, err := serialization.DecodeOnchainBatchHeader(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, h, decoded)
+		})
+	}
+}
+
+func TestBlobHeaderVectors(t *testing.T) {
+	for _, v := range loadVectors(t).BlobHeaders {
+		t.Run(v.Name, func(t *testing.T) {
+			var commitment bn254.G1Affine
+			_, err := commitment.X.SetString(v.CommitmentX)
+			require.NoError(t, err)
+			_, err = commitment.Y.SetString(v.CommitmentY)
+			require.NoError(t, err)
+
+			quorumInfos := make([]*core.BlobQuorumInfo, len(v.QuorumBlobParams))
+			for i, p := range v.QuorumBlobParams {
+				quorumInfos[i] = &core.BlobQuorumInfo{
+					SecurityParam: core.SecurityParam{
+						QuorumID:              p.QuorumNumber,
+						AdversaryThreshold:    p.AdversaryThresholdPercentage,
+						ConfirmationThreshold: p.ConfirmationThresholdPercentage,
+					},
+					ChunkLength: p.ChunkLength,
+				}
+			}
+			h := &core.BlobHeader{
+				BlobCommitments: encoding.BlobCommitments{
+					Commitment: (*encoding.G1Commitment)(&commitment),
+					Length:     v.DataLength,
+				},
+				QuorumInfos: quorumInfos,
+			}
+
+			encoded, err := serialization.EncodeBlobHeader(h)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Encoded, hexutil.Encode(encoded))
+
+			hash, err := serialization.HashBlobHeader(h)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Hash, hexutil.Encode(hash[:]))
+
+			decoded, err := serialization.DecodeBlobHeader(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, h, decoded)
+
+			// The encoding does not depend on the order of the quorums in the header
+			reversed := *h
+			reversed.QuorumInfos = make([]*core.BlobQuorumInfo, len(quorumInfos))
+			for i, q := range quorumInfos {
+				reversed.QuorumInfos[len(quorumInfos)-1-i] = q
+			}
+			encoded, err = serialization.EncodeBlobHeader(&reversed)
+			assert.NoError(t, err)
+			assert.Equal(t, v.Encoded, hexutil.Encode(encoded))
+		})
+	}
+}
+
+func TestSignatoryRecordVectors(t *testing.T) {
+	for _, v := range loadVectors(t).SignatoryRecords {
+		t.Run(v.Name, func(t *testing.T) {
+			ids := make([]core.OperatorID, len(v.NonSignerIDs))
+			for i, id := range v.NonSignerIDs {
+				ids[i] = decodeHex32(t, id)
+			}
+
+			encoded := serialization.EncodeSignatoryRecord(v.ReferenceBlockNumber, ids)
+			assert.Equal(t, v.Encoded, hexutil.Encode(encoded))
+
+			hash := serialization.HashSignatoryRecord(v.ReferenceBlockNumber, ids)
+			assert.Equal(t, v.Hash, hexutil.Encode(hash[:]))
+
+			referenceBlockNumber, decoded, err := serialization.DecodeSignatoryRecord(encoded)
+			assert.NoError(t, err)
+			assert.Equal(t, v.ReferenceBlockNumber, referenceBlockNumber)
+			assert.Equal(t, ids, decoded)
+		})
+	}
+}
+
+func TestSignatoryRecordMatchesCore(t *testing.T) {
+	_, _, g1, _ := bn254.Generators()
+	var k2 bn254.G1Affine
+	k2.ScalarMultiplication(&g1, big.NewInt(7))
+	keys := []*core.G1Point{{G1Affine: &g1}, {G1Affine: &k2}}
+
+	ids := make([]core.OperatorID, len(keys))
+	for i, k := range keys {
+		ids[i] = k.GetOperatorID()
+	}
+	assert.Equal(t, core.ComputeSignatoryRecordHash(42, keys), serialization.HashSignatoryRecord(42, ids))
+}
+
+func TestDecodeRejectsNonCanonical(t *testing.T) {
+	v := loadVectors(t)
+
+	// Trailing bytes
+	encoded := append(hexutil.MustDecode(v.BatchHeaders[0].Encoded), 0)
+	_, err := serialization.DecodeBatchHeader(encoded)
+	assert.Error(t, err)
+
+	// Quorum params out of order
+	var twoQuorums []byte
+	for _, b := range v.BlobHeaders {
+		if len(b.QuorumBlobParams) == 2 {
+			twoQuorums = hexutil.MustDecode(b.Encoded)
+		}
+	}
+	require.NotNil(t, twoQuorums)
+	swapped := make([]byte, len(twoQuorums))
+	copy(swapped, twoQuorums)
+	first := len(swapped) - 8*32
+	copy(swapped[first:first+4*32], twoQuorums[first+4*32:])
+	copy(swapped[first+4*32:], twoQuorums[first:first+4*32])
+	_, err = serialization.DecodeBlobHeader(swapped)
+	assert.ErrorIs(t, err, serialization.ErrNonCanonical)
+
+	// Commitment not on the curve
+	offCurve := hexutil.MustDecode(v.BlobHeaders[0].Encoded)
+	offCurve[2*32-1] ^= 1
+	_, err = serialization.DecodeBlobHeader(offCurve)
+	assert.ErrorIs(t, err, serialization.ErrCommitmentNotOnCurve)
+
+	// Truncated signatory record
+	_, _, err = serialization.DecodeSignatoryRecord(hexutil.MustDecode(v.SignatoryRecords[1].Encoded)[:40])
+	assert.ErrorIs(t, err, serialization.ErrInvalidLength)
+}
@@ -211,6 +211,41 @@ func TestParseOperatorSocket(t *testing.T) {
 	assert.Equal(t, "invalid socket address format: localhost1234;5678", err.Error())
 }
 
+func TestParseOperatorSocketIPv6AndDNS(t *testing.T) {
+	socket := core.MakeOperatorSocket("2001:db8::1", "32005", "32004")
+	assert.Equal(t, core.OperatorSocket("[2001:db8::1]:32005;32004"), socket)
+	assert.Equal(t, socket, core.MakeOperatorSocket("[2001:db8::1]", "32005", "32004"))
+	host, dispersalPort, retrievalPort, err := core.ParseOperatorSocket(string(socket))
+	assert.NoError(t, err)
+	assert.Equal(t, "2001:db8::1", host)
+	assert.Equal(t, "32005", dispersalPort)
+	assert.Equal(t, "32004", retrievalPort)
+	assert.Equal(t, "[2001:db8::1]:32005", socket.GetDispersalSocket())
+	assert.Equal(t, "[2001:db8::1]:32004", socket.GetRetrievalSocket())
+	assert.NoError(t, core.ValidateOperatorSocket(string(socket)))
+
+	socket = core.MakeOperatorSocket("node-1.operator.example.com", "32005", "32004")
+	assert.Equal(t, "node-1.operator.example.com:32005", socket.GetDispersalSocket())
+	assert.Equal(t, "node-1.operator.example.com:32004", socket.GetRetrievalSocket())
+	assert.NoError(t, core.ValidateOperatorSocket(string(socket)))
+	assert.NoError(t, core.ValidateOperatorSocket("127.0.0.1:32005;32004"))
+
+	for _, invalid := range []string{
+		"2001:db8::1:32005;32004",
+		"[2001:db8::1]:32005",
+		"-node.example.com:32005;32004",
+		"node_1.example.com:32005;32004",
+		"node..example.com:32005;32004",
+		"node.example.com:0;32004",
+		"node.example.com:32005;65536",
+		"node.example.com:http;32004",
+		":32005;32004",
+	} {
+		assert.Error(t, core.ValidateOperatorSocket(invalid), invalid)
+	}
+	assert.Equal(t, "", core.OperatorSocket("2001:db8::1:32005;32004").GetDispersalSocket())
+}
+
 func TestSignatureBytes(t *testing.T) {
 	sig := &core.Signature{
 		G1Point: core.NewG1Point(big.NewInt(1), big.NewInt(2)),
@@ -6,7 +6,9 @@ import (
 	"encoding/json"
 	"fmt"
 	"math/big"
+	"net"
 	"slices"
+	"strconv"
 	"strings"
 )
 
@@ -18,12 +20,17 @@ func (s OperatorSocket) String() string {
 	return string(s)
 }
 
+// MakeOperatorSocket returns the socket of an operator available at the host, an IPv4 or IPv6 address or a DNS name,
+// on the dispersal and retrieval ports. IPv6 addresses are bracketed, e.g. "[2001:db8::1]:32005;32004".
 func MakeOperatorSocket(nodeIP, dispersalPort, retrievalPort string) OperatorSocket {
-	return OperatorSocket(fmt.Sprintf("%s:%s;%s", nodeIP, dispersalPort, retrievalPort))
+	host := strings.TrimSuffix(strings.TrimPrefix(nodeIP, "["), "]")
+	return OperatorSocket(fmt.Sprintf("%s;%s", net.JoinHostPort(host, dispersalPort), retrievalPort))
 }
 
 type StakeAmount = *big.Int
 
+// ParseOperatorSocket parses a socket in the form "host:dispersalPort;retrievalPort". The host of a bracketed IPv6
+// address is returned without the brackets.
 func ParseOperatorSocket(socket string) (host string, dispersalPort string, retrievalPort string, err error) {
 	s := strings.Split(socket, ";")
 	if len(s) != 2 {
@@ -32,17 +39,55 @@ func ParseOperatorSocket(socket string) (host string, dispersalPort string, retr
 	}
 	retrievalPort = s[1]
 
-	s = strings.Split(s[0], ":")
-	if len(s) != 2 {
+	host, dispersalPort, err = net.SplitHostPort(s[0])
+	if err != nil {
 		err = fmt.Errorf("invalid socket address format: %s", socket)
 		return
 	}
-	host = s[0]
-	dispersalPort = s[1]
 
 	return
 }
 
+// ValidateOperatorSocket checks that the socket is in the form "host:dispersalPort;retrievalPort", where the host is an
+// IPv4 address, a bracketed IPv6 address or a DNS name, and the ports are valid TCP ports.
+func ValidateOperatorSocket(socket string) error {
+	host, dispersalPort, retrievalPort, err := ParseOperatorSocket(socket)
+	if err != nil {
+		return err
+	}
+	if net.ParseIP(host) == nil && !isDNSName(host) {
+		return fmt.Errorf("invalid socket host %q: must be an IP address or a DNS name", host)
+	}
+	if strings.Contains(host, ":") && !strings.HasPrefix(socket, "[") {
+		return fmt.Errorf("invalid socket host %q: IPv6 addresses must be bracketed", host)
+	}
+	for _, port := range []string{dispersalPort, retrievalPort} {
+		if p, err := strconv.ParseUint(port, 10, 16); err != nil || p == 0 {
+			return fmt.Errorf("invalid socket port %q", port)
+		}
+	}
+	return nil
+}
+
+// isDNSName returns whether the name is a valid DNS name, made of labels of letters, digits and hyphens
+func isDNSName(name string) bool {
+	name = strings.TrimSuffix(name, ".")
+	if len(name) == 0 || len(name) > 253 {
+		return false
+	}
+	for _, label := range strings.Split(name, ".") {
+		if len(label) == 0 || len(label) > 63 || label[0] == '-' || label[len(label)-1] == '-' {
+			return false
+		}
+		for _, c := range label {
+			if !(c >= 'a' && c <= 'z' || c >= 'A' && c <= 'Z' || c >= '0' && c <= '9' || c == '-') {
+				return false
+			}
+		}
+	}
+	return true
+}
+
 // OperatorInfo contains information about an operator which is stored on the blockchain state,
 // corresponding to a particular quorum
 type OperatorInfo struct {
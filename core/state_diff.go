@@ -0,0 +1,150 @@
+package core
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"slices"
+)
+
+// StakeChange is the stake of an operator in a quorum before and after a range of blocks
+type StakeChange struct {
+	Before StakeAmount
+	After  StakeAmount
+}
+
+// SocketChange is the socket of an operator before and after a range of blocks
+type SocketChange struct {
+	Before string
+	After  string
+}
+
+// QuorumStateDiff is the difference of the operator set of a quorum between two blocks
+type QuorumStateDiff struct {
+	// Registered are the operators that are in the quorum at the later block but not at the earlier block
+	Registered []OperatorID
+	// Deregistered are the operators that are in the quorum at the earlier block but not at the later block
+	Deregistered []OperatorID
+	// StakeChanges are the stake changes of the operators that are in the quorum at both blocks
+	StakeChanges map[OperatorID]*StakeChange
+}
+
+func (d *QuorumStateDiff) IsEmpty() bool {
+	return len(d.Registered) == 0 && len(d.Deregistered) == 0 && len(d.StakeChanges) == 0
+}
+
+// OperatorStateDiff is the difference of the operator state between two blocks
+type OperatorStateDiff struct {
+	FromBlock uint
+	ToBlock   uint
+	// Quorums is a map from quorum ID to the difference of the operators in that quorum.
+	// It contains every quorum present at either block, including the unchanged ones.
+	Quorums map[QuorumID]*QuorumStateDiff
+	// SocketChanges are the socket changes of the operators that are registered at both blocks.
+	// Sockets do not depend on the quorum. This is only populated when diffing indexed states.
+	SocketChanges map[OperatorID]*SocketChange
+}
+
+// IsEmpty returns true if the operator state did not change between the two blocks
+func (d *OperatorStateDiff) IsEmpty() bool {
+	for _, quorumDiff := range d.Quorums {
+		if !quorumDiff.IsEmpty() {
+			return false
+		}
+	}
+	return len(d.SocketChanges) == 0
+}
+
+// DiffOperatorStates returns the difference from one operator state to another
+func DiffOperatorStates(from, to *OperatorState) *OperatorStateDiff {
+	diff := &OperatorStateDiff{
+		FromBlock:     from.BlockNumber,
+		ToBlock:       to.BlockNumber,
+		Quorums:       make(map[QuorumID]*QuorumStateDiff),
+		SocketChanges: make(map[OperatorID]*SocketChange),
+	}
+
+	quorums := make(map[QuorumID]struct{})
+	for quorumID := range from.Operators {
+		quorums[quorumID] = struct{}{}
+	}
+	for quorumID := range to.Operators {
+		quorums[quorumID] = struct{}{}
+	}
+
+	for quorumID := range quorums {
+		fromOperators := from.Operators[quorumID]
+		toOperators := to.Operators[quorumID]
+		quorumDiff := &QuorumStateDiff{
+			Registered:   make([]OperatorID, 0),
+			Deregistered: make([]OperatorID, 0),
+			StakeChanges: make(map[OperatorID]*StakeChange),
+		}
+
+		for opID, toInfo := range toOperators {
+			fromInfo, ok := fromOperators[opID]
+			if !ok {
+				quorumDiff.Registered = append(quorumDiff.Registered, opID)
+				continue
+			}
+			if fromInfo.Stake.Cmp(toInfo.Stake) != 0 {
+				quorumDiff.StakeChanges[opID] = &StakeChange{
+					Before: fromInfo.Stake,
+					After:  toInfo.Stake,
+				}
+			}
+		}
+		for opID := range fromOperators {
+			if _, ok := toOperators[opID]; !ok {
+				quorumDiff.Deregistered = append(quorumDiff.Deregistered, opID)
+			}
+		}
+
+		sortOperatorIDs(quorumDiff.Registered)
+		sortOperatorIDs(quorumDiff.Deregistered)
+		diff.Quorums[quorumID] = quorumDiff
+	}
+
+	return diff
+}
+
+// DiffIndexedOperatorStates returns the difference from one indexed operator state to another,
+// including the socket changes of the operators.
+func DiffIndexedOperatorStates(from, to *IndexedOperatorState) *OperatorStateDiff {
+	diff := DiffOperatorStates(from.OperatorState, to.OperatorState)
+	for opID, toInfo := range to.IndexedOperators {
+		fromInfo, ok := from.IndexedOperators[opID]
+		if !ok || fromInfo.Socket == toInfo.Socket {
+			continue
+		}
+		diff.SocketChanges[opID] = &SocketChange{
+			Before: fromInfo.Socket,
+			After:  toInfo.Socket,
+		}
+	}
+	return diff
+}
+
+// GetOperatorStateDiff returns the difference of the operator state of the given quorums between two blocks
+func GetOperatorStateDiff(ctx context.Context, chainState IndexedChainState, fromBlock, toBlock uint, quorums []QuorumID) (*OperatorStateDiff, error) {
+	if fromBlock > toBlock {
+		return nil, fmt.Errorf("from block %d is after to block %d", fromBlock, toBlock)
+	}
+
+	from, err := chainState.GetIndexedOperatorState(ctx, fromBlock, quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", fromBlock, err)
+	}
+	to, err := chainState.GetIndexedOperatorState(ctx, toBlock, quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", toBlock, err)
+	}
+
+	return DiffIndexedOperatorStates(from, to), nil
+}
+
+func sortOperatorIDs(ids []OperatorID) {
+	slices.SortFunc(ids, func(a, b OperatorID) int {
+		return bytes.Compare(a[:], b[:])
+	})
+}
@@ -96,3 +96,71 @@ func TestOperatorStateHash(t *testing.T) {
 	assert.Equal(t, "1836448b57ae79decdcb77157cf31698", hex.EncodeToString(q0[:]))
 	assert.Equal(t, "2f110a29f2bdd8a19c2d87d05736be0a", hex.EncodeToString(q1[:]))
 }
+
+func TestDiffIndexedOperatorStates(t *testing.T) {
+	op0, op1, op2, op3 := core.OperatorID{0}, core.OperatorID{1}, core.OperatorID{2}, core.OperatorID{3}
+	from := &core.IndexedOperatorState{
+		OperatorState: &core.OperatorState{
+			Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+				0: {
+					op0: {Stake: big.NewInt(10), Index: 0},
+					op1: {Stake: big.NewInt(20), Index: 1},
+				},
+				1: {
+					op1: {Stake: big.NewInt(5), Index: 0},
+				},
+			},
+			BlockNumber: 100,
+		},
+		IndexedOperators: map[core.OperatorID]*core.IndexedOperatorInfo{
+			op0: {Socket: "op0:32005;32004"},
+			op1: {Socket: "op1:32005;32004"},
+		},
+	}
+	to := &core.IndexedOperatorState{
+		OperatorState: &core.OperatorState{
+			Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+				0: {
+					op1: {Stake: big.NewInt(25), Index: 0},
+					op3: {Stake: big.NewInt(1), Index: 1},
+					op2: {Stake: big.NewInt(1), Index: 2},
+				},
+				1: {
+					op1: {Stake: big.NewInt(5), Index: 0},
+				},
+				2: {
+					op2: {Stake: big.NewInt(7), Index: 0},
+				},
+			},
+			BlockNumber: 200,
+		},
+		IndexedOperators: map[core.OperatorID]*core.IndexedOperatorInfo{
+			op1: {Socket: "op1.new:32005;32004"},
+			op2: {Socket: "op2:32005;32004"},
+			op3: {Socket: "op3:32005;32004"},
+		},
+	}
+
+	diff := core.DiffIndexedOperatorStates(from, to)
+	assert.False(t, diff.IsEmpty())
+	assert.Equal(t, uint(100), diff.FromBlock)
+	assert.Equal(t, uint(200), diff.ToBlock)
+	assert.Len(t, diff.Quorums, 3)
+
+	assert.Equal(t, []core.OperatorID{op2, op3}, diff.Quorums[0].Registered)
+	assert.Equal(t, []core.OperatorID{op0}, diff.Quorums[0].Deregistered)
+	assert.Equal(t, map[core.OperatorID]*core.StakeChange{
+		op1: {Before: big.NewInt(20), After: big.NewInt(25)},
+	}, diff.Quorums[0].StakeChanges)
+
+	assert.True(t, diff.Quorums[1].IsEmpty())
+
+	assert.Equal(t, []core.OperatorID{op2}, diff.Quorums[2].Registered)
+	assert.Empty(t, diff.Quorums[2].Deregistered)
+
+	assert.Equal(t, map[core.OperatorID]*core.SocketChange{
+		op1: {Before: "op1:32005;32004", After: "op1.new:32005;32004"},
+	}, diff.SocketChanges)
+
+	assert.True(t, core.DiffIndexedOperatorStates(to, to).IsEmpty())
+}
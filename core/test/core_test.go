@@ -261,8 +261,6 @@ func TestValidationSucceeds(t *testing.T) {
 
 	bn := uint(0)
 
-	pool := workerpool.New(1)
-
 	for _, operatorCount := range operatorCounts {
 
 		// batch can only be tested per operatorCount, because the assignment would be wrong otherwise
@@ -275,10 +273,15 @@ func TestValidationSucceeds(t *testing.T) {
 
 		blobMessages, header, cst := prepareBatch(t, operatorCount, blobs, bn)
 
-		t.Run(fmt.Sprintf("universal verifier operatorCount=%v over %v blobs", operatorCount, len(blobs)), func(t *testing.T) {
-			err := checkBatchByUniversalVerifier(cst, blobMessages, header, pool)
-			assert.NoError(t, err)
-		})
+		// The blobs encoded alike are split across the workers of the larger pools
+		for _, poolSize := range []int{1, 4} {
+			pool := workerpool.New(poolSize)
+			t.Run(fmt.Sprintf("universal verifier operatorCount=%v over %v blobs with %v workers", operatorCount, len(blobs), poolSize), func(t *testing.T) {
+				err := checkBatchByUniversalVerifier(cst, blobMessages, header, pool)
+				assert.NoError(t, err)
+			})
+			pool.StopWait()
+		}
 
 	}
 
@@ -335,3 +338,58 @@ func TestImproperBatchHeader(t *testing.T) {
 	assert.Error(t, err)
 
 }
+
+func TestValidationFailsOnSwappedChunks(t *testing.T) {
+
+	operatorCount := uint(4)
+
+	numBlob := 8
+	blobLength := 64
+
+	securityParams := []*core.SecurityParam{
+		{
+			QuorumID:              0,
+			AdversaryThreshold:    50,
+			ConfirmationThreshold: 100,
+		},
+	}
+
+	bn := uint(0)
+
+	blobs := make([]core.Blob, 0, numBlob)
+	for i := 0; i < numBlob; i++ {
+		blobs = append(blobs, makeTestBlob(t, blobLength, securityParams))
+	}
+	encodedBlobs, header, cst := prepareBatch(t, operatorCount, blobs, bn)
+
+	state, err := cst.GetIndexedOperatorState(context.Background(), header.ReferenceBlockNumber, []core.QuorumID{0})
+	assert.NoError(t, err)
+	var operatorID core.OperatorID
+	for id := range state.IndexedOperators {
+		operatorID = id
+		break
+	}
+	val := core.NewShardValidator(v, asn, cst, operatorID)
+
+	blobMessages := make([]*core.BlobMessage, numBlob)
+	for z, encodedBlob := range encodedBlobs {
+		bundles, err := new(core.Bundles).FromEncodedBundles(encodedBlob.EncodedBundlesByOperator[operatorID])
+		assert.NoError(t, err)
+		blobMessages[z] = &core.BlobMessage{
+			BlobHeader: encodedBlob.BlobHeader,
+			Bundles:    bundles,
+		}
+	}
+
+	// The chunks of the last two blobs are swapped, which only the worker verifying the last sub batch catches
+	last := numBlob - 1
+	blobMessages[last].Bundles, blobMessages[last-1].Bundles = blobMessages[last-1].Bundles, blobMessages[last].Bundles
+
+	for _, poolSize := range []int{1, 4} {
+		pool := workerpool.New(poolSize)
+		err = val.ValidateBatch(&header, blobMessages, state.OperatorState, pool)
+		assert.Error(t, err)
+		pool.StopWait()
+	}
+
+}
@@ -0,0 +1,149 @@
+package thegraph
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// The kinds of discrepancies between the operator state indexed by the subgraph and the one read onchain
+const (
+	// MissingInSubgraph is reported for an operator registered onchain in a quorum but not in the subgraph
+	MissingInSubgraph = "missing_in_subgraph"
+	// MissingOnchain is reported for an operator registered in the subgraph but in none of the quorums onchain
+	MissingOnchain = "missing_onchain"
+	// OperatorIdMismatch is reported for an operator whose ID in the subgraph isn't the hash of its G1 public key
+	OperatorIdMismatch = "operator_id_mismatch"
+	// QuorumApkMismatch is reported for a quorum whose aggregate public key in the subgraph isn't the sum of the G1
+	// public keys of the operators registered onchain in the quorum
+	QuorumApkMismatch = "quorum_apk_mismatch"
+	// QuorumApkMissing is reported for a quorum without aggregate public key in the subgraph
+	QuorumApkMissing = "quorum_apk_missing"
+)
+
+// StateDiscrepancy is a difference between the operator state indexed by the subgraph and the one read onchain
+type StateDiscrepancy struct {
+	Kind string
+	// Quorum is nil for the discrepancies that aren't specific to a quorum
+	Quorum *core.QuorumID
+	// OperatorId is nil for the discrepancies that aren't specific to an operator
+	OperatorId *core.OperatorID
+	Detail     string
+}
+
+// ConsistencyReport is the result of the comparison of the operator state indexed by the subgraph and the one read
+// onchain at the same block
+type ConsistencyReport struct {
+	BlockNumber uint
+	// OnchainState is the operator state read onchain, with the stakes of the operators
+	OnchainState *core.OperatorState
+	// NumSubgraphOperators is the number of operators registered in the subgraph
+	NumSubgraphOperators int
+	Discrepancies        []*StateDiscrepancy
+}
+
+// CheckConsistency compares the operators registered in the quorums at the block number, as indexed by the subgraph
+// and as read onchain. The subgraph doesn't index the stakes, which are only read onchain, so the quorum membership is
+// cross-checked through the quorum aggregate public keys indexed by the subgraph instead. The subgraph doesn't index
+// the quorums of the operators either, so the operators registered only in other quorums are reported as missing
+// onchain: all the quorums should be checked.
+func (ics *indexedChainState) CheckConsistency(ctx context.Context, blockNumber uint, quorums []core.QuorumID) (*ConsistencyReport, error) {
+	onchainState, err := ics.ChainState.GetOperatorState(ctx, blockNumber, quorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the operator state onchain: %w", err)
+	}
+	indexedOperators, err := ics.getRegisteredIndexedOperatorInfo(ctx, uint32(blockNumber))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the operators from the subgraph: %w", err)
+	}
+
+	report := &ConsistencyReport{
+		BlockNumber:          blockNumber,
+		OnchainState:         onchainState,
+		NumSubgraphOperators: len(indexedOperators),
+		Discrepancies:        make([]*StateDiscrepancy, 0),
+	}
+
+	for operatorId, info := range indexedOperators {
+		if info.PubkeyG1 != nil && info.PubkeyG1.GetOperatorID() != operatorId {
+			report.add(OperatorIdMismatch, nil, &operatorId, fmt.Sprintf("the G1 public key hashes to %s", info.PubkeyG1.GetOperatorID().Hex()))
+		}
+	}
+
+	onchainOperators := make(map[core.OperatorID]struct{})
+	apks := ics.getQuorumAPKs(ctx, quorums, uint32(blockNumber))
+	for _, quorum := range quorums {
+		quorumOperators := onchainState.Operators[quorum]
+		apk := core.NewG1Point(big.NewInt(0), big.NewInt(0))
+		complete := true
+		for operatorId := range quorumOperators {
+			onchainOperators[operatorId] = struct{}{}
+			info, ok := indexedOperators[operatorId]
+			if !ok || info.PubkeyG1 == nil {
+				report.add(MissingInSubgraph, &quorum, &operatorId, "")
+				complete = false
+				continue
+			}
+			apk.Add(info.PubkeyG1)
+		}
+
+		indexedApk, ok := apks[quorum]
+		if !ok || indexedApk.Err != nil || indexedApk.AggregatePubk == nil {
+			report.add(QuorumApkMissing, &quorum, nil, "")
+			continue
+		}
+		// The aggregate public key can't be recomputed if public keys are missing, which is already reported
+		if complete && !apk.Equal(indexedApk.AggregatePubk.G1Affine) {
+			report.add(QuorumApkMismatch, &quorum, nil, fmt.Sprintf("%d operators registered onchain", len(quorumOperators)))
+		}
+	}
+
+	for operatorId := range indexedOperators {
+		if _, ok := onchainOperators[operatorId]; !ok {
+			report.add(MissingOnchain, nil, &operatorId, "")
+		}
+	}
+
+	sort.Slice(report.Discrepancies, func(i, j int) bool {
+		return report.Discrepancies[i].less(report.Discrepancies[j])
+	})
+	return report, nil
+}
+
+// add records a discrepancy, which isn't specific to a quorum or an operator if they are nil
+func (r *ConsistencyReport) add(kind string, quorum *core.QuorumID, operatorId *core.OperatorID, detail string) {
+	discrepancy := &StateDiscrepancy{
+		Kind:   kind,
+		Detail: detail,
+	}
+	if quorum != nil {
+		q := *quorum
+		discrepancy.Quorum = &q
+	}
+	if operatorId != nil {
+		id := *operatorId
+		discrepancy.OperatorId = &id
+	}
+	r.Discrepancies = append(r.Discrepancies, discrepancy)
+}
+
+// less orders the discrepancies by kind, quorum and operator ID
+func (d *StateDiscrepancy) less(other *StateDiscrepancy) bool {
+	if d.Kind != other.Kind {
+		return d.Kind < other.Kind
+	}
+	if (d.Quorum == nil) != (other.Quorum == nil) {
+		return d.Quorum == nil
+	}
+	if d.Quorum != nil && *d.Quorum != *other.Quorum {
+		return *d.Quorum < *other.Quorum
+	}
+	if d.OperatorId == nil || other.OperatorId == nil {
+		return d.OperatorId == nil && other.OperatorId != nil
+	}
+	return bytes.Compare(d.OperatorId[:], other.OperatorId[:]) < 0
+}
@@ -0,0 +1,90 @@
+package thegraph_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/shurcooL/graphql"
+	"github.com/stretchr/testify/assert"
+)
+
+func indexedOperatorGql(id core.OperatorID, keyPair *core.KeyPair) thegraph.IndexedOperatorInfoGql {
+	g1 := keyPair.GetPubKeyG1()
+	g2 := keyPair.GetPubKeyG2()
+	return thegraph.IndexedOperatorInfoGql{
+		Id:            graphql.String("0x" + id.Hex()),
+		PubkeyG1_X:    graphql.String(g1.X.String()),
+		PubkeyG1_Y:    graphql.String(g1.Y.String()),
+		PubkeyG2_X:    []graphql.String{graphql.String(g2.X.A0.String()), graphql.String(g2.X.A1.String())},
+		PubkeyG2_Y:    []graphql.String{graphql.String(g2.Y.A0.String()), graphql.String(g2.Y.A1.String())},
+		SocketUpdates: []thegraph.SocketUpdates{{Socket: "localhost:32006;32007"}},
+	}
+}
+
+func TestIndexedChainState_CheckConsistency(t *testing.T) {
+	chainState, err := mock.MakeChainDataMock(map[uint8]int{
+		0: 2,
+		1: 2,
+	})
+	assert.NoError(t, err)
+	operator0, operator1 := mock.MakeOperatorId(0), mock.MakeOperatorId(1)
+	extraKeyPair, err := core.GenRandomBlsKeys()
+	assert.NoError(t, err)
+	extraOperator := extraKeyPair.GetPubKeyG1().GetOperatorID()
+
+	// Quorum 0 is consistent, the aggregate public key of quorum 1 misses operator 1, and the extra operator isn't
+	// registered onchain
+	apks := map[graphql.Int]*core.G1Point{
+		0: chainState.KeyPairs[operator0].GetPubKeyG1().Clone(),
+		1: chainState.KeyPairs[operator0].GetPubKeyG1().Clone(),
+	}
+	apks[0].Add(chainState.KeyPairs[operator1].GetPubKeyG1())
+	operatorsQueried := false
+	querier := &mockGraphQLQuerier{}
+	querier.QueryFn = func(ctx context.Context, q any, variables map[string]any) error {
+		switch res := q.(type) {
+		case *thegraph.QueryQuorumAPKGql:
+			apk := apks[variables["quorumNumber"].(graphql.Int)]
+			res.QuorumAPK = append(res.QuorumAPK, thegraph.AggregatePubkeyKeyGql{
+				Apk_X: graphql.String(apk.X.String()),
+				Apk_Y: graphql.String(apk.Y.String()),
+			})
+		case *thegraph.QueryOperatorsGql:
+			if operatorsQueried {
+				return nil
+			}
+			res.Operators = []thegraph.IndexedOperatorInfoGql{
+				indexedOperatorGql(operator0, chainState.KeyPairs[operator0]),
+				indexedOperatorGql(operator1, chainState.KeyPairs[operator1]),
+				indexedOperatorGql(extraOperator, extraKeyPair),
+			}
+			operatorsQueried = true
+		}
+		return nil
+	}
+
+	cs := thegraph.NewIndexedChainState(chainState, querier, logging.NewNoopLogger())
+	report, err := cs.CheckConsistency(context.Background(), 1, []core.QuorumID{0, 1})
+	assert.NoError(t, err)
+	assert.Equal(t, 3, report.NumSubgraphOperators)
+	assert.Len(t, report.OnchainState.Operators[0], 2)
+
+	// The IDs of the mock operators aren't the hashes of their public keys
+	discrepancies := make([]*thegraph.StateDiscrepancy, 0)
+	for _, discrepancy := range report.Discrepancies {
+		if discrepancy.Kind != thegraph.OperatorIdMismatch {
+			discrepancies = append(discrepancies, discrepancy)
+		} else {
+			assert.NotEqual(t, extraOperator, *discrepancy.OperatorId)
+		}
+	}
+	assert.Len(t, discrepancies, 2)
+	assert.Equal(t, thegraph.MissingOnchain, discrepancies[0].Kind)
+	assert.Equal(t, extraOperator, *discrepancies[0].OperatorId)
+	assert.Equal(t, thegraph.QuorumApkMismatch, discrepancies[1].Kind)
+	assert.Equal(t, core.QuorumID(1), *discrepancies[1].Quorum)
+}
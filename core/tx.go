@@ -29,6 +29,14 @@ type OperatorSetParam struct {
 
 type OperatorStakes map[QuorumID]map[OperatorIndex]OperatorStake
 
+// BatchConfirmation is the onchain confirmation of a batch
+type BatchConfirmation struct {
+	BatchID uint32
+	// TxnHash is the hash of the transaction which confirmed the batch
+	TxnHash     gethcommon.Hash
+	BlockNumber uint32
+}
+
 type Transactor interface {
 
 	// GetRegisteredQuorumIdsForOperator returns the quorum ids that the operator is registered in with the given public key.
@@ -91,6 +99,10 @@ type Transactor interface {
 	// specified in the batch header. If the signature aggregation does not satisfy the quorum thresholds, the transaction will fail.
 	ConfirmBatch(ctx context.Context, batchHeader *BatchHeader, quorums map[QuorumID]*QuorumResult, signatureAggregation *SignatureAggregation) (*types.Receipt, error)
 
+	// GetBatchConfirmation returns the confirmation of the batch with the given batch header hash, searching from the given block
+	// number onwards. It returns nil if the batch hasn't been confirmed.
+	GetBatchConfirmation(ctx context.Context, batchHeaderHash [32]byte, fromBlock uint32) (*BatchConfirmation, error)
+
 	// GetBlockStaleMeasure returns the BLOCK_STALE_MEASURE defined onchain.
 	GetBlockStaleMeasure(ctx context.Context) (uint32, error)
 	// GetStoreDurationBlocks returns the STORE_DURATION_BLOCKS defined onchain.
@@ -146,4 +158,7 @@ type Transactor interface {
 
 	// GetRequiredQuorumNumbers returns set of required quorum numbers
 	GetRequiredQuorumNumbers(ctx context.Context, blockNumber uint32) ([]QuorumID, error)
+
+	// IsBatchConfirmer returns whether the given address is allowed to confirm batches on the EigenDAServiceManager.
+	IsBatchConfirmer(ctx context.Context, address gethcommon.Address) (bool, error)
 }
@@ -101,8 +101,8 @@ func (v *shardValidator) ValidateBatch(batchHeader *BatchHeader, blobs []*BlobMe
 }
 
 func (v *shardValidator) ValidateBlobs(blobs []*BlobMessage, operatorState *OperatorState, pool common.WorkerPool) error {
-	var err error
-	subBatchMap := make(map[encoding.EncodingParams]*encoding.SubBatch)
+	// The samples of each blob, grouped by the encoding parameters they are verified with
+	blobSamplesByParams := make(map[encoding.EncodingParams][][]encoding.Sample)
 	blobCommitmentList := make([]encoding.BlobCommitments, len(blobs))
 
 	for k, blob := range blobs {
@@ -120,50 +120,45 @@ func (v *shardValidator) ValidateBlobs(blobs []*BlobMessage, operatorState *Oper
 				continue
 			} else if err != nil {
 				return err
-			} else {
-				// Check the received chunks against the commitment
-				blobIndex := 0
-				subBatch, ok := subBatchMap[*params]
-				if ok {
-					blobIndex = subBatch.NumBlobs
-				}
-
-				indices := assignment.GetIndices()
-				samples := make([]encoding.Sample, len(chunks))
-				for ind := range chunks {
-					samples[ind] = encoding.Sample{
-						Commitment:      blob.BlobHeader.BlobCommitments.Commitment,
-						Chunk:           chunks[ind],
-						AssignmentIndex: uint(indices[ind]),
-						BlobIndex:       blobIndex,
-					}
-				}
+			}
 
-				// update subBatch
-				if !ok {
-					subBatchMap[*params] = &encoding.SubBatch{
-						Samples:  samples,
-						NumBlobs: 1,
-					}
-				} else {
-					subBatch.Samples = append(subBatch.Samples, samples...)
-					subBatch.NumBlobs += 1
+			indices := assignment.GetIndices()
+			samples := make([]encoding.Sample, len(chunks))
+			for ind := range chunks {
+				samples[ind] = encoding.Sample{
+					Commitment:      blob.BlobHeader.BlobCommitments.Commitment,
+					Chunk:           chunks[ind],
+					AssignmentIndex: uint(indices[ind]),
 				}
 			}
+			blobSamplesByParams[*params] = append(blobSamplesByParams[*params], samples)
+		}
+	}
+
+	// The blobs sharing encoding parameters are split across the workers, so that a batch of blobs encoded alike
+	// isn't verified by a single worker
+	numShards := 1
+	if pool.Size() > 1 {
+		numShards = pool.Size()
+	}
+	subBatches := make([]paramsSubBatch, 0, len(blobSamplesByParams))
+	for params, blobSamples := range blobSamplesByParams {
+		for _, subBatch := range splitSubBatch(blobSamples, numShards) {
+			subBatches = append(subBatches, paramsSubBatch{params: params, subBatch: subBatch})
 		}
 	}
 
-	// Parallelize the universal verification for each subBatch
-	numResult := len(subBatchMap) + len(blobCommitmentList)
+	// The KZG proofs of the sub batches, the length proofs of the blobs and the equivalence of their commitments are
+	// all verified in parallel
+	numResult := len(subBatches) + len(blobCommitmentList) + 1
 	// create a channel to accept results, we don't use stop
 	out := make(chan error, numResult)
 
 	// parallelize subBatch verification
-	for params, subBatch := range subBatchMap {
-		params := params
+	for _, subBatch := range subBatches {
 		subBatch := subBatch
 		pool.Submit(func() {
-			v.universalVerifyWorker(params, subBatch, out)
+			v.universalVerifyWorker(subBatch.params, subBatch.subBatch, out)
 		})
 	}
 
@@ -174,11 +169,11 @@ func (v *shardValidator) ValidateBlobs(blobs []*BlobMessage, operatorState *Oper
 			v.VerifyBlobLengthWorker(blobCommitments, out)
 		})
 	}
+
 	// check if commitments are equivalent
-	err = v.verifier.VerifyCommitEquivalenceBatch(blobCommitmentList)
-	if err != nil {
-		return err
-	}
+	pool.Submit(func() {
+		out <- v.verifier.VerifyCommitEquivalenceBatch(blobCommitmentList)
+	})
 
 	for i := 0; i < numResult; i++ {
 		err := <-out
@@ -190,6 +185,34 @@ func (v *shardValidator) ValidateBlobs(blobs []*BlobMessage, operatorState *Oper
 	return nil
 }
 
+// paramsSubBatch is a sub batch of samples along with the encoding parameters they are verified with
+type paramsSubBatch struct {
+	params   encoding.EncodingParams
+	subBatch *encoding.SubBatch
+}
+
+// splitSubBatch splits the samples of the blobs into at most numShards sub batches of consecutive blobs, of about
+// the same number of blobs. The samples are indexed by their blob within their sub batch.
+func splitSubBatch(blobSamples [][]encoding.Sample, numShards int) []*encoding.SubBatch {
+	blobsPerShard := (len(blobSamples) + numShards - 1) / numShards
+	subBatches := make([]*encoding.SubBatch, 0, numShards)
+	for start := 0; start < len(blobSamples); start += blobsPerShard {
+		end := start + blobsPerShard
+		if end > len(blobSamples) {
+			end = len(blobSamples)
+		}
+		subBatch := &encoding.SubBatch{NumBlobs: end - start}
+		for blobIndex, samples := range blobSamples[start:end] {
+			for _, sample := range samples {
+				sample.BlobIndex = blobIndex
+				subBatch.Samples = append(subBatch.Samples, sample)
+			}
+		}
+		subBatches = append(subBatches, subBatch)
+	}
+	return subBatches
+}
+
 func (v *shardValidator) universalVerifyWorker(params encoding.EncodingParams, subBatch *encoding.SubBatch, out chan error) {
 
 	err := v.verifier.UniversalVerifySubBatch(params, subBatch.Samples, subBatch.NumBlobs)
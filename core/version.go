@@ -0,0 +1,53 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+)
+
+// BlobHeaderVersion is the version of the format of the blob headers exchanged between the clients and the disperser.
+// New fields, e.g. new payment modes or retention classes, are introduced with a new version so that deployed
+// clients keep working: the client declares the versions it supports and the disperser picks one of them.
+type BlobHeaderVersion uint32
+
+const (
+	// BlobHeaderVersion0 is the format of the headers before versioning was introduced.
+	// Clients that do not declare the versions they support only support this version.
+	BlobHeaderVersion0 BlobHeaderVersion = 0
+)
+
+// SupportedBlobHeaderVersions are the blob header versions supported by this release, in increasing order
+var SupportedBlobHeaderVersions = []BlobHeaderVersion{BlobHeaderVersion0}
+
+var ErrNoCommonBlobHeaderVersion = errors.New("no common blob header version")
+
+// NegotiateBlobHeaderVersion returns the highest version supported by both the client and the server.
+// An empty list of client versions is a client that predates versioning, which only supports BlobHeaderVersion0.
+func NegotiateBlobHeaderVersion(clientVersions, serverVersions []BlobHeaderVersion) (BlobHeaderVersion, error) {
+	if len(clientVersions) == 0 {
+		clientVersions = []BlobHeaderVersion{BlobHeaderVersion0}
+	}
+
+	found := false
+	var chosen BlobHeaderVersion
+	for _, version := range clientVersions {
+		if (!found || version > chosen) && IsBlobHeaderVersionSupported(version, serverVersions) {
+			chosen = version
+			found = true
+		}
+	}
+	if !found {
+		return 0, fmt.Errorf("%w: client supports %v, server supports %v", ErrNoCommonBlobHeaderVersion, clientVersions, serverVersions)
+	}
+	return chosen, nil
+}
+
+// IsBlobHeaderVersionSupported returns true if the version is one of the supported versions
+func IsBlobHeaderVersionSupported(version BlobHeaderVersion, supportedVersions []BlobHeaderVersion) bool {
+	for _, v := range supportedVersions {
+		if v == version {
+			return true
+		}
+	}
+	return false
+}
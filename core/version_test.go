@@ -0,0 +1,40 @@
+package core_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestNegotiateBlobHeaderVersion(t *testing.T) {
+	serverVersions := []core.BlobHeaderVersion{0, 1, 2}
+
+	tests := []struct {
+		name           string
+		clientVersions []core.BlobHeaderVersion
+		expected       core.BlobHeaderVersion
+		expectErr      bool
+	}{
+		{name: "legacy client", clientVersions: nil, expected: 0},
+		{name: "highest common version", clientVersions: []core.BlobHeaderVersion{2, 0, 1}, expected: 2},
+		{name: "client ahead of server", clientVersions: []core.BlobHeaderVersion{1, 3}, expected: 1},
+		{name: "no common version", clientVersions: []core.BlobHeaderVersion{3, 4}, expectErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			version, err := core.NegotiateBlobHeaderVersion(tt.clientVersions, serverVersions)
+			if tt.expectErr {
+				assert.ErrorIs(t, err, core.ErrNoCommonBlobHeaderVersion)
+				return
+			}
+			assert.NoError(t, err)
+			assert.Equal(t, tt.expected, version)
+		})
+	}
+
+	// A server that only supports the original format still serves legacy clients
+	version, err := core.NegotiateBlobHeaderVersion(nil, core.SupportedBlobHeaderVersions)
+	assert.NoError(t, err)
+	assert.Equal(t, core.BlobHeaderVersion0, version)
+}
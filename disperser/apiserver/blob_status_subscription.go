@@ -0,0 +1,192 @@
+package apiserver
+
+import (
+	"bytes"
+	"crypto/sha256"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	// maxSubscribedBlobs is the maximum number of blobs whose status is streamed by a subscription
+	maxSubscribedBlobs = 100
+
+	defaultBlobStatusPollInterval      = time.Second
+	defaultBlobStatusKeepaliveInterval = 15 * time.Second
+
+	resumeTokenVersion = 0
+	// the resume token holds the version, a digest of the request IDs and a status per blob
+	resumeTokenDigestSize = 8
+)
+
+// SubscribeBlobStatus streams the status updates of the blobs by polling their metadata, until they all reach a
+// terminal state or the client cancels the stream.
+func (s *DispersalServer) SubscribeBlobStatus(req *pb.SubscribeBlobStatusRequest, stream pb.Disperser_SubscribeBlobStatusServer) error {
+	requestIDs := req.GetRequestIds()
+	if len(requestIDs) == 0 || len(requestIDs) > maxSubscribedBlobs {
+		s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+		s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+		return api.NewInvalidArgError(fmt.Sprintf("the number of request IDs must be between 1 and %d", maxSubscribedBlobs))
+	}
+	blobKeys := make([]disperser.BlobKey, len(requestIDs))
+	seen := make(map[disperser.BlobKey]struct{}, len(requestIDs))
+	for i, requestID := range requestIDs {
+		blobKey, err := disperser.ParseBlobKey(string(requestID))
+		if err != nil {
+			s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+			s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+			return api.NewInvalidArgError(fmt.Sprintf("failed to parse the requestID: %s", err.Error()))
+		}
+		if _, ok := seen[blobKey]; ok {
+			s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+			s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+			return api.NewInvalidArgError(fmt.Sprintf("duplicate requestID: %s", string(requestID)))
+		}
+		seen[blobKey] = struct{}{}
+		blobKeys[i] = blobKey
+	}
+
+	// sent holds the last status sent for each blob
+	sent := make([]pb.BlobStatus, len(requestIDs))
+	if len(req.GetResumeToken()) > 0 {
+		var err error
+		sent, err = decodeResumeToken(req.GetResumeToken(), requestIDs)
+		if err != nil {
+			s.metrics.HandleInvalidArgRpcRequest("SubscribeBlobStatus")
+			s.metrics.HandleInvalidArgRequest("SubscribeBlobStatus")
+			return api.NewInvalidArgError(err.Error())
+		}
+	}
+
+	pollInterval := s.serverConfig.BlobStatusPollInterval
+	if pollInterval <= 0 {
+		pollInterval = defaultBlobStatusPollInterval
+	}
+	keepaliveInterval := s.serverConfig.BlobStatusKeepaliveInterval
+	if keepaliveInterval <= 0 {
+		keepaliveInterval = defaultBlobStatusKeepaliveInterval
+	}
+	ticker := time.NewTicker(pollInterval)
+	defer ticker.Stop()
+
+	ctx := stream.Context()
+	lastSent := time.Now()
+	for {
+		metadatas, err := s.blobStore.GetBulkBlobMetadata(ctx, blobKeys)
+		if err != nil {
+			if ctx.Err() != nil {
+				return ctx.Err()
+			}
+			s.metrics.HandleInternalFailureRpcRequest("SubscribeBlobStatus")
+			return api.NewInternalError(fmt.Sprintf("failed to get blob metadata: %s", err.Error()))
+		}
+		metadataByKey := make(map[disperser.BlobKey]*disperser.BlobMetadata, len(metadatas))
+		for _, metadata := range metadatas {
+			if metadata != nil {
+				metadataByKey[metadata.GetBlobKey()] = metadata
+			}
+		}
+
+		terminal := 0
+		for i, blobKey := range blobKeys {
+			metadata, ok := metadataByKey[blobKey]
+			if !ok {
+				s.metrics.HandleNotFoundRpcRequest("SubscribeBlobStatus")
+				s.metrics.HandleNotFoundRequest("SubscribeBlobStatus")
+				return api.NewNotFoundError(fmt.Sprintf("no metadata found for the requestID %s", blobKey.String()))
+			}
+			reply, err := getBlobStatusReply(metadata)
+			if err != nil {
+				s.metrics.HandleInternalFailureRpcRequest("SubscribeBlobStatus")
+				return api.NewInternalError(fmt.Sprintf("missing confirmation information: %s", err.Error()))
+			}
+			if isTerminalStatus(reply.Status) {
+				terminal++
+			}
+			if reply.Status == sent[i] {
+				continue
+			}
+
+			sent[i] = reply.Status
+			err = stream.Send(&pb.BlobStatusUpdate{
+				RequestId:   requestIDs[i],
+				Status:      reply.Status,
+				Info:        reply.Info,
+				ResumeToken: encodeResumeToken(sent, requestIDs),
+			})
+			if err != nil {
+				return err
+			}
+			lastSent = time.Now()
+		}
+
+		if terminal == len(blobKeys) {
+			s.metrics.HandleSuccessfulRpcRequest("SubscribeBlobStatus")
+			return nil
+		}
+		if time.Since(lastSent) >= keepaliveInterval {
+			if err := stream.Send(&pb.BlobStatusUpdate{ResumeToken: encodeResumeToken(sent, requestIDs)}); err != nil {
+				return err
+			}
+			lastSent = time.Now()
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
+
+func isTerminalStatus(status pb.BlobStatus) bool {
+	switch status {
+	case pb.BlobStatus_FAILED, pb.BlobStatus_FINALIZED, pb.BlobStatus_INSUFFICIENT_SIGNATURES:
+		return true
+	}
+	return false
+}
+
+// encodeResumeToken returns the token holding the last status sent for each blob. It holds a digest of the request
+// IDs, so that a token can't be used to resume a subscription to other blobs.
+func encodeResumeToken(sent []pb.BlobStatus, requestIDs [][]byte) []byte {
+	digest := requestIDsDigest(requestIDs)
+	token := make([]byte, 0, 1+len(digest)+len(sent))
+	token = append(token, resumeTokenVersion)
+	token = append(token, digest...)
+	for _, status := range sent {
+		token = append(token, byte(status))
+	}
+	return token
+}
+
+func decodeResumeToken(token []byte, requestIDs [][]byte) ([]pb.BlobStatus, error) {
+	if len(token) != 1+resumeTokenDigestSize+len(requestIDs) || token[0] != resumeTokenVersion {
+		return nil, fmt.Errorf("invalid resume token")
+	}
+	if !bytes.Equal(token[1:1+resumeTokenDigestSize], requestIDsDigest(requestIDs)) {
+		return nil, fmt.Errorf("the resume token was issued for other request IDs")
+	}
+	sent := make([]pb.BlobStatus, len(requestIDs))
+	for i, status := range token[1+resumeTokenDigestSize:] {
+		if _, ok := pb.BlobStatus_name[int32(status)]; !ok {
+			return nil, fmt.Errorf("invalid resume token")
+		}
+		sent[i] = pb.BlobStatus(status)
+	}
+	return sent, nil
+}
+
+func requestIDsDigest(requestIDs [][]byte) []byte {
+	h := sha256.New()
+	for _, requestID := range requestIDs {
+		// length-prefixed, so that the digest of the list is unambiguous
+		h.Write([]byte{byte(len(requestID) >> 8), byte(len(requestID))})
+		h.Write(requestID)
+	}
+	return h.Sum(nil)[:resumeTokenDigestSize]
+}
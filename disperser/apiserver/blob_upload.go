@@ -0,0 +1,205 @@
+package apiserver
+
+import (
+	"bytes"
+	"context"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+)
+
+// blobUploadPrefix is the prefix of the keys of the uploaded blobs in the upload bucket. The blobs which are
+// never dispersed should be deleted by a lifecycle rule of the bucket.
+const blobUploadPrefix = "uploads/"
+
+// BlobUploadConfig is the configuration of the dispersal of the blobs uploaded to S3
+type BlobUploadConfig struct {
+	// BucketName is the name of the bucket to which the blobs are uploaded
+	BucketName string
+	// URLExpiry is how long the upload URLs are valid for
+	URLExpiry time.Duration
+}
+
+// blobUploads issues the upload URLs of the blobs and reads back the uploaded blobs
+type blobUploads struct {
+	config    BlobUploadConfig
+	s3Client  s3.Client
+	presigner s3.Presigner
+}
+
+// SetBlobUploads enables the dispersal of the blobs uploaded to the bucket of the config with presigned URLs. It must
+// be called before Start.
+func (s *DispersalServer) SetBlobUploads(config BlobUploadConfig, s3Client s3.Client, presigner s3.Presigner) {
+	s.logger.Info("blob upload config", "bucket", config.BucketName, "urlExpiry", config.URLExpiry.String())
+	s.uploads = &blobUploads{
+		config:    config,
+		s3Client:  s3Client,
+		presigner: presigner,
+	}
+}
+
+// GetBlobUploadURL issues a presigned URL to which the client uploads a blob of the requested size with an HTTP PUT
+// request before dispersing it with DisperseUploadedBlob. The URLs issued to each origin, and the bytes which may be
+// uploaded with them, are rate limited since the uploads are stored until they are dispersed or expire.
+func (s *DispersalServer) GetBlobUploadURL(ctx context.Context, req *pb.BlobUploadURLRequest) (*pb.BlobUploadURLReply, error) {
+	if s.uploads == nil {
+		return nil, status.Errorf(codes.Unimplemented, "method GetBlobUploadURL not enabled")
+	}
+	if req.GetBlobSize() == 0 || int(req.GetBlobSize()) > s.maxBlobSize {
+		s.metrics.HandleInvalidArgRpcRequest("GetBlobUploadURL")
+		s.metrics.HandleInvalidArgRequest("GetBlobUploadURL")
+		return nil, api.NewInvalidArgError(fmt.Sprintf("blob size must be between 1 and %v Bytes", s.maxBlobSize))
+	}
+
+	if s.ratelimiter != nil {
+		origin, err := common.GetClientAddress(ctx, s.rateConfig.ClientIPHeader, 2, true)
+		if err != nil {
+			s.metrics.HandleInvalidArgRpcRequest("GetBlobUploadURL")
+			s.metrics.HandleInvalidArgRequest("GetBlobUploadURL")
+			return nil, api.NewInvalidArgError(err.Error())
+		}
+		allowed, param, err := s.ratelimiter.AllowRequest(ctx, []common.RequestParams{
+			{
+				RequesterID: fmt.Sprintf("%s:%s", origin, BlobUploadURLRateType.Plug()),
+				BlobSize:    blobRateMultiplier,
+				Rate:        s.rateConfig.BlobUploadURLRate,
+				Info:        BlobUploadURLRateType.String(),
+			},
+			{
+				RequesterID: fmt.Sprintf("%s:%s", origin, BlobUploadThroughputType.Plug()),
+				BlobSize:    uint(req.GetBlobSize()),
+				Rate:        s.rateConfig.BlobUploadThroughput,
+				Info:        BlobUploadThroughputType.String(),
+			},
+		})
+		if err != nil {
+			s.metrics.HandleInternalFailureRpcRequest("GetBlobUploadURL")
+			return nil, api.NewInternalError(fmt.Sprintf("ratelimiter error: %v", err))
+		}
+		if !allowed {
+			s.metrics.HandleRateLimitedRpcRequest("GetBlobUploadURL")
+			errorString := "request ratelimited"
+			if info, ok := param.Info.(string); ok {
+				errorString += ": " + info
+			}
+			return nil, api.NewResourceExhaustedError(errorString)
+		}
+	}
+
+	id := make([]byte, 16)
+	if _, err := rand.Read(id); err != nil {
+		s.metrics.HandleInternalFailureRpcRequest("GetBlobUploadURL")
+		return nil, api.NewInternalError(fmt.Sprintf("failed to generate upload ID: %v", err))
+	}
+	uploadID := hex.EncodeToString(id)
+	expiresAt := time.Now().Add(s.uploads.config.URLExpiry)
+	url, err := s.uploads.presigner.PresignPutObject(ctx, s.uploads.config.BucketName, blobUploadPrefix+uploadID, int64(req.GetBlobSize()), s.uploads.config.URLExpiry)
+	if err != nil {
+		s.logger.Error("failed to presign blob upload URL", "err", err)
+		s.metrics.HandleInternalFailureRpcRequest("GetBlobUploadURL")
+		return nil, api.NewInternalError("failed to issue upload URL")
+	}
+
+	s.metrics.HandleSuccessfulRpcRequest("GetBlobUploadURL")
+	return &pb.BlobUploadURLReply{
+		UploadUrl: url,
+		UploadId:  uploadID,
+		ExpiresAt: uint64(expiresAt.Unix()),
+	}, nil
+}
+
+// DisperseUploadedBlob disperses the blob uploaded with the upload URL of GetBlobUploadURL as DisperseBlob. The size
+// of the uploaded blob is checked against the maximum blob size and its hash against the hash of the request before
+// the blob is validated. The uploaded blob is deleted once it is dispersed.
+func (s *DispersalServer) DisperseUploadedBlob(ctx context.Context, req *pb.DisperseUploadedBlobRequest) (*pb.DisperseBlobReply, error) {
+	if s.uploads == nil {
+		return nil, status.Errorf(codes.Unimplemented, "method DisperseUploadedBlob not enabled")
+	}
+
+	// This uses the existing deadline of ctx if it is earlier.
+	ctx, cancel := context.WithTimeout(ctx, s.serverConfig.GrpcTimeout)
+	defer cancel()
+
+	header, key, err := s.readUploadedBlob(ctx, req)
+	if err != nil {
+		if status.Code(err) == codes.InvalidArgument {
+			s.metrics.HandleInvalidArgRpcRequest("DisperseUploadedBlob")
+			s.metrics.HandleInvalidArgRequest("DisperseUploadedBlob")
+		} else {
+			s.metrics.HandleInternalFailureRpcRequest("DisperseUploadedBlob")
+		}
+		return nil, err
+	}
+
+	blob, err := s.validateRequestAndGetBlob(ctx, header)
+	if err != nil {
+		for _, quorumID := range header.GetCustomQuorumNumbers() {
+			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(header.GetData()), "DisperseUploadedBlob")
+		}
+		s.metrics.HandleInvalidArgRpcRequest("DisperseUploadedBlob")
+		return nil, api.NewInvalidArgError(err.Error())
+	}
+
+	reply, err := s.disperseBlob(ctx, blob, "", header.GetPrivateRetrieval(), header.GetVoucher(), "DisperseUploadedBlob")
+	if err != nil {
+		// Note the disperseBlob already updated metrics for this error.
+		s.logger.Info("failed to disperse blob", "err", err)
+		return nil, err
+	}
+
+	// The blob is in the blob store once dispersed, so failing to delete the upload only leaves it to the lifecycle
+	// rule of the bucket.
+	if err := s.uploads.s3Client.DeleteObject(ctx, s.uploads.config.BucketName, key); err != nil {
+		s.logger.Warn("failed to delete uploaded blob", "key", key, "err", err)
+	}
+
+	s.metrics.HandleSuccessfulRpcRequest("DisperseUploadedBlob")
+	return reply, nil
+}
+
+// readUploadedBlob downloads the blob uploaded for the request, checks it, and returns the header of the request with
+// the blob as its data along with the key of the uploaded blob.
+func (s *DispersalServer) readUploadedBlob(ctx context.Context, req *pb.DisperseUploadedBlobRequest) (*pb.DisperseBlobRequest, string, error) {
+	header := req.GetHeader()
+	if header == nil {
+		return nil, "", api.NewInvalidArgError("missing DisperseBlobRequest header")
+	}
+	if len(header.GetData()) > 0 {
+		return nil, "", api.NewInvalidArgError("the header must not contain data, the data must be uploaded")
+	}
+	if len(req.GetDataHash()) != sha256.Size {
+		return nil, "", api.NewInvalidArgError(fmt.Sprintf("the data hash must be a %d bytes sha256 hash", sha256.Size))
+	}
+	if _, err := hex.DecodeString(req.GetUploadId()); err != nil || len(req.GetUploadId()) != 32 {
+		return nil, "", api.NewInvalidArgError("invalid upload ID")
+	}
+
+	key := blobUploadPrefix + req.GetUploadId()
+	data, err := s.uploads.s3Client.DownloadObject(ctx, s.uploads.config.BucketName, key)
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, "", api.NewInvalidArgError(fmt.Sprintf("no blob was uploaded for upload ID %s", req.GetUploadId()))
+	}
+	if err != nil {
+		s.logger.Error("failed to download uploaded blob", "key", key, "err", err)
+		return nil, "", api.NewInternalError("failed to read uploaded blob")
+	}
+	if len(data) > s.maxBlobSize {
+		return nil, "", api.NewInvalidArgError(fmt.Sprintf("blob size cannot exceed %v Bytes", s.maxBlobSize))
+	}
+	if hash := sha256.Sum256(data); !bytes.Equal(hash[:], req.GetDataHash()) {
+		return nil, "", api.NewInvalidArgError("the hash of the uploaded blob doesn't match the data hash")
+	}
+
+	header.Data = data
+	return header, key, nil
+}
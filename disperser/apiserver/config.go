@@ -24,13 +24,27 @@ const (
 	ClientIPHeaderFlagName           = "auth.client-ip-header"
 	AllowlistFileFlagName            = "auth.allowlist-file"
 	AllowlistRefreshIntervalFlagName = "auth.allowlist-refresh-interval"
+	RateTiersFileFlagName            = "auth.rate-tiers-file"
+
+	DegradedQuorumSigningRateThresholdFlagName = "auth.degraded-quorum-signing-rate-threshold"
+	DegradedQuorumThroughputFactorFlagName     = "auth.degraded-quorum-throughput-factor"
+	DegradedQuorumWindowFlagName               = "auth.degraded-quorum-window"
+	DegradedQuorumMinBatchesFlagName           = "auth.degraded-quorum-min-batches"
+	DegradedQuorumRefreshIntervalFlagName      = "auth.degraded-quorum-refresh-interval"
 
 	RetrievalBlobRateFlagName   = "auth.retrieval-blob-rate"
 	RetrievalThroughputFlagName = "auth.retrieval-throughput"
 
+	BlobUploadURLRateFlagName    = "auth.blob-upload-url-rate"
+	BlobUploadThroughputFlagName = "auth.blob-upload-throughput"
+
 	// We allow the user to specify the blob rate in blobs/sec, but internally we use blobs/sec * 1e6 (i.e. blobs/microsec).
 	// This is because the rate limiter takes an integer rate.
 	blobRateMultiplier = 1e6
+
+	// DefaultRateTier is the tier of the authenticated accounts which aren't in the allowlist, if it is defined.
+	// Otherwise, they are rate limited by IP as unauthenticated requests.
+	DefaultRateTier = "default"
 )
 
 type QuorumRateInfo struct {
@@ -48,12 +62,26 @@ type PerUserRateInfo struct {
 
 type Allowlist = map[string]map[core.QuorumID]PerUserRateInfo
 
+// RateTiers are the rates of each tier by quorum, which the allowlist entries can refer to instead of setting
+// their own rates
+type RateTiers = map[string]map[core.QuorumID]PerUserRateInfo
+
 type AllowlistEntry struct {
 	Name     string  `json:"name"`
 	Account  string  `json:"account"`
 	QuorumID uint8   `json:"quorumID"`
 	BlobRate float64 `json:"blobRate"`
 	ByteRate float64 `json:"byteRate"`
+	// Tier is the rate tier of the account. If set, the account gets the rates of the tier for all its quorums, and
+	// the quorum and rates of the entry are ignored.
+	Tier string `json:"tier,omitempty"`
+}
+
+type RateTierEntry struct {
+	Tier     string  `json:"tier"`
+	QuorumID uint8   `json:"quorumID"`
+	BlobRate float64 `json:"blobRate"`
+	ByteRate float64 `json:"byteRate"`
 }
 
 type RateConfig struct {
@@ -64,8 +92,18 @@ type RateConfig struct {
 	RetrievalBlobRate   common.RateParam
 	RetrievalThroughput common.RateParam
 
+	// BlobUploadURLRate and BlobUploadThroughput limit the upload URLs issued to each origin, and the bytes which
+	// may be uploaded with them
+	BlobUploadURLRate    common.RateParam
+	BlobUploadThroughput common.RateParam
+
 	AllowlistFile            string
 	AllowlistRefreshInterval time.Duration
+
+	RateTiers     RateTiers
+	RateTiersFile string
+
+	QuorumHealth QuorumHealthConfig
 }
 
 func AllowlistFileFlag(envPrefix string) cli.Flag {
@@ -124,6 +162,12 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVar:   common.PrefixEnvVar(envPrefix, "ALLOWLIST_REFRESH_INTERVAL"),
 			Value:    5 * time.Minute,
 		},
+		cli.StringFlag{
+			Name:     RateTiersFileFlagName,
+			Usage:    "Path to a JSON file containing the blob/byte rates of the rate tiers by quorum. The allowlist entries can refer to a tier instead of setting their rates, and the \"default\" tier applies to the authenticated accounts which aren't in the allowlist. The file is refreshed with the allowlist",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "RATE_TIERS_FILE"),
+		},
 		cli.IntFlag{
 			Name:     RetrievalBlobRateFlagName,
 			Usage:    "The blob rate limit for retrieval requests (Blobs/sec)",
@@ -136,10 +180,90 @@ func CLIFlags(envPrefix string) []cli.Flag {
 			EnvVar:   common.PrefixEnvVar(envPrefix, "RETRIEVAL_BYTE_RATE"),
 			Required: true,
 		},
+		cli.IntFlag{
+			Name:     BlobUploadURLRateFlagName,
+			Usage:    "The rate limit for the blob upload URLs issued to each origin (URLs/sec)",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_UPLOAD_URL_RATE"),
+			Value:    1,
+		},
+		cli.IntFlag{
+			Name:     BlobUploadThroughputFlagName,
+			Usage:    "The throughput rate limit for the bytes which each origin may upload with the blob upload URLs (Bytes/sec)",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "BLOB_UPLOAD_BYTE_RATE"),
+			Value:    2 * 1024 * 1024,
+		},
+		cli.UintFlag{
+			Name:     DegradedQuorumSigningRateThresholdFlagName,
+			Usage:    "Average percentage of stake signing the recent batches of a quorum below which the dispersals to the quorum are throttled. Set to 0 to disable",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DEGRADED_QUORUM_SIGNING_RATE_THRESHOLD"),
+			Value:    0,
+		},
+		cli.Float64Flag{
+			Name:     DegradedQuorumThroughputFactorFlagName,
+			Usage:    "Fraction of the total unauthenticated throughput of a quorum accepted while the quorum is degraded",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DEGRADED_QUORUM_THROUGHPUT_FACTOR"),
+			Value:    0.1,
+		},
+		cli.DurationFlag{
+			Name:     DegradedQuorumWindowFlagName,
+			Usage:    "How far back the attestation results of the batches are used to compute the signing rate of the quorums",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DEGRADED_QUORUM_WINDOW"),
+			Value:    10 * time.Minute,
+		},
+		cli.IntFlag{
+			Name:     DegradedQuorumMinBatchesFlagName,
+			Usage:    "Minimum number of batches attested within the window to consider a quorum degraded",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DEGRADED_QUORUM_MIN_BATCHES"),
+			Value:    3,
+		},
+		cli.DurationFlag{
+			Name:     DegradedQuorumRefreshIntervalFlagName,
+			Usage:    "The interval at which to refresh the signing rate of the quorums",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envPrefix, "DEGRADED_QUORUM_REFRESH_INTERVAL"),
+			Value:    time.Minute,
+		},
+	}
+}
+
+func ReadRateTiersFromFile(f string) (RateTiers, error) {
+	tiers := make(RateTiers)
+	if f == "" {
+		return tiers, nil
+	}
+
+	content, err := os.ReadFile(f)
+	if err != nil {
+		return tiers, err
+	}
+	var tierEntries []RateTierEntry
+	if err := json.Unmarshal(content, &tierEntries); err != nil {
+		return tiers, err
+	}
+
+	for _, entry := range tierEntries {
+		if entry.Tier == "" {
+			return tiers, errors.New("rate tier entry without tier name")
+		}
+		if _, ok := tiers[entry.Tier]; !ok {
+			tiers[entry.Tier] = make(map[core.QuorumID]PerUserRateInfo)
+		}
+		tiers[entry.Tier][core.QuorumID(entry.QuorumID)] = PerUserRateInfo{
+			Throughput: common.RateParam(entry.ByteRate),
+			BlobRate:   common.RateParam(entry.BlobRate * blobRateMultiplier),
+		}
 	}
+
+	return tiers, nil
 }
 
-func ReadAllowlistFromFile(f string) (Allowlist, error) {
+func ReadAllowlistFromFile(f string, tiers RateTiers) (Allowlist, error) {
 	allowlist := make(Allowlist)
 	if f == "" {
 		return allowlist, nil
@@ -164,6 +288,21 @@ func ReadAllowlistFromFile(f string) (Allowlist, error) {
 	}
 
 	for _, entry := range allowlistEntries {
+		if entry.Tier != "" {
+			tierRates, ok := tiers[entry.Tier]
+			if !ok {
+				return allowlist, fmt.Errorf("unknown rate tier %s of account %s", entry.Tier, entry.Account)
+			}
+			if _, ok := allowlist[entry.Account]; !ok {
+				allowlist[entry.Account] = make(map[core.QuorumID]PerUserRateInfo)
+			}
+			for quorumID, rateInfo := range tierRates {
+				rateInfo.Name = entry.Name
+				allowlist[entry.Account][quorumID] = rateInfo
+			}
+			continue
+		}
+
 		rateInfoByQuorum, ok := allowlist[entry.Account]
 		if !ok {
 			allowlist[entry.Account] = map[core.QuorumID]PerUserRateInfo{
@@ -221,23 +360,48 @@ func ReadCLIConfig(c *cli.Context) (RateConfig, error) {
 		}
 	}
 
+	rateTiers, err := ReadRateTiersFromFile(c.String(RateTiersFileFlagName))
+	if err != nil {
+		return RateConfig{}, fmt.Errorf("failed to read rate tiers file %s: %w", c.String(RateTiersFileFlagName), err)
+	}
+
 	allowlist := make(Allowlist)
 	allowlistFileName := c.String(AllowlistFileFlagName)
 	if allowlistFileName != "" {
 		var err error
-		allowlist, err = ReadAllowlistFromFile(allowlistFileName)
+		allowlist, err = ReadAllowlistFromFile(allowlistFileName, rateTiers)
 		if err != nil {
 			return RateConfig{}, fmt.Errorf("failed to read allowlist file %s: %w", allowlistFileName, err)
 		}
 	}
 
+	signingRateThreshold := c.Uint(DegradedQuorumSigningRateThresholdFlagName)
+	if signingRateThreshold > 100 {
+		return RateConfig{}, fmt.Errorf("degraded quorum signing rate threshold must be at most 100, got %d", signingRateThreshold)
+	}
+	throughputFactor := c.Float64(DegradedQuorumThroughputFactorFlagName)
+	if throughputFactor < 0 || throughputFactor > 1 {
+		return RateConfig{}, fmt.Errorf("degraded quorum throughput factor must be between 0 and 1, got %v", throughputFactor)
+	}
+
 	return RateConfig{
 		QuorumRateInfos:          quorumRateInfos,
 		ClientIPHeader:           c.String(ClientIPHeaderFlagName),
 		Allowlist:                allowlist,
 		RetrievalBlobRate:        common.RateParam(c.Int(RetrievalBlobRateFlagName) * blobRateMultiplier),
 		RetrievalThroughput:      common.RateParam(c.Int(RetrievalThroughputFlagName)),
+		BlobUploadURLRate:        common.RateParam(c.Int(BlobUploadURLRateFlagName) * blobRateMultiplier),
+		BlobUploadThroughput:     common.RateParam(c.Int(BlobUploadThroughputFlagName)),
 		AllowlistFile:            c.String(AllowlistFileFlagName),
 		AllowlistRefreshInterval: c.Duration(AllowlistRefreshIntervalFlagName),
+		RateTiers:                rateTiers,
+		RateTiersFile:            c.String(RateTiersFileFlagName),
+		QuorumHealth: QuorumHealthConfig{
+			SigningRateThreshold: uint8(signingRateThreshold),
+			Window:               c.Duration(DegradedQuorumWindowFlagName),
+			MinBatches:           c.Int(DegradedQuorumMinBatchesFlagName),
+			ThroughputFactor:     throughputFactor,
+			RefreshInterval:      c.Duration(DegradedQuorumRefreshIntervalFlagName),
+		},
 	}, nil
 }
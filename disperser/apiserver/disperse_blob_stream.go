@@ -0,0 +1,115 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"google.golang.org/grpc/codes"
+)
+
+// DisperseBlobStream reassembles a blob streamed in chunks and disperses it as DisperseBlob. The stream must be fully
+// received within the grpc timeout, and the size of the chunks is checked against the maximum blob size as they are
+// received so that the disperser never buffers more than a blob.
+func (s *DispersalServer) DisperseBlobStream(stream pb.Disperser_DisperseBlobStreamServer) error {
+	// This uses the existing deadline of stream.Context() if it is earlier.
+	ctx, cancel := context.WithTimeout(stream.Context(), s.serverConfig.GrpcTimeout)
+	defer cancel()
+
+	type result struct {
+		req *pb.DisperseBlobRequest
+		err error
+	}
+	// Buffered so that the receiving goroutine doesn't leak if the context is done first. Its pending Recv fails
+	// once this handler returns.
+	resultCh := make(chan result, 1)
+	go func() {
+		req, err := s.receiveStreamedBlob(stream)
+		resultCh <- result{req: req, err: err}
+	}()
+
+	var req *pb.DisperseBlobRequest
+	select {
+	case r := <-resultCh:
+		if r.err != nil {
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+			s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+			return api.NewInvalidArgError(r.err.Error())
+		}
+		req = r.req
+	case <-ctx.Done():
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+		s.metrics.HandleInvalidArgRequest("DisperseBlobStream")
+		return api.NewInvalidArgError("context deadline exceeded")
+	}
+
+	blob, err := s.validateRequestAndGetBlob(ctx, req)
+	if err != nil {
+		for _, quorumID := range req.GetCustomQuorumNumbers() {
+			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(req.GetData()), "DisperseBlobStream")
+		}
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobStream")
+		return api.NewInvalidArgError(err.Error())
+	}
+
+	reply, err := s.disperseBlob(ctx, blob, "", req.GetPrivateRetrieval(), req.GetVoucher(), "DisperseBlobStream")
+	if err != nil {
+		// Note the disperseBlob already updated metrics for this error.
+		s.logger.Info("failed to disperse blob", "err", err)
+		return err
+	}
+
+	if err := stream.SendAndClose(reply); err != nil {
+		s.logger.Error("failed to stream back DisperseReply", "err", err)
+		return err
+	}
+
+	s.metrics.HandleSuccessfulRpcRequest("DisperseBlobStream")
+	return nil
+}
+
+// receiveStreamedBlob receives the header, the chunks and the commit of a streamed blob, and returns the request of the
+// header with the reassembled data.
+func (s *DispersalServer) receiveStreamedBlob(stream pb.Disperser_DisperseBlobStreamServer) (*pb.DisperseBlobRequest, error) {
+	in, err := stream.Recv()
+	if err != nil {
+		return nil, fmt.Errorf("error receiving next message: %v", err)
+	}
+	header := in.GetHeader()
+	if header == nil {
+		return nil, errors.New("missing DisperseBlobRequest header")
+	}
+	if len(header.GetData()) > 0 {
+		return nil, errors.New("the header must not contain data, the data must be sent in chunks")
+	}
+
+	var data []byte
+	for {
+		in, err := stream.Recv()
+		if errors.Is(err, io.EOF) {
+			return nil, errors.New("the stream ended without a commit")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("error receiving next message: %v", err)
+		}
+
+		switch payload := in.GetPayload().(type) {
+		case *pb.DisperseBlobStreamRequest_Chunk:
+			if len(data)+len(payload.Chunk) > s.maxBlobSize {
+				return nil, fmt.Errorf("blob size cannot exceed %v Bytes", s.maxBlobSize)
+			}
+			data = append(data, payload.Chunk...)
+		case *pb.DisperseBlobStreamRequest_Commit:
+			if int(payload.Commit.GetTotalSize()) != len(data) {
+				return nil, fmt.Errorf("the total size %d of the commit doesn't match the %d bytes received", payload.Commit.GetTotalSize(), len(data))
+			}
+			header.Data = data
+			return header, nil
+		default:
+			return nil, errors.New("expected a chunk or a commit")
+		}
+	}
+}
@@ -0,0 +1,108 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/prometheus/client_golang/prometheus"
+	"google.golang.org/grpc/codes"
+)
+
+// maxBlobsPerDispersal is the maximum number of blobs dispersed by a DisperseBlobs call
+const maxBlobsPerDispersal = 32
+
+// DisperseBlobs disperses several blobs in a single call. All the blobs are validated and checked against the rate
+// limits before any of them is stored, so that an invalid or rate limited request stores no blob. Note that the rates
+// of the blobs checked before a rate limited one are still consumed. If storing a blob fails, the blobs already stored
+// are marked as failed so that they aren't dispersed.
+func (s *DispersalServer) DisperseBlobs(ctx context.Context, req *pb.DisperseBlobsRequest) (*pb.DisperseBlobsReply, error) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("DisperseBlobs", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if len(req.GetBlobs()) == 0 || len(req.GetBlobs()) > maxBlobsPerDispersal {
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobs")
+		return nil, api.NewInvalidArgError(fmt.Sprintf("the number of blobs must be between 1 and %d", maxBlobsPerDispersal))
+	}
+
+	blobs := make([]*core.Blob, len(req.GetBlobs()))
+	for i, blobReq := range req.GetBlobs() {
+		blob, err := s.validateRequestAndGetBlob(ctx, blobReq)
+		if err == nil && blobReq.GetPrivateRetrieval() {
+			err = errPrivateRetrievalUnauthenticated
+		}
+		if err == nil && blobReq.GetVoucher() != nil {
+			err = errVoucherUnauthenticated
+		}
+		if err != nil {
+			for _, quorumID := range blobReq.GetCustomQuorumNumbers() {
+				s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprint(quorumID), len(blobReq.GetData()), "DisperseBlobs")
+			}
+			s.metrics.HandleInvalidArgRpcRequest("DisperseBlobs")
+			return nil, api.NewInvalidArgError(fmt.Sprintf("invalid blob %d: %s", i, err.Error()))
+		}
+		blobs[i] = blob
+	}
+
+	origin, err := common.GetClientAddress(ctx, s.rateConfig.ClientIPHeader, 2, true)
+	if err != nil {
+		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobs")
+		return nil, api.NewInvalidArgError(err.Error())
+	}
+	s.logger.Debug("received a new batch dispersal request", "origin", origin, "numBlobs", len(blobs))
+
+	if s.ratelimiter != nil {
+		for _, blob := range blobs {
+			err := s.checkRateLimitsAndAddRatesToHeader(ctx, blob, origin, "", false, "DisperseBlobs")
+			if err != nil {
+				// Note checkRateLimitsAndAddRatesToHeader already updated the metrics for this error.
+				return nil, err
+			}
+		}
+	}
+
+	requestOrigin := disperser.RequestOrigin{
+		ClientVersion:    getClientVersion(ctx),
+		DispersalSurface: disperser.FreeDispersal,
+	}
+	replies := make([]*pb.DisperseBlobReply, 0, len(blobs))
+	stored := make([]disperser.BlobKey, 0, len(blobs))
+	for _, blob := range blobs {
+		requestedAt := uint64(time.Now().UnixNano())
+		metadataKey, err := s.blobStore.StoreBlob(ctx, blob, requestedAt, requestOrigin)
+		if err != nil {
+			s.logger.Error("failed to store blob", "err", err)
+			for _, key := range stored {
+				if err := s.blobStore.MarkBlobFailed(ctx, key); err != nil {
+					s.logger.Error("failed to mark a blob of a failed batch dispersal as failed", "blobKey", key.String(), "err", err)
+				}
+			}
+			for _, param := range blob.RequestHeader.SecurityParams {
+				s.metrics.HandleBlobStoreFailedRequest(fmt.Sprint(param.QuorumID), len(blob.Data), "DisperseBlobs")
+			}
+			s.metrics.HandleStoreFailureRpcRequest("DisperseBlobs")
+			return nil, api.NewInternalError("failed to store blob, please try again later")
+		}
+		stored = append(stored, metadataKey)
+		replies = append(replies, &pb.DisperseBlobReply{
+			Result:            pb.BlobStatus_PROCESSING,
+			RequestId:         []byte(metadataKey.String()),
+			BlobHeaderVersion: uint32(blob.RequestHeader.Version),
+		})
+	}
+
+	for _, blob := range blobs {
+		for _, param := range blob.RequestHeader.SecurityParams {
+			s.metrics.HandleSuccessfulRequest(fmt.Sprint(param.QuorumID), len(blob.Data), "DisperseBlobs")
+		}
+	}
+	s.metrics.HandleSuccessfulRpcRequest("DisperseBlobs")
+	return &pb.DisperseBlobsReply{Replies: replies}, nil
+}
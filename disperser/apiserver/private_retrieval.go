@@ -0,0 +1,38 @@
+package apiserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/disperser"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// maxRetrievalClockSkew bounds how far from the local time a retrieval request of a private blob may be signed, so
+// that a leaked signature can't be replayed for long
+const maxRetrievalClockSkew = 2 * time.Minute
+
+var errPrivateRetrievalUnauthenticated = errors.New("private_retrieval is only supported by DisperseBlobAuthenticated")
+
+// authenticateRetrieval checks that the retrieval request of a blob dispersed with private retrieval is signed by the
+// account which dispersed the blob
+func authenticateRetrieval(req *pb.RetrieveBlobRequest, origin disperser.RequestOrigin, now time.Time) error {
+	if len(req.GetAuthenticationData()) == 0 {
+		return errors.New("the blob was dispersed with private retrieval, the request must be signed by the account which dispersed it")
+	}
+	signedAt := time.Unix(int64(req.GetTimestamp()), 0)
+	if signedAt.Before(now.Add(-maxRetrievalClockSkew)) || signedAt.After(now.Add(maxRetrievalClockSkew)) {
+		return fmt.Errorf("request timestamp %d is outside of the allowed clock skew %v", req.GetTimestamp(), maxRetrievalClockSkew)
+	}
+	account, err := auth.RetrievalRequestAccount(req.GetBatchHeaderHash(), req.GetBlobIndex(), req.GetTimestamp(), req.GetAuthenticationData())
+	if err != nil {
+		return err
+	}
+	if !gethcommon.IsHexAddress(origin.AuthenticatedAccount) || account != gethcommon.HexToAddress(origin.AuthenticatedAccount) {
+		return fmt.Errorf("the request is signed by %s, which is not the account which dispersed the blob", account.Hex())
+	}
+	return nil
+}
@@ -0,0 +1,153 @@
+package apiserver
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// QuorumHealthConfig configures the throttling of the dispersals to quorums whose operators fail to sign
+type QuorumHealthConfig struct {
+	// SigningRateThreshold is the average percentage of stake signing the recent batches below which a quorum
+	// is degraded. Throttling is disabled if it is 0.
+	SigningRateThreshold uint8
+	// Window is how far back the attestation results of the batches are considered
+	Window time.Duration
+	// MinBatches is the minimum number of batches attested within the window to consider a quorum degraded
+	MinBatches int
+	// ThroughputFactor is the fraction of the total unauthenticated throughput of a quorum accepted while it is degraded
+	ThroughputFactor float64
+	// RefreshInterval is the interval at which the health of the quorums is refreshed
+	RefreshInterval time.Duration
+}
+
+// QuorumHealthMonitor tracks the signing rate of each quorum from the attestation results recorded by the batcher
+// in the confirmation info of the blobs, and marks the quorums whose recent signing rate is below the threshold
+// as degraded. Dispersals to degraded quorums are throttled so that batches that would fail the thresholds do not pile up.
+type QuorumHealthMonitor struct {
+	config    QuorumHealthConfig
+	blobStore disperser.BlobStore
+	logger    logging.Logger
+	health    *healthcheck.Tracker
+
+	mu       sync.RWMutex
+	degraded map[core.QuorumID]bool
+}
+
+func NewQuorumHealthMonitor(config QuorumHealthConfig, blobStore disperser.BlobStore, logger logging.Logger) *QuorumHealthMonitor {
+	return &QuorumHealthMonitor{
+		config:    config,
+		blobStore: blobStore,
+		logger:    logger.With("component", "QuorumHealthMonitor"),
+		health:    healthcheck.NewTracker("QuorumHealthMonitor", 5),
+		degraded:  make(map[core.QuorumID]bool),
+	}
+}
+
+// Start refreshes the health of the quorums periodically until the context is done
+func (m *QuorumHealthMonitor) Start(ctx context.Context) {
+	go func() {
+		ticker := time.NewTicker(m.config.RefreshInterval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				err := m.Refresh(ctx, time.Now())
+				if err != nil {
+					m.logger.Error("failed to refresh quorum health", "err", err)
+				}
+				m.health.Record(err)
+			}
+		}
+	}()
+}
+
+// Health reports whether the health of the quorums is refreshed, along with the quorums that are degraded
+func (m *QuorumHealthMonitor) Health() healthcheck.ComponentHealth {
+	h := m.health.Health()
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	degraded := make([]core.QuorumID, 0)
+	for quorumID, isDegraded := range m.degraded {
+		if isDegraded {
+			degraded = append(degraded, quorumID)
+		}
+	}
+	sort.Slice(degraded, func(i, j int) bool { return degraded[i] < degraded[j] })
+	h.Details["degradedQuorums"] = fmt.Sprint(degraded)
+	return h
+}
+
+// IsDegraded returns true if the recent signing rate of the quorum is below the threshold
+func (m *QuorumHealthMonitor) IsDegraded(quorumID core.QuorumID) bool {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	return m.degraded[quorumID]
+}
+
+// Refresh recomputes the signing rate of each quorum over the batches of the blobs requested within the window,
+// including the batches that failed to reach the thresholds.
+func (m *QuorumHealthMonitor) Refresh(ctx context.Context, now time.Time) error {
+	since := uint64(now.Add(-m.config.Window).UnixNano())
+
+	// Confirmed blobs are finalized after a while, so only the recent ones are fetched
+	attested := make(map[[32]byte][]*core.QuorumResult)
+	for _, status := range []disperser.BlobStatus{disperser.Confirmed, disperser.InsufficientSignatures} {
+		metadatas, err := m.blobStore.GetBlobMetadataByStatus(ctx, status)
+		if err != nil {
+			return err
+		}
+		for _, metadata := range metadatas {
+			if metadata.ConfirmationInfo == nil || metadata.RequestMetadata == nil || metadata.RequestMetadata.RequestedAt < since {
+				continue
+			}
+			results := make([]*core.QuorumResult, 0, len(metadata.ConfirmationInfo.QuorumResults))
+			for _, result := range metadata.ConfirmationInfo.QuorumResults {
+				results = append(results, result)
+			}
+			attested[metadata.ConfirmationInfo.BatchHeaderHash] = results
+		}
+	}
+
+	numBatches := make(map[core.QuorumID]int)
+	totalSigned := make(map[core.QuorumID]int)
+	for _, results := range attested {
+		for _, result := range results {
+			numBatches[result.QuorumID]++
+			totalSigned[result.QuorumID] += int(result.PercentSigned)
+		}
+	}
+
+	degraded := make(map[core.QuorumID]bool)
+	for quorumID, n := range numBatches {
+		if n < m.config.MinBatches {
+			continue
+		}
+		signingRate := float64(totalSigned[quorumID]) / float64(n)
+		degraded[quorumID] = signingRate < float64(m.config.SigningRateThreshold)
+	}
+
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	for quorumID, isDegraded := range degraded {
+		if isDegraded != m.degraded[quorumID] {
+			signingRate := float64(totalSigned[quorumID]) / float64(numBatches[quorumID])
+			if isDegraded {
+				m.logger.Warn("quorum degraded, throttling dispersals", "quorum", quorumID, "signingRate", signingRate, "numBatches", numBatches[quorumID])
+			} else {
+				m.logger.Info("quorum recovered", "quorum", quorumID, "signingRate", signingRate, "numBatches", numBatches[quorumID])
+			}
+		}
+	}
+	m.degraded = degraded
+	return nil
+}
@@ -0,0 +1,82 @@
+package apiserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+// attestBatch stores a blob requested at the given time and records the attestation result of its batch
+func attestBatch(t *testing.T, store disperser.BlobStore, batchHeaderHash byte, requestedAt time.Time, percentSigned map[core.QuorumID]uint8) {
+	ctx := context.Background()
+	blob := &core.Blob{Data: []byte{1, 2, 3}}
+	blobKey, err := store.StoreBlob(ctx, blob, uint64(requestedAt.UnixNano()), disperser.RequestOrigin{})
+	assert.NoError(t, err)
+	metadata, err := store.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+
+	quorumResults := make(map[core.QuorumID]*core.QuorumResult)
+	insufficient := false
+	for quorumID, signed := range percentSigned {
+		quorumResults[quorumID] = &core.QuorumResult{QuorumID: quorumID, PercentSigned: signed}
+		insufficient = insufficient || signed < 55
+	}
+	confirmationInfo := &disperser.ConfirmationInfo{
+		BatchHeaderHash: [32]byte{batchHeaderHash},
+		QuorumResults:   quorumResults,
+	}
+	if insufficient {
+		_, err = store.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo)
+	} else {
+		_, err = store.MarkBlobConfirmed(ctx, metadata, confirmationInfo)
+	}
+	assert.NoError(t, err)
+}
+
+func TestQuorumHealthMonitor(t *testing.T) {
+	store := inmem.NewBlobStore()
+	monitor := apiserver.NewQuorumHealthMonitor(apiserver.QuorumHealthConfig{
+		SigningRateThreshold: 60,
+		Window:               10 * time.Minute,
+		MinBatches:           3,
+		ThroughputFactor:     0.1,
+		RefreshInterval:      time.Minute,
+	}, store, logging.NewNoopLogger())
+
+	now := time.Now()
+	// Batches older than the window are ignored
+	for i := byte(0); i < 5; i++ {
+		attestBatch(t, store, i, now.Add(-time.Hour), map[core.QuorumID]uint8{0: 10, 1: 10})
+	}
+	attestBatch(t, store, 10, now.Add(-time.Minute), map[core.QuorumID]uint8{0: 90, 1: 40})
+	attestBatch(t, store, 11, now.Add(-time.Minute), map[core.QuorumID]uint8{0: 80, 1: 50})
+	assert.NoError(t, monitor.Refresh(context.Background(), now))
+	// Too few batches to judge the quorums
+	assert.False(t, monitor.IsDegraded(0))
+	assert.False(t, monitor.IsDegraded(1))
+
+	// A second blob of the same batch does not count the batch twice
+	attestBatch(t, store, 11, now.Add(-time.Minute), map[core.QuorumID]uint8{0: 80, 1: 50})
+	assert.NoError(t, monitor.Refresh(context.Background(), now))
+	assert.False(t, monitor.IsDegraded(1))
+
+	attestBatch(t, store, 12, now.Add(-time.Minute), map[core.QuorumID]uint8{0: 70, 1: 45})
+	assert.NoError(t, monitor.Refresh(context.Background(), now))
+	assert.False(t, monitor.IsDegraded(0))
+	assert.True(t, monitor.IsDegraded(1))
+	assert.False(t, monitor.IsDegraded(2))
+
+	// The quorum recovers once the failing batches leave the window
+	for i := byte(20); i < 23; i++ {
+		attestBatch(t, store, i, now.Add(9*time.Minute), map[core.QuorumID]uint8{0: 90, 1: 90})
+	}
+	assert.NoError(t, monitor.Refresh(context.Background(), now.Add(15*time.Minute)))
+	assert.False(t, monitor.IsDegraded(1))
+}
@@ -9,14 +9,20 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/api/grpc/mock"
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/utils/codec"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc/codes"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 func TestRatelimit(t *testing.T) {
@@ -48,6 +54,10 @@ func TestRatelimit(t *testing.T) {
 		CustomQuorumNumbers: []uint32{0},
 	})
 	assert.ErrorContains(t, err, "Account throughput rate limit")
+	// The encoded blob takes several seconds to refill at 20 KiB/s
+	retryDelay, ok := api.RetryDelay(err)
+	assert.True(t, ok)
+	assert.Greater(t, retryDelay, time.Second)
 
 	// Try with non-allowlisted IP. Should fail with account blob limit because blob rate (3 blobs/s) X bucket size (3s) is smaller than 20 blobs.
 	numLimited := 0
@@ -153,6 +163,75 @@ func TestAuthRatelimit(t *testing.T) {
 
 }
 
+func TestVoucherRatelimit(t *testing.T) {
+
+	data50KiB := make([]byte, 49600)
+	_, err := rand.Read(data50KiB)
+	assert.NoError(t, err)
+
+	data50KiB = codec.ConvertByPaddingEmptyByte(data50KiB)
+
+	issuerKey, err := crypto.HexToECDSA("fedcba9876543210fedcba9876543210fedcba9876543210fedcba9876543210")
+	assert.NoError(t, err)
+	dispersalServer.SetVouchers(apiserver.VoucherConfig{
+		Issuer: crypto.PubkeyToAddress(issuerKey.PublicKey),
+	}, apiserver.NewLocalVoucherStore())
+
+	// The account isn't in the allowlist, so its throughput limit is 20 KiB/s for quorum 0
+	signer := auth.NewLocalBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdea")
+	account := crypto.PubkeyToAddress(signer.PrivateKey.PublicKey)
+	voucher, err := auth.SignVoucher(issuerKey, account, uint64(2*len(data50KiB)), time.Now().Add(time.Hour))
+	assert.NoError(t, err)
+	voucherRequest := &pb.Voucher{
+		Account:    account.Hex(),
+		ByteBudget: voucher.ByteBudget,
+		Expiry:     voucher.Expiry,
+		Signature:  voucher.Signature,
+	}
+
+	errorChan := make(chan error, 10)
+
+	// The voucher isn't bound to an account without authentication
+	_, err = dispersalServer.DisperseBlob(peer.NewContext(context.Background(), &peer.Peer{
+		Addr: &net.TCPAddr{IP: net.ParseIP("6.6.6.6"), Port: 51001},
+	}), &pb.DisperseBlobRequest{
+		Data:                data50KiB,
+		CustomQuorumNumbers: []uint32{0},
+		Voucher:             voucherRequest,
+	})
+	assert.ErrorContains(t, err, "voucher is only supported by DisperseBlobAuthenticated")
+
+	// The voucher can't be used by another account
+	otherSigner := auth.NewLocalBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcde9")
+	simulateClientRequest(t, otherSigner, "6.6.6.6", &pb.DisperseBlobRequest{
+		Data:                data50KiB,
+		CustomQuorumNumbers: []uint32{0},
+		Voucher:             voucherRequest,
+	}, 0, errorChan, false)
+	err = <-errorChan
+	assert.ErrorContains(t, err, "voucher is issued to another account")
+
+	// The budget covers two blobs above the account throughput limit
+	for i := 0; i < 2; i++ {
+		simulateClientRequest(t, signer, "6.6.6.6", &pb.DisperseBlobRequest{
+			Data:                data50KiB,
+			CustomQuorumNumbers: []uint32{0},
+			Voucher:             voucherRequest,
+		}, 0, errorChan, false)
+		err = <-errorChan
+		assert.NoError(t, err)
+	}
+
+	simulateClientRequest(t, signer, "6.6.6.6", &pb.DisperseBlobRequest{
+		Data:                data50KiB,
+		CustomQuorumNumbers: []uint32{0},
+		Voucher:             voucherRequest,
+	}, 0, errorChan, false)
+	err = <-errorChan
+	assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+	assert.ErrorContains(t, err, "voucher byte budget exhausted")
+}
+
 func TestRetrievalRateLimit(t *testing.T) {
 
 	// Create random data
@@ -207,7 +286,54 @@ func TestRetrievalRateLimit(t *testing.T) {
 	}
 	assert.Greater(t, numLimited, 0)
 }
+func TestBlobUploadURLRateLimit(t *testing.T) {
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.2"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	uploadClient := cmock.NewS3Client()
+	dispersalServer.SetBlobUploads(apiserver.BlobUploadConfig{BucketName: "test-uploads", URLExpiry: time.Minute}, uploadClient, uploadClient)
+
+	// The URLs issued to the origin are rate limited, whatever the size of the blobs
+	numLimited := 0
+	for i := 0; i < 15; i++ {
+		_, err := dispersalServer.GetBlobUploadURL(ctx, &pb.BlobUploadURLRequest{BlobSize: 1024})
+		if err != nil {
+			assert.ErrorContains(t, err, "request ratelimited: Blob upload URL rate limit")
+			assert.Equal(t, codes.ResourceExhausted, status.Code(err))
+			numLimited++
+		}
+	}
+	assert.Greater(t, numLimited, 0)
+
+	// So are the bytes which may be uploaded with them
+	p.Addr = &net.TCPAddr{IP: net.ParseIP("0.0.0.3"), Port: 51001}
+	ctx = peer.NewContext(context.Background(), p)
+	numLimited = 0
+	for i := 0; i < 5; i++ {
+		_, err := dispersalServer.GetBlobUploadURL(ctx, &pb.BlobUploadURLRequest{BlobSize: uint32(testMaxBlobSize)})
+		if err != nil {
+			assert.ErrorContains(t, err, "request ratelimited: Blob upload throughput rate limit")
+			numLimited++
+		}
+	}
+	assert.Greater(t, numLimited, 0)
+}
+
 func simulateClient(t *testing.T, signer core.BlobRequestSigner, origin string, data []byte, quorums []uint32, delay time.Duration, errorChan chan error, shouldSucceed bool) {
+	simulateClientRequest(t, signer, origin, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: quorums,
+	}, delay, errorChan, shouldSucceed)
+}
+
+// simulateClientRequest disperses the request with DisperseBlobAuthenticated, setting its account ID to the one of
+// the signer
+func simulateClientRequest(t *testing.T, signer core.BlobRequestSigner, origin string, request *pb.DisperseBlobRequest, delay time.Duration, errorChan chan error, shouldSucceed bool) {
 
 	p := &peer.Peer{
 		Addr: &net.TCPAddr{
@@ -227,14 +353,11 @@ func simulateClient(t *testing.T, signer core.BlobRequestSigner, origin string,
 
 	accountId, err := signer.GetAccountID()
 	assert.NoError(t, err)
+	request.AccountId = accountId
 
 	err = stream.SendFromClient(&pb.AuthenticatedRequest{
 		Payload: &pb.AuthenticatedRequest_DisperseRequest{
-			DisperseRequest: &pb.DisperseBlobRequest{
-				Data:                data,
-				CustomQuorumNumbers: quorums,
-				AccountId:           accountId,
-			},
+			DisperseRequest: request,
 		},
 	})
 	assert.NoError(t, err)
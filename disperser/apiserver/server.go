@@ -29,10 +29,21 @@ import (
 	"github.com/prometheus/client_golang/prometheus"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/reflection"
 )
 
-const systemAccountKey = "system"
+const (
+	systemAccountKey = "system"
+
+	// ClientVersionHeader is the grpc metadata key clients use to report their version.
+	// The grpc user agent is recorded instead if it is not set.
+	ClientVersionHeader = "eigenda-client-version"
+	// maxClientVersionLength bounds the size of the client version recorded in the blob metadata
+	maxClientVersionLength = 128
+	// healthCheckInterval is the interval at which the health of the components is reflected in the gRPC health check
+	healthCheckInterval = 10 * time.Second
+)
 
 type DispersalServer struct {
 	pb.UnimplementedDisperserServer
@@ -47,6 +58,15 @@ type DispersalServer struct {
 
 	ratelimiter   common.RateLimiter
 	authenticator core.BlobRequestAuthenticator
+	// quorumHealth is nil if the throttling of the degraded quorums is disabled
+	quorumHealth *QuorumHealthMonitor
+	// health aggregates the health of the components of the server. The gRPC health check only reports whether the
+	// server is up if it is nil.
+	health *healthcheck.Aggregator
+	// uploads is nil if the dispersal of the blobs uploaded to S3 is disabled
+	uploads *blobUploads
+	// vouchers is nil if the dispersal with vouchers is disabled
+	vouchers *vouchers
 
 	metrics *disperser.Metrics
 
@@ -84,6 +104,12 @@ func NewDispersalServer(
 
 	authenticator := auth.NewAuthenticator(auth.AuthConfig{})
 
+	var quorumHealth *QuorumHealthMonitor
+	if rateConfig.QuorumHealth.SigningRateThreshold > 0 {
+		logger.Info("degraded quorum throttling config", "signingRateThreshold", rateConfig.QuorumHealth.SigningRateThreshold, "window", rateConfig.QuorumHealth.Window.String(), "throughputFactor", rateConfig.QuorumHealth.ThroughputFactor)
+		quorumHealth = NewQuorumHealthMonitor(rateConfig.QuorumHealth, store, logger)
+	}
+
 	return &DispersalServer{
 		serverConfig:  serverConfig,
 		rateConfig:    rateConfig,
@@ -93,6 +119,7 @@ func NewDispersalServer(
 		logger:        logger,
 		ratelimiter:   ratelimiter,
 		authenticator: authenticator,
+		quorumHealth:  quorumHealth,
 		mu:            &sync.RWMutex{},
 		quorumConfig:  QuorumConfig{},
 		maxBlobSize:   maxBlobSize,
@@ -197,13 +224,18 @@ func (s *DispersalServer) DisperseBlobAuthenticated(stream pb.Disperser_Disperse
 
 	err = s.authenticator.AuthenticateBlobRequest(blob.RequestHeader.BlobAuthHeader)
 	if err != nil {
+		// A well-formed request signed by another account is distinguished from a malformed one
+		if errors.Is(err, auth.ErrAccountMismatch) {
+			s.metrics.HandleUnauthenticatedRpcRequest("DisperseBlobAuthenticated")
+			return api.NewUnauthenticatedError(fmt.Sprintf("failed to authenticate blob request: %v", err))
+		}
 		s.metrics.HandleInvalidArgRpcRequest("DisperseBlobAuthenticated")
 		s.metrics.HandleInvalidArgRequest("DisperseBlobAuthenticated")
 		return api.NewInvalidArgError(fmt.Sprintf("failed to authenticate blob request: %v", err))
 	}
 
 	// Disperse the blob
-	reply, err := s.disperseBlob(ctx, blob, authenticatedAddress, "DisperseBlobAuthenticated")
+	reply, err := s.disperseBlob(ctx, blob, authenticatedAddress, request.DisperseRequest.GetPrivateRetrieval(), request.DisperseRequest.GetVoucher(), "DisperseBlobAuthenticated")
 	if err != nil {
 		// Note the disperseBlob already updated metrics for this error.
 		s.logger.Info("failed to disperse blob", "err", err)
@@ -235,7 +267,7 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 		return nil, api.NewInvalidArgError(err.Error())
 	}
 
-	reply, err := s.disperseBlob(ctx, blob, "", "DisperseBlob")
+	reply, err := s.disperseBlob(ctx, blob, "", req.GetPrivateRetrieval(), req.GetVoucher(), "DisperseBlob")
 	if err != nil {
 		// Note the disperseBlob already updated metrics for this error.
 		s.logger.Info("failed to disperse blob", "err", err)
@@ -247,7 +279,10 @@ func (s *DispersalServer) DisperseBlob(ctx context.Context, req *pb.DisperseBlob
 
 // Note: disperseBlob will internally update metrics upon an error; the caller doesn't need
 // to track the error again.
-func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, authenticatedAddress string, apiMethodName string) (*pb.DisperseBlobReply, error) {
+//
+// A blob dispersed with privateRetrieval can only be retrieved by the authenticated account, so it is rejected if
+// the request isn't authenticated. So is a blob dispersed with a voucher, which is bound to the account.
+func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, authenticatedAddress string, privateRetrieval bool, voucherRequest *pb.Voucher, apiMethodName string) (*pb.DisperseBlobReply, error) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
 		s.metrics.ObserveLatency("DisperseBlob", f*1000) // make milliseconds
 	}))
@@ -261,6 +296,27 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 
 	blobSize := len(blob.Data)
 
+	if privateRetrieval && authenticatedAddress == "" {
+		for _, param := range securityParams {
+			s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprintf("%d", param.QuorumID), blobSize, apiMethodName)
+		}
+		s.metrics.HandleInvalidArgRpcRequest(apiMethodName)
+		return nil, api.NewInvalidArgError(errPrivateRetrievalUnauthenticated.Error())
+	}
+
+	var voucher *auth.Voucher
+	if voucherRequest != nil {
+		var err error
+		voucher, err = s.verifyVoucher(voucherRequest, authenticatedAddress, time.Now())
+		if err != nil {
+			for _, param := range securityParams {
+				s.metrics.HandleFailedRequest(codes.InvalidArgument.String(), fmt.Sprintf("%d", param.QuorumID), blobSize, apiMethodName)
+			}
+			s.metrics.HandleInvalidArgRpcRequest(apiMethodName)
+			return nil, api.NewInvalidArgError(err.Error())
+		}
+	}
+
 	origin, err := common.GetClientAddress(ctx, s.rateConfig.ClientIPHeader, 2, true)
 	if err != nil {
 		for _, param := range securityParams {
@@ -273,21 +329,57 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 	s.logger.Debug("received a new blob dispersal request", "authenticatedAddress", authenticatedAddress, "origin", origin, "blobSizeBytes", blobSize, "securityParams", strings.Join(securityParamsStrings, ", "))
 
 	if s.ratelimiter != nil {
-		err := s.checkRateLimitsAndAddRatesToHeader(ctx, blob, origin, authenticatedAddress, apiMethodName)
+		err := s.checkRateLimitsAndAddRatesToHeader(ctx, blob, origin, authenticatedAddress, voucher != nil, apiMethodName)
 		if err != nil {
 			// Note checkRateLimitsAndAddRatesToHeader already updated the metrics for this error.
 			return nil, err
 		}
 	}
 
+	// The budget of the voucher is only consumed by the blobs within the system rate limits
+	if voucher != nil {
+		remaining, err := s.vouchers.store.ConsumeVoucher(ctx, voucher, uint64(blobSize))
+		if errors.Is(err, ErrVoucherBudgetExhausted) {
+			for _, param := range securityParams {
+				s.metrics.HandleAccountRateLimitedRequest(fmt.Sprint(param.QuorumID), blobSize, apiMethodName)
+			}
+			s.metrics.HandleAccountRateLimitedRpcRequest(apiMethodName)
+			return nil, api.NewResourceExhaustedError(fmt.Sprintf("voucher byte budget exhausted: %d bytes remaining for a blob of %d bytes", remaining, blobSize))
+		}
+		if err != nil {
+			s.metrics.HandleInternalFailureRpcRequest(apiMethodName)
+			s.logger.Error("failed to consume voucher", "account", voucher.Account.Hex(), "err", err)
+			return nil, api.NewInternalError("failed to consume voucher, please try again later")
+		}
+		s.logger.Debug("blob dispersed with voucher", "account", voucher.Account.Hex(), "blobSizeBytes", blobSize, "remainingBytes", remaining)
+	}
+
+	requestOrigin := disperser.RequestOrigin{
+		AuthenticatedAccount: authenticatedAddress,
+		ClientVersion:        getClientVersion(ctx),
+		DispersalSurface:     disperser.FreeDispersal,
+		PrivateRetrieval:     privateRetrieval,
+	}
+	if voucher != nil {
+		requestOrigin.DispersalSurface = disperser.VoucherDispersal
+	} else if authenticatedAddress != "" {
+		requestOrigin.DispersalSurface = disperser.AuthenticatedDispersal
+	}
+
 	requestedAt := uint64(time.Now().UnixNano())
-	metadataKey, err := s.blobStore.StoreBlob(ctx, blob, requestedAt)
+	metadataKey, err := s.blobStore.StoreBlob(ctx, blob, requestedAt, requestOrigin)
 	if err != nil {
 		for _, param := range securityParams {
 			s.metrics.HandleBlobStoreFailedRequest(fmt.Sprintf("%d", param.QuorumID), blobSize, apiMethodName)
 		}
 		s.metrics.HandleStoreFailureRpcRequest(apiMethodName)
 		s.logger.Error("failed to store blob", "err", err)
+		// The blob wasn't accepted, so it doesn't spend the budget of the voucher
+		if voucher != nil {
+			if err := s.vouchers.store.RefundVoucher(ctx, voucher, uint64(blobSize)); err != nil {
+				s.logger.Error("failed to refund voucher", "account", voucher.Account.Hex(), "blobSizeBytes", blobSize, "err", err)
+			}
+		}
 		return nil, api.NewInternalError("failed to store blob, please try again later")
 	}
 
@@ -296,11 +388,30 @@ func (s *DispersalServer) disperseBlob(ctx context.Context, blob *core.Blob, aut
 	}
 
 	return &pb.DisperseBlobReply{
-		Result:    pb.BlobStatus_PROCESSING,
-		RequestId: []byte(metadataKey.String()),
+		Result:            pb.BlobStatus_PROCESSING,
+		RequestId:         []byte(metadataKey.String()),
+		BlobHeaderVersion: uint32(blob.RequestHeader.Version),
 	}, nil
 }
 
+// getClientVersion returns the client version reported in the request headers, falling back to the grpc user agent.
+func getClientVersion(ctx context.Context) string {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return ""
+	}
+	version := ""
+	if values := md.Get(ClientVersionHeader); len(values) > 0 {
+		version = values[0]
+	} else if values := md.Get("user-agent"); len(values) > 0 {
+		version = values[0]
+	}
+	if len(version) > maxClientVersionLength {
+		version = version[:maxClientVersionLength]
+	}
+	return version
+}
+
 func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, quorumID core.QuorumID) (*PerUserRateInfo, string, error) {
 	unauthRates, ok := s.rateConfig.QuorumRateInfos[quorumID]
 	if !ok {
@@ -330,6 +441,13 @@ func (s *DispersalServer) getAccountRate(origin, authenticatedAddress string, qu
 				return rates, key, nil
 			}
 		}
+
+		// The authenticated accounts which aren't in the allowlist get the rates of the default tier
+		if rateInfo, ok := s.rateConfig.RateTiers[DefaultRateTier][quorumID]; ok {
+			rates.Throughput = rateInfo.Throughput
+			rates.BlobRate = rateInfo.BlobRate
+			return rates, "address:" + authenticatedAddress, nil
+		}
 	}
 
 	// Check if the origin is in the allowlist
@@ -376,6 +494,9 @@ const (
 	AccountBlobRateType
 	RetrievalThroughputType
 	RetrievalBlobRateType
+	DegradedQuorumThroughputType
+	BlobUploadURLRateType
+	BlobUploadThroughputType
 )
 
 func (r RateType) String() string {
@@ -392,6 +513,12 @@ func (r RateType) String() string {
 		return "Retrieval throughput rate limit"
 	case RetrievalBlobRateType:
 		return "Retrieval blob rate limit"
+	case DegradedQuorumThroughputType:
+		return "quorum degraded"
+	case BlobUploadURLRateType:
+		return "Blob upload URL rate limit"
+	case BlobUploadThroughputType:
+		return "Blob upload throughput rate limit"
 	default:
 		return "Unknown rate type"
 	}
@@ -411,6 +538,12 @@ func (r RateType) Plug() string {
 		return "retrieval_throughput"
 	case RetrievalBlobRateType:
 		return "retrieval_blob_rate"
+	case DegradedQuorumThroughputType:
+		return "degraded_quorum_throughput"
+	case BlobUploadURLRateType:
+		return "blob_upload_url_rate"
+	case BlobUploadThroughputType:
+		return "blob_upload_throughput"
 	default:
 		return "unknown_rate_type"
 	}
@@ -421,6 +554,22 @@ type limiterInfo struct {
 	QuorumID core.QuorumID
 }
 
+// retryAfter estimates how long the requester should wait before retrying a rate limited request, as the time for
+// the rate limiter to refill the size of the request at the limited rate
+func retryAfter(params *common.RequestParams) time.Duration {
+	if params.Rate == 0 {
+		return 0
+	}
+	return time.Duration(float64(params.BlobSize) / float64(params.Rate) * float64(time.Second))
+}
+
+// systemThroughputUtilization returns the fraction, between 0 and 1, of the system throughput rate limit of the quorum
+// which is used.
+func (s *DispersalServer) systemThroughputUtilization(ctx context.Context, quorumID core.QuorumID) float64 {
+	key := fmt.Sprintf("%s:%d-%s", systemAccountKey, quorumID, SystemThroughputType.Plug())
+	return s.ratelimiter.GetUtilization(ctx, key)
+}
+
 // checkRateLimitsAndAddRatesToHeader checks the configured rate limits for all of the quorums in the blob's security params,
 // including both system and account level rates, relative to both the blob rate and the data bandwidth rate.
 // The function will check for whitelist entries for both the authenticated address (if authenticated) and the origin.
@@ -430,7 +579,8 @@ type limiterInfo struct {
 //
 // This information is currently passed to the DA nodes for their use is ratelimiting retrieval requests. This retrieval ratelimiting
 // is a temporary measure until the DA nodes are able to determine rates by themselves and will be simplified or replaced in the future.
-func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context, blob *core.Blob, origin, authenticatedAddress string, apiMethodName string) error {
+// The account rate limits don't apply to the blobs dispersed with a voucher, which are instead limited by its budget.
+func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context, blob *core.Blob, origin, authenticatedAddress string, withVoucher bool, apiMethodName string) error {
 
 	requestParams := make([]common.RequestParams, 0)
 
@@ -493,30 +643,47 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 		})
 
 		// Account Level
-		key = fmt.Sprintf("%s:%d-%s", accountKey, param.QuorumID, AccountThroughputType.Plug())
-		requestParams = append(requestParams, common.RequestParams{
-			RequesterID:   key,
-			RequesterName: requesterName,
-			BlobSize:      encodedSize,
-			Rate:          accountRates.Throughput,
-			Info: limiterInfo{
-				RateType: AccountThroughputType,
-				QuorumID: param.QuorumID,
-			},
-		})
-
-		key = fmt.Sprintf("%s:%d-%s", accountKey, param.QuorumID, AccountBlobRateType.Plug())
-		requestParams = append(requestParams, common.RequestParams{
-			RequesterID:   key,
-			RequesterName: requesterName,
-			BlobSize:      blobRateMultiplier,
-			Rate:          accountRates.BlobRate,
-			Info: limiterInfo{
-				RateType: AccountBlobRateType,
-				QuorumID: param.QuorumID,
-			},
-		})
+		if !withVoucher {
+			key = fmt.Sprintf("%s:%d-%s", accountKey, param.QuorumID, AccountThroughputType.Plug())
+			requestParams = append(requestParams, common.RequestParams{
+				RequesterID:   key,
+				RequesterName: requesterName,
+				BlobSize:      encodedSize,
+				Rate:          accountRates.Throughput,
+				Info: limiterInfo{
+					RateType: AccountThroughputType,
+					QuorumID: param.QuorumID,
+				},
+			})
+
+			key = fmt.Sprintf("%s:%d-%s", accountKey, param.QuorumID, AccountBlobRateType.Plug())
+			requestParams = append(requestParams, common.RequestParams{
+				RequesterID:   key,
+				RequesterName: requesterName,
+				BlobSize:      blobRateMultiplier,
+				Rate:          accountRates.BlobRate,
+				Info: limiterInfo{
+					RateType: AccountBlobRateType,
+					QuorumID: param.QuorumID,
+				},
+			})
+		}
 
+		// Reduced system throughput while the operators of the quorum fail to sign,
+		// so that batches which would not reach the thresholds do not pile up
+		if s.quorumHealth != nil && s.quorumHealth.IsDegraded(param.QuorumID) {
+			key = fmt.Sprintf("%s:%d-%s", systemAccountKey, param.QuorumID, DegradedQuorumThroughputType.Plug())
+			requestParams = append(requestParams, common.RequestParams{
+				RequesterID:   key,
+				RequesterName: systemAccountKey,
+				BlobSize:      encodedSize,
+				Rate:          common.RateParam(float64(globalRates.TotalUnauthThroughput) * s.rateConfig.QuorumHealth.ThroughputFactor),
+				Info: limiterInfo{
+					RateType: DegradedQuorumThroughputType,
+					QuorumID: param.QuorumID,
+				},
+			})
+		}
 	}
 
 	s.mu.Lock()
@@ -529,6 +696,12 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 		return api.NewInternalError(err.Error())
 	}
 
+	utilizations := make(map[core.QuorumID]float64, len(blob.RequestHeader.SecurityParams))
+	for _, param := range blob.RequestHeader.SecurityParams {
+		utilizations[param.QuorumID] = s.systemThroughputUtilization(ctx, param.QuorumID)
+		s.metrics.UpdateSystemThroughputUtilization(fmt.Sprint(param.QuorumID), utilizations[param.QuorumID])
+	}
+
 	if !allowed {
 		info, ok := params.Info.(limiterInfo)
 		if !ok {
@@ -542,9 +715,14 @@ func (s *DispersalServer) checkRateLimitsAndAddRatesToHeader(ctx context.Context
 			s.metrics.HandleAccountRateLimitedRpcRequest(apiMethodName)
 			s.metrics.HandleAccountRateLimitedRequest(fmt.Sprint(info.QuorumID), blobSize, apiMethodName)
 			s.logger.Info("request ratelimited", "requesterName", requesterName, "requesterID", params.RequesterID, "rateType", info.RateType.String(), "quorum", info.QuorumID)
+		} else if info.RateType == DegradedQuorumThroughputType {
+			s.metrics.HandleSystemRateLimitedRpcRequest(apiMethodName)
+			s.metrics.HandleSystemRateLimitedRequest(fmt.Sprint(info.QuorumID), blobSize, apiMethodName)
+			return api.NewResourceExhaustedErrorWithRetry(fmt.Sprintf("quorum degraded: dispersals to quorum %d are throttled until its signing rate recovers", info.QuorumID), retryAfter(params))
 		}
-		errorString := fmt.Sprintf("request ratelimited: %s for quorum %d", info.RateType.String(), info.QuorumID)
-		return api.NewResourceExhaustedError(errorString)
+		// The system load tells the clients rate limited on their account whether the disperser is also busy
+		errorString := fmt.Sprintf("request ratelimited: %s for quorum %d (system load %.0f%%)", info.RateType.String(), info.QuorumID, 100*utilizations[info.QuorumID])
+		return api.NewResourceExhaustedErrorWithRetry(errorString, retryAfter(params))
 	}
 
 	return nil
@@ -584,15 +762,23 @@ func (s *DispersalServer) GetBlobStatus(ctx context.Context, req *pb.BlobStatusR
 		return nil, api.NewInternalError(fmt.Sprintf("failed to get blob metadata, blobkey: %s", metadataKey.String()))
 	}
 
-	isConfirmed, err := metadata.IsConfirmed()
+	reply, err := getBlobStatusReply(metadata)
 	if err != nil {
 		s.metrics.HandleInternalFailureRpcRequest("GetBlobStatus")
 		return nil, api.NewInternalError(fmt.Sprintf("missing confirmation information: %s", err.Error()))
 	}
 
 	s.metrics.HandleSuccessfulRpcRequest("GetBlobStatus")
+	return reply, nil
+}
+
+// getBlobStatusReply returns the status of the blob, with the blob info needed to confirm it once it's confirmed
+func getBlobStatusReply(metadata *disperser.BlobMetadata) (*pb.BlobStatusReply, error) {
+	isConfirmed, err := metadata.IsConfirmed()
+	if err != nil {
+		return nil, err
+	}
 
-	s.logger.Debug("isConfirmed", "metadataKey", metadataKey, "isConfirmed", isConfirmed)
 	if isConfirmed {
 		confirmationInfo := metadata.ConfirmationInfo
 		dataLength := uint32(confirmationInfo.BlobCommitment.Length)
@@ -736,6 +922,14 @@ func (s *DispersalServer) RetrieveBlob(ctx context.Context, req *pb.RetrieveBlob
 
 	s.logger.Debug("fetched blob metadata", "batchHeaderHash", req.BatchHeaderHash, "blobIndex", req.BlobIndex, "duration", time.Since(stageTimer).String())
 
+	if blobMetadata.RequestMetadata != nil && blobMetadata.RequestMetadata.PrivateRetrieval {
+		if err := authenticateRetrieval(req, blobMetadata.RequestMetadata.RequestOrigin, time.Now()); err != nil {
+			s.metrics.HandleUnauthenticatedRpcRequest("RetrieveBlob")
+			s.metrics.IncrementFailedBlobRequestNum(codes.Unauthenticated.String(), "", "RetrieveBlob")
+			return nil, api.NewUnauthenticatedError(err.Error())
+		}
+	}
+
 	stageTimer = time.Now()
 	// Check throughout rate limit
 	blobSize := encoding.GetBlobSize(blobMetadata.ConfirmationInfo.BlobCommitment.Length)
@@ -792,6 +986,12 @@ func (s *DispersalServer) GetRateConfig() *RateConfig {
 	return &s.rateConfig
 }
 
+// SetHealthAggregator sets the aggregator whose health is reported by the gRPC health check. It must be called before
+// Start.
+func (s *DispersalServer) SetHealthAggregator(health *healthcheck.Aggregator) {
+	s.health = health
+}
+
 func (s *DispersalServer) Start(ctx context.Context) error {
 	go func() {
 		t := time.NewTicker(s.rateConfig.AllowlistRefreshInterval)
@@ -805,6 +1005,9 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 			}
 		}
 	}()
+	if s.quorumHealth != nil {
+		s.quorumHealth.Start(ctx)
+	}
 	// Serve grpc requests
 	addr := fmt.Sprintf("%s:%s", disperser.Localhost, s.serverConfig.GrpcPort)
 	listener, err := net.Listen("tcp", addr)
@@ -820,7 +1023,14 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 
 	// Register Server for Health Checks
 	name := pb.Disperser_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
+	if s.health != nil {
+		if s.quorumHealth != nil {
+			s.health.Register(s.quorumHealth)
+		}
+		healthcheck.RegisterAggregatedHealthServer(ctx, name, gs, s.health, healthCheckInterval)
+	} else {
+		healthcheck.RegisterHealthServer(name, gs)
+	}
 
 	s.logger.Info("GRPC Listening", "port", s.serverConfig.GrpcPort, "address", listener.Addr().String(), "maxBlobSize", s.maxBlobSize)
 
@@ -832,11 +1042,21 @@ func (s *DispersalServer) Start(ctx context.Context) error {
 }
 
 func (s *DispersalServer) LoadAllowlist() {
-	al, err := ReadAllowlistFromFile(s.rateConfig.AllowlistFile)
+	tiers := s.rateConfig.RateTiers
+	if s.rateConfig.RateTiersFile != "" {
+		var err error
+		tiers, err = ReadRateTiersFromFile(s.rateConfig.RateTiersFile)
+		if err != nil {
+			s.logger.Error("failed to load rate tiers", "err", err)
+			return
+		}
+	}
+	al, err := ReadAllowlistFromFile(s.rateConfig.AllowlistFile, tiers)
 	if err != nil {
 		s.logger.Error("failed to load allowlist", "err", err)
 		return
 	}
+	s.rateConfig.RateTiers = tiers
 	s.rateConfig.Allowlist = al
 	for account, rateInfoByQuorum := range al {
 		for quorumID, rateInfo := range rateInfoByQuorum {
@@ -1008,11 +1228,21 @@ func (s *DispersalServer) validateRequestAndGetBlob(ctx context.Context, req *pb
 		i++
 	}
 
+	clientVersions := make([]core.BlobHeaderVersion, len(req.GetBlobHeaderVersions()))
+	for i, version := range req.GetBlobHeaderVersions() {
+		clientVersions[i] = core.BlobHeaderVersion(version)
+	}
+	version, err := core.NegotiateBlobHeaderVersion(clientVersions, core.SupportedBlobHeaderVersions)
+	if err != nil {
+		return nil, err
+	}
+
 	header := core.BlobRequestHeader{
 		BlobAuthHeader: core.BlobAuthHeader{
 			AccountID: req.AccountId,
 		},
 		SecurityParams: params,
+		Version:        version,
 	}
 
 	blob := &core.Blob{
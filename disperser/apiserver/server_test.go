@@ -3,8 +3,10 @@ package apiserver_test
 import (
 	"context"
 	"crypto/rand"
+	"crypto/sha256"
 	"flag"
 	"fmt"
+	"io"
 	"net"
 	"os"
 	"testing"
@@ -25,36 +27,38 @@ import (
 
 	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/common"
-	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/common/aws/localstack"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
-	"github.com/Layr-Labs/eigenda/inabox/deploy"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
-	"github.com/ory/dockertest/v3"
 	"github.com/stretchr/testify/assert"
 	tmock "github.com/stretchr/testify/mock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 )
 
 var (
 	queue           disperser.BlobStore
 	dispersalServer *apiserver.DispersalServer
 
-	dockertestPool          *dockertest.Pool
-	dockertestResource      *dockertest.Resource
+	harness                 *localstack.Harness
 	UUID                    = uuid.New()
 	metadataTableName       = fmt.Sprintf("test-BlobMetadata-%v", UUID)
 	shadowMetadataTableName = fmt.Sprintf("test-BlobMetadata-Shadow-%v", UUID)
 	bucketTableName         = fmt.Sprintf("test-BucketStore-%v", UUID)
 
-	deployLocalStack bool
-	localStackPort   = "4568"
-	allowlistFile    *os.File
-	testMaxBlobSize  = 2 * 1024 * 1024
+	localStackPort  = "4568"
+	bucketName      = "test-eigenda-blobstore"
+	allowlistFile   *os.File
+	testMaxBlobSize = 2 * 1024 * 1024
 )
 
 func TestMain(m *testing.M) {
@@ -76,6 +80,228 @@ func TestDisperseBlob(t *testing.T) {
 	assert.NotNil(t, key)
 }
 
+func TestDisperseBlobs(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	reply, err := dispersalServer.DisperseBlobs(ctx, &pb.DisperseBlobsRequest{
+		Blobs: []*pb.DisperseBlobRequest{
+			{Data: data, CustomQuorumNumbers: []uint32{0, 1}},
+			{Data: data[:512], CustomQuorumNumbers: []uint32{0}},
+		},
+	})
+	assert.NoError(t, err)
+	assert.Len(t, reply.GetReplies(), 2)
+	for i, blobReply := range reply.GetReplies() {
+		assert.Equal(t, pb.BlobStatus_PROCESSING, blobReply.GetResult())
+		blobKey, err := disperser.ParseBlobKey(string(blobReply.GetRequestId()))
+		assert.NoError(t, err)
+		blob, err := queue.GetBlobContent(context.Background(), blobKey.BlobHash)
+		assert.NoError(t, err)
+		if i == 0 {
+			assert.Equal(t, data, blob)
+		} else {
+			assert.Equal(t, data[:512], blob)
+		}
+	}
+
+	// No blob is stored if one of them is invalid
+	_, err = dispersalServer.DisperseBlobs(ctx, &pb.DisperseBlobsRequest{
+		Blobs: []*pb.DisperseBlobRequest{
+			{Data: data, CustomQuorumNumbers: []uint32{0}},
+			{Data: data, CustomQuorumNumbers: []uint32{0, 0}},
+		},
+	})
+	assert.ErrorContains(t, err, "invalid blob 1")
+
+	_, err = dispersalServer.DisperseBlobs(ctx, &pb.DisperseBlobsRequest{})
+	assert.ErrorContains(t, err, "the number of blobs must be between 1 and 32")
+}
+
+// blobUploadStream sends the given requests to a DisperseBlobStream stream and records its reply
+type blobUploadStream struct {
+	grpc.ServerStream
+	ctx      context.Context
+	requests []*pb.DisperseBlobStreamRequest
+	reply    *pb.DisperseBlobReply
+}
+
+func (s *blobUploadStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *blobUploadStream) Recv() (*pb.DisperseBlobStreamRequest, error) {
+	if len(s.requests) == 0 {
+		return nil, io.EOF
+	}
+	req := s.requests[0]
+	s.requests = s.requests[1:]
+	return req, nil
+}
+
+func (s *blobUploadStream) SendAndClose(reply *pb.DisperseBlobReply) error {
+	s.reply = reply
+	return nil
+}
+
+func TestDisperseBlobStream(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	header := func() *pb.DisperseBlobStreamRequest {
+		return &pb.DisperseBlobStreamRequest{Payload: &pb.DisperseBlobStreamRequest_Header{
+			Header: &pb.DisperseBlobRequest{CustomQuorumNumbers: []uint32{0}},
+		}}
+	}
+	chunk := func(data []byte) *pb.DisperseBlobStreamRequest {
+		return &pb.DisperseBlobStreamRequest{Payload: &pb.DisperseBlobStreamRequest_Chunk{Chunk: data}}
+	}
+	commit := func(size int) *pb.DisperseBlobStreamRequest {
+		return &pb.DisperseBlobStreamRequest{Payload: &pb.DisperseBlobStreamRequest_Commit{
+			Commit: &pb.DisperseBlobStreamCommit{TotalSize: uint32(size)},
+		}}
+	}
+
+	stream := &blobUploadStream{ctx: ctx, requests: []*pb.DisperseBlobStreamRequest{
+		header(), chunk(data[:500]), chunk(data[500:1000]), chunk(data[1000:]), commit(len(data)),
+	}}
+	err = dispersalServer.DisperseBlobStream(stream)
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, stream.reply.GetResult())
+	blobKey, err := disperser.ParseBlobKey(string(stream.reply.GetRequestId()))
+	assert.NoError(t, err)
+	blob, err := queue.GetBlobContent(context.Background(), blobKey.BlobHash)
+	assert.NoError(t, err)
+	assert.Equal(t, data, blob)
+
+	// The total size must match the chunks received
+	stream = &blobUploadStream{ctx: ctx, requests: []*pb.DisperseBlobStreamRequest{header(), chunk(data), commit(len(data) + 1)}}
+	assert.ErrorContains(t, dispersalServer.DisperseBlobStream(stream), "doesn't match")
+
+	// The stream must end with a commit
+	stream = &blobUploadStream{ctx: ctx, requests: []*pb.DisperseBlobStreamRequest{header(), chunk(data)}}
+	assert.ErrorContains(t, dispersalServer.DisperseBlobStream(stream), "without a commit")
+
+	// The stream must start with a header
+	stream = &blobUploadStream{ctx: ctx, requests: []*pb.DisperseBlobStreamRequest{chunk(data), commit(len(data))}}
+	assert.ErrorContains(t, dispersalServer.DisperseBlobStream(stream), "missing DisperseBlobRequest header")
+
+	// The blob can't exceed the maximum blob size
+	large := make([]byte, testMaxBlobSize)
+	stream = &blobUploadStream{ctx: ctx, requests: []*pb.DisperseBlobStreamRequest{header(), chunk(large), chunk(data), commit(len(large) + len(data))}}
+	assert.ErrorContains(t, dispersalServer.DisperseBlobStream(stream), "blob size cannot exceed")
+}
+
+func TestDisperseUploadedBlob(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+	dataHash := sha256.Sum256(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	uploadClient := cmock.NewS3Client()
+	dispersalServer.SetBlobUploads(apiserver.BlobUploadConfig{BucketName: "test-uploads", URLExpiry: time.Minute}, uploadClient, uploadClient)
+
+	_, err = dispersalServer.GetBlobUploadURL(ctx, &pb.BlobUploadURLRequest{BlobSize: uint32(testMaxBlobSize + 1)})
+	assert.ErrorContains(t, err, "blob size must be between")
+
+	upload, err := dispersalServer.GetBlobUploadURL(ctx, &pb.BlobUploadURLRequest{BlobSize: uint32(len(data))})
+	assert.NoError(t, err)
+	assert.Contains(t, upload.GetUploadUrl(), upload.GetUploadId())
+	assert.Greater(t, upload.GetExpiresAt(), uint64(time.Now().Unix()))
+
+	request := func(hash []byte) *pb.DisperseUploadedBlobRequest {
+		return &pb.DisperseUploadedBlobRequest{
+			Header:   &pb.DisperseBlobRequest{CustomQuorumNumbers: []uint32{0}},
+			UploadId: upload.GetUploadId(),
+			DataHash: hash,
+		}
+	}
+
+	// The blob must be uploaded before it is dispersed
+	_, err = dispersalServer.DisperseUploadedBlob(ctx, request(dataHash[:]))
+	assert.ErrorContains(t, err, "no blob was uploaded")
+
+	key := "uploads/" + upload.GetUploadId()
+	assert.NoError(t, uploadClient.UploadObject(ctx, "test-uploads", key, data))
+
+	// The hash of the uploaded blob must match
+	_, err = dispersalServer.DisperseUploadedBlob(ctx, request(make([]byte, sha256.Size)))
+	assert.ErrorContains(t, err, "doesn't match the data hash")
+
+	reply, err := dispersalServer.DisperseUploadedBlob(ctx, request(dataHash[:]))
+	assert.NoError(t, err)
+	assert.Equal(t, pb.BlobStatus_PROCESSING, reply.GetResult())
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	blob, err := queue.GetBlobContent(context.Background(), blobKey.BlobHash)
+	assert.NoError(t, err)
+	assert.Equal(t, data, blob)
+
+	// The uploaded blob is deleted once dispersed
+	_, err = uploadClient.DownloadObject(ctx, "test-uploads", key)
+	assert.ErrorIs(t, err, s3.ErrObjectNotFound)
+}
+
+func TestDisperseBlobRecordsOrigin(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	ctx = metadata.NewIncomingContext(ctx, metadata.Pairs(apiserver.ClientVersionHeader, "eigenda-client/v0.7.0"))
+
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+	})
+	assert.NoError(t, err)
+
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	meta, err := queue.GetBlobMetadata(context.Background(), blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.RequestOrigin{
+		ClientVersion:    "eigenda-client/v0.7.0",
+		DispersalSurface: disperser.FreeDispersal,
+	}, meta.RequestMetadata.RequestOrigin)
+}
+
 func TestDisperseBlobAuth(t *testing.T) {
 
 	data1KiB := make([]byte, 1024)
@@ -220,6 +446,42 @@ func TestDisperseBlobWithInvalidQuorum(t *testing.T) {
 
 }
 
+func TestDisperseBlobNegotiatesBlobHeaderVersion(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	reply, err := dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		BlobHeaderVersions:  []uint32{0, 1000},
+	})
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(core.BlobHeaderVersion0), reply.GetBlobHeaderVersion())
+
+	blobKey, err := disperser.ParseBlobKey(string(reply.GetRequestId()))
+	assert.NoError(t, err)
+	meta, err := queue.GetBlobMetadata(context.Background(), blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, core.BlobHeaderVersion0, meta.RequestMetadata.Version)
+
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		BlobHeaderVersions:  []uint32{1000},
+	})
+	assert.ErrorContains(t, err, core.ErrNoCommonBlobHeaderVersion.Error())
+}
+
 func TestGetBlobStatus(t *testing.T) {
 	data := make([]byte, 1024)
 	_, err := rand.Read(data)
@@ -298,6 +560,107 @@ func TestGetBlobStatus(t *testing.T) {
 	assert.Equal(t, reply.GetInfo().GetBlobVerificationProof().GetQuorumIndexes(), quorumIndexes)
 }
 
+// blobStatusStream collects the updates sent to a SubscribeBlobStatus stream
+type blobStatusStream struct {
+	grpc.ServerStream
+	ctx     context.Context
+	updates chan *pb.BlobStatusUpdate
+}
+
+func newBlobStatusStream(ctx context.Context) *blobStatusStream {
+	return &blobStatusStream{ctx: ctx, updates: make(chan *pb.BlobStatusUpdate, 100)}
+}
+
+func (s *blobStatusStream) Context() context.Context {
+	return s.ctx
+}
+
+func (s *blobStatusStream) Send(update *pb.BlobStatusUpdate) error {
+	s.updates <- update
+	return nil
+}
+
+// nextStatusUpdate returns the next update which isn't a keepalive
+func (s *blobStatusStream) nextStatusUpdate(t *testing.T) *pb.BlobStatusUpdate {
+	for {
+		select {
+		case update := <-s.updates:
+			if len(update.GetRequestId()) > 0 {
+				return update
+			}
+		case <-time.After(5 * time.Second):
+			t.Fatal("timed out waiting for a blob status update")
+		}
+	}
+}
+
+func TestSubscribeBlobStatus(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	_, blobSize, requestID := disperseBlob(t, dispersalServer, data)
+	blobKey, err := disperser.ParseBlobKey(string(requestID))
+	assert.NoError(t, err)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	stream := newBlobStatusStream(ctx)
+	done := make(chan error, 1)
+	go func() {
+		done <- dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{RequestIds: [][]byte{requestID}}, stream)
+	}()
+
+	update := stream.nextStatusUpdate(t)
+	assert.Equal(t, requestID, update.GetRequestId())
+	assert.Equal(t, pb.BlobStatus_PROCESSING, update.GetStatus())
+	assert.NotEmpty(t, update.GetResumeToken())
+
+	// a keepalive is sent while the status doesn't change
+	select {
+	case keepalive := <-stream.updates:
+		assert.Empty(t, keepalive.GetRequestId())
+		assert.Equal(t, update.GetResumeToken(), keepalive.GetResumeToken())
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for a keepalive")
+	}
+
+	securityParams := []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+	}
+	confirmedMetadata := simulateBlobConfirmation(t, requestID, blobSize, securityParams, 0)
+	update = stream.nextStatusUpdate(t)
+	assert.Equal(t, pb.BlobStatus_CONFIRMED, update.GetStatus())
+	assert.Equal(t, confirmedMetadata.ConfirmationInfo.BatchID, update.GetInfo().GetBlobVerificationProof().GetBatchId())
+	confirmedToken := update.GetResumeToken()
+
+	assert.NoError(t, queue.MarkBlobFinalized(context.Background(), blobKey))
+	update = stream.nextStatusUpdate(t)
+	assert.Equal(t, pb.BlobStatus_FINALIZED, update.GetStatus())
+	// the stream ends once the blob reaches a terminal state
+	assert.NoError(t, <-done)
+
+	// resuming after the confirmation only sends the finalization
+	stream = newBlobStatusStream(ctx)
+	err = dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{RequestIds: [][]byte{requestID}, ResumeToken: confirmedToken}, stream)
+	assert.NoError(t, err)
+	assert.Len(t, stream.updates, 1)
+	update = stream.nextStatusUpdate(t)
+	assert.Equal(t, pb.BlobStatus_FINALIZED, update.GetStatus())
+
+	err = dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{}, stream)
+	assert.ErrorContains(t, err, "the number of request IDs must be between 1 and 100")
+	err = dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{RequestIds: [][]byte{requestID, requestID}}, stream)
+	assert.ErrorContains(t, err, "duplicate requestID")
+	err = dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{RequestIds: [][]byte{requestID}, ResumeToken: []byte{1, 2}}, stream)
+	assert.ErrorContains(t, err, "invalid resume token")
+	_, _, otherRequestID := disperseBlob(t, dispersalServer, data)
+	err = dispersalServer.SubscribeBlobStatus(&pb.SubscribeBlobStatusRequest{RequestIds: [][]byte{otherRequestID}, ResumeToken: confirmedToken}, stream)
+	assert.ErrorContains(t, err, "the resume token was issued for other request IDs")
+}
+
 func TestGetBlobDispersingStatus(t *testing.T) {
 	data := make([]byte, 1024)
 	_, err := rand.Read(data)
@@ -558,6 +921,40 @@ func TestLoadAllowlistFromFile(t *testing.T) {
 	assert.Equal(t, al["7.7.7.7"][1].Throughput, uint32(1234))
 }
 
+func TestReadAllowlistWithRateTiers(t *testing.T) {
+	tiersFile, err := os.CreateTemp(t.TempDir(), "tiers.*.json")
+	assert.NoError(t, err)
+	overwriteFile(t, tiersFile, `
+[
+  {"tier": "pro", "quorumID": 0, "blobRate": 2, "byteRate": 2048},
+  {"tier": "pro", "quorumID": 1, "blobRate": 4, "byteRate": 4096},
+  {"tier": "default", "quorumID": 0, "blobRate": 0.5, "byteRate": 512}
+]
+	`)
+	tiers, err := apiserver.ReadRateTiersFromFile(tiersFile.Name())
+	assert.NoError(t, err)
+	assert.Len(t, tiers, 2)
+	assert.Equal(t, uint32(0.5*1e6), tiers[apiserver.DefaultRateTier][0].BlobRate)
+
+	overwriteFile(t, allowlistFile, `
+[
+  {"name": "rollup", "account": "0x1aa8226f6d354380dDE75eE6B634875c4203e522", "tier": "pro"},
+  {"name": "foo", "account": "5.5.5.5", "quorumID": 1, "blobRate": 0.1, "byteRate": 4092}
+]
+	`)
+	al, err := apiserver.ReadAllowlistFromFile(allowlistFile.Name(), tiers)
+	assert.NoError(t, err)
+	rollup := al["0x1aa8226f6d354380dDE75eE6B634875c4203e522"]
+	assert.Len(t, rollup, 2)
+	assert.Equal(t, apiserver.PerUserRateInfo{Name: "rollup", Throughput: 2048, BlobRate: 2 * 1e6}, rollup[0])
+	assert.Equal(t, apiserver.PerUserRateInfo{Name: "rollup", Throughput: 4096, BlobRate: 4 * 1e6}, rollup[1])
+	assert.Equal(t, uint32(4092), al["5.5.5.5"][1].Throughput)
+
+	// The allowlist can't refer to an undefined tier
+	_, err = apiserver.ReadAllowlistFromFile(allowlistFile.Name(), apiserver.RateTiers{})
+	assert.ErrorContains(t, err, "unknown rate tier pro")
+}
+
 func overwriteFile(t *testing.T, f *os.File, content string) {
 	err := f.Truncate(0)
 	assert.NoError(t, err)
@@ -574,24 +971,16 @@ func setup() {
 		panic("failed to create allowlist file")
 	}
 
-	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
-	if !deployLocalStack {
-		localStackPort = os.Getenv("LOCALSTACK_PORT")
-	}
-
-	if deployLocalStack {
-		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
-		if err != nil {
-			teardown()
-			panic("failed to start localstack container")
-		}
-
+	harness, err = localstack.Start(localStackPort)
+	if err != nil {
+		teardown()
+		panic("failed to start localstack: " + err.Error())
 	}
 
-	err = deploy.DeployResources(dockertestPool, localStackPort, metadataTableName, shadowMetadataTableName, bucketTableName)
+	err = deployResources(context.Background())
 	if err != nil {
 		teardown()
-		panic("failed to deploy AWS resources")
+		panic("failed to deploy AWS resources: " + err.Error())
 	}
 
 	transactor := &mock.MockTransactor{}
@@ -607,9 +996,20 @@ func setup() {
 	dispersalServer = newTestServer(transactor)
 }
 
+func deployResources(ctx context.Context) error {
+	if err := harness.CreateBucket(ctx, bucketName); err != nil {
+		return err
+	}
+	if _, err := harness.CreateTable(ctx, metadataTableName, blobstore.GenerateTableSchema(metadataTableName, 10, 10)); err != nil {
+		return err
+	}
+	_, err := harness.CreateTable(ctx, bucketTableName, store.GenerateTableSchema(10, 10, bucketTableName))
+	return err
+}
+
 func teardown() {
-	if deployLocalStack {
-		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+	if err := harness.Stop(); err != nil {
+		panic(err)
 	}
 	if allowlistFile != nil {
 		_ = os.Remove(allowlistFile.Name())
@@ -619,18 +1019,11 @@ func teardown() {
 func newTestServer(transactor core.Transactor) *apiserver.DispersalServer {
 	logger := logging.NewNoopLogger()
 
-	bucketName := "test-eigenda-blobstore"
-	awsConfig := aws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
-	}
-	s3Client, err := s3.NewClient(context.Background(), awsConfig, logger)
+	s3Client, err := s3.NewClient(context.Background(), harness.Config, logger)
 	if err != nil {
 		panic("failed to create s3 client")
 	}
-	dynamoClient, err := dynamodb.NewClient(awsConfig, logger)
+	dynamoClient, err := dynamodb.NewClient(harness.Config, logger)
 	if err != nil {
 		panic("failed to create dynamoDB client")
 	}
@@ -692,6 +1085,9 @@ func newTestServer(transactor core.Transactor) *apiserver.DispersalServer {
 		RetrievalBlobRate:   3 * 1e6,
 		RetrievalThroughput: 20 * 1024,
 
+		BlobUploadURLRate:    2 * 1e6,
+		BlobUploadThroughput: 1024 * 1024,
+
 		AllowlistFile:            allowlistFile.Name(),
 		AllowlistRefreshInterval: 10 * time.Minute,
 	}
@@ -699,11 +1095,77 @@ func newTestServer(transactor core.Transactor) *apiserver.DispersalServer {
 	queue = blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
 
 	return apiserver.NewDispersalServer(disperser.ServerConfig{
-		GrpcPort:    "51001",
-		GrpcTimeout: 1 * time.Second,
+		GrpcPort:                    "51001",
+		GrpcTimeout:                 1 * time.Second,
+		BlobStatusPollInterval:      10 * time.Millisecond,
+		BlobStatusKeepaliveInterval: 100 * time.Millisecond,
 	}, queue, transactor, logger, disperser.NewMetrics(prometheus.NewRegistry(), "9001", logger), ratelimiter, rateConfig, testMaxBlobSize)
 }
 
+func TestRetrieveBlobPrivate(t *testing.T) {
+	data := make([]byte, 1024)
+	_, err := rand.Read(data)
+	assert.NoError(t, err)
+	data = codec.ConvertByPaddingEmptyByte(data)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("0.0.0.0"),
+			Port: 51001,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+
+	// The disperser can't know the account of an unauthenticated request
+	_, err = dispersalServer.DisperseBlob(ctx, &pb.DisperseBlobRequest{
+		Data:                data,
+		CustomQuorumNumbers: []uint32{0, 1},
+		PrivateRetrieval:    true,
+	})
+	assert.ErrorContains(t, err, "private_retrieval is only supported by DisperseBlobAuthenticated")
+
+	signer := auth.NewLocalBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdeb")
+	otherSigner := auth.NewLocalBlobRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdec")
+	securityParams := []*core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		{QuorumID: 1, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+	}
+	blobKey, err := queue.StoreBlob(ctx, &core.Blob{
+		RequestHeader: core.BlobRequestHeader{SecurityParams: securityParams},
+		Data:          data,
+	}, uint64(time.Now().UnixNano()), disperser.RequestOrigin{
+		AuthenticatedAccount: crypto.PubkeyToAddress(signer.PrivateKey.PublicKey).String(),
+		DispersalSurface:     disperser.AuthenticatedDispersal,
+		PrivateRetrieval:     true,
+	})
+	assert.NoError(t, err)
+	requestID := []byte(blobKey.String())
+	_ = simulateBlobConfirmation(t, requestID, uint(len(data)), securityParams, 1)
+	batchHeaderHash := crypto.Keccak256(requestID)
+
+	signedRequest := func(signer *auth.LocalBlobRequestSigner, timestamp time.Time) *pb.RetrieveBlobRequest {
+		sig, err := signer.SignRetrievalRequest(batchHeaderHash, 1, uint64(timestamp.Unix()))
+		assert.NoError(t, err)
+		return &pb.RetrieveBlobRequest{
+			BatchHeaderHash:    batchHeaderHash,
+			BlobIndex:          1,
+			Timestamp:          uint64(timestamp.Unix()),
+			AuthenticationData: sig,
+		}
+	}
+
+	_, err = retrieveBlob(dispersalServer, requestID, 1)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = dispersalServer.RetrieveBlob(ctx, signedRequest(otherSigner, time.Now()))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	_, err = dispersalServer.RetrieveBlob(ctx, signedRequest(signer, time.Now().Add(-time.Hour)))
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	reply, err := dispersalServer.RetrieveBlob(ctx, signedRequest(signer, time.Now()))
+	assert.NoError(t, err)
+	assert.Equal(t, data, reply.GetData())
+}
+
 func disperseBlob(t *testing.T, server *apiserver.DispersalServer, data []byte) (pb.BlobStatus, uint, []byte) {
 	p := &peer.Peer{
 		Addr: &net.TCPAddr{
@@ -0,0 +1,57 @@
+package apiserver
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var (
+	errVoucherUnauthenticated = errors.New("voucher is only supported by DisperseBlobAuthenticated")
+	errVouchersDisabled       = errors.New("vouchers are not accepted by this disperser")
+)
+
+// vouchers verifies the vouchers attached to the dispersal requests and tracks their consumption
+type vouchers struct {
+	config VoucherConfig
+	store  VoucherStore
+}
+
+// SetVouchers enables the dispersal of the blobs of the authenticated requests with a voucher signed by the issuer of
+// the config, which exempts the blobs from the rate limits of the account until the budget of the voucher is consumed.
+// The system rate limits still apply. It must be called before Start.
+func (s *DispersalServer) SetVouchers(config VoucherConfig, store VoucherStore) {
+	s.logger.Info("voucher config", "issuer", config.Issuer.Hex(), "table", config.TableName)
+	s.vouchers = &vouchers{
+		config: config,
+		store:  store,
+	}
+}
+
+// verifyVoucher returns the voucher of the request once it is checked to be signed by the issuer, issued to the
+// authenticated account and not expired
+func (s *DispersalServer) verifyVoucher(req *pb.Voucher, authenticatedAddress string, now time.Time) (*auth.Voucher, error) {
+	if authenticatedAddress == "" {
+		return nil, errVoucherUnauthenticated
+	}
+	if s.vouchers == nil {
+		return nil, errVouchersDisabled
+	}
+	if !gethcommon.IsHexAddress(req.GetAccount()) {
+		return nil, fmt.Errorf("invalid voucher account %q", req.GetAccount())
+	}
+	voucher := &auth.Voucher{
+		Account:    gethcommon.HexToAddress(req.GetAccount()),
+		ByteBudget: req.GetByteBudget(),
+		Expiry:     req.GetExpiry(),
+		Signature:  req.GetSignature(),
+	}
+	if err := auth.VerifyVoucher(voucher, s.vouchers.config.Issuer, gethcommon.HexToAddress(authenticatedAddress), now); err != nil {
+		return nil, fmt.Errorf("invalid voucher: %w", err)
+	}
+	return voucher, nil
+}
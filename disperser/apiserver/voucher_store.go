@@ -0,0 +1,183 @@
+package apiserver
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+var ErrVoucherBudgetExhausted = errors.New("voucher byte budget exhausted")
+
+// VoucherConfig is the configuration of the dispersals with the vouchers issued by the disperser operator
+type VoucherConfig struct {
+	// Issuer is the address of the key the vouchers are signed with
+	Issuer gethcommon.Address
+	// TableName is the name of the DynamoDB table which tracks the consumption of the vouchers. The consumption is
+	// tracked in memory if it is empty, which only works with a single instance of the server.
+	TableName string
+}
+
+// VoucherStore tracks the bytes dispersed with each voucher against the budget of the voucher
+type VoucherStore interface {
+	// ConsumeVoucher atomically adds numBytes to the bytes consumed with the voucher and returns the remaining budget.
+	// It returns ErrVoucherBudgetExhausted and consumes nothing if the budget doesn't cover numBytes.
+	ConsumeVoucher(ctx context.Context, voucher *auth.Voucher, numBytes uint64) (uint64, error)
+	// RefundVoucher gives back numBytes consumed with the voucher by a blob which wasn't accepted
+	RefundVoucher(ctx context.Context, voucher *auth.Voucher, numBytes uint64) error
+}
+
+type localVoucherStore struct {
+	mu       sync.Mutex
+	consumed map[[32]byte]*localVoucherUsage
+}
+
+type localVoucherUsage struct {
+	consumed uint64
+	expiry   uint64
+}
+
+var _ VoucherStore = (*localVoucherStore)(nil)
+
+// NewLocalVoucherStore returns a VoucherStore which tracks the consumption of the vouchers in memory
+func NewLocalVoucherStore() VoucherStore {
+	return &localVoucherStore{consumed: make(map[[32]byte]*localVoucherUsage)}
+}
+
+func (s *localVoucherStore) ConsumeVoucher(_ context.Context, voucher *auth.Voucher, numBytes uint64) (uint64, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	// The expired vouchers are rejected before they are consumed, so their usage can be dropped
+	now := uint64(time.Now().Unix())
+	for hash, usage := range s.consumed {
+		if usage.expiry < now {
+			delete(s.consumed, hash)
+		}
+	}
+
+	hash := voucher.Hash()
+	usage, ok := s.consumed[hash]
+	if !ok {
+		usage = &localVoucherUsage{expiry: voucher.Expiry}
+	}
+	if numBytes > voucher.ByteBudget-usage.consumed {
+		return voucher.ByteBudget - usage.consumed, ErrVoucherBudgetExhausted
+	}
+	usage.consumed += numBytes
+	s.consumed[hash] = usage
+	return voucher.ByteBudget - usage.consumed, nil
+}
+
+func (s *localVoucherStore) RefundVoucher(_ context.Context, voucher *auth.Voucher, numBytes uint64) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	usage, ok := s.consumed[voucher.Hash()]
+	if !ok {
+		return nil
+	}
+	if numBytes > usage.consumed {
+		numBytes = usage.consumed
+	}
+	usage.consumed -= numBytes
+	return nil
+}
+
+type dynamoVoucherStore struct {
+	client    *commondynamodb.Client
+	tableName string
+}
+
+var _ VoucherStore = (*dynamoVoucherStore)(nil)
+
+// NewDynamoVoucherStore returns a VoucherStore backed by the DynamoDB table of GenerateVoucherTableSchema. The
+// consumption of the vouchers expires with the vouchers if the TTL of the table is enabled on the Expiry attribute.
+func NewDynamoVoucherStore(client *commondynamodb.Client, tableName string) VoucherStore {
+	return &dynamoVoucherStore{client: client, tableName: tableName}
+}
+
+func (s *dynamoVoucherStore) ConsumeVoucher(ctx context.Context, voucher *auth.Voucher, numBytes uint64) (uint64, error) {
+	if numBytes > voucher.ByteBudget {
+		return 0, ErrVoucherBudgetExhausted
+	}
+	hash := voucher.Hash()
+	// The budget isn't exceeded by the bytes consumed once numBytes are added
+	condition := expression.Or(
+		expression.AttributeNotExists(expression.Name("Consumed")),
+		expression.LessThanEqual(expression.Name("Consumed"), expression.Value(voucher.ByteBudget-numBytes)),
+	)
+	item, err := s.client.IncrementItemWithCondition(ctx, s.tableName, commondynamodb.Key{
+		"VoucherHash": &types.AttributeValueMemberS{Value: gethcommon.Bytes2Hex(hash[:])},
+	}, map[string]int64{
+		"Consumed": int64(numBytes),
+	}, commondynamodb.Item{
+		"Account": &types.AttributeValueMemberS{Value: voucher.Account.Hex()},
+		"Expiry":  &types.AttributeValueMemberN{Value: strconv.FormatUint(voucher.Expiry, 10)},
+	}, condition)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return 0, ErrVoucherBudgetExhausted
+	}
+	if err != nil {
+		return 0, fmt.Errorf("failed to consume voucher of account %s: %w", voucher.Account.Hex(), err)
+	}
+
+	consumedAttr, ok := item["Consumed"].(*types.AttributeValueMemberN)
+	if !ok {
+		return 0, fmt.Errorf("unexpected consumption of voucher of account %s: %v", voucher.Account.Hex(), item["Consumed"])
+	}
+	consumed, err := strconv.ParseUint(consumedAttr.Value, 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse consumption of voucher of account %s: %w", voucher.Account.Hex(), err)
+	}
+	return voucher.ByteBudget - consumed, nil
+}
+
+func (s *dynamoVoucherStore) RefundVoucher(ctx context.Context, voucher *auth.Voucher, numBytes uint64) error {
+	hash := voucher.Hash()
+	// The bytes refunded were consumed, so the consumption never drops below 0
+	condition := expression.GreaterThanEqual(expression.Name("Consumed"), expression.Value(numBytes))
+	_, err := s.client.IncrementItemWithCondition(ctx, s.tableName, commondynamodb.Key{
+		"VoucherHash": &types.AttributeValueMemberS{Value: gethcommon.Bytes2Hex(hash[:])},
+	}, map[string]int64{
+		"Consumed": -int64(numBytes),
+	}, nil, condition)
+	if err != nil {
+		return fmt.Errorf("failed to refund voucher of account %s: %w", voucher.Account.Hex(), err)
+	}
+	return nil
+}
+
+// GenerateVoucherTableSchema returns the schema of the table of the consumption of the vouchers, keyed by the hash of
+// the voucher
+func GenerateVoucherTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("VoucherHash"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("VoucherHash"),
+				KeyType:       types.KeyTypeHash,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
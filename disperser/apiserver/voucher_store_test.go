@@ -0,0 +1,48 @@
+package apiserver_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core/auth"
+	"github.com/Layr-Labs/eigenda/disperser/apiserver"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestLocalVoucherStore(t *testing.T) {
+	ctx := context.Background()
+	store := apiserver.NewLocalVoucherStore()
+	voucher := &auth.Voucher{
+		Account:    gethcommon.HexToAddress("0x1aa8226f6d354380dDE75eE6B634875c4203e522"),
+		ByteBudget: 100,
+		Expiry:     uint64(time.Now().Add(time.Hour).Unix()),
+	}
+
+	remaining, err := store.ConsumeVoucher(ctx, voucher, 60)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(40), remaining)
+
+	// A blob above the remaining budget consumes nothing
+	remaining, err = store.ConsumeVoucher(ctx, voucher, 41)
+	assert.ErrorIs(t, err, apiserver.ErrVoucherBudgetExhausted)
+	assert.Equal(t, uint64(40), remaining)
+
+	remaining, err = store.ConsumeVoucher(ctx, voucher, 40)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), remaining)
+
+	// The bytes of a blob which wasn't accepted are available again once refunded
+	assert.NoError(t, store.RefundVoucher(ctx, voucher, 40))
+	remaining, err = store.ConsumeVoucher(ctx, voucher, 40)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), remaining)
+
+	// Another voucher of the account has its own budget
+	other := *voucher
+	other.Expiry++
+	remaining, err = store.ConsumeVoucher(ctx, &other, 100)
+	assert.NoError(t, err)
+	assert.Equal(t, uint64(0), remaining)
+}
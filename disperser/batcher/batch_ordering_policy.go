@@ -0,0 +1,147 @@
+package batcher
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	// FIFOOrdering batches the blobs in the order they were requested
+	FIFOOrdering = "fifo"
+	// SizeBalancedOrdering packs the blobs into the batch so that it gets as close as possible to the batch size limit
+	SizeBalancedOrdering = "size-balanced"
+	// AccountFairOrdering batches the blobs of the accounts in turn, so that an account can't crowd out the others
+	AccountFairOrdering = "account-fair"
+)
+
+// BatchCandidate is an encoded blob which can be added to the next batch
+type BatchCandidate struct {
+	Metadata *disperser.BlobMetadata
+	// EncodedSize is the size in bytes of the chunks of the blob for all its quorums
+	EncodedSize uint64
+}
+
+// BatchOrderingPolicy selects which of the encoded blobs go into the next batch, and in which order. The blobs which
+// aren't selected are left for the later batches.
+type BatchOrderingPolicy interface {
+	// Order returns the candidates to batch in the order of the batch. Their total size must not exceed maxBatchSize
+	// unless a single candidate does, in which case it is batched alone. The size isn't limited if maxBatchSize is 0.
+	Order(candidates []*BatchCandidate, maxBatchSize uint64) []*BatchCandidate
+}
+
+// NewBatchOrderingPolicy returns the batch ordering policy of the name. The policy defaults to FIFO if the name is
+// empty.
+func NewBatchOrderingPolicy(name string) (BatchOrderingPolicy, error) {
+	switch name {
+	case "", FIFOOrdering:
+		return &fifoOrdering{}, nil
+	case SizeBalancedOrdering:
+		return &sizeBalancedOrdering{}, nil
+	case AccountFairOrdering:
+		return &accountFairOrdering{}, nil
+	default:
+		return nil, fmt.Errorf("unknown batch ordering policy %q, must be one of %s, %s or %s", name, FIFOOrdering, SizeBalancedOrdering, AccountFairOrdering)
+	}
+}
+
+// fifoOrdering batches the oldest blobs until the next one doesn't fit
+type fifoOrdering struct{}
+
+func (*fifoOrdering) Order(candidates []*BatchCandidate, maxBatchSize uint64) []*BatchCandidate {
+	ordered := sortByRequestTime(candidates)
+	batched := make([]*BatchCandidate, 0, len(ordered))
+	size := uint64(0)
+	for _, candidate := range ordered {
+		if !fits(size, candidate, maxBatchSize) && len(batched) > 0 {
+			break
+		}
+		batched = append(batched, candidate)
+		size += candidate.EncodedSize
+	}
+	return batched
+}
+
+// sizeBalancedOrdering batches the oldest blob, so that the large blobs aren't starved, and fills the rest of the
+// batch with the largest blobs which fit
+type sizeBalancedOrdering struct{}
+
+func (*sizeBalancedOrdering) Order(candidates []*BatchCandidate, maxBatchSize uint64) []*BatchCandidate {
+	if len(candidates) == 0 {
+		return []*BatchCandidate{}
+	}
+	ordered := sortByRequestTime(candidates)
+	batched := []*BatchCandidate{ordered[0]}
+	size := ordered[0].EncodedSize
+
+	rest := ordered[1:]
+	sort.SliceStable(rest, func(i, j int) bool {
+		return rest[i].EncodedSize > rest[j].EncodedSize
+	})
+	for _, candidate := range rest {
+		if fits(size, candidate, maxBatchSize) {
+			batched = append(batched, candidate)
+			size += candidate.EncodedSize
+		}
+	}
+	return batched
+}
+
+// accountFairOrdering batches a blob of each account in turn, the oldest first. The accounts are visited in the
+// order of their oldest blobs, and an account is skipped once its next blob doesn't fit.
+type accountFairOrdering struct{}
+
+func (*accountFairOrdering) Order(candidates []*BatchCandidate, maxBatchSize uint64) []*BatchCandidate {
+	queues := make([][]*BatchCandidate, 0)
+	queueByAccount := make(map[string]int)
+	for _, candidate := range sortByRequestTime(candidates) {
+		account := candidate.Metadata.RequestMetadata.AccountID
+		i, ok := queueByAccount[account]
+		if !ok {
+			i = len(queues)
+			queueByAccount[account] = i
+			queues = append(queues, make([]*BatchCandidate, 0))
+		}
+		queues[i] = append(queues[i], candidate)
+	}
+
+	batched := make([]*BatchCandidate, 0, len(candidates))
+	size := uint64(0)
+	for len(queues) > 0 {
+		remaining := queues[:0]
+		for _, queue := range queues {
+			candidate := queue[0]
+			if !fits(size, candidate, maxBatchSize) && len(batched) > 0 {
+				continue
+			}
+			batched = append(batched, candidate)
+			size += candidate.EncodedSize
+			if len(queue) > 1 {
+				remaining = append(remaining, queue[1:])
+			}
+		}
+		queues = remaining
+	}
+	return batched
+}
+
+// sortByRequestTime returns a copy of the candidates sorted by request time, and by blob key among the blobs requested
+// at the same time so that the order is deterministic
+func sortByRequestTime(candidates []*BatchCandidate) []*BatchCandidate {
+	sorted := make([]*BatchCandidate, len(candidates))
+	copy(sorted, candidates)
+	sort.Slice(sorted, func(i, j int) bool {
+		requestedAtI := sorted[i].Metadata.RequestMetadata.RequestedAt
+		requestedAtJ := sorted[j].Metadata.RequestMetadata.RequestedAt
+		if requestedAtI != requestedAtJ {
+			return requestedAtI < requestedAtJ
+		}
+		return sorted[i].Metadata.GetBlobKey().String() < sorted[j].Metadata.GetBlobKey().String()
+	})
+	return sorted
+}
+
+func fits(size uint64, candidate *BatchCandidate, maxBatchSize uint64) bool {
+	return maxBatchSize == 0 || size+candidate.EncodedSize <= maxBatchSize
+}
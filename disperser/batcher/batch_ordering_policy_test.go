@@ -0,0 +1,134 @@
+package batcher_test
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeBatchCandidate(account string, requestedAt uint64, size uint64) *batcher.BatchCandidate {
+	return &batcher.BatchCandidate{
+		Metadata: &disperser.BlobMetadata{
+			BlobHash:     fmt.Sprintf("%s-%d", account, requestedAt),
+			MetadataHash: "hash",
+			RequestMetadata: &disperser.RequestMetadata{
+				BlobRequestHeader: core.BlobRequestHeader{
+					BlobAuthHeader: core.BlobAuthHeader{AccountID: account},
+				},
+				RequestedAt: requestedAt,
+			},
+		},
+		EncodedSize: size,
+	}
+}
+
+func requestTimes(candidates []*batcher.BatchCandidate) []uint64 {
+	times := make([]uint64, len(candidates))
+	for i, candidate := range candidates {
+		times[i] = candidate.Metadata.RequestMetadata.RequestedAt
+	}
+	return times
+}
+
+func TestNewBatchOrderingPolicy(t *testing.T) {
+	for _, name := range []string{"", batcher.FIFOOrdering, batcher.SizeBalancedOrdering, batcher.AccountFairOrdering} {
+		policy, err := batcher.NewBatchOrderingPolicy(name)
+		assert.NoError(t, err)
+		assert.NotNil(t, policy)
+	}
+	_, err := batcher.NewBatchOrderingPolicy("lifo")
+	assert.ErrorContains(t, err, "unknown batch ordering policy")
+}
+
+func TestFIFOOrdering(t *testing.T) {
+	policy, err := batcher.NewBatchOrderingPolicy(batcher.FIFOOrdering)
+	assert.NoError(t, err)
+	candidates := []*batcher.BatchCandidate{
+		makeBatchCandidate("a", 3, 10),
+		makeBatchCandidate("a", 1, 10),
+		makeBatchCandidate("b", 2, 10),
+		makeBatchCandidate("b", 4, 10),
+	}
+
+	assert.Equal(t, []uint64{1, 2, 3, 4}, requestTimes(policy.Order(candidates, 0)))
+	// The batch stops at the first blob which doesn't fit
+	assert.Equal(t, []uint64{1, 2}, requestTimes(policy.Order(candidates, 25)))
+	// A blob larger than the limit is batched alone
+	assert.Equal(t, []uint64{1}, requestTimes(policy.Order(candidates, 5)))
+	assert.Empty(t, policy.Order(nil, 5))
+}
+
+func TestSizeBalancedOrdering(t *testing.T) {
+	policy, err := batcher.NewBatchOrderingPolicy(batcher.SizeBalancedOrdering)
+	assert.NoError(t, err)
+	candidates := []*batcher.BatchCandidate{
+		makeBatchCandidate("a", 1, 30),
+		makeBatchCandidate("a", 2, 50),
+		makeBatchCandidate("a", 3, 10),
+		makeBatchCandidate("a", 4, 60),
+		makeBatchCandidate("a", 5, 10),
+	}
+
+	// The oldest blob is batched first, and the batch is filled up to the limit with the largest blobs which fit
+	batched := policy.Order(candidates, 100)
+	assert.Equal(t, []uint64{1, 4, 3}, requestTimes(batched))
+	size := uint64(0)
+	for _, candidate := range batched {
+		size += candidate.EncodedSize
+	}
+	assert.Equal(t, uint64(100), size)
+
+	// FIFO would stop at the second blob
+	fifo, err := batcher.NewBatchOrderingPolicy(batcher.FIFOOrdering)
+	assert.NoError(t, err)
+	assert.Equal(t, []uint64{1, 2, 3}, requestTimes(fifo.Order(candidates, 100)))
+
+	assert.Equal(t, []uint64{1, 4, 2, 3, 5}, requestTimes(policy.Order(candidates, 0)))
+	assert.Empty(t, policy.Order(nil, 100))
+}
+
+func TestAccountFairOrdering(t *testing.T) {
+	policy, err := batcher.NewBatchOrderingPolicy(batcher.AccountFairOrdering)
+	assert.NoError(t, err)
+
+	// A heavy account floods the disperser before two light accounts request a blob each
+	candidates := make([]*batcher.BatchCandidate, 0)
+	for i := uint64(1); i <= 100; i++ {
+		candidates = append(candidates, makeBatchCandidate("heavy", i, 10))
+	}
+	candidates = append(candidates, makeBatchCandidate("light1", 101, 10))
+	candidates = append(candidates, makeBatchCandidate("light2", 102, 10))
+
+	// FIFO batches only the blobs of the heavy account
+	fifo, err := batcher.NewBatchOrderingPolicy(batcher.FIFOOrdering)
+	assert.NoError(t, err)
+	for _, candidate := range fifo.Order(candidates, 100) {
+		assert.Equal(t, "heavy", candidate.Metadata.RequestMetadata.AccountID)
+	}
+
+	// The accounts are batched in turn, so the light accounts make it into the batch
+	batched := policy.Order(candidates, 100)
+	assert.Len(t, batched, 10)
+	numBlobs := make(map[string]int)
+	for _, candidate := range batched {
+		numBlobs[candidate.Metadata.RequestMetadata.AccountID]++
+	}
+	assert.Equal(t, map[string]int{"heavy": 8, "light1": 1, "light2": 1}, numBlobs)
+	assert.Equal(t, []uint64{1, 101, 102, 2, 3}, requestTimes(batched[:5]))
+
+	// Without a limit, every blob is batched
+	assert.Len(t, policy.Order(candidates, 0), len(candidates))
+
+	// An account whose next blob doesn't fit is skipped, while the others keep their turns
+	candidates = []*batcher.BatchCandidate{
+		makeBatchCandidate("a", 1, 10),
+		makeBatchCandidate("a", 2, 80),
+		makeBatchCandidate("b", 3, 10),
+		makeBatchCandidate("b", 4, 10),
+	}
+	assert.Equal(t, []uint64{1, 3, 4}, requestTimes(policy.Order(candidates, 50)))
+}
@@ -3,13 +3,16 @@ package batcher
 import (
 	"bytes"
 	"context"
+	"encoding/hex"
 	"errors"
 	"fmt"
 	"math"
 	"math/big"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -25,6 +28,8 @@ import (
 const (
 	QuantizationFactor = uint(1)
 	indexerWarmupDelay = 2 * time.Second
+	// unhealthyAfterFailures is the number of batches in a row that fail to be processed before the batcher is unhealthy
+	unhealthyAfterFailures = 5
 )
 
 type BatchPlan struct {
@@ -49,10 +54,14 @@ type TimeoutConfig struct {
 }
 
 type Config struct {
-	PullInterval             time.Duration
-	FinalizerInterval        time.Duration
-	FinalizerPoolSize        int
-	EncoderSocket            string
+	PullInterval      time.Duration
+	FinalizerInterval time.Duration
+	FinalizerPoolSize int
+	EncoderSocket     string
+	// EncoderReplicaSockets are the sockets of the other encoder replicas, to which the encoding requests are hedged
+	// after EncoderHedgingDelay. The requests aren't hedged if it is empty.
+	EncoderReplicaSockets    []string
+	EncoderHedgingDelay      time.Duration
 	SRSOrder                 int
 	NumConnections           int
 	EncodingRequestQueueSize int
@@ -64,6 +73,14 @@ type Config struct {
 
 	TargetNumChunks          uint
 	MaxBlobsToFetchFromStore int
+
+	// ConfirmationPolicies are the quorums which must attest the blobs of the accounts for them to be confirmed
+	ConfirmationPolicies ConfirmationPolicies
+	// BatchOrderingPolicy is the name of the policy selecting the blobs of each batch and their order, FIFO if empty
+	BatchOrderingPolicy string
+	// ConfirmationLockRenewInterval is the interval at which the confirmation lock is acquired or renewed. It must be
+	// well below the lease of the lock. Only used with a ConfirmationLock.
+	ConfirmationLockRenewInterval time.Duration
 }
 
 type Batcher struct {
@@ -82,12 +99,31 @@ type Batcher struct {
 	TransactionManager    TxnManager
 	Metrics               *Metrics
 	HeartbeatChan         chan time.Time
+	// ConfirmationLock elects the batcher which makes and confirms the batches among the batchers sharing the blob
+	// store. It is nil if the batcher is the only one confirming the batches of its blob store.
+	ConfirmationLock ConfirmationLock
 
 	ethClient common.EthClient
 	finalizer Finalizer
+	health    *healthcheck.Tracker
 	logger    logging.Logger
+	// submittedBatches are the blobs of the batches which were submitted for confirmation before the batcher
+	// restarted and may still be confirmed, by batch header hash
+	submittedBatches map[[32]byte][]*disperser.BlobMetadata
+
+	lockMu sync.Mutex
+	// lockedUntil is the time until which the batcher holds the confirmation lock
+	lockedUntil time.Time
+	// recovered is whether the state was recovered since the batcher acquired the confirmation lock
+	recovered bool
 }
 
+// errConfirmationLockNotHeld is returned when the batcher doesn't make a batch because another batcher holds the
+// confirmation lock
+var errConfirmationLockNotHeld = errors.New("confirmation lock is not held")
+
+var _ healthcheck.HealthReporter = (*Batcher)(nil)
+
 func NewBatcher(
 	config Config,
 	timeoutConfig TimeoutConfig,
@@ -109,6 +145,10 @@ func NewBatcher(
 		make(chan struct{}, 1),
 		uint64(config.BatchSizeMBLimit)*1024*1024, // convert to bytes
 	)
+	batchOrderingPolicy, err := NewBatchOrderingPolicy(config.BatchOrderingPolicy)
+	if err != nil {
+		return nil, err
+	}
 	streamerConfig := StreamerConfig{
 		SRSOrder:                 config.SRSOrder,
 		EncodingRequestTimeout:   config.PullInterval,
@@ -117,6 +157,8 @@ func NewBatcher(
 		MaxBlobsToFetchFromStore: config.MaxBlobsToFetchFromStore,
 		FinalizationBlockDelay:   config.FinalizationBlockDelay,
 		ChainStateTimeout:        timeoutConfig.ChainStateTimeout,
+		BatchOrderingPolicy:      batchOrderingPolicy,
+		MaxBatchSize:             uint64(config.BatchSizeMBLimit) * 1024 * 1024,
 	}
 	encodingWorkerPool := workerpool.New(config.NumConnections)
 	encodingStreamer, err := NewEncodingStreamer(streamerConfig, queue, chainState, encoderClient, assignmentCoordinator, batchTrigger, encodingWorkerPool, metrics.EncodingStreamerMetrics, metrics, logger)
@@ -142,11 +184,17 @@ func NewBatcher(
 
 		ethClient:     ethClient,
 		finalizer:     finalizer,
+		health:        healthcheck.NewTracker("Batcher", unhealthyAfterFailures),
 		logger:        logger.With("component", "Batcher"),
 		HeartbeatChan: heartbeatChan,
+
+		submittedBatches: make(map[[32]byte][]*disperser.BlobMetadata),
 	}, nil
 }
 
+// RecoverState moves the blobs which were dispersing when the batcher stopped back to processing, unless the
+// confirmBatch transaction of their batch was submitted. The blobs of a submitted batch are updated if the batch was
+// confirmed onchain, and are kept dispersing until the batch can no longer be confirmed otherwise.
 func (b *Batcher) RecoverState(ctx context.Context) error {
 	b.logger.Info("Recovering state...")
 	start := time.Now()
@@ -156,31 +204,120 @@ func (b *Batcher) RecoverState(ctx context.Context) error {
 	}
 	expired := 0
 	processing := 0
+	submitted := 0
 	for _, meta := range metas {
-		if meta.Expiry == 0 || meta.Expiry < uint64(time.Now().Unix()) {
-			err = b.Queue.MarkBlobFailed(ctx, meta.GetBlobKey())
-			if err != nil {
-				return fmt.Errorf("failed to mark blob (%s) as failed: %w", meta.GetBlobKey(), err)
-			}
+		if meta.ConfirmationSubmission != nil && meta.ConfirmationSubmission.ConfirmationInfo != nil {
+			headerHash := meta.ConfirmationSubmission.ConfirmationInfo.BatchHeaderHash
+			b.submittedBatches[headerHash] = append(b.submittedBatches[headerHash], meta)
+			submitted += 1
+			continue
+		}
+		isExpired, err := b.requeueBlob(ctx, meta)
+		if err != nil {
+			return err
+		}
+		if isExpired {
 			expired += 1
 		} else {
-			err = b.Queue.MarkBlobProcessing(ctx, meta.GetBlobKey())
-			if err != nil {
-				return fmt.Errorf("failed to mark blob (%s) as processing: %w", meta.GetBlobKey(), err)
-			}
 			processing += 1
 		}
 	}
-	b.logger.Info("Recovering state took", "duration", time.Since(start), "numBlobs", len(metas), "expired", expired, "processing", processing)
+	b.resolveSubmittedBatches(ctx)
+	b.logger.Info("Recovering state took", "duration", time.Since(start), "numBlobs", len(metas), "expired", expired, "processing", processing, "submitted", submitted)
 	return nil
 }
 
-func (b *Batcher) Start(ctx context.Context) error {
-	err := b.RecoverState(ctx)
+// requeueBlob moves a dispersing blob back to processing, or marks it failed if it has expired
+func (b *Batcher) requeueBlob(ctx context.Context, meta *disperser.BlobMetadata) (bool, error) {
+	if meta.Expiry == 0 || meta.Expiry < uint64(time.Now().Unix()) {
+		err := b.Queue.MarkBlobFailed(ctx, meta.GetBlobKey())
+		if err != nil {
+			return true, fmt.Errorf("failed to mark blob (%s) as failed: %w", meta.GetBlobKey(), err)
+		}
+		return true, nil
+	}
+	err := b.Queue.MarkBlobProcessing(ctx, meta.GetBlobKey())
 	if err != nil {
-		return fmt.Errorf("failed to recover state: %w", err)
+		return false, fmt.Errorf("failed to mark blob (%s) as processing: %w", meta.GetBlobKey(), err)
+	}
+	return false, nil
+}
+
+// resolveSubmittedBatches checks onchain the batches which were submitted for confirmation before the batcher
+// restarted. It must be called before a new batch is submitted, so that the blobs of a submitted batch aren't
+// confirmed twice.
+func (b *Batcher) resolveSubmittedBatches(ctx context.Context) {
+	for headerHash, blobs := range b.submittedBatches {
+		resolved, err := b.resolveSubmittedBatch(ctx, headerHash, blobs)
+		if err != nil {
+			b.logger.Warn("failed to check the confirmation of a submitted batch, will retry", "batchHeaderHash", hex.EncodeToString(headerHash[:]), "err", err)
+			continue
+		}
+		if resolved {
+			delete(b.submittedBatches, headerHash)
+		}
+	}
+}
+
+// resolveSubmittedBatch updates the blobs of a submitted batch if it was confirmed onchain, and moves them back to
+// processing if it can no longer be confirmed. It returns false if the batch may still be confirmed.
+func (b *Batcher) resolveSubmittedBatch(ctx context.Context, headerHash [32]byte, blobs []*disperser.BlobMetadata) (bool, error) {
+	referenceBlockNumber := blobs[0].ConfirmationSubmission.ConfirmationInfo.ReferenceBlockNumber
+	// The current block number is read before looking up the confirmation, so that a confirmation in a later block
+	// is found
+	currentBlockNumber, err := b.Transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return false, fmt.Errorf("failed to get current block number: %w", err)
 	}
-	err = b.ChainState.Start(ctx)
+	confirmation, err := b.Transactor.GetBatchConfirmation(ctx, headerHash, referenceBlockNumber)
+	if err != nil {
+		return false, err
+	}
+
+	if confirmation == nil {
+		blockStaleMeasure, err := b.Transactor.GetBlockStaleMeasure(ctx)
+		if err != nil {
+			return false, fmt.Errorf("failed to get BLOCK_STALE_MEASURE: %w", err)
+		}
+		// The batch can't be confirmed once its reference block is stale
+		if currentBlockNumber <= referenceBlockNumber+blockStaleMeasure {
+			return false, nil
+		}
+		b.logger.Info("submitted batch wasn't confirmed, dispersing its blobs again", "batchHeaderHash", hex.EncodeToString(headerHash[:]), "numBlobs", len(blobs))
+		for _, meta := range blobs {
+			if _, err := b.requeueBlob(ctx, meta); err != nil {
+				return false, err
+			}
+		}
+		return true, nil
+	}
+
+	b.logger.Info("submitted batch was confirmed onchain", "batchHeaderHash", hex.EncodeToString(headerHash[:]), "batchID", confirmation.BatchID, "txnHash", confirmation.TxnHash.Hex(), "numBlobs", len(blobs))
+	for _, meta := range blobs {
+		confirmationInfo := *meta.ConfirmationSubmission.ConfirmationInfo
+		confirmationInfo.BatchID = confirmation.BatchID
+		confirmationInfo.ConfirmationTxnHash = confirmation.TxnHash
+		confirmationInfo.ConfirmationBlockNumber = confirmation.BlockNumber
+		if err := b.markBlobConfirmation(ctx, meta, meta.ConfirmationSubmission.BlobStatus, &confirmationInfo); err != nil {
+			return false, err
+		}
+	}
+	return true, nil
+}
+
+func (b *Batcher) Start(ctx context.Context) error {
+	// With a confirmation lock, the state is recovered once the lock is acquired, as the blobs dispersing may be
+	// the ones of the batcher holding it
+	if b.ConfirmationLock == nil {
+		err := b.RecoverState(ctx)
+		if err != nil {
+			return fmt.Errorf("failed to recover state: %w", err)
+		}
+	} else {
+		b.renewConfirmationLock(ctx)
+		go b.maintainConfirmationLock(ctx)
+	}
+	err := b.ChainState.Start(ctx)
 	if err != nil {
 		return err
 	}
@@ -205,6 +342,7 @@ func (b *Batcher) Start(ctx context.Context) error {
 				if err != nil {
 					b.logger.Error("failed to process confirmed batch", "err", err)
 				}
+				b.health.Record(err)
 			}
 		}
 	}()
@@ -221,23 +359,11 @@ func (b *Batcher) Start(ctx context.Context) error {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := b.HandleSingleBatch(ctx); err != nil {
-					if errors.Is(err, errNoEncodedResults) {
-						b.logger.Warn("no encoded results to make a batch with")
-					} else {
-						b.logger.Error("failed to process a batch", "err", err)
-					}
-				}
+				b.handleBatch(ctx)
 			case <-batchTrigger.Notify:
 				ticker.Stop()
 
-				if err := b.HandleSingleBatch(ctx); err != nil {
-					if errors.Is(err, errNoEncodedResults) {
-						b.logger.Warn("no encoded results to make a batch with")
-					} else {
-						b.logger.Error("failed to process a batch", "err", err)
-					}
-				}
+				b.handleBatch(ctx)
 				ticker.Reset(b.PullInterval)
 			}
 		}
@@ -246,6 +372,119 @@ func (b *Batcher) Start(ctx context.Context) error {
 	return nil
 }
 
+// handleBatch makes and disperses a batch, and records whether it succeeded for the health of the batcher
+func (b *Batcher) handleBatch(ctx context.Context) {
+	err := b.HandleSingleBatch(ctx)
+	if errors.Is(err, errNoEncodedResults) {
+		// Having nothing to batch isn't a failure of the batcher
+		b.logger.Warn("no encoded results to make a batch with")
+		return
+	}
+	if errors.Is(err, errConfirmationLockNotHeld) {
+		// Standing by while another batcher confirms the batches isn't a failure of the batcher
+		b.logger.Debug("another batcher holds the confirmation lock, standing by")
+		return
+	}
+	if err != nil {
+		b.logger.Error("failed to process a batch", "err", err)
+	}
+	b.health.Record(err)
+}
+
+// maintainConfirmationLock renews the confirmation lock, or tries to take it over, until the context is done, and
+// releases it then
+func (b *Batcher) maintainConfirmationLock(ctx context.Context) {
+	ticker := time.NewTicker(b.ConfirmationLockRenewInterval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			releaseCtx, cancel := context.WithTimeout(context.Background(), b.ChainWriteTimeout)
+			if err := b.ConfirmationLock.Release(releaseCtx); err != nil {
+				b.logger.Warn("failed to release the confirmation lock", "err", err)
+			}
+			cancel()
+			return
+		case <-ticker.C:
+			b.renewConfirmationLock(ctx)
+		}
+	}
+}
+
+// renewConfirmationLock acquires or renews the confirmation lock. The lock is kept until its lease expires if it
+// fails to be renewed, as another batcher can't take it over before then either.
+func (b *Batcher) renewConfirmationLock(ctx context.Context) {
+	until, err := b.ConfirmationLock.TryAcquire(ctx)
+
+	b.lockMu.Lock()
+	defer b.lockMu.Unlock()
+	held := time.Now().Before(b.lockedUntil)
+	switch {
+	case errors.Is(err, ErrConfirmationLockHeld):
+		if held {
+			b.logger.Warn("lost the confirmation lock to another batcher")
+		}
+		b.lockedUntil = time.Time{}
+	case err != nil:
+		b.logger.Warn("failed to renew the confirmation lock", "held", held, "err", err)
+	default:
+		if !held {
+			b.logger.Info("acquired the confirmation lock", "until", until)
+		}
+		b.lockedUntil = until
+	}
+	if !time.Now().Before(b.lockedUntil) {
+		b.recovered = false
+	}
+	b.Metrics.UpdateConfirmationLockHeld(time.Now().Before(b.lockedUntil))
+}
+
+// holdsConfirmationLock returns true if the batcher holds the confirmation lock, or if it doesn't use one
+func (b *Batcher) holdsConfirmationLock() bool {
+	if b.ConfirmationLock == nil {
+		return true
+	}
+	b.lockMu.Lock()
+	defer b.lockMu.Unlock()
+	return time.Now().Before(b.lockedUntil)
+}
+
+// prepareBatchUnderLock returns errConfirmationLockNotHeld if another batcher holds the confirmation lock. Once the
+// batcher acquires the lock, it first recovers the state left by the batcher which held it before.
+func (b *Batcher) prepareBatchUnderLock(ctx context.Context) error {
+	if b.ConfirmationLock == nil {
+		return nil
+	}
+	// A standby batcher tries to take the lock over before each batch too, so that it doesn't wait for the next
+	// renewal once the lease of the batcher holding it expired
+	if !b.holdsConfirmationLock() {
+		b.renewConfirmationLock(ctx)
+	}
+	b.lockMu.Lock()
+	held := time.Now().Before(b.lockedUntil)
+	recovered := b.recovered
+	b.lockMu.Unlock()
+	if !held {
+		return errConfirmationLockNotHeld
+	}
+	if recovered {
+		return nil
+	}
+
+	if err := b.RecoverState(ctx); err != nil {
+		return fmt.Errorf("failed to recover state: %w", err)
+	}
+	b.lockMu.Lock()
+	b.recovered = true
+	b.lockMu.Unlock()
+	return nil
+}
+
+// Health returns the health of the batcher, which is degraded once it fails to disperse or confirm a batch
+func (b *Batcher) Health() healthcheck.ComponentHealth {
+	return b.health.Health()
+}
+
 // updateConfirmationInfo updates the confirmation info for each blob in the batch and returns failed blobs to retry.
 func (b *Batcher) updateConfirmationInfo(
 	ctx context.Context,
@@ -280,71 +519,92 @@ func (b *Batcher) updateConfirmationInfo(
 	}
 
 	blobsToRetry := make([]*disperser.BlobMetadata, 0)
-	var updateConfirmationInfoErr error
-
 	for blobIndex, metadata := range batchData.blobs {
-		// Mark the blob failed if it didn't get enough signatures.
-		status := disperser.InsufficientSignatures
-
-		var proof []byte
-		if isBlobAttested(batchData.aggSig.QuorumResults, batchData.blobHeaders[blobIndex]) {
-			status = disperser.Confirmed
-			// generate inclusion proof
-			if blobIndex >= len(batchData.blobHeaders) {
-				b.logger.Error("HandleSingleBatch: error confirming blobs: blob header not found in batch", "index", blobIndex)
-				blobsToRetry = append(blobsToRetry, batchData.blobs[blobIndex])
-				continue
-			}
+		submission, err := b.newConfirmationSubmission(batchData, headerHash, blobIndex)
+		if err != nil {
+			b.logger.Error("HandleSingleBatch: error confirming blobs", "index", blobIndex, "err", err)
+			blobsToRetry = append(blobsToRetry, metadata)
+			continue
+		}
+		confirmationInfo := submission.ConfirmationInfo
+		confirmationInfo.BatchID = batchID
+		confirmationInfo.ConfirmationTxnHash = txnReceipt.TxHash
+		confirmationInfo.ConfirmationBlockNumber = uint32(txnReceipt.BlockNumber.Uint64())
+
+		if err := b.markBlobConfirmation(ctx, metadata, submission.BlobStatus, confirmationInfo); err != nil {
+			b.logger.Error("HandleSingleBatch: error updating blob confirmed metadata", "err", err)
+			blobsToRetry = append(blobsToRetry, metadata)
+		}
+	}
 
-			merkleProof, err := batchData.merkleTree.GenerateProofWithIndex(uint64(blobIndex), 0)
-			if err != nil {
-				b.logger.Error("HandleSingleBatch: failed to generate blob header inclusion proof", "err", err)
-				blobsToRetry = append(blobsToRetry, batchData.blobs[blobIndex])
-				continue
-			}
-			proof = serializeProof(merkleProof)
-		}
-
-		confirmationInfo := &disperser.ConfirmationInfo{
-			BatchHeaderHash:         headerHash,
-			BlobIndex:               uint32(blobIndex),
-			SignatoryRecordHash:     core.ComputeSignatoryRecordHash(uint32(batchData.batchHeader.ReferenceBlockNumber), batchData.aggSig.NonSigners),
-			ReferenceBlockNumber:    uint32(batchData.batchHeader.ReferenceBlockNumber),
-			BatchRoot:               batchData.batchHeader.BatchRoot[:],
-			BlobInclusionProof:      proof,
-			BlobCommitment:          &batchData.blobHeaders[blobIndex].BlobCommitments,
-			BatchID:                 uint32(batchID),
-			ConfirmationTxnHash:     txnReceipt.TxHash,
-			ConfirmationBlockNumber: uint32(txnReceipt.BlockNumber.Uint64()),
-			Fee:                     []byte{0}, // No fee
-			QuorumResults:           batchData.aggSig.QuorumResults,
-			BlobQuorumInfos:         batchData.blobHeaders[blobIndex].QuorumInfos,
-		}
-
-		if status == disperser.Confirmed {
-			if _, updateConfirmationInfoErr = b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
-				b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.Confirmed)
-			}
-		} else if status == disperser.InsufficientSignatures {
-			if _, updateConfirmationInfoErr = b.Queue.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo); updateConfirmationInfoErr == nil {
-				b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.InsufficientSignatures)
-			}
-		} else {
-			updateConfirmationInfoErr = fmt.Errorf("HandleSingleBatch: trying to update confirmation info for blob in status other than confirmed or insufficient signatures: %s", status.String())
+	return blobsToRetry, nil
+}
+
+// newConfirmationSubmission returns the status and the confirmation info of the blob at the given index in the batch
+// once the batch is confirmed. The blob is confirmed if the quorums required by the confirmation policy of its account
+// attested it, and gets an inclusion proof.
+func (b *Batcher) newConfirmationSubmission(batchData confirmationMetadata, headerHash [32]byte, blobIndex int) (*disperser.ConfirmationSubmission, error) {
+	if blobIndex >= len(batchData.blobHeaders) || blobIndex >= len(batchData.blobs) {
+		return nil, errors.New("blob header not found in batch")
+	}
+	blobHeader := batchData.blobHeaders[blobIndex]
+
+	// Mark the blob failed if it didn't get enough signatures.
+	status := disperser.InsufficientSignatures
+	var proof []byte
+	if b.isBlobConfirmed(batchData.aggSig.QuorumResults, batchData.blobs[blobIndex], blobHeader) {
+		status = disperser.Confirmed
+		// generate inclusion proof
+		merkleProof, err := batchData.merkleTree.GenerateProofWithIndex(uint64(blobIndex), 0)
+		if err != nil {
+			return nil, fmt.Errorf("failed to generate blob header inclusion proof: %w", err)
 		}
-		if updateConfirmationInfoErr != nil {
-			b.logger.Error("HandleSingleBatch: error updating blob confirmed metadata", "err", updateConfirmationInfoErr)
-			blobsToRetry = append(blobsToRetry, batchData.blobs[blobIndex])
+		proof = serializeProof(merkleProof)
+	}
+
+	return &disperser.ConfirmationSubmission{
+		BlobStatus: status,
+		ConfirmationInfo: &disperser.ConfirmationInfo{
+			BatchHeaderHash:      headerHash,
+			BlobIndex:            uint32(blobIndex),
+			SignatoryRecordHash:  core.ComputeSignatoryRecordHash(uint32(batchData.batchHeader.ReferenceBlockNumber), batchData.aggSig.NonSigners),
+			ReferenceBlockNumber: uint32(batchData.batchHeader.ReferenceBlockNumber),
+			BatchRoot:            batchData.batchHeader.BatchRoot[:],
+			BlobInclusionProof:   proof,
+			BlobCommitment:       &blobHeader.BlobCommitments,
+			Fee:                  []byte{0}, // No fee
+			QuorumResults:        batchData.aggSig.QuorumResults,
+			BlobQuorumInfos:      blobHeader.QuorumInfos,
+		},
+	}, nil
+}
+
+// markBlobConfirmation updates the blob to the given status, Confirmed or InsufficientSignatures, once its batch is
+// confirmed
+func (b *Batcher) markBlobConfirmation(ctx context.Context, metadata *disperser.BlobMetadata, status disperser.BlobStatus, confirmationInfo *disperser.ConfirmationInfo) error {
+	switch status {
+	case disperser.Confirmed:
+		if _, err := b.Queue.MarkBlobConfirmed(ctx, metadata, confirmationInfo); err != nil {
+			return err
 		}
-		requestTime := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
-		b.Metrics.ObserveLatency("E2E", float64(time.Since(requestTime).Milliseconds()))
-		b.Metrics.ObserveBlobAge("confirmed", float64(time.Since(requestTime).Milliseconds()))
-		for _, quorumInfo := range batchData.blobHeaders[blobIndex].QuorumInfos {
-			b.Metrics.IncrementBlobSize("confirmed", quorumInfo.QuorumID, int(metadata.RequestMetadata.BlobSize))
+		b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.Confirmed)
+		b.Metrics.ObserveTimeToFinality("confirmed", metadata.RequestMetadata.RequestedAt)
+	case disperser.InsufficientSignatures:
+		if _, err := b.Queue.MarkBlobInsufficientSignatures(ctx, metadata, confirmationInfo); err != nil {
+			return err
 		}
+		b.Metrics.UpdateCompletedBlob(int(metadata.RequestMetadata.BlobSize), disperser.InsufficientSignatures)
+	default:
+		return fmt.Errorf("trying to update confirmation info for blob in status other than confirmed or insufficient signatures: %s", status.String())
 	}
 
-	return blobsToRetry, nil
+	requestTime := time.Unix(0, int64(metadata.RequestMetadata.RequestedAt))
+	b.Metrics.ObserveLatency("E2E", float64(time.Since(requestTime).Milliseconds()))
+	b.Metrics.ObserveBlobAge("confirmed", float64(time.Since(requestTime).Milliseconds()))
+	for _, quorumInfo := range confirmationInfo.BlobQuorumInfos {
+		b.Metrics.IncrementBlobSize("confirmed", quorumInfo.QuorumID, int(metadata.RequestMetadata.BlobSize))
+	}
+	return nil
 }
 
 func (b *Batcher) ProcessConfirmedBatch(ctx context.Context, receiptOrErr *ReceiptOrErr) error {
@@ -455,6 +715,15 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	}))
 	defer timer.ObserveDuration()
 
+	if err := b.prepareBatchUnderLock(ctx); err != nil {
+		return err
+	}
+
+	// Check the batches submitted before a restart, before any new batch is submitted
+	if len(b.submittedBatches) > 0 {
+		b.resolveSubmittedBatches(ctx)
+	}
+
 	stageTimer := time.Now()
 	batch, err := b.EncodingStreamer.CreateBatch(ctx)
 	if err != nil {
@@ -516,7 +785,13 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 
 	b.observeBlobAgeAndSize("attested", batch)
 
-	numPassed, passedQuorums := numBlobsAttestedByQuorum(quorumAttestation.QuorumResults, batch.BlobHeaders)
+	_, passedQuorums := numBlobsAttestedByQuorum(quorumAttestation.QuorumResults, batch.BlobHeaders)
+	numPassed := 0
+	for i, header := range batch.BlobHeaders {
+		if b.isBlobConfirmed(quorumAttestation.QuorumResults, batch.BlobMetadata[i], header) {
+			numPassed++
+		}
+	}
 	// TODO(mooselumph): Determine whether to confirm the batch based on the number of successes
 	if numPassed == 0 {
 		_ = b.handleFailure(ctx, batch.BlobMetadata, FailNoSignatures)
@@ -542,19 +817,31 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	// Confirm the batch
 	log.Debug("Confirming batch...")
 
+	// The lease is renewed right before the submission, so that another batcher can't take the lock over and submit
+	// a conflicting batch while this one is in flight
+	if b.ConfirmationLock != nil {
+		b.renewConfirmationLock(ctx)
+		if !b.holdsConfirmationLock() {
+			_ = b.handleFailure(ctx, batch.BlobMetadata, FailConfirmationLock)
+			return fmt.Errorf("HandleSingleBatch: lost the confirmation lock before confirming the batch: %w", errConfirmationLockNotHeld)
+		}
+	}
+
 	txn, err := b.Transactor.BuildConfirmBatchTxn(ctx, batch.BatchHeader, aggSig.QuorumResults, aggSig)
 	if err != nil {
 		_ = b.handleFailure(ctx, batch.BlobMetadata, FailConfirmBatch)
 		return fmt.Errorf("HandleSingleBatch: error building confirmBatch transaction: %w", err)
 	}
-	err = b.TransactionManager.ProcessTransaction(ctx, NewTxnRequest(txn, "confirmBatch", big.NewInt(0), confirmationMetadata{
+	batchData := confirmationMetadata{
 		batchID:     uuid.Nil,
 		batchHeader: batch.BatchHeader,
 		blobs:       batch.BlobMetadata,
 		blobHeaders: batch.BlobHeaders,
 		merkleTree:  batch.MerkleTree,
 		aggSig:      aggSig,
-	}))
+	}
+	b.recordConfirmationSubmission(ctx, batchData, headerHash)
+	err = b.TransactionManager.ProcessTransaction(ctx, NewTxnRequest(txn, "confirmBatch", big.NewInt(0), batchData))
 	if err != nil {
 		_ = b.handleFailure(ctx, batch.BlobMetadata, FailConfirmBatch)
 		return fmt.Errorf("HandleSingleBatch: error sending confirmBatch transaction: %w", err)
@@ -563,6 +850,26 @@ func (b *Batcher) HandleSingleBatch(ctx context.Context) error {
 	return nil
 }
 
+// recordConfirmationSubmission records on the blobs of the batch that its confirmBatch transaction is about to be
+// submitted. A blob whose submission fails to be recorded is dispersed again if the batcher restarts before the receipt
+// is processed, as if the submission wasn't recorded.
+func (b *Batcher) recordConfirmationSubmission(ctx context.Context, batchData confirmationMetadata, headerHash [32]byte) {
+	numFailed := 0
+	for blobIndex, metadata := range batchData.blobs {
+		submission, err := b.newConfirmationSubmission(batchData, headerHash, blobIndex)
+		if err == nil {
+			err = b.Queue.RecordConfirmationSubmission(ctx, metadata, submission)
+		}
+		if err != nil {
+			b.logger.Warn("failed to record confirmation submission", "blobKey", metadata.GetBlobKey().String(), "err", err)
+			numFailed++
+		}
+	}
+	if numFailed > 0 {
+		b.logger.Error("failed to record confirmation submission of some blobs", "failed", numFailed, "total", len(batchData.blobs))
+	}
+}
+
 func serializeProof(proof *merkletree.Proof) []byte {
 	proofBytes := make([]byte, 0)
 	for _, hash := range proof.Hashes {
@@ -172,7 +172,7 @@ func makeBatcher(t *testing.T) (*batcherComponents, *bat.Batcher, func() []time.
 
 func queueBlob(t *testing.T, ctx context.Context, blob *core.Blob, blobStore disperser.BlobStore) (uint64, disperser.BlobKey) {
 	requestedAt := uint64(time.Now().UnixNano())
-	blobKey, err := blobStore.StoreBlob(ctx, blob, requestedAt)
+	blobKey, err := blobStore.StoreBlob(ctx, blob, requestedAt, disperser.RequestOrigin{})
 	assert.NoError(t, err)
 
 	return requestedAt, blobKey
@@ -836,3 +836,238 @@ func TestBatcherRecoverState(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, b2.BlobStatus, disperser.Failed)
 }
+
+func TestBatcherRecoverSubmittedBatch(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              1,
+		AdversaryThreshold:    70,
+		ConfirmationThreshold: 100,
+	}})
+	blob3 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	components.dispatcher.On("DisperseBatch").Return(map[core.OperatorID]struct{}{})
+
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+	_, blobKey2 := queueBlob(t, ctx, &blob2, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+
+	// The batch is submitted, but the batcher restarts before it processes the receipt
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	meta1, err := blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Dispersing, meta1.BlobStatus)
+	assert.NotNil(t, meta1.ConfirmationSubmission)
+	assert.Equal(t, disperser.Confirmed, meta1.ConfirmationSubmission.BlobStatus)
+	headerHash := meta1.ConfirmationSubmission.ConfirmationInfo.BatchHeaderHash
+	referenceBlockNumber := meta1.ConfirmationSubmission.ConfirmationInfo.ReferenceBlockNumber
+
+	// Another batch was submitted earlier, and can no longer be confirmed
+	_, blobKey3 := queueBlob(t, ctx, &blob3, blobStore)
+	err = blobStore.MarkBlobDispersing(ctx, blobKey3)
+	assert.NoError(t, err)
+	meta3, err := blobStore.GetBlobMetadata(ctx, blobKey3)
+	assert.NoError(t, err)
+	staleHeaderHash := [32]byte{1}
+	err = blobStore.RecordConfirmationSubmission(ctx, meta3, &disperser.ConfirmationSubmission{
+		BlobStatus: disperser.Confirmed,
+		ConfirmationInfo: &disperser.ConfirmationInfo{
+			BatchHeaderHash:      staleHeaderHash,
+			ReferenceBlockNumber: referenceBlockNumber - 1,
+		},
+	})
+	assert.NoError(t, err)
+
+	// Neither batch is confirmed onchain yet, and the first one can still be confirmed
+	components.transactor.On("GetCurrentBlockNumber").Return(referenceBlockNumber, nil)
+	components.transactor.On("GetBlockStaleMeasure").Return(nil)
+	pending := components.transactor.On("GetBatchConfirmation", headerHash).Return(nil, nil)
+	components.transactor.On("GetBatchConfirmation", staleHeaderHash).Return(nil, nil)
+	err = batcher.RecoverState(ctx)
+	assert.NoError(t, err)
+
+	meta1, err = blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Dispersing, meta1.BlobStatus)
+	meta2, err := blobStore.GetBlobMetadata(ctx, blobKey2)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Dispersing, meta2.BlobStatus)
+	meta3, err = blobStore.GetBlobMetadata(ctx, blobKey3)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta3.BlobStatus)
+
+	// The submitted batch gets confirmed, which is found before the next batch
+	pending.Unset()
+	txHash := gethcommon.HexToHash("0x1234")
+	components.transactor.On("GetBatchConfirmation", headerHash).Return(&core.BatchConfirmation{
+		BatchID:     3,
+		TxnHash:     txHash,
+		BlockNumber: 123,
+	}, nil)
+	_ = batcher.HandleSingleBatch(ctx)
+
+	for _, blobKey := range []disperser.BlobKey{blobKey1, blobKey2} {
+		meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+		assert.NoError(t, err)
+		assert.Equal(t, disperser.Confirmed, meta.BlobStatus)
+		assert.Equal(t, headerHash, meta.ConfirmationInfo.BatchHeaderHash)
+		assert.Equal(t, uint32(3), meta.ConfirmationInfo.BatchID)
+		assert.Equal(t, txHash, meta.ConfirmationInfo.ConfirmationTxnHash)
+		assert.Equal(t, uint32(123), meta.ConfirmationInfo.ConfirmationBlockNumber)
+		assert.NotEmpty(t, meta.ConfirmationInfo.BlobInclusionProof)
+	}
+	components.transactor.AssertNumberOfCalls(t, "GetBatchConfirmation", 3)
+}
+
+// fakeConfirmationLock grants the confirmation lock for as long as held is true
+type fakeConfirmationLock struct {
+	mu       sync.Mutex
+	held     bool
+	acquired int
+}
+
+func (l *fakeConfirmationLock) TryAcquire(context.Context) (time.Time, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	if !l.held {
+		return time.Time{}, bat.ErrConfirmationLockHeld
+	}
+	l.acquired++
+	return time.Now().Add(time.Minute), nil
+}
+
+func (l *fakeConfirmationLock) Release(context.Context) error {
+	return nil
+}
+
+func (l *fakeConfirmationLock) setHeld(held bool) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.held = held
+}
+
+func TestBatcherConfirmationLock(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	components.dispatcher.On("DisperseBatch").Return(map[core.OperatorID]struct{}{})
+	lock := &fakeConfirmationLock{}
+	batcher.ConfirmationLock = lock
+
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+
+	// Another batcher holds the lock, so this one stands by without touching the blobs
+	err = batcher.HandleSingleBatch(ctx)
+	assert.ErrorContains(t, err, "confirmation lock is not held")
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta.BlobStatus)
+	count, _ := components.encodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+	assert.Equal(t, 1, count)
+
+	// The lock is taken over, and the batch is confirmed under it
+	lock.setHeld(true)
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+	assert.Len(t, components.txnManager.Requests, 1)
+	// The lock is acquired before the batch, and renewed before its confirmation
+	assert.Equal(t, 2, lock.acquired)
+	meta, err = blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Dispersing, meta.BlobStatus)
+}
+
+func TestBatcherLosesConfirmationLock(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	lock := &fakeConfirmationLock{held: true}
+	batcher.ConfirmationLock = lock
+	// The lock is taken over by another batcher while the batch is dispersed
+	components.dispatcher.On("DisperseBatch").Run(func(mock.Arguments) {
+		lock.setHeld(false)
+	}).Return(map[core.OperatorID]struct{}{})
+
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.NoError(t, err)
+
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+
+	// The batch isn't confirmed, and its blob is dispersed again
+	err = batcher.HandleSingleBatch(ctx)
+	assert.ErrorContains(t, err, "lost the confirmation lock")
+	assert.Empty(t, components.txnManager.Requests)
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta.BlobStatus)
+	assert.Equal(t, uint(1), meta.NumRetries)
+}
+
+func TestBatcherRecoverStateOnConfirmationLockTakeover(t *testing.T) {
+	blob := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	batcher.ConfirmationLock = &fakeConfirmationLock{held: true}
+
+	// The blob was left dispersing by the batcher which held the lock before
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey := queueBlob(t, ctx, &blob, blobStore)
+	err := blobStore.MarkBlobDispersing(ctx, blobKey)
+	assert.NoError(t, err)
+
+	_ = batcher.HandleSingleBatch(ctx)
+	meta, err := blobStore.GetBlobMetadata(ctx, blobKey)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Processing, meta.BlobStatus)
+}
@@ -0,0 +1,122 @@
+package batcher
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"strconv"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/expression"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+)
+
+// confirmationLockName is the key of the item of the confirmation lock in its table
+const confirmationLockName = "batch-confirmation"
+
+// ErrConfirmationLockHeld is returned when the confirmation lock is held by another batcher
+var ErrConfirmationLockHeld = errors.New("confirmation lock is held by another batcher")
+
+// ConfirmationLock elects the batcher which makes and confirms the batches among the batchers sharing a blob store,
+// so that they never submit conflicting confirmBatch transactions. The lock is a lease, which another batcher takes
+// over once the batcher holding it stops renewing it.
+type ConfirmationLock interface {
+	// TryAcquire acquires the lock, or renews it if this batcher already holds it, and returns the time until which it
+	// is held. It returns ErrConfirmationLockHeld if another batcher holds the lock.
+	TryAcquire(ctx context.Context) (time.Time, error)
+	// Release releases the lock if this batcher holds it, so that another batcher can take it over without waiting
+	// for the lease to expire
+	Release(ctx context.Context) error
+}
+
+type dynamoConfirmationLock struct {
+	client        *commondynamodb.Client
+	tableName     string
+	owner         string
+	leaseDuration time.Duration
+}
+
+var _ ConfirmationLock = (*dynamoConfirmationLock)(nil)
+
+// NewDynamoConfirmationLock returns a ConfirmationLock backed by the DynamoDB table of
+// GenerateConfirmationLockTableSchema. The owner identifies the batcher and must be unique among the batchers sharing
+// the table. The lease is compared with the clocks of the batchers, so it must be well above their clock skew.
+func NewDynamoConfirmationLock(client *commondynamodb.Client, tableName string, owner string, leaseDuration time.Duration) ConfirmationLock {
+	return &dynamoConfirmationLock{
+		client:        client,
+		tableName:     tableName,
+		owner:         owner,
+		leaseDuration: leaseDuration,
+	}
+}
+
+func (l *dynamoConfirmationLock) TryAcquire(ctx context.Context) (time.Time, error) {
+	// The lease is counted from before the request, so that this batcher never believes it holds the lock for longer
+	// than the others do
+	now := time.Now()
+	until := now.Add(l.leaseDuration)
+	condition := expression.Or(
+		expression.AttributeNotExists(expression.Name("Owner")),
+		expression.Equal(expression.Name("Owner"), expression.Value(l.owner)),
+		expression.LessThan(expression.Name("ExpiresAt"), expression.Value(now.UnixMilli())),
+	)
+	_, err := l.client.IncrementItemWithCondition(ctx, l.tableName, l.key(), nil, commondynamodb.Item{
+		"Owner":     &types.AttributeValueMemberS{Value: l.owner},
+		"ExpiresAt": &types.AttributeValueMemberN{Value: strconv.FormatInt(until.UnixMilli(), 10)},
+	}, condition)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		return time.Time{}, ErrConfirmationLockHeld
+	}
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to acquire confirmation lock: %w", err)
+	}
+	return until, nil
+}
+
+func (l *dynamoConfirmationLock) Release(ctx context.Context) error {
+	condition := expression.Equal(expression.Name("Owner"), expression.Value(l.owner))
+	_, err := l.client.IncrementItemWithCondition(ctx, l.tableName, l.key(), nil, commondynamodb.Item{
+		"ExpiresAt": &types.AttributeValueMemberN{Value: "0"},
+	}, condition)
+	if errors.Is(err, commondynamodb.ErrConditionFailed) {
+		// Another batcher already took the lock over
+		return nil
+	}
+	if err != nil {
+		return fmt.Errorf("failed to release confirmation lock: %w", err)
+	}
+	return nil
+}
+
+func (l *dynamoConfirmationLock) key() commondynamodb.Key {
+	return commondynamodb.Key{
+		"LockName": &types.AttributeValueMemberS{Value: confirmationLockName},
+	}
+}
+
+// GenerateConfirmationLockTableSchema returns the schema of the table of the confirmation lock, keyed by the name of
+// the lock
+func GenerateConfirmationLockTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("LockName"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("LockName"),
+				KeyType:       types.KeyTypeHash,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
@@ -0,0 +1,104 @@
+package batcher
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+// QuorumConfirmationPolicy specifies which of the quorums of a blob must attest it for the blob to be confirmed. The
+// blob is marked InsufficientSignatures otherwise.
+type QuorumConfirmationPolicy struct {
+	// RequiredQuorums are the quorums which must attest the blob, among the quorums it is dispersed to. All the quorums
+	// of the blob are required if none of them is listed.
+	RequiredQuorums []core.QuorumID
+	// AnyQuorum confirms the blob once any of its required quorums attests it, instead of all of them
+	AnyQuorum bool
+}
+
+// ConfirmationPolicies are the quorum confirmation policies of the accounts, by account ID. The blobs of the other
+// accounts must be attested by all their quorums.
+type ConfirmationPolicies map[core.AccountID]QuorumConfirmationPolicy
+
+// ConfirmationPolicyEntry is an entry of the file of confirmation policies
+type ConfirmationPolicyEntry struct {
+	Account         string  `json:"account"`
+	RequiredQuorums []uint8 `json:"requiredQuorums"`
+	AnyQuorum       bool    `json:"anyQuorum"`
+}
+
+// ReadConfirmationPoliciesFromFile reads the confirmation policies of the accounts from a JSON file holding a list of
+// ConfirmationPolicyEntry. There is no policy if the file name is empty.
+func ReadConfirmationPoliciesFromFile(f string) (ConfirmationPolicies, error) {
+	policies := make(ConfirmationPolicies)
+	if f == "" {
+		return policies, nil
+	}
+
+	content, err := os.ReadFile(f)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read confirmation policies file: %w", err)
+	}
+	var entries []ConfirmationPolicyEntry
+	if err := json.Unmarshal(content, &entries); err != nil {
+		return nil, fmt.Errorf("failed to parse confirmation policies file: %w", err)
+	}
+
+	for _, entry := range entries {
+		if entry.Account == "" {
+			return nil, errors.New("confirmation policy entry without account")
+		}
+		if _, ok := policies[entry.Account]; ok {
+			return nil, fmt.Errorf("duplicate confirmation policy of account %s", entry.Account)
+		}
+		requiredQuorums := make([]core.QuorumID, len(entry.RequiredQuorums))
+		for i, quorumID := range entry.RequiredQuorums {
+			requiredQuorums[i] = core.QuorumID(quorumID)
+		}
+		policies[entry.Account] = QuorumConfirmationPolicy{
+			RequiredQuorums: requiredQuorums,
+			AnyQuorum:       entry.AnyQuorum,
+		}
+	}
+	return policies, nil
+}
+
+// isAttested returns whether the quorums required by the policy attested the blob
+func (p QuorumConfirmationPolicy) isAttested(signedQuorums map[core.QuorumID]*core.QuorumResult, header *core.BlobHeader) bool {
+	required := make([]*core.BlobQuorumInfo, 0, len(header.QuorumInfos))
+	for _, quorumInfo := range header.QuorumInfos {
+		for _, quorumID := range p.RequiredQuorums {
+			if quorumInfo.QuorumID == quorumID {
+				required = append(required, quorumInfo)
+				break
+			}
+		}
+	}
+	if len(required) == 0 {
+		required = header.QuorumInfos
+	}
+
+	numAttested := 0
+	for _, quorumInfo := range required {
+		if result, ok := signedQuorums[quorumInfo.QuorumID]; ok && result.PercentSigned >= quorumInfo.ConfirmationThreshold {
+			numAttested++
+		}
+	}
+	if p.AnyQuorum {
+		return numAttested > 0
+	}
+	return numAttested == len(required)
+}
+
+// isBlobConfirmed returns whether the blob is attested by the quorums required by the confirmation policy of its account
+func (b *Batcher) isBlobConfirmed(signedQuorums map[core.QuorumID]*core.QuorumResult, metadata *disperser.BlobMetadata, header *core.BlobHeader) bool {
+	policy, ok := b.ConfirmationPolicies[metadata.RequestMetadata.BlobAuthHeader.AccountID]
+	if !ok {
+		return isBlobAttested(signedQuorums, header)
+	}
+	return policy.isAttested(signedQuorums, header)
+}
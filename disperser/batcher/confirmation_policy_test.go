@@ -0,0 +1,119 @@
+package batcher_test
+
+import (
+	"context"
+	"encoding/hex"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestReadConfirmationPoliciesFromFile(t *testing.T) {
+	policies, err := bat.ReadConfirmationPoliciesFromFile("")
+	assert.NoError(t, err)
+	assert.Empty(t, policies)
+
+	f := filepath.Join(t.TempDir(), "policies.json")
+	err = os.WriteFile(f, []byte(`[
+		{"account": "0x1aa8226f6d354380dDE75eE6B634875c4203e522", "requiredQuorums": [0]},
+		{"account": "1.2.3.4", "requiredQuorums": [0, 1], "anyQuorum": true}
+	]`), 0644)
+	assert.NoError(t, err)
+	policies, err = bat.ReadConfirmationPoliciesFromFile(f)
+	assert.NoError(t, err)
+	assert.Equal(t, bat.ConfirmationPolicies{
+		"0x1aa8226f6d354380dDE75eE6B634875c4203e522": {RequiredQuorums: []core.QuorumID{0}},
+		"1.2.3.4": {RequiredQuorums: []core.QuorumID{0, 1}, AnyQuorum: true},
+	}, policies)
+
+	err = os.WriteFile(f, []byte(`[{"account": "1.2.3.4"}, {"account": "1.2.3.4", "anyQuorum": true}]`), 0644)
+	assert.NoError(t, err)
+	_, err = bat.ReadConfirmationPoliciesFromFile(f)
+	assert.ErrorContains(t, err, "duplicate confirmation policy")
+}
+
+func TestBatcherConfirmationPolicies(t *testing.T) {
+	securityParams := []*core.SecurityParam{
+		{
+			QuorumID:              0,
+			AdversaryThreshold:    80,
+			ConfirmationThreshold: 100,
+		},
+		{
+			QuorumID:              2,
+			AdversaryThreshold:    80,
+			ConfirmationThreshold: 100,
+		},
+	}
+	// The blob of the account with a policy only requires quorum 0
+	blob0 := makeTestBlob(securityParams)
+	blob0.RequestHeader.AccountID = "1.2.3.4"
+	blob1 := makeTestBlob(securityParams)
+	blob1.RequestHeader.AccountID = "5.6.7.8"
+
+	components, batcher, _ := makeBatcher(t)
+	batcher.ConfirmationPolicies = bat.ConfirmationPolicies{
+		"1.2.3.4": {RequiredQuorums: []core.QuorumID{0}},
+	}
+
+	blobStore := components.blobStore
+	ctx := context.Background()
+	_, blobKey0 := queueBlob(t, ctx, &blob0, blobStore)
+	_, blobKey1 := queueBlob(t, ctx, &blob1, blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	err := components.encodingStreamer.RequestEncoding(ctx, out)
+	assert.NoError(t, err)
+	for i := 0; i < 4; i++ {
+		err = components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+		assert.NoError(t, err)
+	}
+
+	// Quorum 2 doesn't reach its confirmation threshold
+	components.dispatcher.On("DisperseBatch").Return(map[core.OperatorID]struct{}{
+		coremock.MakeOperatorId(5): {},
+	})
+	txn := types.NewTransaction(0, gethcommon.Address{}, big.NewInt(0), 0, big.NewInt(0), nil)
+	components.transactor.On("BuildConfirmBatchTxn", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(txn, nil)
+	components.txnManager.On("ProcessTransaction").Return(nil)
+
+	err = batcher.HandleSingleBatch(ctx)
+	assert.NoError(t, err)
+
+	logData, err := hex.DecodeString("00000000000000000000000000000000000000000000000000000000000000030000000000000000000000000000000000000000000000000000000000000000")
+	assert.NoError(t, err)
+	err = batcher.ProcessConfirmedBatch(ctx, &bat.ReceiptOrErr{
+		Receipt: &types.Receipt{
+			Logs: []*types.Log{
+				{
+					Topics: []gethcommon.Hash{common.BatchConfirmedEventSigHash, gethcommon.HexToHash("1234")},
+					Data:   logData,
+				},
+			},
+			BlockNumber: big.NewInt(123),
+			TxHash:      gethcommon.HexToHash("0x1234"),
+		},
+		Metadata: components.txnManager.Requests[len(components.txnManager.Requests)-1].Metadata,
+	})
+	assert.NoError(t, err)
+
+	meta0, err := blobStore.GetBlobMetadata(ctx, blobKey0)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.Confirmed, meta0.BlobStatus)
+	assert.NotEmpty(t, meta0.ConfirmationInfo.BlobInclusionProof)
+
+	meta1, err := blobStore.GetBlobMetadata(ctx, blobKey1)
+	assert.NoError(t, err)
+	assert.Equal(t, disperser.InsufficientSignatures, meta1.BlobStatus)
+}
@@ -15,7 +15,8 @@ type requestID string
 type encodedBlobStore struct {
 	mu sync.RWMutex
 
-	requested map[requestID]struct{}
+	// requested are the quorums of the encoding requests in flight
+	requested map[requestID]core.QuorumID
 	encoded   map[requestID]*EncodingResult
 	// encodedResultSize is the total size of all the chunks in the encoded results in bytes
 	encodedResultSize uint64
@@ -42,7 +43,7 @@ type EncodingResultOrStatus struct {
 
 func newEncodedBlobStore(logger logging.Logger) *encodedBlobStore {
 	return &encodedBlobStore{
-		requested:         make(map[requestID]struct{}),
+		requested:         make(map[requestID]core.QuorumID),
 		encoded:           make(map[requestID]*EncodingResult),
 		encodedResultSize: 0,
 		logger:            logger,
@@ -54,7 +55,7 @@ func (e *encodedBlobStore) PutEncodingRequest(blobKey disperser.BlobKey, quorumI
 	defer e.mu.Unlock()
 
 	requestID := getRequestID(blobKey, quorumID)
-	e.requested[requestID] = struct{}{}
+	e.requested[requestID] = quorumID
 }
 
 func (e *encodedBlobStore) HasEncodingRequested(blobKey disperser.BlobKey, quorumID core.QuorumID, referenceBlockNumber uint) bool {
@@ -191,6 +192,22 @@ func (e *encodedBlobStore) GetEncodedResultSize() (int, uint64) {
 	return len(e.encoded), e.encodedResultSize
 }
 
+// GetQueueSizesByQuorum returns the number of encoding requests in flight and of encoded results for each quorum
+func (e *encodedBlobStore) GetQueueSizesByQuorum() (map[core.QuorumID]int, map[core.QuorumID]int) {
+	e.mu.RLock()
+	defer e.mu.RUnlock()
+
+	requested := make(map[core.QuorumID]int)
+	for _, quorumID := range e.requested {
+		requested[quorumID]++
+	}
+	encoded := make(map[core.QuorumID]int)
+	for _, result := range e.encoded {
+		encoded[result.BlobQuorumInfo.QuorumID]++
+	}
+	return requested, encoded
+}
+
 func getRequestID(key disperser.BlobKey, quorumID core.QuorumID) requestID {
 	return requestID(fmt.Sprintf("%s-%d", key.String(), quorumID))
 }
@@ -52,6 +52,12 @@ type StreamerConfig struct {
 	MaxBlobsToFetchFromStore int
 
 	FinalizationBlockDelay uint
+
+	// BatchOrderingPolicy selects the encoded blobs of each batch and their order. The blobs are batched in the order
+	// they were requested if it is nil.
+	BatchOrderingPolicy BatchOrderingPolicy
+	// MaxBatchSize is the maximum size in bytes of the chunks of a batch. The size isn't limited if it is 0.
+	MaxBatchSize uint64
 }
 
 type EncodingStreamer struct {
@@ -70,6 +76,8 @@ type EncodingStreamer struct {
 	assignmentCoordinator core.AssignmentCoordinator
 
 	encodingCtxCancelFuncs []context.CancelFunc
+	// encodingLatency is the latency of the latest successful encodings, reported by the operations endpoint
+	encodingLatency *latencyWindow
 
 	metrics        *EncodingStreamerMetrics
 	batcherMetrics *Metrics
@@ -110,6 +118,9 @@ func NewEncodingStreamer(
 	if config.EncodingQueueLimit <= 0 {
 		return nil, errors.New("EncodingQueueLimit should be greater than 0")
 	}
+	if config.BatchOrderingPolicy == nil {
+		config.BatchOrderingPolicy = &fifoOrdering{}
+	}
 	return &EncodingStreamer{
 		StreamerConfig:         config,
 		EncodedBlobstore:       newEncodedBlobStore(logger),
@@ -121,6 +132,7 @@ func NewEncodingStreamer(
 		encoderClient:          encoderClient,
 		assignmentCoordinator:  assignmentCoordinator,
 		encodingCtxCancelFuncs: make([]context.CancelFunc, 0),
+		encodingLatency:        newLatencyWindow(opsLatencyWindow),
 		metrics:                metrics,
 		batcherMetrics:         batcherMetrics,
 		logger:                 logger.With("component", "EncodingStreamer"),
@@ -410,6 +422,7 @@ func (e *EncodingStreamer) RequestEncodingForBlob(ctx context.Context, metadata
 				},
 				Err: nil,
 			}
+			e.encodingLatency.Record(time.Since(start))
 			e.metrics.ObserveEncodingLatency("success", res.BlobQuorumInfo.QuorumID, len(blob.Data), float64(time.Since(start).Milliseconds()))
 		})
 		e.EncodedBlobstore.PutEncodingRequest(blobKey, res.BlobQuorumInfo.QuorumID)
@@ -513,6 +526,7 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 	blobQuorums := make(map[disperser.BlobKey][]*core.BlobQuorumInfo)
 	blobHeaderByKey := make(map[disperser.BlobKey]*core.BlobHeader)
 	metadataByKey := make(map[disperser.BlobKey]*disperser.BlobMetadata)
+	encodedSizeByKey := make(map[disperser.BlobKey]uint64)
 	for i := range encodedResults {
 		// each result represent an encoded result per (blob, quorum param)
 		// if the same blob has been dispersed multiple time with different security params,
@@ -546,6 +560,7 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 		}
 
 		blobQuorums[blobKey] = append(blobQuorums[blobKey], result.BlobQuorumInfo)
+		encodedSizeByKey[blobKey] += getChunksSize(result)
 	}
 
 	// Populate the blob quorum infos
@@ -574,11 +589,23 @@ func (e *EncodingStreamer) CreateBatch(ctx context.Context) (*batch, error) {
 		return nil, errNoEncodedResults
 	}
 
+	// Select the blobs of the batch. The other blobs stay in the encoded blob store, and are encoded again at the
+	// reference block of a later batch.
+	candidates := make([]*BatchCandidate, 0, len(metadataByKey))
+	for key, metadata := range metadataByKey {
+		candidates = append(candidates, &BatchCandidate{Metadata: metadata, EncodedSize: encodedSizeByKey[key]})
+	}
+	batched := e.BatchOrderingPolicy.Order(candidates, e.MaxBatchSize)
+	if len(batched) < len(candidates) {
+		e.logger.Info("deferring blobs to a later batch", "numBatched", len(batched), "numDeferred", len(candidates)-len(batched))
+	}
+
 	// Transform maps to slices so orders in different slices match
-	encodedBlobs := make([]core.EncodedBlob, 0, len(metadataByKey))
-	blobHeaders := make([]*core.BlobHeader, 0, len(metadataByKey))
-	metadatas := make([]*disperser.BlobMetadata, 0, len(metadataByKey))
-	for key := range metadataByKey {
+	encodedBlobs := make([]core.EncodedBlob, 0, len(batched))
+	blobHeaders := make([]*core.BlobHeader, 0, len(batched))
+	metadatas := make([]*disperser.BlobMetadata, 0, len(batched))
+	for _, candidate := range batched {
+		key := candidate.Metadata.GetBlobKey()
 		err := e.transitionBlobToDispersing(ctx, metadataByKey[key])
 		if err != nil {
 			continue
@@ -105,7 +105,7 @@ func TestEncodingQueueLimit(t *testing.T) {
 	pool.On("WaitingQueueSize").Return(streamerConfig.EncodingQueueLimit).Once()
 
 	ctx := context.Background()
-	key, err := blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	key, err := blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	out := make(chan batcher.EncodingResultOrStatus, 1)
 	// This should return without making a request since encoding queue was already full
@@ -150,7 +150,7 @@ func TestBatchTrigger(t *testing.T) {
 		ConfirmationThreshold: 100,
 	}})
 	ctx := context.Background()
-	_, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	_, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	out := make(chan batcher.EncodingResultOrStatus)
 	// Request encoding
@@ -181,7 +181,7 @@ func TestBatchTrigger(t *testing.T) {
 	}
 
 	// Request encoding once more
-	_, err = c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	_, err = c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	err = encodingStreamer.RequestEncoding(context.Background(), out)
 	assert.Nil(t, err)
@@ -209,7 +209,7 @@ func TestStreamingEncoding(t *testing.T) {
 		ConfirmationThreshold: 100,
 	}})
 	ctx := context.Background()
-	metadataKey, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	metadataKey, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	metadata, err := c.blobStore.GetBlobMetadata(ctx, metadataKey)
 	assert.Nil(t, err)
@@ -262,7 +262,7 @@ func TestStreamingEncoding(t *testing.T) {
 	assert.False(t, isRequested)
 	// Request another blob again
 	requestedAt := uint64(time.Now().UnixNano())
-	metadataKey, err = c.blobStore.StoreBlob(ctx, &blob, requestedAt)
+	metadataKey, err = c.blobStore.StoreBlob(ctx, &blob, requestedAt, disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	err = encodingStreamer.RequestEncoding(context.Background(), out)
 	assert.Nil(t, err)
@@ -284,7 +284,7 @@ func TestStreamingEncoding(t *testing.T) {
 	assert.Equal(t, size, uint64(26630))
 
 	// Request the same blob, which should be dedupped
-	_, err = c.blobStore.StoreBlob(ctx, &blob, requestedAt)
+	_, err = c.blobStore.StoreBlob(ctx, &blob, requestedAt, disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	err = encodingStreamer.RequestEncoding(context.Background(), out)
 	assert.Nil(t, err)
@@ -332,7 +332,7 @@ func TestEncodingFailure(t *testing.T) {
 		ConfirmationThreshold: 100,
 	}})
 
-	metadataKey, err := blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	metadataKey, err := blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 
 	cst.On("GetCurrentBlockNumber").Return(uint(10)+encodingStreamer.FinalizationBlockDelay, nil)
@@ -376,7 +376,7 @@ func TestPartialBlob(t *testing.T) {
 		ConfirmationThreshold: 100,
 	}})
 
-	metadataKey1, err := c.blobStore.StoreBlob(ctx, &blob1, uint64(time.Now().UnixNano()))
+	metadataKey1, err := c.blobStore.StoreBlob(ctx, &blob1, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	metadata1, err := c.blobStore.GetBlobMetadata(ctx, metadataKey1)
 	assert.Nil(t, err)
@@ -402,7 +402,7 @@ func TestPartialBlob(t *testing.T) {
 		AdversaryThreshold:    70,
 		ConfirmationThreshold: 95,
 	}})
-	metadataKey2, err := c.blobStore.StoreBlob(ctx, &blob2, uint64(time.Now().UnixNano()))
+	metadataKey2, err := c.blobStore.StoreBlob(ctx, &blob2, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	metadata2, err := c.blobStore.GetBlobMetadata(ctx, metadataKey2)
 	assert.Nil(t, err)
@@ -508,7 +508,7 @@ func TestIncorrectParameters(t *testing.T) {
 	_, err := rand.Read(blob.Data)
 	assert.NoError(t, err)
 
-	metadataKey, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()))
+	metadataKey, err := c.blobStore.StoreBlob(ctx, &blob, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 
 	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(10)+encodingStreamer.FinalizationBlockDelay, nil)
@@ -560,9 +560,9 @@ func TestInvalidQuorum(t *testing.T) {
 		ConfirmationThreshold: 100,
 	}})
 
-	metadataKey1, err := c.blobStore.StoreBlob(ctx, &blob1, uint64(time.Now().UnixNano()))
+	metadataKey1, err := c.blobStore.StoreBlob(ctx, &blob1, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
-	metadataKey2, err := c.blobStore.StoreBlob(ctx, &blob2, uint64(time.Now().UnixNano()))
+	metadataKey2, err := c.blobStore.StoreBlob(ctx, &blob2, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 
 	// request encoding
@@ -603,12 +603,12 @@ func TestGetBatch(t *testing.T) {
 		AdversaryThreshold:    75,
 		ConfirmationThreshold: 100,
 	}})
-	metadataKey1, err := c.blobStore.StoreBlob(ctx, &blob1, uint64(time.Now().UnixNano()))
+	metadataKey1, err := c.blobStore.StoreBlob(ctx, &blob1, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	metadata1, err := c.blobStore.GetBlobMetadata(ctx, metadataKey1)
 	assert.Nil(t, err)
 	assert.Equal(t, disperser.Processing, metadata1.BlobStatus)
-	metadataKey2, err := c.blobStore.StoreBlob(ctx, &blob2, uint64(time.Now().UnixNano()))
+	metadataKey2, err := c.blobStore.StoreBlob(ctx, &blob2, uint64(time.Now().UnixNano()), disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	metadata2, err := c.blobStore.GetBlobMetadata(ctx, metadataKey2)
 	assert.Nil(t, err)
@@ -749,3 +749,50 @@ func TestGetBatch(t *testing.T) {
 	assert.Contains(t, batch.BlobMetadata, metadata1)
 	assert.Contains(t, batch.BlobMetadata, metadata2)
 }
+
+func TestCreateBatchDefersBlobsOverSizeLimit(t *testing.T) {
+	limitedConfig := streamerConfig
+	// Each blob is larger than the limit, so only the oldest is batched
+	limitedConfig.MaxBatchSize = 1
+	encodingStreamer, c := createEncodingStreamer(t, 10, 1e12, limitedConfig)
+	ctx := context.Background()
+
+	securityParams := []*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}}
+	blob1 := makeTestBlob(securityParams)
+	blob2 := makeTestBlob(securityParams)
+	requestedAt := uint64(time.Now().UnixNano())
+	metadataKey1, err := c.blobStore.StoreBlob(ctx, &blob1, requestedAt, disperser.RequestOrigin{})
+	assert.Nil(t, err)
+	metadataKey2, err := c.blobStore.StoreBlob(ctx, &blob2, requestedAt+1, disperser.RequestOrigin{})
+	assert.Nil(t, err)
+
+	c.chainDataMock.On("GetCurrentBlockNumber").Return(uint(10)+encodingStreamer.FinalizationBlockDelay, nil)
+	out := make(chan batcher.EncodingResultOrStatus)
+	err = encodingStreamer.RequestEncoding(ctx, out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	err = encodingStreamer.ProcessEncodedBlobs(ctx, <-out)
+	assert.Nil(t, err)
+	encodingStreamer.Pool.StopWait()
+
+	batch, err := encodingStreamer.CreateBatch(ctx)
+	assert.Nil(t, err)
+	assert.Len(t, batch.BlobMetadata, 1)
+	assert.Equal(t, metadataKey1, batch.BlobMetadata[0].GetBlobKey())
+	metadata1, err := c.blobStore.GetBlobMetadata(ctx, metadataKey1)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Dispersing, metadata1.BlobStatus)
+
+	// The other blob is left for a later batch
+	metadata2, err := c.blobStore.GetBlobMetadata(ctx, metadataKey2)
+	assert.Nil(t, err)
+	assert.Equal(t, disperser.Processing, metadata2.BlobStatus)
+	res, err := encodingStreamer.EncodedBlobstore.GetEncodingResult(metadataKey2, core.QuorumID(0))
+	assert.Nil(t, err)
+	assert.NotNil(t, res)
+}
@@ -5,9 +5,11 @@ import (
 	"errors"
 	"fmt"
 	"math"
+	"sync"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/ethereum/go-ethereum"
@@ -22,6 +24,8 @@ const baseDelay = 1 * time.Second
 
 // Finalizer runs periodically to finalize blobs that have been confirmed
 type Finalizer interface {
+	healthcheck.HealthReporter
+
 	Start(ctx context.Context)
 	FinalizeBlobs(ctx context.Context) error
 }
@@ -35,10 +39,17 @@ type finalizer struct {
 	maxNumRetriesPerBlob uint
 	numBlobsPerFetch     int32
 	numWorkers           int
+	health               *healthcheck.Tracker
 	logger               logging.Logger
 	metrics              *FinalizerMetrics
+
+	// ops is the state of the latest run reported to the operations endpoint
+	opsMu sync.Mutex
+	ops   FinalizerOps
 }
 
+var _ FinalizerOpsReporter = (*finalizer)(nil)
+
 func NewFinalizer(
 	timeout time.Duration,
 	loopInterval time.Duration,
@@ -60,6 +71,7 @@ func NewFinalizer(
 		maxNumRetriesPerBlob: maxNumRetriesPerBlob,
 		numBlobsPerFetch:     numBlobsPerFetch,
 		numWorkers:           numWorkers,
+		health:               healthcheck.NewTracker("Finalizer", unhealthyAfterFailures),
 		logger:               logger.With("component", "Finalizer"),
 		metrics:              metrics,
 	}
@@ -75,14 +87,28 @@ func (f *finalizer) Start(ctx context.Context) {
 			case <-ctx.Done():
 				return
 			case <-ticker.C:
-				if err := f.FinalizeBlobs(ctx); err != nil {
+				err := f.FinalizeBlobs(ctx)
+				if err != nil {
 					f.logger.Error("failed to finalize blobs", "err", err)
 				}
+				f.health.Record(err)
 			}
 		}
 	}()
 }
 
+// Health returns the health of the finalizer, which is degraded once it fails to finalize blobs
+func (f *finalizer) Health() healthcheck.ComponentHealth {
+	return f.health.Health()
+}
+
+// FinalizerOps returns the backlog found by the latest run of the finalizer
+func (f *finalizer) FinalizerOps() FinalizerOps {
+	f.opsMu.Lock()
+	defer f.opsMu.Unlock()
+	return f.ops
+}
+
 // FinalizeBlobs checks the latest finalized block and marks blobs in `confirmed` state as `finalized` if their confirmation
 // block number is less than or equal to the latest finalized block number.
 // If it failes to process some blobs, it will log the error, skip the failed blobs, and will not return an error. The function should be invoked again to retry.
@@ -121,6 +147,13 @@ func (f *finalizer) FinalizeBlobs(ctx context.Context) error {
 	pool.StopWait()
 
 	f.logger.Info("FinalizeBlobs: successfully processed all finalized blobs", "finalizedBlockNumber", lastFinalBlock, "totalProcessed", totalProcessed, "elapsedTime", time.Since(startTime))
+	f.opsMu.Lock()
+	f.ops = FinalizerOps{
+		Backlog:           totalProcessed,
+		LastRunAt:         &startTime,
+		LastRunDurationMs: float64(time.Since(startTime).Microseconds()) / 1000,
+	}
+	f.opsMu.Unlock()
 	f.metrics.UpdateLastSeenFinalizedBlock(lastFinalBlock)
 	f.metrics.UpdateNumBlobs("processed", totalProcessed)
 	f.metrics.ObserveLatency("total", float64(time.Since(startTime).Milliseconds()))
@@ -215,6 +248,9 @@ func (f *finalizer) updateBlobs(ctx context.Context, metadatas []*disperser.Blob
 			continue
 		}
 		f.metrics.IncrementNumBlobs("finalized")
+		if m.RequestMetadata != nil {
+			f.metrics.ObserveTimeToFinality("finalized", m.RequestMetadata.RequestedAt)
+		}
 		f.metrics.ObserveLatency("round", float64(time.Since(stageTimer).Milliseconds()))
 	}
 }
@@ -48,9 +48,9 @@ func TestFinalizedBlob(t *testing.T) {
 		AdversaryThreshold: 80,
 	}})
 	ctx := context.Background()
-	metadataKey1, err := queue.StoreBlob(ctx, &blob, requestedAt)
+	metadataKey1, err := queue.StoreBlob(ctx, &blob, requestedAt, disperser.RequestOrigin{})
 	assert.NoError(t, err)
-	metadataKey2, err := queue.StoreBlob(ctx, &blob, requestedAt+1)
+	metadataKey2, err := queue.StoreBlob(ctx, &blob, requestedAt+1, disperser.RequestOrigin{})
 	assert.NoError(t, err)
 	batchHeaderHash := [32]byte{1, 2, 3}
 	blobIndex := uint32(10)
@@ -103,8 +103,14 @@ func TestFinalizedBlob(t *testing.T) {
 	assert.Equal(t, disperser.Confirmed, m.BlobStatus)
 	assert.NoError(t, err)
 
+	reporter, ok := finalizer.(batcher.FinalizerOpsReporter)
+	assert.True(t, ok)
+	assert.Nil(t, reporter.FinalizerOps().LastRunAt)
 	err = finalizer.FinalizeBlobs(context.Background())
 	assert.NoError(t, err)
+	// Both confirmed blobs were found by the run
+	assert.Equal(t, 2, reporter.FinalizerOps().Backlog)
+	assert.NotNil(t, reporter.FinalizerOps().LastRunAt)
 
 	metadatas, err := queue.GetBlobMetadataByStatus(ctx, disperser.Confirmed)
 	assert.NoError(t, err)
@@ -146,7 +152,7 @@ func TestUnfinalizedBlob(t *testing.T) {
 		QuorumID:           0,
 		AdversaryThreshold: 80,
 	}})
-	metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt)
+	metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt, disperser.RequestOrigin{})
 	assert.NoError(t, err)
 	batchHeaderHash := [32]byte{1, 2, 3}
 	blobIndex := uint32(10)
@@ -217,7 +223,7 @@ func TestNoReceipt(t *testing.T) {
 		QuorumID:           0,
 		AdversaryThreshold: 80,
 	}})
-	metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt)
+	metadataKey, err := queue.StoreBlob(ctx, &blob, requestedAt, disperser.RequestOrigin{})
 	assert.NoError(t, err)
 	batchHeaderHash := [32]byte{1, 2, 3}
 	blobIndex := uint32(10)
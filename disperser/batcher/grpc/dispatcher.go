@@ -4,11 +4,13 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"sync"
 	"time"
 
-	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	"github.com/Layr-Labs/eigenda/api/conversion"
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -21,24 +23,68 @@ import (
 type Config struct {
 	Timeout                   time.Duration
 	EnableGnarkBundleEncoding bool
+	// RequestSigner signs the requests sent to the operators so that they can authenticate the disperser.
+	// Requests are not signed if it is nil.
+	RequestSigner core.DispersalRequestSigner
+	// Quarantine configures the quarantine of the operators which repeatedly fail to store the chunks
+	Quarantine QuarantineConfig
 }
 
 type dispatcher struct {
 	*Config
 
-	logger  logging.Logger
-	metrics *batcher.DispatcherMetrics
+	logger     logging.Logger
+	metrics    *batcher.DispatcherMetrics
+	quarantine *quarantine
+	inFlight   *inFlightRequests
+}
+
+// inFlightRequests counts the requests awaiting the reply of each operator
+type inFlightRequests struct {
+	mu     sync.Mutex
+	counts map[core.OperatorID]int
+}
+
+// start counts a request to the operator until the returned function is called
+func (r *inFlightRequests) start(id core.OperatorID) func() {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.counts[id]++
+	return func() {
+		r.mu.Lock()
+		defer r.mu.Unlock()
+		r.counts[id]--
+		if r.counts[id] == 0 {
+			delete(r.counts, id)
+		}
+	}
 }
 
 func NewDispatcher(cfg *Config, logger logging.Logger, metrics *batcher.DispatcherMetrics) *dispatcher {
 	return &dispatcher{
-		Config:  cfg,
-		logger:  logger.With("component", "Dispatcher"),
-		metrics: metrics,
+		Config:     cfg,
+		logger:     logger.With("component", "Dispatcher"),
+		metrics:    metrics,
+		quarantine: newQuarantine(cfg.Quarantine),
+		inFlight:   &inFlightRequests{counts: make(map[core.OperatorID]int)},
 	}
 }
 
 var _ disperser.Dispatcher = (*dispatcher)(nil)
+var _ batcher.DispatcherOpsReporter = (*dispatcher)(nil)
+
+// DispatcherOps returns the number of requests in flight to each operator
+func (c *dispatcher) DispatcherOps() batcher.DispatcherOps {
+	c.inFlight.mu.Lock()
+	defer c.inFlight.mu.Unlock()
+	ops := batcher.DispatcherOps{
+		InFlightByOperator: make(map[string]int, len(c.inFlight.counts)),
+	}
+	for id, count := range c.inFlight.counts {
+		ops.InFlightByOperator[id.Hex()] = count
+	}
+	return ops
+}
 
 func (c *dispatcher) DisperseBatch(ctx context.Context, state *core.IndexedOperatorState, blobs []core.EncodedBlob, batchHeader *core.BatchHeader) chan core.SigningMessage {
 	update := make(chan core.SigningMessage, len(state.IndexedOperators))
@@ -79,10 +125,17 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 				}
 				return
 			}
+			if c.quarantine.isQuarantined(id) {
+				update <- c.quarantinedMessage(id, batchHeaderHash)
+				return
+			}
 
 			requestedAt := time.Now()
-			sig, err := c.sendChunks(ctx, blobMessages, batchHeader, &op)
+			done := c.inFlight.start(id)
+			sig, excludedBlobs, err := c.sendChunks(ctx, blobMessages, batchHeader, &op)
+			done()
 			latencyMs := float64(time.Since(requestedAt).Milliseconds())
+			c.recordResult(ctx, id, err)
 			if err != nil {
 				update <- core.SigningMessage{
 					Err:                  err,
@@ -97,6 +150,7 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 					Signature:            sig,
 					Operator:             id,
 					BatchHeaderHash:      batchHeaderHash,
+					ExcludedBlobs:        excludedBlobs,
 					AttestationLatencyMs: latencyMs,
 					Err:                  nil,
 				}
@@ -111,7 +165,32 @@ func (c *dispatcher) sendAllChunks(ctx context.Context, state *core.IndexedOpera
 	}
 }
 
-func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, error) {
+// quarantinedMessage is the signing message of a quarantined operator, which is not sent the batch
+func (c *dispatcher) quarantinedMessage(id core.OperatorID, batchHeaderHash [32]byte) core.SigningMessage {
+	c.metrics.IncrementQuarantinedRequests()
+	return core.SigningMessage{
+		Err:                  ErrOperatorQuarantined,
+		Signature:            nil,
+		Operator:             id,
+		BatchHeaderHash:      batchHeaderHash,
+		AttestationLatencyMs: -1,
+	}
+}
+
+// recordResult records the result of a request to the operator for its quarantine
+func (c *dispatcher) recordResult(ctx context.Context, id core.OperatorID, err error) {
+	if !c.quarantine.enabled() {
+		return
+	}
+	if c.quarantine.recordResult(ctx, id, err) {
+		c.logger.Warn("Quarantining operator after repeated dispersal failures", "operator", id.Hex(), "duration", c.Quarantine.Duration, "err", err)
+	}
+	c.metrics.SetQuarantinedOperators(c.quarantine.numQuarantined())
+}
+
+// sendChunks sends the chunks of a batch to an operator and returns its signature, along with the blobs
+// the operator excluded from its attestation if any.
+func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, core.BlobExclusionBitmap, error) {
 	// TODO Add secure Grpc
 
 	conn, err := grpc.Dial(
@@ -120,7 +199,7 @@ func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.EncodedBlobMe
 	)
 	if err != nil {
 		c.logger.Warn("Disperser cannot connect to operator dispersal socket", "dispersal_socket", core.OperatorSocket(op.Socket).GetDispersalSocket(), "err", err)
-		return nil, err
+		return nil, nil, err
 	}
 	defer conn.Close()
 
@@ -130,23 +209,29 @@ func (c *dispatcher) sendChunks(ctx context.Context, blobs []*core.EncodedBlobMe
 	start := time.Now()
 	request, totalSize, err := GetStoreChunksRequest(blobs, batchHeader, c.EnableGnarkBundleEncoding)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, nil, err
+	}
+	ctx, err = c.signRequest(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, nil, err
 	}
 	c.logger.Debug("sending chunks to operator", "operator", op.Socket, "num blobs", len(blobs), "size", totalSize, "request message size", proto.Size(request), "request serialization time", time.Since(start), "use Gnark chunk encoding", c.EnableGnarkBundleEncoding)
 	opt := grpc.MaxCallSendMsgSize(60 * 1024 * 1024 * 1024)
 	reply, err := gc.StoreChunks(ctx, request, opt)
 
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
-	sigBytes := reply.GetSignature()
-	point, err := new(core.Signature).Deserialize(sigBytes)
+	sig, err := conversion.SignatureFromProto(reply.GetSignature())
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
-	sig := &core.Signature{G1Point: point}
-	return sig, nil
+	return sig, core.BlobExclusionBitmap(reply.GetExcludedBlobs()), nil
 }
 
 // SendBlobsToOperator sends blobs to an operator via the node's StoreBlobs endpoint
@@ -173,6 +258,17 @@ func (c *dispatcher) SendBlobsToOperator(ctx context.Context, blobs []*core.Enco
 	if err != nil {
 		return nil, err
 	}
+	blobHeaderHashes := make([][32]byte, len(blobs))
+	for i, blob := range blobs {
+		blobHeaderHashes[i], err = blob.BlobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to get blob header hash: %w", err)
+		}
+	}
+	ctx, err = c.signRequest(ctx, auth.StoreBlobsRequestDigest(uint32(batchHeader.ReferenceBlockNumber), blobHeaderHashes))
+	if err != nil {
+		return nil, err
+	}
 	c.logger.Debug("sending chunks to operator", "operator", op.Socket, "num blobs", len(blobs), "size", totalSize, "request message size", proto.Size(request), "request serialization time", time.Since(start), "use Gnark chunk encoding", c.EnableGnarkBundleEncoding)
 	opt := grpc.MaxCallSendMsgSize(60 * 1024 * 1024 * 1024)
 	reply, err := gc.StoreBlobs(ctx, request, opt)
@@ -186,11 +282,11 @@ func (c *dispatcher) SendBlobsToOperator(ctx context.Context, blobs []*core.Enco
 	for _, sigBytes := range signaturesInBytes {
 		sig := sigBytes.GetValue()
 		if sig != nil {
-			point, err := new(core.Signature).Deserialize(sig)
+			signature, err := conversion.SignatureFromProto(sig)
 			if err != nil {
 				return nil, err
 			}
-			signatures = append(signatures, &core.Signature{G1Point: point})
+			signatures = append(signatures, signature)
 		} else {
 			signatures = append(signatures, nil)
 		}
@@ -207,6 +303,10 @@ func (c *dispatcher) AttestBatch(ctx context.Context, state *core.IndexedOperato
 
 	for id, op := range state.IndexedOperators {
 		go func(op core.IndexedOperatorInfo, id core.OperatorID) {
+			if c.quarantine.isQuarantined(id) {
+				responseChan <- c.quarantinedMessage(id, batchHeaderHash)
+				return
+			}
 			conn, err := grpc.Dial(
 				core.OperatorSocket(op.Socket).GetDispersalSocket(),
 				grpc.WithTransportCredentials(insecure.NewCredentials()),
@@ -220,8 +320,11 @@ func (c *dispatcher) AttestBatch(ctx context.Context, state *core.IndexedOperato
 			nodeClient := node.NewDispersalClient(conn)
 
 			requestedAt := time.Now()
+			done := c.inFlight.start(id)
 			sig, err := c.SendAttestBatchRequest(ctx, nodeClient, blobHeaderHashes, batchHeader, &op)
+			done()
 			latencyMs := float64(time.Since(requestedAt).Milliseconds())
+			c.recordResult(ctx, id, err)
 			if err != nil {
 				responseChan <- core.SigningMessage{
 					Err:                  err,
@@ -260,10 +363,22 @@ func (c *dispatcher) SendAttestBatchRequest(ctx context.Context, nodeDispersalCl
 		hashes[i] = hash[:]
 	}
 
+	batchHeaderMessage, err := conversion.BatchHeaderToProto(batchHeader)
+	if err != nil {
+		return nil, err
+	}
 	request := &node.AttestBatchRequest{
-		BatchHeader:      getBatchHeaderMessage(batchHeader),
+		BatchHeader:      batchHeaderMessage,
 		BlobHeaderHashes: hashes,
 	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch header hash: %w", err)
+	}
+	ctx, err = c.signRequest(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to sign AttestBatch request: %w", err)
+	}
 
 	c.logger.Debug("sending AttestBatch request to operator", "operator", op.Socket, "numBlobs", len(blobHeaderHashes), "requestMessageSize", proto.Size(request), "referenceBlockNumber", batchHeader.ReferenceBlockNumber)
 	opt := grpc.MaxCallSendMsgSize(60 * 1024 * 1024 * 1024)
@@ -272,12 +387,19 @@ func (c *dispatcher) SendAttestBatchRequest(ctx context.Context, nodeDispersalCl
 		return nil, fmt.Errorf("failed to send AttestBatch request to operator %s: %w", core.OperatorSocket(op.Socket).GetDispersalSocket(), err)
 	}
 
-	sigBytes := reply.GetSignature()
-	point, err := new(core.Signature).Deserialize(sigBytes)
+	sig, err := conversion.SignatureFromProto(reply.GetSignature())
 	if err != nil {
 		return nil, fmt.Errorf("failed to deserialize signature: %w", err)
 	}
-	return &core.Signature{G1Point: point}, nil
+	return sig, nil
+}
+
+// signRequest attaches the disperser's signature of the request digest to the outgoing context, if a signer is configured.
+func (c *dispatcher) signRequest(ctx context.Context, requestDigest [32]byte) (context.Context, error) {
+	if c.RequestSigner == nil {
+		return ctx, nil
+	}
+	return auth.AppendDispersalSignatureToOutgoingContext(ctx, c.RequestSigner, requestDigest)
 }
 
 func GetStoreChunksRequest(blobMessages []*core.EncodedBlobMessage, batchHeader *core.BatchHeader, useGnarkBundleEncoding bool) (*node.StoreChunksRequest, int64, error) {
@@ -292,8 +414,12 @@ func GetStoreChunksRequest(blobMessages []*core.EncodedBlobMessage, batchHeader
 		totalSize += getBundlesSize(blob)
 	}
 
+	batchHeaderMessage, err := conversion.BatchHeaderToProto(batchHeader)
+	if err != nil {
+		return nil, 0, err
+	}
 	request := &node.StoreChunksRequest{
-		BatchHeader: getBatchHeaderMessage(batchHeader),
+		BatchHeader: batchHeaderMessage,
 		Blobs:       blobs,
 	}
 
@@ -324,40 +450,12 @@ func getBlobMessage(blob *core.EncodedBlobMessage, useGnarkBundleEncoding bool)
 	if blob.BlobHeader == nil {
 		return nil, errors.New("blob header is nil")
 	}
-	if blob.BlobHeader.Commitment == nil {
-		return nil, errors.New("blob header commitment is nil")
-	}
-	commitData := &commonpb.G1Commitment{
-		X: blob.BlobHeader.Commitment.X.Marshal(),
-		Y: blob.BlobHeader.Commitment.Y.Marshal(),
-	}
-	var lengthCommitData, lengthProofData node.G2Commitment
-	if blob.BlobHeader.LengthCommitment != nil {
-		lengthCommitData.XA0 = blob.BlobHeader.LengthCommitment.X.A0.Marshal()
-		lengthCommitData.XA1 = blob.BlobHeader.LengthCommitment.X.A1.Marshal()
-		lengthCommitData.YA0 = blob.BlobHeader.LengthCommitment.Y.A0.Marshal()
-		lengthCommitData.YA1 = blob.BlobHeader.LengthCommitment.Y.A1.Marshal()
-	}
-	if blob.BlobHeader.LengthProof != nil {
-		lengthProofData.XA0 = blob.BlobHeader.LengthProof.X.A0.Marshal()
-		lengthProofData.XA1 = blob.BlobHeader.LengthProof.X.A1.Marshal()
-		lengthProofData.YA0 = blob.BlobHeader.LengthProof.Y.A0.Marshal()
-		lengthProofData.YA1 = blob.BlobHeader.LengthProof.Y.A1.Marshal()
-	}
-
-	quorumHeaders := make([]*node.BlobQuorumInfo, len(blob.BlobHeader.QuorumInfos))
-
-	for i, header := range blob.BlobHeader.QuorumInfos {
-		quorumHeaders[i] = &node.BlobQuorumInfo{
-			QuorumId:              uint32(header.QuorumID),
-			AdversaryThreshold:    uint32(header.AdversaryThreshold),
-			ChunkLength:           uint32(header.ChunkLength),
-			ConfirmationThreshold: uint32(header.ConfirmationThreshold),
-			Ratelimit:             header.QuorumRate,
-		}
+	header, err := conversion.BlobHeaderToProto(blob.BlobHeader)
+	if err != nil {
+		return nil, err
 	}
+	quorumHeaders := header.GetQuorumHeaders()
 
-	var err error
 	bundles := make([]*node.Bundle, len(quorumHeaders))
 	if useGnarkBundleEncoding {
 		// the ordering of quorums in bundles must be same as in quorumHeaders
@@ -408,25 +506,11 @@ func getBlobMessage(blob *core.EncodedBlobMessage, useGnarkBundleEncoding bool)
 	}
 
 	return &node.Blob{
-		Header: &node.BlobHeader{
-			Commitment:       commitData,
-			LengthCommitment: &lengthCommitData,
-			LengthProof:      &lengthProofData,
-			Length:           uint32(blob.BlobHeader.Length),
-			QuorumHeaders:    quorumHeaders,
-		},
+		Header:  header,
 		Bundles: bundles,
 	}, nil
 }
 
-func getBatchHeaderMessage(header *core.BatchHeader) *node.BatchHeader {
-
-	return &node.BatchHeader{
-		BatchRoot:            header.BatchRoot[:],
-		ReferenceBlockNumber: uint32(header.ReferenceBlockNumber),
-	}
-}
-
 func getBundlesSize(blob *core.EncodedBlobMessage) int64 {
 	size := int64(0)
 	for _, bundle := range blob.EncodedBundles {
@@ -0,0 +1,114 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"math"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// ErrOperatorQuarantined is the error of the signing messages of the operators skipped because they are quarantined
+var ErrOperatorQuarantined = errors.New("operator is quarantined after repeated dispersal failures")
+
+// QuarantineConfig configures the quarantine of the operators which repeatedly fail to store the chunks, so that
+// the batches don't wait for them until the attestation timeout. A quarantined operator is reported as a non-signer
+// without being sent the batch, so its stake still counts against the thresholds of its quorums.
+type QuarantineConfig struct {
+	// FailureThreshold is the failure score at which an operator is quarantined. Each failed request adds one to
+	// the score of the operator, and each successful request resets it. The operators are never quarantined if it is
+	// zero.
+	FailureThreshold float64
+	// FailureHalfLife is the time after which the failure score of an operator is halved, so that occasional
+	// failures don't add up to a quarantine. The score doesn't decay if it is zero.
+	FailureHalfLife time.Duration
+	// Duration is the time during which a quarantined operator is skipped. The operator is dispersed to again
+	// afterwards, and quarantined again on its next failure unless its score decayed below the threshold.
+	Duration time.Duration
+}
+
+type operatorFailures struct {
+	score            float64
+	updatedAt        time.Time
+	quarantinedUntil time.Time
+}
+
+// quarantine tracks the dispersal failures of the operators
+type quarantine struct {
+	QuarantineConfig
+
+	mu        sync.Mutex
+	operators map[core.OperatorID]*operatorFailures
+	now       func() time.Time
+}
+
+func newQuarantine(config QuarantineConfig) *quarantine {
+	return &quarantine{
+		QuarantineConfig: config,
+		operators:        make(map[core.OperatorID]*operatorFailures),
+		now:              time.Now,
+	}
+}
+
+func (q *quarantine) enabled() bool {
+	return q.FailureThreshold > 0
+}
+
+// isQuarantined returns whether the operator must be skipped
+func (q *quarantine) isQuarantined(operatorID core.OperatorID) bool {
+	if !q.enabled() {
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	failures, ok := q.operators[operatorID]
+	return ok && q.now().Before(failures.quarantinedUntil)
+}
+
+// recordResult updates the failure score of the operator with the result of a request, and returns whether the
+// operator is quarantined by a failure. The requests canceled by the context of the batch aren't counted.
+func (q *quarantine) recordResult(ctx context.Context, operatorID core.OperatorID, err error) bool {
+	if !q.enabled() || ctx.Err() != nil {
+		return false
+	}
+	q.mu.Lock()
+	defer q.mu.Unlock()
+
+	if err == nil {
+		delete(q.operators, operatorID)
+		return false
+	}
+
+	now := q.now()
+	failures, ok := q.operators[operatorID]
+	if !ok {
+		failures = &operatorFailures{updatedAt: now}
+		q.operators[operatorID] = failures
+	}
+	if q.FailureHalfLife > 0 {
+		failures.score *= math.Exp2(-float64(now.Sub(failures.updatedAt)) / float64(q.FailureHalfLife))
+	}
+	failures.score++
+	failures.updatedAt = now
+	if failures.score >= q.FailureThreshold && !now.Before(failures.quarantinedUntil) {
+		failures.quarantinedUntil = now.Add(q.Duration)
+		return true
+	}
+	return false
+}
+
+// numQuarantined returns the number of operators in quarantine
+func (q *quarantine) numQuarantined() int {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	now := q.now()
+	count := 0
+	for _, failures := range q.operators {
+		if now.Before(failures.quarantinedUntil) {
+			count++
+		}
+	}
+	return count
+}
@@ -0,0 +1,58 @@
+package dispatcher
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuarantine(t *testing.T) {
+	q := newQuarantine(QuarantineConfig{
+		FailureThreshold: 2,
+		FailureHalfLife:  time.Minute,
+		Duration:         5 * time.Minute,
+	})
+	now := time.Unix(1000, 0)
+	q.now = func() time.Time { return now }
+	ctx := context.Background()
+	errTimeout := errors.New("timeout")
+	op1, op2 := core.OperatorID{1}, core.OperatorID{2}
+
+	// A single failure doesn't quarantine the operator, and the failures decay
+	assert.False(t, q.recordResult(ctx, op1, errTimeout))
+	now = now.Add(time.Minute)
+	assert.False(t, q.recordResult(ctx, op1, errTimeout))
+	assert.False(t, q.isQuarantined(op1))
+
+	// Failures in a row quarantine the operator until the quarantine expires
+	assert.True(t, q.recordResult(ctx, op1, errTimeout))
+	assert.True(t, q.isQuarantined(op1))
+	assert.False(t, q.isQuarantined(op2))
+	assert.Equal(t, 1, q.numQuarantined())
+	now = now.Add(5 * time.Minute)
+	assert.False(t, q.isQuarantined(op1))
+	assert.Equal(t, 0, q.numQuarantined())
+
+	// A success resets the failures of the operator
+	assert.False(t, q.recordResult(ctx, op1, nil))
+	assert.False(t, q.recordResult(ctx, op1, errTimeout))
+	assert.False(t, q.isQuarantined(op1))
+
+	// The requests canceled by the batch aren't failures of the operator
+	canceledCtx, cancel := context.WithCancel(ctx)
+	cancel()
+	assert.False(t, q.recordResult(canceledCtx, op2, context.Canceled))
+	assert.False(t, q.recordResult(canceledCtx, op2, context.Canceled))
+	assert.False(t, q.isQuarantined(op2))
+
+	// The operators are never quarantined without threshold
+	q = newQuarantine(QuarantineConfig{Duration: time.Minute})
+	for i := 0; i < 10; i++ {
+		assert.False(t, q.recordResult(ctx, op1, errTimeout))
+	}
+	assert.False(t, q.isQuarantined(op1))
+}
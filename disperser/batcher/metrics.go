@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -24,6 +25,7 @@ const (
 	FailGetBatchID             FailReason = "get_batch_id"
 	FailUpdateConfirmationInfo FailReason = "update_confirmation_info"
 	FailNoAggregatedSignature  FailReason = "no_aggregated_signature"
+	FailConfirmationLock       FailReason = "confirmation_lock"
 )
 
 type MetricsConfig struct {
@@ -48,11 +50,22 @@ type FinalizerMetrics struct {
 	NumBlobs               *prometheus.CounterVec
 	LastSeenFinalizedBlock prometheus.Gauge
 	Latency                *prometheus.SummaryVec
+	TimeToFinality         *prometheus.HistogramVec
 }
 
 type DispatcherMetrics struct {
 	Latency         *prometheus.SummaryVec
 	OperatorLatency *prometheus.GaugeVec
+	// QuarantinedOperators is the number of operators skipped after repeated dispersal failures, and
+	// QuarantinedRequests the number of requests they were not sent
+	QuarantinedOperators prometheus.Gauge
+	QuarantinedRequests  prometheus.Counter
+}
+
+// EncoderHedgingMetrics are the metrics of the encoding requests hedged to another encoder replica
+type EncoderHedgingMetrics struct {
+	HedgedRequests *prometheus.CounterVec
+	Winners        *prometheus.CounterVec
 }
 
 type Metrics struct {
@@ -60,6 +73,7 @@ type Metrics struct {
 	*TxnManagerMetrics
 	*FinalizerMetrics
 	*DispatcherMetrics
+	*EncoderHedgingMetrics
 
 	registry *prometheus.Registry
 
@@ -71,6 +85,13 @@ type Metrics struct {
 	BlobSizeTotal             *prometheus.CounterVec
 	Attestation               *prometheus.GaugeVec
 	BatchError                *prometheus.CounterVec
+	// ConfirmationLockHeld is 1 if the batcher holds the confirmation lock shared with the other batchers
+	ConfirmationLockHeld prometheus.Gauge
+
+	// health is served at /health alongside the metrics if set
+	health http.Handler
+	// ops is served at /ops alongside the metrics if set
+	ops http.Handler
 
 	httpPort string
 	logger   logging.Logger
@@ -167,6 +188,16 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"stage"}, // possible values are "round" and "total"
 		),
+		TimeToFinality: promauto.With(reg).NewHistogramVec(
+			prometheus.HistogramOpts{
+				Namespace: namespace,
+				Name:      "blob_time_to_finality_ms",
+				Help:      "time (in ms) from the dispersal request of a blob to its confirmation and finalization",
+				// In minutes: 0.5, 1, 2, 3, 5, 8, 10, 13, 15, 18, 21, 25, 30, 45, 60, 90, 120
+				Buckets: []float64{30_000, 60_000, 120_000, 180_000, 300_000, 480_000, 600_000, 780_000, 900_000, 1_080_000, 1_260_000, 1_500_000, 1_800_000, 2_700_000, 3_600_000, 5_400_000, 7_200_000},
+			},
+			[]string{"stage"}, // possible values are "confirmed" and "finalized"
+		),
 	}
 
 	dispatcherMatrics := DispatcherMetrics{
@@ -187,6 +218,39 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"operator_id"},
 		),
+		QuarantinedOperators: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "quarantined_operators",
+				Help:      "number of operators quarantined after repeated dispersal failures",
+			},
+		),
+		QuarantinedRequests: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "quarantined_operator_requests_total",
+				Help:      "number of requests not sent to the operators because they are quarantined",
+			},
+		),
+	}
+
+	encoderHedgingMetrics := EncoderHedgingMetrics{
+		HedgedRequests: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "encoder_hedged_requests_total",
+				Help:      "number of encoding requests sent to a second encoder replica",
+			},
+			[]string{"reason"}, // reason is either "latency" or "failure"
+		),
+		Winners: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "encoder_request_winners_total",
+				Help:      "number of encoding requests by replica whose result was used",
+			},
+			[]string{"winner"}, // winner is "primary", "hedge", or "none" if both replicas failed
+		),
 	}
 
 	metrics := &Metrics{
@@ -194,6 +258,7 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 		TxnManagerMetrics:       &txnManagerMetrics,
 		FinalizerMetrics:        &finalizerMetrics,
 		DispatcherMetrics:       &dispatcherMatrics,
+		EncoderHedgingMetrics:   &encoderHedgingMetrics,
 		Blob: promauto.With(reg).NewCounterVec(
 			prometheus.CounterOpts{
 				Namespace: namespace,
@@ -264,6 +329,13 @@ func NewMetrics(httpPort string, logger logging.Logger) *Metrics {
 			},
 			[]string{"type"},
 		),
+		ConfirmationLockHeld: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "confirmation_lock_held",
+				Help:      "whether the batcher holds the confirmation lock (1) or stands by (0)",
+			},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger.With("component", "BatcherMetrics"),
@@ -307,6 +379,27 @@ func (t *DispatcherMetrics) ObserveLatency(operatorId string, success bool, late
 	}
 }
 
+// SetQuarantinedOperators sets the number of operators in quarantine
+func (t *DispatcherMetrics) SetQuarantinedOperators(count int) {
+	t.QuarantinedOperators.Set(float64(count))
+}
+
+// IncrementQuarantinedRequests counts a request not sent to an operator because it is quarantined
+func (t *DispatcherMetrics) IncrementQuarantinedRequests() {
+	t.QuarantinedRequests.Inc()
+}
+
+// IncrementHedgedRequests counts an encoding request sent to a second replica, because the first one was slow
+// ("latency") or failed ("failure")
+func (m *EncoderHedgingMetrics) IncrementHedgedRequests(reason string) {
+	m.HedgedRequests.WithLabelValues(reason).Inc()
+}
+
+// IncrementWinner counts an encoding request by the replica whose result was used
+func (m *EncoderHedgingMetrics) IncrementWinner(winner string) {
+	m.Winners.WithLabelValues(winner).Inc()
+}
+
 // UpdateCompletedBlob increments the number and updates size of processed blobs.
 func (g *Metrics) UpdateCompletedBlob(size int, status disperser.BlobStatus) {
 	switch status {
@@ -336,6 +429,15 @@ func (g *Metrics) UpdateBatchError(errType FailReason, numBlobs int) {
 	g.BatchError.WithLabelValues(string(errType)).Add(float64(numBlobs))
 }
 
+// UpdateConfirmationLockHeld records whether the batcher holds the confirmation lock
+func (g *Metrics) UpdateConfirmationLockHeld(held bool) {
+	if held {
+		g.ConfirmationLockHeld.Set(1)
+	} else {
+		g.ConfirmationLockHeld.Set(0)
+	}
+}
+
 func (g *Metrics) ObserveLatency(stage string, latencyMs float64) {
 	g.BatchProcLatency.WithLabelValues(stage).Observe(latencyMs)
 	g.BatchProcLatencyHistogram.WithLabelValues(stage).Observe(latencyMs)
@@ -349,6 +451,17 @@ func (g *Metrics) IncrementBlobSize(stage string, quorumId core.QuorumID, blobSi
 	g.BlobSizeTotal.WithLabelValues(stage, fmt.Sprintf("%d", quorumId)).Add(float64(blobSize))
 }
 
+// SetHealthHandler sets the handler serving the health of the batcher's components at /health. It must be called
+// before Start.
+func (g *Metrics) SetHealthHandler(handler http.Handler) {
+	g.health = handler
+}
+
+// SetOpsHandler sets the handler serving the live internals of the batcher at /ops. It must be called before Start.
+func (g *Metrics) SetOpsHandler(handler http.Handler) {
+	g.ops = handler
+}
+
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("starting metrics server at ", "port", g.httpPort)
 	addr := fmt.Sprintf(":%s", g.httpPort)
@@ -359,6 +472,12 @@ func (g *Metrics) Start(ctx context.Context) {
 			g.registry,
 			promhttp.HandlerOpts{},
 		))
+		if g.health != nil {
+			mux.Handle("/health", g.health)
+		}
+		if g.ops != nil {
+			mux.Handle("/ops", g.ops)
+		}
 		err := http.ListenAndServe(addr, mux)
 		log.Error("prometheus server failed", "err", err)
 	}()
@@ -409,6 +528,12 @@ func (f *FinalizerMetrics) ObserveLatency(stage string, latencyMs float64) {
 	f.Latency.WithLabelValues(stage).Observe(latencyMs)
 }
 
+// ObserveTimeToFinality records the time elapsed since the dispersal request of a blob when it reaches the given stage
+func (f *FinalizerMetrics) ObserveTimeToFinality(stage string, requestedAt uint64) {
+	requestTime := time.Unix(0, int64(requestedAt))
+	f.TimeToFinality.WithLabelValues(stage).Observe(float64(time.Since(requestTime).Milliseconds()))
+}
+
 // blobSizeBucket maps the blob size into a bucket that's defined according to
 // the power of 2.
 func blobSizeBucket(blobSize int) string {
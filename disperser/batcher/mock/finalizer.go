@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/stretchr/testify/mock"
 )
 
@@ -20,3 +21,7 @@ func (b *MockFinalizer) FinalizeBlobs(ctx context.Context) error {
 	args := b.Called()
 	return args.Error(0)
 }
+
+func (b *MockFinalizer) Health() healthcheck.ComponentHealth {
+	return healthcheck.ComponentHealth{Component: "Finalizer", Status: healthcheck.StatusHealthy}
+}
@@ -0,0 +1,167 @@
+package batcher
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// opsLatencyWindow is the number of the latest encodings whose latency is reported by the operations endpoint
+const opsLatencyWindow = 100
+
+// OpsSnapshot is the live state of the internals of the batcher, served to the on-call engineers by the operations
+// endpoint
+type OpsSnapshot struct {
+	TakenAt time.Time  `json:"takenAt"`
+	Encoder EncoderOps `json:"encoder"`
+	Queue   QueueOps   `json:"queue"`
+	// Dispatcher is nil if the dispatcher doesn't report its requests
+	Dispatcher *DispatcherOps `json:"dispatcher,omitempty"`
+	// Finalizer is nil if the finalizer doesn't report its backlog
+	Finalizer *FinalizerOps `json:"finalizer,omitempty"`
+}
+
+// EncoderOps is the state of the requests to the encoder
+type EncoderOps struct {
+	// QueuedRequests is the number of encoding requests waiting for a worker
+	QueuedRequests int `json:"queuedRequests"`
+	// Workers is the number of workers sending the encoding requests
+	Workers int `json:"workers"`
+	// LatencyMs is the latency of the latest successful encodings
+	LatencyMs LatencyOps `json:"latencyMs"`
+}
+
+// LatencyOps summarizes the latency of the latest operations of a component
+type LatencyOps struct {
+	Samples int     `json:"samples"`
+	Mean    float64 `json:"mean"`
+	Max     float64 `json:"max"`
+}
+
+// QueueOps is the state of the blobs queued by the batcher in each lane, which is the quorum they are encoded for
+type QueueOps struct {
+	// EncodingByQuorum is the number of encoding requests in flight for each quorum
+	EncodingByQuorum map[core.QuorumID]int `json:"encodingByQuorum"`
+	// EncodedByQuorum is the number of encoded blobs waiting for a batch for each quorum
+	EncodedByQuorum map[core.QuorumID]int `json:"encodedByQuorum"`
+	// EncodedBytes is the total size of the chunks of the encoded blobs
+	EncodedBytes uint64 `json:"encodedBytes"`
+}
+
+// DispatcherOps is the state of the requests sent by the dispatcher to the operators
+type DispatcherOps struct {
+	// InFlightByOperator is the number of requests awaiting the reply of each operator, by operator ID. The operators
+	// without requests in flight are omitted.
+	InFlightByOperator map[string]int `json:"inFlightByOperator"`
+}
+
+// FinalizerOps is the state of the finalization of the confirmed blobs
+type FinalizerOps struct {
+	// Backlog is the number of confirmed blobs found by the latest run, which are only finalized once the block of
+	// their confirmation is
+	Backlog int `json:"backlog"`
+	// LastRunAt is nil if the finalizer didn't complete a run yet
+	LastRunAt         *time.Time `json:"lastRunAt,omitempty"`
+	LastRunDurationMs float64    `json:"lastRunDurationMs"`
+}
+
+// DispatcherOpsReporter is implemented by the dispatchers which report their requests to the operations endpoint
+type DispatcherOpsReporter interface {
+	DispatcherOps() DispatcherOps
+}
+
+// FinalizerOpsReporter is implemented by the finalizers which report their backlog to the operations endpoint
+type FinalizerOpsReporter interface {
+	FinalizerOps() FinalizerOps
+}
+
+// latencyWindow keeps the latencies of the latest operations
+type latencyWindow struct {
+	mu        sync.Mutex
+	latencies []time.Duration
+	next      int
+}
+
+func newLatencyWindow(size int) *latencyWindow {
+	return &latencyWindow{
+		latencies: make([]time.Duration, 0, size),
+	}
+}
+
+func (w *latencyWindow) Record(latency time.Duration) {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	if len(w.latencies) < cap(w.latencies) {
+		w.latencies = append(w.latencies, latency)
+		return
+	}
+	w.latencies[w.next] = latency
+	w.next = (w.next + 1) % len(w.latencies)
+}
+
+func (w *latencyWindow) Summary() LatencyOps {
+	w.mu.Lock()
+	defer w.mu.Unlock()
+	summary := LatencyOps{Samples: len(w.latencies)}
+	if len(w.latencies) == 0 {
+		return summary
+	}
+	var total time.Duration
+	for _, latency := range w.latencies {
+		total += latency
+		summary.Max = max(summary.Max, float64(latency.Microseconds())/1000)
+	}
+	summary.Mean = float64(total.Microseconds()) / 1000 / float64(len(w.latencies))
+	return summary
+}
+
+// Ops returns the snapshot of the encoding streamer, dispatcher and finalizer of the batcher
+func (b *Batcher) Ops() *OpsSnapshot {
+	encoding, encoded := b.EncodingStreamer.EncodedBlobstore.GetQueueSizesByQuorum()
+	_, encodedBytes := b.EncodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+	snapshot := &OpsSnapshot{
+		TakenAt: time.Now().UTC(),
+		Encoder: EncoderOps{
+			QueuedRequests: b.EncodingStreamer.Pool.WaitingQueueSize(),
+			Workers:        b.EncodingStreamer.Pool.Size(),
+			LatencyMs:      b.EncodingStreamer.encodingLatency.Summary(),
+		},
+		Queue: QueueOps{
+			EncodingByQuorum: encoding,
+			EncodedByQuorum:  encoded,
+			EncodedBytes:     encodedBytes,
+		},
+	}
+	if reporter, ok := b.Dispatcher.(DispatcherOpsReporter); ok {
+		dispatcherOps := reporter.DispatcherOps()
+		snapshot.Dispatcher = &dispatcherOps
+	}
+	if reporter, ok := b.finalizer.(FinalizerOpsReporter); ok {
+		finalizerOps := reporter.FinalizerOps()
+		snapshot.Finalizer = &finalizerOps
+	}
+	return snapshot
+}
+
+// NewOpsHandler returns the handler of the operations endpoint, which serves the snapshot of the batcher as JSON to
+// the requests authenticated with the bearer token. All the requests are rejected if the token is empty.
+func NewOpsHandler(b *Batcher, token string) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		bearer, ok := strings.CutPrefix(r.Header.Get("Authorization"), "Bearer ")
+		if !ok || token == "" || subtle.ConstantTimeCompare([]byte(bearer), []byte(token)) != 1 {
+			w.Header().Set("WWW-Authenticate", "Bearer")
+			http.Error(w, "unauthorized", http.StatusUnauthorized)
+			return
+		}
+		w.Header().Set("Content-Type", "application/json")
+		w.Header().Set("Cache-Control", "no-store")
+		if err := json.NewEncoder(w).Encode(b.Ops()); err != nil {
+			b.logger.Warn("failed to write the operations snapshot", "err", err)
+		}
+	})
+}
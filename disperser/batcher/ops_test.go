@@ -0,0 +1,73 @@
+package batcher_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	bat "github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestOpsHandler(t *testing.T) {
+	blob1 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              0,
+		AdversaryThreshold:    80,
+		ConfirmationThreshold: 100,
+	}})
+	blob2 := makeTestBlob([]*core.SecurityParam{{
+		QuorumID:              1,
+		AdversaryThreshold:    70,
+		ConfirmationThreshold: 100,
+	}})
+	components, batcher, _ := makeBatcher(t)
+	ctx := context.Background()
+	queueBlob(t, ctx, &blob1, components.blobStore)
+	queueBlob(t, ctx, &blob2, components.blobStore)
+
+	out := make(chan bat.EncodingResultOrStatus)
+	require.NoError(t, components.encodingStreamer.RequestEncoding(ctx, out))
+	require.NoError(t, components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+	require.NoError(t, components.encodingStreamer.ProcessEncodedBlobs(ctx, <-out))
+
+	handler := bat.NewOpsHandler(batcher, "secret")
+	get := func(authorization string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r := httptest.NewRequest(http.MethodGet, "/ops", nil)
+		if authorization != "" {
+			r.Header.Set("Authorization", authorization)
+		}
+		handler.ServeHTTP(w, r)
+		return w
+	}
+
+	assert.Equal(t, http.StatusUnauthorized, get("").Code)
+	assert.Equal(t, http.StatusUnauthorized, get("Bearer wrong").Code)
+	assert.Equal(t, http.StatusUnauthorized, get("secret").Code)
+
+	w := get("Bearer secret")
+	require.Equal(t, http.StatusOK, w.Code)
+	var snapshot bat.OpsSnapshot
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &snapshot))
+	assert.Equal(t, 0, snapshot.Encoder.QueuedRequests)
+	assert.Equal(t, 2, snapshot.Encoder.LatencyMs.Samples)
+	assert.LessOrEqual(t, snapshot.Encoder.LatencyMs.Mean, snapshot.Encoder.LatencyMs.Max)
+	assert.Empty(t, snapshot.Queue.EncodingByQuorum)
+	assert.Equal(t, map[core.QuorumID]int{0: 1, 1: 1}, snapshot.Queue.EncodedByQuorum)
+	_, encodedBytes := components.encodingStreamer.EncodedBlobstore.GetEncodedResultSize()
+	assert.Equal(t, encodedBytes, snapshot.Queue.EncodedBytes)
+	// The mocks of the dispatcher and of the finalizer don't report their internals
+	assert.Nil(t, snapshot.Dispatcher)
+	assert.Nil(t, snapshot.Finalizer)
+
+	// The endpoint can't be opened with an empty token
+	w = httptest.NewRecorder()
+	r := httptest.NewRequest(http.MethodGet, "/ops", nil)
+	r.Header.Set("Authorization", "Bearer ")
+	bat.NewOpsHandler(batcher, "").ServeHTTP(w, r)
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+}
@@ -1,6 +1,8 @@
 package main
 
 import (
+	"fmt"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
@@ -9,6 +11,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/apiserver/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
 )
 
@@ -26,6 +29,9 @@ type Config struct {
 	BucketStoreSize   int
 	EthClientConfig   geth.EthClientConfig
 	MaxBlobSize       int
+	BlobUploadConfig  apiserver.BlobUploadConfig
+	// VoucherConfig has a zero issuer if the dispersal with vouchers is disabled
+	VoucherConfig apiserver.VoucherConfig
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -48,16 +54,32 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		return Config{}, err
 	}
 
+	compression, err := blobstore.ParseCompressionAlgorithm(ctx.GlobalString(flags.BlobCompressionFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+
+	var voucherIssuer gethcommon.Address
+	if issuer := ctx.GlobalString(flags.VoucherIssuerFlag.Name); issuer != "" {
+		if !gethcommon.IsHexAddress(issuer) {
+			return Config{}, fmt.Errorf("invalid voucher issuer address %q", issuer)
+		}
+		voucherIssuer = gethcommon.HexToAddress(issuer)
+	}
+
 	config := Config{
 		AwsClientConfig: aws.ReadClientConfig(ctx, flags.FlagPrefix),
 		ServerConfig: disperser.ServerConfig{
-			GrpcPort:    ctx.GlobalString(flags.GrpcPortFlag.Name),
-			GrpcTimeout: ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
+			GrpcPort:                    ctx.GlobalString(flags.GrpcPortFlag.Name),
+			GrpcTimeout:                 ctx.GlobalDuration(flags.GrpcTimeoutFlag.Name),
+			BlobStatusPollInterval:      ctx.GlobalDuration(flags.BlobStatusPollIntervalFlag.Name),
+			BlobStatusKeepaliveInterval: ctx.GlobalDuration(flags.BlobStatusKeepaliveIntervalFlag.Name),
 		},
 		BlobstoreConfig: blobstore.Config{
 			BucketName:      ctx.GlobalString(flags.S3BucketNameFlag.Name),
 			TableName:       ctx.GlobalString(flags.DynamoDBTableNameFlag.Name),
 			ShadowTableName: ctx.GlobalString(flags.ShadowTableNameFlag.Name),
+			Compression:     compression,
 		},
 		LoggerConfig: *loggerConfig,
 		MetricsConfig: disperser.MetricsConfig{
@@ -71,6 +93,14 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		BucketStoreSize:   ctx.GlobalInt(flags.BucketStoreSize.Name),
 		EthClientConfig:   geth.ReadEthClientConfigRPCOnly(ctx),
 		MaxBlobSize:       ctx.GlobalInt(flags.MaxBlobSize.Name),
+		BlobUploadConfig: apiserver.BlobUploadConfig{
+			BucketName: ctx.GlobalString(flags.BlobUploadBucketNameFlag.Name),
+			URLExpiry:  ctx.GlobalDuration(flags.BlobUploadURLExpiryFlag.Name),
+		},
+		VoucherConfig: apiserver.VoucherConfig{
+			Issuer:    voucherIssuer,
+			TableName: ctx.GlobalString(flags.VoucherTableNameFlag.Name),
+		},
 
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
@@ -50,6 +50,20 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GRPC_STREAM_TIMEOUT"),
 		Value:    time.Second * 10,
 	}
+	BlobStatusPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-poll-interval"),
+		Usage:    "Interval at which the statuses of the blobs streamed by SubscribeBlobStatus are polled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_POLL_INTERVAL"),
+		Value:    time.Second,
+	}
+	BlobStatusKeepaliveIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-status-keepalive-interval"),
+		Usage:    "Interval after which a keepalive is sent to the SubscribeBlobStatus streams without status update",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_STATUS_KEEPALIVE_INTERVAL"),
+		Value:    15 * time.Second,
+	}
 	BlsOperatorStateRetrieverFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
 		Usage:    "Address of the BLS Operator State Retriever",
@@ -101,6 +115,41 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_BLOB_SIZE"),
 		Required: false,
 	}
+	BlobCompressionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-compression"),
+		Usage:    "algorithm with which blobs are compressed in the blob store [none, gzip, zstd]. Blobs are decompressed on retrieval whatever the algorithm they were stored with",
+		Value:    "none",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_COMPRESSION"),
+		Required: false,
+	}
+	BlobUploadBucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-upload-bucket-name"),
+		Usage:    "name of the bucket to which clients upload blobs with presigned URLs before dispersing them. The dispersal of uploaded blobs is disabled if not provided. The bucket should expire the objects under uploads/ with a lifecycle rule",
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_UPLOAD_BUCKET_NAME"),
+		Required: false,
+	}
+	BlobUploadURLExpiryFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-upload-url-expiry"),
+		Usage:    "how long the presigned blob upload URLs are valid for",
+		Value:    15 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_UPLOAD_URL_EXPIRY"),
+		Required: false,
+	}
+	VoucherIssuerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "voucher-issuer"),
+		Usage:    "address of the key which signs the vouchers exempting authenticated accounts from their rate limits up to a byte budget. The dispersal with vouchers is disabled if not provided",
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "VOUCHER_ISSUER"),
+		Required: false,
+	}
+	VoucherTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "voucher-table-name"),
+		Usage:    "name of the dynamodb table which tracks the bytes dispersed with each voucher. If not provided, a local store will be used, which is only consistent with a single instance",
+		Value:    "",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "VOUCHER_TABLE_NAME"),
+		Required: false,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -118,8 +167,15 @@ var optionalFlags = []cli.Flag{
 	EnableRatelimiter,
 	BucketStoreSize,
 	GrpcTimeoutFlag,
+	BlobStatusPollIntervalFlag,
+	BlobStatusKeepaliveIntervalFlag,
 	ShadowTableNameFlag,
 	MaxBlobSize,
+	BlobCompressionFlag,
+	BlobUploadBucketNameFlag,
+	BlobUploadURLExpiryFlag,
+	VoucherIssuerFlag,
+	VoucherTableNameFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
@@ -16,6 +16,7 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/common/ratelimit"
 	"github.com/Layr-Labs/eigenda/common/store"
 	"github.com/Layr-Labs/eigenda/core/eth"
@@ -90,9 +91,10 @@ func RunDisperserServer(ctx *cli.Context) error {
 	}
 
 	bucketName := config.BlobstoreConfig.BucketName
-	logger.Info("Creating blob store", "bucket", bucketName)
+	logger.Info("Creating blob store", "bucket", bucketName, "compression", config.BlobstoreConfig.Compression)
 	blobMetadataStore := blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, config.BlobstoreConfig.ShadowTableName, time.Duration((storeDurationBlocks+blockStaleMeasure)*12)*time.Second)
 	blobStore := blobstore.NewSharedStorage(bucketName, s3Client, blobMetadataStore, logger)
+	blobStore.SetCompression(config.BlobstoreConfig.Compression)
 
 	reg := prometheus.NewRegistry()
 
@@ -135,6 +137,19 @@ func RunDisperserServer(ctx *cli.Context) error {
 		config.RateConfig,
 		config.MaxBlobSize,
 	)
+	server.SetHealthAggregator(healthcheck.NewAggregator(s3Client, dynamoClient))
+	if config.BlobUploadConfig.BucketName != "" {
+		server.SetBlobUploads(config.BlobUploadConfig, s3Client, s3Client)
+	}
+	if config.VoucherConfig.Issuer != (gethcommon.Address{}) {
+		var voucherStore apiserver.VoucherStore
+		if config.VoucherConfig.TableName != "" {
+			voucherStore = apiserver.NewDynamoVoucherStore(dynamoClient, config.VoucherConfig.TableName)
+		} else {
+			voucherStore = apiserver.NewLocalVoucherStore()
+		}
+		server.SetVouchers(config.VoucherConfig, voucherStore)
+	}
 
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
@@ -1,11 +1,15 @@
 package main
 
 import (
+	"errors"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -33,6 +37,14 @@ type Config struct {
 	EigenDAServiceManagerAddr     string
 
 	EnableGnarkBundleEncoding bool
+	DispersalAuthPrivateKey   string
+	OperatorQuarantineConfig  dispatcher.QuarantineConfig
+	// ConfirmationLockTableName is the name of the DynamoDB table of the confirmation lock, which isn't used if it is
+	// empty
+	ConfirmationLockTableName string
+	ConfirmationLockLease     time.Duration
+	// OpsToken is the bearer token of the operations endpoint, which isn't served if it is empty
+	OpsToken string
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -45,6 +57,13 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 	if !kmsConfig.Disable {
 		ethClientConfig = geth.ReadEthClientConfigRPCOnly(ctx)
 	}
+	confirmationPolicies, err := batcher.ReadConfirmationPoliciesFromFile(ctx.GlobalString(flags.ConfirmationPoliciesFileFlag.Name))
+	if err != nil {
+		return Config{}, err
+	}
+	if _, err := batcher.NewBatchOrderingPolicy(ctx.GlobalString(flags.BatchOrderingPolicyFlag.Name)); err != nil {
+		return Config{}, err
+	}
 	config := Config{
 		BlobstoreConfig: blobstore.Config{
 			BucketName: ctx.GlobalString(flags.S3BucketNameFlag.Name),
@@ -59,6 +78,8 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			FinalizerInterval:        ctx.GlobalDuration(flags.FinalizerIntervalFlag.Name),
 			FinalizerPoolSize:        ctx.GlobalInt(flags.FinalizerPoolSizeFlag.Name),
 			EncoderSocket:            ctx.GlobalString(flags.EncoderSocket.Name),
+			EncoderReplicaSockets:    ctx.GlobalStringSlice(flags.EncoderReplicaSocketsFlag.Name),
+			EncoderHedgingDelay:      ctx.GlobalDuration(flags.EncoderHedgingDelayFlag.Name),
 			NumConnections:           ctx.GlobalInt(flags.NumConnectionsFlag.Name),
 			EncodingRequestQueueSize: ctx.GlobalInt(flags.EncodingRequestQueueSizeFlag.Name),
 			BatchSizeMBLimit:         ctx.GlobalUint(flags.BatchSizeLimitFlag.Name),
@@ -67,6 +88,10 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 			TargetNumChunks:          ctx.GlobalUint(flags.TargetNumChunksFlag.Name),
 			MaxBlobsToFetchFromStore: ctx.GlobalInt(flags.MaxBlobsToFetchFromStoreFlag.Name),
 			FinalizationBlockDelay:   ctx.GlobalUint(flags.FinalizationBlockDelayFlag.Name),
+			ConfirmationPolicies:     confirmationPolicies,
+			BatchOrderingPolicy:      ctx.GlobalString(flags.BatchOrderingPolicyFlag.Name),
+
+			ConfirmationLockRenewInterval: ctx.GlobalDuration(flags.ConfirmationLockRenewIntervalFlag.Name),
 		},
 		TimeoutConfig: batcher.TimeoutConfig{
 			EncodingTimeout:     ctx.GlobalDuration(flags.EncodingTimeoutFlag.Name),
@@ -88,6 +113,21 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		IndexerConfig:                 indexer.ReadIndexerConfig(ctx),
 		KMSKeyConfig:                  kmsConfig,
 		EnableGnarkBundleEncoding:     ctx.Bool(flags.EnableGnarkBundleEncodingFlag.Name),
+		DispersalAuthPrivateKey:       ctx.GlobalString(flags.DispersalAuthPrivateKeyFlag.Name),
+		OperatorQuarantineConfig: dispatcher.QuarantineConfig{
+			FailureThreshold: ctx.GlobalFloat64(flags.OperatorQuarantineFailureThresholdFlag.Name),
+			FailureHalfLife:  ctx.GlobalDuration(flags.OperatorFailureHalfLifeFlag.Name),
+			Duration:         ctx.GlobalDuration(flags.OperatorQuarantineDurationFlag.Name),
+		},
+		ConfirmationLockTableName: ctx.GlobalString(flags.ConfirmationLockTableNameFlag.Name),
+		ConfirmationLockLease:     ctx.GlobalDuration(flags.ConfirmationLockLeaseFlag.Name),
+		OpsToken:                  ctx.GlobalString(flags.OpsTokenFlag.Name),
+	}
+	if config.ConfirmationLockTableName != "" {
+		renewInterval := config.BatcherConfig.ConfirmationLockRenewInterval
+		if renewInterval <= 0 || renewInterval >= config.ConfirmationLockLease {
+			return Config{}, errors.New("the confirmation lock must be renewed more often than its lease")
+		}
 	}
 	return config, nil
 }
@@ -213,6 +213,85 @@ var (
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "MAX_NUM_RETRIES_PER_DISPERSAL"),
 		Value:    3,
 	}
+	EncoderReplicaSocketsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-replica-sockets"),
+		Usage:    "the ip:port of other replicas of the encoder server. If set, the encoding requests are hedged to another replica when the first one is slow or fails",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_REPLICA_ADDRESSES"),
+	}
+	EncoderHedgingDelayFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "encoder-hedging-delay"),
+		Usage:    "time after which an encoding request is also sent to another encoder replica. Only used when encoder replica sockets are set",
+		Required: false,
+		Value:    2 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENCODER_HEDGING_DELAY"),
+	}
+	DispersalAuthPrivateKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-auth-private-key"),
+		Usage:    "Hex encoded ECDSA private key used to sign the requests sent to the operators. Requests are not signed if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "DISPERSAL_AUTH_PRIVATE_KEY"),
+	}
+	OpsTokenFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "ops-token"),
+		Usage:    "Bearer token of the operations endpoint, which serves the live internals of the batcher at /ops on the metrics port. The endpoint isn't served if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPS_TOKEN"),
+	}
+	ConfirmationPoliciesFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-policies-file"),
+		Usage:    "Path to a JSON file of the quorums which must attest the blobs of each account for them to be confirmed, e.g. [{\"account\": \"0x...\", \"requiredQuorums\": [0, 1], \"anyQuorum\": true}]. The blobs of the accounts without a policy must be attested by all their quorums",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONFIRMATION_POLICIES_FILE"),
+	}
+	BatchOrderingPolicyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "batch-ordering-policy"),
+		Usage:    "Policy selecting the encoded blobs of each batch within the batch size limit: fifo (oldest first), size-balanced (oldest blob, then the largest blobs which fit) or account-fair (round robin across the accounts)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BATCH_ORDERING_POLICY"),
+		Value:    "fifo",
+	}
+	ConfirmationLockTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-lock-table-name"),
+		Usage:    "Name of the DynamoDB table of the lock electing the batcher which makes and confirms the batches among the batchers sharing the blob store. The batcher confirms its batches without a lock if empty, which only works with a single batcher",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONFIRMATION_LOCK_TABLE_NAME"),
+	}
+	ConfirmationLockLeaseFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-lock-lease"),
+		Usage:    "Lease of the confirmation lock, after which another batcher takes the lock over if the batcher holding it stops renewing it. Only used when the confirmation lock table name is set",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONFIRMATION_LOCK_LEASE"),
+	}
+	ConfirmationLockRenewIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-lock-renew-interval"),
+		Usage:    "Interval at which the confirmation lock is renewed, or taken over by a standby batcher. Must be well below the lease. Only used when the confirmation lock table name is set",
+		Required: false,
+		Value:    10 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONFIRMATION_LOCK_RENEW_INTERVAL"),
+	}
+	OperatorQuarantineFailureThresholdFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-quarantine-failure-threshold"),
+		Usage:    "Number of recent dispersal failures (timeouts, errors) after which an operator is quarantined, i.e. reported as a non-signer without being sent the batches. Operators are never quarantined if 0",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_QUARANTINE_FAILURE_THRESHOLD"),
+		Value:    0,
+	}
+	OperatorQuarantineDurationFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-quarantine-duration"),
+		Usage:    "Time during which a quarantined operator isn't sent the batches",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_QUARANTINE_DURATION"),
+		Value:    5 * time.Minute,
+	}
+	OperatorFailureHalfLifeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-failure-half-life"),
+		Usage:    "Time after which the count of recent dispersal failures of an operator is halved",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_FAILURE_HALF_LIFE"),
+		Value:    10 * time.Minute,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -248,6 +327,18 @@ var optionalFlags = []cli.Flag{
 	MaxNodeConnectionsFlag,
 	MaxNumRetriesPerDispersalFlag,
 	EnableGnarkBundleEncodingFlag,
+	DispersalAuthPrivateKeyFlag,
+	EncoderReplicaSocketsFlag,
+	EncoderHedgingDelayFlag,
+	ConfirmationPoliciesFileFlag,
+	BatchOrderingPolicyFlag,
+	ConfirmationLockTableNameFlag,
+	ConfirmationLockLeaseFlag,
+	ConfirmationLockRenewIntervalFlag,
+	OperatorQuarantineFailureThresholdFlag,
+	OperatorQuarantineDurationFlag,
+	OperatorFailureHalfLifeFlag,
+	OpsTokenFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
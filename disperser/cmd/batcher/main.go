@@ -15,8 +15,11 @@ import (
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/batcher"
 	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/batcher/flags"
@@ -24,10 +27,12 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
 	"github.com/Layr-Labs/eigensdk-go/aws/kms"
 	walletsdk "github.com/Layr-Labs/eigensdk-go/chainio/clients/wallet"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/signerv2"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/ethereum/go-ethereum/rpc"
+	"github.com/google/uuid"
 	"github.com/urfave/cli"
 )
 
@@ -97,10 +102,24 @@ func RunBatcher(ctx *cli.Context) error {
 	}
 
 	metrics := batcher.NewMetrics(config.MetricsConfig.HTTPPort, logger)
+	health := healthcheck.NewAggregator(s3Client, dynamoClient)
+	metrics.SetHealthHandler(health)
+
+	var requestSigner core.DispersalRequestSigner
+	if config.DispersalAuthPrivateKey != "" {
+		signer, err := auth.NewLocalDispersalRequestSigner(config.DispersalAuthPrivateKey)
+		if err != nil {
+			return fmt.Errorf("failed to create dispersal request signer: %w", err)
+		}
+		logger.Info("Signing dispersal requests", "address", signer.Address().Hex())
+		requestSigner = signer
+	}
 
 	dispatcher := dispatcher.NewDispatcher(&dispatcher.Config{
 		Timeout:                   config.TimeoutConfig.AttestationTimeout,
 		EnableGnarkBundleEncoding: config.EnableGnarkBundleEncoding,
+		RequestSigner:             requestSigner,
+		Quarantine:                config.OperatorQuarantineConfig,
 	}, logger, metrics.DispatcherMetrics)
 	asgn := &core.StdAssignmentCoordinator{}
 
@@ -216,6 +235,7 @@ func RunBatcher(ctx *cli.Context) error {
 		if err != nil {
 			return err
 		}
+		health.Register(indexer)
 		ics, err = coreindexer.NewIndexedChainState(cs, indexer)
 		if err != nil {
 			return err
@@ -225,13 +245,31 @@ func RunBatcher(ctx *cli.Context) error {
 	if len(config.BatcherConfig.EncoderSocket) == 0 {
 		return errors.New("encoder socket must be specified")
 	}
-	encoderClient, err := encoder.NewEncoderClient(config.BatcherConfig.EncoderSocket, config.TimeoutConfig.EncodingTimeout)
+	encoderClient, err := newEncoderClient(config, logger, metrics.EncoderHedgingMetrics)
 	if err != nil {
 		return err
 	}
 	finalizer := batcher.NewFinalizer(config.TimeoutConfig.ChainReadTimeout, config.BatcherConfig.FinalizerInterval, queue, client, rpcClient, config.BatcherConfig.MaxNumRetriesPerBlob, 1000, config.BatcherConfig.FinalizerPoolSize, logger, metrics.FinalizerMetrics)
+	health.Register(encoderClient, finalizer)
 	txnManager := batcher.NewTxnManager(client, wallet, config.EthClientConfig.NumConfirmations, 20, config.TimeoutConfig.TxnBroadcastTimeout, config.TimeoutConfig.ChainWriteTimeout, logger, metrics.TxnManagerMetrics)
 
+	b, err := batcher.NewBatcher(config.BatcherConfig, config.TimeoutConfig, queue, dispatcher, ics, asgn, encoderClient, agg, client, finalizer, tx, txnManager, logger, metrics, handleBatchLivenessChan)
+	if err != nil {
+		return err
+	}
+	health.Register(b)
+	if config.ConfirmationLockTableName != "" {
+		owner, err := confirmationLockOwner()
+		if err != nil {
+			return err
+		}
+		b.ConfirmationLock = batcher.NewDynamoConfirmationLock(dynamoClient, config.ConfirmationLockTableName, owner, config.ConfirmationLockLease)
+		logger.Info("Confirming the batches under the confirmation lock", "table", config.ConfirmationLockTableName, "owner", owner, "lease", config.ConfirmationLockLease)
+	}
+	if config.OpsToken != "" {
+		metrics.SetOpsHandler(batcher.NewOpsHandler(b, config.OpsToken))
+	}
+
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
 		httpSocket := fmt.Sprintf(":%s", config.MetricsConfig.HTTPPort)
@@ -239,11 +277,7 @@ func RunBatcher(ctx *cli.Context) error {
 		logger.Info("Enabled metrics for Batcher", "socket", httpSocket)
 	}
 
-	batcher, err := batcher.NewBatcher(config.BatcherConfig, config.TimeoutConfig, queue, dispatcher, ics, asgn, encoderClient, agg, client, finalizer, tx, txnManager, logger, metrics, handleBatchLivenessChan)
-	if err != nil {
-		return err
-	}
-	err = batcher.Start(context.Background())
+	err = b.Start(context.Background())
 	if err != nil {
 		return err
 	}
@@ -285,3 +319,38 @@ func heartbeatMonitor(filePath string, maxStallDuration time.Duration) {
 		}
 	}
 }
+
+// confirmationLockOwner returns the identifier of the batcher in the confirmation lock, which is unique across the
+// restarts of the batcher so that a restarted batcher doesn't take over the lease of its previous run
+func confirmationLockOwner() (string, error) {
+	hostname, err := os.Hostname()
+	if err != nil {
+		return "", fmt.Errorf("failed to get hostname: %w", err)
+	}
+	return fmt.Sprintf("%s-%s", hostname, uuid.NewString()), nil
+}
+
+type healthReportingEncoderClient interface {
+	disperser.EncoderClient
+	healthcheck.HealthReporter
+}
+
+// newEncoderClient returns the client of the encoder server, which hedges the encoding requests to the encoder
+// replicas if any
+func newEncoderClient(config Config, logger logging.Logger, metrics *batcher.EncoderHedgingMetrics) (healthReportingEncoderClient, error) {
+	if len(config.BatcherConfig.EncoderReplicaSockets) == 0 {
+		return encoder.NewEncoderClient(config.BatcherConfig.EncoderSocket, config.TimeoutConfig.EncodingTimeout)
+	}
+
+	sockets := append([]string{config.BatcherConfig.EncoderSocket}, config.BatcherConfig.EncoderReplicaSockets...)
+	replicas := make([]disperser.EncoderClient, len(sockets))
+	for i, socket := range sockets {
+		replica, err := encoder.NewEncoderClient(socket, config.TimeoutConfig.EncodingTimeout)
+		if err != nil {
+			return nil, err
+		}
+		replicas[i] = replica
+	}
+	logger.Info("Hedging the encoding requests", "replicas", sockets, "delay", config.BatcherConfig.EncoderHedgingDelay)
+	return encoder.NewHedgedEncoderClient(replicas, config.BatcherConfig.EncoderHedgingDelay, logger, metrics)
+}
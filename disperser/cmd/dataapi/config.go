@@ -1,6 +1,15 @@
 package main
 
 import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/url"
+	"os"
+	"runtime"
+	"strings"
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
@@ -9,6 +18,7 @@ import (
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
 	"github.com/urfave/cli"
 )
 
@@ -28,12 +38,125 @@ type Config struct {
 	ServerMode                   string
 	AllowOrigins                 []string
 
+	InternalSocketAddr        string
+	InternalAllowOrigins      []string
+	PublicRateLimit           float64
+	PublicRateBurst           int
+	PublicTrustedProxies      []string
+	PublicMaxStreamsPerClient int
+
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 
 	DisperserHostname  string
 	ChurnerHostname    string
 	BatcherHealthEndpt string
+
+	// NetworkName is the name of the network configured by the flags above
+	NetworkName string
+	// Networks are the additional networks served by this deployment
+	Networks []NetworkConfig
+
+	EnableAccountAnomalyDetection bool
+	AccountAnomalyPollInterval    time.Duration
+	AccountAnomalyConfig          dataapi.AccountAnomalyConfig
+	AlertWebhookURL               string
+	AlertWebhookSecret            string
+	AlertWebhookMaxRetries        int
+	AlertWebhookRetryBackoff      time.Duration
+	AlertSNSTopicARN              string
+
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	BlockExplorerURL string
+
+	OperatorsPageSize             int
+	NodeInfoWorkers               int
+	OperatorOnlineCheckWorkers    int
+	OperatorOnlineCheckTimeout    time.Duration
+	OperatorProbeVantages         map[string]string
+	OperatorStatusCacheTTL        time.Duration
+	OperatorStatusRefreshInterval time.Duration
+
+	ConfirmationStreamPollInterval time.Duration
+
+	OperatorUptimeTableName     string
+	OperatorUptimeProbeInterval time.Duration
+
+	MetadataArchiveBucketName   string
+	MetadataArchivePrefix       string
+	EnableMetadataArchiver      bool
+	MetadataArchiveInterval     time.Duration
+	MetadataArchiveBeforeExpiry time.Duration
+
+	SubgraphMonitorInterval time.Duration
+
+	// BlobProbeKzgConfig is the SRS with which the blob availability probe verifies the chunks. The probe is disabled
+	// if its G1Path is empty.
+	BlobProbeKzgConfig  kzg.KzgConfig
+	BlobProbeSampleSize int
+	BlobProbeTimeout    time.Duration
+}
+
+// NetworkConfig holds the network specific settings of an additional network.
+// Settings that are not listed here (e.g. AWS credentials, retries) are shared with the primary network.
+type NetworkConfig struct {
+	Name                          string   `json:"name"`
+	RPCURLs                       []string `json:"rpc_urls"`
+	BLSOperatorStateRetrieverAddr string   `json:"bls_operator_state_retriever"`
+	EigenDAServiceManagerAddr     string   `json:"eigenda_service_manager"`
+	SubgraphApiBatchMetadataAddr  string   `json:"subgraph_batch_metadata_addr"`
+	SubgraphApiOperatorStateAddr  string   `json:"subgraph_operator_state_addr"`
+	IndexerEndpoint               string   `json:"indexer_endpoint"`
+	DynamoTableName               string   `json:"dynamo_table_name"`
+	S3BucketName                  string   `json:"s3_bucket_name"`
+	PrometheusClusterLabel        string   `json:"prometheus_cluster_label"`
+	DisperserHostname             string   `json:"disperser_hostname"`
+	ChurnerHostname               string   `json:"churner_hostname"`
+	BatcherHealthEndpt            string   `json:"batcher_health_endpoint"`
+	BlockExplorerURL              string   `json:"block_explorer_url"`
+	OperatorUptimeTableName       string   `json:"operator_uptime_table_name"`
+}
+
+func readNetworksConfig(path string) ([]NetworkConfig, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read networks config file: %w", err)
+	}
+	var networks []NetworkConfig
+	if err := json.Unmarshal(data, &networks); err != nil {
+		return nil, fmt.Errorf("failed to parse networks config file: %w", err)
+	}
+	for _, n := range networks {
+		if n.Name == "" {
+			return nil, errors.New("network name is required in networks config file")
+		}
+		if len(n.RPCURLs) == 0 {
+			return nil, fmt.Errorf("rpc_urls is required for network %s", n.Name)
+		}
+	}
+	return networks, nil
+}
+
+// parseProbeVantages parses the vantages of the operator diagnosis, each given as region=URL
+func parseProbeVantages(values []string) (map[string]string, error) {
+	vantages := make(map[string]string, len(values))
+	for _, value := range values {
+		region, vantageURL, ok := strings.Cut(value, "=")
+		if !ok || region == "" {
+			return nil, fmt.Errorf("vantage %q must be region=URL", value)
+		}
+		parsed, err := url.Parse(vantageURL)
+		if err != nil || (parsed.Scheme != "http" && parsed.Scheme != "https") || parsed.Host == "" {
+			return nil, fmt.Errorf("vantage %s must have an http or https URL, got %q", region, vantageURL)
+		}
+		if _, ok := vantages[region]; ok {
+			return nil, fmt.Errorf("duplicate vantage %s", region)
+		}
+		vantages[region] = vantageURL
+	}
+	return vantages, nil
 }
 
 func NewConfig(ctx *cli.Context) (Config, error) {
@@ -64,6 +187,13 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		},
 		AllowOrigins: ctx.GlobalStringSlice(flags.AllowOriginsFlag.Name),
 
+		InternalSocketAddr:        ctx.GlobalString(flags.InternalSocketAddrFlag.Name),
+		InternalAllowOrigins:      ctx.GlobalStringSlice(flags.InternalAllowOriginsFlag.Name),
+		PublicRateLimit:           ctx.GlobalFloat64(flags.PublicRateLimitFlag.Name),
+		PublicRateBurst:           ctx.GlobalInt(flags.PublicRateBurstFlag.Name),
+		PublicTrustedProxies:      ctx.GlobalStringSlice(flags.PublicTrustedProxiesFlag.Name),
+		PublicMaxStreamsPerClient: ctx.GlobalInt(flags.PublicMaxStreamsPerClientFlag.Name),
+
 		MetricsConfig: dataapi.MetricsConfig{
 			HTTPPort:      ctx.GlobalString(flags.MetricsHTTPPort.Name),
 			EnableMetrics: ctx.GlobalBool(flags.EnableMetricsFlag.Name),
@@ -72,6 +202,109 @@ func NewConfig(ctx *cli.Context) (Config, error) {
 		ChurnerHostname:    ctx.GlobalString(flags.ChurnerHostnameFlag.Name),
 		BatcherHealthEndpt: ctx.GlobalString(flags.BatcherHealthEndptFlag.Name),
 		ChainStateConfig:   thegraph.ReadCLIConfig(ctx),
+		NetworkName:        ctx.GlobalString(flags.NetworkNameFlag.Name),
+
+		EnableAccountAnomalyDetection: ctx.GlobalBool(flags.EnableAccountAnomalyDetectionFlag.Name),
+		AccountAnomalyPollInterval:    ctx.GlobalDuration(flags.AccountAnomalyPollIntervalFlag.Name),
+		AccountAnomalyConfig: dataapi.AccountAnomalyConfig{
+			Window:           ctx.GlobalDuration(flags.AccountAnomalyWindowFlag.Name),
+			Delay:            ctx.GlobalDuration(flags.AccountAnomalyDelayFlag.Name),
+			BaselineWindows:  ctx.GlobalInt(flags.AccountAnomalyBaselineWindowsFlag.Name),
+			SpikeFactor:      ctx.GlobalFloat64(flags.AccountAnomalySpikeFactorFlag.Name),
+			MinSpikeBlobs:    ctx.GlobalInt(flags.AccountAnomalyMinSpikeBlobsFlag.Name),
+			MaxSizeThreshold: ctx.GlobalUint(flags.AccountAnomalyMaxSizeThresholdFlag.Name),
+			MaxSizeFraction:  ctx.GlobalFloat64(flags.AccountAnomalyMaxSizeFractionFlag.Name),
+			SustainedWindows: ctx.GlobalInt(flags.AccountAnomalySustainedWindowsFlag.Name),
+		},
+		AlertWebhookURL:          ctx.GlobalString(flags.AlertWebhookURLFlag.Name),
+		AlertWebhookSecret:       ctx.GlobalString(flags.AlertWebhookSecretFlag.Name),
+		AlertWebhookMaxRetries:   ctx.GlobalInt(flags.AlertWebhookMaxRetriesFlag.Name),
+		AlertWebhookRetryBackoff: ctx.GlobalDuration(flags.AlertWebhookRetryBackoffFlag.Name),
+		AlertSNSTopicARN:         ctx.GlobalString(flags.AlertSNSTopicARNFlag.Name),
+
+		GraphQLMaxDepth:      ctx.GlobalInt(flags.GraphQLMaxDepthFlag.Name),
+		GraphQLMaxComplexity: ctx.GlobalInt(flags.GraphQLMaxComplexityFlag.Name),
+
+		BlockExplorerURL: ctx.GlobalString(flags.BlockExplorerURLFlag.Name),
+
+		OperatorsPageSize:             ctx.GlobalInt(flags.OperatorsPageSizeFlag.Name),
+		NodeInfoWorkers:               ctx.GlobalInt(flags.NodeInfoWorkersFlag.Name),
+		OperatorOnlineCheckWorkers:    ctx.GlobalInt(flags.OperatorOnlineCheckWorkersFlag.Name),
+		OperatorOnlineCheckTimeout:    ctx.GlobalDuration(flags.OperatorOnlineCheckTimeoutFlag.Name),
+		OperatorStatusCacheTTL:        ctx.GlobalDuration(flags.OperatorStatusCacheTTLFlag.Name),
+		OperatorStatusRefreshInterval: ctx.GlobalDuration(flags.OperatorStatusRefreshIntervalFlag.Name),
+
+		ConfirmationStreamPollInterval: ctx.GlobalDuration(flags.ConfirmationStreamPollIntervalFlag.Name),
+
+		OperatorUptimeTableName:     ctx.GlobalString(flags.OperatorUptimeTableNameFlag.Name),
+		OperatorUptimeProbeInterval: ctx.GlobalDuration(flags.OperatorUptimeProbeIntervalFlag.Name),
+
+		MetadataArchiveBucketName:   ctx.GlobalString(flags.MetadataArchiveBucketNameFlag.Name),
+		MetadataArchivePrefix:       ctx.GlobalString(flags.MetadataArchivePrefixFlag.Name),
+		EnableMetadataArchiver:      ctx.GlobalBool(flags.EnableMetadataArchiverFlag.Name),
+		MetadataArchiveInterval:     ctx.GlobalDuration(flags.MetadataArchiveIntervalFlag.Name),
+		MetadataArchiveBeforeExpiry: ctx.GlobalDuration(flags.MetadataArchiveBeforeExpiryFlag.Name),
+
+		SubgraphMonitorInterval: ctx.GlobalDuration(flags.SubgraphMonitorIntervalFlag.Name),
+
+		BlobProbeKzgConfig: kzg.KzgConfig{
+			G1Path:          ctx.GlobalString(flags.BlobProbeG1PathFlag.Name),
+			G2PowerOf2Path:  ctx.GlobalString(flags.BlobProbeG2PowerOf2PathFlag.Name),
+			SRSOrder:        ctx.GlobalUint64(flags.BlobProbeSRSOrderFlag.Name),
+			SRSNumberToLoad: ctx.GlobalUint64(flags.BlobProbeSRSLoadingNumberFlag.Name),
+			NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+		},
+		BlobProbeSampleSize: ctx.GlobalInt(flags.BlobProbeSampleSizeFlag.Name),
+		BlobProbeTimeout:    ctx.GlobalDuration(flags.BlobProbeTimeoutFlag.Name),
+	}
+	if config.OperatorsPageSize <= 0 || config.OperatorsPageSize > 1000 {
+		return Config{}, fmt.Errorf("%s must be between 1 and 1000", flags.OperatorsPageSizeFlag.Name)
+	}
+	if config.PublicRateLimit < 0 {
+		return Config{}, fmt.Errorf("%s must not be negative", flags.PublicRateLimitFlag.Name)
+	}
+	if config.PublicMaxStreamsPerClient < 0 {
+		return Config{}, fmt.Errorf("%s must not be negative", flags.PublicMaxStreamsPerClientFlag.Name)
+	}
+	if config.InternalSocketAddr != "" && config.InternalSocketAddr == config.SocketAddr {
+		return Config{}, fmt.Errorf("%s must differ from %s", flags.InternalSocketAddrFlag.Name, flags.SocketAddrFlag.Name)
+	}
+	if config.NodeInfoWorkers <= 0 {
+		return Config{}, fmt.Errorf("%s must be positive", flags.NodeInfoWorkersFlag.Name)
+	}
+	if config.OperatorOnlineCheckWorkers <= 0 {
+		return Config{}, fmt.Errorf("%s must be positive", flags.OperatorOnlineCheckWorkersFlag.Name)
+	}
+	if config.OperatorOnlineCheckTimeout <= 0 {
+		return Config{}, fmt.Errorf("%s must be positive", flags.OperatorOnlineCheckTimeoutFlag.Name)
+	}
+	config.OperatorProbeVantages, err = parseProbeVantages(ctx.GlobalStringSlice(flags.OperatorProbeVantagesFlag.Name))
+	if err != nil {
+		return Config{}, fmt.Errorf("invalid %s: %w", flags.OperatorProbeVantagesFlag.Name, err)
+	}
+	if config.OperatorStatusCacheTTL > 0 && config.OperatorStatusRefreshInterval >= config.OperatorStatusCacheTTL {
+		return Config{}, fmt.Errorf("%s must be shorter than %s", flags.OperatorStatusRefreshIntervalFlag.Name, flags.OperatorStatusCacheTTLFlag.Name)
+	}
+	if config.BlobProbeSampleSize <= 0 || config.BlobProbeSampleSize > 20 {
+		return Config{}, fmt.Errorf("%s must be between 1 and 20", flags.BlobProbeSampleSizeFlag.Name)
+	}
+	if config.BlobProbeTimeout <= 0 {
+		return Config{}, fmt.Errorf("%s must be positive", flags.BlobProbeTimeoutFlag.Name)
+	}
+	if config.BlobProbeKzgConfig.G1Path != "" && config.BlobProbeKzgConfig.G2PowerOf2Path == "" {
+		return Config{}, fmt.Errorf("%s is required when %s is set", flags.BlobProbeG2PowerOf2PathFlag.Name, flags.BlobProbeG1PathFlag.Name)
+	}
+	if config.EnableMetadataArchiver && config.MetadataArchiveBucketName == "" {
+		return Config{}, fmt.Errorf("%s is required when %s is set", flags.MetadataArchiveBucketNameFlag.Name, flags.EnableMetadataArchiverFlag.Name)
+	}
+	if path := ctx.GlobalString(flags.NetworksConfigFileFlag.Name); path != "" {
+		if config.NetworkName == "" {
+			return Config{}, fmt.Errorf("%s is required when %s is set", flags.NetworkNameFlag.Name, flags.NetworksConfigFileFlag.Name)
+		}
+		config.Networks, err = readNetworksConfig(path)
+		if err != nil {
+			return Config{}, err
+		}
 	}
 	return config, nil
 }
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/geth"
@@ -132,6 +134,326 @@ var (
 		Value:    "9100",
 		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METRICS_HTTP_PORT"),
 	}
+	NetworkNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "network-name"),
+		Usage:    "Name of the network configured by the other flags, used as its route prefix (e.g. mainnet). Required when serving additional networks",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NETWORK_NAME"),
+	}
+	NetworksConfigFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "networks-config-file"),
+		Usage:    "Path to a JSON file listing additional networks to serve from this deployment",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NETWORKS_CONFIG_FILE"),
+	}
+	EnableAccountAnomalyDetectionFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-account-anomaly-detection"),
+		Usage:    "Watch the dispersal rates of the accounts and alert on anomalies such as rate spikes and sustained max-size blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_ACCOUNT_ANOMALY_DETECTION"),
+	}
+	AccountAnomalyPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-poll-interval"),
+		Usage:    "Interval at which the latest dispersals are fetched by the account anomaly detector",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_POLL_INTERVAL"),
+	}
+	AccountAnomalyWindowFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-window"),
+		Usage:    "Duration over which the dispersals of an account are aggregated",
+		Required: false,
+		Value:    10 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_WINDOW"),
+	}
+	AccountAnomalyDelayFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-delay"),
+		Usage:    "How long after its end a window is evaluated, to account for the confirmation delay of the blobs",
+		Required: false,
+		Value:    10 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_DELAY"),
+	}
+	AccountAnomalyBaselineWindowsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-baseline-windows"),
+		Usage:    "Number of windows over which the baseline dispersal rate of an account is averaged",
+		Required: false,
+		Value:    6,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_BASELINE_WINDOWS"),
+	}
+	AccountAnomalySpikeFactorFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-spike-factor"),
+		Usage:    "Ratio of the dispersal rate of an account to its baseline above which a rate spike is reported",
+		Required: false,
+		Value:    10,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_SPIKE_FACTOR"),
+	}
+	AccountAnomalyMinSpikeBlobsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-min-spike-blobs"),
+		Usage:    "Minimum number of blobs dispersed by an account in a window for a rate spike to be reported",
+		Required: false,
+		Value:    20,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_MIN_SPIKE_BLOBS"),
+	}
+	AccountAnomalyMaxSizeThresholdFlag = cli.UintFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-max-size-threshold"),
+		Usage:    "Size in bytes above which a blob counts as max-size",
+		Required: false,
+		Value:    1887436, // 90% of 2 MiB
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_MAX_SIZE_THRESHOLD"),
+	}
+	AccountAnomalyMaxSizeFractionFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-max-size-fraction"),
+		Usage:    "Fraction of the blobs of an account in a window that must be max-size for the window to count towards sustained max-size blobs",
+		Required: false,
+		Value:    0.9,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_MAX_SIZE_FRACTION"),
+	}
+	AccountAnomalySustainedWindowsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "account-anomaly-sustained-windows"),
+		Usage:    "Number of consecutive windows of max-size blobs for sustained max-size blobs to be reported",
+		Required: false,
+		Value:    6,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ACCOUNT_ANOMALY_SUSTAINED_WINDOWS"),
+	}
+	AlertWebhookURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-webhook-url"),
+		Usage:    "URL to which the account anomalies are posted as JSON",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_WEBHOOK_URL"),
+	}
+	AlertWebhookSecretFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-webhook-secret"),
+		Usage:    "Shared secret with which the alerts posted to the webhook are signed. The alerts are not signed if it is not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_WEBHOOK_SECRET"),
+	}
+	AlertWebhookMaxRetriesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-webhook-max-retries"),
+		Usage:    "Maximum number of retries of a failed delivery of an alert to the webhook",
+		Required: false,
+		Value:    3,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_WEBHOOK_MAX_RETRIES"),
+	}
+	AlertWebhookRetryBackoffFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-webhook-retry-backoff"),
+		Usage:    "Backoff before the first retry of a failed delivery of an alert to the webhook, doubled at every retry",
+		Required: false,
+		Value:    time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_WEBHOOK_RETRY_BACKOFF"),
+	}
+	InternalSocketAddrFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "internal-socket-addr"),
+		Usage:    "Socket address of the internal listener serving the full API, including the endpoints probing the operators. If set, the listener on the socket address only serves the public endpoints",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "INTERNAL_SOCKET_ADDR"),
+	}
+	InternalAllowOriginsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "internal-allow-origins"),
+		Usage:    "Set the allowed origins for CORS requests to the internal listener",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "INTERNAL_ALLOW_ORIGINS"),
+	}
+	PublicRateLimitFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "public-rate-limit"),
+		Usage:    "Average number of requests per second each client may make to the public listener when an internal listener is configured, 0 for no limit",
+		Required: false,
+		Value:    10,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PUBLIC_RATE_LIMIT"),
+	}
+	PublicRateBurstFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "public-rate-burst"),
+		Usage:    "Number of requests each client may make to the public listener in a burst",
+		Required: false,
+		Value:    50,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PUBLIC_RATE_BURST"),
+	}
+	PublicTrustedProxiesFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "public-trusted-proxies"),
+		Usage:    "IPs or CIDRs of the proxies whose forwarded client IPs are rate limited by the public listener. The IP of the connection is rate limited if none is set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PUBLIC_TRUSTED_PROXIES"),
+	}
+	PublicMaxStreamsPerClientFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "public-max-streams-per-client"),
+		Usage:    "Number of confirmation streams each client may keep open on the public listener when an internal listener is configured, 0 for no limit",
+		Required: false,
+		Value:    4,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "PUBLIC_MAX_STREAMS_PER_CLIENT"),
+	}
+	GraphQLMaxDepthFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "graphql-max-depth"),
+		Usage:    "Maximum nesting depth of the selections of a GraphQL query",
+		Required: false,
+		Value:    6,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GRAPHQL_MAX_DEPTH"),
+	}
+	GraphQLMaxComplexityFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "graphql-max-complexity"),
+		Usage:    "Maximum complexity of a GraphQL query, i.e. the number of fields it selects with list fields multiplied by their limit",
+		Required: false,
+		Value:    1000,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "GRAPHQL_MAX_COMPLEXITY"),
+	}
+	BlockExplorerURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "block-explorer-url"),
+		Usage:    "Base URL of the block explorer linked as evidence of the batch verifications (e.g. https://etherscan.io)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOCK_EXPLORER_URL"),
+	}
+	OperatorsPageSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operators-page-size"),
+		Usage:    "Number of operators per page of the operator state endpoints when the request sets no limit, at most 1000",
+		Required: false,
+		Value:    100,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATORS_PAGE_SIZE"),
+	}
+	NodeInfoWorkersFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "node-info-workers"),
+		Usage:    "Number of concurrent node info requests of the semver scans and the hardware inventory of the operators",
+		Required: false,
+		Value:    20,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "NODE_INFO_WORKERS"),
+	}
+	OperatorOnlineCheckWorkersFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-online-check-workers"),
+		Usage:    "Number of concurrent online checks of the operators by the operator endpoints, the refresher of their statuses and the uptime tracker",
+		Required: false,
+		Value:    50,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_ONLINE_CHECK_WORKERS"),
+	}
+	OperatorOnlineCheckTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-online-check-timeout"),
+		Usage:    "Timeout of the dial of the socket of an operator checked online by the operator endpoints and the refresher of their statuses",
+		Required: false,
+		Value:    10 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_ONLINE_CHECK_TIMEOUT"),
+	}
+	OperatorProbeVantagesFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-probe-vantages"),
+		Usage:    "Data APIs deployed in other regions from which the operator diagnosis checks the reachability of the operators, as region=URL of their v1 API (e.g. eu-west-1=https://dataapi-eu.example.com/api/v1)",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_PROBE_VANTAGES"),
+	}
+	OperatorStatusCacheTTLFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-status-cache-ttl"),
+		Usage:    "How long the online statuses and the semvers of the operators are reused by the operator endpoints. They are probed on each request if it is 0",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_STATUS_CACHE_TTL"),
+	}
+	OperatorStatusRefreshIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-status-refresh-interval"),
+		Usage:    "Interval at which the cached operator statuses are probed again in the background. It must be shorter than the cache TTL, and the statuses are only probed by the requests if it is 0",
+		Required: false,
+		Value:    45 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_STATUS_REFRESH_INTERVAL"),
+	}
+	ConfirmationStreamPollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-stream-poll-interval"),
+		Usage:    "Interval at which the confirmation stream polls for the newly confirmed batches and finalized blobs. The stream is not served if it is 0",
+		Required: false,
+		Value:    12 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "CONFIRMATION_STREAM_POLL_INTERVAL"),
+	}
+	OperatorUptimeTableNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-uptime-table-name"),
+		Usage:    "Name of the DynamoDB table of the hourly uptime of the operators. The uptime is not tracked nor served if it is not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_UPTIME_TABLE_NAME"),
+	}
+	OperatorUptimeProbeIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-uptime-probe-interval"),
+		Usage:    "Interval at which the operators are probed for their uptime. The uptime is only served if it is 0, e.g. by the replicas which share the table of another",
+		Required: false,
+		Value:    5 * time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "OPERATOR_UPTIME_PROBE_INTERVAL"),
+	}
+	MetadataArchiveBucketNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-archive-bucket-name"),
+		Usage:    "Name of the S3 bucket of the blob metadata archive, from which the metadata expired from DynamoDB is served. The archive isn't used if it is not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_ARCHIVE_BUCKET_NAME"),
+	}
+	MetadataArchivePrefixFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-archive-prefix"),
+		Usage:    "Prefix of the keys of the objects of the blob metadata archive",
+		Required: false,
+		Value:    "blob-metadata",
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_ARCHIVE_PREFIX"),
+	}
+	EnableMetadataArchiverFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-metadata-archiver"),
+		Usage:    "Archive the metadata of the confirmed blobs before it expires from DynamoDB. It only needs to be enabled on one instance",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ENABLE_METADATA_ARCHIVER"),
+	}
+	MetadataArchiveIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-archive-interval"),
+		Usage:    "Interval at which the expiring blob metadata is archived",
+		Required: false,
+		Value:    time.Hour,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_ARCHIVE_INTERVAL"),
+	}
+	MetadataArchiveBeforeExpiryFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metadata-archive-before-expiry"),
+		Usage:    "How long before its expiry the metadata of a blob is archived. It must be longer than the archive interval",
+		Required: false,
+		Value:    24 * time.Hour,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "METADATA_ARCHIVE_BEFORE_EXPIRY"),
+	}
+	SubgraphMonitorIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "subgraph-monitor-interval"),
+		Usage:    "Interval at which the indexing status of the subgraphs is recorded in the metrics. It is not recorded if it is 0 or the metrics are disabled",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "SUBGRAPH_MONITOR_INTERVAL"),
+	}
+	AlertSNSTopicARNFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "alert-sns-topic-arn"),
+		Usage:    "ARN of the SNS topic to which the account anomalies are published",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "ALERT_SNS_TOPIC_ARN"),
+	}
+	BlobProbeG1PathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-probe-g1-path"),
+		Usage:    "Path to the G1 SRS with which the blob availability probe verifies the chunks served by the operators. The probe is not served if it is not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_PROBE_G1_PATH"),
+	}
+	BlobProbeG2PowerOf2PathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-probe-g2-power-of-2-path"),
+		Usage:    "Path to the G2 SRS points on powers of 2 of the blob availability probe",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_PROBE_G2_POWER_OF_2_PATH"),
+	}
+	BlobProbeSRSOrderFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-probe-srs-order"),
+		Usage:    "Order of the SRS of the blob availability probe",
+		Required: false,
+		Value:    268435456,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_PROBE_SRS_ORDER"),
+	}
+	BlobProbeSRSLoadingNumberFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-probe-srs-load"),
+		Usage:    "Number of SRS points loaded into memory by the blob availability probe",
+		Required: false,
+		Value:    524288,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_PROBE_SRS_LOAD"),
+	}
+	BlobProbeSampleSizeFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-probe-sample-size"),
+		Usage:    "Number of operators of each quorum sampled by the blob availability probe when the request sets no sample size, at most 20",
+		Required: false,
+		Value:    5,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_PROBE_SAMPLE_SIZE"),
+	}
+	BlobProbeTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "blob-probe-timeout"),
+		Usage:    "Timeout of the request of the chunks of a blob to an operator by the blob availability probe",
+		Required: false,
+		Value:    10 * time.Second,
+		EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLOB_PROBE_TIMEOUT"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -156,6 +478,54 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	ServerModeFlag,
 	MetricsHTTPPort,
+	NetworkNameFlag,
+	NetworksConfigFileFlag,
+	EnableAccountAnomalyDetectionFlag,
+	AccountAnomalyPollIntervalFlag,
+	AccountAnomalyWindowFlag,
+	AccountAnomalyDelayFlag,
+	AccountAnomalyBaselineWindowsFlag,
+	AccountAnomalySpikeFactorFlag,
+	AccountAnomalyMinSpikeBlobsFlag,
+	AccountAnomalyMaxSizeThresholdFlag,
+	AccountAnomalyMaxSizeFractionFlag,
+	AccountAnomalySustainedWindowsFlag,
+	AlertWebhookURLFlag,
+	AlertWebhookSecretFlag,
+	AlertWebhookMaxRetriesFlag,
+	AlertWebhookRetryBackoffFlag,
+	AlertSNSTopicARNFlag,
+	InternalSocketAddrFlag,
+	InternalAllowOriginsFlag,
+	PublicRateLimitFlag,
+	PublicRateBurstFlag,
+	PublicTrustedProxiesFlag,
+	PublicMaxStreamsPerClientFlag,
+	GraphQLMaxDepthFlag,
+	GraphQLMaxComplexityFlag,
+	BlockExplorerURLFlag,
+	OperatorsPageSizeFlag,
+	NodeInfoWorkersFlag,
+	OperatorOnlineCheckWorkersFlag,
+	OperatorOnlineCheckTimeoutFlag,
+	OperatorProbeVantagesFlag,
+	OperatorStatusCacheTTLFlag,
+	OperatorStatusRefreshIntervalFlag,
+	ConfirmationStreamPollIntervalFlag,
+	OperatorUptimeTableNameFlag,
+	OperatorUptimeProbeIntervalFlag,
+	MetadataArchiveBucketNameFlag,
+	MetadataArchivePrefixFlag,
+	EnableMetadataArchiverFlag,
+	MetadataArchiveIntervalFlag,
+	MetadataArchiveBeforeExpiryFlag,
+	SubgraphMonitorIntervalFlag,
+	BlobProbeG1PathFlag,
+	BlobProbeG2PowerOf2PathFlag,
+	BlobProbeSRSOrderFlag,
+	BlobProbeSRSLoadingNumberFlag,
+	BlobProbeSampleSizeFlag,
+	BlobProbeTimeoutFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
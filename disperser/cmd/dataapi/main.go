@@ -8,17 +8,24 @@ import (
 	"os/signal"
 	"syscall"
 
+	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
 	"github.com/Layr-Labs/eigenda/common/aws/s3"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	coreeth "github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/cmd/dataapi/flags"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	awsconfig "github.com/aws/aws-sdk-go-v2/config"
+	"github.com/aws/aws-sdk-go-v2/credentials"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
 
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/urfave/cli"
@@ -93,23 +100,90 @@ func RunDataApi(ctx *cli.Context) error {
 		blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, config.BlobstoreConfig.TableName, config.BlobstoreConfig.ShadowTableName, 0)
 		sharedStorage     = blobstore.NewSharedStorage(config.BlobstoreConfig.BucketName, s3Client, blobMetadataStore, logger)
 		subgraphApi       = subgraph.NewApi(config.SubgraphApiBatchMetadataAddr, config.SubgraphApiOperatorStateAddr)
-		subgraphClient    = dataapi.NewSubgraphClient(subgraphApi, logger)
+		metrics           = dataapi.NewMetrics(blobMetadataStore, config.MetricsConfig.HTTPPort, logger)
+		subgraphClient    = dataapi.NewSubgraphClient(dataapi.NewInstrumentedSubgraphApi(subgraphApi, config.NetworkName, metrics), logger)
 		chainState        = coreeth.NewChainState(tx, client)
 		indexedChainState = thegraph.MakeIndexedChainState(config.ChainStateConfig, chainState, logger)
-		metrics           = dataapi.NewMetrics(blobMetadataStore, config.MetricsConfig.HTTPPort, logger)
-		server            = dataapi.NewServer(
-			dataapi.Config{
-				ServerMode:         config.ServerMode,
-				SocketAddr:         config.SocketAddr,
-				AllowOrigins:       config.AllowOrigins,
-				DisperserHostname:  config.DisperserHostname,
-				ChurnerHostname:    config.ChurnerHostname,
-				BatcherHealthEndpt: config.BatcherHealthEndpt,
-			},
-			sharedStorage,
+		serverConfig      = dataapi.Config{
+			ServerMode:         config.ServerMode,
+			SocketAddr:         config.SocketAddr,
+			AllowOrigins:       config.AllowOrigins,
+			DisperserHostname:  config.DisperserHostname,
+			ChurnerHostname:    config.ChurnerHostname,
+			BatcherHealthEndpt: config.BatcherHealthEndpt,
+
+			InternalSocketAddr:        config.InternalSocketAddr,
+			InternalAllowOrigins:      config.InternalAllowOrigins,
+			PublicRateLimit:           config.PublicRateLimit,
+			PublicRateBurst:           config.PublicRateBurst,
+			PublicTrustedProxies:      config.PublicTrustedProxies,
+			PublicMaxStreamsPerClient: config.PublicMaxStreamsPerClient,
+
+			GraphQLMaxDepth:      config.GraphQLMaxDepth,
+			GraphQLMaxComplexity: config.GraphQLMaxComplexity,
+
+			EigenDAServiceManagerAddr: config.EigenDAServiceManagerAddr,
+			BlockExplorerURL:          config.BlockExplorerURL,
+
+			OperatorsPageSize:              config.OperatorsPageSize,
+			NodeInfoWorkers:                config.NodeInfoWorkers,
+			OperatorOnlineCheckWorkers:     config.OperatorOnlineCheckWorkers,
+			OperatorOnlineCheckTimeout:     config.OperatorOnlineCheckTimeout,
+			OperatorProbeVantages:          config.OperatorProbeVantages,
+			OperatorStatusCacheTTL:         config.OperatorStatusCacheTTL,
+			OperatorStatusRefreshInterval:  config.OperatorStatusRefreshInterval,
+			ConfirmationStreamPollInterval: config.ConfirmationStreamPollInterval,
+
+			OperatorUptimeStore:         newOperatorUptimeStore(dynamoClient, config.OperatorUptimeTableName),
+			OperatorUptimeProbeInterval: config.OperatorUptimeProbeInterval,
+
+			BlobProbeNodeClient: clients.NewNodeClient(config.BlobProbeTimeout),
+			BlobProbeSampleSize: config.BlobProbeSampleSize,
+		}
+		server interface {
+			dataapi.DispersalSource
+			Start() error
+			Shutdown() error
+		}
+	)
+
+	// Serve the metadata expired from DynamoDB from the archive
+	var blobStore disperser.BlobStore = sharedStorage
+	if config.MetadataArchiveBucketName != "" {
+		archive := blobstore.NewMetadataArchive(s3Client, config.MetadataArchiveBucketName, config.MetadataArchivePrefix, logger)
+		blobStore = blobstore.NewArchivedBlobStore(sharedStorage, archive, logger)
+		if config.EnableMetadataArchiver {
+			archiver := blobstore.NewMetadataArchiver(blobMetadataStore, archive, config.MetadataArchiveBeforeExpiry, logger)
+			archiver.Start(context.Background(), config.MetadataArchiveInterval)
+			logger.Info("Enabled blob metadata archiver", "bucket", config.MetadataArchiveBucketName, "interval", config.MetadataArchiveInterval)
+		}
+	}
+
+	// The blob availability probe needs the SRS to verify the chunks served by the operators
+	if config.BlobProbeKzgConfig.G1Path != "" {
+		serverConfig.BlobProbeVerifier, err = verifier.NewVerifier(&config.BlobProbeKzgConfig, false)
+		if err != nil {
+			return fmt.Errorf("failed to create the verifier of the blob availability probe: %w", err)
+		}
+		logger.Info("Enabled blob availability probe", "sampleSize", config.BlobProbeSampleSize)
+	}
+
+	// Fail fast if a subgraph was redeployed with a schema the queries don't support
+	if err := subgraphApi.DetectSchemaVersions(context.Background()); err != nil {
+		return err
+	}
+	batchMetadataSchema, operatorStateSchema := subgraphApi.SchemaVersions()
+	logger.Info("Detected subgraph schema versions", "batchMetadata", batchMetadataSchema, "operatorState", operatorStateSchema)
+	startSubgraphMonitor(config, subgraphApi, config.NetworkName, metrics, logger)
+
+	if config.NetworkName == "" {
+		server = dataapi.NewServer(
+			serverConfig,
+			blobStore,
 			promClient,
 			subgraphClient,
 			tx,
+			client,
 			chainState,
 			indexedChainState,
 			logger,
@@ -118,7 +192,40 @@ func RunDataApi(ctx *cli.Context) error {
 			nil,
 			nil,
 		)
-	)
+	} else {
+		networks := []dataapi.Network{
+			{
+				Name:               config.NetworkName,
+				BlobStore:          blobStore,
+				PromClient:         promClient,
+				SubgraphClient:     subgraphClient,
+				Transactor:         tx,
+				EthClient:          client,
+				ChainState:         chainState,
+				IndexedChainState:  indexedChainState,
+				DisperserHostname:  config.DisperserHostname,
+				ChurnerHostname:    config.ChurnerHostname,
+				BatcherHealthEndpt: config.BatcherHealthEndpt,
+
+				EigenDAServiceManagerAddr: config.EigenDAServiceManagerAddr,
+				BlockExplorerURL:          config.BlockExplorerURL,
+				UptimeStore:               serverConfig.OperatorUptimeStore,
+			},
+		}
+		for _, networkConfig := range config.Networks {
+			network, err := newNetwork(config, networkConfig, promApi, s3Client, dynamoClient, metrics, logger)
+			if err != nil {
+				return fmt.Errorf("failed to configure network %s: %w", networkConfig.Name, err)
+			}
+			networks = append(networks, network)
+		}
+		multiNetworkServer, err := dataapi.NewMultiNetworkServer(serverConfig, networks, logger, metrics, nil, nil)
+		if err != nil {
+			return err
+		}
+		logger.Info("Serving multiple networks", "networks", multiNetworkServer.Networks())
+		server = multiNetworkServer
+	}
 
 	// Enable Metrics Block
 	if config.MetricsConfig.EnableMetrics {
@@ -127,6 +234,19 @@ func RunDataApi(ctx *cli.Context) error {
 		logger.Info("Enabled metrics for Data Access API", "socket", httpSocket)
 	}
 
+	if config.EnableAccountAnomalyDetection {
+		sinks, err := newAlertSinks(config, logger)
+		if err != nil {
+			return err
+		}
+		detector, err := dataapi.NewAccountAnomalyDetector(config.AccountAnomalyConfig, server, sinks, logger)
+		if err != nil {
+			return err
+		}
+		detector.Start(context.Background(), config.AccountAnomalyPollInterval)
+		logger.Info("Enabled account anomaly detection", "window", config.AccountAnomalyConfig.Window, "numSinks", len(sinks))
+	}
+
 	// Setup channel to listen for termination signals
 	quit := make(chan os.Signal, 1)
 	// catch SIGINT (Ctrl+C) and SIGTERM (e.g., from `kill`)
@@ -150,3 +270,103 @@ func RunDataApi(ctx *cli.Context) error {
 
 	return err
 }
+
+// newNetwork creates the backends of an additional network. The AWS and prometheus clients
+// are shared with the primary network.
+func newNetwork(
+	config Config,
+	networkConfig NetworkConfig,
+	promApi prometheus.Api,
+	s3Client s3.Client,
+	dynamoClient *dynamodb.Client,
+	metrics *dataapi.Metrics,
+	logger logging.Logger,
+) (dataapi.Network, error) {
+	ethClientConfig := config.EthClientConfig
+	ethClientConfig.RPCURLs = networkConfig.RPCURLs
+	client, err := geth.NewMultiHomingClient(ethClientConfig, gethcommon.Address{}, logger)
+	if err != nil {
+		return dataapi.Network{}, err
+	}
+
+	tx, err := coreeth.NewTransactor(logger, client, networkConfig.BLSOperatorStateRetrieverAddr, networkConfig.EigenDAServiceManagerAddr)
+	if err != nil {
+		return dataapi.Network{}, err
+	}
+
+	chainStateConfig := config.ChainStateConfig
+	chainStateConfig.Endpoint = networkConfig.IndexerEndpoint
+
+	var (
+		blobMetadataStore = blobstore.NewBlobMetadataStore(dynamoClient, logger, networkConfig.DynamoTableName, "", 0)
+		sharedStorage     = blobstore.NewSharedStorage(networkConfig.S3BucketName, s3Client, blobMetadataStore, logger)
+		subgraphApi       = subgraph.NewApi(networkConfig.SubgraphApiBatchMetadataAddr, networkConfig.SubgraphApiOperatorStateAddr)
+		chainState        = coreeth.NewChainState(tx, client)
+	)
+	startSubgraphMonitor(config, subgraphApi, networkConfig.Name, metrics, logger)
+	return dataapi.Network{
+		Name:               networkConfig.Name,
+		BlobStore:          sharedStorage,
+		PromClient:         dataapi.NewPrometheusClient(promApi, networkConfig.PrometheusClusterLabel),
+		SubgraphClient:     dataapi.NewSubgraphClient(dataapi.NewInstrumentedSubgraphApi(subgraphApi, networkConfig.Name, metrics), logger),
+		Transactor:         tx,
+		EthClient:          client,
+		ChainState:         chainState,
+		IndexedChainState:  thegraph.MakeIndexedChainState(chainStateConfig, chainState, logger),
+		DisperserHostname:  networkConfig.DisperserHostname,
+		ChurnerHostname:    networkConfig.ChurnerHostname,
+		BatcherHealthEndpt: networkConfig.BatcherHealthEndpt,
+
+		EigenDAServiceManagerAddr: networkConfig.EigenDAServiceManagerAddr,
+		BlockExplorerURL:          networkConfig.BlockExplorerURL,
+		UptimeStore:               newOperatorUptimeStore(dynamoClient, networkConfig.OperatorUptimeTableName),
+	}, nil
+}
+
+// newOperatorUptimeStore returns the store of the uptime of the operators in the table, or nil if no table is set
+func newOperatorUptimeStore(dynamoClient *dynamodb.Client, tableName string) dataapi.OperatorUptimeStore {
+	if tableName == "" {
+		return nil
+	}
+	return dataapi.NewDynamoOperatorUptimeStore(dynamoClient, tableName)
+}
+
+// startSubgraphMonitor records the indexing status of the subgraphs of the network in the metrics if they are enabled
+func startSubgraphMonitor(config Config, source dataapi.SubgraphIndexingSource, network string, metrics *dataapi.Metrics, logger logging.Logger) {
+	if !config.MetricsConfig.EnableMetrics || config.SubgraphMonitorInterval <= 0 {
+		return
+	}
+	dataapi.NewSubgraphMonitor(source, network, metrics, logger).Start(context.Background(), config.SubgraphMonitorInterval)
+	logger.Info("Enabled subgraph monitor", "network", network, "interval", config.SubgraphMonitorInterval)
+}
+
+// newAlertSinks creates the sinks of the account anomalies configured by the flags
+func newAlertSinks(config Config, logger logging.Logger) ([]dataapi.AlertSink, error) {
+	sinks := make([]dataapi.AlertSink, 0)
+	if config.AlertWebhookURL != "" {
+		sinks = append(sinks, dataapi.NewWebhookAlertSink(config.AlertWebhookURL, config.AlertWebhookSecret, config.AlertWebhookMaxRetries, config.AlertWebhookRetryBackoff, logger))
+	}
+	if config.AlertSNSTopicARN != "" {
+		options := [](func(*awsconfig.LoadOptions) error){
+			awsconfig.WithRegion(config.AwsClientConfig.Region),
+		}
+		// If access key and secret access key are not provided, use the default credential provider
+		if len(config.AwsClientConfig.AccessKey) > 0 && len(config.AwsClientConfig.SecretAccessKey) > 0 {
+			options = append(options, awsconfig.WithCredentialsProvider(credentials.NewStaticCredentialsProvider(config.AwsClientConfig.AccessKey, config.AwsClientConfig.SecretAccessKey, "")))
+		}
+		awsConfig, err := awsconfig.LoadDefaultConfig(context.Background(), options...)
+		if err != nil {
+			return nil, fmt.Errorf("failed to load AWS config for SNS alerts: %w", err)
+		}
+		snsClient := sns.NewFromConfig(awsConfig, func(o *sns.Options) {
+			if config.AwsClientConfig.EndpointURL != "" {
+				o.BaseEndpoint = &config.AwsClientConfig.EndpointURL
+			}
+		})
+		sinks = append(sinks, dataapi.NewSNSAlertSink(snsClient, config.AlertSNSTopicARN))
+	}
+	if len(sinks) == 0 {
+		logger.Warn("Account anomaly detection is enabled without alert sinks, anomalies are only logged")
+	}
+	return sinks, nil
+}
@@ -0,0 +1,94 @@
+package blobstore
+
+import (
+	"bytes"
+	"context"
+	"errors"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// ArchivedBlobStore is a blob store which serves the lookups of the blob metadata which isn't in the underlying
+// blob store anymore from the metadata archive. The lookups served from the archive are slower, as they read
+// compressed batch objects from S3.
+type ArchivedBlobStore struct {
+	disperser.BlobStore
+
+	archive *MetadataArchive
+	logger  logging.Logger
+}
+
+var _ disperser.BlobStore = (*ArchivedBlobStore)(nil)
+
+func NewArchivedBlobStore(blobStore disperser.BlobStore, archive *MetadataArchive, logger logging.Logger) *ArchivedBlobStore {
+	return &ArchivedBlobStore{
+		BlobStore: blobStore,
+		archive:   archive,
+		logger:    logger.With("component", "ArchivedBlobStore"),
+	}
+}
+
+func (s *ArchivedBlobStore) GetBlobMetadata(ctx context.Context, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
+	metadata, err := s.BlobStore.GetBlobMetadata(ctx, blobKey)
+	if !errors.Is(err, disperser.ErrMetadataNotFound) {
+		return metadata, err
+	}
+	s.logger.Debug("serving blob metadata from the archive", "blobKey", blobKey.String())
+	return s.archive.GetBlobMetadata(ctx, blobKey)
+}
+
+func (s *ArchivedBlobStore) GetMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
+	metadata, err := s.BlobStore.GetMetadataInBatch(ctx, batchHeaderHash, blobIndex)
+	if !errors.Is(err, disperser.ErrMetadataNotFound) {
+		return metadata, err
+	}
+	s.logger.Debug("serving blob metadata from the archive", "batchHeaderHash", batchHeaderHash, "blobIndex", blobIndex)
+	return s.archive.GetBlobMetadataInBatch(ctx, batchHeaderHash, blobIndex)
+}
+
+func (s *ArchivedBlobStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	metadatas, err := s.BlobStore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if !errors.Is(err, disperser.ErrMetadataNotFound) {
+		return metadatas, err
+	}
+	s.logger.Debug("serving batch metadata from the archive", "batchHeaderHash", batchHeaderHash)
+	return s.archive.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+}
+
+// GetAllBlobMetadataByBatchWithPagination pages through the archived metadata of the batch if the underlying blob
+// store has none. The keys of the archived pages are only set with the batch header hash and the blob index, and are
+// never passed to the underlying blob store.
+func (s *ArchivedBlobStore) GetAllBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *disperser.BatchIndexExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BatchIndexExclusiveStartKey, error) {
+	if exclusiveStartKey == nil || exclusiveStartKey.BlobHash != "" {
+		metadatas, nextKey, err := s.BlobStore.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, limit, exclusiveStartKey)
+		if err != nil || len(metadatas) > 0 || nextKey != nil {
+			return metadatas, nextKey, err
+		}
+	}
+
+	archived, err := s.archive.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if errors.Is(err, disperser.ErrMetadataNotFound) {
+		return nil, nil, nil
+	}
+	if err != nil {
+		return nil, nil, err
+	}
+	s.logger.Debug("serving batch metadata from the archive", "batchHeaderHash", batchHeaderHash)
+
+	page := make([]*disperser.BlobMetadata, 0)
+	for _, metadata := range archived {
+		if exclusiveStartKey != nil && metadata.ConfirmationInfo.BlobIndex <= exclusiveStartKey.BlobIndex {
+			continue
+		}
+		if limit > 0 && int32(len(page)) == limit {
+			last := page[len(page)-1]
+			return page, &disperser.BatchIndexExclusiveStartKey{
+				BatchHeaderHash: bytes.Clone(batchHeaderHash[:]),
+				BlobIndex:       last.ConfirmationInfo.BlobIndex,
+			}, nil
+		}
+		page = append(page, metadata)
+	}
+	return page, nil, nil
+}
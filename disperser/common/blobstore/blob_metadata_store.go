@@ -20,6 +20,8 @@ const (
 	statusIndexName = "StatusIndex"
 	batchIndexName  = "BatchIndex"
 	expiryIndexName = "Status-Expiry-Index"
+	// commitmentIndexName is the sparse index of the confirmed blobs by the hash of their KZG commitment
+	commitmentIndexName = "CommitmentIndex"
 )
 
 // BlobMetadataStore is a blob metadata storage backed by DynamoDB
@@ -28,6 +30,7 @@ const (
 // - Indexes
 //   - StatusIndex: (Partition Key: Status, Sort Key: RequestedAt) -> Metadata
 //   - BatchIndex: (Partition Key: BatchHeaderHash, Sort Key: BlobIndex) -> Metadata
+//   - CommitmentIndex: (Partition Key: CommitmentHash, Sort Key: RequestedAt) -> Metadata
 type BlobMetadataStore struct {
 	dynamoDBClient  *commondynamodb.Client
 	logger          logging.Logger
@@ -220,6 +223,61 @@ func (s *BlobMetadataStore) GetBlobMetadataByStatusWithPagination(ctx context.Co
 	return metadata, exclusiveStartKey, nil
 }
 
+// GetExpiringBlobMetadataByStatusWithPagination returns the metadata with the given status which haven't expired yet
+// but expire before the given unix time, upto the specified limit, along with a pagination token that can be used to
+// fetch the next set of items. It is used to archive the metadata before it is deleted by the TTL of the table.
+func (s *BlobMetadataStore) GetExpiringBlobMetadataByStatusWithPagination(ctx context.Context, status disperser.BlobStatus, expiringBefore int64, limit int32, exclusiveStartKey *disperser.BlobStoreExclusiveStartKey) ([]*disperser.BlobMetadata, *disperser.BlobStoreExclusiveStartKey, error) {
+	var attributeMap map[string]types.AttributeValue
+	var err error
+
+	// Convert the exclusive start key to a map of AttributeValue
+	if exclusiveStartKey != nil {
+		attributeMap, err = convertToAttribMap(exclusiveStartKey)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	queryResult, err := s.dynamoDBClient.QueryIndexWithPagination(ctx, s.tableName, expiryIndexName, "BlobStatus = :status AND Expiry BETWEEN :expiry AND :expiring_before", commondynamodb.ExpresseionValues{
+		":status": &types.AttributeValueMemberN{
+			Value: strconv.Itoa(int(status)),
+		},
+		":expiry": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(time.Now().Unix(), 10),
+		},
+		":expiring_before": &types.AttributeValueMemberN{
+			Value: strconv.FormatInt(expiringBefore, 10),
+		},
+	}, limit, attributeMap)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	// When no more results to fetch, the LastEvaluatedKey is nil
+	if queryResult.Items == nil && queryResult.LastEvaluatedKey == nil {
+		return nil, nil, nil
+	}
+
+	metadata := make([]*disperser.BlobMetadata, len(queryResult.Items))
+	for i, item := range queryResult.Items {
+		metadata[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, nil, err
+		}
+	}
+
+	lastEvaluatedKey := queryResult.LastEvaluatedKey
+	if lastEvaluatedKey == nil {
+		return metadata, nil, nil
+	}
+
+	exclusiveStartKey, err = convertToExclusiveStartKey(lastEvaluatedKey)
+	if err != nil {
+		return nil, nil, err
+	}
+	return metadata, exclusiveStartKey, nil
+}
+
 func (s *BlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
 	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, batchIndexName, "BatchHeaderHash = :batch_header_hash", commondynamodb.ExpresseionValues{
 		":batch_header_hash": &types.AttributeValueMemberB{
@@ -231,7 +289,34 @@ func (s *BlobMetadataStore) GetAllBlobMetadataByBatch(ctx context.Context, batch
 	}
 
 	if len(items) == 0 {
-		return nil, fmt.Errorf("there is no metadata for batch %x", batchHeaderHash)
+		return nil, fmt.Errorf("%w: there is no metadata for batch %x", disperser.ErrMetadataNotFound, batchHeaderHash)
+	}
+
+	metadatas := make([]*disperser.BlobMetadata, len(items))
+	for i, item := range items {
+		metadatas[i], err = UnmarshalBlobMetadata(item)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	return metadatas, nil
+}
+
+// GetBlobMetadataByCommitmentHash returns the metadata of the confirmed blobs whose KZG commitment hashes to
+// commitmentHash, in the order in which they were requested
+func (s *BlobMetadataStore) GetBlobMetadataByCommitmentHash(ctx context.Context, commitmentHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	items, err := s.dynamoDBClient.QueryIndex(ctx, s.tableName, commitmentIndexName, "CommitmentHash = :commitment_hash", commondynamodb.ExpresseionValues{
+		":commitment_hash": &types.AttributeValueMemberB{
+			Value: commitmentHash[:],
+		},
+	})
+	if err != nil {
+		return nil, err
+	}
+
+	if len(items) == 0 {
+		return nil, fmt.Errorf("%w: there is no metadata for commitment hash %x", disperser.ErrMetadataNotFound, commitmentHash)
 	}
 
 	metadatas := make([]*disperser.BlobMetadata, len(items))
@@ -414,6 +499,28 @@ func (s *BlobMetadataStore) SetBlobStatus(ctx context.Context, metadataKey dispe
 	return err
 }
 
+// SetConfirmationSubmission sets the confirmation submission of the blob. It isn't flattened like the confirmation info,
+// so that the blob isn't indexed in the batch before the batch is confirmed.
+func (s *BlobMetadataStore) SetConfirmationSubmission(ctx context.Context, metadataKey disperser.BlobKey, submission *disperser.ConfirmationSubmission) error {
+	av, err := attributevalue.Marshal(submission)
+	if err != nil {
+		return err
+	}
+
+	_, err = s.dynamoDBClient.UpdateItem(ctx, s.tableName, map[string]types.AttributeValue{
+		"BlobHash": &types.AttributeValueMemberS{
+			Value: metadataKey.BlobHash,
+		},
+		"MetadataHash": &types.AttributeValueMemberS{
+			Value: metadataKey.MetadataHash,
+		},
+	}, commondynamodb.Item{
+		"ConfirmationSubmission": av,
+	})
+
+	return err
+}
+
 func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
 	return &dynamodb.CreateTableInput{
 		AttributeDefinitions: []types.AttributeDefinition{
@@ -445,6 +552,10 @@ func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writ
 				AttributeName: aws.String("Expiry"),
 				AttributeType: types.ScalarAttributeTypeN,
 			},
+			{
+				AttributeName: aws.String("CommitmentHash"),
+				AttributeType: types.ScalarAttributeTypeB,
+			},
 		},
 		KeySchema: []types.KeySchemaElement{
 			{
@@ -498,6 +609,26 @@ func GenerateTableSchema(metadataTableName string, readCapacityUnits int64, writ
 					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
 				},
 			},
+			{
+				IndexName: aws.String(commitmentIndexName),
+				KeySchema: []types.KeySchemaElement{
+					{
+						AttributeName: aws.String("CommitmentHash"),
+						KeyType:       types.KeyTypeHash,
+					},
+					{
+						AttributeName: aws.String("RequestedAt"),
+						KeyType:       types.KeyTypeRange,
+					},
+				},
+				Projection: &types.Projection{
+					ProjectionType: types.ProjectionTypeAll,
+				},
+				ProvisionedThroughput: &types.ProvisionedThroughput{
+					ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+					WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+				},
+			},
 			{
 				IndexName: aws.String(expiryIndexName),
 				KeySchema: []types.KeySchemaElement{
@@ -560,6 +691,11 @@ func MarshalBlobMetadata(metadata *disperser.BlobMetadata) (commondynamodb.Item,
 		basicFields[k] = v
 	}
 
+	// Index the blob by the hash of its commitment
+	if commitmentHash, ok := metadata.ConfirmationInfo.CommitmentHash(); ok {
+		basicFields["CommitmentHash"] = &types.AttributeValueMemberB{Value: commitmentHash[:]}
+	}
+
 	return basicFields, nil
 }
 
@@ -101,6 +101,14 @@ func TestBlobMetadataStoreOperations(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, metadata, confirmedMetadata)
 
+	commitmentHash, ok := confirmedMetadata.ConfirmationInfo.CommitmentHash()
+	assert.True(t, ok)
+	byCommitment, err := blobMetadataStore.GetBlobMetadataByCommitmentHash(ctx, commitmentHash)
+	assert.NoError(t, err)
+	assert.Equal(t, []*disperser.BlobMetadata{confirmedMetadata}, byCommitment)
+	_, err = blobMetadataStore.GetBlobMetadataByCommitmentHash(ctx, [32]byte{})
+	assert.ErrorIs(t, err, disperser.ErrMetadataNotFound)
+
 	confirmedCount, err := blobMetadataStore.GetBlobMetadataCountByStatus(ctx, disperser.Confirmed)
 	assert.NoError(t, err)
 	assert.Equal(t, int32(1), confirmedCount)
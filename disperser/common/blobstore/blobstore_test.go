@@ -7,17 +7,14 @@ import (
 	"testing"
 	"time"
 
-	"github.com/Layr-Labs/eigenda/common/aws"
 	"github.com/Layr-Labs/eigenda/common/aws/dynamodb"
-	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
+	"github.com/Layr-Labs/eigenda/common/aws/localstack"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/google/uuid"
 
 	cmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
-	"github.com/Layr-Labs/eigenda/inabox/deploy"
-	"github.com/ory/dockertest/v3"
 )
 
 var (
@@ -39,11 +36,8 @@ var (
 	blobHash   = "9f86d081884c7d659a2feaa0c55ad015a3bf4f1b2b0b822cd15d6c15b0f00a08"
 	blobSize   = uint(len(blob.Data))
 
-	dockertestPool     *dockertest.Pool
-	dockertestResource *dockertest.Resource
-
-	deployLocalStack bool
-	localStackPort   = "4569"
+	harness        *localstack.Harness
+	localStackPort = "4569"
 
 	dynamoClient            *dynamodb.Client
 	blobMetadataStore       *blobstore.BlobMetadataStore
@@ -63,44 +57,27 @@ func TestMain(m *testing.M) {
 }
 
 func setup(m *testing.M) {
-
-	deployLocalStack = !(os.Getenv("DEPLOY_LOCALSTACK") == "false")
-	if !deployLocalStack {
-		localStackPort = os.Getenv("LOCALSTACK_PORT")
-	}
-
-	if deployLocalStack {
-		var err error
-		dockertestPool, dockertestResource, err = deploy.StartDockertestWithLocalstackContainer(localStackPort)
-		if err != nil {
-			teardown()
-			panic("failed to start localstack container")
-		}
-
-	}
-
-	cfg := aws.ClientConfig{
-		Region:          "us-east-1",
-		AccessKey:       "localstack",
-		SecretAccessKey: "localstack",
-		EndpointURL:     fmt.Sprintf("http://0.0.0.0:%s", localStackPort),
+	var err error
+	harness, err = localstack.Start(localStackPort)
+	if err != nil {
+		panic("failed to start localstack: " + err.Error())
 	}
 
-	_, err := test_utils.CreateTable(context.Background(), cfg, metadataTableName, blobstore.GenerateTableSchema(metadataTableName, 10, 10))
+	_, err = harness.CreateTable(context.Background(), metadataTableName, blobstore.GenerateTableSchema(metadataTableName, 10, 10))
 	if err != nil {
 		teardown()
 		panic("failed to create dynamodb table: " + err.Error())
 	}
 
 	if shadowMetadataTableName != "" {
-		_, err = test_utils.CreateTable(context.Background(), cfg, shadowMetadataTableName, blobstore.GenerateTableSchema(shadowMetadataTableName, 10, 10))
+		_, err = harness.CreateTable(context.Background(), shadowMetadataTableName, blobstore.GenerateTableSchema(shadowMetadataTableName, 10, 10))
 		if err != nil {
 			teardown()
 			panic("failed to create shadow dynamodb table: " + err.Error())
 		}
 	}
 
-	dynamoClient, err = dynamodb.NewClient(cfg, logger)
+	dynamoClient, err = dynamodb.NewClient(harness.Config, logger)
 	if err != nil {
 		teardown()
 		panic("failed to create dynamodb client: " + err.Error())
@@ -112,7 +89,7 @@ func setup(m *testing.M) {
 }
 
 func teardown() {
-	if deployLocalStack {
-		deploy.PurgeDockertestResources(dockertestPool, dockertestResource)
+	if err := harness.Stop(); err != nil {
+		panic(err)
 	}
 }
@@ -0,0 +1,123 @@
+package blobstore
+
+import (
+	"bytes"
+	"compress/gzip"
+	"fmt"
+	"io"
+
+	"github.com/klauspost/compress/zstd"
+)
+
+// CompressionAlgorithm is the algorithm with which blob objects are compressed in S3
+type CompressionAlgorithm uint8
+
+const (
+	NoCompression CompressionAlgorithm = iota
+	GzipCompression
+	ZstdCompression
+)
+
+func (a CompressionAlgorithm) String() string {
+	switch a {
+	case NoCompression:
+		return "none"
+	case GzipCompression:
+		return "gzip"
+	case ZstdCompression:
+		return "zstd"
+	default:
+		return fmt.Sprintf("unknown(%d)", uint8(a))
+	}
+}
+
+// ParseCompressionAlgorithm returns the compression algorithm with the given name
+func ParseCompressionAlgorithm(name string) (CompressionAlgorithm, error) {
+	switch name {
+	case "", "none":
+		return NoCompression, nil
+	case "gzip":
+		return GzipCompression, nil
+	case "zstd":
+		return ZstdCompression, nil
+	default:
+		return NoCompression, fmt.Errorf("unsupported compression algorithm %q", name)
+	}
+}
+
+// Compressed blob objects start with a header made of compressedBlobMagic and the algorithm. Blob data is a sequence
+// of 32 byte big endian field elements, whose first byte is at most 0x30, so the header can't be the start of an
+// uncompressed blob object. Uncompressed blobs are stored as is, which keeps the objects stored before compression
+// was enabled readable.
+var compressedBlobMagic = []byte{0xff, 'E', 'D', 'C'}
+
+const compressedBlobHeaderLength = 5
+
+// zstdEncoder is safe for concurrent use through EncodeAll
+var zstdEncoder, _ = zstd.NewWriter(nil)
+
+// compressBlob returns the object storing the blob data compressed with the given algorithm. The data is stored
+// uncompressed if compressing doesn't make it smaller.
+func compressBlob(data []byte, algorithm CompressionAlgorithm) ([]byte, error) {
+	if algorithm == NoCompression {
+		return data, nil
+	}
+
+	object := bytes.NewBuffer(make([]byte, 0, len(data)))
+	object.Write(compressedBlobMagic)
+	object.WriteByte(byte(algorithm))
+	switch algorithm {
+	case GzipCompression:
+		writer := gzip.NewWriter(object)
+		if _, err := writer.Write(data); err != nil {
+			return nil, err
+		}
+		if err := writer.Close(); err != nil {
+			return nil, err
+		}
+	case ZstdCompression:
+		object.Write(zstdEncoder.EncodeAll(data, nil))
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm %s", algorithm)
+	}
+
+	if object.Len() >= len(data) {
+		return data, nil
+	}
+	return object.Bytes(), nil
+}
+
+// decompressBlob returns the blob data stored in the object, decompressing it according to the algorithm in its header
+func decompressBlob(object []byte) ([]byte, error) {
+	if len(object) < compressedBlobHeaderLength || !bytes.Equal(object[:len(compressedBlobMagic)], compressedBlobMagic) {
+		return object, nil
+	}
+
+	algorithm := CompressionAlgorithm(object[len(compressedBlobMagic)])
+	payload := bytes.NewReader(object[compressedBlobHeaderLength:])
+	var reader io.Reader
+	switch algorithm {
+	case GzipCompression:
+		gzipReader, err := gzip.NewReader(payload)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read gzip blob: %w", err)
+		}
+		defer gzipReader.Close()
+		reader = gzipReader
+	case ZstdCompression:
+		zstdReader, err := zstd.NewReader(payload, zstd.WithDecoderConcurrency(1))
+		if err != nil {
+			return nil, fmt.Errorf("failed to read zstd blob: %w", err)
+		}
+		defer zstdReader.Close()
+		reader = zstdReader
+	default:
+		return nil, fmt.Errorf("unsupported compression algorithm %s", algorithm)
+	}
+
+	data, err := io.ReadAll(reader)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decompress %s blob: %w", algorithm, err)
+	}
+	return data, nil
+}
@@ -0,0 +1,55 @@
+package blobstore
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCompressBlob(t *testing.T) {
+	// Blob data made of field elements, which are padded with zeros
+	data := bytes.Repeat(append(make([]byte, 31), 7), 1000)
+
+	for _, algorithm := range []CompressionAlgorithm{GzipCompression, ZstdCompression} {
+		object, err := compressBlob(data, algorithm)
+		assert.NoError(t, err)
+		assert.Less(t, len(object), len(data))
+		assert.Equal(t, compressedBlobMagic, object[:len(compressedBlobMagic)])
+		assert.Equal(t, byte(algorithm), object[len(compressedBlobMagic)])
+
+		decompressed, err := decompressBlob(object)
+		assert.NoError(t, err)
+		assert.Equal(t, data, decompressed)
+	}
+
+	// Blobs that don't shrink are stored uncompressed
+	small := []byte("test")
+	object, err := compressBlob(small, ZstdCompression)
+	assert.NoError(t, err)
+	assert.Equal(t, small, object)
+
+	// Uncompressed objects are read as is
+	object, err = compressBlob(data, NoCompression)
+	assert.NoError(t, err)
+	assert.Equal(t, data, object)
+	decompressed, err := decompressBlob(object)
+	assert.NoError(t, err)
+	assert.Equal(t, data, decompressed)
+
+	_, err = decompressBlob(append(append([]byte{}, compressedBlobMagic...), 9, 1, 2, 3))
+	assert.Error(t, err)
+}
+
+func TestParseCompressionAlgorithm(t *testing.T) {
+	for _, algorithm := range []CompressionAlgorithm{NoCompression, GzipCompression, ZstdCompression} {
+		parsed, err := ParseCompressionAlgorithm(algorithm.String())
+		assert.NoError(t, err)
+		assert.Equal(t, algorithm, parsed)
+	}
+	parsed, err := ParseCompressionAlgorithm("")
+	assert.NoError(t, err)
+	assert.Equal(t, NoCompression, parsed)
+	_, err = ParseCompressionAlgorithm("lz4")
+	assert.Error(t, err)
+}
@@ -0,0 +1,169 @@
+package blobstore
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/common/aws/s3"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// MetadataArchive is the long-term storage of the metadata of the confirmed blobs in S3, which keeps the metadata
+// queryable once it is deleted from DynamoDB by the TTL of the table.
+//
+// The metadata is stored in one object per batch, compressed with zstd:
+//   - <prefix>/batches/<batch header hash>.json: the metadata of the blobs of the batch, sorted by blob index
+//   - <prefix>/blobs/<blob hash>/<metadata hash>: the hex batch header hash of the batch of the blob
+//
+// Lookups by blob key read the index object of the blob and then the object of its batch, so they take two S3
+// requests.
+type MetadataArchive struct {
+	s3Client   s3.Client
+	bucketName string
+	prefix     string
+	logger     logging.Logger
+}
+
+// archivedBatch is the content of the archive object of a batch
+type archivedBatch struct {
+	BatchHeaderHash string                    `json:"batch_header_hash"`
+	Blobs           []*disperser.BlobMetadata `json:"blobs"`
+}
+
+func NewMetadataArchive(s3Client s3.Client, bucketName string, prefix string, logger logging.Logger) *MetadataArchive {
+	return &MetadataArchive{
+		s3Client:   s3Client,
+		bucketName: bucketName,
+		prefix:     prefix,
+		logger:     logger.With("component", "MetadataArchive"),
+	}
+}
+
+func (a *MetadataArchive) batchObjectKey(batchHeaderHash [32]byte) string {
+	return fmt.Sprintf("%s/batches/%s.json", a.prefix, hex.EncodeToString(batchHeaderHash[:]))
+}
+
+func (a *MetadataArchive) blobObjectKey(blobKey disperser.BlobKey) string {
+	return fmt.Sprintf("%s/blobs/%s/%s", a.prefix, blobKey.BlobHash, blobKey.MetadataHash)
+}
+
+// ArchiveBatch stores the metadata of blobs of the batch in the archive. The metadata already archived for the batch
+// is kept, so the blobs of a batch can be archived in several calls, and archiving the same blob again replaces its
+// metadata. All the metadata must be of confirmed blobs of the batch.
+func (a *MetadataArchive) ArchiveBatch(ctx context.Context, batchHeaderHash [32]byte, metadatas []*disperser.BlobMetadata) error {
+	for _, metadata := range metadatas {
+		if metadata.ConfirmationInfo == nil || metadata.ConfirmationInfo.BatchHeaderHash != batchHeaderHash {
+			return fmt.Errorf("blob %s is not confirmed in batch %x", metadata.GetBlobKey(), batchHeaderHash)
+		}
+	}
+
+	archived, err := a.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil && !errors.Is(err, disperser.ErrMetadataNotFound) {
+		return err
+	}
+	byIndex := make(map[uint32]*disperser.BlobMetadata, len(archived)+len(metadatas))
+	for _, metadata := range archived {
+		byIndex[metadata.ConfirmationInfo.BlobIndex] = metadata
+	}
+	for _, metadata := range metadatas {
+		byIndex[metadata.ConfirmationInfo.BlobIndex] = metadata
+	}
+	batch := archivedBatch{
+		BatchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+		Blobs:           make([]*disperser.BlobMetadata, 0, len(byIndex)),
+	}
+	for _, metadata := range byIndex {
+		batch.Blobs = append(batch.Blobs, metadata)
+	}
+	sort.Slice(batch.Blobs, func(i, j int) bool {
+		return batch.Blobs[i].ConfirmationInfo.BlobIndex < batch.Blobs[j].ConfirmationInfo.BlobIndex
+	})
+
+	data, err := json.Marshal(batch)
+	if err != nil {
+		return fmt.Errorf("failed to marshal archived batch %x: %w", batchHeaderHash, err)
+	}
+	object, err := compressBlob(data, ZstdCompression)
+	if err != nil {
+		return err
+	}
+	if err := a.s3Client.UploadObject(ctx, a.bucketName, a.batchObjectKey(batchHeaderHash), object); err != nil {
+		return fmt.Errorf("failed to upload archived batch %x: %w", batchHeaderHash, err)
+	}
+
+	// The index objects are written after the batch object, so that they never point to a missing batch
+	for _, metadata := range metadatas {
+		if err := a.s3Client.UploadObject(ctx, a.bucketName, a.blobObjectKey(metadata.GetBlobKey()), []byte(batch.BatchHeaderHash)); err != nil {
+			return fmt.Errorf("failed to upload archive index of blob %s: %w", metadata.GetBlobKey(), err)
+		}
+	}
+	a.logger.Debug("archived batch metadata", "batchHeaderHash", batch.BatchHeaderHash, "numBlobs", len(metadatas), "numArchivedBlobs", len(batch.Blobs))
+	return nil
+}
+
+// GetAllBlobMetadataByBatch returns the archived metadata of the blobs of the batch, sorted by blob index
+func (a *MetadataArchive) GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	object, err := a.s3Client.DownloadObject(ctx, a.bucketName, a.batchObjectKey(batchHeaderHash))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: there is no archived metadata for batch %x", disperser.ErrMetadataNotFound, batchHeaderHash)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archived batch %x: %w", batchHeaderHash, err)
+	}
+	data, err := decompressBlob(object)
+	if err != nil {
+		return nil, err
+	}
+	var batch archivedBatch
+	if err := json.Unmarshal(data, &batch); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal archived batch %x: %w", batchHeaderHash, err)
+	}
+	return batch.Blobs, nil
+}
+
+// GetBlobMetadataInBatch returns the archived metadata of the blob at the index of the batch
+func (a *MetadataArchive) GetBlobMetadataInBatch(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32) (*disperser.BlobMetadata, error) {
+	metadatas, err := a.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, metadata := range metadatas {
+		if metadata.ConfirmationInfo.BlobIndex == blobIndex {
+			return metadata, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: there is no archived metadata for batch %x and blob index %d", disperser.ErrMetadataNotFound, batchHeaderHash, blobIndex)
+}
+
+// GetBlobMetadata returns the archived metadata of the blob
+func (a *MetadataArchive) GetBlobMetadata(ctx context.Context, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
+	index, err := a.s3Client.DownloadObject(ctx, a.bucketName, a.blobObjectKey(blobKey))
+	if errors.Is(err, s3.ErrObjectNotFound) {
+		return nil, fmt.Errorf("%w: there is no archived metadata for key %s", disperser.ErrMetadataNotFound, blobKey)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to download archive index of blob %s: %w", blobKey, err)
+	}
+	hash, err := hex.DecodeString(string(index))
+	if err != nil || len(hash) != 32 {
+		return nil, fmt.Errorf("invalid archive index of blob %s", blobKey)
+	}
+	var batchHeaderHash [32]byte
+	copy(batchHeaderHash[:], hash)
+
+	metadatas, err := a.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, err
+	}
+	for _, metadata := range metadatas {
+		if metadata.GetBlobKey() == blobKey {
+			return metadata, nil
+		}
+	}
+	return nil, fmt.Errorf("%w: archived batch %x has no metadata for key %s", disperser.ErrMetadataNotFound, batchHeaderHash, blobKey)
+}
@@ -0,0 +1,118 @@
+package blobstore_test
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	cmock "github.com/Layr-Labs/eigenda/common/mock"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newArchivedMetadata(t *testing.T, blobHash string, blobIndex uint32) *disperser.BlobMetadata {
+	return getConfirmedMetadata(t, &disperser.BlobMetadata{
+		BlobHash:     blobHash,
+		MetadataHash: "hash",
+		Expiry:       uint64(time.Now().Add(time.Hour).Unix()),
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			BlobSize:          blobSize,
+			RequestedAt:       123,
+		},
+	}, blobIndex)
+}
+
+func TestMetadataArchive(t *testing.T) {
+	ctx := context.Background()
+	archive := blobstore.NewMetadataArchive(cmock.NewS3Client(), bucketName, "archive", logger)
+	metadata1 := newArchivedMetadata(t, "blob1", 0)
+	metadata2 := newArchivedMetadata(t, "blob2", 1)
+	batchHeaderHash := metadata1.ConfirmationInfo.BatchHeaderHash
+
+	_, err := archive.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	assert.ErrorIs(t, err, disperser.ErrMetadataNotFound)
+	_, err = archive.GetBlobMetadata(ctx, metadata1.GetBlobKey())
+	assert.ErrorIs(t, err, disperser.ErrMetadataNotFound)
+
+	// The blobs of a batch can be archived in several calls
+	require.NoError(t, archive.ArchiveBatch(ctx, batchHeaderHash, []*disperser.BlobMetadata{metadata2}))
+	require.NoError(t, archive.ArchiveBatch(ctx, batchHeaderHash, []*disperser.BlobMetadata{metadata1, metadata2}))
+
+	metadatas, err := archive.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, []*disperser.BlobMetadata{metadata1, metadata2}, metadatas)
+
+	metadata, err := archive.GetBlobMetadata(ctx, metadata2.GetBlobKey())
+	require.NoError(t, err)
+	assert.Equal(t, metadata2, metadata)
+
+	metadata, err = archive.GetBlobMetadataInBatch(ctx, batchHeaderHash, 0)
+	require.NoError(t, err)
+	assert.Equal(t, metadata1, metadata)
+	_, err = archive.GetBlobMetadataInBatch(ctx, batchHeaderHash, 2)
+	assert.ErrorIs(t, err, disperser.ErrMetadataNotFound)
+
+	// The metadata of blobs which aren't confirmed in the batch isn't archived
+	err = archive.ArchiveBatch(ctx, [32]byte{9}, []*disperser.BlobMetadata{metadata1})
+	assert.ErrorContains(t, err, "is not confirmed in batch")
+}
+
+func TestArchivedBlobStore(t *testing.T) {
+	ctx := context.Background()
+	archive := blobstore.NewMetadataArchive(cmock.NewS3Client(), bucketName, "archive", logger)
+	archiver := blobstore.NewMetadataArchiver(blobMetadataStore, archive, 2*time.Hour, logger)
+	archivedStore := blobstore.NewArchivedBlobStore(sharedStorage, archive, logger)
+
+	metadata1 := newArchivedMetadata(t, "archived-blob1", 0)
+	metadata2 := newArchivedMetadata(t, "archived-blob2", 1)
+	metadata2.BlobStatus = disperser.Finalized
+	batchHeaderHash := metadata1.ConfirmationInfo.BatchHeaderHash
+	keys := make([]commondynamodb.Key, 0)
+	for _, metadata := range []*disperser.BlobMetadata{metadata1, metadata2} {
+		require.NoError(t, blobMetadataStore.QueueNewBlobMetadata(ctx, metadata))
+		keys = append(keys, commondynamodb.Key{
+			"MetadataHash": &types.AttributeValueMemberS{Value: metadata.MetadataHash},
+			"BlobHash":     &types.AttributeValueMemberS{Value: metadata.BlobHash},
+		})
+	}
+
+	// The metadata isn't archived until it is about to expire
+	numArchived, err := archiver.ArchiveExpiring(ctx, time.Now().Add(-2*time.Hour))
+	require.NoError(t, err)
+	assert.Equal(t, 0, numArchived)
+	numArchived, err = archiver.ArchiveExpiring(ctx, time.Now())
+	require.NoError(t, err)
+	assert.Equal(t, 2, numArchived)
+
+	// The metadata is served from the archive once deleted from the metadata store
+	deleteItems(t, keys)
+	_, err = sharedStorage.GetBlobMetadata(ctx, metadata1.GetBlobKey())
+	assert.ErrorIs(t, err, disperser.ErrMetadataNotFound)
+
+	metadata, err := archivedStore.GetBlobMetadata(ctx, metadata1.GetBlobKey())
+	require.NoError(t, err)
+	assert.Equal(t, metadata1, metadata)
+	metadata, err = archivedStore.GetMetadataInBatch(ctx, batchHeaderHash, 1)
+	require.NoError(t, err)
+	assert.Equal(t, metadata2, metadata)
+	metadatas, err := archivedStore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	require.NoError(t, err)
+	assert.Equal(t, []*disperser.BlobMetadata{metadata1, metadata2}, metadatas)
+
+	metadatas, exclusiveStartKey, err := archivedStore.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, 1, nil)
+	require.NoError(t, err)
+	assert.Equal(t, []*disperser.BlobMetadata{metadata1}, metadatas)
+	require.NotNil(t, exclusiveStartKey)
+	metadatas, exclusiveStartKey, err = archivedStore.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, 1, exclusiveStartKey)
+	require.NoError(t, err)
+	assert.Equal(t, []*disperser.BlobMetadata{metadata2}, metadatas)
+	assert.Nil(t, exclusiveStartKey)
+
+	_, err = archivedStore.GetBlobMetadata(ctx, disperser.BlobKey{BlobHash: "unknown", MetadataHash: "hash"})
+	assert.ErrorIs(t, err, disperser.ErrMetadataNotFound)
+}
@@ -0,0 +1,92 @@
+package blobstore
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const archiveQueryLimit = 1000
+
+// MetadataArchiver periodically moves the metadata of the confirmed blobs which are about to expire from the blob
+// metadata store to the metadata archive. The metadata of the blobs which were never confirmed isn't archived.
+type MetadataArchiver struct {
+	metadataStore *BlobMetadataStore
+	archive       *MetadataArchive
+	// archiveBeforeExpiry is how long before its expiry the metadata of a blob is archived. It must be longer than
+	// the archive interval, so that the metadata is archived before the TTL of the table deletes it.
+	archiveBeforeExpiry time.Duration
+	logger              logging.Logger
+}
+
+func NewMetadataArchiver(metadataStore *BlobMetadataStore, archive *MetadataArchive, archiveBeforeExpiry time.Duration, logger logging.Logger) *MetadataArchiver {
+	return &MetadataArchiver{
+		metadataStore:       metadataStore,
+		archive:             archive,
+		archiveBeforeExpiry: archiveBeforeExpiry,
+		logger:              logger.With("component", "MetadataArchiver"),
+	}
+}
+
+// Start archives the expiring metadata at each interval until the context is done
+func (a *MetadataArchiver) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				numArchived, err := a.ArchiveExpiring(ctx, time.Now())
+				if err != nil {
+					a.logger.Error("failed to archive blob metadata", "err", err)
+					continue
+				}
+				a.logger.Info("archived expiring blob metadata", "numBlobs", numArchived)
+			}
+		}
+	}()
+}
+
+// ArchiveExpiring archives the metadata of the confirmed and finalized blobs expiring within archiveBeforeExpiry of
+// now, grouped by batch, and returns the number of archived blobs. The metadata stays in the metadata store until
+// it expires, so it may be archived several times, which is harmless since the archive replaces it.
+func (a *MetadataArchiver) ArchiveExpiring(ctx context.Context, now time.Time) (int, error) {
+	expiringBefore := now.Add(a.archiveBeforeExpiry).Unix()
+	numArchived := 0
+	for _, status := range []disperser.BlobStatus{disperser.Confirmed, disperser.Finalized} {
+		var exclusiveStartKey *disperser.BlobStoreExclusiveStartKey
+		for {
+			metadatas, nextKey, err := a.metadataStore.GetExpiringBlobMetadataByStatusWithPagination(ctx, status, expiringBefore, archiveQueryLimit, exclusiveStartKey)
+			if err != nil {
+				return numArchived, fmt.Errorf("failed to get expiring %s blob metadata: %w", status, err)
+			}
+
+			batches := make(map[[32]byte][]*disperser.BlobMetadata)
+			for _, metadata := range metadatas {
+				if metadata.ConfirmationInfo == nil {
+					a.logger.Warn("skipping the archival of blob without confirmation info", "blobKey", metadata.GetBlobKey().String(), "status", status)
+					continue
+				}
+				batchHeaderHash := metadata.ConfirmationInfo.BatchHeaderHash
+				batches[batchHeaderHash] = append(batches[batchHeaderHash], metadata)
+			}
+			for batchHeaderHash, batchMetadatas := range batches {
+				if err := a.archive.ArchiveBatch(ctx, batchHeaderHash, batchMetadatas); err != nil {
+					return numArchived, err
+				}
+				numArchived += len(batchMetadatas)
+			}
+
+			if nextKey == nil {
+				break
+			}
+			exclusiveStartKey = nextKey
+		}
+	}
+	return numArchived, nil
+}
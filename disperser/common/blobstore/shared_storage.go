@@ -44,6 +44,7 @@ type SharedBlobStore struct {
 	bucketName        string
 	s3Client          s3.Client
 	blobMetadataStore *BlobMetadataStore
+	compression       CompressionAlgorithm
 	logger            logging.Logger
 }
 
@@ -51,6 +52,8 @@ type Config struct {
 	BucketName      string
 	TableName       string
 	ShadowTableName string
+	// Compression is the algorithm with which the blobs are compressed when they are stored
+	Compression CompressionAlgorithm
 }
 
 // This represents the s3 fetch result for a blob.
@@ -75,7 +78,13 @@ func NewSharedStorage(bucketName string, s3Client s3.Client, blobMetadataStore *
 	}
 }
 
-func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (disperser.BlobKey, error) {
+// SetCompression sets the algorithm with which the store compresses the blobs it uploads. Blobs are decompressed on
+// retrieval whatever the algorithm they were stored with.
+func (s *SharedBlobStore) SetCompression(algorithm CompressionAlgorithm) {
+	s.compression = algorithm
+}
+
+func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64, origin disperser.RequestOrigin) (disperser.BlobKey, error) {
 	metadataKey := disperser.BlobKey{}
 	if blob == nil {
 		return metadataKey, errors.New("blob is nil")
@@ -90,7 +99,12 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 	metadataKey.BlobHash = blobHash
 	metadataKey.MetadataHash = metadataHash
 
-	err = s.s3Client.UploadObject(ctx, s.bucketName, blobObjectKey(blobHash), blob.Data)
+	object, err := compressBlob(blob.Data, s.compression)
+	if err != nil {
+		s.logger.Error("error compressing blob", "err", err)
+		return metadataKey, err
+	}
+	err = s.s3Client.UploadObject(ctx, s.bucketName, blobObjectKey(blobHash), object)
 	if err != nil {
 		s.logger.Error("error uploading blob", "err", err)
 		return metadataKey, err
@@ -109,6 +123,7 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 		Expiry:       expiry,
 		RequestMetadata: &disperser.RequestMetadata{
 			BlobRequestHeader: blob.RequestHeader,
+			RequestOrigin:     origin,
 			BlobSize:          uint(len(blob.Data)),
 			RequestedAt:       requestedAt,
 		},
@@ -124,11 +139,20 @@ func (s *SharedBlobStore) StoreBlob(ctx context.Context, blob *core.Blob, reques
 
 // GetBlobContent retrieves blob content by the blob key.
 func (s *SharedBlobStore) GetBlobContent(ctx context.Context, blobHash disperser.BlobHash) ([]byte, error) {
-	return s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobHash))
+	object, err := s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobHash))
+	if err != nil {
+		return nil, err
+	}
+	return decompressBlob(object)
 }
 
 func (s *SharedBlobStore) getBlobContentParallel(ctx context.Context, blobKey disperser.BlobKey, blobRequestHeader core.BlobRequestHeader, resultChan chan<- blobResultOrError) {
-	blob, err := s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobKey.BlobHash))
+	object, err := s.s3Client.DownloadObject(ctx, s.bucketName, blobObjectKey(blobKey.BlobHash))
+	if err != nil {
+		resultChan <- blobResultOrError{err: err}
+		return
+	}
+	blob, err := decompressBlob(object)
 	if err != nil {
 		resultChan <- blobResultOrError{err: err}
 		return
@@ -175,6 +199,10 @@ func (s *SharedBlobStore) MarkBlobDispersing(ctx context.Context, metadataKey di
 	return s.blobMetadataStore.SetBlobStatus(ctx, metadataKey, disperser.Dispersing)
 }
 
+func (s *SharedBlobStore) RecordConfirmationSubmission(ctx context.Context, existingMetadata *disperser.BlobMetadata, submission *disperser.ConfirmationSubmission) error {
+	return s.blobMetadataStore.SetConfirmationSubmission(ctx, existingMetadata.GetBlobKey(), submission)
+}
+
 func (s *SharedBlobStore) MarkBlobInsufficientSignatures(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
 	if existingMetadata == nil {
 		return nil, errors.New("metadata is nil")
@@ -260,6 +288,10 @@ func (s *SharedBlobStore) GetAllBlobMetadataByBatchWithPagination(ctx context.Co
 	return s.blobMetadataStore.GetAllBlobMetadataByBatchWithPagination(ctx, batchHeaderHash, limit, exclusiveStartKey)
 }
 
+func (s *SharedBlobStore) GetBlobMetadataByCommitmentHash(ctx context.Context, commitmentHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	return s.blobMetadataStore.GetBlobMetadataByCommitmentHash(ctx, commitmentHash)
+}
+
 // GetMetadata returns a blob metadata given a metadata key
 func (s *SharedBlobStore) GetBlobMetadata(ctx context.Context, metadataKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
 	return s.blobMetadataStore.GetBlobMetadata(ctx, metadataKey)
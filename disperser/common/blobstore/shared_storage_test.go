@@ -21,7 +21,7 @@ import (
 func TestSharedBlobStore(t *testing.T) {
 	requestedAt := uint64(time.Now().UnixNano())
 	ctx := context.Background()
-	blobKey, err := sharedStorage.StoreBlob(ctx, blob, requestedAt)
+	blobKey, err := sharedStorage.StoreBlob(ctx, blob, requestedAt, disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	assert.Equal(t, blobHash, blobKey.BlobHash)
 
@@ -126,7 +126,7 @@ func TestSharedBlobStore(t *testing.T) {
 	// Store the second blob and then check the metadata.
 	blob.Data = []byte("foo")
 	blobSize2 := uint(len(blob.Data))
-	blobKey2, err := sharedStorage.StoreBlob(ctx, blob, requestedAt)
+	blobKey2, err := sharedStorage.StoreBlob(ctx, blob, requestedAt, disperser.RequestOrigin{})
 	assert.Nil(t, err)
 	assert.NotEqual(t, blobKey, blobKey2)
 	confirmationInfo = &disperser.ConfirmationInfo{
@@ -36,7 +36,7 @@ func NewBlobStore() disperser.BlobStore {
 	}
 }
 
-func (q *BlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (disperser.BlobKey, error) {
+func (q *BlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64, origin disperser.RequestOrigin) (disperser.BlobKey, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
 	blobKey := disperser.BlobKey{}
@@ -60,6 +60,7 @@ func (q *BlobStore) StoreBlob(ctx context.Context, blob *core.Blob, requestedAt
 		NumRetries:   0,
 		RequestMetadata: &disperser.RequestMetadata{
 			BlobRequestHeader: blob.RequestHeader,
+			RequestOrigin:     origin,
 			BlobSize:          uint(len(blob.Data)),
 			RequestedAt:       requestedAt,
 		},
@@ -112,6 +113,17 @@ func (q *BlobStore) MarkBlobDispersing(ctx context.Context, blobKey disperser.Bl
 	return nil
 }
 
+func (q *BlobStore) RecordConfirmationSubmission(ctx context.Context, existingMetadata *disperser.BlobMetadata, submission *disperser.ConfirmationSubmission) error {
+	q.mu.Lock()
+	defer q.mu.Unlock()
+	blobKey := existingMetadata.GetBlobKey()
+	if _, ok := q.Metadata[blobKey]; !ok {
+		return disperser.ErrBlobNotFound
+	}
+	q.Metadata[blobKey].ConfirmationSubmission = submission
+	return nil
+}
+
 func (q *BlobStore) MarkBlobInsufficientSignatures(ctx context.Context, existingMetadata *disperser.BlobMetadata, confirmationInfo *disperser.ConfirmationInfo) (*disperser.BlobMetadata, error) {
 	q.mu.Lock()
 	defer q.mu.Unlock()
@@ -323,6 +335,24 @@ func (q *BlobStore) GetAllBlobMetadataByBatchWithPagination(ctx context.Context,
 	return metas, nil, nil
 }
 
+func (q *BlobStore) GetBlobMetadataByCommitmentHash(ctx context.Context, commitmentHash [32]byte) ([]*disperser.BlobMetadata, error) {
+	q.mu.RLock()
+	defer q.mu.RUnlock()
+	metas := make([]*disperser.BlobMetadata, 0)
+	for _, meta := range q.Metadata {
+		if meta.ConfirmationInfo == nil {
+			continue
+		}
+		if hash, ok := meta.ConfirmationInfo.CommitmentHash(); ok && hash == commitmentHash {
+			metas = append(metas, meta)
+		}
+	}
+	sort.Slice(metas, func(i, j int) bool {
+		return metas[i].RequestMetadata.RequestedAt < metas[j].RequestMetadata.RequestedAt
+	})
+	return metas, nil
+}
+
 func (q *BlobStore) GetBlobMetadata(ctx context.Context, blobKey disperser.BlobKey) (*disperser.BlobMetadata, error) {
 	if meta, ok := q.Metadata[blobKey]; ok {
 		return meta, nil
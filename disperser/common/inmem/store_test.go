@@ -27,7 +27,7 @@ func TestBlobStore(t *testing.T) {
 				SecurityParams: []*core.SecurityParam{},
 			},
 			Data: []byte{byte(i)},
-		}, requestedAt)
+		}, requestedAt, disperser.RequestOrigin{})
 		assert.Nil(t, err)
 		keys[i] = blobKey
 	}
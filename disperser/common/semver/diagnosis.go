@@ -0,0 +1,96 @@
+package semver
+
+import (
+	"context"
+	"crypto/tls"
+	"net"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/grpc/credentials"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// The TLS statuses of an operator socket
+const (
+	TLSEnabled  = "enabled"
+	TLSDisabled = "disabled"
+	// TLSUnknown is reported for the sockets whose port isn't reachable
+	TLSUnknown = "unknown"
+)
+
+// SocketDiagnosis is the result of the diagnosis of a socket of an operator
+type SocketDiagnosis struct {
+	Socket string
+	// Port is the status of the probe of the socket
+	Port string
+	TLS  string
+	// Semver is the semver reported by the node info of the socket, or the failure of the request like in the scans
+	Semver string
+	// Latency is the time taken by the node info request, which is zero if the port isn't reachable
+	Latency time.Duration
+	// ClockSkew is how far the clock of the node is ahead of the local clock, estimated from the time it reports in
+	// its node info. It is nil if the node didn't report its time.
+	ClockSkew *time.Duration
+	// Capabilities are the quorums and the features reported by the node info, which is nil if the node didn't
+	// respond or predates them
+	Capabilities *NodeCapabilities
+	// Error is the error of the node info request, which is empty if it succeeded
+	Error string
+}
+
+// DiagnoseSocket probes the socket, detects whether it serves TLS and requests the node info of its dispersal
+// service, or of its retrieval service if retrieval is set. Each step is bounded by timeout.
+func DiagnoseSocket(ctx context.Context, socket string, retrieval bool, timeout time.Duration, logger logging.Logger) *SocketDiagnosis {
+	diagnosis := &SocketDiagnosis{
+		Socket: socket,
+		Port:   ProbeSocket(ctx, socket, timeout),
+		TLS:    TLSUnknown,
+	}
+	if diagnosis.Port != PortReachable {
+		diagnosis.Semver = diagnosis.Port
+		return diagnosis
+	}
+
+	diagnosis.TLS = detectTLS(ctx, socket, timeout)
+	creds := insecure.NewCredentials()
+	if diagnosis.TLS == TLSEnabled {
+		// The certificate isn't verified, as only the response of the node matters to the diagnosis
+		creds = credentials.NewTLS(&tls.Config{InsecureSkipVerify: true})
+	}
+	start := time.Now()
+	reply, err := requestNodeInfo(ctx, socket, retrieval, creds, timeout)
+	diagnosis.Latency = time.Since(start)
+	if err != nil {
+		diagnosis.Semver = semverOfError(ctx, err)
+		diagnosis.Error = err.Error()
+		logger.Warn("NodeInfo diagnosis", "socket", socket, "retrieval", retrieval, "semver", diagnosis.Semver, "error", err)
+		return diagnosis
+	}
+	diagnosis.Semver = reply.Semver
+	diagnosis.Capabilities = nodeCapabilities(reply)
+	if reply.GetUnixTimeMs() > 0 {
+		// The node replied halfway through the request on average
+		skew := time.UnixMilli(int64(reply.GetUnixTimeMs())).Sub(start.Add(diagnosis.Latency / 2))
+		diagnosis.ClockSkew = &skew
+	}
+	return diagnosis
+}
+
+// detectTLS returns whether the socket completes a TLS handshake
+func detectTLS(ctx context.Context, socket string, timeout time.Duration) string {
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", socket)
+	if err != nil {
+		return TLSUnknown
+	}
+	defer conn.Close()
+
+	ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	tlsConn := tls.Client(conn, &tls.Config{InsecureSkipVerify: true, NextProtos: []string{"h2"}})
+	if err := tlsConn.HandshakeContext(ctxWithTimeout); err != nil {
+		return TLSDisabled
+	}
+	return TLSEnabled
+}
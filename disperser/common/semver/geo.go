@@ -0,0 +1,223 @@
+package semver
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// UnknownLocation is the key of the operators whose country or ASN can't be resolved in the distributions
+const UnknownLocation = "unknown"
+
+var ErrLocationNotFound = errors.New("location not found")
+
+// GeoLocation is the network location of an IP address
+type GeoLocation struct {
+	ASN   uint32
+	ASOrg string
+	// Country is the ISO 3166 alpha-2 code of the country
+	Country string
+}
+
+// GeoIPProvider resolves the network location of IP addresses
+type GeoIPProvider interface {
+	// Lookup returns the location of the IP address, or ErrLocationNotFound if it isn't known
+	Lookup(ip net.IP) (*GeoLocation, error)
+}
+
+type ipRange struct {
+	start    net.IP
+	end      net.IP
+	location *GeoLocation
+}
+
+// ip2asnProvider resolves the locations from an IP to ASN database in the TSV format of iptoasn.com
+type ip2asnProvider struct {
+	ranges []ipRange
+}
+
+var _ GeoIPProvider = (*ip2asnProvider)(nil)
+
+// NewIP2ASNProvider loads the IP to ASN database at path, with a range per line:
+// range_start, range_end, AS_number, country_code, AS_description separated by tabs.
+// The ranges of AS number 0 are not routed and are skipped.
+func NewIP2ASNProvider(path string) (GeoIPProvider, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	p := &ip2asnProvider{}
+	scanner := bufio.NewScanner(f)
+	line := 0
+	for scanner.Scan() {
+		line++
+		if strings.TrimSpace(scanner.Text()) == "" {
+			continue
+		}
+		fields := strings.Split(scanner.Text(), "\t")
+		if len(fields) < 4 {
+			return nil, fmt.Errorf("invalid IP to ASN database %s at line %d: expected at least 4 fields", path, line)
+		}
+		start, end := net.ParseIP(fields[0]).To16(), net.ParseIP(fields[1]).To16()
+		if start == nil || end == nil || bytes.Compare(start, end) > 0 {
+			return nil, fmt.Errorf("invalid IP to ASN database %s at line %d: invalid range %s-%s", path, line, fields[0], fields[1])
+		}
+		asn, err := strconv.ParseUint(fields[2], 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid IP to ASN database %s at line %d: invalid AS number %s", path, line, fields[2])
+		}
+		if asn == 0 {
+			continue
+		}
+		location := &GeoLocation{
+			ASN:     uint32(asn),
+			Country: fields[3],
+		}
+		if len(fields) > 4 {
+			location.ASOrg = fields[4]
+		}
+		p.ranges = append(p.ranges, ipRange{start: start, end: end, location: location})
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+
+	sort.Slice(p.ranges, func(i, j int) bool {
+		return bytes.Compare(p.ranges[i].start, p.ranges[j].start) < 0
+	})
+	return p, nil
+}
+
+func (p *ip2asnProvider) Lookup(ip net.IP) (*GeoLocation, error) {
+	ip = ip.To16()
+	if ip == nil {
+		return nil, ErrLocationNotFound
+	}
+	// The last range starting at or before the IP address is the only one which may contain it
+	i := sort.Search(len(p.ranges), func(i int) bool {
+		return bytes.Compare(p.ranges[i].start, ip) > 0
+	}) - 1
+	if i < 0 || bytes.Compare(ip, p.ranges[i].end) > 0 {
+		return nil, ErrLocationNotFound
+	}
+	return p.ranges[i].location, nil
+}
+
+// LocateOperators resolves the IP address of the dispersal socket of the operators and its location with the
+// provider. The operators whose IP address or location can't be resolved are left without location.
+func LocateOperators(ctx context.Context, results []*OperatorSemver, provider GeoIPProvider, logger logging.Logger) {
+	for _, result := range results {
+		if ctx.Err() != nil {
+			return
+		}
+		ip, err := resolveSocketIP(ctx, result.Socket)
+		if err != nil {
+			logger.Warn("failed to resolve operator IP address", "operatorId", result.OperatorId.Hex(), "socket", result.Socket, "err", err)
+			continue
+		}
+		result.IP = ip.String()
+		location, err := provider.Lookup(ip)
+		if err != nil {
+			logger.Warn("failed to locate operator", "operatorId", result.OperatorId.Hex(), "ip", result.IP, "err", err)
+			continue
+		}
+		result.Location = location
+	}
+}
+
+// resolveSocketIP returns the IP address of the host of the socket, preferring IPv4 addresses
+func resolveSocketIP(ctx context.Context, socket string) (net.IP, error) {
+	host, _, err := net.SplitHostPort(socket)
+	if err != nil {
+		return nil, err
+	}
+	if ip := net.ParseIP(host); ip != nil {
+		return ip, nil
+	}
+	addrs, err := net.DefaultResolver.LookupIPAddr(ctx, host)
+	if err != nil {
+		return nil, err
+	}
+	if len(addrs) == 0 {
+		return nil, fmt.Errorf("no IP address for host %s", host)
+	}
+	for _, addr := range addrs {
+		if addr.IP.To4() != nil {
+			return addr.IP, nil
+		}
+	}
+	return addrs[0].IP, nil
+}
+
+// CountLocations returns the number of operators by country and by ASN in the results of a scan
+func CountLocations(results []*OperatorSemver) (countries map[string]int, asns map[string]int) {
+	countries = make(map[string]int)
+	asns = make(map[string]int)
+	for _, result := range results {
+		if result.Location == nil {
+			countries[UnknownLocation]++
+			asns[UnknownLocation]++
+			continue
+		}
+		countries[result.Location.Country]++
+		asns[result.Location.ASName()]++
+	}
+	return countries, asns
+}
+
+// ASName returns the AS number and organization of the location, e.g. "AS16509 AMAZON-02"
+func (l *GeoLocation) ASName() string {
+	if l.ASOrg == "" {
+		return fmt.Sprintf("AS%d", l.ASN)
+	}
+	return fmt.Sprintf("AS%d %s", l.ASN, l.ASOrg)
+}
+
+// LatencyDistribution is the distribution of the node info latencies of the operators which responded to the scan
+type LatencyDistribution struct {
+	Count int
+	Min   time.Duration
+	P50   time.Duration
+	P90   time.Duration
+	P99   time.Duration
+	Max   time.Duration
+}
+
+// GetLatencyDistribution returns the distribution of the node info latencies of the operators which responded,
+// including with an error, to the node info request
+func GetLatencyDistribution(results []*OperatorSemver) *LatencyDistribution {
+	latencies := make([]time.Duration, 0, len(results))
+	for _, result := range results {
+		if result.Responded() {
+			latencies = append(latencies, result.Latency)
+		}
+	}
+	distribution := &LatencyDistribution{Count: len(latencies)}
+	if len(latencies) == 0 {
+		return distribution
+	}
+	sort.Slice(latencies, func(i, j int) bool { return latencies[i] < latencies[j] })
+	// nearest-rank percentiles
+	percentile := func(p int) time.Duration {
+		rank := (p*len(latencies) + 99) / 100
+		return latencies[max(rank, 1)-1]
+	}
+	distribution.Min = latencies[0]
+	distribution.P50 = percentile(50)
+	distribution.P90 = percentile(90)
+	distribution.P99 = percentile(99)
+	distribution.Max = latencies[len(latencies)-1]
+	return distribution
+}
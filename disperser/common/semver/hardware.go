@@ -0,0 +1,127 @@
+package semver
+
+import (
+	"fmt"
+	"math/big"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// UnknownHardware is the bucket of the operators which didn't report their hardware in the distributions
+const UnknownHardware = "unknown"
+
+// HardwareInfo is the hardware reported by the node info of an operator
+type HardwareInfo struct {
+	OS       string
+	Arch     string
+	NumCPU   uint32
+	MemBytes uint64
+}
+
+var (
+	// cpuBuckets are the upper bounds of the buckets of the number of CPUs
+	cpuBuckets = []uint32{2, 4, 8, 16, 32, 64}
+	// memoryBuckets are the upper bounds, in GiB, of the buckets of the memory
+	memoryBuckets = []uint64{4, 8, 16, 32, 64, 128, 256}
+)
+
+// HardwareDistribution is the distribution of the operators among the buckets of a hardware characteristic
+type HardwareDistribution struct {
+	// NumOperators is the number of operators in each bucket
+	NumOperators map[string]int
+	// StakeShares is the percentage of the stake of each quorum held by the operators in each bucket
+	StakeShares map[core.QuorumID]map[string]float64
+}
+
+// HardwareInventory is the distribution of the hardware of the operators
+type HardwareInventory struct {
+	// CPU is bucketed by the number of CPUs, e.g. "5-8"
+	CPU *HardwareDistribution
+	// Memory is bucketed by GiB of memory, e.g. "16-32GiB"
+	Memory *HardwareDistribution
+	// Platform is bucketed by OS and architecture, e.g. "linux/amd64"
+	Platform *HardwareDistribution
+}
+
+// GetHardwareInventory returns the distributions of the hardware reported by the operators in the results of a scan,
+// weighted by the stake of each quorum of the operator state. The operators which didn't report their hardware are
+// counted in the UnknownHardware bucket.
+func GetHardwareInventory(results []*OperatorSemver, state *core.OperatorState) *HardwareInventory {
+	return &HardwareInventory{
+		CPU:      getHardwareDistribution(results, state, cpuBucket),
+		Memory:   getHardwareDistribution(results, state, memoryBucket),
+		Platform: getHardwareDistribution(results, state, platformBucket),
+	}
+}
+
+func getHardwareDistribution(results []*OperatorSemver, state *core.OperatorState, bucket func(*HardwareInfo) string) *HardwareDistribution {
+	distribution := &HardwareDistribution{
+		NumOperators: make(map[string]int),
+		StakeShares:  make(map[core.QuorumID]map[string]float64),
+	}
+	buckets := make(map[core.OperatorID]string, len(results))
+	for _, result := range results {
+		b := UnknownHardware
+		if result.Hardware != nil {
+			b = bucket(result.Hardware)
+		}
+		buckets[result.OperatorId] = b
+		distribution.NumOperators[b]++
+	}
+
+	for quorum, operators := range state.Operators {
+		distribution.StakeShares[quorum] = make(map[string]float64)
+		total, ok := state.Totals[quorum]
+		if !ok || total.Stake == nil || total.Stake.Sign() == 0 {
+			continue
+		}
+		for operatorId, operator := range operators {
+			b, ok := buckets[operatorId]
+			if !ok || operator.Stake == nil {
+				continue
+			}
+			share, _ := new(big.Rat).SetFrac(operator.Stake, total.Stake).Float64()
+			distribution.StakeShares[quorum][b] += 100 * share
+		}
+	}
+	return distribution
+}
+
+func cpuBucket(hardware *HardwareInfo) string {
+	if hardware.NumCPU == 0 {
+		return UnknownHardware
+	}
+	lower := uint32(1)
+	for _, upper := range cpuBuckets {
+		if hardware.NumCPU <= upper {
+			if lower == upper {
+				return fmt.Sprint(upper)
+			}
+			return fmt.Sprintf("%d-%d", lower, upper)
+		}
+		lower = upper + 1
+	}
+	return fmt.Sprintf(">%d", cpuBuckets[len(cpuBuckets)-1])
+}
+
+func memoryBucket(hardware *HardwareInfo) string {
+	if hardware.MemBytes == 0 {
+		return UnknownHardware
+	}
+	const gib = 1 << 30
+	lower := uint64(0)
+	for _, upper := range memoryBuckets {
+		if hardware.MemBytes <= upper*gib {
+			return fmt.Sprintf("%d-%dGiB", lower, upper)
+		}
+		lower = upper
+	}
+	return fmt.Sprintf(">%dGiB", memoryBuckets[len(memoryBuckets)-1])
+}
+
+func platformBucket(hardware *HardwareInfo) string {
+	if hardware.OS == "" || hardware.Arch == "" {
+		return UnknownHardware
+	}
+	return hardware.OS + "/" + hardware.Arch
+}
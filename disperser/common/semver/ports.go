@@ -0,0 +1,48 @@
+package semver
+
+import (
+	"context"
+	"errors"
+	"net"
+	"syscall"
+	"time"
+)
+
+// The statuses of the probe of an operator socket
+const (
+	PortReachable = "reachable"
+	PortRefused   = "refused"
+	PortTimeout   = "timeout"
+	// PortUnreachable is reported for the sockets that can't be resolved or routed to
+	PortUnreachable = "unreachable"
+	PortCanceled    = "canceled"
+	PortError       = "error"
+)
+
+// ProbeSocket dials the socket over TCP within timeout and returns the status of the port
+func ProbeSocket(ctx context.Context, socket string, timeout time.Duration) string {
+	if socket == "" {
+		return PortUnreachable
+	}
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", socket)
+	if err == nil {
+		conn.Close()
+		return PortReachable
+	}
+
+	var netErr net.Error
+	var dnsErr *net.DNSError
+	switch {
+	case ctx.Err() != nil:
+		return PortCanceled
+	case errors.Is(err, syscall.ECONNREFUSED):
+		return PortRefused
+	case errors.As(err, &netErr) && netErr.Timeout():
+		return PortTimeout
+	case errors.As(err, &dnsErr), errors.Is(err, syscall.EHOSTUNREACH), errors.Is(err, syscall.ENETUNREACH):
+		return PortUnreachable
+	default:
+		return PortError
+	}
+}
@@ -1,7 +1,12 @@
 package semver
 
 import (
+	"bytes"
 	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
 	"strings"
 	"sync"
 	"time"
@@ -10,29 +15,112 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/credentials/insecure"
 )
 
-func ScanOperators(operators map[core.OperatorID]*core.IndexedOperatorInfo, numWorkers int, nodeInfoTimeout time.Duration, logger logging.Logger) map[string]int {
+// errUnreachable is the error of the node info requests to the sockets which can't be dialed
+var errUnreachable = errors.New("socket is unreachable")
+
+// OperatorSemver is the result of the scan of an operator
+type OperatorSemver struct {
+	OperatorId core.OperatorID
+	// Socket is the dispersal socket queried for the node info of the operator
+	Socket          string
+	RetrievalSocket string
+	Semver          string
+	// Latency is the time taken by the node info request, which is zero if the operator isn't queried
+	Latency time.Duration
+	// Error is the error of the node info request, which is empty if it succeeded
+	Error string
+	// DispersalPort and RetrievalPort are the statuses of the probes of the sockets, which are only set if the
+	// ports are checked
+	DispersalPort string
+	RetrievalPort string
+	// IP is the address of the host of the dispersal socket, and Location its network location, which are only set
+	// if the operators are located
+	IP       string
+	Location *GeoLocation
+	// Hardware is the hardware reported by the node info of the operator, which is nil if it didn't respond
+	Hardware *HardwareInfo
+	// Config is the configuration reported by the node info of the operator, which is nil if it didn't respond or
+	// doesn't report its configuration
+	Config *NodeConfig
+	// Capabilities are the quorums and the features reported by the node info of the operator, which is nil if it
+	// didn't respond or predates them
+	Capabilities *NodeCapabilities
+	// MissingQuorums are the quorums the operator is registered in which its node isn't configured to serve. They are
+	// only set by CheckRegisteredQuorums, for the operators whose capabilities are known.
+	MissingQuorums []core.QuorumID
+}
+
+// NodeCapabilities are the quorums a node is configured to serve and the optional features it enables
+type NodeCapabilities struct {
+	QuorumIDs []core.QuorumID
+	Features  []string
+}
+
+// NodeConfig is the configuration reported by the node info of an operator
+type NodeConfig struct {
+	ChunkStorageBackend string
+	DbSizeBytes         uint64
+}
+
+// Responded returns whether the operator responded to the node info request, even if with an error
+func (s *OperatorSemver) Responded() bool {
+	switch s.Semver {
+	case "unreachable", "timeout", "refused", "error", "canceled":
+		return false
+	}
+	return true
+}
+
+// ScanOperators queries the semver of the operators with numWorkers concurrent requests, each bounded by
+// nodeInfoTimeout, and returns the number of operators by semver. If the context is done before all the operators
+// are scanned, the operators left are not queried and are counted as "canceled".
+func ScanOperators(ctx context.Context, operators map[core.OperatorID]*core.IndexedOperatorInfo, numWorkers int, nodeInfoTimeout time.Duration, logger logging.Logger) map[string]int {
+	return CountSemvers(ScanOperatorSemvers(ctx, operators, numWorkers, nodeInfoTimeout, false, logger))
+}
+
+// ScanOperatorSemvers scans the operators like ScanOperators, but returns the result of each operator, sorted by
+// operator ID. If checkPorts is set, both the dispersal and retrieval sockets of the operators are probed as well.
+func ScanOperatorSemvers(ctx context.Context, operators map[core.OperatorID]*core.IndexedOperatorInfo, numWorkers int, nodeInfoTimeout time.Duration, checkPorts bool, logger logging.Logger) []*OperatorSemver {
 	var wg sync.WaitGroup
 	var mu sync.Mutex
-	semvers := make(map[string]int)
+	results := make([]*OperatorSemver, 0, len(operators))
 	operatorChan := make(chan core.OperatorID, len(operators))
 	worker := func() {
 		for operatorId := range operatorChan {
 			operatorSocket := core.OperatorSocket(operators[operatorId].Socket)
-			dispersalSocket := operatorSocket.GetDispersalSocket()
-			semver := GetSemverInfo(context.Background(), dispersalSocket, operatorId, logger, nodeInfoTimeout)
+			result := &OperatorSemver{
+				OperatorId:      operatorId,
+				Socket:          operatorSocket.GetDispersalSocket(),
+				RetrievalSocket: operatorSocket.GetRetrievalSocket(),
+				Semver:          "canceled",
+			}
+			if ctx.Err() == nil {
+				start := time.Now()
+				var err error
+				result.Semver, result.Hardware, result.Config, result.Capabilities, err = getNodeInfo(ctx, result.Socket, operatorId, logger, nodeInfoTimeout)
+				result.Latency = time.Since(start)
+				if err != nil {
+					result.Error = err.Error()
+				}
+			}
+			if checkPorts {
+				result.DispersalPort = ProbeSocket(ctx, result.Socket, nodeInfoTimeout)
+				result.RetrievalPort = ProbeSocket(ctx, result.RetrievalSocket, nodeInfoTimeout)
+			}
 
 			mu.Lock()
-			semvers[semver]++
+			results = append(results, result)
 			mu.Unlock()
 		}
 		wg.Done()
 	}
 
 	// Launch worker goroutines
-	for i := 0; i < numWorkers; i++ {
+	for i := 0; i < max(numWorkers, 1); i++ {
 		wg.Add(1)
 		go worker()
 	}
@@ -45,43 +133,174 @@ func ScanOperators(operators map[core.OperatorID]*core.IndexedOperatorInfo, numW
 
 	// Wait for all workers to finish
 	wg.Wait()
+	sort.Slice(results, func(i, j int) bool {
+		return bytes.Compare(results[i].OperatorId[:], results[j].OperatorId[:]) < 0
+	})
+	if ctx.Err() != nil {
+		canceled := 0
+		for _, result := range results {
+			if result.Semver == "canceled" {
+				canceled++
+			}
+		}
+		logger.Warn("operator scan canceled", "canceled", canceled, "total", len(operators), "err", ctx.Err())
+	}
+	return results
+}
+
+// CountSemvers returns the number of operators by semver in the results of a scan
+func CountSemvers(results []*OperatorSemver) map[string]int {
+	semvers := make(map[string]int)
+	for _, result := range results {
+		semvers[result.Semver]++
+	}
 	return semvers
 }
 
 // query operator host info endpoint if available
 func GetSemverInfo(ctx context.Context, socket string, operatorId core.OperatorID, logger logging.Logger, timeout time.Duration) string {
-	conn, err := grpc.Dial(socket, grpc.WithTransportCredentials(insecure.NewCredentials()))
+	semver, _, _, _, _ := getNodeInfo(ctx, socket, operatorId, logger, timeout)
+	return semver
+}
+
+// getNodeInfo returns the semver of the operator, and the hardware, configuration and capabilities it reports if it
+// responds to the node info request. The configuration is nil if the node doesn't report it, and the capabilities if
+// it predates them. The error of the request is returned with the semver describing it otherwise.
+func getNodeInfo(ctx context.Context, socket string, operatorId core.OperatorID, logger logging.Logger, timeout time.Duration) (string, *HardwareInfo, *NodeConfig, *NodeCapabilities, error) {
+	reply, err := requestNodeInfo(ctx, socket, false, insecure.NewCredentials(), timeout)
 	if err != nil {
-		return "unreachable"
+		semver := semverOfError(ctx, err)
+		logger.Warn("NodeInfo", "operatorId", operatorId, "semver", semver, "error", err)
+		return semver, nil, nil, nil, err
+	}
+
+	var config *NodeConfig
+	if reply.GetConfig() != nil {
+		config = &NodeConfig{
+			ChunkStorageBackend: reply.GetConfig().GetChunkStorageBackend(),
+			DbSizeBytes:         reply.GetConfig().GetDbSizeBytes(),
+		}
+	}
+
+	logger.Info("NodeInfo", "operatorId", operatorId, "socker", socket, "semver", reply.Semver, "os", reply.Os, "arch", reply.Arch, "numCpu", reply.NumCpu, "memBytes", reply.MemBytes)
+	return reply.Semver, &HardwareInfo{
+		OS:       reply.Os,
+		Arch:     reply.Arch,
+		NumCPU:   reply.NumCpu,
+		MemBytes: reply.MemBytes,
+	}, config, nodeCapabilities(reply), nil
+}
+
+// nodeCapabilities returns the quorums and the features reported by the node info, or nil if the node predates them
+func nodeCapabilities(reply *node.NodeInfoReply) *NodeCapabilities {
+	quorumIDs, features := reply.GetQuorumIds(), reply.GetFeatures()
+	if len(quorumIDs) == 0 && len(features) == 0 {
+		return nil
+	}
+	capabilities := &NodeCapabilities{
+		QuorumIDs: make([]core.QuorumID, len(quorumIDs)),
+		Features:  features,
+	}
+	for i, quorumID := range quorumIDs {
+		capabilities.QuorumIDs[i] = core.QuorumID(quorumID)
+	}
+	return capabilities
+}
+
+// requestNodeInfo requests the node info of the dispersal service of the socket, or of its retrieval service if
+// retrieval is set
+func requestNodeInfo(ctx context.Context, socket string, retrieval bool, creds credentials.TransportCredentials, timeout time.Duration) (*node.NodeInfoReply, error) {
+	conn, err := grpc.Dial(socket, grpc.WithTransportCredentials(creds))
+	if err != nil {
+		return nil, fmt.Errorf("%w: %v", errUnreachable, err)
 	}
 	defer conn.Close()
 	ctxWithTimeout, cancel := context.WithTimeout(ctx, timeout)
 	defer cancel()
-	client := node.NewDispersalClient(conn)
-	reply, err := client.NodeInfo(ctxWithTimeout, &node.NodeInfoRequest{})
+	var reply *node.NodeInfoReply
+	if retrieval {
+		reply, err = node.NewRetrievalClient(conn).NodeInfo(ctxWithTimeout, &node.NodeInfoRequest{})
+	} else {
+		reply, err = node.NewDispersalClient(conn).NodeInfo(ctxWithTimeout, &node.NodeInfoRequest{})
+	}
 	if err != nil {
-		var semver string
-		if strings.Contains(err.Error(), "unknown method NodeInfo") {
-			semver = "<0.8.0"
-		} else if strings.Contains(err.Error(), "unknown service") {
-			semver = "filtered"
-		} else if strings.Contains(err.Error(), "DeadlineExceeded") {
-			semver = "timeout"
-		} else if strings.Contains(err.Error(), "Unavailable") {
-			semver = "refused"
-		} else {
-			semver = "error"
-		}
-
-		logger.Warn("NodeInfo", "operatorId", operatorId, "semver", semver, "error", err)
-		return semver
+		return nil, err
 	}
 
 	// local node source compiles without semver
 	if reply.Semver == "" {
 		reply.Semver = "src-compile"
 	}
+	return reply, nil
+}
 
-	logger.Info("NodeInfo", "operatorId", operatorId, "socker", socket, "semver", reply.Semver, "os", reply.Os, "arch", reply.Arch, "numCpu", reply.NumCpu, "memBytes", reply.MemBytes)
-	return reply.Semver
+// semverOfError returns the semver describing the failure of a node info request
+func semverOfError(ctx context.Context, err error) string {
+	switch {
+	case errors.Is(err, errUnreachable):
+		return "unreachable"
+	case strings.Contains(err.Error(), "unknown method NodeInfo"):
+		return "<0.8.0"
+	case strings.Contains(err.Error(), "unknown service"):
+		return "filtered"
+	case strings.Contains(err.Error(), "DeadlineExceeded"):
+		return "timeout"
+	case ctx.Err() != nil:
+		return "canceled"
+	case strings.Contains(err.Error(), "Unavailable"):
+		return "refused"
+	default:
+		return "error"
+	}
+}
+
+// CheckRegisteredQuorums sets the quorums of the operator state each operator of the results is registered in but
+// which its node isn't configured to serve, and returns the number of operators missing any quorum
+func CheckRegisteredQuorums(results []*OperatorSemver, state *core.OperatorState) int {
+	mismatches := 0
+	for _, result := range results {
+		result.MissingQuorums = nil
+		if result.Capabilities == nil {
+			continue
+		}
+		served := make(map[core.QuorumID]struct{}, len(result.Capabilities.QuorumIDs))
+		for _, quorumID := range result.Capabilities.QuorumIDs {
+			served[quorumID] = struct{}{}
+		}
+		for quorumID, operators := range state.Operators {
+			if _, ok := operators[result.OperatorId]; !ok {
+				continue
+			}
+			if _, ok := served[quorumID]; !ok {
+				result.MissingQuorums = append(result.MissingQuorums, quorumID)
+			}
+		}
+		if len(result.MissingQuorums) > 0 {
+			sort.Slice(result.MissingQuorums, func(i, j int) bool { return result.MissingQuorums[i] < result.MissingQuorums[j] })
+			mismatches++
+		}
+	}
+	return mismatches
+}
+
+// StakeShares returns the percentage of the stake of each quorum of the operator state held by the operators of each
+// semver in the results of a scan
+func StakeShares(results []*OperatorSemver, state *core.OperatorState) map[core.QuorumID]map[string]float64 {
+	shares := make(map[core.QuorumID]map[string]float64)
+	for quorum, operators := range state.Operators {
+		shares[quorum] = make(map[string]float64)
+		total, ok := state.Totals[quorum]
+		if !ok || total.Stake == nil || total.Stake.Sign() == 0 {
+			continue
+		}
+		for _, result := range results {
+			operator, ok := operators[result.OperatorId]
+			if !ok || operator.Stake == nil {
+				continue
+			}
+			share, _ := new(big.Rat).SetFrac(operator.Stake, total.Stake).Float64()
+			shares[quorum][result.Semver] += 100 * share
+		}
+	}
+	return shares
 }
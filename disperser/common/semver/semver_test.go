@@ -0,0 +1,321 @@
+package semver_test
+
+import (
+	"context"
+	"math/big"
+	"net"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/semver"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"google.golang.org/grpc"
+)
+
+func TestScanOperatorsCanceled(t *testing.T) {
+	operators := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	for i := 0; i < 100; i++ {
+		operators[core.OperatorID{byte(i)}] = &core.IndexedOperatorInfo{Socket: "127.0.0.1:1;127.0.0.1:1"}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	semvers := semver.ScanOperators(ctx, operators, 10, time.Second, logging.NewNoopLogger())
+	assert.Equal(t, map[string]int{"canceled": 100}, semvers)
+}
+
+func TestScanOperatorSemvers(t *testing.T) {
+	operators := map[core.OperatorID]*core.IndexedOperatorInfo{
+		{2}: {Socket: "127.0.0.1:1;2"},
+		{1}: {Socket: "127.0.0.1:3;4"},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	results := semver.ScanOperatorSemvers(ctx, operators, 1, time.Second, false, logging.NewNoopLogger())
+	assert.Len(t, results, 2)
+	assert.Equal(t, core.OperatorID{1}, results[0].OperatorId)
+	assert.Equal(t, "127.0.0.1:3", results[0].Socket)
+	assert.Equal(t, core.OperatorID{2}, results[1].OperatorId)
+	assert.Equal(t, "127.0.0.1:1", results[1].Socket)
+	for _, result := range results {
+		assert.Equal(t, "canceled", result.Semver)
+		assert.Zero(t, result.Latency)
+		assert.Empty(t, result.Error)
+	}
+	assert.Equal(t, map[string]int{"canceled": 2}, semver.CountSemvers(results))
+}
+
+func TestScanOperatorSemversError(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	socket := listener.Addr().String()
+	assert.NoError(t, listener.Close())
+	operators := map[core.OperatorID]*core.IndexedOperatorInfo{
+		{1}: {Socket: socket + ";1"},
+	}
+
+	results := semver.ScanOperatorSemvers(context.Background(), operators, 1, time.Second, false, logging.NewNoopLogger())
+	assert.Len(t, results, 1)
+	assert.NotEqual(t, "canceled", results[0].Semver)
+	assert.NotEmpty(t, results[0].Error)
+	assert.Positive(t, results[0].Latency)
+}
+
+func TestProbeSocket(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	socket := listener.Addr().String()
+	assert.Equal(t, semver.PortReachable, semver.ProbeSocket(context.Background(), socket, time.Second))
+
+	assert.NoError(t, listener.Close())
+	assert.Equal(t, semver.PortRefused, semver.ProbeSocket(context.Background(), socket, time.Second))
+	assert.Equal(t, semver.PortUnreachable, semver.ProbeSocket(context.Background(), "", time.Second))
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	assert.Equal(t, semver.PortCanceled, semver.ProbeSocket(ctx, socket, time.Second))
+}
+
+func TestStakeShares(t *testing.T) {
+	results := []*semver.OperatorSemver{
+		{OperatorId: core.OperatorID{1}, Semver: "0.8.0"},
+		{OperatorId: core.OperatorID{2}, Semver: "0.8.0"},
+		{OperatorId: core.OperatorID{3}, Semver: "0.7.0"},
+	}
+	state := &core.OperatorState{
+		Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+			0: {
+				{1}: {Stake: big.NewInt(10)},
+				{2}: {Stake: big.NewInt(30)},
+				{3}: {Stake: big.NewInt(60)},
+			},
+			1: {
+				{3}: {Stake: big.NewInt(5)},
+			},
+		},
+		Totals: map[core.QuorumID]*core.OperatorInfo{
+			0: {Stake: big.NewInt(100)},
+			1: {Stake: big.NewInt(5)},
+		},
+	}
+
+	shares := semver.StakeShares(results, state)
+	assert.InDelta(t, 40, shares[0]["0.8.0"], 1e-9)
+	assert.InDelta(t, 60, shares[0]["0.7.0"], 1e-9)
+	assert.Equal(t, map[string]float64{"0.7.0": 100}, shares[1])
+}
+
+func TestGetHardwareInventory(t *testing.T) {
+	results := []*semver.OperatorSemver{
+		{OperatorId: core.OperatorID{1}, Hardware: &semver.HardwareInfo{OS: "linux", Arch: "amd64", NumCPU: 4, MemBytes: 16 << 30}},
+		{OperatorId: core.OperatorID{2}, Hardware: &semver.HardwareInfo{OS: "linux", Arch: "arm64", NumCPU: 16, MemBytes: 32<<30 + 1}},
+		{OperatorId: core.OperatorID{3}, Hardware: &semver.HardwareInfo{OS: "linux", Arch: "amd64", NumCPU: 128, MemBytes: 512 << 30}},
+		{OperatorId: core.OperatorID{4}, Semver: "timeout"},
+	}
+	state := &core.OperatorState{
+		Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+			0: {
+				{1}: {Stake: big.NewInt(10)},
+				{2}: {Stake: big.NewInt(20)},
+				{3}: {Stake: big.NewInt(30)},
+				{4}: {Stake: big.NewInt(40)},
+			},
+			1: {
+				{2}: {Stake: big.NewInt(5)},
+			},
+		},
+		Totals: map[core.QuorumID]*core.OperatorInfo{
+			0: {Stake: big.NewInt(100)},
+			1: {Stake: big.NewInt(5)},
+		},
+	}
+
+	inventory := semver.GetHardwareInventory(results, state)
+	assert.Equal(t, map[string]int{"3-4": 1, "9-16": 1, ">64": 1, semver.UnknownHardware: 1}, inventory.CPU.NumOperators)
+	assert.Equal(t, map[string]int{"8-16GiB": 1, "32-64GiB": 1, ">256GiB": 1, semver.UnknownHardware: 1}, inventory.Memory.NumOperators)
+	assert.Equal(t, map[string]int{"linux/amd64": 2, "linux/arm64": 1, semver.UnknownHardware: 1}, inventory.Platform.NumOperators)
+
+	assert.InDelta(t, 40, inventory.Platform.StakeShares[0]["linux/amd64"], 1e-9)
+	assert.InDelta(t, 20, inventory.Platform.StakeShares[0]["linux/arm64"], 1e-9)
+	assert.InDelta(t, 40, inventory.Platform.StakeShares[0][semver.UnknownHardware], 1e-9)
+	assert.Equal(t, map[string]float64{"9-16": 100}, inventory.CPU.StakeShares[1])
+}
+
+func TestLocateOperators(t *testing.T) {
+	db := "1.0.0.0\t1.0.0.255\t13335\tUS\tCLOUDFLARENET\n" +
+		"3.0.0.0\t3.255.255.255\t16509\tUS\tAMAZON-02\n" +
+		"5.0.0.0\t5.0.255.255\t0\tNone\tNot routed\n" +
+		"2a01:4f8::\t2a01:4f8:ffff:ffff:ffff:ffff:ffff:ffff\t24940\tDE\tHETZNER-AS\n"
+	path := filepath.Join(t.TempDir(), "ip2asn.tsv")
+	assert.NoError(t, os.WriteFile(path, []byte(db), 0644))
+	provider, err := semver.NewIP2ASNProvider(path)
+	assert.NoError(t, err)
+
+	location, err := provider.Lookup(net.ParseIP("3.4.5.6"))
+	assert.NoError(t, err)
+	assert.Equal(t, &semver.GeoLocation{ASN: 16509, ASOrg: "AMAZON-02", Country: "US"}, location)
+	location, err = provider.Lookup(net.ParseIP("2a01:4f8::1"))
+	assert.NoError(t, err)
+	assert.Equal(t, "AS24940 HETZNER-AS", location.ASName())
+	_, err = provider.Lookup(net.ParseIP("2.0.0.1"))
+	assert.ErrorIs(t, err, semver.ErrLocationNotFound)
+	_, err = provider.Lookup(net.ParseIP("5.0.0.1"))
+	assert.ErrorIs(t, err, semver.ErrLocationNotFound)
+
+	results := []*semver.OperatorSemver{
+		{OperatorId: core.OperatorID{1}, Socket: "1.0.0.1:32005"},
+		{OperatorId: core.OperatorID{2}, Socket: "3.0.0.1:32005"},
+		{OperatorId: core.OperatorID{3}, Socket: "3.0.0.2:32005"},
+		{OperatorId: core.OperatorID{4}, Socket: "2.0.0.1:32005"},
+		{OperatorId: core.OperatorID{5}, Socket: "invalid"},
+	}
+	semver.LocateOperators(context.Background(), results, provider, logging.NewNoopLogger())
+	assert.Equal(t, "1.0.0.1", results[0].IP)
+	assert.Equal(t, "2.0.0.1", results[3].IP)
+	assert.Nil(t, results[3].Location)
+	assert.Equal(t, "", results[4].IP)
+
+	countries, asns := semver.CountLocations(results)
+	assert.Equal(t, map[string]int{"US": 3, semver.UnknownLocation: 2}, countries)
+	assert.Equal(t, map[string]int{"AS13335 CLOUDFLARENET": 1, "AS16509 AMAZON-02": 2, semver.UnknownLocation: 2}, asns)
+
+	path = filepath.Join(t.TempDir(), "invalid.tsv")
+	assert.NoError(t, os.WriteFile(path, []byte("1.0.0.255\t1.0.0.0\t13335\tUS\n"), 0644))
+	_, err = semver.NewIP2ASNProvider(path)
+	assert.Error(t, err)
+}
+
+func TestGetLatencyDistribution(t *testing.T) {
+	results := []*semver.OperatorSemver{
+		{Semver: "timeout", Latency: 3 * time.Second},
+		{Semver: "canceled"},
+	}
+	for i := 1; i <= 100; i++ {
+		results = append(results, &semver.OperatorSemver{Semver: "0.8.0", Latency: time.Duration(i) * time.Millisecond})
+	}
+
+	distribution := semver.GetLatencyDistribution(results)
+	assert.Equal(t, &semver.LatencyDistribution{
+		Count: 100,
+		Min:   time.Millisecond,
+		P50:   50 * time.Millisecond,
+		P90:   90 * time.Millisecond,
+		P99:   99 * time.Millisecond,
+		Max:   100 * time.Millisecond,
+	}, distribution)
+	assert.Equal(t, &semver.LatencyDistribution{}, semver.GetLatencyDistribution(results[:2]))
+}
+
+type nodeInfoServer struct {
+	pb.UnimplementedDispersalServer
+	clockSkew time.Duration
+	config    *pb.NodeConfig
+	quorumIds []uint32
+	features  []string
+}
+
+func (s *nodeInfoServer) NodeInfo(context.Context, *pb.NodeInfoRequest) (*pb.NodeInfoReply, error) {
+	return &pb.NodeInfoReply{
+		Semver:     "0.8.4",
+		UnixTimeMs: uint64(time.Now().Add(s.clockSkew).UnixMilli()),
+		Config:     s.config,
+		QuorumIds:  s.quorumIds,
+		Features:   s.features,
+	}, nil
+}
+
+// serveNodeInfo serves the node info on a local socket until the end of the test
+func serveNodeInfo(t *testing.T, info *nodeInfoServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	server := grpc.NewServer()
+	pb.RegisterDispersalServer(server, info)
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	return listener.Addr().String()
+}
+
+func TestScanOperatorSemversConfig(t *testing.T) {
+	operators := map[core.OperatorID]*core.IndexedOperatorInfo{
+		{1}: {Socket: serveNodeInfo(t, &nodeInfoServer{config: &pb.NodeConfig{
+			ChunkStorageBackend: "leveldb",
+			DbSizeBytes:         1 << 30,
+		}}) + ";1"},
+		{2}: {Socket: serveNodeInfo(t, &nodeInfoServer{}) + ";1"},
+	}
+
+	results := semver.ScanOperatorSemvers(context.Background(), operators, 2, time.Second, false, logging.NewNoopLogger())
+	assert.Len(t, results, 2)
+	assert.Equal(t, &semver.NodeConfig{
+		ChunkStorageBackend: "leveldb",
+		DbSizeBytes:         1 << 30,
+	}, results[0].Config)
+	// The node predates the capabilities
+	assert.Nil(t, results[0].Capabilities)
+	// The node doesn't report its configuration
+	assert.Equal(t, "0.8.4", results[1].Semver)
+	assert.Nil(t, results[1].Config)
+	assert.Nil(t, results[1].Capabilities)
+}
+
+func TestCheckRegisteredQuorums(t *testing.T) {
+	operators := map[core.OperatorID]*core.IndexedOperatorInfo{
+		{1}: {Socket: serveNodeInfo(t, &nodeInfoServer{quorumIds: []uint32{0, 1}, features: []string{"dispersal_auth"}}) + ";1"},
+		{2}: {Socket: serveNodeInfo(t, &nodeInfoServer{quorumIds: []uint32{1}}) + ";1"},
+		{3}: {Socket: serveNodeInfo(t, &nodeInfoServer{}) + ";1"},
+	}
+	results := semver.ScanOperatorSemvers(context.Background(), operators, 3, time.Second, false, logging.NewNoopLogger())
+	assert.Len(t, results, 3)
+	assert.Equal(t, &semver.NodeCapabilities{QuorumIDs: []core.QuorumID{0, 1}, Features: []string{"dispersal_auth"}}, results[0].Capabilities)
+
+	// All the operators are registered in quorums 0 and 1
+	state := &core.OperatorState{Operators: map[core.QuorumID]map[core.OperatorID]*core.OperatorInfo{
+		0: {{1}: {}, {2}: {}, {3}: {}},
+		1: {{1}: {}, {2}: {}, {3}: {}},
+	}}
+	assert.Equal(t, 1, semver.CheckRegisteredQuorums(results, state))
+	assert.Empty(t, results[0].MissingQuorums)
+	assert.Equal(t, []core.QuorumID{0}, results[1].MissingQuorums)
+	// The capabilities of the node are unknown
+	assert.Empty(t, results[2].MissingQuorums)
+}
+
+func TestDiagnoseSocket(t *testing.T) {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	socket := listener.Addr().String()
+	server := grpc.NewServer()
+	pb.RegisterDispersalServer(server, &nodeInfoServer{clockSkew: time.Minute, quorumIds: []uint32{0}})
+	go func() { _ = server.Serve(listener) }()
+	defer server.Stop()
+
+	diagnosis := semver.DiagnoseSocket(context.Background(), socket, false, time.Second, logging.NewNoopLogger())
+	assert.Equal(t, semver.PortReachable, diagnosis.Port)
+	assert.Equal(t, semver.TLSDisabled, diagnosis.TLS)
+	assert.Equal(t, "0.8.4", diagnosis.Semver)
+	assert.Empty(t, diagnosis.Error)
+	assert.Positive(t, diagnosis.Latency)
+	assert.NotNil(t, diagnosis.ClockSkew)
+	assert.InDelta(t, time.Minute, *diagnosis.ClockSkew, float64(time.Second))
+	assert.Equal(t, &semver.NodeCapabilities{QuorumIDs: []core.QuorumID{0}}, diagnosis.Capabilities)
+
+	// The retrieval service isn't registered
+	diagnosis = semver.DiagnoseSocket(context.Background(), socket, true, time.Second, logging.NewNoopLogger())
+	assert.Equal(t, semver.PortReachable, diagnosis.Port)
+	assert.Equal(t, "filtered", diagnosis.Semver)
+	assert.NotEmpty(t, diagnosis.Error)
+	assert.Nil(t, diagnosis.ClockSkew)
+
+	server.Stop()
+	diagnosis = semver.DiagnoseSocket(context.Background(), socket, false, time.Second, logging.NewNoopLogger())
+	assert.Equal(t, semver.PortRefused, diagnosis.Port)
+	assert.Equal(t, semver.TLSUnknown, diagnosis.TLS)
+	assert.Equal(t, semver.PortRefused, diagnosis.Semver)
+	assert.Zero(t, diagnosis.Latency)
+}
@@ -0,0 +1,351 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// maxRecentDispersals is the number of latest confirmed blobs fetched at each poll of the anomaly detector
+const maxRecentDispersals = 1000
+
+// AccountAnomalyKind is the kind of anomalous dispersal behaviour of an account
+type AccountAnomalyKind string
+
+const (
+	// RateSpike is reported when the number of blobs dispersed by an account in a window
+	// exceeds its average over the previous windows by the configured factor
+	RateSpike AccountAnomalyKind = "rate_spike"
+	// SustainedMaxSizeBlobs is reported when most blobs of an account are close to the maximum
+	// blob size for several consecutive windows
+	SustainedMaxSizeBlobs AccountAnomalyKind = "sustained_max_size_blobs"
+)
+
+// AccountAnomaly describes an anomalous dispersal behaviour of an account
+type AccountAnomaly struct {
+	Kind    AccountAnomalyKind `json:"kind"`
+	Account string             `json:"account"`
+	// WindowStart and WindowEnd delimit the window in which the anomaly was detected
+	WindowStart time.Time `json:"window_start"`
+	WindowEnd   time.Time `json:"window_end"`
+	// NumBlobs and TotalBlobSize are the dispersals of the account in the window
+	NumBlobs      int  `json:"num_blobs"`
+	TotalBlobSize uint `json:"total_blob_size"`
+	// BaselineNumBlobs is the average number of blobs per window of the account over the baseline windows
+	BaselineNumBlobs float64 `json:"baseline_num_blobs"`
+	Description      string  `json:"description"`
+}
+
+// AlertSink delivers the anomalies to the disperser operator
+type AlertSink interface {
+	Send(ctx context.Context, anomaly *AccountAnomaly) error
+}
+
+// Dispersal is a blob dispersed by an account
+type Dispersal struct {
+	BlobKey     string
+	Account     string
+	BlobSize    uint
+	RequestedAt time.Time
+}
+
+// DispersalSource provides the latest dispersals. The same dispersal may be returned by several calls.
+type DispersalSource interface {
+	RecentDispersals(ctx context.Context) ([]*Dispersal, error)
+}
+
+type AccountAnomalyConfig struct {
+	// Window is the duration over which the dispersals of an account are aggregated
+	Window time.Duration
+	// Delay is how long after the end of a window it is evaluated, so that late dispersals are accounted for
+	Delay time.Duration
+	// BaselineWindows is the number of windows preceding the evaluated window that make up the baseline rate
+	BaselineWindows int
+	// SpikeFactor is the ratio to the baseline rate above which a rate spike is reported
+	SpikeFactor float64
+	// MinSpikeBlobs is the minimum number of blobs in a window for a rate spike to be reported
+	MinSpikeBlobs int
+	// MaxSizeThreshold is the size in bytes above which a blob is considered to be max-size
+	MaxSizeThreshold uint
+	// MaxSizeFraction is the fraction of max-size blobs in a window above which the window counts towards sustained max-size blobs
+	MaxSizeFraction float64
+	// SustainedWindows is the number of consecutive windows of max-size blobs for the anomaly to be reported
+	SustainedWindows int
+}
+
+func (c *AccountAnomalyConfig) validate() error {
+	if c.Window <= 0 {
+		return errors.New("window must be positive")
+	}
+	if c.BaselineWindows <= 0 || c.SustainedWindows <= 0 {
+		return errors.New("number of baseline and sustained windows must be positive")
+	}
+	if c.SpikeFactor <= 1 {
+		return errors.New("spike factor must be greater than 1")
+	}
+	if c.MaxSizeThreshold == 0 {
+		return errors.New("max-size threshold must be positive")
+	}
+	if c.MaxSizeFraction <= 0 || c.MaxSizeFraction > 1 {
+		return errors.New("max-size fraction must be in (0, 1]")
+	}
+	return nil
+}
+
+type accountWindow struct {
+	numBlobs        int
+	numMaxSizeBlobs int
+	totalBlobSize   uint
+}
+
+// AccountAnomalyDetector aggregates the dispersals of each account into fixed windows and reports
+// the anomalies of each completed window to the alert sinks.
+type AccountAnomalyDetector struct {
+	config AccountAnomalyConfig
+	source DispersalSource
+	sinks  []AlertSink
+	logger logging.Logger
+
+	// windows maps the accounts to the aggregated dispersals of each window, indexed by window number
+	windows map[string]map[int64]*accountWindow
+	// seen is the set of dispersals already aggregated, mapped to their window number
+	seen map[string]int64
+	// lastEvaluated is the number of the last evaluated window
+	lastEvaluated int64
+}
+
+func NewAccountAnomalyDetector(config AccountAnomalyConfig, source DispersalSource, sinks []AlertSink, logger logging.Logger) (*AccountAnomalyDetector, error) {
+	if err := config.validate(); err != nil {
+		return nil, fmt.Errorf("invalid account anomaly config: %w", err)
+	}
+	return &AccountAnomalyDetector{
+		config:        config,
+		source:        source,
+		sinks:         sinks,
+		logger:        logger.With("component", "AccountAnomalyDetector"),
+		windows:       make(map[string]map[int64]*accountWindow),
+		seen:          make(map[string]int64),
+		lastEvaluated: -1,
+	}, nil
+}
+
+// Start polls the dispersal source at the given interval until the context is done
+func (d *AccountAnomalyDetector) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if _, err := d.Poll(ctx, time.Now()); err != nil {
+					d.logger.Error("failed to detect account anomalies", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// Poll fetches the recent dispersals, evaluates the windows completed by now and sends their anomalies to the sinks
+func (d *AccountAnomalyDetector) Poll(ctx context.Context, now time.Time) ([]*AccountAnomaly, error) {
+	dispersals, err := d.source.RecentDispersals(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, dispersal := range dispersals {
+		d.Observe(dispersal)
+	}
+
+	anomalies := d.Evaluate(now)
+	for _, anomaly := range anomalies {
+		d.logger.Warn("account anomaly detected", "kind", anomaly.Kind, "account", anomaly.Account, "description", anomaly.Description)
+		for _, sink := range d.sinks {
+			if err := sink.Send(ctx, anomaly); err != nil {
+				d.logger.Error("failed to send account anomaly alert", "kind", anomaly.Kind, "account", anomaly.Account, "err", err)
+			}
+		}
+	}
+	return anomalies, nil
+}
+
+// Observe aggregates a dispersal into the window of its request time. Dispersals observed before are ignored.
+func (d *AccountAnomalyDetector) Observe(dispersal *Dispersal) {
+	if _, ok := d.seen[dispersal.BlobKey]; ok {
+		return
+	}
+	window := d.windowOf(dispersal.RequestedAt)
+	d.seen[dispersal.BlobKey] = window
+
+	accountWindows, ok := d.windows[dispersal.Account]
+	if !ok {
+		accountWindows = make(map[int64]*accountWindow)
+		d.windows[dispersal.Account] = accountWindows
+	}
+	w, ok := accountWindows[window]
+	if !ok {
+		w = &accountWindow{}
+		accountWindows[window] = w
+	}
+	w.numBlobs++
+	w.totalBlobSize += dispersal.BlobSize
+	if dispersal.BlobSize >= d.config.MaxSizeThreshold {
+		w.numMaxSizeBlobs++
+	}
+}
+
+// Evaluate returns the anomalies of the windows that completed since the last evaluation,
+// ordered by window and account.
+func (d *AccountAnomalyDetector) Evaluate(now time.Time) []*AccountAnomaly {
+	lastComplete := d.windowOf(now.Add(-d.config.Delay)) - 1
+	if d.lastEvaluated < 0 {
+		// There is no history before the first evaluation, only evaluate the latest window
+		d.lastEvaluated = lastComplete - 1
+	}
+
+	anomalies := make([]*AccountAnomaly, 0)
+	for window := d.lastEvaluated + 1; window <= lastComplete; window++ {
+		accounts := make([]string, 0, len(d.windows))
+		for account := range d.windows {
+			accounts = append(accounts, account)
+		}
+		sort.Strings(accounts)
+		for _, account := range accounts {
+			anomalies = append(anomalies, d.evaluateAccount(account, window)...)
+		}
+	}
+	if lastComplete > d.lastEvaluated {
+		d.lastEvaluated = lastComplete
+	}
+	d.prune()
+	return anomalies
+}
+
+func (d *AccountAnomalyDetector) evaluateAccount(account string, window int64) []*AccountAnomaly {
+	accountWindows := d.windows[account]
+	current, ok := accountWindows[window]
+	if !ok {
+		return nil
+	}
+	start := time.Unix(window*d.windowSeconds(), 0).UTC()
+	newAnomaly := func(kind AccountAnomalyKind, baseline float64, description string) *AccountAnomaly {
+		return &AccountAnomaly{
+			Kind:             kind,
+			Account:          account,
+			WindowStart:      start,
+			WindowEnd:        start.Add(d.config.Window),
+			NumBlobs:         current.numBlobs,
+			TotalBlobSize:    current.totalBlobSize,
+			BaselineNumBlobs: baseline,
+			Description:      description,
+		}
+	}
+
+	anomalies := make([]*AccountAnomaly, 0)
+
+	total := 0
+	for w := window - int64(d.config.BaselineWindows); w < window; w++ {
+		if previous, ok := accountWindows[w]; ok {
+			total += previous.numBlobs
+		}
+	}
+	baseline := float64(total) / float64(d.config.BaselineWindows)
+	// Accounts without any history are not reported, they have no rate to compare against
+	if baseline > 0 && current.numBlobs >= d.config.MinSpikeBlobs && float64(current.numBlobs) >= d.config.SpikeFactor*baseline {
+		anomalies = append(anomalies, newAnomaly(RateSpike, baseline, fmt.Sprintf(
+			"%d blobs dispersed in %s, %.1fx the baseline of %.1f blobs", current.numBlobs, d.config.Window, float64(current.numBlobs)/baseline, baseline)))
+	}
+
+	// Report the streak of max-size windows once, when it reaches the configured length
+	streak := 0
+	for w := window; d.isMaxSizeWindow(accountWindows[w]); w-- {
+		streak++
+		if streak > d.config.SustainedWindows {
+			break
+		}
+	}
+	if streak == d.config.SustainedWindows {
+		anomalies = append(anomalies, newAnomaly(SustainedMaxSizeBlobs, baseline, fmt.Sprintf(
+			"%d of %d blobs are at least %d bytes for %d consecutive windows of %s", current.numMaxSizeBlobs, current.numBlobs, d.config.MaxSizeThreshold, streak, d.config.Window)))
+	}
+
+	return anomalies
+}
+
+func (d *AccountAnomalyDetector) isMaxSizeWindow(w *accountWindow) bool {
+	return w != nil && w.numBlobs > 0 && float64(w.numMaxSizeBlobs) >= d.config.MaxSizeFraction*float64(w.numBlobs)
+}
+
+// prune removes the windows that are too old to be part of the baseline or of a streak of the next evaluation
+func (d *AccountAnomalyDetector) prune() {
+	history := d.config.BaselineWindows
+	if d.config.SustainedWindows > history {
+		history = d.config.SustainedWindows
+	}
+	oldest := d.lastEvaluated - int64(history)
+	for account, accountWindows := range d.windows {
+		for window := range accountWindows {
+			if window < oldest {
+				delete(accountWindows, window)
+			}
+		}
+		if len(accountWindows) == 0 {
+			delete(d.windows, account)
+		}
+	}
+	for blobKey, window := range d.seen {
+		if window < oldest {
+			delete(d.seen, blobKey)
+		}
+	}
+}
+
+func (d *AccountAnomalyDetector) windowSeconds() int64 {
+	seconds := int64(d.config.Window / time.Second)
+	if seconds == 0 {
+		return 1
+	}
+	return seconds
+}
+
+func (d *AccountAnomalyDetector) windowOf(t time.Time) int64 {
+	return t.Unix() / d.windowSeconds()
+}
+
+// RecentDispersals returns the dispersals of the latest confirmed blobs. Blobs dispersed through the
+// authenticated endpoint are attributed to the signing account, the others to the account of the request header.
+func (s *server) RecentDispersals(ctx context.Context) ([]*Dispersal, error) {
+	_, metadatas, err := s.getBlobMetadataByBatchesWithLimit(ctx, maxRecentDispersals)
+	if err != nil {
+		return nil, err
+	}
+	return getDispersals(metadatas), nil
+}
+
+func getDispersals(metadatas []*disperser.BlobMetadata) []*Dispersal {
+	dispersals := make([]*Dispersal, 0, len(metadatas))
+	for _, metadata := range metadatas {
+		if metadata.RequestMetadata == nil {
+			continue
+		}
+		account := metadata.RequestMetadata.AuthenticatedAccount
+		if account == "" {
+			account = metadata.RequestMetadata.AccountID
+		}
+		if account == "" {
+			account = unknownDispersalOrigin
+		}
+		dispersals = append(dispersals, &Dispersal{
+			BlobKey:     metadata.GetBlobKey().String(),
+			Account:     account,
+			BlobSize:    metadata.RequestMetadata.BlobSize,
+			RequestedAt: time.Unix(0, int64(metadata.RequestMetadata.RequestedAt)),
+		})
+	}
+	return dispersals
+}
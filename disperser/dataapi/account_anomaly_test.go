@@ -0,0 +1,224 @@
+package dataapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+	"github.com/stretchr/testify/assert"
+)
+
+type fakeDispersalSource struct {
+	dispersals []*dataapi.Dispersal
+}
+
+func (s *fakeDispersalSource) RecentDispersals(ctx context.Context) ([]*dataapi.Dispersal, error) {
+	return s.dispersals, nil
+}
+
+func (s *fakeDispersalSource) disperse(account string, blobSize uint, requestedAt time.Time, numBlobs int) {
+	for i := 0; i < numBlobs; i++ {
+		s.dispersals = append(s.dispersals, &dataapi.Dispersal{
+			BlobKey:     fmt.Sprintf("blob-%d", len(s.dispersals)),
+			Account:     account,
+			BlobSize:    blobSize,
+			RequestedAt: requestedAt,
+		})
+	}
+}
+
+type recordingAlertSink struct {
+	anomalies []*dataapi.AccountAnomaly
+}
+
+func (s *recordingAlertSink) Send(ctx context.Context, anomaly *dataapi.AccountAnomaly) error {
+	s.anomalies = append(s.anomalies, anomaly)
+	return nil
+}
+
+type fakeSNSPublisher struct {
+	inputs []*sns.PublishInput
+}
+
+func (p *fakeSNSPublisher) Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error) {
+	p.inputs = append(p.inputs, params)
+	return &sns.PublishOutput{}, nil
+}
+
+var (
+	// anomalyWindowStart is aligned to the anomaly window
+	anomalyWindowStart   = time.Unix(1_700_000_400, 0)
+	accountAnomalyConfig = dataapi.AccountAnomalyConfig{
+		Window:           10 * time.Minute,
+		BaselineWindows:  6,
+		SpikeFactor:      10,
+		MinSpikeBlobs:    10,
+		MaxSizeThreshold: 1000,
+		MaxSizeFraction:  0.9,
+		SustainedWindows: 3,
+	}
+)
+
+func anomalyWindow(i int) time.Time {
+	return anomalyWindowStart.Add(time.Duration(i) * accountAnomalyConfig.Window)
+}
+
+func TestAccountAnomalyRateSpike(t *testing.T) {
+	source := &fakeDispersalSource{}
+	for i := 0; i < 6; i++ {
+		source.disperse("steady", 100, anomalyWindow(i), 2)
+		source.disperse("spiking", 100, anomalyWindow(i), 2)
+	}
+	source.disperse("steady", 100, anomalyWindow(6), 3)
+	source.disperse("spiking", 100, anomalyWindow(6).Add(time.Minute), 25)
+	// New accounts have no baseline to compare against
+	source.disperse("new", 100, anomalyWindow(6), 50)
+
+	sink := &recordingAlertSink{}
+	detector, err := dataapi.NewAccountAnomalyDetector(accountAnomalyConfig, source, []dataapi.AlertSink{sink}, mockLogger)
+	assert.NoError(t, err)
+
+	anomalies, err := detector.Poll(context.Background(), anomalyWindow(7))
+	assert.NoError(t, err)
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, anomalies, sink.anomalies)
+	assert.Equal(t, dataapi.RateSpike, anomalies[0].Kind)
+	assert.Equal(t, "spiking", anomalies[0].Account)
+	assert.Equal(t, 25, anomalies[0].NumBlobs)
+	assert.Equal(t, uint(2500), anomalies[0].TotalBlobSize)
+	assert.Equal(t, 2.0, anomalies[0].BaselineNumBlobs)
+	assert.Equal(t, anomalyWindow(6).UTC(), anomalies[0].WindowStart)
+
+	// Polling again does not count the dispersals twice nor report the evaluated window again
+	anomalies, err = detector.Poll(context.Background(), anomalyWindow(7).Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, anomalies)
+	assert.Len(t, sink.anomalies, 1)
+}
+
+func TestAccountAnomalySustainedMaxSizeBlobs(t *testing.T) {
+	source := &fakeDispersalSource{}
+	for i := 0; i < 3; i++ {
+		source.disperse("max-size", 1000, anomalyWindow(i), 9)
+		source.disperse("max-size", 10, anomalyWindow(i), 1)
+		source.disperse("mixed", 1000, anomalyWindow(i), 1)
+		source.disperse("mixed", 10, anomalyWindow(i), 1)
+	}
+
+	sink := &recordingAlertSink{}
+	detector, err := dataapi.NewAccountAnomalyDetector(accountAnomalyConfig, source, []dataapi.AlertSink{sink}, mockLogger)
+	assert.NoError(t, err)
+
+	anomalies, err := detector.Poll(context.Background(), anomalyWindow(3))
+	assert.NoError(t, err)
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, dataapi.SustainedMaxSizeBlobs, anomalies[0].Kind)
+	assert.Equal(t, "max-size", anomalies[0].Account)
+	assert.Equal(t, 10, anomalies[0].NumBlobs)
+
+	// The streak is only reported once
+	source.disperse("max-size", 1000, anomalyWindow(3), 10)
+	anomalies, err = detector.Poll(context.Background(), anomalyWindow(4))
+	assert.NoError(t, err)
+	assert.Empty(t, anomalies)
+	assert.Len(t, sink.anomalies, 1)
+}
+
+func TestAccountAnomalyDelay(t *testing.T) {
+	source := &fakeDispersalSource{}
+	for i := 0; i < 6; i++ {
+		source.disperse("spiking", 100, anomalyWindow(i), 2)
+	}
+	config := accountAnomalyConfig
+	config.Delay = 5 * time.Minute
+	detector, err := dataapi.NewAccountAnomalyDetector(config, source, nil, mockLogger)
+	assert.NoError(t, err)
+
+	anomalies, err := detector.Poll(context.Background(), anomalyWindow(6))
+	assert.NoError(t, err)
+	assert.Empty(t, anomalies)
+
+	// The spike is dispersed in a window that has ended but whose delay has not elapsed yet
+	source.disperse("spiking", 100, anomalyWindow(6), 30)
+	anomalies, err = detector.Poll(context.Background(), anomalyWindow(7).Add(time.Minute))
+	assert.NoError(t, err)
+	assert.Empty(t, anomalies)
+
+	anomalies, err = detector.Poll(context.Background(), anomalyWindow(7).Add(5*time.Minute))
+	assert.NoError(t, err)
+	assert.Len(t, anomalies, 1)
+	assert.Equal(t, dataapi.RateSpike, anomalies[0].Kind)
+}
+
+func TestNewAccountAnomalyDetectorInvalidConfig(t *testing.T) {
+	config := accountAnomalyConfig
+	config.SpikeFactor = 1
+	_, err := dataapi.NewAccountAnomalyDetector(config, &fakeDispersalSource{}, nil, mockLogger)
+	assert.Error(t, err)
+
+	config = accountAnomalyConfig
+	config.Window = 0
+	_, err = dataapi.NewAccountAnomalyDetector(config, &fakeDispersalSource{}, nil, mockLogger)
+	assert.Error(t, err)
+}
+
+func TestAlertSinks(t *testing.T) {
+	anomaly := &dataapi.AccountAnomaly{
+		Kind:     dataapi.RateSpike,
+		Account:  "account",
+		NumBlobs: 25,
+	}
+
+	var received dataapi.AccountAnomaly
+	var header http.Header
+	attempts := 0
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, http.MethodPost, r.Method)
+		// The first delivery fails and is retried
+		attempts++
+		if attempts == 1 {
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		body, err := io.ReadAll(r.Body)
+		assert.NoError(t, err)
+		assert.NoError(t, dataapi.VerifyWebhookSignature("secret", r.Header, body, time.Minute, time.Now()))
+		assert.NoError(t, json.Unmarshal(body, &received))
+		header = r.Header
+		w.WriteHeader(http.StatusNoContent)
+	}))
+	defer webhook.Close()
+	assert.NoError(t, dataapi.NewWebhookAlertSink(webhook.URL, "secret", 3, time.Millisecond, mockLogger).Send(context.Background(), anomaly))
+	assert.Equal(t, *anomaly, received)
+	assert.Equal(t, 2, attempts)
+
+	// Forged and replayed alerts are rejected
+	body, err := json.Marshal(anomaly)
+	assert.NoError(t, err)
+	assert.Error(t, dataapi.VerifyWebhookSignature("other secret", header, body, time.Minute, time.Now()))
+	assert.Error(t, dataapi.VerifyWebhookSignature("secret", header, []byte("{}"), time.Minute, time.Now()))
+	assert.Error(t, dataapi.VerifyWebhookSignature("secret", header, body, time.Minute, time.Now().Add(time.Hour)))
+
+	// Client errors are not retried
+	attempts = 0
+	failingWebhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		attempts++
+		w.WriteHeader(http.StatusBadRequest)
+	}))
+	defer failingWebhook.Close()
+	assert.Error(t, dataapi.NewWebhookAlertSink(failingWebhook.URL, "", 3, time.Millisecond, mockLogger).Send(context.Background(), anomaly))
+	assert.Equal(t, 1, attempts)
+
+	publisher := &fakeSNSPublisher{}
+	assert.NoError(t, dataapi.NewSNSAlertSink(publisher, "arn:aws:sns:us-east-1:000000000000:alerts").Send(context.Background(), anomaly))
+	assert.Len(t, publisher.inputs, 1)
+	assert.Equal(t, "arn:aws:sns:us-east-1:000000000000:alerts", *publisher.inputs[0].TopicArn)
+	assert.Contains(t, *publisher.inputs[0].Message, `"kind":"rate_spike"`)
+}
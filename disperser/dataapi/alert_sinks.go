@@ -0,0 +1,182 @@
+package dataapi
+
+import (
+	"bytes"
+	"context"
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/service/sns"
+)
+
+const (
+	// webhookTimeout is the timeout of the requests to the alert webhook
+	webhookTimeout = 10 * time.Second
+
+	// The headers authenticating the alerts posted to the webhook. The signature is the hex encoded HMAC-SHA256,
+	// keyed by the webhook secret, of "<timestamp>.<delivery ID>.<body>", so that receivers can reject forged alerts,
+	// stale alerts by their timestamp, and replayed alerts by their delivery ID, which is kept across the retries.
+	WebhookTimestampHeader  = "X-EigenDA-Timestamp"
+	WebhookDeliveryIdHeader = "X-EigenDA-Delivery-Id"
+	WebhookSignatureHeader  = "X-EigenDA-Signature"
+)
+
+var (
+	errWebhookSignatureMismatch = errors.New("webhook signature mismatch")
+	errWebhookTimestampExpired  = errors.New("webhook timestamp is outside of the tolerance")
+)
+
+// WebhookAlertSink posts the anomalies as JSON to a webhook URL, signing them if a secret is set, and retries the
+// failed deliveries with exponential backoff
+type WebhookAlertSink struct {
+	url        string
+	secret     []byte
+	maxRetries int
+	backoff    time.Duration
+	client     *http.Client
+	logger     logging.Logger
+}
+
+var _ AlertSink = (*WebhookAlertSink)(nil)
+
+func NewWebhookAlertSink(url string, secret string, maxRetries int, backoff time.Duration, logger logging.Logger) *WebhookAlertSink {
+	return &WebhookAlertSink{
+		url:        url,
+		secret:     []byte(secret),
+		maxRetries: maxRetries,
+		backoff:    backoff,
+		client:     &http.Client{Timeout: webhookTimeout},
+		logger:     logger.With("component", "WebhookAlertSink"),
+	}
+}
+
+func (s *WebhookAlertSink) Send(ctx context.Context, anomaly *AccountAnomaly) error {
+	body, err := json.Marshal(anomaly)
+	if err != nil {
+		return err
+	}
+	deliveryId := make([]byte, 16)
+	if _, err := rand.Read(deliveryId); err != nil {
+		return err
+	}
+
+	backoff := s.backoff
+	for attempt := 0; ; attempt++ {
+		retryable, err := s.post(ctx, body, hex.EncodeToString(deliveryId))
+		if err == nil {
+			s.logger.Debug("alert delivered", "deliveryId", hex.EncodeToString(deliveryId), "attempt", attempt+1)
+			return nil
+		}
+		if !retryable || attempt >= s.maxRetries {
+			return err
+		}
+		s.logger.Warn("alert delivery failed, retrying", "deliveryId", hex.EncodeToString(deliveryId), "attempt", attempt+1, "backoff", backoff, "err", err)
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// post makes one delivery attempt of the alert, returning whether it can be retried if it fails
+func (s *WebhookAlertSink) post(ctx context.Context, body []byte, deliveryId string) (bool, error) {
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, s.url, bytes.NewReader(body))
+	if err != nil {
+		return false, err
+	}
+	req.Header.Set("Content-Type", "application/json")
+	if len(s.secret) > 0 {
+		timestamp := strconv.FormatInt(time.Now().Unix(), 10)
+		req.Header.Set(WebhookTimestampHeader, timestamp)
+		req.Header.Set(WebhookDeliveryIdHeader, deliveryId)
+		req.Header.Set(WebhookSignatureHeader, signWebhookPayload(s.secret, timestamp, deliveryId, body))
+	}
+
+	resp, err := s.client.Do(req)
+	if err != nil {
+		return true, fmt.Errorf("failed to post alert to webhook: %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		retryable := resp.StatusCode >= 500 || resp.StatusCode == http.StatusTooManyRequests
+		return retryable, fmt.Errorf("webhook returned status %s", resp.Status)
+	}
+	return false, nil
+}
+
+// VerifyWebhookSignature authenticates an alert received by a webhook from the headers set by the WebhookAlertSink,
+// rejecting the alerts whose timestamp is more than tolerance away from now. Receivers are left to reject the delivery
+// IDs they have already seen within the tolerance.
+func VerifyWebhookSignature(secret string, header http.Header, body []byte, tolerance time.Duration, now time.Time) error {
+	timestamp := header.Get(WebhookTimestampHeader)
+	seconds, err := strconv.ParseInt(timestamp, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid webhook timestamp %q", timestamp)
+	}
+	if age := now.Sub(time.Unix(seconds, 0)); age > tolerance || age < -tolerance {
+		return errWebhookTimestampExpired
+	}
+	expected := signWebhookPayload([]byte(secret), timestamp, header.Get(WebhookDeliveryIdHeader), body)
+	if !hmac.Equal([]byte(expected), []byte(header.Get(WebhookSignatureHeader))) {
+		return errWebhookSignatureMismatch
+	}
+	return nil
+}
+
+func signWebhookPayload(secret []byte, timestamp string, deliveryId string, body []byte) string {
+	mac := hmac.New(sha256.New, secret)
+	mac.Write([]byte(timestamp))
+	mac.Write([]byte("."))
+	mac.Write([]byte(deliveryId))
+	mac.Write([]byte("."))
+	mac.Write(body)
+	return hex.EncodeToString(mac.Sum(nil))
+}
+
+// SNSPublisher is the subset of the SNS client used to publish alerts
+type SNSPublisher interface {
+	Publish(ctx context.Context, params *sns.PublishInput, optFns ...func(*sns.Options)) (*sns.PublishOutput, error)
+}
+
+// SNSAlertSink publishes the anomalies as JSON to an SNS topic
+type SNSAlertSink struct {
+	client   SNSPublisher
+	topicARN string
+}
+
+var _ AlertSink = (*SNSAlertSink)(nil)
+
+func NewSNSAlertSink(client SNSPublisher, topicARN string) *SNSAlertSink {
+	return &SNSAlertSink{
+		client:   client,
+		topicARN: topicARN,
+	}
+}
+
+func (s *SNSAlertSink) Send(ctx context.Context, anomaly *AccountAnomaly) error {
+	body, err := json.Marshal(anomaly)
+	if err != nil {
+		return err
+	}
+	_, err = s.client.Publish(ctx, &sns.PublishInput{
+		TopicArn: aws.String(s.topicARN),
+		Subject:  aws.String(fmt.Sprintf("EigenDA account anomaly: %s", anomaly.Kind)),
+		Message:  aws.String(string(body)),
+	})
+	if err != nil {
+		return fmt.Errorf("failed to publish alert to SNS topic %s: %w", s.topicARN, err)
+	}
+	return nil
+}
@@ -0,0 +1,298 @@
+package dataapi
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/ethereum/go-ethereum"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"golang.org/x/crypto/sha3"
+)
+
+const (
+	BatchVerified   = "verified"
+	BatchUnverified = "unverified"
+
+	// The checks of the batch verification, in the order they are performed
+	checkRecordedBatch       = "recorded_batch"
+	checkConfirmationTxn     = "confirmation_transaction"
+	checkBatchHeader         = "batch_header"
+	checkBatchHeaderHash     = "batch_header_hash"
+	checkBatchConfirmedEvent = "batch_confirmed_event"
+	checkBatchMetadataHash   = "batch_metadata_hash"
+)
+
+var errBatchVerificationNotConfigured = errors.New("batch verification is not configured")
+
+type (
+	BatchVerificationCheck struct {
+		Name   string `json:"name"`
+		Passed bool   `json:"passed"`
+		Detail string `json:"detail,omitempty"`
+	}
+
+	BatchVerificationEvidence struct {
+		ConfirmationTxnHash     string `json:"confirmation_txn_hash,omitempty"`
+		ConfirmationBlockNumber uint32 `json:"confirmation_block_number,omitempty"`
+		BatchId                 uint32 `json:"batch_id"`
+		BatchRoot               string `json:"batch_root,omitempty"`
+		ReferenceBlockNumber    uint32 `json:"reference_block_number,omitempty"`
+		// OnchainBatchHeaderHash is the hash of the full batch header, as emitted in the BatchConfirmed event
+		OnchainBatchHeaderHash string `json:"onchain_batch_header_hash,omitempty"`
+		// BatchMetadataHash is the hash of the batch metadata stored by the EigenDAServiceManager for the batch ID
+		BatchMetadataHash string `json:"batch_metadata_hash,omitempty"`
+		ServiceManager    string `json:"service_manager"`
+	}
+
+	BatchVerificationResponse struct {
+		BatchHeaderHash string `json:"batch_header_hash"`
+		// Verdict is "verified" if every check passed, and "unverified" otherwise
+		Verdict  string                     `json:"verdict"`
+		Checks   []*BatchVerificationCheck  `json:"checks"`
+		Evidence *BatchVerificationEvidence `json:"evidence"`
+		// Links are the block explorer pages of the evidence, keyed by what they show
+		Links map[string]string `json:"links,omitempty"`
+	}
+)
+
+// batchVerification accumulates the checks of a batch verification, which stops at the first failed check
+type batchVerification struct {
+	response *BatchVerificationResponse
+}
+
+func (v *batchVerification) check(name string, passed bool, detailFormat string, args ...any) bool {
+	check := &BatchVerificationCheck{
+		Name:   name,
+		Passed: passed,
+	}
+	if detailFormat != "" {
+		check.Detail = fmt.Sprintf(detailFormat, args...)
+	}
+	v.response.Checks = append(v.response.Checks, check)
+	if !passed {
+		v.response.Verdict = BatchUnverified
+	}
+	return passed
+}
+
+// verifyBatch re-verifies the onchain confirmation of the batch with the given (reduced) batch header hash against
+// the batch recorded by the disperser. Failing checks are reported in the response, while the errors are returned
+// for the failures to read the records or the chain.
+func (s *server) verifyBatch(ctx context.Context, batchHeaderHash [32]byte) (*BatchVerificationResponse, error) {
+	if s.ethClient == nil || s.serviceManagerAddr == (gethcommon.Address{}) {
+		return nil, errBatchVerificationNotConfigured
+	}
+
+	metadatas, err := s.blobstore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get blobs of batch: %w", err)
+	}
+	var recorded *disperser.ConfirmationInfo
+	for _, metadata := range metadatas {
+		if metadata.ConfirmationInfo != nil {
+			recorded = metadata.ConfirmationInfo
+			break
+		}
+	}
+	if recorded == nil {
+		return nil, errNotFound
+	}
+
+	v := &batchVerification{
+		response: &BatchVerificationResponse{
+			BatchHeaderHash: gethcommon.Hash(batchHeaderHash).Hex(),
+			Verdict:         BatchVerified,
+			Checks:          make([]*BatchVerificationCheck, 0),
+			Evidence: &BatchVerificationEvidence{
+				ConfirmationTxnHash:     recorded.ConfirmationTxnHash.Hex(),
+				ConfirmationBlockNumber: recorded.ConfirmationBlockNumber,
+				BatchId:                 recorded.BatchID,
+				BatchRoot:               gethcommon.BytesToHash(recorded.BatchRoot).Hex(),
+				ReferenceBlockNumber:    recorded.ReferenceBlockNumber,
+				ServiceManager:          s.serviceManagerAddr.Hex(),
+			},
+		},
+	}
+	v.response.Links = s.batchVerificationLinks(v.response.Evidence)
+
+	// The blobs of the batch must agree on the confirmation of the batch
+	for _, metadata := range metadatas {
+		info := metadata.ConfirmationInfo
+		if info == nil {
+			continue
+		}
+		if info.ConfirmationTxnHash != recorded.ConfirmationTxnHash || info.BatchID != recorded.BatchID ||
+			info.ConfirmationBlockNumber != recorded.ConfirmationBlockNumber || info.ReferenceBlockNumber != recorded.ReferenceBlockNumber ||
+			!bytes.Equal(info.BatchRoot, recorded.BatchRoot) || info.SignatoryRecordHash != recorded.SignatoryRecordHash {
+			v.check(checkRecordedBatch, false, "blob %d records a different confirmation of the batch", info.BlobIndex)
+			return v.response, nil
+		}
+	}
+	v.check(checkRecordedBatch, true, "%d blobs", len(metadatas))
+
+	// The confirmation transaction must have called the service manager successfully at the recorded block
+	tx, isPending, err := s.ethClient.TransactionByHash(ctx, recorded.ConfirmationTxnHash)
+	if errors.Is(err, ethereum.NotFound) {
+		v.check(checkConfirmationTxn, false, "transaction %s not found", recorded.ConfirmationTxnHash.Hex())
+		return v.response, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmation transaction: %w", err)
+	}
+	if isPending {
+		v.check(checkConfirmationTxn, false, "transaction %s is pending", recorded.ConfirmationTxnHash.Hex())
+		return v.response, nil
+	}
+	if tx.To() == nil || *tx.To() != s.serviceManagerAddr {
+		v.check(checkConfirmationTxn, false, "transaction %s is not sent to the service manager", recorded.ConfirmationTxnHash.Hex())
+		return v.response, nil
+	}
+	receipt, err := s.ethClient.TransactionReceipt(ctx, recorded.ConfirmationTxnHash)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get confirmation transaction receipt: %w", err)
+	}
+	if receipt.Status != types.ReceiptStatusSuccessful {
+		v.check(checkConfirmationTxn, false, "transaction %s reverted", recorded.ConfirmationTxnHash.Hex())
+		return v.response, nil
+	}
+	if receipt.BlockNumber == nil || receipt.BlockNumber.Uint64() != uint64(recorded.ConfirmationBlockNumber) {
+		v.check(checkConfirmationTxn, false, "transaction %s is included in block %v instead of %d", recorded.ConfirmationTxnHash.Hex(), receipt.BlockNumber, recorded.ConfirmationBlockNumber)
+		return v.response, nil
+	}
+	v.check(checkConfirmationTxn, true, "")
+
+	// The batch header submitted onchain must be the recorded one
+	header, err := decodeConfirmBatchHeader(tx.Data())
+	if err != nil {
+		v.check(checkBatchHeader, false, "%v", err)
+		return v.response, nil
+	}
+	if !bytes.Equal(header.BlobHeadersRoot[:], recorded.BatchRoot) || header.ReferenceBlockNumber != recorded.ReferenceBlockNumber {
+		v.check(checkBatchHeader, false, "the batch header submitted onchain has batch root %s and reference block %d", gethcommon.Hash(header.BlobHeadersRoot).Hex(), header.ReferenceBlockNumber)
+		return v.response, nil
+	}
+	v.check(checkBatchHeader, true, "quorums %v", header.QuorumNumbers)
+
+	// The batch header hash, which is the hash of the reduced batch header, must follow from the onchain batch header
+	reducedHeader := core.BatchHeader{
+		BatchRoot:            header.BlobHeadersRoot,
+		ReferenceBlockNumber: uint(header.ReferenceBlockNumber),
+	}
+	recomputedHash, err := reducedHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	if !v.check(checkBatchHeaderHash, recomputedHash == batchHeaderHash, "recomputed %s", gethcommon.Hash(recomputedHash).Hex()) {
+		return v.response, nil
+	}
+
+	// The service manager must have emitted the BatchConfirmed event for the batch with the recorded batch ID
+	onchainHash, err := core.HashBatchHeader(header)
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash onchain batch header: %w", err)
+	}
+	v.response.Evidence.OnchainBatchHeaderHash = gethcommon.Hash(onchainHash).Hex()
+	batchID, err := findBatchConfirmedEvent(receipt, s.serviceManagerAddr, onchainHash)
+	if err != nil {
+		v.check(checkBatchConfirmedEvent, false, "%v", err)
+		return v.response, nil
+	}
+	if !v.check(checkBatchConfirmedEvent, batchID == recorded.BatchID, "batch ID %d", batchID) {
+		return v.response, nil
+	}
+
+	// The metadata hash stored for the batch ID must commit to the batch header, signatories and confirmation block
+	caller, err := binding.NewContractEigenDAServiceManagerCaller(s.serviceManagerAddr, s.ethClient)
+	if err != nil {
+		return nil, fmt.Errorf("failed to bind service manager: %w", err)
+	}
+	storedMetadataHash, err := caller.BatchIdToBatchMetadataHash(&bind.CallOpts{Context: ctx}, recorded.BatchID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get batch metadata hash: %w", err)
+	}
+	v.response.Evidence.BatchMetadataHash = gethcommon.Hash(storedMetadataHash).Hex()
+	metadataHash := hashBatchMetadata(onchainHash, recorded.SignatoryRecordHash, recorded.ConfirmationBlockNumber)
+	v.check(checkBatchMetadataHash, metadataHash == storedMetadataHash, "recomputed %s", gethcommon.Hash(metadataHash).Hex())
+	return v.response, nil
+}
+
+// batchVerificationLinks returns the block explorer pages of the evidence of a batch verification
+func (s *server) batchVerificationLinks(evidence *BatchVerificationEvidence) map[string]string {
+	if s.blockExplorerURL == "" {
+		return nil
+	}
+	return map[string]string{
+		"confirmation_transaction": fmt.Sprintf("%s/tx/%s", s.blockExplorerURL, evidence.ConfirmationTxnHash),
+		"confirmation_block":       fmt.Sprintf("%s/block/%d", s.blockExplorerURL, evidence.ConfirmationBlockNumber),
+		"service_manager":          fmt.Sprintf("%s/address/%s", s.blockExplorerURL, evidence.ServiceManager),
+	}
+}
+
+// decodeConfirmBatchHeader decodes the batch header from the calldata of a confirmBatch transaction
+func decodeConfirmBatchHeader(calldata []byte) (binding.IEigenDAServiceManagerBatchHeader, error) {
+	if len(calldata) < 4 {
+		return binding.IEigenDAServiceManagerBatchHeader{}, errors.New("the transaction has no calldata")
+	}
+	smAbi, err := abi.JSON(bytes.NewReader(common.ServiceManagerAbi))
+	if err != nil {
+		return binding.IEigenDAServiceManagerBatchHeader{}, fmt.Errorf("failed to parse ServiceManager ABI: %w", err)
+	}
+	method, err := smAbi.MethodById(calldata[:4])
+	if err != nil || method.Name != "confirmBatch" {
+		return binding.IEigenDAServiceManagerBatchHeader{}, errors.New("the transaction doesn't call confirmBatch")
+	}
+	inputs, err := method.Inputs.Unpack(calldata[4:])
+	if err != nil {
+		return binding.IEigenDAServiceManagerBatchHeader{}, fmt.Errorf("failed to unpack confirmBatch calldata: %w", err)
+	}
+	header, ok := abi.ConvertType(inputs[0], new(binding.IEigenDAServiceManagerBatchHeader)).(*binding.IEigenDAServiceManagerBatchHeader)
+	if !ok {
+		return binding.IEigenDAServiceManagerBatchHeader{}, errors.New("failed to decode batch header")
+	}
+	return *header, nil
+}
+
+// findBatchConfirmedEvent returns the batch ID of the BatchConfirmed event emitted by the service manager for the
+// batch header hash in the receipt
+func findBatchConfirmedEvent(receipt *types.Receipt, serviceManagerAddr gethcommon.Address, onchainBatchHeaderHash [32]byte) (uint32, error) {
+	smAbi, err := abi.JSON(bytes.NewReader(common.ServiceManagerAbi))
+	if err != nil {
+		return 0, fmt.Errorf("failed to parse ServiceManager ABI: %w", err)
+	}
+	for _, log := range receipt.Logs {
+		if log.Address != serviceManagerAddr || len(log.Topics) < 2 || log.Topics[0] != common.BatchConfirmedEventSigHash {
+			continue
+		}
+		if log.Topics[1] != gethcommon.Hash(onchainBatchHeaderHash) {
+			return 0, fmt.Errorf("the BatchConfirmed event is emitted for batch header hash %s", log.Topics[1].Hex())
+		}
+		unpacked, err := smAbi.Unpack("BatchConfirmed", log.Data)
+		if err != nil || len(unpacked) != 1 {
+			return 0, errors.New("failed to unpack BatchConfirmed event")
+		}
+		return unpacked[0].(uint32), nil
+	}
+	return 0, errors.New("the transaction didn't emit a BatchConfirmed event")
+}
+
+// hashBatchMetadata returns the hash of the batch metadata stored by the EigenDAServiceManager
+// ref: https://github.com/Layr-Labs/eigenda/blob/master/contracts/src/libraries/EigenDAHasher.sol#L19
+func hashBatchMetadata(onchainBatchHeaderHash [32]byte, signatoryRecordHash [32]byte, confirmationBlockNumber uint32) [32]byte {
+	hasher := sha3.NewLegacyKeccak256()
+	hasher.Write(onchainBatchHeaderHash[:])
+	hasher.Write(signatoryRecordHash[:])
+	_ = binary.Write(hasher, binary.BigEndian, confirmationBlockNumber)
+	var metadataHash [32]byte
+	copy(metadataHash[:], hasher.Sum(nil))
+	return metadataHash
+}
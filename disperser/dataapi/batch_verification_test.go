@@ -0,0 +1,167 @@
+package dataapi_test
+
+import (
+	"bytes"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"math/big"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common"
+	commonmock "github.com/Layr-Labs/eigenda/common/mock"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/ethereum/go-ethereum/accounts/abi"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/core/types"
+	"github.com/ethereum/go-ethereum/crypto"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+var serviceManagerAddr = gethcommon.HexToAddress("0x0000000000000000000000000000000000000abc")
+
+type confirmedBatch struct {
+	batchHeaderHash   [32]byte
+	tx                *types.Transaction
+	receipt           *types.Receipt
+	batchMetadataHash [32]byte
+}
+
+// confirmBatch records a batch of one blob as confirmed by a confirmBatch transaction and returns the
+// transaction, its receipt and the batch metadata hash stored by the service manager
+func confirmBatch(t *testing.T, store disperser.BlobStore) *confirmedBatch {
+	header := binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:       [32]byte{1, 2, 3},
+		QuorumNumbers:         []byte{0},
+		SignedStakeForQuorums: []byte{100},
+		ReferenceBlockNumber:  expectedReferenceBlockNumber,
+	}
+	reducedHeader := core.BatchHeader{BatchRoot: header.BlobHeadersRoot, ReferenceBlockNumber: uint(header.ReferenceBlockNumber)}
+	batchHeaderHash, err := reducedHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+	onchainHash, err := core.HashBatchHeader(header)
+	assert.NoError(t, err)
+
+	smAbi, err := abi.JSON(bytes.NewReader(common.ServiceManagerAbi))
+	assert.NoError(t, err)
+	g1 := binding.BN254G1Point{X: big.NewInt(0), Y: big.NewInt(0)}
+	calldata, err := smAbi.Pack("confirmBatch", header, binding.IBLSSignatureCheckerNonSignerStakesAndSignature{
+		QuorumApks: []binding.BN254G1Point{g1},
+		ApkG2:      binding.BN254G2Point{X: [2]*big.Int{big.NewInt(0), big.NewInt(0)}, Y: [2]*big.Int{big.NewInt(0), big.NewInt(0)}},
+		Sigma:      g1,
+	})
+	assert.NoError(t, err)
+	tx := types.NewTx(&types.LegacyTx{To: &serviceManagerAddr, Data: calldata})
+	eventData, err := smAbi.Events["BatchConfirmed"].Inputs.NonIndexed().Pack(expectedBatchId)
+	assert.NoError(t, err)
+	receipt := &types.Receipt{
+		Status:      types.ReceiptStatusSuccessful,
+		BlockNumber: big.NewInt(int64(expectedConfirmationBlockNumber)),
+		Logs: []*types.Log{{
+			Address: serviceManagerAddr,
+			Topics:  []gethcommon.Hash{common.BatchConfirmedEventSigHash, onchainHash},
+			Data:    eventData,
+		}},
+	}
+
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, store)
+	metadata, err := store.GetBlobMetadata(context.Background(), key)
+	assert.NoError(t, err)
+	_, err = store.MarkBlobConfirmed(context.Background(), metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash:         batchHeaderHash,
+		BlobIndex:               0,
+		BlobCount:               1,
+		SignatoryRecordHash:     expectedSignatoryRecordHash,
+		ReferenceBlockNumber:    expectedReferenceBlockNumber,
+		BatchRoot:               header.BlobHeadersRoot[:],
+		BatchID:                 expectedBatchId,
+		ConfirmationTxnHash:     tx.Hash(),
+		ConfirmationBlockNumber: expectedConfirmationBlockNumber,
+	})
+	assert.NoError(t, err)
+
+	blockNumber := make([]byte, 4)
+	big.NewInt(int64(expectedConfirmationBlockNumber)).FillBytes(blockNumber)
+	var batchMetadataHash [32]byte
+	copy(batchMetadataHash[:], crypto.Keccak256(onchainHash[:], expectedSignatoryRecordHash[:], blockNumber))
+	return &confirmedBatch{
+		batchHeaderHash:   batchHeaderHash,
+		tx:                tx,
+		receipt:           receipt,
+		batchMetadataHash: batchMetadataHash,
+	}
+}
+
+func getBatchVerification(t *testing.T, ethClient common.EthClient, store disperser.BlobStore, batchHeaderHash [32]byte) (int, *dataapi.BatchVerificationResponse) {
+	verificationConfig := config
+	verificationConfig.EigenDAServiceManagerAddr = serviceManagerAddr.Hex()
+	verificationConfig.BlockExplorerURL = "https://explorer.test/"
+	server := dataapi.NewServer(verificationConfig, store, prometheusClient, subgraphClient, mockTx, ethClient, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+
+	r := setUpRouter()
+	r.GET("/v1/feed/batches/:batch_header_hash/verification", server.VerifyBatchHandler)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/feed/batches/"+hex.EncodeToString(batchHeaderHash[:])+"/verification", nil)
+	r.ServeHTTP(w, req)
+
+	var response dataapi.BatchVerificationResponse
+	if w.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w.Code, &response
+}
+
+func TestVerifyBatch(t *testing.T) {
+	store := inmem.NewBlobStore()
+	batch := confirmBatch(t, store)
+
+	ethClient := &commonmock.MockEthClient{}
+	ethClient.On("TransactionByHash", batch.tx.Hash()).Return(batch.tx, false, nil)
+	ethClient.On("TransactionReceipt").Return(batch.receipt, nil)
+	ethClient.On("CallContract").Return(batch.batchMetadataHash[:], nil).Once()
+
+	code, response := getBatchVerification(t, ethClient, store, batch.batchHeaderHash)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.BatchVerified, response.Verdict)
+	assert.Len(t, response.Checks, 6)
+	for _, check := range response.Checks {
+		assert.True(t, check.Passed, check.Name)
+	}
+	assert.Equal(t, expectedBatchId, response.Evidence.BatchId)
+	assert.Equal(t, gethcommon.Hash(batch.batchMetadataHash).Hex(), response.Evidence.BatchMetadataHash)
+	assert.Equal(t, "https://explorer.test/tx/"+batch.tx.Hash().Hex(), response.Links["confirmation_transaction"])
+	assert.Equal(t, "https://explorer.test/block/150", response.Links["confirmation_block"])
+
+	// The metadata hash stored onchain doesn't commit to the recorded batch
+	ethClient.On("CallContract").Return(make([]byte, 32), nil).Once()
+	code, response = getBatchVerification(t, ethClient, store, batch.batchHeaderHash)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.BatchUnverified, response.Verdict)
+	assert.Len(t, response.Checks, 6)
+	assert.False(t, response.Checks[5].Passed)
+
+	// The transaction isn't sent to the service manager
+	otherStore := inmem.NewBlobStore()
+	otherBatch := confirmBatch(t, otherStore)
+	otherTx := types.NewTx(&types.LegacyTx{To: &gethcommon.Address{1}, Data: otherBatch.tx.Data()})
+	ethClient = &commonmock.MockEthClient{}
+	ethClient.On("TransactionByHash", mock.Anything).Return(otherTx, false, nil)
+	code, response = getBatchVerification(t, ethClient, otherStore, otherBatch.batchHeaderHash)
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, dataapi.BatchUnverified, response.Verdict)
+	assert.Len(t, response.Checks, 2)
+	assert.Equal(t, "confirmation_transaction", response.Checks[1].Name)
+	assert.False(t, response.Checks[1].Passed)
+
+	// Unknown batch
+	code, _ = getBatchVerification(t, ethClient, store, [32]byte{1})
+	assert.Equal(t, http.StatusNotFound, code)
+}
@@ -0,0 +1,232 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/sampling"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/encoding"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+const (
+	// BlobAvailable is the verdict of a probe where every sampled operator served its chunks
+	BlobAvailable = "available"
+	// BlobDegraded is the verdict of a probe where some of the sampled operators didn't serve valid chunks
+	BlobDegraded = "degraded"
+	// BlobUnavailable is the verdict of a probe where none of the sampled operators of a quorum served valid chunks
+	BlobUnavailable = "unavailable"
+
+	defaultBlobProbeSampleSize = 5
+	maxBlobProbeSampleSize     = 20
+	defaultBlobProbeTimeout    = 10 * time.Second
+)
+
+type (
+	// BlobProbeOperator is the result of the request of the chunks of the blob to a sampled operator
+	BlobProbeOperator struct {
+		OperatorId string `json:"operator_id"`
+		Socket     string `json:"socket"`
+		QuorumId   uint8  `json:"quorum_id"`
+		// Responded is whether the operator served chunks, and Verified whether they are the chunks assigned to the
+		// operator and match the commitment of the blob
+		Responded bool    `json:"responded"`
+		Verified  bool    `json:"verified"`
+		NumChunks int     `json:"num_chunks"`
+		LatencyMs float64 `json:"latency_ms"`
+		Error     string  `json:"error,omitempty"`
+	}
+
+	// BlobProbeQuorum is the result of the probe of the operators of a quorum of the blob
+	BlobProbeQuorum struct {
+		QuorumId    uint8  `json:"quorum_id"`
+		Verdict     string `json:"verdict"`
+		NumSampled  int    `json:"num_sampled"`
+		NumVerified int    `json:"num_verified"`
+		// VerifiedChunks is the number of chunks verified by the probe, and RequiredChunks the number of chunks from
+		// which the blob can be reconstructed. The blob can be reconstructed from the sample alone if Reconstructable.
+		VerifiedChunks  uint `json:"verified_chunks"`
+		RequiredChunks  uint `json:"required_chunks"`
+		Reconstructable bool `json:"reconstructable"`
+	}
+
+	BlobAvailabilityResponse struct {
+		BatchHeaderHash      string `json:"batch_header_hash"`
+		BlobIndex            uint32 `json:"blob_index"`
+		ReferenceBlockNumber uint32 `json:"reference_block_number"`
+		// Verdict is the worst verdict of the quorums
+		Verdict   string               `json:"verdict"`
+		Quorums   []*BlobProbeQuorum   `json:"quorums"`
+		Operators []*BlobProbeOperator `json:"operators"`
+	}
+)
+
+// probeBlobAvailability samples up to sampleSize operators of each probed quorum of the blob, weighted by their stake,
+// requests the chunks of the blob assigned to them and verifies the chunks against the commitment of the blob. All
+// the quorums of the blob are probed if quorumIDs is empty.
+func (s *server) probeBlobAvailability(ctx context.Context, batchHeaderHash [32]byte, blobIndex uint32, quorumIDs []core.QuorumID, sampleSize int) (*BlobAvailabilityResponse, error) {
+	metadata, err := s.blobstore.GetMetadataInBatch(ctx, batchHeaderHash, blobIndex)
+	if errors.Is(err, disperser.ErrMetadataNotFound) || errors.Is(err, disperser.ErrBlobNotFound) {
+		return nil, errNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	info := metadata.ConfirmationInfo
+	if info == nil || info.BlobCommitment == nil {
+		return nil, fmt.Errorf("%w: the blob is not confirmed", errNotFound)
+	}
+
+	quorumInfos := make(map[core.QuorumID]*core.BlobQuorumInfo, len(info.BlobQuorumInfos))
+	for _, quorumInfo := range info.BlobQuorumInfos {
+		quorumInfos[quorumInfo.QuorumID] = quorumInfo
+	}
+	if len(quorumIDs) == 0 {
+		for quorumID := range quorumInfos {
+			quorumIDs = append(quorumIDs, quorumID)
+		}
+		sort.Slice(quorumIDs, func(i, j int) bool { return quorumIDs[i] < quorumIDs[j] })
+	}
+	for _, quorumID := range quorumIDs {
+		if _, ok := quorumInfos[quorumID]; !ok {
+			return nil, fmt.Errorf("%w: the blob is not dispersed to quorum %d", errNotFound, quorumID)
+		}
+	}
+
+	state, err := s.indexedChainState.GetIndexedOperatorState(ctx, uint(info.ReferenceBlockNumber), quorumIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", info.ReferenceBlockNumber, err)
+	}
+
+	response := &BlobAvailabilityResponse{
+		BatchHeaderHash:      gethcommon.Hash(batchHeaderHash).Hex(),
+		BlobIndex:            blobIndex,
+		ReferenceBlockNumber: info.ReferenceBlockNumber,
+		Verdict:              BlobAvailable,
+		Quorums:              make([]*BlobProbeQuorum, 0, len(quorumIDs)),
+		Operators:            make([]*BlobProbeOperator, 0),
+	}
+	coordinator := &core.StdAssignmentCoordinator{}
+	blobLength := info.BlobCommitment.Length
+	for _, quorumID := range quorumIDs {
+		quorumInfo := quorumInfos[quorumID]
+		assignments, assignmentInfo, err := coordinator.GetAssignments(state.OperatorState, blobLength, quorumInfo)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get the assignments of quorum %d: %w", quorumID, err)
+		}
+		sampler, err := sampling.NewSampler(state.Operators[quorumID], sampling.NewRand())
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample the operators of quorum %d: %w", quorumID, err)
+		}
+		sampled, err := sampler.SampleN(sampleSize)
+		if err != nil {
+			return nil, fmt.Errorf("failed to sample the operators of quorum %d: %w", quorumID, err)
+		}
+
+		params := encoding.ParamsFromMins(quorumInfo.ChunkLength, assignmentInfo.TotalChunks)
+		operators := s.probeOperators(ctx, batchHeaderHash, blobIndex, quorumID, sampled, state, assignments, *info.BlobCommitment, params)
+
+		quorum := &BlobProbeQuorum{
+			QuorumId:       quorumID,
+			NumSampled:     len(operators),
+			RequiredChunks: (blobLength + quorumInfo.ChunkLength - 1) / quorumInfo.ChunkLength,
+		}
+		for _, operator := range operators {
+			if operator.Verified {
+				quorum.NumVerified++
+				quorum.VerifiedChunks += uint(operator.NumChunks)
+			}
+		}
+		quorum.Reconstructable = quorum.RequiredChunks > 0 && quorum.VerifiedChunks >= quorum.RequiredChunks
+		switch {
+		case quorum.NumSampled > 0 && quorum.NumVerified == quorum.NumSampled:
+			quorum.Verdict = BlobAvailable
+		case quorum.NumVerified > 0:
+			quorum.Verdict = BlobDegraded
+		default:
+			quorum.Verdict = BlobUnavailable
+		}
+		response.Verdict = worseBlobVerdict(response.Verdict, quorum.Verdict)
+		response.Quorums = append(response.Quorums, quorum)
+		response.Operators = append(response.Operators, operators...)
+	}
+	return response, nil
+}
+
+// probeOperators requests the chunks of the blob to the operators concurrently, and verifies the chunks they serve
+func (s *server) probeOperators(
+	ctx context.Context,
+	batchHeaderHash [32]byte,
+	blobIndex uint32,
+	quorumID core.QuorumID,
+	operatorIDs []core.OperatorID,
+	state *core.IndexedOperatorState,
+	assignments map[core.OperatorID]core.Assignment,
+	commitments encoding.BlobCommitments,
+	params encoding.EncodingParams,
+) []*BlobProbeOperator {
+	results := make([]*BlobProbeOperator, len(operatorIDs))
+	var wg sync.WaitGroup
+	for i, operatorID := range operatorIDs {
+		result := &BlobProbeOperator{
+			OperatorId: operatorID.Hex(),
+			QuorumId:   quorumID,
+		}
+		results[i] = result
+		operatorInfo, ok := state.IndexedOperators[operatorID]
+		if !ok {
+			result.Error = "the operator has no socket"
+			continue
+		}
+		result.Socket = operatorInfo.Socket
+		assignment, ok := assignments[operatorID]
+		if !ok {
+			result.Error = "no chunks are assigned to the operator"
+			continue
+		}
+
+		wg.Add(1)
+		go func(operatorID core.OperatorID, operatorInfo *core.IndexedOperatorInfo, assignment core.Assignment) {
+			defer wg.Done()
+			// GetChunks sends exactly one reply
+			replies := make(chan clients.RetrievedChunks, 1)
+			start := time.Now()
+			s.blobProbeNodeClient.GetChunks(ctx, operatorID, operatorInfo, batchHeaderHash, blobIndex, quorumID, replies)
+			reply := <-replies
+			result.LatencyMs = float64(time.Since(start).Microseconds()) / 1000
+			if reply.Err != nil {
+				result.Error = reply.Err.Error()
+				return
+			}
+			result.Responded = true
+			result.NumChunks = len(reply.Chunks)
+			if len(reply.Chunks) != int(assignment.NumChunks) {
+				result.Error = fmt.Sprintf("served %d chunks instead of the %d assigned", len(reply.Chunks), assignment.NumChunks)
+				return
+			}
+			if err := s.blobProbeVerifier.VerifyFrames(reply.Chunks, assignment.GetIndices(), commitments, params); err != nil {
+				result.Error = fmt.Sprintf("invalid chunks: %v", err)
+				return
+			}
+			result.Verified = true
+		}(operatorID, operatorInfo, assignment)
+	}
+	wg.Wait()
+	return results
+}
+
+// worseBlobVerdict returns the worse of the two verdicts
+func worseBlobVerdict(a, b string) string {
+	rank := map[string]int{BlobAvailable: 0, BlobDegraded: 1, BlobUnavailable: 2}
+	if rank[b] > rank[a] {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,156 @@
+package dataapi_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/encoding"
+	encmock "github.com/Layr-Labs/eigenda/encoding/mock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+)
+
+// chunkServingNodeClient serves the number of chunks assigned to each operator, except for the operators which fail
+type chunkServingNodeClient struct {
+	clients.NodeClient
+
+	assignments map[core.OperatorID]core.Assignment
+	failing     map[core.OperatorID]bool
+}
+
+func (c *chunkServingNodeClient) GetChunks(ctx context.Context, opID core.OperatorID, opInfo *core.IndexedOperatorInfo, batchHeaderHash [32]byte, blobIndex uint32, quorumID core.QuorumID, chunksChan chan clients.RetrievedChunks) {
+	if c.failing[opID] {
+		chunksChan <- clients.RetrievedChunks{OperatorID: opID, Err: errors.New("connection refused")}
+		return
+	}
+	chunks := make([]*encoding.Frame, c.assignments[opID].NumChunks)
+	for i := range chunks {
+		chunks[i] = &encoding.Frame{}
+	}
+	chunksChan <- clients.RetrievedChunks{OperatorID: opID, Chunks: chunks}
+}
+
+func TestProbeBlobAvailability(t *testing.T) {
+	store := inmem.NewBlobStore()
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, store)
+	batchHeaderHash := [32]byte{7}
+	quorumInfo := &core.BlobQuorumInfo{
+		SecurityParam: core.SecurityParam{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 100},
+		ChunkLength:   2,
+	}
+	_, err := store.MarkBlobConfirmed(context.Background(), &disperser.BlobMetadata{
+		BlobHash:     key.BlobHash,
+		MetadataHash: key.MetadataHash,
+		BlobStatus:   disperser.Confirmed,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			RequestedAt:       expectedRequestedAt,
+			BlobSize:          uint(len(blob.Data)),
+		},
+	}, &disperser.ConfirmationInfo{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            0,
+		ReferenceBlockNumber: expectedReferenceBlockNumber,
+		BlobCommitment:       &encoding.BlobCommitments{Length: uint(expectedDataLength)},
+		BlobQuorumInfos:      []*core.BlobQuorumInfo{quorumInfo},
+	})
+	require.NoError(t, err)
+
+	state, err := mockIndexedChainState.GetIndexedOperatorState(context.Background(), uint(expectedReferenceBlockNumber), []core.QuorumID{0})
+	require.NoError(t, err)
+	assignments, _, err := (&core.StdAssignmentCoordinator{}).GetAssignments(state.OperatorState, uint(expectedDataLength), quorumInfo)
+	require.NoError(t, err)
+	nodeClient := &chunkServingNodeClient{assignments: assignments, failing: make(map[core.OperatorID]bool)}
+	verifier := &encmock.MockEncoder{}
+	verifier.On("VerifyFrames", mock.Anything, mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	probeConfig := config
+	probeConfig.BlobProbeVerifier = verifier
+	probeConfig.BlobProbeNodeClient = nodeClient
+	server := dataapi.NewServer(probeConfig, store, prometheusClient, subgraphClient, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r := setUpRouter()
+	r.GET("/v1/feed/batches/:batch_header_hash/blobs/:blob_index/availability", server.ProbeBlobAvailabilityHandler)
+	probe := func(path string) (int, *dataapi.BlobAvailabilityResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/feed/batches/"+path, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response dataapi.BlobAvailabilityResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, &response))
+			assert.Equal(t, "no-store", res.Header.Get("Cache-Control"))
+		}
+		return res.StatusCode, &response
+	}
+	blobPath := hex.EncodeToString(batchHeaderHash[:]) + "/blobs/0/availability"
+
+	// Every operator serves its chunks
+	status, response := probe(blobPath + "?sample_size=20")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, dataapi.BlobAvailable, response.Verdict)
+	assert.Equal(t, expectedReferenceBlockNumber, response.ReferenceBlockNumber)
+	require.Len(t, response.Quorums, 1)
+	quorum := response.Quorums[0]
+	assert.Equal(t, len(assignments), quorum.NumSampled)
+	assert.Equal(t, len(assignments), quorum.NumVerified)
+	assert.True(t, quorum.Reconstructable)
+	assert.Len(t, response.Operators, len(assignments))
+
+	// The default sample size is used if the request sets none
+	status, response = probe(blobPath)
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 5, response.Quorums[0].NumSampled)
+
+	// An operator which doesn't serve its chunks degrades the blob
+	failing := state.OperatorState.Operators[0]
+	for operatorID := range failing {
+		nodeClient.failing[operatorID] = true
+		break
+	}
+	status, response = probe(blobPath + "?sample_size=20&quorum_id=0")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, dataapi.BlobDegraded, response.Verdict)
+	assert.Equal(t, len(assignments)-1, response.Quorums[0].NumVerified)
+
+	// The blob is unavailable if no operator serves its chunks
+	for operatorID := range failing {
+		nodeClient.failing[operatorID] = true
+	}
+	status, response = probe(blobPath + "?sample_size=20")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, dataapi.BlobUnavailable, response.Verdict)
+	assert.False(t, response.Quorums[0].Reconstructable)
+	for _, operator := range response.Operators {
+		assert.False(t, operator.Responded)
+		assert.NotEmpty(t, operator.Error)
+	}
+
+	status, _ = probe(blobPath + "?quorum_id=1")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = probe(hex.EncodeToString(make([]byte, 32)) + "/blobs/0/availability")
+	assert.Equal(t, http.StatusNotFound, status)
+	status, _ = probe(blobPath + "?sample_size=21")
+	assert.Equal(t, http.StatusBadRequest, status)
+
+	// The probe is not served without a verifier
+	r = setUpRouter()
+	r.GET("/v1/feed/batches/:batch_header_hash/blobs/:blob_index/availability", testDataApiServer.ProbeBlobAvailabilityHandler)
+	status, _ = probe(blobPath)
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+}
@@ -3,6 +3,7 @@ package dataapi
 import (
 	"context"
 	"encoding/hex"
+	"errors"
 	"sort"
 
 	"github.com/Layr-Labs/eigenda/disperser"
@@ -52,6 +53,21 @@ func (s *server) getBlobsFromBatchHeaderHash(ctx context.Context, batcherHeaderH
 	return responses, newExclusiveStartKey, nil
 }
 
+func (s *server) getBlobsFromCommitmentHash(ctx context.Context, commitmentHash [32]byte) ([]*BlobMetadataResponse, error) {
+	blobMetadatas, err := s.blobstore.GetBlobMetadataByCommitmentHash(ctx, commitmentHash)
+	if errors.Is(err, disperser.ErrMetadataNotFound) {
+		return nil, errNotFound
+	}
+	if err != nil {
+		return nil, err
+	}
+	if len(blobMetadatas) == 0 {
+		return nil, errNotFound
+	}
+
+	return s.convertBlobMetadatasToBlobMetadataResponse(ctx, blobMetadatas)
+}
+
 func (s *server) convertBlobMetadatasToBlobMetadataResponse(ctx context.Context, metadatas []*disperser.BlobMetadata) ([]*BlobMetadataResponse, error) {
 	var (
 		err               error
@@ -1,5 +1,12 @@
 package dataapi
 
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
 type Config struct {
 	SocketAddr         string
 	ServerMode         string
@@ -7,4 +14,79 @@ type Config struct {
 	DisperserHostname  string
 	ChurnerHostname    string
 	BatcherHealthEndpt string
+
+	// InternalSocketAddr is the address of the internal listener, which serves the full API, including the endpoints
+	// probing the operators and the services. If it is set, the listener on SocketAddr is the public one, which only
+	// serves the cacheable endpoints that don't trigger probes. The full API is served on SocketAddr if it is not set.
+	InternalSocketAddr string
+	// InternalAllowOrigins are the origins allowed for the CORS requests to the internal listener
+	InternalAllowOrigins []string
+	// PublicRateLimit is the number of requests per second each client may make to the public listener on average,
+	// with bursts of up to PublicRateBurst requests. The public listener isn't rate limited if it is 0.
+	PublicRateLimit float64
+	PublicRateBurst int
+	// PublicTrustedProxies are the IPs or CIDRs of the proxies whose forwarded client IPs the public listener rate
+	// limits. The IP of the connection is rate limited if there are none.
+	PublicTrustedProxies []string
+	// PublicMaxStreamsPerClient is the number of confirmation streams each client may keep open on the public
+	// listener. The streams aren't capped if it is 0.
+	PublicMaxStreamsPerClient int
+
+	// GraphQLMaxDepth and GraphQLMaxComplexity limit the queries of the GraphQL API.
+	// The defaults are used if they are not set.
+	GraphQLMaxDepth      int
+	GraphQLMaxComplexity int
+
+	// EigenDAServiceManagerAddr is the address of the contract confirming the batches, whose confirmations are
+	// verified by the batch verification API
+	EigenDAServiceManagerAddr string
+	// BlockExplorerURL is the base URL of the block explorer linked as evidence by the batch verification API,
+	// e.g. https://etherscan.io. No links are returned if it is not set.
+	BlockExplorerURL string
+
+	// OperatorsPageSize is the number of operators per page of the operator state endpoints when the request sets no
+	// limit. The default is used if it is not set.
+	OperatorsPageSize int
+
+	// NodeInfoWorkers is the number of concurrent node info requests of the scans of the operators. The default is
+	// used if it is not set.
+	NodeInfoWorkers int
+
+	// OperatorOnlineCheckWorkers is the number of concurrent online checks of the operators, by the operator
+	// endpoints, the refresher of their statuses and the uptime tracker. The default is used if it is not set.
+	OperatorOnlineCheckWorkers int
+	// OperatorOnlineCheckTimeout bounds the dial of the socket of an operator checked online by the operator
+	// endpoints and the refresher of their statuses. The default is used if it is not set.
+	OperatorOnlineCheckTimeout time.Duration
+
+	// OperatorStatusCacheTTL is how long the online statuses and the semvers of the operators are reused by the
+	// operator endpoints. They are probed on each request if it is 0.
+	OperatorStatusCacheTTL time.Duration
+	// OperatorStatusRefreshInterval is the interval at which the cached statuses are probed again in the
+	// background. They are only probed by the requests if it is 0.
+	OperatorStatusRefreshInterval time.Duration
+
+	// ConfirmationStreamPollInterval is the interval at which the confirmation stream polls for the new
+	// confirmations. The stream isn't served if it is 0.
+	ConfirmationStreamPollInterval time.Duration
+
+	// OperatorProbeVantages are the base URLs of the v1 APIs of the Data APIs deployed in other regions by region name,
+	// from which the operator diagnosis checks the reachability of the operators
+	OperatorProbeVantages map[string]string
+
+	// OperatorUptimeStore stores the uptime of the operators. The uptime isn't tracked nor served if it is nil.
+	OperatorUptimeStore OperatorUptimeStore
+	// OperatorUptimeProbeInterval is the interval at which the operators are probed for their uptime. The uptime is
+	// only served if it is 0, e.g. by the replicas which share the store of another.
+	OperatorUptimeProbeInterval time.Duration
+
+	// BlobProbeVerifier verifies the chunks of the blobs sampled by the blob availability probe. The probe isn't
+	// served if it is nil.
+	BlobProbeVerifier encoding.Verifier
+	// BlobProbeNodeClient requests the chunks of the blobs to the operators. A client with the default timeout is
+	// used if it is not set.
+	BlobProbeNodeClient clients.NodeClient
+	// BlobProbeSampleSize is the number of operators of each quorum sampled by the probe when the request sets none.
+	// The default is used if it is not set.
+	BlobProbeSampleSize int
 }
@@ -0,0 +1,361 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"net/http"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gin-contrib/sse"
+	"github.com/gin-gonic/gin"
+)
+
+const (
+	FeedEventBatchConfirmed = "batch_confirmed"
+	FeedEventBlobConfirmed  = "blob_confirmed"
+	FeedEventBlobFinalized  = "blob_finalized"
+
+	// feedPollBatches is the number of latest batches queried at each poll, which bounds the number of batches
+	// confirmed between two polls which are published
+	feedPollBatches = 50
+	// maxPendingFinalizationBatches bounds the number of confirmed batches whose blobs are watched until they are
+	// finalized. The blobs of the oldest batches aren't published as finalized once it is exceeded.
+	maxPendingFinalizationBatches = 200
+	// feedSubscriptionBuffer is the number of events buffered for a subscriber. The subscribers which fall behind
+	// by more are disconnected, and have to reconnect.
+	feedSubscriptionBuffer = 1024
+	// feedHeartbeatInterval is the interval at which a comment is sent to the idle streams, so that the proxies
+	// don't close them
+	feedHeartbeatInterval = 15 * time.Second
+	// feedWriteTimeout bounds the writes of an event to a stream
+	feedWriteTimeout = 10 * time.Second
+)
+
+var feedEventTypes = []string{FeedEventBatchConfirmed, FeedEventBlobConfirmed, FeedEventBlobFinalized}
+
+type (
+	BatchConfirmationResponse struct {
+		BatchHeaderHash string `json:"batch_header_hash"`
+		BatchId         uint64 `json:"batch_id"`
+		BlockNumber     uint64 `json:"block_number"`
+		BlockTimestamp  uint64 `json:"block_timestamp"`
+		TxHash          string `json:"tx_hash"`
+		NumBlobs        int    `json:"num_blobs"`
+	}
+
+	// FeedEvent is an event of the confirmation feed. Its ID is the batch header hash of the batch events, and the
+	// blob key of the blob events.
+	FeedEvent struct {
+		Id   string
+		Type string
+		// Data is a *BatchConfirmationResponse for the batch events, and a *BlobMetadataResponse for the blob events
+		Data any
+	}
+
+	// feedSubscription receives the events of the selected types until it is closed
+	feedSubscription struct {
+		events chan *FeedEvent
+		types  map[string]bool
+	}
+
+	// pendingBatch is a confirmed batch whose blobs aren't all finalized yet
+	pendingBatch struct {
+		batchHeaderHash [32]byte
+		// confirmed are the keys of the blobs which aren't finalized yet
+		confirmed map[string]struct{}
+	}
+
+	// confirmationFeed polls the subgraph for the newly confirmed batches and the blob store for their blobs, and
+	// publishes the confirmations of the batches and the confirmations and finalizations of their blobs to the
+	// subscribers. Only the events which happen while the feed runs are published.
+	confirmationFeed struct {
+		subgraphClient SubgraphClient
+		blobstore      disperser.BlobStore
+		logger         logging.Logger
+
+		// lastBatchId is the ID of the last published batch, and initialized is whether it is set
+		initialized bool
+		lastBatchId uint64
+		pending     []*pendingBatch
+
+		mu            sync.Mutex
+		subscriptions map[*feedSubscription]struct{}
+		stopped       bool
+	}
+)
+
+func newConfirmationFeed(subgraphClient SubgraphClient, blobstore disperser.BlobStore, logger logging.Logger) *confirmationFeed {
+	return &confirmationFeed{
+		subgraphClient: subgraphClient,
+		blobstore:      blobstore,
+		logger:         logger.With("component", "ConfirmationFeed"),
+		subscriptions:  make(map[*feedSubscription]struct{}),
+	}
+}
+
+// Start polls at each interval until the context is done, and then closes the subscriptions
+func (f *confirmationFeed) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := f.poll(ctx); err != nil && ctx.Err() == nil {
+				f.logger.Error("failed to poll the confirmations", "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				f.stop()
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// parseFeedEventTypes parses the comma separated list of event types of the events query parameter
+func parseFeedEventTypes(events string) ([]string, error) {
+	if events == "" {
+		return nil, nil
+	}
+	types := make([]string, 0)
+	for _, typ := range strings.Split(events, ",") {
+		typ = strings.TrimSpace(typ)
+		if !slices.Contains(feedEventTypes, typ) {
+			return nil, fmt.Errorf("Invalid 'events' parameter. The event types are %s", strings.Join(feedEventTypes, ", "))
+		}
+		types = append(types, typ)
+	}
+	return types, nil
+}
+
+// streamFeedEvents writes the events of the subscription to the response as server-sent events until the client
+// disconnects or the subscription is closed
+func streamFeedEvents(c *gin.Context, subscription *feedSubscription) {
+	c.Writer.Header().Set("Content-Type", "text/event-stream")
+	c.Writer.Header().Set(cacheControlParam, "no-cache")
+	// Disable the buffering of the responses by nginx
+	c.Writer.Header().Set("X-Accel-Buffering", "no")
+	c.Status(http.StatusOK)
+	c.Writer.Flush()
+
+	ctx := c.Request.Context()
+	controller, _ := ctx.Value(responseControllerKey{}).(*http.ResponseController)
+	heartbeat := time.NewTicker(feedHeartbeatInterval)
+	defer heartbeat.Stop()
+	c.Stream(func(w io.Writer) bool {
+		// The write timeout of the server would otherwise close the stream
+		if controller != nil {
+			_ = controller.SetWriteDeadline(time.Now().Add(feedHeartbeatInterval + feedWriteTimeout))
+		}
+		select {
+		case <-ctx.Done():
+			return false
+		case event, ok := <-subscription.events:
+			if !ok {
+				return false
+			}
+			c.Render(-1, sse.Event{Id: event.Id, Event: event.Type, Data: event.Data})
+			return true
+		case <-heartbeat.C:
+			_, err := io.WriteString(w, ": heartbeat\n\n")
+			return err == nil
+		}
+	})
+}
+
+// subscribe returns a subscription to the events of the types, or to all the events if there are none. It returns
+// nil if the feed is stopped.
+func (f *confirmationFeed) subscribe(types []string) *feedSubscription {
+	subscription := &feedSubscription{
+		events: make(chan *FeedEvent, feedSubscriptionBuffer),
+		types:  make(map[string]bool),
+	}
+	if len(types) == 0 {
+		types = feedEventTypes
+	}
+	for _, typ := range types {
+		subscription.types[typ] = true
+	}
+
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if f.stopped {
+		return nil
+	}
+	f.subscriptions[subscription] = struct{}{}
+	return subscription
+}
+
+// unsubscribe closes the subscription if it isn't closed yet
+func (f *confirmationFeed) unsubscribe(subscription *feedSubscription) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	if _, ok := f.subscriptions[subscription]; ok {
+		delete(f.subscriptions, subscription)
+		close(subscription.events)
+	}
+}
+
+func (f *confirmationFeed) stop() {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	f.stopped = true
+	for subscription := range f.subscriptions {
+		delete(f.subscriptions, subscription)
+		close(subscription.events)
+	}
+}
+
+func (f *confirmationFeed) publish(event *FeedEvent) {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	for subscription := range f.subscriptions {
+		if !subscription.types[event.Type] {
+			continue
+		}
+		select {
+		case subscription.events <- event:
+		default:
+			f.logger.Warn("disconnecting a subscriber of the confirmation feed which fell behind")
+			delete(f.subscriptions, subscription)
+			close(subscription.events)
+		}
+	}
+}
+
+// poll publishes the batches confirmed since the last poll and their blobs, and the blobs of the pending batches
+// finalized since. The first poll only records the last confirmed batch.
+func (f *confirmationFeed) poll(ctx context.Context) error {
+	batches, err := f.subgraphClient.QueryBatchesWithLimit(ctx, feedPollBatches, 0)
+	if err != nil {
+		return fmt.Errorf("failed to query the latest batches: %w", err)
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].BatchId < batches[j].BatchId
+	})
+	if !f.initialized {
+		if len(batches) > 0 {
+			f.lastBatchId = batches[len(batches)-1].BatchId
+		}
+		f.initialized = true
+		return nil
+	}
+
+	if err := f.pollFinalizations(ctx); err != nil {
+		return err
+	}
+	for _, batch := range batches {
+		if batch.BatchId <= f.lastBatchId {
+			continue
+		}
+		if err := f.publishBatch(ctx, batch); err != nil {
+			// The batch is published again by the next poll
+			return err
+		}
+		f.lastBatchId = batch.BatchId
+	}
+	return nil
+}
+
+// publishBatch publishes the confirmation of the batch and of its blobs, and watches the blobs which aren't
+// finalized yet
+func (f *confirmationFeed) publishBatch(ctx context.Context, batch *Batch) error {
+	batchHeaderHash, err := ConvertHexadecimalToBytes(batch.BatchHeaderHash)
+	if err != nil {
+		f.logger.Warn("skipping batch with an invalid header hash", "batchHeaderHash", string(batch.BatchHeaderHash), "err", err)
+		return nil
+	}
+	metadatas, err := f.blobstore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil && !errors.Is(err, disperser.ErrMetadataNotFound) {
+		return fmt.Errorf("failed to get the blobs of batch %s: %w", batch.BatchHeaderHash, err)
+	}
+	sort.Slice(metadatas, func(i, j int) bool {
+		if metadatas[i].ConfirmationInfo == nil || metadatas[j].ConfirmationInfo == nil {
+			return metadatas[j].ConfirmationInfo == nil && metadatas[i].ConfirmationInfo != nil
+		}
+		return metadatas[i].ConfirmationInfo.BlobIndex < metadatas[j].ConfirmationInfo.BlobIndex
+	})
+
+	hashHex := hex.EncodeToString(batchHeaderHash[:])
+	f.publish(&FeedEvent{
+		Id:   hashHex,
+		Type: FeedEventBatchConfirmed,
+		Data: &BatchConfirmationResponse{
+			BatchHeaderHash: hashHex,
+			BatchId:         batch.BatchId,
+			BlockNumber:     batch.BlockNumber,
+			BlockTimestamp:  batch.BlockTimestamp,
+			TxHash:          string(batch.TxHash),
+			NumBlobs:        len(metadatas),
+		},
+	})
+
+	pending := &pendingBatch{batchHeaderHash: batchHeaderHash, confirmed: make(map[string]struct{})}
+	for _, metadata := range metadatas {
+		blob, err := convertMetadataToBlobMetadataResponse(metadata)
+		if err != nil {
+			f.logger.Warn("skipping blob of the confirmation feed", "blobKey", metadata.GetBlobKey().String(), "err", err)
+			continue
+		}
+		f.publish(&FeedEvent{Id: blob.BlobKey, Type: FeedEventBlobConfirmed, Data: blob})
+		switch metadata.BlobStatus {
+		case disperser.Finalized:
+			f.publish(&FeedEvent{Id: blob.BlobKey, Type: FeedEventBlobFinalized, Data: blob})
+		case disperser.Confirmed:
+			pending.confirmed[blob.BlobKey] = struct{}{}
+		}
+	}
+	if len(pending.confirmed) > 0 {
+		f.pending = append(f.pending, pending)
+		if len(f.pending) > maxPendingFinalizationBatches {
+			f.pending = f.pending[len(f.pending)-maxPendingFinalizationBatches:]
+		}
+	}
+	return nil
+}
+
+// pollFinalizations publishes the blobs of the pending batches which were finalized since the last poll
+func (f *confirmationFeed) pollFinalizations(ctx context.Context) error {
+	pending := make([]*pendingBatch, 0, len(f.pending))
+	for i, batch := range f.pending {
+		metadatas, err := f.blobstore.GetAllBlobMetadataByBatch(ctx, batch.batchHeaderHash)
+		if err != nil && !errors.Is(err, disperser.ErrMetadataNotFound) {
+			f.pending = append(pending, f.pending[i:]...)
+			return fmt.Errorf("failed to get the blobs of batch %x: %w", batch.batchHeaderHash, err)
+		}
+		if len(metadatas) == 0 {
+			// The metadata of the batch expired before its blobs were finalized
+			continue
+		}
+		for _, metadata := range metadatas {
+			blobKey := metadata.GetBlobKey().String()
+			if _, ok := batch.confirmed[blobKey]; !ok || metadata.BlobStatus == disperser.Confirmed {
+				continue
+			}
+			delete(batch.confirmed, blobKey)
+			if metadata.BlobStatus != disperser.Finalized {
+				continue
+			}
+			blob, err := convertMetadataToBlobMetadataResponse(metadata)
+			if err != nil {
+				f.logger.Warn("skipping blob of the confirmation feed", "blobKey", blobKey, "err", err)
+				continue
+			}
+			f.publish(&FeedEvent{Id: blobKey, Type: FeedEventBlobFinalized, Data: blob})
+		}
+		if len(batch.confirmed) > 0 {
+			pending = append(pending, batch)
+		}
+	}
+	f.pending = pending
+	return nil
+}
@@ -0,0 +1,253 @@
+package dataapi_test
+
+import (
+	"bufio"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+// batchSource is a subgraph client which only serves the latest batches
+type batchSource struct {
+	dataapi.SubgraphClient
+
+	mu         sync.Mutex
+	batches    []*dataapi.Batch
+	numQueries int
+}
+
+func (s *batchSource) QueryBatchesWithLimit(ctx context.Context, limit, skip int) ([]*dataapi.Batch, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.numQueries++
+	return append([]*dataapi.Batch{}, s.batches...), nil
+}
+
+func (s *batchSource) addBatch(batch *dataapi.Batch) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.batches = append(s.batches, batch)
+}
+
+func (s *batchSource) queried() bool {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.numQueries > 0
+}
+
+type streamEvent struct {
+	id   string
+	typ  string
+	data string
+}
+
+// readStreamEvent reads the next event of the stream, skipping the comments
+func readStreamEvent(t *testing.T, reader *bufio.Reader) streamEvent {
+	var event streamEvent
+	for {
+		line, err := reader.ReadString('\n')
+		require.NoError(t, err)
+		line = strings.TrimRight(line, "\n")
+		if line == "" {
+			if event.typ != "" {
+				return event
+			}
+			continue
+		}
+		field, value, _ := strings.Cut(line, ":")
+		switch field {
+		case "id":
+			event.id = value
+		case "event":
+			event.typ = value
+		case "data":
+			event.data = value
+		}
+	}
+}
+
+func TestConfirmationStream(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	store := inmem.NewBlobStore()
+	source := &batchSource{batches: []*dataapi.Batch{{BatchId: 1, BatchHeaderHash: []byte(hex.EncodeToString(make([]byte, 32)))}}}
+	streamConfig := config
+	streamConfig.ConfirmationStreamPollInterval = 20 * time.Millisecond
+	server := dataapi.NewServer(streamConfig, store, prometheusClient, source, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPNilConnection{}, nil, nil)
+	defer func() { assert.NoError(t, server.Shutdown()) }()
+
+	r := setUpRouter()
+	r.GET("/v1/feed/stream", server.FetchConfirmationStreamHandler)
+	httpServer := httptest.NewServer(r)
+	defer httpServer.Close()
+	client := &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+
+	// The event types are validated
+	res, err := client.Get(httpServer.URL + "/v1/feed/stream?events=batch_confirmed,unknown")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusBadRequest, res.StatusCode)
+	res.Body.Close()
+
+	// The batches confirmed before the stream is started aren't published
+	assert.Eventually(t, source.queried, 5*time.Second, 10*time.Millisecond)
+	res, err = client.Get(httpServer.URL + "/v1/feed/stream")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	reader := bufio.NewReader(res.Body)
+
+	batchHeaderHash := [32]byte{2}
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, store)
+	markBlobConfirmed(t, &blob, key, 0, batchHeaderHash, store)
+	source.addBatch(&dataapi.Batch{
+		BatchId:         2,
+		BatchHeaderHash: []byte(hex.EncodeToString(batchHeaderHash[:])),
+		BlockNumber:     100,
+		BlockTimestamp:  1700000000,
+		TxHash:          []byte("0x123"),
+	})
+
+	event := readStreamEvent(t, reader)
+	assert.Equal(t, dataapi.FeedEventBatchConfirmed, event.typ)
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), event.id)
+	var batch dataapi.BatchConfirmationResponse
+	require.NoError(t, json.Unmarshal([]byte(event.data), &batch))
+	assert.Equal(t, dataapi.BatchConfirmationResponse{
+		BatchHeaderHash: hex.EncodeToString(batchHeaderHash[:]),
+		BatchId:         2,
+		BlockNumber:     100,
+		BlockTimestamp:  1700000000,
+		TxHash:          "0x123",
+		NumBlobs:        1,
+	}, batch)
+
+	event = readStreamEvent(t, reader)
+	assert.Equal(t, dataapi.FeedEventBlobConfirmed, event.typ)
+	assert.Equal(t, key.String(), event.id)
+	var metadata dataapi.BlobMetadataResponse
+	require.NoError(t, json.Unmarshal([]byte(event.data), &metadata))
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), metadata.BatchHeaderHash)
+
+	// The blobs of the confirmed batches are published again once finalized
+	require.NoError(t, store.MarkBlobFinalized(context.Background(), key))
+	event = readStreamEvent(t, reader)
+	assert.Equal(t, dataapi.FeedEventBlobFinalized, event.typ)
+	assert.Equal(t, key.String(), event.id)
+}
+
+func TestConfirmationStreamWithFieldSelection(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	store := inmem.NewBlobStore()
+	source := &batchSource{batches: []*dataapi.Batch{{BatchId: 1, BatchHeaderHash: []byte(hex.EncodeToString(make([]byte, 32)))}}}
+	streamConfig := config
+	streamConfig.ConfirmationStreamPollInterval = 20 * time.Millisecond
+	server, err := dataapi.NewMultiNetworkServer(streamConfig, []dataapi.Network{
+		{
+			Name:              "mainnet",
+			BlobStore:         store,
+			PromClient:        prometheusClient,
+			SubgraphClient:    source,
+			Transactor:        mockTx,
+			ChainState:        mockChainState,
+			IndexedChainState: mockIndexedChainState,
+		},
+	}, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPNilConnection{}, nil)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, server.Shutdown()) }()
+
+	httpServer := httptest.NewServer(server.Router())
+	defer httpServer.Close()
+	client := &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+
+	// The streamed events aren't buffered to select their fields, and they are received as they are compressed
+	assert.Eventually(t, source.queried, 5*time.Second, 10*time.Millisecond)
+	res, err := client.Get(httpServer.URL + "/api/v1/feed/stream?events=batch_confirmed&fields=batch_id")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, "text/event-stream", res.Header.Get("Content-Type"))
+	assert.True(t, res.Uncompressed)
+	reader := bufio.NewReader(res.Body)
+
+	batchHeaderHash := [32]byte{3}
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, store)
+	markBlobConfirmed(t, &blob, key, 0, batchHeaderHash, store)
+	source.addBatch(&dataapi.Batch{
+		BatchId:         2,
+		BatchHeaderHash: []byte(hex.EncodeToString(batchHeaderHash[:])),
+		BlockNumber:     100,
+		BlockTimestamp:  1700000000,
+		TxHash:          []byte("0x123"),
+	})
+
+	event := readStreamEvent(t, reader)
+	assert.Equal(t, dataapi.FeedEventBatchConfirmed, event.typ)
+	var batch dataapi.BatchConfirmationResponse
+	require.NoError(t, json.Unmarshal([]byte(event.data), &batch))
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), batch.BatchHeaderHash)
+	assert.Equal(t, uint64(2), batch.BatchId)
+}
+
+func TestPublicConfirmationStreamLimit(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	streamConfig := config
+	streamConfig.ConfirmationStreamPollInterval = 20 * time.Millisecond
+	streamConfig.InternalSocketAddr = ":8081"
+	streamConfig.PublicMaxStreamsPerClient = 1
+	server, err := dataapi.NewMultiNetworkServer(streamConfig, []dataapi.Network{
+		{
+			Name:              "mainnet",
+			BlobStore:         inmem.NewBlobStore(),
+			PromClient:        prometheusClient,
+			SubgraphClient:    &batchSource{},
+			Transactor:        mockTx,
+			ChainState:        mockChainState,
+			IndexedChainState: mockIndexedChainState,
+		},
+	}, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPNilConnection{}, nil)
+	require.NoError(t, err)
+	defer func() { assert.NoError(t, server.Shutdown()) }()
+
+	public, err := server.PublicRouter()
+	require.NoError(t, err)
+	httpServer := httptest.NewServer(public)
+	defer httpServer.Close()
+	client := &http.Client{Timeout: 10 * time.Second, Transport: &http.Transport{DisableKeepAlives: true}}
+
+	// The stream is served on the public listener, but each client may only keep one open, on any network
+	res, err := client.Get(httpServer.URL + "/api/v1/feed/stream")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	rejected, err := client.Get(httpServer.URL + "/mainnet/api/v1/feed/stream")
+	require.NoError(t, err)
+	assert.Equal(t, http.StatusTooManyRequests, rejected.StatusCode)
+	rejected.Body.Close()
+
+	// The stream can be reopened once it is closed
+	res.Body.Close()
+	assert.Eventually(t, func() bool {
+		res, err := client.Get(httpServer.URL + "/api/v1/feed/stream")
+		if err != nil {
+			return false
+		}
+		defer res.Body.Close()
+		return res.StatusCode == http.StatusOK
+	}, 5*time.Second, 20*time.Millisecond)
+}
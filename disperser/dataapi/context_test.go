@@ -0,0 +1,79 @@
+package dataapi_test
+
+import (
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// contextPackages are the packages whose operator probes and scans must be canceled with the request
+var contextPackages = []string{".", "./subgraph", "../common/semver"}
+
+// TestNoDroppedContexts checks that the functions which are given a context don't create a new one with
+// context.Background or context.TODO, which would keep their probes running after the request is canceled
+func TestNoDroppedContexts(t *testing.T) {
+	fset := token.NewFileSet()
+	for _, dir := range contextPackages {
+		files, err := filepath.Glob(filepath.Join(dir, "*.go"))
+		require.NoError(t, err)
+		for _, path := range files {
+			if strings.HasSuffix(path, "_test.go") {
+				continue
+			}
+			src, err := os.ReadFile(path)
+			require.NoError(t, err)
+			file, err := parser.ParseFile(fset, path, src, 0)
+			require.NoError(t, err)
+
+			for _, decl := range file.Decls {
+				fn, ok := decl.(*ast.FuncDecl)
+				if !ok || fn.Body == nil || !hasContextParam(fn.Type) {
+					continue
+				}
+				ast.Inspect(fn.Body, func(node ast.Node) bool {
+					call, ok := node.(*ast.CallExpr)
+					if !ok {
+						return true
+					}
+					if name := selectorName(call.Fun); name == "context.Background" || name == "context.TODO" {
+						assert.Failf(t, "dropped context", "%s: %s calls %s instead of using its context", fset.Position(call.Pos()), fn.Name.Name, name)
+					}
+					return true
+				})
+			}
+		}
+	}
+}
+
+// hasContextParam returns whether the function is given a context.Context or a *gin.Context
+func hasContextParam(fnType *ast.FuncType) bool {
+	for _, param := range fnType.Params.List {
+		typ := param.Type
+		if star, ok := typ.(*ast.StarExpr); ok {
+			typ = star.X
+		}
+		if name := selectorName(typ); name == "context.Context" || name == "gin.Context" {
+			return true
+		}
+	}
+	return false
+}
+
+func selectorName(expr ast.Expr) string {
+	sel, ok := expr.(*ast.SelectorExpr)
+	if !ok {
+		return ""
+	}
+	pkg, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	return pkg.Name + "." + sel.Sel.Name
+}
@@ -0,0 +1,110 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+)
+
+var errUnknownQuorum = errors.New("unknown quorum")
+
+type (
+	QuorumDispersalCost struct {
+		QuorumId              core.QuorumID `json:"quorum_id"`
+		Required              bool          `json:"required"`
+		AdversaryThreshold    uint8         `json:"adversary_threshold"`
+		ConfirmationThreshold uint8         `json:"confirmation_threshold"`
+		NumOperators          int           `json:"num_operators"`
+		// ChunkLength is the number of symbols of each chunk
+		ChunkLength uint `json:"chunk_length"`
+		NumChunks   uint `json:"num_chunks"`
+		// EncodedSize is the number of bytes of the chunks stored by the operators of the quorum
+		EncodedSize uint `json:"encoded_size"`
+	}
+
+	DispersalCostEstimateResponse struct {
+		BlockNumber uint32 `json:"block_number"`
+		// BlobSize is the size of the blob in bytes, and BlobLength its number of symbols once padded
+		BlobSize   uint                   `json:"blob_size"`
+		BlobLength uint                   `json:"blob_length"`
+		Quorums    []*QuorumDispersalCost `json:"quorums"`
+		// TotalEncodedSize is the number of bytes stored by the operators of all the quorums
+		TotalEncodedSize uint `json:"total_encoded_size"`
+	}
+)
+
+// estimateDispersalCost estimates the chunks stored by the operators for a blob of the given size dispersed to the
+// quorums, from the security parameters and the stakes of the quorums at the current block. The chunk length is the
+// largest one allowed by the protocol, which the disperser picks when it isn't given a target number of chunks.
+// If no quorum is given, the blob is dispersed to the required quorums.
+func (s *server) estimateDispersalCost(ctx context.Context, blobSize uint, quorumIDs []core.QuorumID) (*DispersalCostEstimateResponse, error) {
+	blockNumber, err := s.transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	securityParams, err := s.transactor.GetQuorumSecurityParams(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get quorum security params: %w", err)
+	}
+	requiredQuorums, err := s.transactor.GetRequiredQuorumNumbers(ctx, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get required quorums: %w", err)
+	}
+	required := make(map[core.QuorumID]bool, len(requiredQuorums))
+	for _, quorumID := range requiredQuorums {
+		required[quorumID] = true
+	}
+	if len(quorumIDs) == 0 {
+		quorumIDs = requiredQuorums
+	}
+	for _, quorumID := range quorumIDs {
+		if int(quorumID) >= len(securityParams) {
+			return nil, fmt.Errorf("%w: %d", errUnknownQuorum, quorumID)
+		}
+	}
+
+	state, err := s.chainState.GetOperatorState(ctx, uint(blockNumber), quorumIDs)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state: %w", err)
+	}
+
+	blobLength := encoding.GetBlobLength(blobSize)
+	response := &DispersalCostEstimateResponse{
+		BlockNumber: blockNumber,
+		BlobSize:    blobSize,
+		BlobLength:  blobLength,
+		Quorums:     make([]*QuorumDispersalCost, 0, len(quorumIDs)),
+	}
+	coordinator := &core.StdAssignmentCoordinator{}
+	for _, quorumID := range quorumIDs {
+		param := securityParams[quorumID]
+		chunkLength, err := coordinator.CalculateChunkLength(state, blobLength, 0, &param)
+		if err != nil {
+			return nil, fmt.Errorf("failed to calculate chunk length of quorum %d: %w", quorumID, err)
+		}
+		_, info, err := coordinator.GetAssignments(state, blobLength, &core.BlobQuorumInfo{
+			SecurityParam: param,
+			ChunkLength:   chunkLength,
+		})
+		if err != nil {
+			return nil, fmt.Errorf("failed to get assignments of quorum %d: %w", quorumID, err)
+		}
+
+		encodedSize := info.TotalChunks * chunkLength * encoding.BYTES_PER_SYMBOL
+		response.Quorums = append(response.Quorums, &QuorumDispersalCost{
+			QuorumId:              quorumID,
+			Required:              required[quorumID],
+			AdversaryThreshold:    param.AdversaryThreshold,
+			ConfirmationThreshold: param.ConfirmationThreshold,
+			NumOperators:          len(state.Operators[quorumID]),
+			ChunkLength:           chunkLength,
+			NumChunks:             info.TotalChunks,
+			EncodedSize:           encodedSize,
+		})
+		response.TotalEncodedSize += encodedSize
+	}
+	return response, nil
+}
@@ -0,0 +1,70 @@
+package dataapi_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+)
+
+func getDispersalCostEstimate(t *testing.T, query string) (int, *dataapi.DispersalCostEstimateResponse) {
+	tx := &coremock.MockTransactor{}
+	tx.On("GetCurrentBlockNumber").Return(uint32(10), nil)
+	tx.On("GetQuorumSecurityParams").Return([]core.SecurityParam{
+		{QuorumID: 0, AdversaryThreshold: 33, ConfirmationThreshold: 55},
+		{QuorumID: 1, AdversaryThreshold: 50, ConfirmationThreshold: 100},
+	}, nil)
+	tx.On("GetRequiredQuorumNumbers").Return([]uint8{0}, nil)
+	server := dataapi.NewServer(config, blobstore, prometheusClient, subgraphClient, tx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+
+	r := setUpRouter()
+	r.GET("/v1/cost-estimate", server.EstimateDispersalCostHandler)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/cost-estimate"+query, nil)
+	r.ServeHTTP(w, req)
+
+	var response dataapi.DispersalCostEstimateResponse
+	if w.Code == http.StatusOK {
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	}
+	return w.Code, &response
+}
+
+func TestEstimateDispersalCost(t *testing.T) {
+	// The required quorums by default
+	code, response := getDispersalCostEstimate(t, "?size=1000")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, uint32(10), response.BlockNumber)
+	assert.Equal(t, uint(1000), response.BlobSize)
+	assert.Equal(t, uint(32), response.BlobLength)
+	assert.Len(t, response.Quorums, 1)
+	quorum := response.Quorums[0]
+	assert.Equal(t, core.QuorumID(0), quorum.QuorumId)
+	assert.True(t, quorum.Required)
+	assert.Equal(t, uint8(33), quorum.AdversaryThreshold)
+	assert.Equal(t, uint8(55), quorum.ConfirmationThreshold)
+	assert.Equal(t, 2, quorum.NumOperators)
+	assert.Greater(t, quorum.NumChunks, uint(0))
+	assert.Equal(t, quorum.NumChunks*quorum.ChunkLength*32, quorum.EncodedSize)
+	// The chunks hold at least the blob encoded at the rate of the quorum
+	assert.GreaterOrEqual(t, quorum.EncodedSize, uint(1000*100/(55-33)))
+	assert.Equal(t, quorum.EncodedSize, response.TotalEncodedSize)
+
+	code, response = getDispersalCostEstimate(t, "?size=1000&quorums=0,1")
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, response.Quorums, 2)
+	assert.False(t, response.Quorums[1].Required)
+	assert.Equal(t, response.Quorums[0].EncodedSize+response.Quorums[1].EncodedSize, response.TotalEncodedSize)
+
+	code, _ = getDispersalCostEstimate(t, "?size=1000&quorums=2")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = getDispersalCostEstimate(t, "?size=0")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = getDispersalCostEstimate(t, "?size=1000&quorums=a")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
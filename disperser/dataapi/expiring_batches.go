@@ -0,0 +1,135 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+)
+
+const (
+	// secondsPerBlock is the block time from which the operators compute when they may prune a batch
+	secondsPerBlock = 12
+
+	defaultExpiringBatchesHours = 24
+	maxExpiringBatchesHours     = 7 * 24
+	defaultExpiringBatchesLimit = 100
+	maxExpiringBatchesLimit     = 1000
+)
+
+type (
+	ExpiringBlob struct {
+		BlobKey   string `json:"blob_key"`
+		BlobIndex uint32 `json:"blob_index"`
+		BlobSize  uint   `json:"blob_size"`
+		AccountId string `json:"account_id"`
+	}
+
+	ExpiringBatch struct {
+		BatchHeaderHash       string `json:"batch_header_hash"`
+		BatchId               uint64 `json:"batch_id"`
+		ConfirmationBlock     uint64 `json:"confirmation_block_number"`
+		ConfirmationTimestamp uint64 `json:"confirmation_timestamp"`
+		// ExpiresAt is the unix time in seconds from which the operators may prune the batch. It is estimated from the
+		// confirmation of the batch, before which the operators can't have received it.
+		ExpiresAt int64           `json:"expires_at"`
+		Blobs     []*ExpiringBlob `json:"blobs"`
+	}
+
+	ExpiringBatchesResponse struct {
+		Meta Meta `json:"meta"`
+		// StoreDurationBlocks is the number of blocks for which the operators must store the batches
+		StoreDurationBlocks uint32           `json:"store_duration_blocks"`
+		Data                []*ExpiringBatch `json:"data"`
+	}
+)
+
+// getExpiringBatches returns the earliest limit batches which the operators may prune within the next hours, with
+// their blobs. The blobs are filtered by account if accountId isn't empty, and the batches left without blobs are
+// omitted.
+func (s *server) getExpiringBatches(ctx context.Context, now time.Time, hours int, limit int, accountId string) (*ExpiringBatchesResponse, error) {
+	storeDurationBlocks, err := s.transactor.GetStoreDurationBlocks(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the store duration: %w", err)
+	}
+	retention := int64(storeDurationBlocks) * secondsPerBlock
+
+	// The batches expiring within the window are those confirmed a retention before it
+	start := now.Unix() - retention
+	end := start + int64(hours)*int64(time.Hour/time.Second)
+	if end < 0 {
+		return &ExpiringBatchesResponse{StoreDurationBlocks: storeDurationBlocks, Data: []*ExpiringBatch{}}, nil
+	}
+	batches, err := s.subgraphClient.QueryBatchesByBlockTimestampRange(ctx, uint64(max(start, 0)), uint64(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the batches confirmed between %d and %d: %w", start, end, err)
+	}
+	sort.Slice(batches, func(i, j int) bool {
+		return batches[i].BlockTimestamp < batches[j].BlockTimestamp
+	})
+
+	expiring := make([]*ExpiringBatch, 0)
+	for _, batch := range batches {
+		if len(expiring) == limit {
+			break
+		}
+		expiringBatch, err := s.getExpiringBatch(ctx, batch, retention, accountId)
+		if err != nil {
+			return nil, err
+		}
+		if expiringBatch == nil || (accountId != "" && len(expiringBatch.Blobs) == 0) {
+			continue
+		}
+		expiring = append(expiring, expiringBatch)
+	}
+	return &ExpiringBatchesResponse{
+		Meta:                Meta{Size: len(expiring)},
+		StoreDurationBlocks: storeDurationBlocks,
+		Data:                expiring,
+	}, nil
+}
+
+// getExpiringBatch returns the batch with its blobs of the account, or nil if the header hash of the batch is invalid
+func (s *server) getExpiringBatch(ctx context.Context, batch *Batch, retention int64, accountId string) (*ExpiringBatch, error) {
+	batchHeaderHash, err := ConvertHexadecimalToBytes(batch.BatchHeaderHash)
+	if err != nil {
+		s.logger.Warn("skipping batch with an invalid header hash", "batchHeaderHash", string(batch.BatchHeaderHash), "err", err)
+		return nil, nil
+	}
+	metadatas, err := s.blobstore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil && !errors.Is(err, disperser.ErrMetadataNotFound) {
+		return nil, fmt.Errorf("failed to get the blobs of batch %s: %w", batch.BatchHeaderHash, err)
+	}
+
+	blobs := make([]*ExpiringBlob, 0, len(metadatas))
+	for _, metadata := range metadatas {
+		if accountId != "" && metadata.RequestMetadata.AccountID != accountId {
+			continue
+		}
+		blob := &ExpiringBlob{
+			BlobKey:   metadata.GetBlobKey().String(),
+			BlobSize:  metadata.RequestMetadata.BlobSize,
+			AccountId: metadata.RequestMetadata.AccountID,
+		}
+		if metadata.ConfirmationInfo != nil {
+			blob.BlobIndex = metadata.ConfirmationInfo.BlobIndex
+		}
+		blobs = append(blobs, blob)
+	}
+	sort.Slice(blobs, func(i, j int) bool {
+		return blobs[i].BlobIndex < blobs[j].BlobIndex
+	})
+
+	return &ExpiringBatch{
+		BatchHeaderHash:       hex.EncodeToString(batchHeaderHash[:]),
+		BatchId:               batch.BatchId,
+		ConfirmationBlock:     batch.BlockNumber,
+		ConfirmationTimestamp: batch.BlockTimestamp,
+		ExpiresAt:             int64(batch.BlockTimestamp) + retention,
+		Blobs:                 blobs,
+	}, nil
+}
@@ -0,0 +1,115 @@
+package dataapi_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// batchRangeSource is a subgraph client which serves the batches confirmed within a range of timestamps
+type batchRangeSource struct {
+	dataapi.SubgraphClient
+
+	batches    []*dataapi.Batch
+	start, end uint64
+}
+
+func (s *batchRangeSource) QueryBatchesByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*dataapi.Batch, error) {
+	s.start, s.end = start, end
+	batches := make([]*dataapi.Batch, 0)
+	for _, batch := range s.batches {
+		if batch.BlockTimestamp >= start && batch.BlockTimestamp <= end {
+			batches = append(batches, batch)
+		}
+	}
+	return batches, nil
+}
+
+func TestFetchExpiringBatches(t *testing.T) {
+	store := inmem.NewBlobStore()
+	tx := &coremock.MockTransactor{}
+	storeDurationBlocks := uint32(100_800)
+	tx.On("GetStoreDurationBlocks").Return(storeDurationBlocks, nil)
+	retention := int64(storeDurationBlocks) * 12
+
+	// The first batch expires in an hour and the second in two days, while the third already expired
+	now := time.Now().Unix()
+	expiringHash, laterHash, expiredHash := [32]byte{1}, [32]byte{2}, [32]byte{3}
+	source := &batchRangeSource{batches: []*dataapi.Batch{
+		{BatchId: 2, BatchHeaderHash: []byte(hex.EncodeToString(laterHash[:])), BlockNumber: 200, BlockTimestamp: uint64(now - retention + 48*3600)},
+		{BatchId: 1, BatchHeaderHash: []byte(hex.EncodeToString(expiringHash[:])), BlockNumber: 100, BlockTimestamp: uint64(now - retention + 3600)},
+		{BatchId: 0, BatchHeaderHash: []byte(hex.EncodeToString(expiredHash[:])), BlockNumber: 50, BlockTimestamp: uint64(now - retention - 3600)},
+	}}
+	blob := makeTestBlob(0, 80)
+	blob.RequestHeader.AccountID = "0x1234"
+	key := queueBlob(t, &blob, store)
+	markBlobConfirmed(t, &blob, key, 0, expiringHash, store)
+	otherBlob := makeTestBlob(0, 80)
+	otherKey := queueBlob(t, &otherBlob, store)
+	markBlobConfirmed(t, &otherBlob, otherKey, 0, laterHash, store)
+
+	server := dataapi.NewServer(config, store, prometheusClient, source, tx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r := setUpRouter()
+	r.GET("/v1/feed/expiring-batches", server.FetchExpiringBatchesHandler)
+	fetch := func(query string) (int, *dataapi.ExpiringBatchesResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/feed/expiring-batches"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response dataapi.ExpiringBatchesResponse
+		require.NoError(t, json.Unmarshal(data, &response))
+		return res.StatusCode, &response
+	}
+
+	status, response := fetch("")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, storeDurationBlocks, response.StoreDurationBlocks)
+	assert.InDelta(t, now-retention, int64(source.start), 5)
+	assert.Equal(t, int64(24*3600), int64(source.end)-int64(source.start))
+	require.Equal(t, 1, response.Meta.Size)
+	batch := response.Data[0]
+	assert.Equal(t, hex.EncodeToString(expiringHash[:]), batch.BatchHeaderHash)
+	assert.Equal(t, uint64(100), batch.ConfirmationBlock)
+	assert.Equal(t, int64(batch.ConfirmationTimestamp)+retention, batch.ExpiresAt)
+	require.Len(t, batch.Blobs, 1)
+	assert.Equal(t, key.String(), batch.Blobs[0].BlobKey)
+	assert.Equal(t, "0x1234", batch.Blobs[0].AccountId)
+
+	// The batches are returned earliest expiring first
+	status, response = fetch("?hours=72")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 2, response.Meta.Size)
+	assert.Equal(t, uint64(1), response.Data[0].BatchId)
+	assert.Equal(t, uint64(2), response.Data[1].BatchId)
+	status, response = fetch("?hours=72&limit=1")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, uint64(1), response.Data[0].BatchId)
+
+	// The batches without blobs of the account are omitted
+	status, response = fetch("?hours=72&account_id=0x1234")
+	require.Equal(t, http.StatusOK, status)
+	require.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, uint64(1), response.Data[0].BatchId)
+
+	status, _ = fetch("?hours=0")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetch("?hours=169")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetch("?limit=1001")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
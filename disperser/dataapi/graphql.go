@@ -0,0 +1,551 @@
+package dataapi
+
+import (
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"math/big"
+	"net/http"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/gqlerrors"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/graphql-go/graphql/language/source"
+	"github.com/prometheus/client_golang/prometheus"
+)
+
+const (
+	defaultGraphQLMaxDepth      = 6
+	defaultGraphQLMaxComplexity = 1000
+
+	// maxGraphQLListLimit bounds the limit argument of the list fields
+	maxGraphQLListLimit = 1000
+	// maxGraphQLOperatorDays is the maximum number of days of the operators field, as for the REST API
+	maxGraphQLOperatorDays = 30
+)
+
+type GraphQLRequest struct {
+	Query         string                 `json:"query"`
+	OperationName string                 `json:"operationName"`
+	Variables     map[string]interface{} `json:"variables"`
+}
+
+// graphqlBatch is a batch in the GraphQL API. The onchain details of the batch are only known
+// when the batch is listed from the subgraph, otherwise they resolve to null.
+type graphqlBatch struct {
+	BatchHeaderHash [32]byte
+	Batch           *Batch
+}
+
+// graphqlQuorumStake is an entry of the total stake per quorum of a metric
+type graphqlQuorumStake struct {
+	QuorumID core.QuorumID
+	Stake    *big.Int
+}
+
+// GraphQLHandler godoc
+//
+//	@Summary	Query operators, batches, blobs and metrics with GraphQL
+//	@Tags		GraphQL
+//	@Accept		json
+//	@Produce	json
+//	@Param		request	body		GraphQLRequest	true	"GraphQL request"
+//	@Success	200		{object}	object
+//	@Failure	400		{object}	object	"errors: Bad request or query too deep or complex"
+//	@Router		/graphql [post]
+func (s *server) GraphQLHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("GraphQL", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	var req GraphQLRequest
+	if err := c.ShouldBindJSON(&req); err != nil {
+		s.metrics.IncrementFailedRequestNum("GraphQL")
+		graphqlErrorResponse(c, fmt.Errorf("invalid request: %w", err))
+		return
+	}
+	if err := checkGraphQLQueryLimits(req.Query, req.Variables, s.graphqlMaxDepth, s.graphqlMaxComplexity); err != nil {
+		s.metrics.IncrementFailedRequestNum("GraphQL")
+		graphqlErrorResponse(c, err)
+		return
+	}
+
+	result := graphql.Do(graphql.Params{
+		Schema:         *s.graphqlSchema,
+		RequestString:  req.Query,
+		VariableValues: req.Variables,
+		OperationName:  req.OperationName,
+		Context:        c.Request.Context(),
+	})
+	if result.HasErrors() {
+		s.metrics.IncrementFailedRequestNum("GraphQL")
+	} else {
+		s.metrics.IncrementSuccessfulRequestNum("GraphQL")
+	}
+	c.JSON(http.StatusOK, result)
+}
+
+func graphqlErrorResponse(c *gin.Context, err error) {
+	_ = c.Error(err)
+	c.JSON(http.StatusBadRequest, &graphql.Result{
+		Errors: []gqlerrors.FormattedError{gqlerrors.NewFormattedError(err.Error())},
+	})
+}
+
+// checkGraphQLQueryLimits rejects the queries whose selections are nested deeper than maxDepth or whose complexity
+// exceeds maxComplexity. Each selected field costs 1 plus the complexity of its selections, multiplied by its limit
+// argument if any, so that the complexity bounds the number of objects a query can fetch.
+func checkGraphQLQueryLimits(query string, variables map[string]interface{}, maxDepth, maxComplexity int) error {
+	doc, err := parser.Parse(parser.ParseParams{Source: source.NewSource(&source.Source{Body: []byte(query)})})
+	if err != nil {
+		return err
+	}
+
+	cost := &graphqlQueryCost{
+		fragments:     make(map[string]*ast.FragmentDefinition),
+		variables:     variables,
+		visiting:      make(map[string]bool),
+		maxComplexity: maxComplexity,
+	}
+	for _, definition := range doc.Definitions {
+		if fragment, ok := definition.(*ast.FragmentDefinition); ok {
+			cost.fragments[fragment.Name.Value] = fragment
+		}
+	}
+	for _, definition := range doc.Definitions {
+		operation, ok := definition.(*ast.OperationDefinition)
+		if !ok {
+			continue
+		}
+		depth, complexity, err := cost.selectionSet(operation.SelectionSet)
+		if err != nil {
+			return err
+		}
+		if depth > maxDepth {
+			return fmt.Errorf("query depth exceeds the maximum of %d", maxDepth)
+		}
+		if complexity > maxComplexity {
+			return fmt.Errorf("query complexity exceeds the maximum of %d", maxComplexity)
+		}
+	}
+	return nil
+}
+
+type graphqlQueryCost struct {
+	fragments map[string]*ast.FragmentDefinition
+	variables map[string]interface{}
+	// visiting are the fragments being expanded, to detect cycles
+	visiting map[string]bool
+	// maxComplexity is used to saturate the complexity so that deeply nested limits do not overflow
+	maxComplexity int
+}
+
+func (q *graphqlQueryCost) selectionSet(set *ast.SelectionSet) (depth int, complexity int, err error) {
+	if set == nil {
+		return 0, 0, nil
+	}
+	for _, selection := range set.Selections {
+		var d, c int
+		switch selection := selection.(type) {
+		case *ast.Field:
+			d, c, err = q.selectionSet(selection.SelectionSet)
+			if err != nil {
+				return 0, 0, err
+			}
+			d++
+			c = 1 + c*q.multiplier(selection)
+		case *ast.InlineFragment:
+			d, c, err = q.selectionSet(selection.SelectionSet)
+			if err != nil {
+				return 0, 0, err
+			}
+		case *ast.FragmentSpread:
+			name := selection.Name.Value
+			fragment, ok := q.fragments[name]
+			if !ok {
+				return 0, 0, fmt.Errorf("unknown fragment %q", name)
+			}
+			if q.visiting[name] {
+				return 0, 0, fmt.Errorf("cycle in fragment %q", name)
+			}
+			q.visiting[name] = true
+			d, c, err = q.selectionSet(fragment.SelectionSet)
+			delete(q.visiting, name)
+			if err != nil {
+				return 0, 0, err
+			}
+		}
+		depth = max(depth, d)
+		complexity = min(complexity+c, q.maxComplexity+1)
+	}
+	return depth, complexity, nil
+}
+
+// multiplier returns the limit argument of the field, or 1 if it has none
+func (q *graphqlQueryCost) multiplier(field *ast.Field) int {
+	for _, arg := range field.Arguments {
+		if arg.Name.Value != "limit" {
+			continue
+		}
+		var limit int
+		switch value := arg.Value.(type) {
+		case *ast.IntValue:
+			limit, _ = strconv.Atoi(value.Value)
+		case *ast.Variable:
+			switch v := q.variables[value.Name.Value].(type) {
+			case float64:
+				limit = int(v)
+			case int:
+				limit = v
+			}
+		}
+		if limit > 1 {
+			return min(limit, maxGraphQLListLimit)
+		}
+	}
+	return 1
+}
+
+func graphqlLimit(p graphql.ResolveParams) (int, error) {
+	limit, _ := p.Args["limit"].(int)
+	if limit <= 0 || limit > maxGraphQLListLimit {
+		return 0, fmt.Errorf("limit must be between 1 and %d", maxGraphQLListLimit)
+	}
+	return limit, nil
+}
+
+// graphqlTimeRange returns the start and end arguments, defaulting to the last hour as the REST API
+func graphqlTimeRange(p graphql.ResolveParams) (int64, int64) {
+	now := time.Now()
+	start, _ := p.Args["start"].(int)
+	end, _ := p.Args["end"].(int)
+	if start == 0 {
+		start = int(now.Add(-time.Hour).Unix())
+	}
+	if end == 0 {
+		end = int(now.Unix())
+	}
+	return int64(start), int64(end)
+}
+
+func parseGraphQLBatchHeaderHash(hash string) ([32]byte, error) {
+	bytes, err := hex.DecodeString(strings.TrimPrefix(hash, "0x"))
+	if err != nil || len(bytes) != 32 {
+		return [32]byte{}, fmt.Errorf("invalid batch header hash %q", hash)
+	}
+	var batchHeaderHash [32]byte
+	copy(batchHeaderHash[:], bytes)
+	return batchHeaderHash, nil
+}
+
+// onchainBatchField resolves a field of the onchain details of a batch, which are null if unknown
+func onchainBatchField(t graphql.Output, resolve func(*Batch) interface{}) *graphql.Field {
+	return &graphql.Field{
+		Type: t,
+		Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+			batch := p.Source.(*graphqlBatch)
+			if batch.Batch == nil {
+				return nil, nil
+			}
+			return resolve(batch.Batch), nil
+		},
+	}
+}
+
+// newGraphQLSchema builds the schema of the GraphQL API, which exposes the same data as the REST API
+// with the same field names, so that clients can fetch nested data in a single request.
+func (s *server) newGraphQLSchema() (graphql.Schema, error) {
+	securityParamType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SecurityParam",
+		Fields: graphql.Fields{
+			"quorum_id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*core.SecurityParam).QuorumID, nil
+			}},
+			"adversary_threshold": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*core.SecurityParam).AdversaryThreshold, nil
+			}},
+			"confirmation_threshold": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*core.SecurityParam).ConfirmationThreshold, nil
+			}},
+			"quorum_rate": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*core.SecurityParam).QuorumRate, nil
+			}},
+		},
+	})
+
+	// Blobs and batches reference each other, so their fields are defined lazily
+	var blobType, batchType *graphql.Object
+	blobType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Blob",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"blob_key":                  &graphql.Field{Type: graphql.String},
+				"batch_header_hash":         &graphql.Field{Type: graphql.String},
+				"blob_index":                &graphql.Field{Type: graphql.Int},
+				"signatory_record_hash":     &graphql.Field{Type: graphql.String},
+				"reference_block_number":    &graphql.Field{Type: graphql.Int},
+				"batch_root":                &graphql.Field{Type: graphql.String},
+				"blob_inclusion_proof":      &graphql.Field{Type: graphql.String},
+				"batch_id":                  &graphql.Field{Type: graphql.Int},
+				"confirmation_block_number": &graphql.Field{Type: graphql.Int},
+				"confirmation_txn_hash":     &graphql.Field{Type: graphql.String},
+				"fee":                       &graphql.Field{Type: graphql.String},
+				"security_params":           &graphql.Field{Type: graphql.NewList(securityParamType)},
+				"requested_at":              &graphql.Field{Type: graphql.Int},
+				"blob_status": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return p.Source.(*BlobMetadataResponse).BlobStatus.String(), nil
+				}},
+				"batch": &graphql.Field{Type: batchType, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					batchHeaderHash, err := parseGraphQLBatchHeaderHash(p.Source.(*BlobMetadataResponse).BatchHeaderHash)
+					if err != nil {
+						return nil, err
+					}
+					return &graphqlBatch{BatchHeaderHash: batchHeaderHash}, nil
+				}},
+			}
+		}),
+	})
+	batchType = graphql.NewObject(graphql.ObjectConfig{
+		Name: "Batch",
+		Fields: graphql.FieldsThunk(func() graphql.Fields {
+			return graphql.Fields{
+				"batch_header_hash": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					batch := p.Source.(*graphqlBatch)
+					return hex.EncodeToString(batch.BatchHeaderHash[:]), nil
+				}},
+				"batch_id":        onchainBatchField(graphql.Int, func(b *Batch) interface{} { return b.BatchId }),
+				"block_number":    onchainBatchField(graphql.Int, func(b *Batch) interface{} { return b.BlockNumber }),
+				"block_timestamp": onchainBatchField(graphql.Int, func(b *Batch) interface{} { return b.BlockTimestamp }),
+				"tx_hash":         onchainBatchField(graphql.String, func(b *Batch) interface{} { return string(b.TxHash) }),
+				"gas_used": onchainBatchField(graphql.String, func(b *Batch) interface{} {
+					if b.GasFees == nil {
+						return nil
+					}
+					return strconv.FormatUint(b.GasFees.GasUsed, 10)
+				}),
+				"gas_price": onchainBatchField(graphql.String, func(b *Batch) interface{} {
+					if b.GasFees == nil {
+						return nil
+					}
+					return strconv.FormatUint(b.GasFees.GasPrice, 10)
+				}),
+				"blobs": &graphql.Field{
+					Type: graphql.NewList(blobType),
+					Args: graphql.FieldConfigArgument{
+						"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+					},
+					Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+						limit, err := graphqlLimit(p)
+						if err != nil {
+							return nil, err
+						}
+						batch := p.Source.(*graphqlBatch)
+						blobs, _, err := s.getBlobsFromBatchHeaderHash(p.Context, batch.BatchHeaderHash, limit, nil)
+						if errors.Is(err, errNotFound) {
+							return []*BlobMetadataResponse{}, nil
+						}
+						return blobs, err
+					},
+				},
+			}
+		}),
+	})
+
+	operatorType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Operator",
+		Fields: graphql.Fields{
+			"operator_id":            &graphql.Field{Type: graphql.String},
+			"block_number":           &graphql.Field{Type: graphql.Int},
+			"socket":                 &graphql.Field{Type: graphql.String},
+			"is_online":              &graphql.Field{Type: graphql.Boolean},
+			"operator_process_error": &graphql.Field{Type: graphql.String},
+		},
+	})
+	operatorStateType := graphql.NewEnum(graphql.EnumConfig{
+		Name: "OperatorState",
+		Values: graphql.EnumValueConfigMap{
+			"REGISTERED":   &graphql.EnumValueConfig{Value: Registered},
+			"DEREGISTERED": &graphql.EnumValueConfig{Value: Deregistered},
+		},
+	})
+	operatorNonsigningType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "OperatorNonsigningPercentage",
+		Fields: graphql.Fields{
+			"operator_id":            &graphql.Field{Type: graphql.String},
+			"operator_address":       &graphql.Field{Type: graphql.String},
+			"quorum_id":              &graphql.Field{Type: graphql.Int},
+			"total_unsigned_batches": &graphql.Field{Type: graphql.Int},
+			"total_batches":          &graphql.Field{Type: graphql.Int},
+			"percentage":             &graphql.Field{Type: graphql.Float},
+			"stake_percentage":       &graphql.Field{Type: graphql.Float},
+		},
+	})
+
+	quorumStakeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "QuorumStake",
+		Fields: graphql.Fields{
+			"quorum_id": &graphql.Field{Type: graphql.Int, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphqlQuorumStake).QuorumID, nil
+			}},
+			"stake": &graphql.Field{Type: graphql.String, Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				return p.Source.(*graphqlQuorumStake).Stake.String(), nil
+			}},
+		},
+	})
+	metricType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Metric",
+		Fields: graphql.Fields{
+			"throughput":  &graphql.Field{Type: graphql.Float},
+			"cost_in_gas": &graphql.Field{Type: graphql.Float},
+			"total_stake_per_quorum": &graphql.Field{Type: graphql.NewList(quorumStakeType), Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+				stakes := make([]*graphqlQuorumStake, 0)
+				for quorumID, stake := range p.Source.(*Metric).TotalStakePerQuorum {
+					stakes = append(stakes, &graphqlQuorumStake{QuorumID: quorumID, Stake: stake})
+				}
+				sort.Slice(stakes, func(i, j int) bool { return stakes[i].QuorumID < stakes[j].QuorumID })
+				return stakes, nil
+			}},
+		},
+	})
+	throughputType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Throughput",
+		Fields: graphql.Fields{
+			"throughput": &graphql.Field{Type: graphql.Float},
+			"timestamp":  &graphql.Field{Type: graphql.Int},
+		},
+	})
+
+	listArgs := graphql.FieldConfigArgument{
+		"limit": &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 10},
+	}
+	timeRangeArgs := graphql.FieldConfigArgument{
+		"start": &graphql.ArgumentConfig{Type: graphql.Int, Description: "Start unix timestamp [default: 1 hour ago]"},
+		"end":   &graphql.ArgumentConfig{Type: graphql.Int, Description: "End unix timestamp [default: unix time now]"},
+	}
+
+	queryType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"blob": &graphql.Field{
+				Type: blobType,
+				Args: graphql.FieldConfigArgument{
+					"blob_key": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					return s.getBlob(p.Context, p.Args["blob_key"].(string))
+				},
+			},
+			"blobs": &graphql.Field{
+				Type: graphql.NewList(blobType),
+				Args: listArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, err := graphqlLimit(p)
+					if err != nil {
+						return nil, err
+					}
+					blobs, err := s.getBlobs(p.Context, limit)
+					if errors.Is(err, errNotFound) {
+						return []*BlobMetadataResponse{}, nil
+					}
+					return blobs, err
+				},
+			},
+			"batch": &graphql.Field{
+				Type: batchType,
+				Args: graphql.FieldConfigArgument{
+					"batch_header_hash": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					batchHeaderHash, err := parseGraphQLBatchHeaderHash(p.Args["batch_header_hash"].(string))
+					if err != nil {
+						return nil, err
+					}
+					return &graphqlBatch{BatchHeaderHash: batchHeaderHash}, nil
+				},
+			},
+			"batches": &graphql.Field{
+				Type: graphql.NewList(batchType),
+				Args: listArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					limit, err := graphqlLimit(p)
+					if err != nil {
+						return nil, err
+					}
+					batches, err := s.subgraphClient.QueryBatchesWithLimit(p.Context, limit, 0)
+					if err != nil {
+						return nil, err
+					}
+					results := make([]*graphqlBatch, 0, len(batches))
+					for _, batch := range batches {
+						batchHeaderHash, err := parseGraphQLBatchHeaderHash(string(batch.BatchHeaderHash))
+						if err != nil {
+							return nil, err
+						}
+						results = append(results, &graphqlBatch{BatchHeaderHash: batchHeaderHash, Batch: batch})
+					}
+					return results, nil
+				},
+			},
+			"operators": &graphql.Field{
+				Type: graphql.NewList(operatorType),
+				Args: graphql.FieldConfigArgument{
+					"state": &graphql.ArgumentConfig{Type: operatorStateType, DefaultValue: Registered},
+					"days":  &graphql.ArgumentConfig{Type: graphql.Int, DefaultValue: 14},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					days, _ := p.Args["days"].(int)
+					if days <= 0 || days > maxGraphQLOperatorDays {
+						return nil, fmt.Errorf("days must be between 1 and %d", maxGraphQLOperatorDays)
+					}
+					if p.Args["state"] == Deregistered {
+						return s.getDeregisteredOperatorForDays(p.Context, int32(days))
+					}
+					return s.getRegisteredOperatorForDays(p.Context, int32(days))
+				},
+			},
+			"operator_nonsigning_percentage": &graphql.Field{
+				Type: graphql.NewList(operatorNonsigningType),
+				Args: graphql.FieldConfigArgument{
+					"start":     timeRangeArgs["start"],
+					"end":       timeRangeArgs["end"],
+					"live_only": &graphql.ArgumentConfig{Type: graphql.Boolean, DefaultValue: true},
+				},
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					start, end := graphqlTimeRange(p)
+					liveOnly, _ := p.Args["live_only"].(bool)
+					percentages, err := s.getOperatorNonsigningRate(p.Context, start, end, liveOnly)
+					if err != nil {
+						return nil, err
+					}
+					return percentages.Data, nil
+				},
+			},
+			"metric": &graphql.Field{
+				Type: metricType,
+				Args: timeRangeArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					start, end := graphqlTimeRange(p)
+					return s.getMetric(p.Context, start, end)
+				},
+			},
+			"throughput": &graphql.Field{
+				Type: graphql.NewList(throughputType),
+				Args: timeRangeArgs,
+				Resolve: func(p graphql.ResolveParams) (interface{}, error) {
+					start, end := graphqlTimeRange(p)
+					return s.getThroughput(p.Context, start, end)
+				},
+			},
+		},
+	})
+
+	return graphql.NewSchema(graphql.SchemaConfig{Query: queryType})
+}
@@ -0,0 +1,126 @@
+package dataapi_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+)
+
+type graphqlResponse struct {
+	Data   json.RawMessage `json:"data"`
+	Errors []struct {
+		Message string `json:"message"`
+	} `json:"errors"`
+}
+
+func postGraphQL(t *testing.T, request dataapi.GraphQLRequest) (int, *graphqlResponse) {
+	r := setUpRouter()
+	r.POST("/v1/graphql", testDataApiServer.GraphQLHandler)
+
+	body, err := json.Marshal(request)
+	assert.NoError(t, err)
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodPost, "/v1/graphql", bytes.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	r.ServeHTTP(w, req)
+
+	var response graphqlResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	return w.Code, &response
+}
+
+func TestGraphQLBlobWithBatch(t *testing.T) {
+	batchHeaderHash := [32]byte{9, 9, 9}
+	blob1 := makeTestBlob(0, 80)
+	key1 := queueBlob(t, &blob1, blobstore)
+	markBlobConfirmed(t, &blob1, key1, 0, batchHeaderHash, blobstore)
+	blob2 := makeTestBlob(1, 80)
+	key2 := queueBlob(t, &blob2, blobstore)
+	markBlobConfirmed(t, &blob2, key2, 1, batchHeaderHash, blobstore)
+
+	code, response := postGraphQL(t, dataapi.GraphQLRequest{
+		Query: `query ($key: String!) {
+			blob(blob_key: $key) {
+				blob_key
+				blob_status
+				reference_block_number
+				security_params { quorum_id adversary_threshold }
+				batch {
+					batch_header_hash
+					block_number
+					blobs(limit: 10) { blob_index }
+				}
+			}
+		}`,
+		Variables: map[string]interface{}{"key": key1.String()},
+	})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Empty(t, response.Errors)
+
+	var data struct {
+		Blob struct {
+			BlobKey              string `json:"blob_key"`
+			BlobStatus           string `json:"blob_status"`
+			ReferenceBlockNumber uint32 `json:"reference_block_number"`
+			SecurityParams       []struct {
+				QuorumID           uint8 `json:"quorum_id"`
+				AdversaryThreshold uint8 `json:"adversary_threshold"`
+			} `json:"security_params"`
+			Batch struct {
+				BatchHeaderHash string  `json:"batch_header_hash"`
+				BlockNumber     *uint64 `json:"block_number"`
+				Blobs           []struct {
+					BlobIndex uint32 `json:"blob_index"`
+				} `json:"blobs"`
+			} `json:"batch"`
+		} `json:"blob"`
+	}
+	assert.NoError(t, json.Unmarshal(response.Data, &data))
+	assert.Equal(t, key1.String(), data.Blob.BlobKey)
+	assert.Equal(t, "Confirmed", data.Blob.BlobStatus)
+	assert.Equal(t, expectedReferenceBlockNumber, data.Blob.ReferenceBlockNumber)
+	assert.Len(t, data.Blob.SecurityParams, 1)
+	assert.Equal(t, blob1.RequestHeader.SecurityParams[0].AdversaryThreshold, data.Blob.SecurityParams[0].AdversaryThreshold)
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), data.Blob.Batch.BatchHeaderHash)
+	// The onchain details of the batch are unknown when it is not listed from the subgraph
+	assert.Nil(t, data.Blob.Batch.BlockNumber)
+	assert.Len(t, data.Blob.Batch.Blobs, 2)
+}
+
+func TestGraphQLQueryLimits(t *testing.T) {
+	// The default maximum depth is 6
+	code, response := postGraphQL(t, dataapi.GraphQLRequest{
+		Query: `{ blobs { batch { blobs { batch { blobs { batch { batch_header_hash } } } } } } }`,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Len(t, response.Errors, 1)
+	assert.Contains(t, response.Errors[0].Message, "query depth exceeds")
+
+	// The nested limits multiply to more than the default maximum complexity of 1000
+	code, response = postGraphQL(t, dataapi.GraphQLRequest{
+		Query:     `query ($limit: Int) { blobs(limit: $limit) { batch { blobs(limit: 100) { blob_key } } } }`,
+		Variables: map[string]interface{}{"limit": 100},
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response.Errors[0].Message, "query complexity exceeds")
+
+	// Fragments are expanded when computing the limits, and cycles are rejected
+	code, response = postGraphQL(t, dataapi.GraphQLRequest{
+		Query: `{ blobs { ...a } } fragment a on Blob { batch { ...b } } fragment b on Batch { blobs { ...a } }`,
+	})
+	assert.Equal(t, http.StatusBadRequest, code)
+	assert.Contains(t, response.Errors[0].Message, "cycle in fragment")
+
+	code, response = postGraphQL(t, dataapi.GraphQLRequest{
+		Query: `{ blobs(limit: 0) { blob_key } }`,
+	})
+	assert.Equal(t, http.StatusOK, code)
+	assert.Len(t, response.Errors, 1)
+	assert.Contains(t, response.Errors[0].Message, "limit must be between 1 and 1000")
+}
@@ -0,0 +1,132 @@
+package dataapi
+
+import (
+	"fmt"
+	"math"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gin-gonic/gin"
+)
+
+// listeners are the listeners the API is served on. The full API is served on the public address, unless an internal
+// address is set, in which case the public listener only serves the public routes and rate limits its clients.
+type listeners struct {
+	serverMode string
+
+	publicAddr           string
+	publicAllowOrigins   []string
+	publicRateLimit      float64
+	publicRateBurst      int
+	publicTrustedProxies []string
+	publicMaxStreams     int
+
+	internalAddr         string
+	internalAllowOrigins []string
+}
+
+func newListeners(config Config) listeners {
+	return listeners{
+		serverMode:           config.ServerMode,
+		publicAddr:           config.SocketAddr,
+		publicAllowOrigins:   config.AllowOrigins,
+		publicRateLimit:      config.PublicRateLimit,
+		publicRateBurst:      config.PublicRateBurst,
+		publicTrustedProxies: config.PublicTrustedProxies,
+		publicMaxStreams:     config.PublicMaxStreamsPerClient,
+		internalAddr:         config.InternalSocketAddr,
+		internalAllowOrigins: config.InternalAllowOrigins,
+	}
+}
+
+// serve registers the routes on the router of each listener and runs the listeners until a shutdown signal is
+// received or one of them fails. registerRoutes only registers the internal routes if internal is set.
+func (l listeners) serve(logger logging.Logger, registerRoutes func(router *gin.Engine, internal bool)) error {
+	if l.internalAddr == "" {
+		router := newRouter()
+		registerRoutes(router, true)
+		return <-listen(logger, router, l.publicAddr, l.serverMode, l.publicAllowOrigins)
+	}
+
+	public, err := l.newPublicRouter()
+	if err != nil {
+		return err
+	}
+	registerRoutes(public, false)
+	internal := newRouter()
+	registerRoutes(internal, true)
+
+	publicErrChan := listen(logger.With("listener", "public"), public, l.publicAddr, l.serverMode, l.publicAllowOrigins)
+	internalErrChan := listen(logger.With("listener", "internal"), internal, l.internalAddr, l.serverMode, l.internalAllowOrigins)
+	select {
+	case err := <-publicErrChan:
+		return err
+	case err := <-internalErrChan:
+		return err
+	}
+}
+
+// newPublicRouter returns a router which rate limits the requests of each client, identified by its IP as forwarded
+// by the trusted proxies, or by the IP of the connection if there are none, and caps the streams each client keeps open
+func (l listeners) newPublicRouter() (*gin.Engine, error) {
+	router := newRouter()
+	if err := router.SetTrustedProxies(l.publicTrustedProxies); err != nil {
+		return nil, fmt.Errorf("invalid trusted proxies of the public listener: %w", err)
+	}
+	if l.publicRateLimit > 0 {
+		router.Use(rateLimitMiddleware(l.publicRateLimit, l.publicRateBurst))
+	}
+	if l.publicMaxStreams > 0 {
+		router.Use(streamLimitMiddleware(l.publicMaxStreams))
+	}
+	return router, nil
+}
+
+// rateLimitMiddleware rejects the requests of the clients which exceed limit requests per second on average, with
+// bursts of up to burst requests
+func rateLimitMiddleware(limit float64, burst int) gin.HandlerFunc {
+	limiter := ratelimit.NewClientRateLimiter(limit, burst)
+	return func(c *gin.Context) {
+		delay, ok := limiter.Reserve(c.ClientIP(), time.Now())
+		if !ok {
+			c.Header("Retry-After", fmt.Sprintf("%d", int(math.Ceil(delay.Seconds()))))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{Error: "rate limit exceeded"})
+			return
+		}
+		c.Next()
+	}
+}
+
+// streamLimitMiddleware rejects the requests to the confirmation streams of the clients which already have max of
+// them open, as the rate limit only applies to opening the streams
+func streamLimitMiddleware(max int) gin.HandlerFunc {
+	var mu sync.Mutex
+	open := make(map[string]int)
+	return func(c *gin.Context) {
+		if !strings.HasSuffix(c.FullPath(), "/feed/stream") {
+			c.Next()
+			return
+		}
+		client := c.ClientIP()
+		mu.Lock()
+		if open[client] >= max {
+			mu.Unlock()
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, ErrorResponse{Error: "too many open streams"})
+			return
+		}
+		open[client]++
+		mu.Unlock()
+		defer func() {
+			mu.Lock()
+			if open[client]--; open[client] == 0 {
+				delete(open, client)
+			}
+			mu.Unlock()
+		}()
+		c.Next()
+	}
+}
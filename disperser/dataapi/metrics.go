@@ -2,11 +2,14 @@ package dataapi
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"net/http"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/prometheus/client_golang/prometheus/collectors"
@@ -26,6 +29,12 @@ type Metrics struct {
 	Latency     *prometheus.SummaryVec
 	Semvers     *prometheus.GaugeVec
 
+	SubgraphQueries        *prometheus.CounterVec
+	SubgraphQueryLatency   *prometheus.SummaryVec
+	SubgraphIndexedBlock   *prometheus.GaugeVec
+	SubgraphIndexingLag    *prometheus.GaugeVec
+	SubgraphIndexingErrors *prometheus.GaugeVec
+
 	httpPort string
 	logger   logging.Logger
 }
@@ -61,6 +70,47 @@ func NewMetrics(blobMetadataStore *blobstore.BlobMetadataStore, httpPort string,
 			},
 			[]string{"semver"},
 		),
+		SubgraphQueries: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: namespace,
+				Name:      "subgraph_queries",
+				Help:      "the number of subgraph queries",
+			},
+			[]string{"network", "status", "query"},
+		),
+		SubgraphQueryLatency: promauto.With(reg).NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  namespace,
+				Name:       "subgraph_query_latency_ms",
+				Help:       "subgraph query latency summary in milliseconds",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.95: 0.01, 0.99: 0.001},
+			},
+			[]string{"network", "query"},
+		),
+		SubgraphIndexedBlock: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "subgraph_indexed_block",
+				Help:      "the latest block indexed by the subgraph",
+			},
+			[]string{"network", "subgraph"},
+		),
+		SubgraphIndexingLag: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "subgraph_indexing_lag_seconds",
+				Help:      "the age of the latest block indexed by the subgraph in seconds",
+			},
+			[]string{"network", "subgraph"},
+		),
+		SubgraphIndexingErrors: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "subgraph_indexing_errors",
+				Help:      "1 if the subgraph skipped blocks because of indexing errors, 0 otherwise",
+			},
+			[]string{"network", "subgraph"},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger.With("component", "DataAPIMetrics"),
@@ -104,6 +154,36 @@ func (g *Metrics) UpdateSemverCounts(semverData map[string]int) {
 	}
 }
 
+// ObserveSubgraphQuery records the latency and the outcome of a subgraph query. The queries canceled by the
+// caller, e.g. when the client of the request disconnects, aren't counted as failed.
+func (g *Metrics) ObserveSubgraphQuery(network string, query string, latencyMs float64, err error) {
+	status := "success"
+	if errors.Is(err, context.Canceled) {
+		status = "canceled"
+	} else if err != nil {
+		status = "failed"
+	}
+	g.SubgraphQueries.With(prometheus.Labels{
+		"network": network,
+		"status":  status,
+		"query":   query,
+	}).Inc()
+	g.SubgraphQueryLatency.WithLabelValues(network, query).Observe(latencyMs)
+}
+
+// UpdateSubgraphIndexingStatus updates the indexing metrics of a subgraph
+func (g *Metrics) UpdateSubgraphIndexingStatus(network string, name string, status *subgraph.IndexingStatus, now time.Time) {
+	g.SubgraphIndexedBlock.WithLabelValues(network, name).Set(float64(status.BlockNumber))
+	if status.BlockTimestamp > 0 {
+		g.SubgraphIndexingLag.WithLabelValues(network, name).Set(now.Sub(time.Unix(int64(status.BlockTimestamp), 0)).Seconds())
+	}
+	indexingErrors := 0.0
+	if status.HasIndexingErrors {
+		indexingErrors = 1
+	}
+	g.SubgraphIndexingErrors.WithLabelValues(network, name).Set(indexingErrors)
+}
+
 // Start starts the metrics server
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
@@ -4,7 +4,9 @@ import (
 	"context"
 	"errors"
 	"fmt"
+	"math"
 	"math/big"
+	"sort"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
@@ -14,8 +16,23 @@ const (
 	maxWorkersGetOperatorState = 10  // The maximum number of workers to use when querying operator state.
 	defaultThroughputRateSecs  = 240 // 4m rate is used for < 7d window to match $__rate_interval
 	sevenDayThroughputRateSecs = 660 // 11m rate is used for >= 7d window to match $__rate_interval
+	maxDispersalOriginsLimit   = 10000
+
+	// unknownDispersalOrigin is reported for blobs dispersed before the origin was recorded
+	unknownDispersalOrigin = "unknown"
+
+	defaultTimeToFinalityWindow = "24h"
 )
 
+// timeToFinalityWindows are the windows over which the time to finality percentiles can be computed
+var timeToFinalityWindows = map[string]time.Duration{
+	"1h":  time.Hour,
+	"6h":  6 * time.Hour,
+	"24h": 24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
 func (s *server) getMetric(ctx context.Context, startTime int64, endTime int64) (*Metric, error) {
 	blockNumber, err := s.transactor.GetCurrentBlockNumber(ctx)
 	if err != nil {
@@ -147,20 +164,163 @@ func (s *server) calculateTotalCostGasUsed(ctx context.Context) (float64, error)
 	return totalGasUsed, nil
 }
 
-func (s *server) getNonSigners(ctx context.Context, intervalSeconds int64) (*[]NonSigner, error) {
-	nonSigners, err := s.subgraphClient.QueryBatchNonSigningOperatorIdsInInterval(ctx, intervalSeconds)
+// getNonSigners returns the number of batches in [startTime, endTime] each operator failed to sign for each of its
+// quorums, restricted to the given quorums if any. The records are sorted by descending count.
+func (s *server) getNonSigners(ctx context.Context, startTime, endTime int64, quorumIDs []core.QuorumID) ([]*NonSigner, error) {
+	batches, err := s.subgraphClient.QueryBatchNonSigningInfoInInterval(ctx, startTime, endTime)
 	if err != nil {
 		return nil, err
 	}
+	if len(batches) == 0 {
+		return []*NonSigner{}, nil
+	}
 
-	nonSignersObj := make([]NonSigner, 0)
-	for nonSigner, nonSigningAmount := range nonSigners {
-		s.logger.Info("NonSigner", "nonSigner", nonSigner, "nonSigningAmount", nonSigningAmount)
-		nonSignersObj = append(nonSignersObj, NonSigner{
-			OperatorId: nonSigner,
-			Count:      nonSigningAmount,
-		})
+	operatorQuorumIntervals, _, _, err := s.getNonsignerQuorumIntervals(ctx, batches)
+	if err != nil {
+		return nil, err
+	}
+
+	quorumFilter := make(map[uint8]struct{}, len(quorumIDs))
+	for _, q := range quorumIDs {
+		quorumFilter[q] = struct{}{}
+	}
+	nonSigners := make([]*NonSigner, 0)
+	for op, val := range computeNumFailed(batches, operatorQuorumIntervals) {
+		for q, count := range val {
+			if _, ok := quorumFilter[q]; len(quorumFilter) > 0 && !ok {
+				continue
+			}
+			nonSigners = append(nonSigners, &NonSigner{
+				OperatorId: fmt.Sprintf("0x%s", op),
+				QuorumId:   q,
+				Count:      count,
+			})
+		}
+	}
+
+	// Sort by descending count, so that the pages are stable.
+	sort.Slice(nonSigners, func(i, j int) bool {
+		if nonSigners[i].Count == nonSigners[j].Count {
+			if nonSigners[i].OperatorId == nonSigners[j].OperatorId {
+				return nonSigners[i].QuorumId < nonSigners[j].QuorumId
+			}
+			return nonSigners[i].OperatorId < nonSigners[j].OperatorId
+		}
+		return nonSigners[i].Count > nonSigners[j].Count
+	})
+	return nonSigners, nil
+}
+
+// getDispersalOrigins aggregates the request origins of the latest confirmed blobs.
+// Only authenticated accounts are reported, the account of unauthenticated dispersals is the client IP.
+func (s *server) getDispersalOrigins(ctx context.Context, limit int) (*DispersalOriginsResponse, error) {
+	_, metadatas, err := s.getBlobMetadataByBatchesWithLimit(ctx, limit)
+	if err != nil {
+		return nil, err
+	}
+	if len(metadatas) == 0 {
+		return nil, errNotFound
+	}
+
+	surfaces := make(map[string]*DispersalOriginCount)
+	clientVersions := make(map[string]*DispersalOriginCount)
+	accounts := make(map[string]*DispersalOriginCount)
+	for _, metadata := range metadatas {
+		if metadata.RequestMetadata == nil {
+			continue
+		}
+		origin := metadata.RequestMetadata.RequestOrigin
+		size := metadata.RequestMetadata.BlobSize
+		countDispersalOrigin(surfaces, string(origin.DispersalSurface), size)
+		countDispersalOrigin(clientVersions, origin.ClientVersion, size)
+		if origin.AuthenticatedAccount != "" {
+			countDispersalOrigin(accounts, origin.AuthenticatedAccount, size)
+		}
+	}
+
+	return &DispersalOriginsResponse{
+		Meta: Meta{
+			Size: len(metadatas),
+		},
+		Surfaces:       sortDispersalOriginCounts(surfaces),
+		ClientVersions: sortDispersalOriginCounts(clientVersions),
+		Accounts:       sortDispersalOriginCounts(accounts),
+	}, nil
+}
+
+func countDispersalOrigin(counts map[string]*DispersalOriginCount, origin string, blobSize uint) {
+	if origin == "" {
+		origin = unknownDispersalOrigin
+	}
+	count, ok := counts[origin]
+	if !ok {
+		count = &DispersalOriginCount{Origin: origin}
+		counts[origin] = count
+	}
+	count.NumBlobs++
+	count.TotalBlobSize += blobSize
+}
+
+// sortDispersalOriginCounts returns the counts ordered by decreasing number of blobs.
+func sortDispersalOriginCounts(counts map[string]*DispersalOriginCount) []*DispersalOriginCount {
+	sorted := make([]*DispersalOriginCount, 0, len(counts))
+	for _, count := range counts {
+		sorted = append(sorted, count)
+	}
+	sort.Slice(sorted, func(i, j int) bool {
+		if sorted[i].NumBlobs != sorted[j].NumBlobs {
+			return sorted[i].NumBlobs > sorted[j].NumBlobs
+		}
+		return sorted[i].Origin < sorted[j].Origin
+	})
+	return sorted
+}
+
+// getTimeToFinality returns the distribution of the time from dispersal request to confirmation and to
+// finalization of the blobs that reached these stages within the window ending at the given time.
+func (s *server) getTimeToFinality(ctx context.Context, window time.Duration, end time.Time) (*TimeToFinalityResponse, error) {
+	confirmation, err := s.getTimeToFinalityPercentiles(ctx, "confirmed", window, end)
+	if err != nil {
+		return nil, err
+	}
+	finalization, err := s.getTimeToFinalityPercentiles(ctx, "finalized", window, end)
+	if err != nil {
+		return nil, err
+	}
+
+	return &TimeToFinalityResponse{
+		End:          uint64(end.Unix()),
+		Confirmation: confirmation,
+		Finalization: finalization,
+	}, nil
+}
+
+func (s *server) getTimeToFinalityPercentiles(ctx context.Context, stage string, window time.Duration, end time.Time) (*TimeToFinalityPercentiles, error) {
+	count, err := s.promClient.QueryBlobTimeToFinalityCount(ctx, stage, window, end)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query number of %s blobs: %w", stage, err)
+	}
+
+	percentiles := &TimeToFinalityPercentiles{
+		NumBlobs: uint64(math.Round(count)),
+	}
+	if percentiles.NumBlobs == 0 {
+		return percentiles, nil
+	}
+
+	for _, p := range []struct {
+		quantile float64
+		value    *float64
+	}{
+		{0.5, &percentiles.P50},
+		{0.95, &percentiles.P95},
+		{0.99, &percentiles.P99},
+	} {
+		*p.value, err = s.promClient.QueryBlobTimeToFinalityQuantile(ctx, stage, p.quantile, window, end)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query time to %s quantile %g: %w", stage, p.quantile, err)
+		}
 	}
 
-	return &nonSignersObj, nil
+	return percentiles, nil
 }
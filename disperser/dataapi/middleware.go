@@ -0,0 +1,297 @@
+package dataapi
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+const (
+	encodingGzip = "gzip"
+	encodingZstd = "zstd"
+
+	// fieldsQueryParam is the query parameter selecting the fields of a JSON response, e.g.
+	// ?fields=meta,data.operator_id,data.is_online
+	fieldsQueryParam = "fields"
+)
+
+// newRouter returns a router with the response middlewares installed.
+// The middlewares only apply to the routes registered after them, so the routes must be
+// registered on the returned router.
+func newRouter() *gin.Engine {
+	router := gin.New()
+	router.Use(compressionMiddleware(), fieldSelectionMiddleware())
+	return router
+}
+
+type responseControllerKey struct{}
+
+// withResponseController makes the response controller of each request available in its context, as the response
+// writer of gin doesn't expose it, so that the streaming handlers can extend the write deadline of the server
+func withResponseController(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		ctx := context.WithValue(r.Context(), responseControllerKey{}, http.NewResponseController(w))
+		handler.ServeHTTP(w, r.WithContext(ctx))
+	})
+}
+
+// compressionMiddleware compresses the response body with zstd or gzip, depending on the
+// encodings accepted by the client. zstd is preferred when both are accepted.
+func compressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding == "" || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		w := &compressedResponseWriter{ResponseWriter: c.Writer, encoding: encoding}
+		c.Writer = w
+		c.Next()
+		if err := w.Close(); err != nil {
+			_ = c.Error(fmt.Errorf("failed to compress response: %w", err))
+		}
+	}
+}
+
+// negotiateEncoding returns the preferred supported encoding in the Accept-Encoding header,
+// or an empty string if none of them is accepted.
+func negotiateEncoding(acceptEncoding string) string {
+	accepted := make(map[string]bool)
+	for _, part := range strings.Split(acceptEncoding, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		name = strings.ToLower(strings.TrimSpace(name))
+		q := 1.0
+		if v, ok := strings.CutPrefix(strings.TrimSpace(params), "q="); ok {
+			if parsed, err := strconv.ParseFloat(v, 64); err == nil {
+				q = parsed
+			}
+		}
+		accepted[name] = q > 0
+	}
+
+	switch {
+	case accepted[encodingZstd]:
+		return encodingZstd
+	case accepted[encodingGzip]:
+		return encodingGzip
+	default:
+		return ""
+	}
+}
+
+type compressedResponseWriter struct {
+	gin.ResponseWriter
+	encoding string
+	encoder  io.WriteCloser
+}
+
+func (w *compressedResponseWriter) Write(data []byte) (int, error) {
+	if w.encoder == nil {
+		if err := w.startEncoding(); err != nil {
+			return 0, err
+		}
+	}
+	return w.encoder.Write(data)
+}
+
+func (w *compressedResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// startEncoding is deferred to the first write, or flush, so that responses without a body are not encoded.
+func (w *compressedResponseWriter) startEncoding() error {
+	switch w.encoding {
+	case encodingZstd:
+		encoder, err := zstd.NewWriter(w.ResponseWriter, zstd.WithEncoderConcurrency(1))
+		if err != nil {
+			return err
+		}
+		w.encoder = encoder
+	default:
+		w.encoder = gzip.NewWriter(w.ResponseWriter)
+	}
+
+	header := w.Header()
+	header.Set("Content-Encoding", w.encoding)
+	header.Add("Vary", "Accept-Encoding")
+	header.Del("Content-Length")
+	return nil
+}
+
+// Flush flushes the data compressed so far, so that the streamed responses are received as they are written. The
+// headers of a response are written by its first flush, so the encoding is started before it.
+func (w *compressedResponseWriter) Flush() {
+	if w.encoder == nil {
+		if err := w.startEncoding(); err != nil {
+			w.ResponseWriter.Flush()
+			return
+		}
+	}
+	if flusher, ok := w.encoder.(interface{ Flush() error }); ok {
+		_ = flusher.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+func (w *compressedResponseWriter) Close() error {
+	if w.encoder == nil {
+		return nil
+	}
+	return w.encoder.Close()
+}
+
+// fieldSelectionMiddleware filters successful JSON responses down to the fields requested with
+// the fields query parameter. Nested fields are separated by dots, and arrays are traversed so
+// that data.operator_id selects the operator_id of every element of data. The responses which
+// aren't JSON or which are streamed, e.g. the server-sent events, are written as they are.
+func fieldSelectionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		fields := c.Query(fieldsQueryParam)
+		if fields == "" {
+			c.Next()
+			return
+		}
+
+		selection, err := parseFieldSelection(fields)
+		if err != nil {
+			c.AbortWithStatusJSON(http.StatusBadRequest, ErrorResponse{
+				Error: err.Error(),
+			})
+			return
+		}
+
+		w := &bufferedResponseWriter{ResponseWriter: c.Writer}
+		c.Writer = w
+		c.Next()
+		c.Writer = w.ResponseWriter
+
+		if w.passthrough {
+			return
+		}
+		body := w.body.Bytes()
+		if w.Status() >= 200 && w.Status() < 300 && strings.Contains(w.Header().Get("Content-Type"), "application/json") {
+			filtered, err := selection.filter(body)
+			if err != nil {
+				_ = c.Error(fmt.Errorf("failed to select response fields: %w", err))
+			} else {
+				body = filtered
+			}
+		}
+		if len(body) > 0 {
+			_, _ = w.ResponseWriter.Write(body)
+		}
+	}
+}
+
+// fieldSelection is a tree of the selected fields. A field without children selects the whole value.
+type fieldSelection map[string]fieldSelection
+
+func parseFieldSelection(fields string) (fieldSelection, error) {
+	selection := make(fieldSelection)
+	for _, field := range strings.Split(fields, ",") {
+		field = strings.TrimSpace(field)
+		node := selection
+		for _, name := range strings.Split(field, ".") {
+			if name == "" {
+				return nil, fmt.Errorf("invalid field %q", field)
+			}
+			child, ok := node[name]
+			if !ok {
+				child = make(fieldSelection)
+				node[name] = child
+			}
+			node = child
+		}
+	}
+	return selection, nil
+}
+
+func (s fieldSelection) filter(body []byte) ([]byte, error) {
+	var value interface{}
+	decoder := json.NewDecoder(bytes.NewReader(body))
+	decoder.UseNumber()
+	if err := decoder.Decode(&value); err != nil {
+		return nil, err
+	}
+	return json.Marshal(s.apply(value))
+}
+
+func (s fieldSelection) apply(value interface{}) interface{} {
+	if len(s) == 0 {
+		return value
+	}
+	switch v := value.(type) {
+	case map[string]interface{}:
+		filtered := make(map[string]interface{}, len(s))
+		for name, child := range s {
+			if fieldValue, ok := v[name]; ok {
+				filtered[name] = child.apply(fieldValue)
+			}
+		}
+		return filtered
+	case []interface{}:
+		filtered := make([]interface{}, len(v))
+		for i := range v {
+			filtered[i] = s.apply(v[i])
+		}
+		return filtered
+	default:
+		return value
+	}
+}
+
+// bufferedResponseWriter buffers a JSON response until the handler returns so that its fields can be selected, and
+// writes the other responses, and the flushed ones, as they are written
+type bufferedResponseWriter struct {
+	gin.ResponseWriter
+	body bytes.Buffer
+	// passthrough is set once the response is known not to be a JSON document, or to be streamed, after which it is
+	// written unbuffered
+	passthrough bool
+}
+
+func (w *bufferedResponseWriter) Write(data []byte) (int, error) {
+	if !w.passthrough && !strings.Contains(w.Header().Get("Content-Type"), "application/json") {
+		if err := w.stopBuffering(); err != nil {
+			return 0, err
+		}
+	}
+	if w.passthrough {
+		return w.ResponseWriter.Write(data)
+	}
+	return w.body.Write(data)
+}
+
+func (w *bufferedResponseWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+// Flush stops buffering the response, since a flushed response is streamed
+func (w *bufferedResponseWriter) Flush() {
+	_ = w.stopBuffering()
+	w.ResponseWriter.Flush()
+}
+
+// stopBuffering writes the response buffered so far, and the rest of the response unbuffered
+func (w *bufferedResponseWriter) stopBuffering() error {
+	if w.passthrough {
+		return nil
+	}
+	w.passthrough = true
+	if w.body.Len() == 0 {
+		return nil
+	}
+	_, err := w.ResponseWriter.Write(w.body.Bytes())
+	w.body.Reset()
+	return err
+}
@@ -0,0 +1,188 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"os"
+	"regexp"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/docs"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gin-gonic/gin"
+)
+
+var networkNameRegex = regexp.MustCompile(`^[a-z0-9][a-z0-9-]*$`)
+
+// Network holds the backends that are specific to one EigenDA network (e.g. mainnet or holesky).
+type Network struct {
+	// Name is used as the route prefix of the network, e.g. /holesky/api/v1
+	Name string
+
+	BlobStore         disperser.BlobStore
+	PromClient        PrometheusClient
+	SubgraphClient    SubgraphClient
+	Transactor        core.Transactor
+	EthClient         common.EthClient
+	ChainState        core.ChainState
+	IndexedChainState core.IndexedChainState
+
+	DisperserHostname  string
+	ChurnerHostname    string
+	BatcherHealthEndpt string
+
+	EigenDAServiceManagerAddr string
+	BlockExplorerURL          string
+
+	// UptimeStore stores the uptime of the operators of the network. The uptime isn't tracked nor served if it is nil.
+	UptimeStore OperatorUptimeStore
+}
+
+// MultiNetworkServer serves the data api of several networks from a single deployment.
+// Each network is served under /<network>/api/v1, and the first network is additionally
+// served under /api/v1 so that existing clients keep working.
+// The metrics, the grpc connection used by the availability checks and the operator
+// probes are shared between the networks.
+type MultiNetworkServer struct {
+	listeners listeners
+	logger    logging.Logger
+
+	networks []string
+	servers  map[string]*server
+}
+
+func NewMultiNetworkServer(
+	config Config,
+	networks []Network,
+	logger logging.Logger,
+	metrics *Metrics,
+	grpcConn GRPCConn,
+	eigenDAHttpServiceChecker EigenDAHttpServiceChecker,
+) (*MultiNetworkServer, error) {
+	if len(networks) == 0 {
+		return nil, errors.New("at least one network must be configured")
+	}
+
+	if grpcConn == nil {
+		grpcConn = &GRPCDialerSkipTLS{}
+	}
+
+	s := &MultiNetworkServer{
+		listeners: newListeners(config),
+		logger:    logger.With("component", "MultiNetworkDataAPIServer"),
+		networks:  make([]string, 0, len(networks)),
+		servers:   make(map[string]*server, len(networks)),
+	}
+	for _, n := range networks {
+		if !networkNameRegex.MatchString(n.Name) {
+			return nil, fmt.Errorf("invalid network name %q: must match %s", n.Name, networkNameRegex)
+		}
+		if _, ok := s.servers[n.Name]; ok {
+			return nil, fmt.Errorf("duplicate network name %q", n.Name)
+		}
+
+		networkConfig := config
+		networkConfig.DisperserHostname = n.DisperserHostname
+		networkConfig.ChurnerHostname = n.ChurnerHostname
+		networkConfig.BatcherHealthEndpt = n.BatcherHealthEndpt
+		networkConfig.EigenDAServiceManagerAddr = n.EigenDAServiceManagerAddr
+		networkConfig.BlockExplorerURL = n.BlockExplorerURL
+		networkConfig.OperatorUptimeStore = n.UptimeStore
+		srv := NewServer(
+			networkConfig,
+			n.BlobStore,
+			n.PromClient,
+			n.SubgraphClient,
+			n.Transactor,
+			n.EthClient,
+			n.ChainState,
+			n.IndexedChainState,
+			logger.With("network", n.Name),
+			metrics,
+			grpcConn,
+			nil,
+			eigenDAHttpServiceChecker,
+		)
+		s.networks = append(s.networks, n.Name)
+		s.servers[n.Name] = srv
+	}
+
+	return s, nil
+}
+
+// Networks returns the names of the served networks, the default network first.
+func (s *MultiNetworkServer) Networks() []string {
+	return s.networks
+}
+
+func (s *MultiNetworkServer) Start() error {
+	if s.listeners.serverMode == gin.ReleaseMode {
+		// optimize performance and disable debug features.
+		gin.SetMode(gin.ReleaseMode)
+	}
+
+	docs.SwaggerInfo.BasePath = "/api/v1"
+	docs.SwaggerInfo.Host = os.Getenv("SWAGGER_HOST")
+
+	return s.listeners.serve(s.logger, s.registerRoutes)
+}
+
+// Router returns a router with the routes of every network registered, as served by the internal listener.
+func (s *MultiNetworkServer) Router() *gin.Engine {
+	router := newRouter()
+	s.registerRoutes(router, true)
+	return router
+}
+
+// PublicRouter returns a router with the public routes of every network registered, rate limited as served by the
+// public listener.
+func (s *MultiNetworkServer) PublicRouter() (*gin.Engine, error) {
+	router, err := s.listeners.newPublicRouter()
+	if err != nil {
+		return nil, err
+	}
+	s.registerRoutes(router, false)
+	return router, nil
+}
+
+func (s *MultiNetworkServer) registerRoutes(router *gin.Engine, internal bool) {
+	s.servers[s.networks[0]].registerRoutes(router.Group("/api/v1"), internal)
+	for _, name := range s.networks {
+		s.servers[name].registerRoutes(router.Group(fmt.Sprintf("/%s/api/v1", name)), internal)
+	}
+	router.GET("/networks", s.FetchNetworks)
+}
+
+// RecentDispersals returns the dispersals of the latest confirmed blobs of the default network
+func (s *MultiNetworkServer) RecentDispersals(ctx context.Context) ([]*Dispersal, error) {
+	return s.servers[s.networks[0]].RecentDispersals(ctx)
+}
+
+func (s *MultiNetworkServer) Shutdown() error {
+	var errs []error
+	for _, name := range s.networks {
+		if err := s.servers[name].Shutdown(); err != nil {
+			errs = append(errs, fmt.Errorf("failed to shutdown network %s: %w", name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// FetchNetworks godoc
+//
+//	@Summary	Fetch the networks served by this deployment
+//	@Tags		Networks
+//	@Produce	json
+//	@Success	200	{object}	NetworksResponse
+//	@Router		/networks [get]
+func (s *MultiNetworkServer) FetchNetworks(c *gin.Context) {
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxNetworksAge))
+	c.JSON(http.StatusOK, NetworksResponse{
+		Default:  s.networks[0],
+		Networks: s.networks,
+	})
+}
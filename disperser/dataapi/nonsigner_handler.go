@@ -21,49 +21,14 @@ func (s *server) getOperatorNonsigningRate(ctx context.Context, startTime, endTi
 		return &OperatorsNonsigningPercentage{}, nil
 	}
 
-	// Get the block interval of interest [startBlock, endBlock].
-	startBlock := batches[0].ReferenceBlockNumber
-	endBlock := batches[0].ReferenceBlockNumber
-	for i := range batches {
-		if startBlock > batches[i].ReferenceBlockNumber {
-			startBlock = batches[i].ReferenceBlockNumber
-		}
-		if endBlock < batches[i].ReferenceBlockNumber {
-			endBlock = batches[i].ReferenceBlockNumber
-		}
-	}
-
-	// Get the nonsigner (in operatorId) list.
-	nonsigners, err := getNonSigners(batches)
+	operatorQuorumIntervals, nonsignerIdToAddress, quorumIDs, err := s.getNonsignerQuorumIntervals(ctx, batches)
 	if err != nil {
 		return nil, err
 	}
-	if len(nonsigners) == 0 {
+	if len(nonsignerIdToAddress) == 0 {
 		return &OperatorsNonsigningPercentage{}, nil
 	}
 
-	// Get the address for the nonsigners (from their operatorIDs).
-	// nonsignerAddresses[i] is the address for nonsigners[i].
-	nonsignerAddresses, err := s.transactor.BatchOperatorIDToAddress(ctx, nonsigners)
-	if err != nil {
-		return nil, err
-	}
-
-	// Create a mapping from address to operatorID.
-	nonsignerAddressToId := make(map[string]core.OperatorID)
-	nonsignerIdToAddress := make(map[string]string)
-	for i := range nonsigners {
-		addr := strings.ToLower(nonsignerAddresses[i].Hex())
-		nonsignerAddressToId[addr] = nonsigners[i]
-		nonsignerIdToAddress[nonsigners[i].Hex()] = addr
-	}
-
-	// Create operators' quorum intervals.
-	operatorQuorumIntervals, quorumIDs, err := s.createOperatorQuorumIntervals(ctx, nonsigners, nonsignerAddressToId, startBlock, endBlock)
-	if err != nil {
-		return nil, err
-	}
-
 	// Compute num batches failed, where numFailed[op][q] is the number of batches
 	// failed to sign for operator "op" and quorum "q".
 	numFailed := computeNumFailed(batches, operatorQuorumIntervals)
@@ -72,6 +37,7 @@ func (s *server) getOperatorNonsigningRate(ctx context.Context, startTime, endTi
 	// that operator "op" and quorum "q" are responsible for.
 	numResponsible := computeNumResponsible(batches, operatorQuorumIntervals)
 
+	_, endBlock := getBlockInterval(batches)
 	state, err := s.chainState.GetOperatorState(ctx, uint(endBlock), quorumIDs)
 	if err != nil {
 		return nil, err
@@ -140,6 +106,61 @@ func (s *server) getOperatorNonsigningRate(ctx context.Context, startTime, endTi
 	}, nil
 }
 
+// getBlockInterval returns the block interval [startBlock, endBlock] of the reference blocks of the batches, which
+// must not be empty.
+func getBlockInterval(batches []*BatchNonSigningInfo) (uint32, uint32) {
+	startBlock := batches[0].ReferenceBlockNumber
+	endBlock := batches[0].ReferenceBlockNumber
+	for i := range batches {
+		if startBlock > batches[i].ReferenceBlockNumber {
+			startBlock = batches[i].ReferenceBlockNumber
+		}
+		if endBlock < batches[i].ReferenceBlockNumber {
+			endBlock = batches[i].ReferenceBlockNumber
+		}
+	}
+	return startBlock, endBlock
+}
+
+// getNonsignerQuorumIntervals returns the quorum intervals of the nonsigners of the batches, which must not be empty,
+// the mapping from the operatorID (in hex) of the nonsigners to their address, and the quorums they were in.
+func (s *server) getNonsignerQuorumIntervals(ctx context.Context, batches []*BatchNonSigningInfo) (OperatorQuorumIntervals, map[string]string, []uint8, error) {
+	// Get the block interval of interest [startBlock, endBlock].
+	startBlock, endBlock := getBlockInterval(batches)
+
+	// Get the nonsigner (in operatorId) list.
+	nonsigners, err := getNonSigners(batches)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	if len(nonsigners) == 0 {
+		return OperatorQuorumIntervals{}, map[string]string{}, nil, nil
+	}
+
+	// Get the address for the nonsigners (from their operatorIDs).
+	// nonsignerAddresses[i] is the address for nonsigners[i].
+	nonsignerAddresses, err := s.transactor.BatchOperatorIDToAddress(ctx, nonsigners)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+
+	// Create a mapping from address to operatorID.
+	nonsignerAddressToId := make(map[string]core.OperatorID)
+	nonsignerIdToAddress := make(map[string]string)
+	for i := range nonsigners {
+		addr := strings.ToLower(nonsignerAddresses[i].Hex())
+		nonsignerAddressToId[addr] = nonsigners[i]
+		nonsignerIdToAddress[nonsigners[i].Hex()] = addr
+	}
+
+	// Create operators' quorum intervals.
+	operatorQuorumIntervals, quorumIDs, err := s.createOperatorQuorumIntervals(ctx, nonsigners, nonsignerAddressToId, startBlock, endBlock)
+	if err != nil {
+		return nil, nil, nil, err
+	}
+	return operatorQuorumIntervals, nonsignerIdToAddress, quorumIDs, nil
+}
+
 func (s *server) createOperatorQuorumIntervals(ctx context.Context, nonsigners []core.OperatorID, nonsignerAddressToId map[string]core.OperatorID, startBlock, endBlock uint32) (OperatorQuorumIntervals, []uint8, error) {
 	// Get operators' initial quorums (at startBlock).
 	quorumSeen := make(map[uint8]struct{}, 0)
@@ -0,0 +1,253 @@
+package dataapi
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/semver"
+)
+
+const (
+	// diagnosisTimeout bounds each step of the diagnosis of a socket
+	diagnosisTimeout = 3 * time.Second
+	// vantageTimeout bounds the port check requested from each vantage
+	vantageTimeout = 10 * time.Second
+	// maxClockSkew is the largest skew of the clock of a node which isn't reported as an issue
+	maxClockSkew = time.Second
+)
+
+type (
+	OperatorSocketDiagnosis struct {
+		Socket string `json:"socket"`
+		// Port is the status of the probe of the socket, e.g. reachable, refused or timeout
+		Port string `json:"port"`
+		// TLS is enabled if the socket completes a TLS handshake, disabled if it doesn't, and unknown if the port
+		// isn't reachable
+		TLS string `json:"tls"`
+		// Semver is the semver reported by the node info of the socket, or the failure of the request
+		Semver    string  `json:"semver"`
+		LatencyMs float64 `json:"latency_ms"`
+		// ClockSkewMs is how far the clock of the node is ahead of the clock of the Data API, which is omitted if the
+		// node doesn't report its time
+		ClockSkewMs *float64 `json:"clock_skew_ms,omitempty"`
+		// QuorumIds and Features are the capabilities reported by the node info, which are omitted if the node
+		// predates them
+		QuorumIds []uint32 `json:"quorum_ids,omitempty"`
+		Features  []string `json:"features,omitempty"`
+		Error     string   `json:"error,omitempty"`
+	}
+
+	OperatorVantageReachability struct {
+		Region          string `json:"region"`
+		DispersalOnline bool   `json:"dispersal_online"`
+		RetrievalOnline bool   `json:"retrieval_online"`
+		// Error is the error of the port check requested from the vantage, in which case the reachability is unknown
+		Error string `json:"error,omitempty"`
+	}
+
+	OperatorDiagnosisIssue struct {
+		// Check is the check which found the issue: port, tls, node_info, semver, capabilities, clock_skew or vantage
+		Check string `json:"check"`
+		// Message describes the issue and the action the operator can take to fix it
+		Message string `json:"message"`
+	}
+
+	OperatorDiagnosisResponse struct {
+		OperatorId string                   `json:"operator_id"`
+		Dispersal  *OperatorSocketDiagnosis `json:"dispersal"`
+		Retrieval  *OperatorSocketDiagnosis `json:"retrieval"`
+		// Vantages is the reachability of the sockets from each configured vantage region
+		Vantages []*OperatorVantageReachability `json:"vantages"`
+		Issues   []*OperatorDiagnosisIssue      `json:"issues"`
+		// Healthy is whether no issue was found
+		Healthy bool `json:"healthy"`
+	}
+)
+
+// diagnoseOperator diagnoses both sockets of the operator, and requests the port check of the operator from the
+// vantages
+func (s *server) diagnoseOperator(ctx context.Context, operatorId string) (*OperatorDiagnosisResponse, error) {
+	operatorInfo, err := s.getOperatorInfo(ctx, operatorId)
+	if err != nil {
+		return nil, err
+	}
+	operatorSocket := core.OperatorSocket(operatorInfo.Socket)
+	dispersalSocket := operatorSocket.GetDispersalSocket()
+	retrievalSocket := operatorSocket.GetRetrievalSocket()
+
+	response := &OperatorDiagnosisResponse{
+		OperatorId: operatorId,
+		Vantages:   make([]*OperatorVantageReachability, 0, len(s.probeVantages)),
+	}
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		response.Dispersal = s.diagnoseSocket(ctx, dispersalSocket, false)
+	}()
+	go func() {
+		defer wg.Done()
+		response.Retrieval = s.diagnoseSocket(ctx, retrievalSocket, true)
+	}()
+	var mu sync.Mutex
+	for region, vantageURL := range s.probeVantages {
+		region, vantageURL := region, vantageURL
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			reachability := s.checkFromVantage(ctx, region, vantageURL, operatorId)
+			mu.Lock()
+			defer mu.Unlock()
+			response.Vantages = append(response.Vantages, reachability)
+		}()
+	}
+	wg.Wait()
+	sort.Slice(response.Vantages, func(i, j int) bool {
+		return response.Vantages[i].Region < response.Vantages[j].Region
+	})
+
+	response.Issues = diagnosisIssues(response)
+	response.Healthy = len(response.Issues) == 0
+	s.logger.Info("operator diagnosis", "operatorId", operatorId, "healthy", response.Healthy, "issues", len(response.Issues))
+	return response, nil
+}
+
+func (s *server) diagnoseSocket(ctx context.Context, socket string, retrieval bool) *OperatorSocketDiagnosis {
+	// The private sockets aren't dialed, so that the diagnosis can't be used to probe the internal network
+	if !ValidOperatorIP(ctx, socket, s.logger) {
+		return &OperatorSocketDiagnosis{
+			Socket: socket,
+			Port:   semver.PortUnreachable,
+			TLS:    semver.TLSUnknown,
+			Semver: semver.PortUnreachable,
+			Error:  "the socket doesn't resolve to a public IP address",
+		}
+	}
+
+	result := semver.DiagnoseSocket(ctx, socket, retrieval, diagnosisTimeout, s.logger)
+	diagnosis := &OperatorSocketDiagnosis{
+		Socket:    result.Socket,
+		Port:      result.Port,
+		TLS:       result.TLS,
+		Semver:    result.Semver,
+		LatencyMs: float64(result.Latency.Microseconds()) / 1000,
+		Error:     result.Error,
+	}
+	if result.ClockSkew != nil {
+		skewMs := float64(result.ClockSkew.Microseconds()) / 1000
+		diagnosis.ClockSkewMs = &skewMs
+	}
+	if result.Capabilities != nil {
+		diagnosis.QuorumIds = make([]uint32, len(result.Capabilities.QuorumIDs))
+		for i, quorumID := range result.Capabilities.QuorumIDs {
+			diagnosis.QuorumIds[i] = uint32(quorumID)
+		}
+		diagnosis.Features = result.Capabilities.Features
+	}
+	return diagnosis
+}
+
+// checkFromVantage requests the port check of the operator from the Data API of the vantage, whose URL is the base
+// URL of its v1 API
+func (s *server) checkFromVantage(ctx context.Context, region string, vantageURL string, operatorId string) *OperatorVantageReachability {
+	reachability := &OperatorVantageReachability{Region: region}
+	ctx, cancel := context.WithTimeout(ctx, vantageTimeout)
+	defer cancel()
+
+	requestURL := strings.TrimSuffix(vantageURL, "/") + "/operators-info/port-check?operator_id=" + url.QueryEscape(operatorId)
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, requestURL, nil)
+	if err != nil {
+		reachability.Error = err.Error()
+		return reachability
+	}
+	resp, err := s.vantageClient.Do(req)
+	if err != nil {
+		s.logger.Warn("vantage port check failed", "region", region, "operatorId", operatorId, "error", err)
+		reachability.Error = err.Error()
+		return reachability
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		reachability.Error = fmt.Sprintf("the port check returned status %d", resp.StatusCode)
+		return reachability
+	}
+	var portCheck OperatorPortCheckResponse
+	if err := json.NewDecoder(resp.Body).Decode(&portCheck); err != nil {
+		reachability.Error = fmt.Sprintf("failed to decode the port check: %v", err)
+		return reachability
+	}
+	reachability.DispersalOnline = portCheck.DispersalOnline
+	reachability.RetrievalOnline = portCheck.RetrievalOnline
+	return reachability
+}
+
+// diagnosisIssues returns the issues found by the diagnosis, with the action the operator can take for each
+func diagnosisIssues(diagnosis *OperatorDiagnosisResponse) []*OperatorDiagnosisIssue {
+	issues := make([]*OperatorDiagnosisIssue, 0)
+	add := func(check string, format string, args ...any) {
+		issues = append(issues, &OperatorDiagnosisIssue{Check: check, Message: fmt.Sprintf(format, args...)})
+	}
+
+	for _, socket := range []struct {
+		name      string
+		diagnosis *OperatorSocketDiagnosis
+	}{
+		{"dispersal", diagnosis.Dispersal},
+		{"retrieval", diagnosis.Retrieval},
+	} {
+		d := socket.diagnosis
+		if d.Port != semver.PortReachable {
+			add("port", "The %s socket %s isn't reachable (%s): check that the node is running, that it listens on the registered port and that the port is open to the internet, or update the registered socket.", socket.name, d.Socket, d.Port)
+			continue
+		}
+		if d.TLS == semver.TLSEnabled {
+			add("tls", "The %s socket %s serves TLS, but the dispersers and the retrievers connect to the nodes without TLS: terminate TLS in front of the node only for other ports.", socket.name, d.Socket)
+		}
+		if d.Error != "" {
+			switch d.Semver {
+			case "<0.8.0":
+				add("node_info", "The node at the %s socket %s doesn't serve the node info: upgrade it to the latest release.", socket.name, d.Socket)
+			case "filtered":
+				add("node_info", "The %s socket %s doesn't serve the %s service of the node: check that the ports of the sockets aren't swapped and that a proxy doesn't filter the gRPC services.", socket.name, d.Socket, socket.name)
+			default:
+				add("node_info", "The node info request to the %s socket %s failed with %s: check the logs of the node.", socket.name, d.Socket, d.Error)
+			}
+			continue
+		}
+		if d.ClockSkewMs != nil && time.Duration(*d.ClockSkewMs*float64(time.Millisecond)).Abs() > maxClockSkew {
+			add("clock_skew", "The clock of the node at the %s socket is %.0fms off: synchronize it with NTP.", socket.name, *d.ClockSkewMs)
+		}
+	}
+
+	dispersal, retrieval := diagnosis.Dispersal, diagnosis.Retrieval
+	if dispersal.Error == "" && retrieval.Error == "" && dispersal.Port == semver.PortReachable && retrieval.Port == semver.PortReachable && dispersal.Semver != retrieval.Semver {
+		add("semver", "The dispersal socket reports semver %s but the retrieval socket reports %s: check that both sockets route to the same node.", dispersal.Semver, retrieval.Semver)
+	}
+	// The capabilities are only compared if both sockets report them, as the nodes predating them report none
+	if (len(dispersal.QuorumIds) > 0 || len(dispersal.Features) > 0) && (len(retrieval.QuorumIds) > 0 || len(retrieval.Features) > 0) {
+		if fmt.Sprint(dispersal.QuorumIds) != fmt.Sprint(retrieval.QuorumIds) || fmt.Sprint(dispersal.Features) != fmt.Sprint(retrieval.Features) {
+			add("capabilities", "The dispersal socket reports quorums %v and features %v but the retrieval socket reports quorums %v and features %v: check that both sockets route to the same node.", dispersal.QuorumIds, dispersal.Features, retrieval.QuorumIds, retrieval.Features)
+		}
+	}
+
+	for _, vantage := range diagnosis.Vantages {
+		if vantage.Error != "" {
+			continue
+		}
+		if !vantage.DispersalOnline {
+			add("vantage", "The dispersal socket isn't reachable from %s: check that the firewall doesn't restrict the source regions.", vantage.Region)
+		}
+		if !vantage.RetrievalOnline {
+			add("vantage", "The retrieval socket isn't reachable from %s: check that the firewall doesn't restrict the source regions.", vantage.Region)
+		}
+	}
+	return issues
+}
@@ -0,0 +1,175 @@
+package dataapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/common/semver"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc"
+)
+
+// operatorInfoSource is a subgraph client which serves the info of a single operator
+type operatorInfoSource struct {
+	dataapi.SubgraphClient
+
+	operatorId string
+	socket     string
+}
+
+func (s *operatorInfoSource) QueryOperatorInfoByOperatorId(ctx context.Context, operatorId string) (*core.IndexedOperatorInfo, error) {
+	if operatorId != s.operatorId {
+		return nil, errors.New("operator not found")
+	}
+	return &core.IndexedOperatorInfo{Socket: s.socket}, nil
+}
+
+type nodeInfoServer struct {
+	pb.UnimplementedDispersalServer
+	pb.UnimplementedRetrievalServer
+	semver    string
+	clockSkew time.Duration
+	quorumIds []uint32
+}
+
+func (s *nodeInfoServer) NodeInfo(context.Context, *pb.NodeInfoRequest) (*pb.NodeInfoReply, error) {
+	return &pb.NodeInfoReply{Semver: s.semver, UnixTimeMs: uint64(time.Now().Add(s.clockSkew).UnixMilli()), QuorumIds: s.quorumIds}, nil
+}
+
+// serveNodeInfo serves the node info on a local port, of the retrieval service if retrieval is set
+func serveNodeInfo(t *testing.T, retrieval bool, info *nodeInfoServer) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	server := grpc.NewServer()
+	if retrieval {
+		pb.RegisterRetrievalServer(server, info)
+	} else {
+		pb.RegisterDispersalServer(server, info)
+	}
+	go func() { _ = server.Serve(listener) }()
+	t.Cleanup(server.Stop)
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	return port
+}
+
+func TestOperatorDiagnosis(t *testing.T) {
+	operatorId := "0xa96bfb4a7ca981ad365220f336dc5a3de0816ebd5130b79bbc85aca94bc9b6ab"
+	// The clock of the node behind the retrieval socket is off, and it runs another release serving other quorums
+	dispersalPort := serveNodeInfo(t, false, &nodeInfoServer{semver: "0.8.4", quorumIds: []uint32{0, 1}})
+	retrievalPort := serveNodeInfo(t, true, &nodeInfoServer{semver: "0.8.3", clockSkew: -5 * time.Second, quorumIds: []uint32{0}})
+	source := &operatorInfoSource{operatorId: operatorId, socket: fmt.Sprintf("127.0.0.1:%s;%s", dispersalPort, retrievalPort)}
+
+	// The retrieval port is filtered from one region, and the other region is down
+	filtered := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		assert.Equal(t, "/api/v1/operators-info/port-check", r.URL.Path)
+		assert.Equal(t, operatorId, r.URL.Query().Get("operator_id"))
+		_ = json.NewEncoder(w).Encode(&dataapi.OperatorPortCheckResponse{OperatorId: operatorId, DispersalOnline: true})
+	}))
+	defer filtered.Close()
+	down := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusInternalServerError)
+	}))
+	defer down.Close()
+
+	diagnosisConfig := config
+	diagnosisConfig.OperatorProbeVantages = map[string]string{
+		"eu-west-1":      filtered.URL + "/api/v1/",
+		"ap-southeast-1": down.URL + "/api/v1",
+	}
+	server := dataapi.NewServer(diagnosisConfig, inmem.NewBlobStore(), prometheusClient, source, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r := setUpRouter()
+	r.GET("/v1/operators-info/diagnosis", server.OperatorDiagnosis)
+	fetch := func(query string) (int, *dataapi.OperatorDiagnosisResponse) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/operators-info/diagnosis"+query, nil))
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		var response dataapi.OperatorDiagnosisResponse
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, &response))
+		}
+		return res.StatusCode, &response
+	}
+
+	code, diagnosis := fetch("?operator_id=" + operatorId)
+	require.Equal(t, http.StatusOK, code)
+	assert.Equal(t, operatorId, diagnosis.OperatorId)
+	assert.Equal(t, "127.0.0.1:"+dispersalPort, diagnosis.Dispersal.Socket)
+	assert.Equal(t, semver.PortReachable, diagnosis.Dispersal.Port)
+	assert.Equal(t, semver.TLSDisabled, diagnosis.Dispersal.TLS)
+	assert.Equal(t, "0.8.4", diagnosis.Dispersal.Semver)
+	assert.Empty(t, diagnosis.Dispersal.Error)
+	require.NotNil(t, diagnosis.Dispersal.ClockSkewMs)
+	assert.InDelta(t, 0, *diagnosis.Dispersal.ClockSkewMs, 1000)
+	assert.Equal(t, "0.8.3", diagnosis.Retrieval.Semver)
+	require.NotNil(t, diagnosis.Retrieval.ClockSkewMs)
+	assert.InDelta(t, -5000, *diagnosis.Retrieval.ClockSkewMs, 1000)
+	assert.Equal(t, []uint32{0, 1}, diagnosis.Dispersal.QuorumIds)
+	assert.Equal(t, []uint32{0}, diagnosis.Retrieval.QuorumIds)
+
+	assert.Equal(t, []*dataapi.OperatorVantageReachability{
+		{Region: "ap-southeast-1", Error: "the port check returned status 500"},
+		{Region: "eu-west-1", DispersalOnline: true},
+	}, diagnosis.Vantages)
+
+	assert.False(t, diagnosis.Healthy)
+	checks := make([]string, len(diagnosis.Issues))
+	for i, issue := range diagnosis.Issues {
+		checks[i] = issue.Check
+	}
+	assert.Equal(t, []string{"clock_skew", "semver", "capabilities", "vantage"}, checks)
+	assert.True(t, strings.Contains(diagnosis.Issues[2].Message, "retrieval socket reports quorums [0]"))
+	assert.True(t, strings.Contains(diagnosis.Issues[3].Message, "retrieval socket isn't reachable from eu-west-1"))
+
+	code, _ = fetch("")
+	assert.Equal(t, http.StatusBadRequest, code)
+	code, _ = fetch("?operator_id=0x1234")
+	assert.Equal(t, http.StatusNotFound, code)
+}
+
+func TestOperatorDiagnosisUnreachable(t *testing.T) {
+	operatorId := "0xa96bfb4a7ca981ad365220f336dc5a3de0816ebd5130b79bbc85aca94bc9b6ab"
+	dispersalPort := serveNodeInfo(t, false, &nodeInfoServer{semver: "0.8.4"})
+	// The retrieval port is closed, and the dispersal socket doesn't serve the retrieval service
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	require.NoError(t, err)
+	_, closedPort, err := net.SplitHostPort(listener.Addr().String())
+	require.NoError(t, err)
+	require.NoError(t, listener.Close())
+	source := &operatorInfoSource{operatorId: operatorId, socket: fmt.Sprintf("127.0.0.1:%s;%s", dispersalPort, closedPort)}
+
+	server := dataapi.NewServer(config, inmem.NewBlobStore(), prometheusClient, source, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r := setUpRouter()
+	r.GET("/v1/operators-info/diagnosis", server.OperatorDiagnosis)
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/operators-info/diagnosis?operator_id="+operatorId, nil))
+	require.Equal(t, http.StatusOK, w.Code)
+	var diagnosis dataapi.OperatorDiagnosisResponse
+	require.NoError(t, json.Unmarshal(w.Body.Bytes(), &diagnosis))
+
+	assert.Equal(t, semver.PortRefused, diagnosis.Retrieval.Port)
+	assert.Equal(t, semver.TLSUnknown, diagnosis.Retrieval.TLS)
+	assert.Nil(t, diagnosis.Retrieval.ClockSkewMs)
+	assert.Empty(t, diagnosis.Vantages)
+	assert.False(t, diagnosis.Healthy)
+	require.Len(t, diagnosis.Issues, 1)
+	assert.Equal(t, "port", diagnosis.Issues[0].Check)
+	assert.Contains(t, diagnosis.Issues[0].Message, "retrieval socket 127.0.0.1:"+closedPort+" isn't reachable (refused)")
+}
@@ -0,0 +1,50 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+)
+
+type (
+	// OperatorSocketHistoryEntry is an update of the socket of an operator, which moved it to another host if the
+	// socket changed
+	OperatorSocketHistoryEntry struct {
+		// OldSocket is empty for the socket the operator first registered with
+		OldSocket   string `json:"old_socket"`
+		NewSocket   string `json:"new_socket"`
+		BlockNumber uint64 `json:"block_number"`
+	}
+
+	OperatorSocketHistoryResponse struct {
+		OperatorId    string `json:"operator_id"`
+		CurrentSocket string `json:"current_socket"`
+		// Changes are the updates of the socket, ascending by block number
+		Changes []*OperatorSocketHistoryEntry `json:"changes"`
+	}
+)
+
+// getOperatorSocketHistory returns the socket updates of the operator indexed from the events of the registry
+// coordinator, each with the socket it replaced
+func (s *server) getOperatorSocketHistory(ctx context.Context, operatorId string) (*OperatorSocketHistoryResponse, error) {
+	socketUpdates, err := s.subgraphClient.QueryOperatorSocketUpdates(ctx, operatorId)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the socket updates of operator %s: %w", operatorId, err)
+	}
+	if socketUpdates == nil {
+		return nil, fmt.Errorf("operator %s: %w", operatorId, errNotFound)
+	}
+
+	response := &OperatorSocketHistoryResponse{
+		OperatorId: operatorId,
+		Changes:    make([]*OperatorSocketHistoryEntry, 0, len(socketUpdates)),
+	}
+	for _, update := range socketUpdates {
+		response.Changes = append(response.Changes, &OperatorSocketHistoryEntry{
+			OldSocket:   response.CurrentSocket,
+			NewSocket:   update.Socket,
+			BlockNumber: update.BlockNumber,
+		})
+		response.CurrentSocket = update.Socket
+	}
+	return response, nil
+}
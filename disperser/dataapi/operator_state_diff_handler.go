@@ -0,0 +1,79 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+// getOperatorStateDiff returns the operator state changes between two blocks.
+// If no quorum is given, all the quorums existing at the later block are diffed.
+func (s *server) getOperatorStateDiff(ctx context.Context, fromBlock, toBlock uint, quorumIDs []core.QuorumID) (*OperatorStateDiffResponse, error) {
+	if len(quorumIDs) == 0 {
+		quorumCount, err := s.transactor.GetQuorumCount(ctx, uint32(toBlock))
+		if err != nil {
+			return nil, fmt.Errorf("failed to get quorum count: %w", err)
+		}
+		// assume quorum IDs are consequent integers starting from 0
+		quorumIDs = make([]core.QuorumID, quorumCount)
+		for i := 0; i < int(quorumCount); i++ {
+			quorumIDs[i] = core.QuorumID(i)
+		}
+	}
+
+	diff, err := core.GetOperatorStateDiff(ctx, s.indexedChainState, fromBlock, toBlock, quorumIDs)
+	if err != nil {
+		return nil, err
+	}
+
+	return convertOperatorStateDiff(diff), nil
+}
+
+func convertOperatorStateDiff(diff *core.OperatorStateDiff) *OperatorStateDiffResponse {
+	response := &OperatorStateDiffResponse{
+		FromBlock:     diff.FromBlock,
+		ToBlock:       diff.ToBlock,
+		Quorums:       make(map[core.QuorumID]*QuorumStateDiffResponse, len(diff.Quorums)),
+		SocketChanges: make([]*OperatorSocketChange, 0, len(diff.SocketChanges)),
+	}
+
+	for quorumID, quorumDiff := range diff.Quorums {
+		quorumResponse := &QuorumStateDiffResponse{
+			Registered:   make([]string, len(quorumDiff.Registered)),
+			Deregistered: make([]string, len(quorumDiff.Deregistered)),
+			StakeChanges: make([]*OperatorStakeChange, 0, len(quorumDiff.StakeChanges)),
+		}
+		for i, opID := range quorumDiff.Registered {
+			quorumResponse.Registered[i] = opID.Hex()
+		}
+		for i, opID := range quorumDiff.Deregistered {
+			quorumResponse.Deregistered[i] = opID.Hex()
+		}
+		for opID, change := range quorumDiff.StakeChanges {
+			quorumResponse.StakeChanges = append(quorumResponse.StakeChanges, &OperatorStakeChange{
+				OperatorId: opID.Hex(),
+				Before:     change.Before,
+				After:      change.After,
+			})
+		}
+		sort.Slice(quorumResponse.StakeChanges, func(i, j int) bool {
+			return quorumResponse.StakeChanges[i].OperatorId < quorumResponse.StakeChanges[j].OperatorId
+		})
+		response.Quorums[quorumID] = quorumResponse
+	}
+
+	for opID, change := range diff.SocketChanges {
+		response.SocketChanges = append(response.SocketChanges, &OperatorSocketChange{
+			OperatorId: opID.Hex(),
+			Before:     change.Before,
+			After:      change.After,
+		})
+	}
+	sort.Slice(response.SocketChanges, func(i, j int) bool {
+		return response.SocketChanges[i].OperatorId < response.SocketChanges[j].OperatorId
+	})
+
+	return response
+}
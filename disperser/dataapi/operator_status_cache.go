@@ -0,0 +1,218 @@
+package dataapi
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser/common/semver"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/gammazero/workerpool"
+)
+
+const (
+	// operatorStatusIdleTTLs is the number of TTLs after which the refresher stops probing an operator whose status
+	// isn't requested anymore, e.g. an operator which left the queried time window
+	operatorStatusIdleTTLs = 10
+	// refreshNodeInfoTimeout bounds the node info requests of the refresher
+	refreshNodeInfoTimeout = time.Second
+)
+
+type (
+	// onlineStatus is the cached result of the online check of a retrieval socket
+	onlineStatus struct {
+		isOnline    bool
+		checkedAt   time.Time
+		requestedAt time.Time
+	}
+
+	// semverStatus is the cached result of the node info request of an operator
+	semverStatus struct {
+		// socket is the full socket of the operator, from which the dispersal socket is queried
+		socket      string
+		semver      string
+		checkedAt   time.Time
+		requestedAt time.Time
+	}
+
+	// operatorStatusCache caches the online status and the semver of the operators for ttl, so that the repeated
+	// queries of the operator endpoints don't probe every operator again. Nothing is cached if ttl is 0.
+	operatorStatusCache struct {
+		ttl    time.Duration
+		logger logging.Logger
+
+		mu      sync.Mutex
+		online  map[string]*onlineStatus
+		semvers map[core.OperatorID]*semverStatus
+	}
+)
+
+func newOperatorStatusCache(ttl time.Duration, logger logging.Logger) *operatorStatusCache {
+	return &operatorStatusCache{
+		ttl:     ttl,
+		logger:  logger.With("component", "OperatorStatusCache"),
+		online:  make(map[string]*onlineStatus),
+		semvers: make(map[core.OperatorID]*semverStatus),
+	}
+}
+
+// isOnline returns whether the retrieval socket accepts connections, dialing it only if the cached status is older
+// than the ttl, for up to timeout. The statuses of the checks cut short by the context aren't cached.
+func (c *operatorStatusCache) isOnline(ctx context.Context, socket string, timeout time.Duration) bool {
+	if c.ttl <= 0 {
+		return checkIsOperatorOnline(ctx, socket, timeout, c.logger)
+	}
+	now := time.Now()
+	c.mu.Lock()
+	status, ok := c.online[socket]
+	if ok {
+		status.requestedAt = now
+		if now.Sub(status.checkedAt) < c.ttl {
+			c.mu.Unlock()
+			return status.isOnline
+		}
+	}
+	c.mu.Unlock()
+
+	isOnline := checkIsOperatorOnline(ctx, socket, timeout, c.logger)
+	if ctx.Err() == nil {
+		c.setOnline(socket, isOnline, now)
+	}
+	return isOnline
+}
+
+func (c *operatorStatusCache) setOnline(socket string, isOnline bool, requestedAt time.Time) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	status, ok := c.online[socket]
+	if !ok {
+		status = &onlineStatus{}
+		c.online[socket] = status
+	}
+	status.isOnline = isOnline
+	status.checkedAt = time.Now()
+	if requestedAt.After(status.requestedAt) {
+		status.requestedAt = requestedAt
+	}
+}
+
+// scanSemvers returns the number of operators by semver, querying the node info of the operators whose cached
+// semver is older than the ttl or whose socket changed
+func (c *operatorStatusCache) scanSemvers(ctx context.Context, operators map[core.OperatorID]*core.IndexedOperatorInfo, numWorkers int, nodeInfoTimeout time.Duration) map[string]int {
+	if c.ttl <= 0 {
+		return semver.ScanOperators(ctx, operators, numWorkers, nodeInfoTimeout, c.logger)
+	}
+	now := time.Now()
+	semvers := make(map[string]int)
+	stale := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	c.mu.Lock()
+	for operatorId, info := range operators {
+		status, ok := c.semvers[operatorId]
+		if ok && status.socket == info.Socket && now.Sub(status.checkedAt) < c.ttl {
+			status.requestedAt = now
+			semvers[status.semver]++
+			continue
+		}
+		stale[operatorId] = info
+	}
+	c.mu.Unlock()
+
+	for _, result := range c.scanAndCacheSemvers(ctx, stale, numWorkers, nodeInfoTimeout, now) {
+		semvers[result.Semver]++
+	}
+	return semvers
+}
+
+// scanSemverResults queries the node info of every operator, whatever their cached semvers, and returns the result
+// of each operator. The semvers are cached for the next scans.
+func (c *operatorStatusCache) scanSemverResults(ctx context.Context, operators map[core.OperatorID]*core.IndexedOperatorInfo, numWorkers int, nodeInfoTimeout time.Duration) []*semver.OperatorSemver {
+	if c.ttl <= 0 {
+		return semver.ScanOperatorSemvers(ctx, operators, numWorkers, nodeInfoTimeout, false, c.logger)
+	}
+	return c.scanAndCacheSemvers(ctx, operators, numWorkers, nodeInfoTimeout, time.Now())
+}
+
+// scanAndCacheSemvers scans the operators and caches their semvers, except those of the operators left unscanned
+// when the context is done
+func (c *operatorStatusCache) scanAndCacheSemvers(ctx context.Context, operators map[core.OperatorID]*core.IndexedOperatorInfo, numWorkers int, nodeInfoTimeout time.Duration, requestedAt time.Time) []*semver.OperatorSemver {
+	if len(operators) == 0 {
+		return nil
+	}
+	results := semver.ScanOperatorSemvers(ctx, operators, numWorkers, nodeInfoTimeout, false, c.logger)
+	checkedAt := time.Now()
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for _, result := range results {
+		if result.Semver == "canceled" {
+			continue
+		}
+		status, ok := c.semvers[result.OperatorId]
+		if !ok {
+			status = &semverStatus{}
+			c.semvers[result.OperatorId] = status
+		}
+		status.socket = operators[result.OperatorId].Socket
+		status.semver = result.Semver
+		status.checkedAt = checkedAt
+		if requestedAt.After(status.requestedAt) {
+			status.requestedAt = requestedAt
+		}
+	}
+	return results
+}
+
+// Start probes the cached operators again at each interval until the context is done, so that the requests find
+// fresh statuses. The operators whose statuses weren't requested for operatorStatusIdleTTLs TTLs are evicted instead.
+// The operators are probed by numWorkers workers, which dial each socket for up to onlineCheckTimeout.
+func (c *operatorStatusCache) Start(ctx context.Context, interval time.Duration, numWorkers int, onlineCheckTimeout time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				c.refresh(ctx, time.Now(), numWorkers, onlineCheckTimeout)
+			}
+		}
+	}()
+}
+
+// refresh evicts the idle statuses and probes the operators of the others again
+func (c *operatorStatusCache) refresh(ctx context.Context, now time.Time, numWorkers int, onlineCheckTimeout time.Duration) {
+	idleBefore := now.Add(-operatorStatusIdleTTLs * c.ttl)
+	sockets := make([]string, 0)
+	operators := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	c.mu.Lock()
+	for socket, status := range c.online {
+		if status.requestedAt.Before(idleBefore) {
+			delete(c.online, socket)
+			continue
+		}
+		sockets = append(sockets, socket)
+	}
+	for operatorId, status := range c.semvers {
+		if status.requestedAt.Before(idleBefore) {
+			delete(c.semvers, operatorId)
+			continue
+		}
+		operators[operatorId] = &core.IndexedOperatorInfo{Socket: status.socket}
+	}
+	c.mu.Unlock()
+
+	wp := workerpool.New(numWorkers)
+	for _, socket := range sockets {
+		socket := socket
+		wp.Submit(func() {
+			isOnline := checkIsOperatorOnline(ctx, socket, onlineCheckTimeout, c.logger)
+			if ctx.Err() == nil {
+				c.setOnline(socket, isOnline, time.Time{})
+			}
+		})
+	}
+	wp.StopWait()
+	c.scanAndCacheSemvers(ctx, operators, numWorkers, refreshNodeInfoTimeout, time.Time{})
+	c.logger.Debug("Refreshed operator statuses", "numSockets", len(sockets), "numSemvers", len(operators))
+}
@@ -0,0 +1,276 @@
+package dataapi
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync"
+	"time"
+
+	commondynamodb "github.com/Layr-Labs/eigenda/common/aws/dynamodb"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/aws/aws-sdk-go-v2/aws"
+	"github.com/aws/aws-sdk-go-v2/feature/dynamodb/attributevalue"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb"
+	"github.com/aws/aws-sdk-go-v2/service/dynamodb/types"
+	"github.com/gammazero/workerpool"
+)
+
+const (
+	// operatorUptimeRetention is how long the hourly uptime of the operators is kept, which bounds the longest window
+	operatorUptimeRetention = 31 * 24 * time.Hour
+	// operatorUptimeProbeTimeout bounds the dial of each socket probed by the uptime tracker
+	operatorUptimeProbeTimeout = 3 * time.Second
+)
+
+// operatorUptimeWindows are the windows over which the uptime of an operator can be queried
+var operatorUptimeWindows = map[string]time.Duration{
+	"1d":  24 * time.Hour,
+	"7d":  7 * 24 * time.Hour,
+	"30d": 30 * 24 * time.Hour,
+}
+
+type (
+	// OperatorProbe is the result of the probe of the sockets of an operator
+	OperatorProbe struct {
+		OperatorId      string
+		DispersalOnline bool
+		RetrievalOnline bool
+	}
+
+	// OperatorUptimeBucket counts the probes of an operator during an hour
+	OperatorUptimeBucket struct {
+		OperatorId string `dynamodbav:"OperatorId"`
+		// HourStart is the unix time in seconds of the start of the hour
+		HourStart          int64 `dynamodbav:"HourStart"`
+		NumProbes          int64 `dynamodbav:"NumProbes"`
+		NumDispersalOnline int64 `dynamodbav:"NumDispersalOnline"`
+		NumRetrievalOnline int64 `dynamodbav:"NumRetrievalOnline"`
+		// NumOnline is the number of probes for which both sockets were online
+		NumOnline int64 `dynamodbav:"NumOnline"`
+	}
+
+	// OperatorUptimeStore stores the probes of the operators aggregated by hour
+	OperatorUptimeStore interface {
+		// RecordProbes adds the probes made at probedAt to the hourly buckets of the operators
+		RecordProbes(ctx context.Context, probedAt time.Time, probes []*OperatorProbe) error
+		// GetUptimeBuckets returns the hourly buckets of the operator which start at or after since
+		GetUptimeBuckets(ctx context.Context, operatorId string, since time.Time) ([]*OperatorUptimeBucket, error)
+	}
+
+	OperatorUptimeResponse struct {
+		OperatorId string `json:"operator_id"`
+		Window     string `json:"window"`
+		// NumProbes is the number of probes of the operator during the window
+		NumProbes int64 `json:"num_probes"`
+		// Uptime is the percentage of the probes for which both sockets were online
+		Uptime          float64 `json:"uptime"`
+		DispersalUptime float64 `json:"dispersal_uptime"`
+		RetrievalUptime float64 `json:"retrieval_uptime"`
+	}
+)
+
+type dynamoOperatorUptimeStore struct {
+	client    *commondynamodb.Client
+	tableName string
+}
+
+var _ OperatorUptimeStore = (*dynamoOperatorUptimeStore)(nil)
+
+// NewDynamoOperatorUptimeStore returns an OperatorUptimeStore backed by the DynamoDB table of
+// GenerateOperatorUptimeTableSchema. The buckets expire after operatorUptimeRetention if the TTL of the table is
+// enabled on the Expiry attribute.
+func NewDynamoOperatorUptimeStore(client *commondynamodb.Client, tableName string) OperatorUptimeStore {
+	return &dynamoOperatorUptimeStore{client: client, tableName: tableName}
+}
+
+func (s *dynamoOperatorUptimeStore) RecordProbes(ctx context.Context, probedAt time.Time, probes []*OperatorProbe) error {
+	hourStart := probedAt.Truncate(time.Hour)
+	expiry := hourStart.Add(operatorUptimeRetention).Unix()
+	for _, probe := range probes {
+		increments := map[string]int64{
+			"NumProbes":          1,
+			"NumDispersalOnline": boolToInt64(probe.DispersalOnline),
+			"NumRetrievalOnline": boolToInt64(probe.RetrievalOnline),
+			"NumOnline":          boolToInt64(probe.DispersalOnline && probe.RetrievalOnline),
+		}
+		_, err := s.client.IncrementItem(ctx, s.tableName, commondynamodb.Key{
+			"OperatorId": &types.AttributeValueMemberS{Value: probe.OperatorId},
+			"HourStart":  &types.AttributeValueMemberN{Value: strconv.FormatInt(hourStart.Unix(), 10)},
+		}, increments, commondynamodb.Item{
+			"Expiry": &types.AttributeValueMemberN{Value: strconv.FormatInt(expiry, 10)},
+		})
+		if err != nil {
+			return fmt.Errorf("failed to record the probe of operator %s: %w", probe.OperatorId, err)
+		}
+	}
+	return nil
+}
+
+func (s *dynamoOperatorUptimeStore) GetUptimeBuckets(ctx context.Context, operatorId string, since time.Time) ([]*OperatorUptimeBucket, error) {
+	items, err := s.client.Query(ctx, s.tableName, "OperatorId = :operatorId AND HourStart >= :since", commondynamodb.ExpresseionValues{
+		":operatorId": &types.AttributeValueMemberS{Value: operatorId},
+		":since":      &types.AttributeValueMemberN{Value: strconv.FormatInt(since.Unix(), 10)},
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the uptime of operator %s: %w", operatorId, err)
+	}
+	buckets := make([]*OperatorUptimeBucket, 0, len(items))
+	if err := attributevalue.UnmarshalListOfMaps(items, &buckets); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal the uptime of operator %s: %w", operatorId, err)
+	}
+	return buckets, nil
+}
+
+// GenerateOperatorUptimeTableSchema returns the schema of the table of the uptime of the operators, keyed by operator
+// ID and start of the hour
+func GenerateOperatorUptimeTableSchema(tableName string, readCapacityUnits int64, writeCapacityUnits int64) *dynamodb.CreateTableInput {
+	return &dynamodb.CreateTableInput{
+		AttributeDefinitions: []types.AttributeDefinition{
+			{
+				AttributeName: aws.String("OperatorId"),
+				AttributeType: types.ScalarAttributeTypeS,
+			},
+			{
+				AttributeName: aws.String("HourStart"),
+				AttributeType: types.ScalarAttributeTypeN,
+			},
+		},
+		KeySchema: []types.KeySchemaElement{
+			{
+				AttributeName: aws.String("OperatorId"),
+				KeyType:       types.KeyTypeHash,
+			},
+			{
+				AttributeName: aws.String("HourStart"),
+				KeyType:       types.KeyTypeRange,
+			},
+		},
+		TableName: aws.String(tableName),
+		ProvisionedThroughput: &types.ProvisionedThroughput{
+			ReadCapacityUnits:  aws.Int64(readCapacityUnits),
+			WriteCapacityUnits: aws.Int64(writeCapacityUnits),
+		},
+	}
+}
+
+func boolToInt64(b bool) int64 {
+	if b {
+		return 1
+	}
+	return 0
+}
+
+// operatorUptimeTracker periodically probes the dispersal and retrieval sockets of the registered operators and
+// records the results in the uptime store
+type operatorUptimeTracker struct {
+	store             OperatorUptimeStore
+	indexedChainState core.IndexedChainState
+	// numWorkers is the number of concurrent probes
+	numWorkers int
+	logger     logging.Logger
+
+	// mu serializes the rounds of probes, so that a slow round doesn't overlap with the next one
+	mu sync.Mutex
+}
+
+func newOperatorUptimeTracker(store OperatorUptimeStore, indexedChainState core.IndexedChainState, numWorkers int, logger logging.Logger) *operatorUptimeTracker {
+	return &operatorUptimeTracker{
+		store:             store,
+		indexedChainState: indexedChainState,
+		numWorkers:        numWorkers,
+		logger:            logger.With("component", "OperatorUptimeTracker"),
+	}
+}
+
+// Start probes the operators at each interval until the context is done
+func (t *operatorUptimeTracker) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				if err := t.probe(ctx, time.Now()); err != nil && ctx.Err() == nil {
+					t.logger.Error("failed to probe the operators", "err", err)
+				}
+			}
+		}
+	}()
+}
+
+// probe probes the operators registered at the current block and records the results at now
+func (t *operatorUptimeTracker) probe(ctx context.Context, now time.Time) error {
+	t.mu.Lock()
+	defer t.mu.Unlock()
+
+	currentBlock, err := t.indexedChainState.GetCurrentBlockNumber()
+	if err != nil {
+		return fmt.Errorf("failed to fetch current block number: %w", err)
+	}
+	operatorState, err := t.indexedChainState.GetIndexedOperatorState(ctx, currentBlock, []core.QuorumID{0, 1, 2})
+	if err != nil {
+		return fmt.Errorf("failed to fetch indexed operator state: %w", err)
+	}
+
+	var probesMu sync.Mutex
+	probes := make([]*OperatorProbe, 0, len(operatorState.IndexedOperators))
+	wp := workerpool.New(t.numWorkers)
+	for operatorId, operatorInfo := range operatorState.IndexedOperators {
+		operatorId, operatorInfo := operatorId, operatorInfo
+		wp.Submit(func() {
+			socket := core.OperatorSocket(operatorInfo.Socket)
+			probe := &OperatorProbe{
+				OperatorId:      operatorId.Hex(),
+				DispersalOnline: checkIsOperatorOnline(ctx, socket.GetDispersalSocket(), operatorUptimeProbeTimeout, t.logger),
+				RetrievalOnline: checkIsOperatorOnline(ctx, socket.GetRetrievalSocket(), operatorUptimeProbeTimeout, t.logger),
+			}
+			probesMu.Lock()
+			probes = append(probes, probe)
+			probesMu.Unlock()
+		})
+	}
+	wp.StopWait()
+	// The probes cut short by the context would count as offline
+	if ctx.Err() != nil {
+		return ctx.Err()
+	}
+
+	if err := t.store.RecordProbes(ctx, now, probes); err != nil {
+		return err
+	}
+	t.logger.Debug("Probed the operators", "numOperators", len(probes))
+	return nil
+}
+
+// getOperatorUptime returns the uptime of the operator over the window ending at now. The window starts at the start
+// of the hour, so that the bucket of the first hour is counted in full.
+func (s *server) getOperatorUptime(ctx context.Context, operatorId string, window string, now time.Time) (*OperatorUptimeResponse, error) {
+	duration, ok := operatorUptimeWindows[window]
+	if !ok {
+		return nil, fmt.Errorf("unknown uptime window %q", window)
+	}
+	buckets, err := s.uptimeStore.GetUptimeBuckets(ctx, operatorId, now.Add(-duration).Truncate(time.Hour))
+	if err != nil {
+		return nil, err
+	}
+
+	response := &OperatorUptimeResponse{OperatorId: operatorId, Window: window}
+	var numOnline, numDispersalOnline, numRetrievalOnline int64
+	for _, bucket := range buckets {
+		response.NumProbes += bucket.NumProbes
+		numOnline += bucket.NumOnline
+		numDispersalOnline += bucket.NumDispersalOnline
+		numRetrievalOnline += bucket.NumRetrievalOnline
+	}
+	if response.NumProbes == 0 {
+		return nil, errNotFound
+	}
+	response.Uptime = 100 * float64(numOnline) / float64(response.NumProbes)
+	response.DispersalUptime = 100 * float64(numDispersalOnline) / float64(response.NumProbes)
+	response.RetrievalUptime = 100 * float64(numRetrievalOnline) / float64(response.NumProbes)
+	return response, nil
+}
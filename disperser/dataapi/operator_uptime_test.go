@@ -0,0 +1,174 @@
+package dataapi_test
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+	"time"
+
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"go.uber.org/goleak"
+)
+
+// uptimeStore is an in-memory OperatorUptimeStore
+type uptimeStore struct {
+	mu      sync.Mutex
+	buckets map[string]map[int64]*dataapi.OperatorUptimeBucket
+}
+
+func newUptimeStore() *uptimeStore {
+	return &uptimeStore{buckets: make(map[string]map[int64]*dataapi.OperatorUptimeBucket)}
+}
+
+func (s *uptimeStore) RecordProbes(ctx context.Context, probedAt time.Time, probes []*dataapi.OperatorProbe) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	hourStart := probedAt.Truncate(time.Hour).Unix()
+	for _, probe := range probes {
+		if _, ok := s.buckets[probe.OperatorId]; !ok {
+			s.buckets[probe.OperatorId] = make(map[int64]*dataapi.OperatorUptimeBucket)
+		}
+		bucket, ok := s.buckets[probe.OperatorId][hourStart]
+		if !ok {
+			bucket = &dataapi.OperatorUptimeBucket{OperatorId: probe.OperatorId, HourStart: hourStart}
+			s.buckets[probe.OperatorId][hourStart] = bucket
+		}
+		bucket.NumProbes++
+		if probe.DispersalOnline {
+			bucket.NumDispersalOnline++
+		}
+		if probe.RetrievalOnline {
+			bucket.NumRetrievalOnline++
+		}
+		if probe.DispersalOnline && probe.RetrievalOnline {
+			bucket.NumOnline++
+		}
+	}
+	return nil
+}
+
+func (s *uptimeStore) GetUptimeBuckets(ctx context.Context, operatorId string, since time.Time) ([]*dataapi.OperatorUptimeBucket, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	buckets := make([]*dataapi.OperatorUptimeBucket, 0)
+	for hourStart, bucket := range s.buckets[operatorId] {
+		if hourStart >= since.Unix() {
+			copied := *bucket
+			buckets = append(buckets, &copied)
+		}
+	}
+	return buckets, nil
+}
+
+func (s *uptimeStore) numOperators() int {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return len(s.buckets)
+}
+
+func fetchOperatorUptime(t *testing.T, handler http.Handler, query string) (int, *dataapi.OperatorUptimeResponse) {
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/operators-info/uptime"+query, nil)
+	handler.ServeHTTP(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	require.NoError(t, err)
+	if res.StatusCode != http.StatusOK {
+		return res.StatusCode, nil
+	}
+	var response dataapi.OperatorUptimeResponse
+	require.NoError(t, json.Unmarshal(data, &response))
+	return res.StatusCode, &response
+}
+
+func TestFetchOperatorUptime(t *testing.T) {
+	r := setUpRouter()
+
+	// The uptime isn't served if it isn't tracked
+	server := dataapi.NewServer(config, blobstore, prometheusClient, subgraphClient, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r.GET("/v1/operators-info/uptime", server.FetchOperatorUptime)
+	status, _ := fetchOperatorUptime(t, r, "?operator_id=0x01")
+	assert.Equal(t, http.StatusServiceUnavailable, status)
+
+	store := newUptimeStore()
+	now := time.Now()
+	require.NoError(t, store.RecordProbes(context.Background(), now, []*dataapi.OperatorProbe{
+		{OperatorId: "0x01", DispersalOnline: true, RetrievalOnline: true},
+	}))
+	require.NoError(t, store.RecordProbes(context.Background(), now.Add(-2*time.Hour), []*dataapi.OperatorProbe{
+		{OperatorId: "0x01", DispersalOnline: true, RetrievalOnline: false},
+	}))
+	require.NoError(t, store.RecordProbes(context.Background(), now.Add(-3*24*time.Hour), []*dataapi.OperatorProbe{
+		{OperatorId: "0x01", DispersalOnline: false, RetrievalOnline: false},
+		{OperatorId: "0x01", DispersalOnline: false, RetrievalOnline: false},
+	}))
+	uptimeConfig := config
+	uptimeConfig.OperatorUptimeStore = store
+	server = dataapi.NewServer(uptimeConfig, blobstore, prometheusClient, subgraphClient, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r = setUpRouter()
+	r.GET("/v1/operators-info/uptime", server.FetchOperatorUptime)
+
+	status, response := fetchOperatorUptime(t, r, "?operator_id=0x01&window=1d")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, &dataapi.OperatorUptimeResponse{
+		OperatorId:      "0x01",
+		Window:          "1d",
+		NumProbes:       2,
+		Uptime:          50,
+		DispersalUptime: 100,
+		RetrievalUptime: 50,
+	}, response)
+
+	// The window defaults to 7d
+	status, response = fetchOperatorUptime(t, r, "?operator_id=0x01")
+	require.Equal(t, http.StatusOK, status)
+	assert.Equal(t, "7d", response.Window)
+	assert.Equal(t, int64(4), response.NumProbes)
+	assert.Equal(t, 25.0, response.Uptime)
+	assert.Equal(t, 50.0, response.DispersalUptime)
+	assert.Equal(t, 25.0, response.RetrievalUptime)
+
+	// The operators which were never probed aren't found
+	status, _ = fetchOperatorUptime(t, r, "?operator_id=0x02")
+	assert.Equal(t, http.StatusNotFound, status)
+
+	status, _ = fetchOperatorUptime(t, r, "?window=1d")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetchOperatorUptime(t, r, "?operator_id=0x01&window=2d")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
+func TestOperatorUptimeTracker(t *testing.T) {
+	defer goleak.VerifyNone(t)
+
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 2, 1: 1})
+	require.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+	store := newUptimeStore()
+	uptimeConfig := config
+	uptimeConfig.OperatorUptimeStore = store
+	uptimeConfig.OperatorUptimeProbeInterval = 20 * time.Millisecond
+	server := dataapi.NewServer(uptimeConfig, blobstore, prometheusClient, subgraphClient, mockTx, nil, mockChainState, indexedChainState, mockLogger, metrics, &MockGRPNilConnection{}, nil, nil)
+	defer func() { assert.NoError(t, server.Shutdown()) }()
+
+	// The operators of the mock aren't running, so they are probed offline
+	assert.Eventually(t, func() bool { return store.numOperators() == 2 }, 10*time.Second, 10*time.Millisecond)
+	store.mu.Lock()
+	defer store.mu.Unlock()
+	for _, buckets := range store.buckets {
+		for _, bucket := range buckets {
+			assert.Positive(t, bucket.NumProbes)
+			assert.Zero(t, bucket.NumDispersalOnline)
+			assert.Zero(t, bucket.NumRetrievalOnline)
+			assert.Zero(t, bucket.NumOnline)
+		}
+	}
+}
@@ -18,6 +18,7 @@ var (
 
 type Api interface {
 	QueryRange(ctx context.Context, query string, start time.Time, end time.Time, step time.Duration) (model.Value, v1.Warnings, error)
+	Query(ctx context.Context, query string, ts time.Time) (model.Value, v1.Warnings, error)
 }
 
 type prometheusApi struct {
@@ -64,3 +65,11 @@ func (p *prometheusApi) QueryRange(
 	}
 	return result, warnings, nil
 }
+
+func (p *prometheusApi) Query(ctx context.Context, query string, ts time.Time) (model.Value, v1.Warnings, error) {
+	result, warnings, err := p.api.Query(ctx, query, ts)
+	if err != nil {
+		return nil, nil, err
+	}
+	return result, warnings, nil
+}
@@ -28,3 +28,16 @@ func (m *MockPrometheusApi) QueryRange(ctx context.Context, query string, start
 	}
 	return value, warnings, args.Error(2)
 }
+
+func (m *MockPrometheusApi) Query(ctx context.Context, query string, ts time.Time) (model.Value, v1.Warnings, error) {
+	args := m.Called(query)
+	var value model.Value
+	if args.Get(0) != nil {
+		value = args.Get(0).(model.Value)
+	}
+	var warnings v1.Warnings
+	if args.Get(1) != nil {
+		warnings = args.Get(1).(v1.Warnings)
+	}
+	return value, warnings, args.Error(2)
+}
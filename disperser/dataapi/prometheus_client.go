@@ -3,8 +3,11 @@ package dataapi
 import (
 	"context"
 	"fmt"
+	"math"
+	"strconv"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/prometheus"
 	"github.com/prometheus/common/model"
 )
@@ -18,6 +21,9 @@ type (
 	PrometheusClient interface {
 		QueryDisperserBlobSizeBytesPerSecond(ctx context.Context, start time.Time, end time.Time) (*PrometheusResult, error)
 		QueryDisperserAvgThroughputBlobSizeBytes(ctx context.Context, start time.Time, end time.Time, windowSizeInSec uint16) (*PrometheusResult, error)
+		QueryBlobTimeToFinalityQuantile(ctx context.Context, stage string, quantile float64, window time.Duration, at time.Time) (float64, error)
+		QueryBlobTimeToFinalityCount(ctx context.Context, stage string, window time.Duration, at time.Time) (float64, error)
+		QueryDisperserThroughputUtilization(ctx context.Context, at time.Time) (map[core.QuorumID]float64, error)
 	}
 
 	PrometheusResultValues struct {
@@ -51,6 +57,69 @@ func (pc *prometheusClient) QueryDisperserAvgThroughputBlobSizeBytes(ctx context
 	return pc.queryRange(ctx, query, start, end)
 }
 
+// QueryBlobTimeToFinalityQuantile returns the given quantile of the time (in ms) from dispersal request to the
+// given stage ("confirmed" or "finalized") of the blobs that reached the stage within the window ending at the given time.
+func (pc *prometheusClient) QueryBlobTimeToFinalityQuantile(ctx context.Context, stage string, quantile float64, window time.Duration, at time.Time) (float64, error) {
+	query := fmt.Sprintf("histogram_quantile(%g, sum by (le) (increase(eigenda_batcher_blob_time_to_finality_ms_bucket{stage=\"%s\",cluster=\"%s\"}[%ds])))", quantile, stage, pc.cluster, int64(window.Seconds()))
+	return pc.queryScalar(ctx, query, at)
+}
+
+// QueryBlobTimeToFinalityCount returns the number of blobs that reached the given stage within the window ending at the given time.
+func (pc *prometheusClient) QueryBlobTimeToFinalityCount(ctx context.Context, stage string, window time.Duration, at time.Time) (float64, error) {
+	query := fmt.Sprintf("sum(increase(eigenda_batcher_blob_time_to_finality_ms_count{stage=\"%s\",cluster=\"%s\"}[%ds]))", stage, pc.cluster, int64(window.Seconds()))
+	return pc.queryScalar(ctx, query, at)
+}
+
+// QueryDisperserThroughputUtilization returns the fraction of the system throughput rate limit of each quorum which is
+// used at the given time, as reported by the disperser API servers.
+func (pc *prometheusClient) QueryDisperserThroughputUtilization(ctx context.Context, at time.Time) (map[core.QuorumID]float64, error) {
+	query := fmt.Sprintf("max by (quorum) (eigenda_disperser_system_throughput_utilization{cluster=\"%s\"})", pc.cluster)
+	v, _, err := pc.api.Query(ctx, query, at)
+	if err != nil {
+		return nil, err
+	}
+
+	vector, ok := v.(model.Vector)
+	if !ok {
+		return nil, fmt.Errorf("unexpected prometheus result type %s", v.Type())
+	}
+	utilization := make(map[core.QuorumID]float64, len(vector))
+	for _, sample := range vector {
+		quorum, err := strconv.ParseUint(string(sample.Metric["quorum"]), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quorum label %q: %w", sample.Metric["quorum"], err)
+		}
+		value := float64(sample.Value)
+		if math.IsNaN(value) || math.IsInf(value, 0) {
+			continue
+		}
+		utilization[core.QuorumID(quorum)] = value
+	}
+	return utilization, nil
+}
+
+// queryScalar runs an instant query that is expected to return a single sample.
+// It returns 0 if there is no sample or the sample is not a number, e.g. the quantile of an empty histogram.
+func (pc *prometheusClient) queryScalar(ctx context.Context, query string, at time.Time) (float64, error) {
+	v, _, err := pc.api.Query(ctx, query, at)
+	if err != nil {
+		return 0, err
+	}
+
+	vector, ok := v.(model.Vector)
+	if !ok {
+		return 0, fmt.Errorf("unexpected prometheus result type %s", v.Type())
+	}
+	if len(vector) == 0 {
+		return 0, nil
+	}
+	value := float64(vector[0].Value)
+	if math.IsNaN(value) || math.IsInf(value, 0) {
+		return 0, nil
+	}
+	return value, nil
+}
+
 func (pc *prometheusClient) queryRange(ctx context.Context, query string, start time.Time, end time.Time) (*PrometheusResult, error) {
 	numSecondsInTimeRange := end.Sub(start).Seconds()
 	step := uint64(numSecondsInTimeRange / maxNumOfDataPoints)
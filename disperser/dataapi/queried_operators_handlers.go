@@ -2,15 +2,24 @@ package dataapi
 
 import (
 	"context"
+	"encoding/base64"
+	"encoding/json"
+	"errors"
 	"fmt"
+	"math"
+	"math/big"
 	"net"
+	"slices"
 	"sort"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser/common/semver"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/gammazero/workerpool"
+	"github.com/gin-gonic/gin"
 )
 
 type OperatorOnlineStatus struct {
@@ -19,46 +28,105 @@ type OperatorOnlineStatus struct {
 	OperatorProcessError string
 }
 
-var (
-	// TODO: Poolsize should be configurable
-	// Observe performance and tune accordingly
-	poolSize                        = 50
-	operatorOnlineStatusresultsChan chan *QueriedStateOperatorMetadata
+const (
+	defaultOperatorsPageSize = 100
+	// maxOperatorsPageSize bounds the limit of the operator state endpoints
+	maxOperatorsPageSize = 1000
+	// defaultNodeInfoWorkers is the default number of concurrent node info requests of the scans of the operators
+	defaultNodeInfoWorkers = 20
+	// defaultOperatorOnlineCheckWorkers is the default number of concurrent online checks of the operators
+	defaultOperatorOnlineCheckWorkers = 50
+	// defaultOperatorOnlineCheckTimeout is the default bound of the dial of the socket of an operator checked online
+	defaultOperatorOnlineCheckTimeout = 10 * time.Second
 )
 
-// Function to get registered operators for given number of days
-// Queries subgraph for deregistered operators
-// Process operator online status
-// Returns list of Operators with their online status, socket address and block number they deregistered
-func (s *server) getDeregisteredOperatorForDays(ctx context.Context, days int32) ([]*QueriedStateOperatorMetadata, error) {
-	// Track time taken to get deregistered operators
-	startTime := time.Now()
+// operatorsQuery selects a page of the operators registered or deregistered in a time window
+type operatorsQuery struct {
+	// quorumIDs are the quorums the operators registered in or deregistered from. The operators of all the quorums
+	// are selected if it is empty.
+	quorumIDs []core.QuorumID
+	// online selects the operators by online status if it is set
+	online *bool
+	// limit is the maximum number of operators of the page. The page isn't bounded if it is 0.
+	limit int
+	// cursor is the last operator of the previous page, or nil for the first page
+	cursor *operatorCursor
+}
 
-	indexedDeregisteredOperatorState, err := s.subgraphClient.QueryIndexedOperatorsWithStateForTimeWindow(ctx, days, Deregistered)
-	if err != nil {
-		return nil, err
-	}
+// operatorCursor is the position of an operator in the operators ordered by block number and operator ID
+type operatorCursor struct {
+	BlockNumber uint   `json:"block_number"`
+	OperatorId  string `json:"operator_id"`
+}
 
-	// Convert the map to a slice.
-	operators := indexedDeregisteredOperatorState.Operators
+func (c *operatorCursor) before(blockNumber uint, operatorId string) bool {
+	return c.BlockNumber < blockNumber || (c.BlockNumber == blockNumber && c.OperatorId < operatorId)
+}
 
-	operatorOnlineStatusresultsChan = make(chan *QueriedStateOperatorMetadata, len(operators))
-	processOperatorOnlineCheck(indexedDeregisteredOperatorState, operatorOnlineStatusresultsChan, s.logger)
+func encodeOperatorCursor(cursor *operatorCursor) (string, error) {
+	jsonBytes, err := json.Marshal(cursor)
+	if err != nil {
+		return "", fmt.Errorf("failed to marshal cursor: %w", err)
+	}
+	return base64.URLEncoding.EncodeToString(jsonBytes), nil
+}
 
-	// Collect results of work done
-	DeregisteredOperatorMetadata := make([]*QueriedStateOperatorMetadata, 0, len(operators))
-	for range operators {
-		metadata := <-operatorOnlineStatusresultsChan
-		DeregisteredOperatorMetadata = append(DeregisteredOperatorMetadata, metadata)
+func decodeOperatorCursor(token string) (*operatorCursor, error) {
+	decodedBytes, err := base64.URLEncoding.DecodeString(token)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode token: %w", err)
 	}
+	var cursor operatorCursor
+	if err := json.Unmarshal(decodedBytes, &cursor); err != nil {
+		return nil, fmt.Errorf("failed to unmarshal token: %w", err)
+	}
+	return &cursor, nil
+}
 
-	// Log the time taken
-	s.logger.Info("Time taken to get deregistered operators for days", "duration", time.Since(startTime))
-	sort.Slice(DeregisteredOperatorMetadata, func(i, j int) bool {
-		return DeregisteredOperatorMetadata[i].BlockNumber < DeregisteredOperatorMetadata[j].BlockNumber
-	})
+// parseOperatorsQuery parses the quorums, online, limit and next_token query parameters of the operator state
+// endpoints
+func (s *server) parseOperatorsQuery(c *gin.Context) (operatorsQuery, error) {
+	query := operatorsQuery{limit: s.operatorsPageSize}
+	if c.Query("quorums") != "" {
+		for _, quorum := range strings.Split(c.Query("quorums"), ",") {
+			quorumID, err := strconv.ParseUint(strings.TrimSpace(quorum), 10, 8)
+			if err != nil {
+				return operatorsQuery{}, errors.New("Invalid 'quorums' parameter")
+			}
+			query.quorumIDs = append(query.quorumIDs, core.QuorumID(quorumID))
+		}
+	}
+	if c.Query("online") != "" {
+		online, err := strconv.ParseBool(c.Query("online"))
+		if err != nil {
+			return operatorsQuery{}, errors.New("Invalid 'online' parameter")
+		}
+		query.online = &online
+	}
+	if c.Query("limit") != "" {
+		limit, err := strconv.Atoi(c.Query("limit"))
+		if err != nil || limit <= 0 || limit > maxOperatorsPageSize {
+			return operatorsQuery{}, fmt.Errorf("Invalid 'limit' parameter. It must be between 1 and %d", maxOperatorsPageSize)
+		}
+		query.limit = limit
+	}
+	if c.Query("next_token") != "" {
+		cursor, err := decodeOperatorCursor(c.Query("next_token"))
+		if err != nil {
+			return operatorsQuery{}, errors.New("Invalid 'next_token' parameter")
+		}
+		query.cursor = cursor
+	}
+	return query, nil
+}
 
-	return DeregisteredOperatorMetadata, nil
+// Function to get registered operators for given number of days
+// Queries subgraph for deregistered operators
+// Process operator online status
+// Returns list of Operators with their online status, socket address and block number they deregistered
+func (s *server) getDeregisteredOperatorForDays(ctx context.Context, days int32) ([]*QueriedStateOperatorMetadata, error) {
+	operators, _, err := s.getOperatorsForDays(ctx, days, Deregistered, operatorsQuery{})
+	return operators, err
 }
 
 // Function to get registered operators for given number of days
@@ -66,39 +134,157 @@ func (s *server) getDeregisteredOperatorForDays(ctx context.Context, days int32)
 // Process operator online status
 // Returns list of Operators with their online status, socket address and block number they registered
 func (s *server) getRegisteredOperatorForDays(ctx context.Context, days int32) ([]*QueriedStateOperatorMetadata, error) {
-	// Track time taken to get registered operators
+	operators, _, err := s.getOperatorsForDays(ctx, days, Registered, operatorsQuery{})
+	return operators, err
+}
+
+// getOperatorsForDays returns the page of the operators registered or deregistered, depending on the state, for the
+// given number of days selected by the query, ordered by block number and operator ID, along with the cursor of the
+// next page, which is nil if it is the last page. Only the operators of the page are checked online, unless the
+// operators are selected by online status, in which case they are checked a page at a time until the page is full.
+func (s *server) getOperatorsForDays(ctx context.Context, days int32, state OperatorState, query operatorsQuery) ([]*QueriedStateOperatorMetadata, *operatorCursor, error) {
+	// Track time taken to get the operators
 	startTime := time.Now()
 
-	indexedRegisteredOperatorState, err := s.subgraphClient.QueryIndexedOperatorsWithStateForTimeWindow(ctx, days, Registered)
+	indexedOperatorState, err := s.subgraphClient.QueryIndexedOperatorsWithStateForTimeWindow(ctx, days, state)
 	if err != nil {
-		return nil, err
+		return nil, nil, err
+	}
+	if len(query.quorumIDs) > 0 {
+		if err := s.filterOperatorsByQuorum(ctx, indexedOperatorState, state, query.quorumIDs); err != nil {
+			return nil, nil, err
+		}
+	}
+
+	type candidate struct {
+		operatorID core.OperatorID
+		info       *QueriedOperatorInfo
 	}
+	candidates := make([]candidate, 0, len(indexedOperatorState.Operators))
+	for operatorID, info := range indexedOperatorState.Operators {
+		if query.cursor != nil && !query.cursor.before(info.BlockNumber, info.Metadata.OperatorId) {
+			continue
+		}
+		candidates = append(candidates, candidate{operatorID: operatorID, info: info})
+	}
+	sort.Slice(candidates, func(i, j int) bool {
+		a, b := candidates[i].info, candidates[j].info
+		if a.BlockNumber != b.BlockNumber {
+			return a.BlockNumber < b.BlockNumber
+		}
+		return a.Metadata.OperatorId < b.Metadata.OperatorId
+	})
 
-	// Convert the map to a slice.
-	operators := indexedRegisteredOperatorState.Operators
+	batchSize := query.limit
+	if batchSize <= 0 {
+		batchSize = len(candidates)
+	}
+	page := make([]*QueriedStateOperatorMetadata, 0)
+	for checked := 0; checked < len(candidates); checked += batchSize {
+		batch := candidates[checked:min(checked+batchSize, len(candidates))]
+		batchOperators := &IndexedQueriedOperatorInfo{Operators: make(map[core.OperatorID]*QueriedOperatorInfo, len(batch))}
+		for _, c := range batch {
+			batchOperators.Operators[c.operatorID] = c.info
+		}
 
-	operatorOnlineStatusresultsChan = make(chan *QueriedStateOperatorMetadata, len(operators))
-	processOperatorOnlineCheck(indexedRegisteredOperatorState, operatorOnlineStatusresultsChan, s.logger)
+		results := s.newOperatorOnlineCheck(len(batch)).run(ctx, batchOperators)
+		if ctx.Err() != nil {
+			return nil, nil, ctx.Err()
+		}
+		sort.Slice(results, func(i, j int) bool {
+			if results[i].BlockNumber != results[j].BlockNumber {
+				return results[i].BlockNumber < results[j].BlockNumber
+			}
+			return results[i].OperatorId < results[j].OperatorId
+		})
 
-	// Collect results of work done
-	RegisteredOperatorMetadata := make([]*QueriedStateOperatorMetadata, 0, len(operators))
-	for range operators {
-		metadata := <-operatorOnlineStatusresultsChan
-		RegisteredOperatorMetadata = append(RegisteredOperatorMetadata, metadata)
+		for i, metadata := range results {
+			if query.online != nil && metadata.IsOnline != *query.online {
+				continue
+			}
+			page = append(page, metadata)
+			if query.limit > 0 && len(page) == query.limit {
+				if checked+i+1 == len(candidates) {
+					break
+				}
+				s.logger.Info("Time taken to get a page of operators for days", "state", state, "duration", time.Since(startTime))
+				return page, &operatorCursor{BlockNumber: metadata.BlockNumber, OperatorId: metadata.OperatorId}, nil
+			}
+		}
 	}
 
 	// Log the time taken
-	s.logger.Info("Time taken to get registered operators for days", "duration", time.Since(startTime))
-	sort.Slice(RegisteredOperatorMetadata, func(i, j int) bool {
-		return RegisteredOperatorMetadata[i].BlockNumber < RegisteredOperatorMetadata[j].BlockNumber
-	})
+	s.logger.Info("Time taken to get operators for days", "state", state, "duration", time.Since(startTime))
+	return page, nil, nil
+}
+
+// filterOperatorsByQuorum removes the operators which didn't register in or deregister from, depending on the state,
+// any of the quorums at the block of their registration or deregistration
+func (s *server) filterOperatorsByQuorum(ctx context.Context, indexedOperatorState *IndexedQueriedOperatorInfo, state OperatorState, quorumIDs []core.QuorumID) error {
+	if len(indexedOperatorState.Operators) == 0 {
+		return nil
+	}
+	startBlock, endBlock := uint32(math.MaxUint32), uint32(0)
+	for _, info := range indexedOperatorState.Operators {
+		startBlock = min(startBlock, uint32(info.BlockNumber))
+		endBlock = max(endBlock, uint32(info.BlockNumber))
+	}
+	events, err := s.subgraphClient.QueryOperatorQuorumEvent(ctx, startBlock, endBlock)
+	if err != nil {
+		return fmt.Errorf("failed to query operator quorum events: %w", err)
+	}
+	quorumEvents := events.AddedToQuorum
+	if state == Deregistered {
+		quorumEvents = events.RemovedFromQuorum
+	}
+
+	for operatorID, info := range indexedOperatorState.Operators {
+		inQuorums := false
+		for _, event := range quorumEvents[info.Metadata.Operator] {
+			if uint(event.BlockNumber) != info.BlockNumber {
+				continue
+			}
+			for _, quorum := range event.QuorumNumbers {
+				if slices.Contains(quorumIDs, core.QuorumID(quorum)) {
+					inQuorums = true
+				}
+			}
+		}
+		if !inQuorums {
+			delete(indexedOperatorState.Operators, operatorID)
+		}
+	}
+	return nil
+}
+
+// operatorOnlineCheck checks a set of operators online for a single request. It owns the channel of its results, so
+// that the concurrent requests only share the operator status cache, which is safe for concurrent use.
+type operatorOnlineCheck struct {
+	statuses   *operatorStatusCache
+	numWorkers int
+	timeout    time.Duration
+	logger     logging.Logger
+	results    chan *QueriedStateOperatorMetadata
+}
 
-	return RegisteredOperatorMetadata, nil
+// newOperatorOnlineCheck returns a check of up to numOperators operators with the online check settings of the server
+func (s *server) newOperatorOnlineCheck(numOperators int) *operatorOnlineCheck {
+	return &operatorOnlineCheck{
+		statuses:   s.operatorStatuses,
+		numWorkers: s.onlineCheckWorkers,
+		timeout:    s.onlineCheckTimeout,
+		logger:     s.logger,
+		results:    make(chan *QueriedStateOperatorMetadata, numOperators),
+	}
 }
 
-func processOperatorOnlineCheck(queriedOperatorsInfo *IndexedQueriedOperatorInfo, operatorOnlineStatusresultsChan chan<- *QueriedStateOperatorMetadata, logger logging.Logger) {
+// run checks the operators with a pool of workers and returns a result for each of them, in no particular order. The
+// operators left when the context is done are reported offline without being checked. The online statuses cached by
+// the operator status cache are reused, and the others are checked by the workers which dial each socket for up to
+// the timeout.
+func (c *operatorOnlineCheck) run(ctx context.Context, queriedOperatorsInfo *IndexedQueriedOperatorInfo) []*QueriedStateOperatorMetadata {
 	operators := queriedOperatorsInfo.Operators
-	wp := workerpool.New(poolSize)
+	wp := workerpool.New(c.numWorkers)
 
 	for _, operatorInfo := range operators {
 		operatorStatus := OperatorOnlineStatus{
@@ -109,26 +295,34 @@ func processOperatorOnlineCheck(queriedOperatorsInfo *IndexedQueriedOperatorInfo
 
 		// Submit each operator status check to the worker pool
 		wp.Submit(func() {
-			checkIsOnlineAndProcessOperator(operatorStatus, operatorOnlineStatusresultsChan, logger)
+			c.checkOperator(ctx, operatorStatus)
 		})
 	}
 
 	wp.StopWait() // Wait for all submitted tasks to complete and stop the pool
+
+	results := make([]*QueriedStateOperatorMetadata, 0, len(operators))
+	for range operators {
+		results = append(results, <-c.results)
+	}
+	return results
 }
 
-func checkIsOnlineAndProcessOperator(operatorStatus OperatorOnlineStatus, operatorOnlineStatusresultsChan chan<- *QueriedStateOperatorMetadata, logger logging.Logger) {
+func (c *operatorOnlineCheck) checkOperator(ctx context.Context, operatorStatus OperatorOnlineStatus) {
 	var isOnline bool
 	var socket string
 	if operatorStatus.IndexedOperatorInfo != nil {
 		socket = core.OperatorSocket(operatorStatus.IndexedOperatorInfo.Socket).GetRetrievalSocket()
-		isOnline = checkIsOperatorOnline(socket, 10, logger)
+		if ctx.Err() == nil {
+			isOnline = c.statuses.isOnline(ctx, socket, c.timeout)
+		}
 	}
 
 	// Log the online status
 	if isOnline {
-		logger.Debug("Operator is online", "operatorInfo", operatorStatus.IndexedOperatorInfo, "socket", socket)
+		c.logger.Debug("Operator is online", "operatorInfo", operatorStatus.IndexedOperatorInfo, "socket", socket)
 	} else {
-		logger.Debug("Operator is offline", "operatorInfo", operatorStatus.IndexedOperatorInfo, "socket", socket)
+		c.logger.Debug("Operator is offline", "operatorInfo", operatorStatus.IndexedOperatorInfo, "socket", socket)
 	}
 
 	// Create the metadata regardless of online status
@@ -141,17 +335,17 @@ func checkIsOnlineAndProcessOperator(operatorStatus OperatorOnlineStatus, operat
 	}
 
 	// Send the metadata to the results channel
-	operatorOnlineStatusresultsChan <- metadata
+	c.results <- metadata
 }
 
 // Check that the socketString is not private/unspecified
-func ValidOperatorIP(address string, logger logging.Logger) bool {
+func ValidOperatorIP(ctx context.Context, address string, logger logging.Logger) bool {
 	host, _, err := net.SplitHostPort(address)
 	if err != nil {
 		logger.Error("Failed to split host port", "address", address, "error", err)
 		return false
 	}
-	ips, err := net.LookupIP(host)
+	ips, err := net.DefaultResolver.LookupIP(ctx, "ip", host)
 	if err != nil {
 		logger.Error("Error resolving operator host IP", "host", host, "error", err)
 		return false
@@ -176,10 +370,10 @@ func (s *server) probeOperatorPorts(ctx context.Context, operatorId string) (*Op
 
 	operatorSocket := core.OperatorSocket(operatorInfo.Socket)
 	retrievalSocket := operatorSocket.GetRetrievalSocket()
-	retrievalOnline := checkIsOperatorOnline(retrievalSocket, 3, s.logger)
+	retrievalOnline := checkIsOperatorOnline(ctx, retrievalSocket, 3*time.Second, s.logger)
 
 	dispersalSocket := operatorSocket.GetDispersalSocket()
-	dispersalOnline := checkIsOperatorOnline(dispersalSocket, 3, s.logger)
+	dispersalOnline := checkIsOperatorOnline(ctx, dispersalSocket, 3*time.Second, s.logger)
 
 	// Create the metadata regardless of online status
 	portCheckResponse := &OperatorPortCheckResponse{
@@ -206,43 +400,119 @@ func (s *server) getOperatorInfo(ctx context.Context, operatorId string) (*core.
 	return operatorInfo, nil
 }
 
-func (s *server) scanOperatorsHostInfo(ctx context.Context) (*SemverReportResponse, error) {
+// scanOperatorsHostInfo returns the number of active operators by semver. The semver, the latency and the error of the
+// node info request of each operator are returned as well if detail is set, and the stake shares of the operators
+// and of the semvers if includeStake is set. Every operator is queried again if either is set, instead of reusing
+// the cached semvers.
+func (s *server) scanOperatorsHostInfo(ctx context.Context, detail bool, includeStake bool) (*SemverReportResponse, error) {
 	currentBlock, err := s.indexedChainState.GetCurrentBlockNumber()
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch current block number - %s", err)
+		return nil, fmt.Errorf("failed to fetch current block number: %w", err)
 	}
-	operatorState, err := s.indexedChainState.GetIndexedOperatorState(context.Background(), currentBlock, []core.QuorumID{0, 1, 2})
+	operatorState, err := s.indexedChainState.GetIndexedOperatorState(ctx, currentBlock, []core.QuorumID{0, 1, 2})
 	if err != nil {
-		return nil, fmt.Errorf("failed to fetch indexed operator state - %s", err)
+		return nil, fmt.Errorf("failed to fetch indexed operator state: %w", err)
 	}
 	s.logger.Info("Queried operator state", "count", len(operatorState.IndexedOperators))
 
-	nodeInfoWorkers := 20
 	nodeInfoTimeout := time.Duration(1 * time.Second)
-	semvers := semver.ScanOperators(operatorState.IndexedOperators, nodeInfoWorkers, nodeInfoTimeout, s.logger)
-
-	// Create HostInfoReportResponse instance
-	semverReport := &SemverReportResponse{
-		Semver: semvers,
+	semverReport := &SemverReportResponse{}
+	if !detail && !includeStake {
+		semverReport.Semver = s.operatorStatuses.scanSemvers(ctx, operatorState.IndexedOperators, s.nodeInfoWorkers, nodeInfoTimeout)
+	} else {
+		results := s.operatorStatuses.scanSemverResults(ctx, operatorState.IndexedOperators, s.nodeInfoWorkers, nodeInfoTimeout)
+		semverReport.Semver = semver.CountSemvers(results)
+		if includeStake {
+			semverReport.StakeShares = semver.StakeShares(results, operatorState.OperatorState)
+		}
+		if detail {
+			semverReport.Operators = make([]*OperatorHostInfo, len(results))
+			for i, result := range results {
+				semverReport.Operators[i] = &OperatorHostInfo{
+					OperatorId: result.OperatorId.Hex(),
+					Socket:     result.Socket,
+					Semver:     result.Semver,
+					LatencyMs:  float64(result.Latency.Microseconds()) / 1000,
+					Error:      result.Error,
+				}
+				if includeStake {
+					semverReport.Operators[i].StakeShares = operatorStakeShares(operatorState.OperatorState, result.OperatorId)
+				}
+			}
+		}
 	}
 
 	// Publish semver report metrics
-	s.metrics.UpdateSemverCounts(semvers)
+	s.metrics.UpdateSemverCounts(semverReport.Semver)
 
-	s.logger.Info("Semver scan completed", "semverReport", semverReport)
+	s.logger.Info("Semver scan completed", "semver", semverReport.Semver)
 	return semverReport, nil
 }
 
+// operatorStakeShares returns the percentage of the stake of each quorum of the operator state held by the operator
+func operatorStakeShares(state *core.OperatorState, operatorId core.OperatorID) map[core.QuorumID]float64 {
+	shares := make(map[core.QuorumID]float64)
+	for quorum, operators := range state.Operators {
+		operator, ok := operators[operatorId]
+		total, hasTotal := state.Totals[quorum]
+		if !ok || operator.Stake == nil || !hasTotal || total.Stake == nil || total.Stake.Sign() == 0 {
+			continue
+		}
+		shares[quorum], _ = new(big.Rat).SetFrac(operator.Stake, total.Stake).Float64()
+		shares[quorum] *= 100
+	}
+	return shares
+}
+
+// getHardwareInventory returns the distribution of the hardware reported by the node info of the active operators,
+// scanning them if the last inventory is older than maxHardwareInventoryAge
+func (s *server) getHardwareInventory(ctx context.Context) (*HardwareInventoryResponse, error) {
+	s.hardwareInventoryMu.Lock()
+	defer s.hardwareInventoryMu.Unlock()
+	if s.hardwareInventory != nil && time.Since(time.Unix(int64(s.hardwareInventory.ScannedAt), 0)) < maxHardwareInventoryAge*time.Second {
+		return s.hardwareInventory, nil
+	}
+
+	currentBlock, err := s.indexedChainState.GetCurrentBlockNumber()
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch current block number - %s", err)
+	}
+	operatorState, err := s.indexedChainState.GetIndexedOperatorState(ctx, currentBlock, []core.QuorumID{0, 1, 2})
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch indexed operator state - %s", err)
+	}
+
+	nodeInfoTimeout := time.Duration(1 * time.Second)
+	results := semver.ScanOperatorSemvers(ctx, operatorState.IndexedOperators, s.nodeInfoWorkers, nodeInfoTimeout, false, s.logger)
+	if ctx.Err() != nil {
+		return nil, ctx.Err()
+	}
+	inventory := semver.GetHardwareInventory(results, operatorState.OperatorState)
+
+	distribution := func(d *semver.HardwareDistribution) *HardwareDistribution {
+		return &HardwareDistribution{NumOperators: d.NumOperators, StakeShares: d.StakeShares}
+	}
+	s.hardwareInventory = &HardwareInventoryResponse{
+		ScannedAt:    uint64(time.Now().Unix()),
+		NumOperators: len(results),
+		CPU:          distribution(inventory.CPU),
+		Memory:       distribution(inventory.Memory),
+		Platform:     distribution(inventory.Platform),
+	}
+	s.logger.Info("Hardware inventory completed", "numOperators", len(results))
+	return s.hardwareInventory, nil
+}
+
 // method to check if operator is online via socket dial
-func checkIsOperatorOnline(socket string, timeoutSecs int, logger logging.Logger) bool {
-	if !ValidOperatorIP(socket, logger) {
+func checkIsOperatorOnline(ctx context.Context, socket string, timeout time.Duration, logger logging.Logger) bool {
+	if !ValidOperatorIP(ctx, socket, logger) {
 		logger.Error("port check blocked invalid operator IP", "socket", socket)
 		return false
 	}
-	timeout := time.Second * time.Duration(timeoutSecs)
-	conn, err := net.DialTimeout("tcp", socket, timeout)
+	dialer := net.Dialer{Timeout: timeout}
+	conn, err := dialer.DialContext(ctx, "tcp", socket)
 	if err != nil {
-		logger.Warn("port check timeout", "socket", socket, "timeout", timeoutSecs, "error", err)
+		logger.Warn("port check timeout", "socket", socket, "timeout", timeout, "error", err)
 		return false
 	}
 	defer conn.Close() // Close the connection after checking
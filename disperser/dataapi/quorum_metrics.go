@@ -0,0 +1,236 @@
+package dataapi
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/gammazero/workerpool"
+)
+
+const (
+	defaultQuorumMetricsIntervalSecs = 300
+	// maxQuorumMetricsWindow bounds the window of the quorum metrics, as the blobs of every batch of the window are read
+	maxQuorumMetricsWindow = 7 * 24 * time.Hour
+	// maxQuorumMetricsPoints bounds the number of points of each time series of the quorum metrics
+	maxQuorumMetricsPoints = 1000
+	// maxWorkersGetBatchBlobs is the number of batches whose blobs are read concurrently
+	maxWorkersGetBatchBlobs = 10
+)
+
+type (
+	QuorumThroughputPoint struct {
+		// Timestamp is the unix time in seconds of the start of the interval
+		Timestamp      uint64 `json:"timestamp"`
+		DispersedBytes uint64 `json:"dispersed_bytes"`
+		NumBatches     int    `json:"num_batches"`
+	}
+
+	QuorumThroughputResponse struct {
+		Start    int64 `json:"start"`
+		End      int64 `json:"end"`
+		Interval int64 `json:"interval"`
+		// Quorums are the time series of the quorums to which blobs were dispersed during the window
+		Quorums map[core.QuorumID][]*QuorumThroughputPoint `json:"quorums"`
+	}
+
+	QuorumSigningRatePoint struct {
+		// Timestamp is the unix time in seconds of the start of the interval
+		Timestamp  uint64 `json:"timestamp"`
+		NumBatches int    `json:"num_batches"`
+		// SigningPercentage is the average over the batches of the interval of the percentage of the stake of the
+		// quorum which signed them. It is 0 if there is no batch.
+		SigningPercentage float64 `json:"signing_percentage"`
+	}
+
+	QuorumSigningRateResponse struct {
+		Start    int64 `json:"start"`
+		End      int64 `json:"end"`
+		Interval int64 `json:"interval"`
+		// Quorums are the time series of the quorums which signed batches during the window
+		Quorums map[core.QuorumID][]*QuorumSigningRatePoint `json:"quorums"`
+	}
+)
+
+// quorumBatchStats are the blob sizes and the signatures of a batch by quorum
+type quorumBatchStats struct {
+	timestamp      uint64
+	dispersedBytes map[core.QuorumID]uint64
+	percentSigned  map[core.QuorumID]uint8
+}
+
+// validateQuorumMetricsWindow checks that the window and the interval of the quorum metrics are within bounds
+func validateQuorumMetricsWindow(start, end, interval int64) error {
+	if start >= end {
+		return errors.New("start must be before end")
+	}
+	if time.Duration(end-start)*time.Second > maxQuorumMetricsWindow {
+		return fmt.Errorf("the window must not be longer than %s", maxQuorumMetricsWindow)
+	}
+	if interval <= 0 {
+		return errors.New("interval must be positive")
+	}
+	if numQuorumMetricsPoints(start, end, interval) > maxQuorumMetricsPoints {
+		return fmt.Errorf("the window must not have more than %d intervals", maxQuorumMetricsPoints)
+	}
+	return nil
+}
+
+func numQuorumMetricsPoints(start, end, interval int64) int64 {
+	return (end - start + interval - 1) / interval
+}
+
+// quorumMetricsPoint returns the index of the interval of the timestamp, the last interval including the end
+func quorumMetricsPoint(timestamp uint64, start, end, interval int64) int {
+	return int(min((int64(timestamp)-start)/interval, numQuorumMetricsPoints(start, end, interval)-1))
+}
+
+// getQuorumBatchStats returns the stats of the batches confirmed within [start, end], read from the blobs of the
+// batches
+func (s *server) getQuorumBatchStats(ctx context.Context, start, end int64) ([]*quorumBatchStats, error) {
+	batches, err := s.subgraphClient.QueryBatchesByBlockTimestampRange(ctx, uint64(start), uint64(end))
+	if err != nil {
+		return nil, fmt.Errorf("failed to query the batches confirmed between %d and %d: %w", start, end, err)
+	}
+
+	var (
+		mu    sync.Mutex
+		stats = make([]*quorumBatchStats, 0, len(batches))
+		errs  = make([]error, 0)
+	)
+	pool := workerpool.New(maxWorkersGetBatchBlobs)
+	for _, batch := range batches {
+		batch := batch
+		pool.Submit(func() {
+			batchStats, err := s.getBatchQuorumStats(ctx, batch)
+			mu.Lock()
+			defer mu.Unlock()
+			if err != nil {
+				errs = append(errs, err)
+			} else if batchStats != nil {
+				stats = append(stats, batchStats)
+			}
+		})
+	}
+	pool.StopWait()
+	if len(errs) > 0 {
+		return nil, errs[0]
+	}
+
+	sort.Slice(stats, func(i, j int) bool {
+		return stats[i].timestamp < stats[j].timestamp
+	})
+	return stats, nil
+}
+
+// getBatchQuorumStats returns the stats of the batch, or nil if the header hash of the batch is invalid
+func (s *server) getBatchQuorumStats(ctx context.Context, batch *Batch) (*quorumBatchStats, error) {
+	batchHeaderHash, err := ConvertHexadecimalToBytes(batch.BatchHeaderHash)
+	if err != nil {
+		s.logger.Warn("skipping batch with an invalid header hash", "batchHeaderHash", string(batch.BatchHeaderHash), "err", err)
+		return nil, nil
+	}
+	metadatas, err := s.blobstore.GetAllBlobMetadataByBatch(ctx, batchHeaderHash)
+	if err != nil && !errors.Is(err, disperser.ErrMetadataNotFound) {
+		return nil, fmt.Errorf("failed to get the blobs of batch %s: %w", batch.BatchHeaderHash, err)
+	}
+
+	stats := &quorumBatchStats{
+		timestamp:      batch.BlockTimestamp,
+		dispersedBytes: make(map[core.QuorumID]uint64),
+		percentSigned:  make(map[core.QuorumID]uint8),
+	}
+	for _, metadata := range metadatas {
+		if metadata.RequestMetadata == nil {
+			continue
+		}
+		for _, param := range metadata.RequestMetadata.SecurityParams {
+			stats.dispersedBytes[param.QuorumID] += uint64(metadata.RequestMetadata.BlobSize)
+		}
+		// The quorum results are those of the batch, so they are the same for all its blobs
+		if metadata.ConfirmationInfo != nil {
+			for quorumID, result := range metadata.ConfirmationInfo.QuorumResults {
+				stats.percentSigned[quorumID] = result.PercentSigned
+			}
+		}
+	}
+	return stats, nil
+}
+
+// getQuorumThroughput returns the bytes dispersed to each quorum and the number of batches dispersed to it in each
+// interval of the window
+func (s *server) getQuorumThroughput(ctx context.Context, start, end, interval int64) (*QuorumThroughputResponse, error) {
+	stats, err := s.getQuorumBatchStats(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QuorumThroughputResponse{
+		Start:    start,
+		End:      end,
+		Interval: interval,
+		Quorums:  make(map[core.QuorumID][]*QuorumThroughputPoint),
+	}
+	for _, batchStats := range stats {
+		i := quorumMetricsPoint(batchStats.timestamp, start, end, interval)
+		for quorumID, dispersedBytes := range batchStats.dispersedBytes {
+			points, ok := response.Quorums[quorumID]
+			if !ok {
+				points = make([]*QuorumThroughputPoint, numQuorumMetricsPoints(start, end, interval))
+				for j := range points {
+					points[j] = &QuorumThroughputPoint{Timestamp: uint64(start + int64(j)*interval)}
+				}
+				response.Quorums[quorumID] = points
+			}
+			points[i].DispersedBytes += dispersedBytes
+			points[i].NumBatches++
+		}
+	}
+	return response, nil
+}
+
+// getQuorumSigningRate returns the average percentage of the stake of each quorum which signed the batches in each
+// interval of the window
+func (s *server) getQuorumSigningRate(ctx context.Context, start, end, interval int64) (*QuorumSigningRateResponse, error) {
+	stats, err := s.getQuorumBatchStats(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+
+	response := &QuorumSigningRateResponse{
+		Start:    start,
+		End:      end,
+		Interval: interval,
+		Quorums:  make(map[core.QuorumID][]*QuorumSigningRatePoint),
+	}
+	totalPercentSigned := make(map[core.QuorumID][]uint64)
+	for _, batchStats := range stats {
+		i := quorumMetricsPoint(batchStats.timestamp, start, end, interval)
+		for quorumID, percentSigned := range batchStats.percentSigned {
+			points, ok := response.Quorums[quorumID]
+			if !ok {
+				points = make([]*QuorumSigningRatePoint, numQuorumMetricsPoints(start, end, interval))
+				for j := range points {
+					points[j] = &QuorumSigningRatePoint{Timestamp: uint64(start + int64(j)*interval)}
+				}
+				response.Quorums[quorumID] = points
+				totalPercentSigned[quorumID] = make([]uint64, len(points))
+			}
+			points[i].NumBatches++
+			totalPercentSigned[quorumID][i] += uint64(percentSigned)
+		}
+	}
+	for quorumID, points := range response.Quorums {
+		for i, point := range points {
+			if point.NumBatches > 0 {
+				point.SigningPercentage = float64(totalPercentSigned[quorumID][i]) / float64(point.NumBatches)
+			}
+		}
+	}
+	return response, nil
+}
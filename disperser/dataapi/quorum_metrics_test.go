@@ -0,0 +1,113 @@
+package dataapi_test
+
+import (
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/common/inmem"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// confirmBlobInBatch marks the blob confirmed in the batch with the percentages of the quorums which signed it
+func confirmBlobInBatch(t *testing.T, store disperser.BlobStore, blob *core.Blob, batchHeaderHash [32]byte, percentSigned map[core.QuorumID]uint8) {
+	key := queueBlob(t, blob, store)
+	quorumResults := make(map[core.QuorumID]*core.QuorumResult)
+	for quorumID, percent := range percentSigned {
+		quorumResults[quorumID] = &core.QuorumResult{QuorumID: quorumID, PercentSigned: percent}
+	}
+	metadata := &disperser.BlobMetadata{
+		BlobHash:     key.BlobHash,
+		MetadataHash: key.MetadataHash,
+		BlobStatus:   disperser.Confirmed,
+		RequestMetadata: &disperser.RequestMetadata{
+			BlobRequestHeader: blob.RequestHeader,
+			BlobSize:          uint(len(blob.Data)),
+		},
+	}
+	_, err := store.MarkBlobConfirmed(context.Background(), metadata, &disperser.ConfirmationInfo{
+		BatchHeaderHash: batchHeaderHash,
+		QuorumResults:   quorumResults,
+	})
+	require.NoError(t, err)
+}
+
+func TestFetchQuorumMetrics(t *testing.T) {
+	store := inmem.NewBlobStore()
+	start := int64(1_700_000_000)
+	end := start + 600
+
+	// Two batches are confirmed in the first interval and one in the second
+	hash1, hash2, hash3 := [32]byte{1}, [32]byte{2}, [32]byte{3}
+	source := &batchRangeSource{batches: []*dataapi.Batch{
+		{BatchId: 1, BatchHeaderHash: []byte(hex.EncodeToString(hash1[:])), BlockTimestamp: uint64(start + 10)},
+		{BatchId: 2, BatchHeaderHash: []byte(hex.EncodeToString(hash2[:])), BlockTimestamp: uint64(start + 100)},
+		{BatchId: 3, BatchHeaderHash: []byte(hex.EncodeToString(hash3[:])), BlockTimestamp: uint64(end)},
+	}}
+	blob0 := makeTestBlob(0, 80)
+	blob1 := makeTestBlob(1, 80)
+	blobSize := uint64(len(blob0.Data))
+	confirmBlobInBatch(t, store, &blob0, hash1, map[core.QuorumID]uint8{0: 90, 1: 70})
+	confirmBlobInBatch(t, store, &blob1, hash1, map[core.QuorumID]uint8{0: 90, 1: 70})
+	blob0 = makeTestBlob(0, 70)
+	confirmBlobInBatch(t, store, &blob0, hash2, map[core.QuorumID]uint8{0: 80})
+	blob1 = makeTestBlob(1, 70)
+	confirmBlobInBatch(t, store, &blob1, hash3, map[core.QuorumID]uint8{1: 100})
+
+	server := dataapi.NewServer(config, store, prometheusClient, source, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r := setUpRouter()
+	r.GET("/v1/metrics/quorum-throughput", server.FetchQuorumThroughputHandler)
+	r.GET("/v1/metrics/quorum-signing-rate", server.FetchQuorumSigningRateHandler)
+	fetch := func(path string, query string, response any) int {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/metrics/"+path+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		require.NoError(t, err)
+		if res.StatusCode == http.StatusOK {
+			require.NoError(t, json.Unmarshal(data, response))
+		}
+		return res.StatusCode
+	}
+	window := fmt.Sprintf("?start=%d&end=%d&interval=300", start, end)
+
+	var throughput dataapi.QuorumThroughputResponse
+	require.Equal(t, http.StatusOK, fetch("quorum-throughput", window, &throughput))
+	assert.Equal(t, int64(300), throughput.Interval)
+	assert.Equal(t, []*dataapi.QuorumThroughputPoint{
+		{Timestamp: uint64(start), DispersedBytes: 2 * blobSize, NumBatches: 2},
+		{Timestamp: uint64(start + 300)},
+	}, throughput.Quorums[0])
+	// The batch confirmed at the end of the window falls in the last interval
+	assert.Equal(t, []*dataapi.QuorumThroughputPoint{
+		{Timestamp: uint64(start), DispersedBytes: blobSize, NumBatches: 1},
+		{Timestamp: uint64(start + 300), DispersedBytes: blobSize, NumBatches: 1},
+	}, throughput.Quorums[1])
+
+	var signingRate dataapi.QuorumSigningRateResponse
+	require.Equal(t, http.StatusOK, fetch("quorum-signing-rate", window, &signingRate))
+	assert.Equal(t, []*dataapi.QuorumSigningRatePoint{
+		{Timestamp: uint64(start), NumBatches: 2, SigningPercentage: 85},
+		{Timestamp: uint64(start + 300)},
+	}, signingRate.Quorums[0])
+	assert.Equal(t, []*dataapi.QuorumSigningRatePoint{
+		{Timestamp: uint64(start), NumBatches: 1, SigningPercentage: 70},
+		{Timestamp: uint64(start + 300), NumBatches: 1, SigningPercentage: 100},
+	}, signingRate.Quorums[1])
+
+	assert.Equal(t, http.StatusBadRequest, fetch("quorum-throughput", fmt.Sprintf("?start=%d&end=%d", end, start), nil))
+	assert.Equal(t, http.StatusBadRequest, fetch("quorum-throughput", fmt.Sprintf("?start=%d&end=%d", start, start+8*24*3600), nil))
+	assert.Equal(t, http.StatusBadRequest, fetch("quorum-signing-rate", fmt.Sprintf("?start=%d&end=%d&interval=0", start, end), nil))
+	assert.Equal(t, http.StatusBadRequest, fetch("quorum-signing-rate", fmt.Sprintf("?start=%d&end=%d&interval=1", start, start+3600), nil))
+}
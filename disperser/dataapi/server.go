@@ -12,9 +12,12 @@ import (
 	"os/signal"
 	"strconv"
 	"strings"
+	"sync"
 	"syscall"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -22,9 +25,12 @@ import (
 
 	"github.com/Layr-Labs/eigenda/disperser"
 	"github.com/Layr-Labs/eigenda/disperser/dataapi/docs"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/gin-contrib/cors"
-	"github.com/gin-contrib/logger"
+	ginlogger "github.com/gin-contrib/logger"
 	"github.com/gin-gonic/gin"
+	"github.com/graphql-go/graphql"
 	"github.com/prometheus/client_golang/prometheus"
 	swaggerfiles "github.com/swaggo/files"     // swagger embed files
 	ginswagger "github.com/swaggo/gin-swagger" // gin-swagger middleware
@@ -49,6 +55,18 @@ const (
 	maxDisperserAvailabilityAge         = 3
 	maxChurnerAvailabilityAge           = 3
 	maxBatcherAvailabilityAge           = 3
+	maxNetworksAge                      = 300
+	maxDispersalOriginsAge              = 60
+	maxOperatorStateDiffAge             = 10
+	maxTimeToFinalityAge                = 60
+	maxThroughputUtilizationAge         = 5
+	maxBatchVerificationAge             = 60
+	maxHardwareInventoryAge             = 600
+	maxDispersalCostEstimateAge         = 10
+	maxOperatorUptimeAge                = 60
+	maxOperatorSocketHistoryAge         = 60
+	maxExpiringBatchesAge               = 60
+	maxQuorumMetricsAge                 = 60
 )
 
 var errNotFound = errors.New("not found")
@@ -104,6 +122,11 @@ type (
 		Data []*BlobMetadataResponse `json:"data"`
 	}
 
+	NonSignersResponse struct {
+		Meta Meta         `json:"meta"`
+		Data []*NonSigner `json:"data"`
+	}
+
 	OperatorNonsigningPercentageMetrics struct {
 		OperatorId           string  `json:"operator_id"`
 		OperatorAddress      string  `json:"operator_address"`
@@ -155,6 +178,107 @@ type (
 	}
 	SemverReportResponse struct {
 		Semver map[string]int `json:"semver"`
+		// StakeShares is the percentage of the stake of each quorum held by the operators of each semver, which is
+		// only set if the stakes are requested
+		StakeShares map[core.QuorumID]map[string]float64 `json:"stake_shares,omitempty"`
+		// Operators are the results of the operators, which are only set if the detail is requested
+		Operators []*OperatorHostInfo `json:"operators,omitempty"`
+	}
+
+	OperatorHostInfo struct {
+		OperatorId string `json:"operator_id"`
+		// Socket is the dispersal socket queried for the node info of the operator
+		Socket    string  `json:"socket"`
+		Semver    string  `json:"semver"`
+		LatencyMs float64 `json:"latency_ms"`
+		// Error is the error of the node info request, which is empty if it succeeded
+		Error string `json:"error,omitempty"`
+		// StakeShares is the percentage of the stake of each quorum held by the operator, which is only set if the
+		// stakes are requested
+		StakeShares map[core.QuorumID]float64 `json:"stake_shares,omitempty"`
+	}
+
+	HardwareDistribution struct {
+		// NumOperators is the number of operators in each bucket
+		NumOperators map[string]int `json:"num_operators"`
+		// StakeShares is the percentage of the stake of each quorum held by the operators in each bucket
+		StakeShares map[core.QuorumID]map[string]float64 `json:"stake_shares"`
+	}
+
+	HardwareInventoryResponse struct {
+		// ScannedAt is the unix timestamp (in seconds) of the scan of the operators
+		ScannedAt    uint64                `json:"scanned_at"`
+		NumOperators int                   `json:"num_operators"`
+		CPU          *HardwareDistribution `json:"cpu"`
+		Memory       *HardwareDistribution `json:"memory"`
+		Platform     *HardwareDistribution `json:"platform"`
+	}
+
+	DispersalOriginCount struct {
+		Origin        string `json:"origin"`
+		NumBlobs      int    `json:"num_blobs"`
+		TotalBlobSize uint   `json:"total_blob_size"`
+	}
+
+	DispersalOriginsResponse struct {
+		Meta           Meta                    `json:"meta"`
+		Surfaces       []*DispersalOriginCount `json:"surfaces"`
+		ClientVersions []*DispersalOriginCount `json:"client_versions"`
+		Accounts       []*DispersalOriginCount `json:"accounts"`
+	}
+
+	TimeToFinalityPercentiles struct {
+		// NumBlobs is the number of blobs that reached the stage within the window
+		NumBlobs uint64  `json:"num_blobs"`
+		P50      float64 `json:"p50_ms"`
+		P95      float64 `json:"p95_ms"`
+		P99      float64 `json:"p99_ms"`
+	}
+
+	TimeToFinalityResponse struct {
+		Window string `json:"window"`
+		// End is the unix timestamp (in seconds) at which the window ends
+		End uint64 `json:"end"`
+		// Confirmation is the distribution of the time from dispersal request to on-chain confirmation
+		Confirmation *TimeToFinalityPercentiles `json:"confirmation"`
+		// Finalization is the distribution of the time from dispersal request to finalization of the confirmation block
+		Finalization *TimeToFinalityPercentiles `json:"finalization"`
+	}
+
+	ThroughputUtilizationResponse struct {
+		// Utilization is the fraction, between 0 and 1, of the system throughput rate limit of each quorum which is
+		// used. A quorum without any dispersal is omitted.
+		Utilization map[core.QuorumID]float64 `json:"utilization"`
+	}
+
+	OperatorStakeChange struct {
+		OperatorId string   `json:"operator_id"`
+		Before     *big.Int `json:"before"`
+		After      *big.Int `json:"after"`
+	}
+
+	OperatorSocketChange struct {
+		OperatorId string `json:"operator_id"`
+		Before     string `json:"before"`
+		After      string `json:"after"`
+	}
+
+	QuorumStateDiffResponse struct {
+		Registered   []string               `json:"registered"`
+		Deregistered []string               `json:"deregistered"`
+		StakeChanges []*OperatorStakeChange `json:"stake_changes"`
+	}
+
+	OperatorStateDiffResponse struct {
+		FromBlock     uint                                       `json:"from_block"`
+		ToBlock       uint                                       `json:"to_block"`
+		Quorums       map[core.QuorumID]*QuorumStateDiffResponse `json:"quorums"`
+		SocketChanges []*OperatorSocketChange                    `json:"socket_changes"`
+	}
+
+	NetworksResponse struct {
+		Default  string   `json:"default"`
+		Networks []string `json:"networks"`
 	}
 
 	ErrorResponse struct {
@@ -162,14 +286,13 @@ type (
 	}
 
 	server struct {
-		serverMode        string
-		socketAddr        string
-		allowOrigins      []string
+		listeners         listeners
 		logger            logging.Logger
 		blobstore         disperser.BlobStore
 		promClient        PrometheusClient
 		subgraphClient    SubgraphClient
 		transactor        core.Transactor
+		ethClient         common.EthClient
 		chainState        core.ChainState
 		indexedChainState core.IndexedChainState
 
@@ -179,6 +302,49 @@ type (
 		batcherHealthEndpt        string
 		eigenDAGRPCServiceChecker EigenDAGRPCServiceChecker
 		eigenDAHttpServiceChecker EigenDAHttpServiceChecker
+
+		// graphqlSchema is nil if the schema failed to build, in which case the GraphQL API is not served
+		graphqlSchema        *graphql.Schema
+		graphqlMaxDepth      int
+		graphqlMaxComplexity int
+
+		// serviceManagerAddr and blockExplorerURL are used to verify the confirmations of the batches
+		serviceManagerAddr gethcommon.Address
+		blockExplorerURL   string
+
+		// operatorsPageSize is the default number of operators per page of the operator state endpoints
+		operatorsPageSize int
+		// nodeInfoWorkers is the number of concurrent node info requests of the scans of the operators
+		nodeInfoWorkers int
+		// onlineCheckWorkers is the number of concurrent online checks of the operators, which dial each socket for up
+		// to onlineCheckTimeout
+		onlineCheckWorkers int
+		onlineCheckTimeout time.Duration
+		// probeVantages are the base URLs of the Data APIs of the other regions by region, and vantageClient the
+		// client of their port checks
+		probeVantages map[string]string
+		vantageClient *http.Client
+
+		// operatorStatuses caches the online statuses and the semvers of the operators
+		operatorStatuses *operatorStatusCache
+		// feed publishes the confirmations to the confirmation stream, which isn't served if it is nil
+		feed *confirmationFeed
+		// uptimeStore stores the uptime of the operators, which isn't served if it is nil
+		uptimeStore OperatorUptimeStore
+		// stopBackground stops the background tasks of the server, i.e. the refresher of the operator statuses, the
+		// confirmation feed and the uptime tracker
+		stopBackground context.CancelFunc
+
+		// blobProbeVerifier verifies the chunks requested by the blob availability probe to blobProbeNodeClient, the
+		// probe isn't served if it is nil
+		blobProbeVerifier   encoding.Verifier
+		blobProbeNodeClient clients.NodeClient
+		blobProbeSampleSize int
+
+		// hardwareInventory is the last inventory of the hardware of the operators, which is reused for
+		// maxHardwareInventoryAge as it takes a scan of all the operators
+		hardwareInventoryMu sync.Mutex
+		hardwareInventory   *HardwareInventoryResponse
 	}
 )
 
@@ -188,6 +354,7 @@ func NewServer(
 	promClient PrometheusClient,
 	subgraphClient SubgraphClient,
 	transactor core.Transactor,
+	ethClient common.EthClient,
 	chainState core.ChainState,
 	indexedChainState core.IndexedChainState,
 	logger logging.Logger,
@@ -210,15 +377,39 @@ func NewServer(
 		eigenDAHttpServiceChecker = &HttpServiceAvailability{}
 	}
 
-	return &server{
+	if config.GraphQLMaxDepth <= 0 {
+		config.GraphQLMaxDepth = defaultGraphQLMaxDepth
+	}
+	if config.GraphQLMaxComplexity <= 0 {
+		config.GraphQLMaxComplexity = defaultGraphQLMaxComplexity
+	}
+	if config.OperatorsPageSize <= 0 {
+		config.OperatorsPageSize = defaultOperatorsPageSize
+	}
+	if config.NodeInfoWorkers <= 0 {
+		config.NodeInfoWorkers = defaultNodeInfoWorkers
+	}
+	if config.OperatorOnlineCheckWorkers <= 0 {
+		config.OperatorOnlineCheckWorkers = defaultOperatorOnlineCheckWorkers
+	}
+	if config.OperatorOnlineCheckTimeout <= 0 {
+		config.OperatorOnlineCheckTimeout = defaultOperatorOnlineCheckTimeout
+	}
+	if config.BlobProbeNodeClient == nil {
+		config.BlobProbeNodeClient = clients.NewNodeClient(defaultBlobProbeTimeout)
+	}
+	if config.BlobProbeSampleSize <= 0 {
+		config.BlobProbeSampleSize = defaultBlobProbeSampleSize
+	}
+
+	s := &server{
 		logger:                    logger.With("component", "DataAPIServer"),
-		serverMode:                config.ServerMode,
-		socketAddr:                config.SocketAddr,
-		allowOrigins:              config.AllowOrigins,
+		listeners:                 newListeners(config),
 		blobstore:                 blobstore,
 		promClient:                promClient,
 		subgraphClient:            subgraphClient,
 		transactor:                transactor,
+		ethClient:                 ethClient,
 		chainState:                chainState,
 		indexedChainState:         indexedChainState,
 		metrics:                   metrics,
@@ -227,82 +418,154 @@ func NewServer(
 		batcherHealthEndpt:        config.BatcherHealthEndpt,
 		eigenDAGRPCServiceChecker: eigenDAGRPCServiceChecker,
 		eigenDAHttpServiceChecker: eigenDAHttpServiceChecker,
+		graphqlMaxDepth:           config.GraphQLMaxDepth,
+		graphqlMaxComplexity:      config.GraphQLMaxComplexity,
+		serviceManagerAddr:        gethcommon.HexToAddress(config.EigenDAServiceManagerAddr),
+		blockExplorerURL:          strings.TrimSuffix(config.BlockExplorerURL, "/"),
+		operatorsPageSize:         config.OperatorsPageSize,
+		nodeInfoWorkers:           config.NodeInfoWorkers,
+		onlineCheckWorkers:        config.OperatorOnlineCheckWorkers,
+		onlineCheckTimeout:        config.OperatorOnlineCheckTimeout,
+		probeVantages:             config.OperatorProbeVantages,
+		vantageClient:             &http.Client{Timeout: vantageTimeout},
+		operatorStatuses:          newOperatorStatusCache(config.OperatorStatusCacheTTL, logger),
+		uptimeStore:               config.OperatorUptimeStore,
+		blobProbeVerifier:         config.BlobProbeVerifier,
+		blobProbeNodeClient:       config.BlobProbeNodeClient,
+		blobProbeSampleSize:       config.BlobProbeSampleSize,
+	}
+	var ctx context.Context
+	ctx, s.stopBackground = context.WithCancel(context.Background())
+	if config.OperatorStatusCacheTTL > 0 && config.OperatorStatusRefreshInterval > 0 {
+		s.operatorStatuses.Start(ctx, config.OperatorStatusRefreshInterval, config.OperatorOnlineCheckWorkers, config.OperatorOnlineCheckTimeout)
+	}
+	if config.ConfirmationStreamPollInterval > 0 {
+		s.feed = newConfirmationFeed(subgraphClient, blobstore, s.logger)
+		s.feed.Start(ctx, config.ConfirmationStreamPollInterval)
+	}
+	if config.OperatorUptimeStore != nil && config.OperatorUptimeProbeInterval > 0 {
+		newOperatorUptimeTracker(config.OperatorUptimeStore, indexedChainState, config.OperatorOnlineCheckWorkers, s.logger).Start(ctx, config.OperatorUptimeProbeInterval)
+	}
+	schema, err := s.newGraphQLSchema()
+	if err != nil {
+		s.logger.Error("Failed to build the GraphQL schema", "error", err)
+	} else {
+		s.graphqlSchema = &schema
 	}
+	return s
 }
 
 func (s *server) Start() error {
-	if s.serverMode == gin.ReleaseMode {
+	if s.listeners.serverMode == gin.ReleaseMode {
 		// optimize performance and disable debug features.
 		gin.SetMode(gin.ReleaseMode)
 	}
 
-	router := gin.New()
 	basePath := "/api/v1"
 	docs.SwaggerInfo.BasePath = basePath
 	docs.SwaggerInfo.Host = os.Getenv("SWAGGER_HOST")
-	v1 := router.Group(basePath)
+
+	return s.listeners.serve(s.logger, func(router *gin.Engine, internal bool) {
+		s.registerRoutes(router.Group(basePath), internal)
+	})
+}
+
+// registerRoutes registers the API handlers of this server under the given versioned group. The internal routes,
+// which probe the operators or the services on each request, are only registered if internal is set, so that the
+// public listener can't be used to trigger scans. The lists of the operators are only public if their online statuses
+// are cached.
+func (s *server) registerRoutes(v1 *gin.RouterGroup, internal bool) {
+	feed := v1.Group("/feed")
 	{
-		feed := v1.Group("/feed")
-		{
-			feed.GET("/blobs", s.FetchBlobsHandler)
-			feed.GET("/blobs/:blob_key", s.FetchBlobHandler)
-			feed.GET("/batches/:batch_header_hash/blobs", s.FetchBlobsFromBatchHeaderHash)
+		feed.GET("/blobs", s.FetchBlobsHandler)
+		feed.GET("/blobs/:blob_key", s.FetchBlobHandler)
+		feed.GET("/batches/:batch_header_hash/blobs", s.FetchBlobsFromBatchHeaderHash)
+		feed.GET("/commitments/:commitment_hash/blobs", s.FetchBlobsFromCommitmentHash)
+		feed.GET("/batches/:batch_header_hash/verification", s.VerifyBatchHandler)
+		feed.GET("/expiring-batches", s.FetchExpiringBatchesHandler)
+		feed.GET("/stream", s.FetchConfirmationStreamHandler)
+		if internal {
+			feed.GET("/batches/:batch_header_hash/blobs/:blob_index/availability", s.ProbeBlobAvailabilityHandler)
 		}
-		operatorsInfo := v1.Group("/operators-info")
-		{
+	}
+	operatorsInfo := v1.Group("/operators-info")
+	{
+		if internal || s.operatorStatuses.ttl > 0 {
 			operatorsInfo.GET("/deregistered-operators", s.FetchDeregisteredOperators)
 			operatorsInfo.GET("/registered-operators", s.FetchRegisteredOperators)
+		}
+		operatorsInfo.GET("/state-diff", s.FetchOperatorStateDiff)
+		operatorsInfo.GET("/uptime", s.FetchOperatorUptime)
+		operatorsInfo.GET("/socket-history", s.FetchOperatorSocketHistory)
+		if internal {
 			operatorsInfo.GET("/port-check", s.OperatorPortCheck)
+			operatorsInfo.GET("/diagnosis", s.OperatorDiagnosis)
 			operatorsInfo.GET("/semver-scan", s.SemverScan)
+			operatorsInfo.GET("/hardware-inventory", s.FetchHardwareInventory)
 		}
-		metrics := v1.Group("/metrics")
-		{
-			metrics.GET("/", s.FetchMetricsHandler)
-			metrics.GET("/throughput", s.FetchMetricsThroughputHandler)
-			metrics.GET("/non-signers", s.FetchNonSigners)
-			metrics.GET("/operator-nonsigning-percentage", s.FetchOperatorsNonsigningPercentageHandler)
+	}
+	metrics := v1.Group("/metrics")
+	{
+		metrics.GET("/", s.FetchMetricsHandler)
+		metrics.GET("/throughput", s.FetchMetricsThroughputHandler)
+		metrics.GET("/non-signers", s.FetchNonSigners)
+		metrics.GET("/dispersal-origins", s.FetchDispersalOriginsHandler)
+		metrics.GET("/time-to-finality", s.FetchTimeToFinalityHandler)
+		metrics.GET("/throughput-utilization", s.FetchThroughputUtilizationHandler)
+		metrics.GET("/quorum-throughput", s.FetchQuorumThroughputHandler)
+		metrics.GET("/quorum-signing-rate", s.FetchQuorumSigningRateHandler)
+		metrics.GET("/operator-nonsigning-percentage", s.FetchOperatorsNonsigningPercentageHandler)
+		if internal {
 			metrics.GET("/disperser-service-availability", s.FetchDisperserServiceAvailability)
 			metrics.GET("/churner-service-availability", s.FetchChurnerServiceAvailability)
 			metrics.GET("/batcher-service-availability", s.FetchBatcherAvailability)
 		}
-		swagger := v1.Group("/swagger")
-		{
-			swagger.GET("/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
-		}
 	}
+	v1.GET("/cost-estimate", s.EstimateDispersalCostHandler)
+	if s.graphqlSchema != nil {
+		v1.POST("/graphql", s.GraphQLHandler)
+	}
+	swagger := v1.Group("/swagger")
+	{
+		swagger.GET("/*any", ginswagger.WrapHandler(swaggerfiles.Handler))
+	}
+}
 
+// listen adds the health route and the common middlewares to the router and runs it until
+// a shutdown signal is received. The returned channel receives the error the server stopped with.
+func listen(logger logging.Logger, router *gin.Engine, socketAddr string, serverMode string, allowOrigins []string) <-chan error {
 	router.GET("/", func(g *gin.Context) {
 		g.JSON(http.StatusAccepted, gin.H{"status": "OK"})
 	})
 
-	router.Use(logger.SetLogger(
-		logger.WithSkipPath([]string{"/"}),
+	router.Use(ginlogger.SetLogger(
+		ginlogger.WithSkipPath([]string{"/"}),
 	))
 
 	config := cors.DefaultConfig()
-	config.AllowOrigins = s.allowOrigins
+	config.AllowOrigins = allowOrigins
 	config.AllowCredentials = true
 	config.AllowMethods = []string{"GET", "POST", "HEAD", "OPTIONS"}
 
-	if s.serverMode != gin.ReleaseMode {
+	if serverMode != gin.ReleaseMode {
 		config.AllowOrigins = []string{"*"}
 	}
 	router.Use(cors.New(config))
 
 	srv := &http.Server{
-		Addr:              s.socketAddr,
-		Handler:           router,
+		Addr:              socketAddr,
+		Handler:           withResponseController(router),
 		ReadTimeout:       5 * time.Second,
 		ReadHeaderTimeout: 5 * time.Second,
 		WriteTimeout:      20 * time.Second,
 		IdleTimeout:       120 * time.Second,
 	}
 
-	errChan := run(s.logger, srv)
-	return <-errChan
+	return run(logger, srv)
 }
 
 func (s *server) Shutdown() error {
+	s.stopBackground()
 
 	if s.eigenDAGRPCServiceChecker != nil {
 		err := s.eigenDAGRPCServiceChecker.CloseConnections()
@@ -425,6 +688,289 @@ func (s *server) FetchBlobsFromBatchHeaderHash(c *gin.Context) {
 	})
 }
 
+// FetchBlobsFromCommitmentHash godoc
+//
+//	@Summary	Fetch the metadata of the confirmed blobs by the hash of their KZG commitment
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		commitment_hash	path		string	true	"Keccak256 hash of the ABI encoded KZG commitment"
+//	@Success	200				{object}	BlobsResponse
+//	@Failure	400				{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404				{object}	ErrorResponse	"error: Not found"
+//	@Failure	500				{object}	ErrorResponse	"error: Server error"
+//	@Router		/feed/commitments/{commitment_hash}/blobs [get]
+func (s *server) FetchBlobsFromCommitmentHash(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchBlobsFromCommitmentHash", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	commitmentHash, err := ConvertHexadecimalToBytes([]byte(c.Param("commitment_hash")))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchBlobsFromCommitmentHash")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid commitment hash"})
+		return
+	}
+
+	metadatas, err := s.getBlobsFromCommitmentHash(c.Request.Context(), commitmentHash)
+	if err != nil {
+		if errors.Is(err, errNotFound) {
+			s.metrics.IncrementNotFoundRequestNum("FetchBlobsFromCommitmentHash")
+		} else {
+			s.metrics.IncrementFailedRequestNum("FetchBlobsFromCommitmentHash")
+		}
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchBlobsFromCommitmentHash")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxFeedBlobAge))
+	c.JSON(http.StatusOK, BlobsResponse{
+		Meta: Meta{
+			Size: len(metadatas),
+		},
+		Data: metadatas,
+	})
+}
+
+// VerifyBatchHandler godoc
+//
+//	@Summary	Re-verify the onchain confirmation of a batch
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch Header Hash"
+//	@Success	200					{object}	BatchVerificationResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Router		/feed/batches/{batch_header_hash}/verification [get]
+func (s *server) VerifyBatchHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("VerifyBatch", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(c.Param("batch_header_hash")))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("VerifyBatch")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid batch header hash"})
+		return
+	}
+
+	verification, err := s.verifyBatch(c.Request.Context(), batchHeaderHash)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("VerifyBatch")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("VerifyBatch")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxBatchVerificationAge))
+	c.JSON(http.StatusOK, verification)
+}
+
+// ProbeBlobAvailabilityHandler godoc
+//
+//	@Summary	Spot check the availability of a blob by requesting its chunks to a stake-weighted sample of the operators of its quorums and verifying them
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		batch_header_hash	path		string	true	"Batch Header Hash"
+//	@Param		blob_index			path		int		true	"Blob Index"
+//	@Param		quorum_id			query		int		false	"Quorum to probe, may be repeated [default: all the quorums of the blob]"
+//	@Param		sample_size			query		int		false	"Number of operators sampled per quorum [default: 5, max: 20]"
+//	@Success	200					{object}	BlobAvailabilityResponse
+//	@Failure	400					{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404					{object}	ErrorResponse	"error: Not found"
+//	@Failure	500					{object}	ErrorResponse	"error: Server error"
+//	@Failure	503					{object}	ErrorResponse	"error: The blob availability probe is not enabled"
+//	@Router		/feed/batches/{batch_header_hash}/blobs/{blob_index}/availability [get]
+func (s *server) ProbeBlobAvailabilityHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("ProbeBlobAvailability", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if s.blobProbeVerifier == nil {
+		s.metrics.IncrementFailedRequestNum("ProbeBlobAvailability")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "the blob availability probe is not enabled"})
+		return
+	}
+	batchHeaderHash, err := ConvertHexadecimalToBytes([]byte(c.Param("batch_header_hash")))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("ProbeBlobAvailability")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid batch header hash"})
+		return
+	}
+	blobIndex, err := strconv.ParseUint(c.Param("blob_index"), 10, 32)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("ProbeBlobAvailability")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid blob index"})
+		return
+	}
+	quorumIDs := make([]core.QuorumID, 0)
+	for _, param := range c.QueryArray("quorum_id") {
+		quorumID, err := strconv.ParseUint(param, 10, 8)
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("ProbeBlobAvailability")
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("invalid quorum id %q", param)})
+			return
+		}
+		quorumIDs = append(quorumIDs, core.QuorumID(quorumID))
+	}
+	sampleSize, err := strconv.Atoi(c.DefaultQuery("sample_size", strconv.Itoa(s.blobProbeSampleSize)))
+	if err != nil || sampleSize <= 0 || sampleSize > maxBlobProbeSampleSize {
+		s.metrics.IncrementFailedRequestNum("ProbeBlobAvailability")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("sample_size must be between 1 and %d", maxBlobProbeSampleSize)})
+		return
+	}
+
+	response, err := s.probeBlobAvailability(c.Request.Context(), batchHeaderHash, uint32(blobIndex), quorumIDs, sampleSize)
+	if err != nil {
+		s.logger.Error("Failed to probe blob availability", "error", err)
+		s.metrics.IncrementFailedRequestNum("ProbeBlobAvailability")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("ProbeBlobAvailability")
+	// Each request probes the operators again
+	c.Writer.Header().Set(cacheControlParam, "no-store")
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchExpiringBatchesHandler godoc
+//
+//	@Summary	Fetch the batches which the operators may prune within the next hours, with their blobs, so that they can be dispersed again or archived
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		hours		query		int		false	"Number of hours [default: 24, max: 168]"
+//	@Param		account_id	query		string	false	"Account ID of the blobs [default: all accounts]"
+//	@Param		limit		query		int		false	"Maximum number of batches, earliest expiring first [default: 100, max: 1000]"
+//	@Success	200			{object}	ExpiringBatchesResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/feed/expiring-batches [get]
+func (s *server) FetchExpiringBatchesHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchExpiringBatches", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	hours, err := strconv.Atoi(c.DefaultQuery("hours", strconv.Itoa(defaultExpiringBatchesHours)))
+	if err != nil || hours <= 0 || hours > maxExpiringBatchesHours {
+		s.metrics.IncrementFailedRequestNum("FetchExpiringBatches")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("hours must be between 1 and %d", maxExpiringBatchesHours)})
+		return
+	}
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", strconv.Itoa(defaultExpiringBatchesLimit)))
+	if err != nil || limit <= 0 || limit > maxExpiringBatchesLimit {
+		s.metrics.IncrementFailedRequestNum("FetchExpiringBatches")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: fmt.Sprintf("limit must be between 1 and %d", maxExpiringBatchesLimit)})
+		return
+	}
+
+	response, err := s.getExpiringBatches(c.Request.Context(), time.Now(), hours, limit, c.Query("account_id"))
+	if err != nil {
+		s.logger.Error("Failed to fetch expiring batches", "error", err)
+		s.metrics.IncrementFailedRequestNum("FetchExpiringBatches")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchExpiringBatches")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxExpiringBatchesAge))
+	c.JSON(http.StatusOK, response)
+}
+
+// FetchConfirmationStreamHandler godoc
+//
+//	@Summary	Stream the confirmations of the batches and the confirmations and finalizations of their blobs as server-sent events
+//	@Tags		Feed
+//	@Produce	text/event-stream
+//	@Param		events	query		string						false	"Comma separated list of the event types: batch_confirmed, blob_confirmed, blob_finalized [default: all]"
+//	@Success	200		{object}	BatchConfirmationResponse	"The data of the batch_confirmed events. The data of the blob events is a BlobMetadataResponse"
+//	@Failure	400		{object}	ErrorResponse				"error: Bad request"
+//	@Failure	503		{object}	ErrorResponse				"error: The confirmation stream is not enabled"
+//	@Router		/feed/stream [get]
+func (s *server) FetchConfirmationStreamHandler(c *gin.Context) {
+	if s.feed == nil {
+		s.metrics.IncrementFailedRequestNum("FetchConfirmationStream")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "the confirmation stream is not enabled"})
+		return
+	}
+	types, err := parseFeedEventTypes(c.Query("events"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchConfirmationStream")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	subscription := s.feed.subscribe(types)
+	if subscription == nil {
+		s.metrics.IncrementFailedRequestNum("FetchConfirmationStream")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "the confirmation stream is stopped"})
+		return
+	}
+	defer s.feed.unsubscribe(subscription)
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchConfirmationStream")
+	streamFeedEvents(c, subscription)
+}
+
+// EstimateDispersalCostHandler godoc
+//
+//	@Summary	Estimate the chunks stored by the operators for a blob of the given size, from the current quorum parameters and stakes
+//	@Tags		Feed
+//	@Produce	json
+//	@Param		size	query		int		true	"Blob size in bytes"
+//	@Param		quorums	query		string	false	"Comma separated list of quorum IDs [default: the required quorums]"
+//	@Success	200		{object}	DispersalCostEstimateResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/cost-estimate [get]
+func (s *server) EstimateDispersalCostHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("EstimateDispersalCost", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	size, err := strconv.ParseUint(c.Query("size"), 10, 32)
+	if err != nil || size == 0 {
+		s.metrics.IncrementFailedRequestNum("EstimateDispersalCost")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid 'size' parameter"})
+		return
+	}
+
+	var quorumIDs []core.QuorumID
+	if c.Query("quorums") != "" {
+		for _, quorum := range strings.Split(c.Query("quorums"), ",") {
+			quorumID, err := strconv.ParseUint(strings.TrimSpace(quorum), 10, 8)
+			if err != nil {
+				s.metrics.IncrementFailedRequestNum("EstimateDispersalCost")
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid 'quorums' parameter"})
+				return
+			}
+			quorumIDs = append(quorumIDs, core.QuorumID(quorumID))
+		}
+	}
+
+	estimate, err := s.estimateDispersalCost(c.Request.Context(), uint(size), quorumIDs)
+	if errors.Is(err, errUnknownQuorum) {
+		s.metrics.IncrementFailedRequestNum("EstimateDispersalCost")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+	if err != nil {
+		s.logger.Error("Failed to estimate dispersal cost", "error", err)
+		s.metrics.IncrementFailedRequestNum("EstimateDispersalCost")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("EstimateDispersalCost")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxDispersalCostEstimateAge))
+	c.JSON(http.StatusOK, estimate)
+}
+
 func decodeNextToken(token string) (*disperser.BatchIndexExclusiveStartKey, error) {
 	// Decode the base64 string
 	decodedBytes, err := base64.URLEncoding.DecodeString(token)
@@ -586,11 +1132,15 @@ func (s *server) FetchMetricsThroughputHandler(c *gin.Context) {
 
 // FetchNonSigners godoc
 //
-//	@Summary	Fetch non signers
+//	@Summary	Fetch the number of batches each operator failed to sign, per quorum
 //	@Tags		Metrics
 //	@Produce	json
-//	@Param		interval	query		int	false	"Interval to query for non signers in seconds [default: 3600]"
-//	@Success	200			{object}	[]NonSigner
+//	@Param		interval	query		int		false	"Interval to query for non signers in seconds [default: 3600]"
+//	@Param		end			query		string	false	"End time (2006-01-02T15:04:05Z) to query for non signers [default: now]"
+//	@Param		quorums		query		string	false	"Comma separated list of quorum IDs [default: all quorums]"
+//	@Param		limit		query		int		false	"Limit [default: 100, max: 1000]"
+//	@Param		next_token	query		string	false	"Next page token"
+//	@Success	200			{object}	NonSignersResponse
 //	@Failure	400			{object}	ErrorResponse	"error: Bad request"
 //	@Failure	404			{object}	ErrorResponse	"error: Not found"
 //	@Failure	500			{object}	ErrorResponse	"error: Server error"
@@ -601,48 +1151,13 @@ func (s *server) FetchNonSigners(c *gin.Context) {
 	}))
 	defer timer.ObserveDuration()
 
-	interval, err := strconv.ParseInt(c.DefaultQuery("interval", "3600"), 10, 64)
-	if err != nil || interval == 0 {
-		interval = 3600
-	}
-	metric, err := s.getNonSigners(c.Request.Context(), interval)
-	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchNonSigners")
-		errorResponse(c, err)
-		return
-	}
-
-	s.metrics.IncrementSuccessfulRequestNum("FetchNonSigners")
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxNonSignerAge))
-	c.JSON(http.StatusOK, metric)
-}
-
-// FetchOperatorsNonsigningPercentageHandler godoc
-//
-//	@Summary	Fetch operators non signing percentage
-//	@Tags		Metrics
-//	@Produce	json
-//	@Param		interval	query		int		false	"Interval to query for operators nonsigning percentage [default: 3600]"
-//	@Param		end			query		string	false	"End time (2006-01-02T15:04:05Z) to query for operators nonsigning percentage [default: now]"
-//	@Param		live_only	query		string	false	"Whether return only live nonsigners [default: true]"
-//	@Success	200			{object}	OperatorsNonsigningPercentage
-//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404			{object}	ErrorResponse	"error: Not found"
-//	@Failure	500			{object}	ErrorResponse	"error: Server error"
-//	@Router		/metrics/operator-nonsigning-percentage  [get]
-func (s *server) FetchOperatorsNonsigningPercentageHandler(c *gin.Context) {
-	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
-		s.metrics.ObserveLatency("FetchOperatorsNonsigningPercentageHandler", f*1000) // make milliseconds
-	}))
-	defer timer.ObserveDuration()
-
 	endTime := time.Now()
 	if c.Query("end") != "" {
-
 		var err error
 		endTime, err = time.Parse("2006-01-02T15:04:05Z", c.Query("end"))
 		if err != nil {
-			errorResponse(c, err)
+			s.metrics.IncrementFailedRequestNum("FetchNonSigners")
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid 'end' parameter"})
 			return
 		}
 	}
@@ -651,39 +1166,344 @@ func (s *server) FetchOperatorsNonsigningPercentageHandler(c *gin.Context) {
 	if err != nil || interval == 0 {
 		interval = 3600
 	}
+	startTime := endTime.Add(-time.Duration(interval) * time.Second)
 
-	liveOnly := "true"
-	if c.Query("live_only") != "" {
-		liveOnly = c.Query("live_only")
-		if liveOnly != "true" && liveOnly != "false" {
-			errorResponse(c, errors.New("the live_only param must be \"true\" or \"false\""))
-			return
+	var quorumIDs []core.QuorumID
+	if c.Query("quorums") != "" {
+		for _, quorum := range strings.Split(c.Query("quorums"), ",") {
+			quorumID, err := strconv.ParseUint(strings.TrimSpace(quorum), 10, 8)
+			if err != nil {
+				s.metrics.IncrementFailedRequestNum("FetchNonSigners")
+				c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid 'quorums' parameter"})
+				return
+			}
+			quorumIDs = append(quorumIDs, core.QuorumID(quorumID))
 		}
 	}
 
-	startTime := endTime.Add(-time.Duration(interval) * time.Second)
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "100"))
+	if err != nil || limit <= 0 || limit > 1000 {
+		s.metrics.IncrementFailedRequestNum("FetchNonSigners")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "limit must be between 1 and 1000"})
+		return
+	}
 
-	metric, err := s.getOperatorNonsigningRate(c.Request.Context(), startTime.Unix(), endTime.Unix(), liveOnly == "true")
+	// The next page token is the offset of the first record of the page.
+	offset := 0
+	if c.Query("next_token") != "" {
+		offset, err = strconv.Atoi(c.Query("next_token"))
+		if err != nil || offset < 0 {
+			s.metrics.IncrementFailedRequestNum("FetchNonSigners")
+			c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid next_token"})
+			return
+		}
+	}
+
+	nonSigners, err := s.getNonSigners(c.Request.Context(), startTime.Unix(), endTime.Unix(), quorumIDs)
 	if err != nil {
-		s.metrics.IncrementFailedRequestNum("FetchOperatorsNonsigningPercentageHandler")
+		s.metrics.IncrementFailedRequestNum("FetchNonSigners")
 		errorResponse(c, err)
 		return
 	}
 
-	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorsNonsigningPercentageHandler")
-	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorsNonsigningPercentageAge))
-	c.JSON(http.StatusOK, metric)
-}
+	var nextPageToken string
+	page := nonSigners[min(offset, len(nonSigners)):]
+	if len(page) > limit {
+		page = page[:limit]
+		nextPageToken = strconv.Itoa(offset + limit)
+	}
 
-// FetchDeregisteredOperators godoc
-//
-//	@Summary	Fetch list of operators that have been deregistered for days. Days is a query parameter with a default value of 14 and max value of 30.
-//	@Tags		OperatorsInfo
+	s.metrics.IncrementSuccessfulRequestNum("FetchNonSigners")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxNonSignerAge))
+	c.JSON(http.StatusOK, NonSignersResponse{
+		Meta: Meta{
+			Size:      len(page),
+			NextToken: nextPageToken,
+		},
+		Data: page,
+	})
+}
+
+// FetchDispersalOriginsHandler godoc
+//
+//	@Summary	Fetch the origins (API surface, client version and authenticated account) of the latest dispersals
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		limit	query		int	false	"Number of latest confirmed blobs to aggregate [default: 1000, max: 10000]"
+//	@Success	200		{object}	DispersalOriginsResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/dispersal-origins  [get]
+func (s *server) FetchDispersalOriginsHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchDispersalOrigins", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	limit, err := strconv.Atoi(c.DefaultQuery("limit", "1000"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchDispersalOrigins")
+		errorResponse(c, fmt.Errorf("invalid limit parameter"))
+		return
+	}
+	if limit <= 0 || limit > maxDispersalOriginsLimit {
+		s.metrics.IncrementFailedRequestNum("FetchDispersalOrigins")
+		errorResponse(c, fmt.Errorf("limit must be between 1 and %d", maxDispersalOriginsLimit))
+		return
+	}
+
+	origins, err := s.getDispersalOrigins(c.Request.Context(), limit)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchDispersalOrigins")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchDispersalOrigins")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxDispersalOriginsAge))
+	c.JSON(http.StatusOK, origins)
+}
+
+// FetchTimeToFinalityHandler godoc
+//
+//	@Summary	Fetch the P50/P95/P99 of the time from dispersal request to confirmation and to finalization
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		window	query		string	false	"Window to compute the percentiles over, one of 1h, 6h, 24h, 7d, 30d [default: 24h]"
+//	@Param		end		query		int		false	"End unix timestamp of the window [default: unix time now]"
+//	@Success	200		{object}	TimeToFinalityResponse
+//	@Failure	400		{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404		{object}	ErrorResponse	"error: Not found"
+//	@Failure	500		{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/time-to-finality  [get]
+func (s *server) FetchTimeToFinalityHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchTimeToFinality", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	window := c.DefaultQuery("window", defaultTimeToFinalityWindow)
+	windowDuration, ok := timeToFinalityWindows[window]
+	if !ok {
+		s.metrics.IncrementFailedRequestNum("FetchTimeToFinality")
+		c.JSON(http.StatusBadRequest, gin.H{"error": fmt.Sprintf("invalid window %q", window)})
+		return
+	}
+
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end <= 0 {
+		end = time.Now().Unix()
+	}
+
+	ttf, err := s.getTimeToFinality(c.Request.Context(), windowDuration, time.Unix(end, 0))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchTimeToFinality")
+		errorResponse(c, err)
+		return
+	}
+	ttf.Window = window
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchTimeToFinality")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxTimeToFinalityAge))
+	c.JSON(http.StatusOK, ttf)
+}
+
+// FetchQuorumThroughputHandler godoc
+//
+//	@Summary	Fetch the time series of the bytes dispersed and of the number of batches of each quorum
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start		query		int	false	"Start unix timestamp [default: 1 hour ago]"
+//	@Param		end			query		int	false	"End unix timestamp [default: unix time now]"
+//	@Param		interval	query		int	false	"Interval of the points in seconds [default: 300]"
+//	@Success	200			{object}	QuorumThroughputResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/quorum-throughput  [get]
+func (s *server) FetchQuorumThroughputHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchQuorumThroughput", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	start, end, interval, err := parseQuorumMetricsWindow(c)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchQuorumThroughput")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	throughput, err := s.getQuorumThroughput(c.Request.Context(), start, end, interval)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchQuorumThroughput")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchQuorumThroughput")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxQuorumMetricsAge))
+	c.JSON(http.StatusOK, throughput)
+}
+
+// FetchQuorumSigningRateHandler godoc
+//
+//	@Summary	Fetch the time series of the percentage of the stake of each quorum which signed the batches
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		start		query		int	false	"Start unix timestamp [default: 1 hour ago]"
+//	@Param		end			query		int	false	"End unix timestamp [default: unix time now]"
+//	@Param		interval	query		int	false	"Interval of the points in seconds [default: 300]"
+//	@Success	200			{object}	QuorumSigningRateResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/quorum-signing-rate  [get]
+func (s *server) FetchQuorumSigningRateHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchQuorumSigningRate", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	start, end, interval, err := parseQuorumMetricsWindow(c)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchQuorumSigningRate")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: err.Error()})
+		return
+	}
+
+	signingRate, err := s.getQuorumSigningRate(c.Request.Context(), start, end, interval)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchQuorumSigningRate")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchQuorumSigningRate")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxQuorumMetricsAge))
+	c.JSON(http.StatusOK, signingRate)
+}
+
+// parseQuorumMetricsWindow returns the window and the interval of the quorum metrics requested
+func parseQuorumMetricsWindow(c *gin.Context) (int64, int64, int64, error) {
+	now := time.Now()
+	start, err := strconv.ParseInt(c.DefaultQuery("start", "0"), 10, 64)
+	if err != nil || start == 0 {
+		start = now.Add(-time.Hour * 1).Unix()
+	}
+	end, err := strconv.ParseInt(c.DefaultQuery("end", "0"), 10, 64)
+	if err != nil || end == 0 {
+		end = now.Unix()
+	}
+	interval, err := strconv.ParseInt(c.DefaultQuery("interval", strconv.Itoa(defaultQuorumMetricsIntervalSecs)), 10, 64)
+	if err != nil {
+		return 0, 0, 0, errors.New("invalid interval parameter")
+	}
+	if err := validateQuorumMetricsWindow(start, end, interval); err != nil {
+		return 0, 0, 0, err
+	}
+	return start, end, interval, nil
+}
+
+// FetchThroughputUtilizationHandler godoc
+//
+//	@Summary	Fetch the fraction of the system throughput rate limit of each quorum which is used by the dispersals
+//	@Tags		Metrics
 //	@Produce	json
-//	@Success	200	{object}	QueriedStateOperatorsResponse
+//	@Success	200	{object}	ThroughputUtilizationResponse
 //	@Failure	400	{object}	ErrorResponse	"error: Bad request"
 //	@Failure	404	{object}	ErrorResponse	"error: Not found"
 //	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/throughput-utilization  [get]
+func (s *server) FetchThroughputUtilizationHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchThroughputUtilization", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	utilization, err := s.promClient.QueryDisperserThroughputUtilization(c.Request.Context(), time.Now())
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchThroughputUtilization")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchThroughputUtilization")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxThroughputUtilizationAge))
+	c.JSON(http.StatusOK, &ThroughputUtilizationResponse{Utilization: utilization})
+}
+
+// FetchOperatorsNonsigningPercentageHandler godoc
+//
+//	@Summary	Fetch operators non signing percentage
+//	@Tags		Metrics
+//	@Produce	json
+//	@Param		interval	query		int		false	"Interval to query for operators nonsigning percentage [default: 3600]"
+//	@Param		end			query		string	false	"End time (2006-01-02T15:04:05Z) to query for operators nonsigning percentage [default: now]"
+//	@Param		live_only	query		string	false	"Whether return only live nonsigners [default: true]"
+//	@Success	200			{object}	OperatorsNonsigningPercentage
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/metrics/operator-nonsigning-percentage  [get]
+func (s *server) FetchOperatorsNonsigningPercentageHandler(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchOperatorsNonsigningPercentageHandler", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	endTime := time.Now()
+	if c.Query("end") != "" {
+
+		var err error
+		endTime, err = time.Parse("2006-01-02T15:04:05Z", c.Query("end"))
+		if err != nil {
+			errorResponse(c, err)
+			return
+		}
+	}
+
+	interval, err := strconv.ParseInt(c.DefaultQuery("interval", "3600"), 10, 64)
+	if err != nil || interval == 0 {
+		interval = 3600
+	}
+
+	liveOnly := "true"
+	if c.Query("live_only") != "" {
+		liveOnly = c.Query("live_only")
+		if liveOnly != "true" && liveOnly != "false" {
+			errorResponse(c, errors.New("the live_only param must be \"true\" or \"false\""))
+			return
+		}
+	}
+
+	startTime := endTime.Add(-time.Duration(interval) * time.Second)
+
+	metric, err := s.getOperatorNonsigningRate(c.Request.Context(), startTime.Unix(), endTime.Unix(), liveOnly == "true")
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorsNonsigningPercentageHandler")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorsNonsigningPercentageHandler")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorsNonsigningPercentageAge))
+	c.JSON(http.StatusOK, metric)
+}
+
+// FetchDeregisteredOperators godoc
+//
+//	@Summary	Fetch list of operators that have been deregistered for days. Days is a query parameter with a default value of 14 and max value of 30.
+//	@Tags		OperatorsInfo
+//	@Produce	json
+//	@Param		days		query		int		false	"Number of days [default: 14, max: 30]"
+//	@Param		quorums		query		string	false	"Comma separated list of quorum IDs the operators deregistered from [default: all quorums]"
+//	@Param		online		query		bool	false	"Online status of the operators [default: any]"
+//	@Param		limit		query		int		false	"Limit [default: configured page size, max: 1000]"
+//	@Param		next_token	query		string	false	"Next page token"
+//	@Success	200			{object}	QueriedStateOperatorsResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
 //	@Router		/operators-info/deregistered-operators [get]
 func (s *server) FetchDeregisteredOperators(c *gin.Context) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
@@ -707,7 +1527,14 @@ func (s *server) FetchDeregisteredOperators(c *gin.Context) {
 		return
 	}
 
-	operatorMetadatas, err := s.getDeregisteredOperatorForDays(c.Request.Context(), int32(daysInt))
+	query, err := s.parseOperatorsQuery(c)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchDeregisteredOperators")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	operatorMetadatas, nextCursor, err := s.getOperatorsForDays(c.Request.Context(), int32(daysInt), Deregistered, query)
 	if err != nil {
 		s.logger.Error("Failed to fetch deregistered operators", "error", err)
 		s.metrics.IncrementFailedRequestNum("FetchDeregisteredOperators")
@@ -715,25 +1542,213 @@ func (s *server) FetchDeregisteredOperators(c *gin.Context) {
 		return
 	}
 
+	var nextPageToken string
+	if nextCursor != nil {
+		nextPageToken, err = encodeOperatorCursor(nextCursor)
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchDeregisteredOperators")
+			errorResponse(c, fmt.Errorf("failed to generate next page token"))
+			return
+		}
+	}
+
 	s.metrics.IncrementSuccessfulRequestNum("FetchDeregisteredOperators")
 	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxDeregisteredOperatorAage))
 	c.JSON(http.StatusOK, QueriedStateOperatorsResponse{
 		Meta: Meta{
-			Size: len(operatorMetadatas),
+			Size:      len(operatorMetadatas),
+			NextToken: nextPageToken,
 		},
 		Data: operatorMetadatas,
 	})
 }
 
+// FetchOperatorStateDiff godoc
+//
+//	@Summary	Fetch the operator registrations, deregistrations, stake changes and socket changes between two blocks
+//	@Tags		OperatorsInfo
+//	@Produce	json
+//	@Param		from_block	query		int		true	"Block number to diff from"
+//	@Param		to_block	query		int		false	"Block number to diff to [default: current block]"
+//	@Param		quorums		query		string	false	"Comma separated list of quorum IDs [default: all quorums]"
+//	@Success	200			{object}	OperatorStateDiffResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators-info/state-diff [get]
+func (s *server) FetchOperatorStateDiff(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchOperatorStateDiff", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	fromBlock, err := strconv.ParseUint(c.Query("from_block"), 10, 32)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorStateDiff")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'from_block' parameter"})
+		return
+	}
+
+	var toBlock uint64
+	if c.Query("to_block") != "" {
+		toBlock, err = strconv.ParseUint(c.Query("to_block"), 10, 32)
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchOperatorStateDiff")
+			c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'to_block' parameter"})
+			return
+		}
+	} else {
+		currentBlock, err := s.transactor.GetCurrentBlockNumber(c.Request.Context())
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchOperatorStateDiff")
+			errorResponse(c, fmt.Errorf("failed to get current block number: %w", err))
+			return
+		}
+		toBlock = uint64(currentBlock)
+	}
+	if fromBlock > toBlock {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorStateDiff")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'from_block' must not be after 'to_block'"})
+		return
+	}
+
+	var quorumIDs []core.QuorumID
+	if c.Query("quorums") != "" {
+		for _, quorum := range strings.Split(c.Query("quorums"), ",") {
+			quorumID, err := strconv.ParseUint(strings.TrimSpace(quorum), 10, 8)
+			if err != nil {
+				s.metrics.IncrementFailedRequestNum("FetchOperatorStateDiff")
+				c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'quorums' parameter"})
+				return
+			}
+			quorumIDs = append(quorumIDs, core.QuorumID(quorumID))
+		}
+	}
+
+	diff, err := s.getOperatorStateDiff(c.Request.Context(), uint(fromBlock), uint(toBlock), quorumIDs)
+	if err != nil {
+		s.logger.Error("Failed to fetch operator state diff", "error", err)
+		s.metrics.IncrementFailedRequestNum("FetchOperatorStateDiff")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorStateDiff")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorStateDiffAge))
+	c.JSON(http.StatusOK, diff)
+}
+
+// FetchOperatorUptime godoc
+//
+//	@Summary	Fetch the percentage of the probes of an operator for which its dispersal and retrieval sockets were online
+//	@Tags		OperatorsInfo
+//	@Produce	json
+//	@Param		operator_id	query		string	true	"Operator ID"
+//	@Param		window		query		string	false	"Window of the uptime, one of 1d, 7d or 30d [default: 7d]"
+//	@Success	200			{object}	OperatorUptimeResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Failure	503			{object}	ErrorResponse	"error: Uptime tracking not enabled"
+//	@Router		/operators-info/uptime [get]
+func (s *server) FetchOperatorUptime(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchOperatorUptime", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	if s.uptimeStore == nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorUptime")
+		c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: "the uptime of the operators is not tracked"})
+		return
+	}
+	operatorId := c.Query("operator_id")
+	if operatorId == "" {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorUptime")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "'operator_id' parameter is required"})
+		return
+	}
+	window := c.DefaultQuery("window", "7d")
+	if _, ok := operatorUptimeWindows[window]; !ok {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorUptime")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'window' parameter. It must be one of 1d, 7d or 30d"})
+		return
+	}
+
+	uptime, err := s.getOperatorUptime(c.Request.Context(), operatorId, window, time.Now())
+	if err != nil {
+		s.logger.Error("Failed to fetch operator uptime", "error", err)
+		s.metrics.IncrementFailedRequestNum("FetchOperatorUptime")
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorUptime")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorUptimeAge))
+	c.JSON(http.StatusOK, uptime)
+}
+
+// FetchOperatorSocketHistory godoc
+//
+//	@Summary	Fetch the history of the socket updates of an operator, to check whether it recently moved hosts
+//	@Tags		OperatorsInfo
+//	@Produce	json
+//	@Param		operator_id	query		string	true	"Operator ID"
+//	@Success	200			{object}	OperatorSocketHistoryResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Failure	503			{object}	ErrorResponse	"error: Socket updates not indexed"
+//	@Router		/operators-info/socket-history [get]
+func (s *server) FetchOperatorSocketHistory(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchOperatorSocketHistory", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	operatorId, err := core.OperatorIDFromHex(c.Query("operator_id"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchOperatorSocketHistory")
+		c.JSON(http.StatusBadRequest, gin.H{"error": "Invalid 'operator_id' parameter"})
+		return
+	}
+
+	history, err := s.getOperatorSocketHistory(c.Request.Context(), operatorId.Hex())
+	if err != nil {
+		switch {
+		case errors.Is(err, subgraph.ErrSocketUpdatesNotIndexed):
+			s.metrics.IncrementFailedRequestNum("FetchOperatorSocketHistory")
+			c.JSON(http.StatusServiceUnavailable, ErrorResponse{Error: err.Error()})
+			return
+		case errors.Is(err, errNotFound):
+			s.metrics.IncrementNotFoundRequestNum("FetchOperatorSocketHistory")
+		default:
+			s.logger.Error("Failed to fetch operator socket history", "error", err)
+			s.metrics.IncrementFailedRequestNum("FetchOperatorSocketHistory")
+		}
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("FetchOperatorSocketHistory")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorSocketHistoryAge))
+	c.JSON(http.StatusOK, history)
+}
+
 // FetchRegisteredOperators godoc
 //
 //	@Summary	Fetch list of operators that have been registered for days. Days is a query parameter with a default value of 14 and max value of 30.
 //	@Tags		OperatorsInfo
 //	@Produce	json
-//	@Success	200	{object}	QueriedStateOperatorsResponse
-//	@Failure	400	{object}	ErrorResponse	"error: Bad request"
-//	@Failure	404	{object}	ErrorResponse	"error: Not found"
-//	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Param		days		query		int		false	"Number of days [default: 14, max: 30]"
+//	@Param		quorums		query		string	false	"Comma separated list of quorum IDs the operators registered in [default: all quorums]"
+//	@Param		online		query		bool	false	"Online status of the operators [default: any]"
+//	@Param		limit		query		int		false	"Limit [default: configured page size, max: 1000]"
+//	@Param		next_token	query		string	false	"Next page token"
+//	@Success	200			{object}	QueriedStateOperatorsResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
 //	@Router		/operators-info/registered-operators [get]
 func (s *server) FetchRegisteredOperators(c *gin.Context) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
@@ -757,18 +1772,36 @@ func (s *server) FetchRegisteredOperators(c *gin.Context) {
 		return
 	}
 
-	operatorMetadatas, err := s.getRegisteredOperatorForDays(c.Request.Context(), int32(daysInt))
+	query, err := s.parseOperatorsQuery(c)
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("FetchRegisteredOperators")
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	operatorMetadatas, nextCursor, err := s.getOperatorsForDays(c.Request.Context(), int32(daysInt), Registered, query)
 	if err != nil {
 		s.metrics.IncrementFailedRequestNum("FetchRegisteredOperators")
 		errorResponse(c, err)
 		return
 	}
 
+	var nextPageToken string
+	if nextCursor != nil {
+		nextPageToken, err = encodeOperatorCursor(nextCursor)
+		if err != nil {
+			s.metrics.IncrementFailedRequestNum("FetchRegisteredOperators")
+			errorResponse(c, fmt.Errorf("failed to generate next page token"))
+			return
+		}
+	}
+
 	s.metrics.IncrementSuccessfulRequestNum("FetchRegisteredOperators")
 	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxDeregisteredOperatorAage))
 	c.JSON(http.StatusOK, QueriedStateOperatorsResponse{
 		Meta: Meta{
-			Size: len(operatorMetadatas),
+			Size:      len(operatorMetadatas),
+			NextToken: nextPageToken,
 		},
 		Data: operatorMetadatas,
 	})
@@ -811,13 +1844,57 @@ func (s *server) OperatorPortCheck(c *gin.Context) {
 	c.JSON(http.StatusOK, portCheckResponse)
 }
 
+// OperatorDiagnosis godoc
+//
+//	@Summary	Operator node self-diagnosis of the ports, TLS, node info and clock of both sockets, and of the reachability from the vantage regions
+//	@Tags		OperatorsInfo
+//	@Produce	json
+//	@Param		operator_id	query		string	true	"Operator ID"
+//	@Success	200			{object}	OperatorDiagnosisResponse
+//	@Failure	400			{object}	ErrorResponse	"error: Bad request"
+//	@Failure	404			{object}	ErrorResponse	"error: Not found"
+//	@Failure	500			{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators-info/diagnosis [get]
+func (s *server) OperatorDiagnosis(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("OperatorDiagnosis", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	operatorId := c.Query("operator_id")
+	if operatorId == "" {
+		s.metrics.IncrementFailedRequestNum("OperatorDiagnosis")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "operator_id is required"})
+		return
+	}
+	diagnosis, err := s.diagnoseOperator(c.Request.Context(), operatorId)
+	if err != nil {
+		if strings.Contains(err.Error(), "not found") {
+			err = errNotFound
+			s.metrics.IncrementNotFoundRequestNum("OperatorDiagnosis")
+		} else {
+			s.logger.Error("operator diagnosis failed", "error", err)
+			s.metrics.IncrementFailedRequestNum("OperatorDiagnosis")
+		}
+		errorResponse(c, err)
+		return
+	}
+
+	s.metrics.IncrementSuccessfulRequestNum("OperatorDiagnosis")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorPortCheckAge))
+	c.JSON(http.StatusOK, diagnosis)
+}
+
 // Semver scan godoc
 //
 //	@Summary	Active operator semver scan
 //	@Tags		OperatorsInfo
 //	@Produce	json
-//	@Success	200	{object}	SemverReportResponse
-//	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Param		detail			query		bool	false	"Return the semver, latency and error of each operator [default: false]"
+//	@Param		include_stake	query		bool	false	"Return the stake shares of the semvers, and of the operators with the detail [default: false]"
+//	@Success	200				{object}	SemverReportResponse
+//	@Failure	400				{object}	ErrorResponse	"error: Bad request"
+//	@Failure	500				{object}	ErrorResponse	"error: Server error"
 //	@Router		/operators-info/semver-scan [get]
 func (s *server) SemverScan(c *gin.Context) {
 	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
@@ -825,16 +1902,57 @@ func (s *server) SemverScan(c *gin.Context) {
 	}))
 	defer timer.ObserveDuration()
 
-	report, err := s.scanOperatorsHostInfo(c.Request.Context())
+	detail, err := strconv.ParseBool(c.DefaultQuery("detail", "false"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("SemverScan")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid 'detail' parameter"})
+		return
+	}
+	includeStake, err := strconv.ParseBool(c.DefaultQuery("include_stake", "false"))
+	if err != nil {
+		s.metrics.IncrementFailedRequestNum("SemverScan")
+		c.JSON(http.StatusBadRequest, ErrorResponse{Error: "invalid 'include_stake' parameter"})
+		return
+	}
+
+	report, err := s.scanOperatorsHostInfo(c.Request.Context(), detail, includeStake)
 	if err != nil {
 		s.logger.Error("failed to scan operators host info", "error", err)
 		s.metrics.IncrementFailedRequestNum("SemverScan")
 		errorResponse(c, err)
+		return
 	}
+	s.metrics.IncrementSuccessfulRequestNum("SemverScan")
 	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxOperatorPortCheckAge))
 	c.JSON(http.StatusOK, report)
 }
 
+// FetchHardwareInventory godoc
+//
+//	@Summary	Distribution of the hardware of the active operators, weighted by stake
+//	@Tags		OperatorsInfo
+//	@Produce	json
+//	@Success	200	{object}	HardwareInventoryResponse
+//	@Failure	500	{object}	ErrorResponse	"error: Server error"
+//	@Router		/operators-info/hardware-inventory [get]
+func (s *server) FetchHardwareInventory(c *gin.Context) {
+	timer := prometheus.NewTimer(prometheus.ObserverFunc(func(f float64) {
+		s.metrics.ObserveLatency("FetchHardwareInventory", f*1000) // make milliseconds
+	}))
+	defer timer.ObserveDuration()
+
+	inventory, err := s.getHardwareInventory(c.Request.Context())
+	if err != nil {
+		s.logger.Error("failed to get the hardware inventory of the operators", "error", err)
+		s.metrics.IncrementFailedRequestNum("FetchHardwareInventory")
+		errorResponse(c, err)
+		return
+	}
+	s.metrics.IncrementSuccessfulRequestNum("FetchHardwareInventory")
+	c.Writer.Header().Set(cacheControlParam, fmt.Sprintf("max-age=%d", maxHardwareInventoryAge))
+	c.JSON(http.StatusOK, inventory)
+}
+
 // FetchDisperserServiceAvailability godoc
 //
 //	@Summary	Get status of EigenDA Disperser service.
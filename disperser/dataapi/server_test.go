@@ -1,6 +1,7 @@
 package dataapi_test
 
 import (
+	"compress/gzip"
 	"context"
 	_ "embed"
 	"encoding/hex"
@@ -12,6 +13,8 @@ import (
 	"net"
 	"net/http"
 	"net/http/httptest"
+	"strings"
+	"sync"
 	"testing"
 	"time"
 
@@ -28,10 +31,14 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/ethereum/go-ethereum/common"
 	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
 	"github.com/prometheus/common/model"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 	"go.uber.org/goleak"
 	"google.golang.org/grpc"
 	"google.golang.org/grpc/health/grpc_health_v1"
@@ -72,7 +79,7 @@ var (
 		1: 10,
 		2: 10,
 	})
-	testDataApiServer               = dataapi.NewServer(config, blobstore, prometheusClient, subgraphClient, mockTx, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	testDataApiServer               = dataapi.NewServer(config, blobstore, prometheusClient, subgraphClient, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
 	expectedRequestedAt             = uint64(5567830000000000000)
 	expectedDataLength              = 32
 	expectedBatchId                 = uint32(99)
@@ -234,6 +241,74 @@ func TestFetchBlobsHandler(t *testing.T) {
 	assert.Equal(t, 2, len(response.Data))
 }
 
+func TestFetchDispersalOriginsHandler(t *testing.T) {
+	r := setUpRouter()
+
+	store := inmem.NewBlobStore()
+	subgraphApi := &subgraphmock.MockSubgraphApi{}
+	subgraphApi.On("QueryBatches").Return(subgraphBatches[:1], nil)
+	server := dataapi.NewServer(config, store, prometheusClient, dataapi.NewSubgraphClient(subgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+
+	batchHeaderHash, err := dataapi.ConvertHexadecimalToBytes([]byte(subgraphBatches[0].BatchHeaderHash))
+	assert.NoError(t, err)
+	blob := makeTestBlob(0, 10)
+	origins := []disperser.RequestOrigin{
+		{DispersalSurface: disperser.FreeDispersal, ClientVersion: "grpc-go/1.59.0"},
+		{DispersalSurface: disperser.AuthenticatedDispersal, ClientVersion: "eigenda-client/v0.7.0", AuthenticatedAccount: "0x1234"},
+		{DispersalSurface: disperser.AuthenticatedDispersal, ClientVersion: "eigenda-client/v0.7.0", AuthenticatedAccount: "0x1234"},
+		{},
+	}
+	for i, origin := range origins {
+		key, err := store.StoreBlob(context.Background(), &blob, uint64(i), origin)
+		assert.NoError(t, err)
+		metadata, err := store.GetBlobMetadata(context.Background(), key)
+		assert.NoError(t, err)
+		_, err = store.MarkBlobConfirmed(context.Background(), metadata, &disperser.ConfirmationInfo{
+			BatchHeaderHash: batchHeaderHash,
+			BlobIndex:       uint32(i),
+		})
+		assert.NoError(t, err)
+	}
+
+	r.GET("/v1/metrics/dispersal-origins", server.FetchDispersalOriginsHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/metrics/dispersal-origins?limit=10", nil)
+	r.ServeHTTP(w, req)
+
+	res := w.Result()
+	defer res.Body.Close()
+
+	data, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+
+	var response dataapi.DispersalOriginsResponse
+	err = json.Unmarshal(data, &response)
+	assert.NoError(t, err)
+
+	blobSize := uint(len(blob.Data))
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+	assert.Equal(t, 4, response.Meta.Size)
+	assert.Equal(t, []*dataapi.DispersalOriginCount{
+		{Origin: "authenticated", NumBlobs: 2, TotalBlobSize: 2 * blobSize},
+		{Origin: "free", NumBlobs: 1, TotalBlobSize: blobSize},
+		{Origin: "unknown", NumBlobs: 1, TotalBlobSize: blobSize},
+	}, response.Surfaces)
+	assert.Equal(t, []*dataapi.DispersalOriginCount{
+		{Origin: "eigenda-client/v0.7.0", NumBlobs: 2, TotalBlobSize: 2 * blobSize},
+		{Origin: "grpc-go/1.59.0", NumBlobs: 1, TotalBlobSize: blobSize},
+		{Origin: "unknown", NumBlobs: 1, TotalBlobSize: blobSize},
+	}, response.ClientVersions)
+	assert.Equal(t, []*dataapi.DispersalOriginCount{
+		{Origin: "0x1234", NumBlobs: 2, TotalBlobSize: 2 * blobSize},
+	}, response.Accounts)
+
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v1/metrics/dispersal-origins?limit=0", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusInternalServerError, w.Result().StatusCode)
+}
+
 func TestFetchBlobsFromBatchHeaderHash(t *testing.T) {
 	r := setUpRouter()
 
@@ -346,6 +421,62 @@ func TestFetchBlobsFromBatchHeaderHash(t *testing.T) {
 	assert.Equal(t, "invalid batch header hash", errorResponse.Error)
 }
 
+func TestFetchBlobsFromCommitmentHash(t *testing.T) {
+	r := setUpRouter()
+	// The blobs are stored apart from the other tests, which confirm all their blobs with the same commitment
+	store := inmem.NewBlobStore()
+	server := dataapi.NewServer(config, store, prometheusClient, subgraphClient, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	r.GET("/v1/feed/commitments/:commitment_hash/blobs", server.FetchBlobsFromCommitmentHash)
+
+	batchHeaderHash := [32]byte{1, 2, 3}
+	blob1 := makeTestBlob(0, 80)
+	key1 := queueBlob(t, &blob1, store)
+	blob2 := makeTestBlob(1, 80)
+	key2 := queueBlob(t, &blob2, store)
+	blob3 := makeTestBlob(0, 70)
+	queueBlob(t, &blob3, store)
+	// The same data is confirmed twice, and the third blob isn't confirmed yet
+	markBlobConfirmed(t, &blob1, key1, 1, batchHeaderHash, store)
+	markBlobConfirmed(t, &blob2, key2, 2, batchHeaderHash, store)
+
+	metadata, err := store.GetBlobMetadata(context.Background(), key1)
+	require.NoError(t, err)
+	commitmentHash, ok := metadata.ConfirmationInfo.CommitmentHash()
+	require.True(t, ok)
+	x := metadata.ConfirmationInfo.BlobCommitment.Commitment.X.Bytes()
+	y := metadata.ConfirmationInfo.BlobCommitment.Commitment.Y.Bytes()
+	assert.Equal(t, crypto.Keccak256Hash(append(x[:], y[:]...)), common.Hash(commitmentHash))
+
+	fetch := func(hash string) (int, dataapi.BlobsResponse) {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/feed/commitments/"+hash+"/blobs", nil))
+		var response dataapi.BlobsResponse
+		if w.Code == http.StatusOK {
+			require.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		}
+		return w.Code, response
+	}
+
+	code, response := fetch(hexutil.Encode(commitmentHash[:]))
+	require.Equal(t, http.StatusOK, code)
+	require.Equal(t, 2, response.Meta.Size)
+	assert.Equal(t, key1.String(), response.Data[0].BlobKey)
+	assert.Equal(t, uint32(1), response.Data[0].BlobIndex)
+	assert.Equal(t, key2.String(), response.Data[1].BlobKey)
+	assert.Equal(t, uint32(2), response.Data[1].BlobIndex)
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), response.Data[1].BatchHeaderHash)
+
+	// The hash is also accepted without the 0x prefix
+	code, response = fetch(hex.EncodeToString(commitmentHash[:]))
+	assert.Equal(t, http.StatusOK, code)
+	assert.Equal(t, 2, response.Meta.Size)
+
+	code, _ = fetch(hex.EncodeToString(batchHeaderHash[:]))
+	assert.Equal(t, http.StatusNotFound, code)
+	code, _ = fetch("invalid")
+	assert.Equal(t, http.StatusBadRequest, code)
+}
+
 func TestFetchMetricsHandler(t *testing.T) {
 	defer goleak.VerifyNone(t)
 
@@ -441,6 +572,179 @@ func TestFetchMetricsThroughputHandler(t *testing.T) {
 	assert.Equal(t, float64(3.503022666666651e+07), totalThroughput)
 }
 
+func TestFetchTimeToFinalityHandler(t *testing.T) {
+	r := setUpRouter()
+
+	promApi := &prommock.MockPrometheusApi{}
+	server := dataapi.NewServer(config, blobstore, dataapi.NewPrometheusClient(promApi, "test-cluster"), subgraphClient, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	sample := func(v float64) model.Vector {
+		return model.Vector{&model.Sample{Value: model.SampleValue(v)}}
+	}
+	queryMatching := func(substrings ...string) interface{} {
+		return mock.MatchedBy(func(query string) bool {
+			for _, substring := range substrings {
+				if !strings.Contains(query, substring) {
+					return false
+				}
+			}
+			return true
+		})
+	}
+	promApi.On("Query", queryMatching(`_count{stage="confirmed",cluster="test-cluster"}[3600s]`)).Return(sample(120), nil, nil)
+	promApi.On("Query", queryMatching("histogram_quantile(0.5,", `stage="confirmed"`)).Return(sample(60_000), nil, nil)
+	promApi.On("Query", queryMatching("histogram_quantile(0.95,", `stage="confirmed"`)).Return(sample(180_000), nil, nil)
+	promApi.On("Query", queryMatching("histogram_quantile(0.99,", `stage="confirmed"`)).Return(sample(300_000), nil, nil)
+	// No blob was finalized within the window
+	promApi.On("Query", queryMatching(`_count{stage="finalized",cluster="test-cluster"}[3600s]`)).Return(model.Vector{}, nil, nil)
+
+	r.GET("/v1/metrics/time-to-finality", server.FetchTimeToFinalityHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/metrics/time-to-finality?window=1h&end=1700000000", nil)
+	r.ServeHTTP(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var response dataapi.TimeToFinalityResponse
+	err = json.Unmarshal(data, &response)
+	assert.NoError(t, err)
+	assert.Equal(t, "1h", response.Window)
+	assert.Equal(t, uint64(1700000000), response.End)
+	assert.Equal(t, &dataapi.TimeToFinalityPercentiles{NumBlobs: 120, P50: 60_000, P95: 180_000, P99: 300_000}, response.Confirmation)
+	assert.Equal(t, &dataapi.TimeToFinalityPercentiles{}, response.Finalization)
+	promApi.AssertNumberOfCalls(t, "Query", 5)
+
+	// Invalid window
+	w = httptest.NewRecorder()
+	req = httptest.NewRequest(http.MethodGet, "/v1/metrics/time-to-finality?window=2h", nil)
+	r.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
+func TestFetchThroughputUtilizationHandler(t *testing.T) {
+	r := setUpRouter()
+
+	promApi := &prommock.MockPrometheusApi{}
+	server := dataapi.NewServer(config, blobstore, dataapi.NewPrometheusClient(promApi, "test-cluster"), subgraphClient, mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	promApi.On("Query", mock.MatchedBy(func(query string) bool {
+		return strings.Contains(query, `eigenda_disperser_system_throughput_utilization{cluster="test-cluster"}`)
+	})).Return(model.Vector{
+		&model.Sample{Metric: model.Metric{"quorum": "0"}, Value: 0.25},
+		&model.Sample{Metric: model.Metric{"quorum": "1"}, Value: 1},
+	}, nil, nil)
+
+	r.GET("/v1/metrics/throughput-utilization", server.FetchThroughputUtilizationHandler)
+
+	w := httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, "/v1/metrics/throughput-utilization", nil)
+	r.ServeHTTP(w, req)
+	res := w.Result()
+	defer res.Body.Close()
+	data, err := io.ReadAll(res.Body)
+	assert.NoError(t, err)
+	assert.Equal(t, http.StatusOK, res.StatusCode)
+
+	var response dataapi.ThroughputUtilizationResponse
+	err = json.Unmarshal(data, &response)
+	assert.NoError(t, err)
+	assert.Equal(t, map[core.QuorumID]float64{0: 0.25, 1: 1}, response.Utilization)
+}
+
+func TestFetchHardwareInventory(t *testing.T) {
+	r := setUpRouter()
+
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 2, 1: 1})
+	assert.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+	server := dataapi.NewServer(config, blobstore, prometheusClient, subgraphClient, mockTx, nil, mockChainState, indexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	r.GET("/v1/operators-info/hardware-inventory", server.FetchHardwareInventory)
+
+	fetch := func() *dataapi.HardwareInventoryResponse {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/operators-info/hardware-inventory", nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+		assert.Equal(t, "max-age=600", res.Header.Get("Cache-Control"))
+
+		var response dataapi.HardwareInventoryResponse
+		assert.NoError(t, json.Unmarshal(data, &response))
+		return &response
+	}
+
+	// The operators of the mock aren't running, so their hardware is unknown
+	response := fetch()
+	assert.Equal(t, 2, response.NumOperators)
+	assert.Equal(t, map[string]int{"unknown": response.NumOperators}, response.CPU.NumOperators)
+	assert.Equal(t, map[string]int{"unknown": response.NumOperators}, response.Memory.NumOperators)
+	assert.Equal(t, map[string]int{"unknown": response.NumOperators}, response.Platform.NumOperators)
+	assert.InDelta(t, 100, response.CPU.StakeShares[0]["unknown"], 1e-9)
+
+	// The inventory is cached
+	assert.Equal(t, response, fetch())
+}
+
+func TestSemverScan(t *testing.T) {
+	r := setUpRouter()
+
+	indexedChainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 2, 1: 1})
+	assert.NoError(t, err)
+	indexedChainState.On("GetCurrentBlockNumber").Return(uint(1), nil)
+	scanConfig := config
+	scanConfig.NodeInfoWorkers = 1
+	server := dataapi.NewServer(scanConfig, blobstore, prometheusClient, subgraphClient, mockTx, nil, mockChainState, indexedChainState, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil, nil)
+	r.GET("/v1/operators-info/semver-scan", server.SemverScan)
+
+	fetch := func(query string) (int, *dataapi.SemverReportResponse) {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/operators-info/semver-scan"+query, nil)
+		r.ServeHTTP(w, req)
+		res := w.Result()
+		defer res.Body.Close()
+		data, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+
+		var response dataapi.SemverReportResponse
+		assert.NoError(t, json.Unmarshal(data, &response))
+		return res.StatusCode, &response
+	}
+
+	// The operators aren't detailed unless requested
+	status, response := fetch("")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, response.Semver, 1)
+	assert.Nil(t, response.StakeShares)
+	assert.Nil(t, response.Operators)
+
+	// The operators of the mock aren't running, so each of them reports the error of its node info request
+	status, response = fetch("?detail=true&include_stake=true")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, response.Operators, 2)
+	totalShare := 0.0
+	for _, operator := range response.Operators {
+		assert.NotEmpty(t, operator.OperatorId)
+		assert.NotEmpty(t, operator.Socket)
+		assert.NotEmpty(t, operator.Semver)
+		assert.NotEmpty(t, operator.Error)
+		totalShare += operator.StakeShares[0]
+	}
+	assert.InDelta(t, 100, totalShare, 1e-9)
+	for _, share := range response.StakeShares[0] {
+		assert.InDelta(t, 100, share, 1e-9)
+	}
+
+	status, _ = fetch("?detail=yes")
+	assert.Equal(t, http.StatusBadRequest, status)
+	status, _ = fetch("?include_stake=1x")
+	assert.Equal(t, http.StatusBadRequest, status)
+}
+
 func TestFetchUnsignedBatchesHandler(t *testing.T) {
 	r := setUpRouter()
 
@@ -500,18 +804,70 @@ func TestFetchUnsignedBatchesHandler(t *testing.T) {
 	assert.Equal(t, float64(25), responseData.StakePercentage)
 }
 
+func TestFetchNonSigners(t *testing.T) {
+	r := setUpRouter()
+
+	stopTime := time.Unix(1700000000, 0).UTC()
+	interval := 600
+	startTime := stopTime.Add(-time.Duration(interval) * time.Second)
+
+	mockSubgraphApi.On("QueryBatchNonSigningInfo", startTime.Unix(), stopTime.Unix()).Return(batchNonSigningInfo, nil)
+	addr1 := gethcommon.HexToAddress("0x00000000219ab540356cbb839cbe05303d7705fa")
+	addr2 := gethcommon.HexToAddress("0xc02aaa39b223fe8d0a0e5c4f27ead9083c756cc2")
+	mockTx.On("BatchOperatorIDToAddress").Return([]gethcommon.Address{addr1, addr2}, nil)
+	mockTx.On("GetQuorumBitmapForOperatorsAtBlockNumber").Return([]*big.Int{big.NewInt(3), big.NewInt(0)}, nil)
+	mockSubgraphApi.On("QueryOperatorAddedToQuorum").Return(operatorAddedToQuorum, nil)
+	mockSubgraphApi.On("QueryOperatorRemovedFromQuorum").Return(operatorRemovedFromQuorum, nil)
+
+	r.GET("/v1/metrics/non-signers", testDataApiServer.FetchNonSigners)
+
+	fetch := func(query string) dataapi.NonSignersResponse {
+		w := httptest.NewRecorder()
+		reqStr := fmt.Sprintf("/v1/metrics/non-signers?interval=%v&end=%s%s", interval, stopTime.Format("2006-01-02T15:04:05Z"), query)
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, reqStr, nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+		var response dataapi.NonSignersResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return response
+	}
+
+	operatorId := "0xe22dae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568311"
+	response := fetch("")
+	assert.Equal(t, 2, response.Meta.Size)
+	assert.Empty(t, response.Meta.NextToken)
+	assert.Equal(t, []*dataapi.NonSigner{
+		{OperatorId: operatorId, QuorumId: 1, Count: 2},
+		{OperatorId: operatorId, QuorumId: 0, Count: 1},
+	}, response.Data)
+
+	response = fetch("&quorums=0")
+	assert.Equal(t, []*dataapi.NonSigner{{OperatorId: operatorId, QuorumId: 0, Count: 1}}, response.Data)
+
+	response = fetch("&limit=1")
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, uint8(1), response.Data[0].QuorumId)
+	response = fetch("&limit=1&next_token=" + response.Meta.NextToken)
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Empty(t, response.Meta.NextToken)
+	assert.Equal(t, uint8(0), response.Data[0].QuorumId)
+
+	w := httptest.NewRecorder()
+	r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/metrics/non-signers?limit=0", nil))
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+}
+
 func TestPortCheckIpValidation(t *testing.T) {
-	assert.Equal(t, false, dataapi.ValidOperatorIP("", mockLogger))
-	assert.Equal(t, false, dataapi.ValidOperatorIP("0.0.0.0:32005", mockLogger))
-	assert.Equal(t, false, dataapi.ValidOperatorIP("10.0.0.1:32005", mockLogger))
-	assert.Equal(t, false, dataapi.ValidOperatorIP("::ffff:192.0.2.1:32005", mockLogger))
-	assert.Equal(t, false, dataapi.ValidOperatorIP("google.com", mockLogger))
-	assert.Equal(t, true, dataapi.ValidOperatorIP("localhost:32005", mockLogger))
-	assert.Equal(t, true, dataapi.ValidOperatorIP("127.0.0.1:32005", mockLogger))
-	assert.Equal(t, true, dataapi.ValidOperatorIP("23.93.76.1:32005", mockLogger))
-	assert.Equal(t, true, dataapi.ValidOperatorIP("google.com:32005", mockLogger))
-	assert.Equal(t, true, dataapi.ValidOperatorIP("[2606:4700:4400::ac40:98f1]:32005", mockLogger))
-	assert.Equal(t, false, dataapi.ValidOperatorIP("2606:4700:4400::ac40:98f1:32005", mockLogger))
+	assert.Equal(t, false, dataapi.ValidOperatorIP(context.Background(), "", mockLogger))
+	assert.Equal(t, false, dataapi.ValidOperatorIP(context.Background(), "0.0.0.0:32005", mockLogger))
+	assert.Equal(t, false, dataapi.ValidOperatorIP(context.Background(), "10.0.0.1:32005", mockLogger))
+	assert.Equal(t, false, dataapi.ValidOperatorIP(context.Background(), "::ffff:192.0.2.1:32005", mockLogger))
+	assert.Equal(t, false, dataapi.ValidOperatorIP(context.Background(), "google.com", mockLogger))
+	assert.Equal(t, true, dataapi.ValidOperatorIP(context.Background(), "localhost:32005", mockLogger))
+	assert.Equal(t, true, dataapi.ValidOperatorIP(context.Background(), "127.0.0.1:32005", mockLogger))
+	assert.Equal(t, true, dataapi.ValidOperatorIP(context.Background(), "23.93.76.1:32005", mockLogger))
+	assert.Equal(t, true, dataapi.ValidOperatorIP(context.Background(), "google.com:32005", mockLogger))
+	assert.Equal(t, true, dataapi.ValidOperatorIP(context.Background(), "[2606:4700:4400::ac40:98f1]:32005", mockLogger))
+	assert.Equal(t, false, dataapi.ValidOperatorIP(context.Background(), "2606:4700:4400::ac40:98f1:32005", mockLogger))
 }
 
 func TestPortCheck(t *testing.T) {
@@ -552,7 +908,7 @@ func TestPortCheck(t *testing.T) {
 
 func TestCheckBatcherHealthExpectServing(t *testing.T) {
 	r := setUpRouter()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, &MockHttpClient{ShouldSucceed: true})
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, &MockHttpClient{ShouldSucceed: true})
 
 	r.GET("/v1/metrics/batcher-service-availability", testDataApiServer.FetchBatcherAvailability)
 
@@ -585,7 +941,7 @@ func TestCheckBatcherHealthExpectServing(t *testing.T) {
 func TestCheckBatcherHealthExpectNotServing(t *testing.T) {
 	r := setUpRouter()
 
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, &MockHttpClient{ShouldSucceed: false})
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, &MockHttpClient{ShouldSucceed: false})
 
 	r.GET("/v1/metrics/batcher-service-availability", testDataApiServer.FetchBatcherAvailability)
 
@@ -623,7 +979,7 @@ func TestFetchDisperserServiceAvailabilityHandler(t *testing.T) {
 		Status: grpc_health_v1.HealthCheckResponse_SERVING,
 	})
 
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, mockHealthCheckService, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, mockHealthCheckService, nil)
 
 	r.GET("/v1/metrics/disperser-service-availability", testDataApiServer.FetchDisperserServiceAvailability)
 
@@ -661,7 +1017,7 @@ func TestChurnerServiceAvailabilityHandler(t *testing.T) {
 		Status: grpc_health_v1.HealthCheckResponse_SERVING,
 	})
 
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, mockHealthCheckService, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, mockHealthCheckService, nil)
 
 	r.GET("/v1/metrics/churner-service-availability", testDataApiServer.FetchChurnerServiceAvailability)
 
@@ -707,7 +1063,7 @@ func TestFetchDeregisteredOperatorNoSocketInfoOneOperatorHandler(t *testing.T) {
 
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfoNoSocketInfo, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -755,7 +1111,7 @@ func TestFetchDeregisteredMultipleOperatorsOneWithNoSocketInfoHandler(t *testing
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfoNoSocketInfo, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -822,7 +1178,7 @@ func TestFetchDeregisteredOperatorInfoInvalidTimeStampHandler(t *testing.T) {
 
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -866,7 +1222,7 @@ func TestFetchDeregisteredOperatorInfoInvalidTimeStampTwoOperatorsHandler(t *tes
 
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -921,7 +1277,7 @@ func TestFetchMetricsDeregisteredOperatorHandler(t *testing.T) {
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -985,7 +1341,7 @@ func TestFetchDeregisteredOperatorOffline(t *testing.T) {
 
 	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorDeregistered, nil)
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil)
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorState, nil)
 
@@ -1037,7 +1393,7 @@ func TestFetchDeregisteredOperatorsWithoutDaysQueryParam(t *testing.T) {
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -1082,6 +1438,138 @@ func TestFetchDeregisteredOperatorsWithoutDaysQueryParam(t *testing.T) {
 	mockSubgraphApi.Calls = nil
 }
 
+func TestFetchDeregisteredOperatorsPagination(t *testing.T) {
+
+	defer goleak.VerifyNone(t)
+
+	r := setUpRouter()
+
+	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphTwoOperatorsDeregistered, nil)
+	// The operators are queried for each page
+	for i := 0; i < 4; i++ {
+		mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
+		mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
+	}
+	// Only the second operator deregistered from quorum 1
+	mockSubgraphApi.On("QueryOperatorAddedToQuorum").Return([]*subgraph.OperatorQuorum{}, nil)
+	mockSubgraphApi.On("QueryOperatorRemovedFromQuorum").Return([]*subgraph.OperatorQuorum{
+		{
+			Operator:       subgraphTwoOperatorsDeregistered[0].Operator,
+			QuorumNumbers:  "0x00",
+			BlockNumber:    "22",
+			BlockTimestamp: "1702666046",
+		},
+		{
+			Operator:       subgraphTwoOperatorsDeregistered[1].Operator,
+			QuorumNumbers:  "0x0001",
+			BlockNumber:    "24",
+			BlockTimestamp: "1702666070",
+		},
+	}, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+
+	// Start the test server for Operator 2
+	closeServer, err := startTestGRPCServer("localhost:32009")
+	if err != nil {
+		t.Fatalf("Failed to start test server: %v", err)
+	}
+	defer closeServer() // Ensure the server is closed after the test
+
+	r.GET("/v1/operators-info/deregistered-operators", testDataApiServer.FetchDeregisteredOperators)
+
+	fetch := func(query string) dataapi.QueriedStateOperatorsResponse {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, "/v1/operators-info/deregistered-operators?"+query, nil)
+		r.ServeHTTP(w, req)
+
+		res := w.Result()
+		defer res.Body.Close()
+		assert.Equal(t, http.StatusOK, res.StatusCode)
+
+		data, err := io.ReadAll(res.Body)
+		assert.NoError(t, err)
+		var response dataapi.QueriedStateOperatorsResponse
+		assert.NoError(t, json.Unmarshal(data, &response))
+		return response
+	}
+
+	// The operators are paged in the order of their deregistration
+	response := fetch("limit=1")
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, "0xe22dae12a0074f20b8fc96a0489376db34075e545ef60c4845d264a732568311", response.Data[0].OperatorId)
+	assert.NotEmpty(t, response.Meta.NextToken)
+
+	response = fetch("limit=1&next_token=" + response.Meta.NextToken)
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, "0xe23cae12a0074f20b8fc96a0489376db34075e545ef60c4845d264b732568312", response.Data[0].OperatorId)
+	assert.Empty(t, response.Meta.NextToken)
+
+	// Only the second operator is online
+	response = fetch("online=true")
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, "0xe23cae12a0074f20b8fc96a0489376db34075e545ef60c4845d264b732568312", response.Data[0].OperatorId)
+	assert.True(t, response.Data[0].IsOnline)
+
+	response = fetch("quorums=1")
+	assert.Equal(t, 1, response.Meta.Size)
+	assert.Equal(t, uint(24), response.Data[0].BlockNumber)
+
+	// The query parameters are validated
+	for _, query := range []string{"limit=0", "limit=1001", "online=maybe", "quorums=a", "next_token=invalid"} {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/operators-info/deregistered-operators?"+query, nil))
+		assert.Equal(t, http.StatusBadRequest, w.Code, query)
+	}
+
+	// Reset the mock
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+}
+
+func TestFetchDeregisteredOperatorsCachedStatus(t *testing.T) {
+
+	defer goleak.VerifyNone(t)
+
+	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphTwoOperatorsDeregistered[1:], nil)
+	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil)
+
+	fetchOnline := func(server interface{ FetchDeregisteredOperators(*gin.Context) }) bool {
+		r := setUpRouter()
+		r.GET("/v1/operators-info/deregistered-operators", server.FetchDeregisteredOperators)
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, "/v1/operators-info/deregistered-operators", nil))
+		assert.Equal(t, http.StatusOK, w.Code)
+
+		var response dataapi.QueriedStateOperatorsResponse
+		assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+		return len(response.Data) == 1 && response.Data[0].IsOnline
+	}
+
+	// The online status is reused until the TTL expires
+	cacheConfig := config
+	cacheConfig.OperatorStatusCacheTTL = time.Hour
+	server := dataapi.NewServer(cacheConfig, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	closeServer, err := startTestGRPCServer("localhost:32009")
+	assert.NoError(t, err)
+	assert.True(t, fetchOnline(server))
+	closeServer()
+	assert.True(t, fetchOnline(server))
+
+	// The refresher probes the cached operators again in the background
+	cacheConfig.OperatorStatusRefreshInterval = 50 * time.Millisecond
+	server = dataapi.NewServer(cacheConfig, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPNilConnection{}, nil, nil)
+	assert.False(t, fetchOnline(server))
+	closeServer, err = startTestGRPCServer("localhost:32009")
+	assert.NoError(t, err)
+	defer closeServer()
+	assert.Eventually(t, func() bool { return fetchOnline(server) }, 5*time.Second, 50*time.Millisecond)
+	assert.NoError(t, server.Shutdown())
+
+	// Reset the mock
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+}
+
 func TestFetchDeregisteredOperatorInvalidDaysQueryParam(t *testing.T) {
 
 	defer goleak.VerifyNone(t)
@@ -1094,7 +1582,7 @@ func TestFetchDeregisteredOperatorInvalidDaysQueryParam(t *testing.T) {
 
 	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorDeregistered, nil)
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil)
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -1135,7 +1623,7 @@ func TestFetchDeregisteredOperatorQueryDaysGreaterThan30(t *testing.T) {
 
 	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorDeregistered, nil)
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil)
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorState, nil)
 
@@ -1180,7 +1668,7 @@ func TestFetchDeregisteredOperatorsMultipleOffline(t *testing.T) {
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -1237,7 +1725,7 @@ func TestFetchDeregisteredOperatorOnline(t *testing.T) {
 
 	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorDeregistered, nil)
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil)
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorState, nil)
 
@@ -1292,7 +1780,7 @@ func TestFetchDeregisteredOperatorsMultipleOfflineOnline(t *testing.T) {
 	// Set up the mock calls for the two operators
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -1358,7 +1846,7 @@ func TestFetchDeregisteredOperatorsMultipleOnline(t *testing.T) {
 	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphTwoOperatorsDeregistered, nil)
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -1432,7 +1920,7 @@ func TestFetchDeregisteredOperatorsMultipleOfflineSameBlock(t *testing.T) {
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo2, nil).Once()
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo3, nil).Once()
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorStates, nil)
 
@@ -1492,7 +1980,7 @@ func TestFetchRegisteredOperatorOnline(t *testing.T) {
 	indexedOperatorState[core.OperatorID{0}] = subgraphDeregisteredOperatorInfo
 	mockSubgraphApi.On("QueryRegisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorRegistered, nil)
 	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil)
-	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
 
 	mockSubgraphApi.On("QueryIndexedOperatorsWithStateForTimeWindow").Return(indexedOperatorState, nil)
 
@@ -1535,7 +2023,7 @@ func setUpRouter() *gin.Engine {
 }
 
 func queueBlob(t *testing.T, blob *core.Blob, queue disperser.BlobStore) disperser.BlobKey {
-	key, err := queue.StoreBlob(context.Background(), blob, expectedRequestedAt)
+	key, err := queue.StoreBlob(context.Background(), blob, expectedRequestedAt, disperser.RequestOrigin{})
 	assert.NoError(t, err)
 	return key
 }
@@ -1575,11 +2063,9 @@ func markBlobConfirmed(t *testing.T, blob *core.Blob, key disperser.BlobKey, blo
 		Expiry:       0,
 		NumRetries:   0,
 		RequestMetadata: &disperser.RequestMetadata{
-			BlobRequestHeader: core.BlobRequestHeader{
-				SecurityParams: blob.RequestHeader.SecurityParams,
-			},
-			RequestedAt: expectedRequestedAt,
-			BlobSize:    uint(len(blob.Data)),
+			BlobRequestHeader: blob.RequestHeader,
+			RequestedAt:       expectedRequestedAt,
+			BlobSize:          uint(len(blob.Data)),
 		},
 	}
 
@@ -1639,3 +2125,324 @@ func getOperatorData(operatorMetadtas []*dataapi.QueriedStateOperatorMetadata, o
 	return dataapi.QueriedStateOperatorMetadata{}
 
 }
+
+func TestMultiNetworkServer(t *testing.T) {
+	holeskyBlobstore := inmem.NewBlobStore()
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, holeskyBlobstore)
+	markBlobConfirmed(t, &blob, key, 1, [32]byte{4, 5, 6}, holeskyBlobstore)
+
+	networks := []dataapi.Network{
+		{
+			Name:              "mainnet",
+			BlobStore:         inmem.NewBlobStore(),
+			PromClient:        prometheusClient,
+			SubgraphClient:    subgraphClient,
+			Transactor:        mockTx,
+			ChainState:        mockChainState,
+			IndexedChainState: mockIndexedChainState,
+		},
+		{
+			Name:              "holesky",
+			BlobStore:         holeskyBlobstore,
+			PromClient:        prometheusClient,
+			SubgraphClient:    subgraphClient,
+			Transactor:        mockTx,
+			ChainState:        mockChainState,
+			IndexedChainState: mockIndexedChainState,
+		},
+	}
+	server, err := dataapi.NewMultiNetworkServer(config, networks, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil)
+	assert.NoError(t, err)
+	r := server.Router()
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	// The blob only exists on holesky
+	w := get("/holesky/api/v1/feed/blobs/" + key.String())
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.BlobMetadataResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, hex.EncodeToString([]byte{4, 5, 6}), response.BatchHeaderHash[:6])
+
+	assert.NotEqual(t, http.StatusOK, get("/mainnet/api/v1/feed/blobs/"+key.String()).Code)
+	// The unprefixed routes serve the default network
+	assert.NotEqual(t, http.StatusOK, get("/api/v1/feed/blobs/"+key.String()).Code)
+	assert.Equal(t, http.StatusNotFound, get("/sepolia/api/v1/feed/blobs/"+key.String()).Code)
+
+	w = get("/networks")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var networksResponse dataapi.NetworksResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &networksResponse))
+	assert.Equal(t, "mainnet", networksResponse.Default)
+	assert.Equal(t, []string{"mainnet", "holesky"}, networksResponse.Networks)
+
+	_, err = dataapi.NewMultiNetworkServer(config, append(networks, networks[1]), mockLogger, nil, &MockGRPCConnection{}, nil)
+	assert.ErrorContains(t, err, "duplicate network name")
+	_, err = dataapi.NewMultiNetworkServer(config, []dataapi.Network{{Name: "Main Net"}}, mockLogger, nil, &MockGRPCConnection{}, nil)
+	assert.ErrorContains(t, err, "invalid network name")
+}
+
+func TestPublicRouter(t *testing.T) {
+	store := inmem.NewBlobStore()
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, store)
+	markBlobConfirmed(t, &blob, key, 1, [32]byte{4, 5, 6}, store)
+
+	publicConfig := config
+	publicConfig.InternalSocketAddr = ":8081"
+	publicConfig.PublicRateLimit = 1
+	publicConfig.PublicRateBurst = 3
+	server, err := dataapi.NewMultiNetworkServer(publicConfig, []dataapi.Network{
+		{
+			Name:              "mainnet",
+			BlobStore:         store,
+			PromClient:        prometheusClient,
+			SubgraphClient:    subgraphClient,
+			Transactor:        mockTx,
+			ChainState:        mockChainState,
+			IndexedChainState: mockIndexedChainState,
+		},
+	}, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil)
+	assert.NoError(t, err)
+	public, err := server.PublicRouter()
+	assert.NoError(t, err)
+
+	get := func(r *gin.Engine, path string, clientIP string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = clientIP + ":1234"
+		r.ServeHTTP(w, req)
+		return w
+	}
+
+	// The probes are only served by the internal router
+	for i, path := range []string{
+		"/api/v1/feed/batches/040506/blobs/1/availability",
+		"/api/v1/operators-info/port-check?operator_id=" + opId0.Hex(),
+		"/api/v1/operators-info/semver-scan",
+		"/api/v1/operators-info/registered-operators",
+		"/mainnet/api/v1/metrics/disperser-service-availability",
+	} {
+		assert.Equal(t, http.StatusNotFound, get(public, path, fmt.Sprintf("10.0.1.%d", i)).Code, path)
+	}
+	assert.Equal(t, http.StatusServiceUnavailable, get(server.Router(), "/api/v1/feed/batches/040506/blobs/1/availability", "10.0.0.1").Code)
+	// The confirmation stream is served by the public router, though it isn't enabled here
+	assert.Equal(t, http.StatusServiceUnavailable, get(public, "/api/v1/feed/stream", "10.0.0.5").Code)
+
+	// Each client is rate limited separately, once its burst is spent
+	path := "/api/v1/feed/blobs/" + key.String()
+	assert.Equal(t, http.StatusOK, get(public, path, "10.0.0.2").Code)
+	assert.Equal(t, http.StatusOK, get(public, path, "10.0.0.2").Code)
+	w := get(public, path, "10.0.0.2")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEmpty(t, w.Header().Get("Cache-Control"))
+	w = get(public, path, "10.0.0.2")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusOK, get(public, path, "10.0.0.3").Code)
+
+	// The forwarded client IPs aren't trusted without trusted proxies
+	w = httptest.NewRecorder()
+	req := httptest.NewRequest(http.MethodGet, path, nil)
+	req.RemoteAddr = "10.0.0.2:1234"
+	req.Header.Set("X-Forwarded-For", "10.0.0.4")
+	public.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+
+	publicConfig.PublicTrustedProxies = []string{"not an ip"}
+	server, err = dataapi.NewMultiNetworkServer(publicConfig, []dataapi.Network{{Name: "mainnet"}}, mockLogger, nil, &MockGRPCConnection{}, nil)
+	assert.NoError(t, err)
+	_, err = server.PublicRouter()
+	assert.ErrorContains(t, err, "trusted proxies")
+}
+
+func TestResponseFieldSelectionAndCompression(t *testing.T) {
+	store := inmem.NewBlobStore()
+	blob := makeTestBlob(0, 80)
+	key := queueBlob(t, &blob, store)
+	markBlobConfirmed(t, &blob, key, 1, [32]byte{4, 5, 6}, store)
+
+	server, err := dataapi.NewMultiNetworkServer(config, []dataapi.Network{
+		{
+			Name:              "mainnet",
+			BlobStore:         store,
+			PromClient:        prometheusClient,
+			SubgraphClient:    subgraphClient,
+			Transactor:        mockTx,
+			ChainState:        mockChainState,
+			IndexedChainState: mockIndexedChainState,
+		},
+	}, mockLogger, dataapi.NewMetrics(nil, "9001", mockLogger), &MockGRPCConnection{}, nil)
+	assert.NoError(t, err)
+	r := server.Router()
+
+	get := func(path string, acceptEncoding string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.Header.Set("Accept-Encoding", acceptEncoding)
+		r.ServeHTTP(w, req)
+		return w
+	}
+	path := "/api/v1/feed/blobs/" + key.String()
+
+	// Without a selection every field is returned
+	w := get(path, "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Empty(t, w.Header().Get("Content-Encoding"))
+	var full map[string]interface{}
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &full))
+	assert.Contains(t, full, "batch_header_hash")
+
+	w = get(path+"?fields=blob_key,blob_commitment.length", "")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.JSONEq(t, fmt.Sprintf(`{"blob_key":%q,"blob_commitment":{"length":%d}}`, key.String(), expectedDataLength), w.Body.String())
+
+	w = get(path+"?fields=blob_key,", "")
+	assert.Equal(t, http.StatusBadRequest, w.Code)
+
+	// Error responses are not filtered
+	w = get("/api/v1/feed/blobs/invalid?fields=blob_key", "")
+	assert.NotEqual(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), "error")
+
+	w = get(path+"?fields=blob_key", "gzip")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	gzipReader, err := gzip.NewReader(w.Body)
+	assert.NoError(t, err)
+	body, err := io.ReadAll(gzipReader)
+	assert.NoError(t, err)
+	assert.JSONEq(t, fmt.Sprintf(`{"blob_key":%q}`, key.String()), string(body))
+
+	w = get(path, "gzip, deflate, br, zstd")
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "zstd", w.Header().Get("Content-Encoding"))
+	zstdReader, err := zstd.NewReader(w.Body)
+	assert.NoError(t, err)
+	defer zstdReader.Close()
+	body, err = io.ReadAll(zstdReader)
+	assert.NoError(t, err)
+	var decompressed map[string]interface{}
+	assert.NoError(t, json.Unmarshal(body, &decompressed))
+	assert.Equal(t, full, decompressed)
+
+	// Encodings with a zero quality are not used
+	w = get(path, "zstd;q=0, gzip;q=0.5")
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+}
+
+func TestFetchOperatorStateDiff(t *testing.T) {
+	r := setUpRouter()
+	r.GET("/v1/operators-info/state-diff", testDataApiServer.FetchOperatorStateDiff)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+
+	w := get("/v1/operators-info/state-diff?from_block=10&to_block=20&quorums=0,1")
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.OperatorStateDiffResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, uint(10), response.FromBlock)
+	assert.Equal(t, uint(20), response.ToBlock)
+	// The mock chain state does not change between blocks
+	assert.Len(t, response.Quorums, 2)
+	for _, quorumDiff := range response.Quorums {
+		assert.Empty(t, quorumDiff.Registered)
+		assert.Empty(t, quorumDiff.Deregistered)
+		assert.Empty(t, quorumDiff.StakeChanges)
+	}
+	assert.Empty(t, response.SocketChanges)
+
+	assert.Equal(t, http.StatusBadRequest, get("/v1/operators-info/state-diff").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/v1/operators-info/state-diff?from_block=20&to_block=10").Code)
+	assert.Equal(t, http.StatusBadRequest, get("/v1/operators-info/state-diff?from_block=10&to_block=20&quorums=256").Code)
+}
+
+func TestFetchOperatorSocketHistory(t *testing.T) {
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+	r := setUpRouter()
+	r.GET("/v1/operators-info/socket-history", testDataApiServer.FetchOperatorSocketHistory)
+
+	get := func(path string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+		return w
+	}
+	path := "/v1/operators-info/socket-history?operator_id=0x" + opId0.Hex()
+
+	mockSubgraphApi.On("QueryOperatorSocketUpdates").Return([]*subgraph.SocketUpdate{
+		{Socket: "23.93.76.1:32005;32006", BlockNumber: "100"},
+		{Socket: "23.93.76.2:32005;32006", BlockNumber: "250"},
+	}, nil).Once()
+	w := get(path)
+	assert.Equal(t, http.StatusOK, w.Code)
+	var response dataapi.OperatorSocketHistoryResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, opId0.Hex(), response.OperatorId)
+	assert.Equal(t, "23.93.76.2:32005;32006", response.CurrentSocket)
+	assert.Equal(t, []*dataapi.OperatorSocketHistoryEntry{
+		{OldSocket: "", NewSocket: "23.93.76.1:32005;32006", BlockNumber: 100},
+		{OldSocket: "23.93.76.1:32005;32006", NewSocket: "23.93.76.2:32005;32006", BlockNumber: 250},
+	}, response.Changes)
+
+	// The operator isn't indexed
+	mockSubgraphApi.On("QueryOperatorSocketUpdates").Return(nil, nil).Once()
+	assert.Equal(t, http.StatusNotFound, get(path).Code)
+
+	mockSubgraphApi.On("QueryOperatorSocketUpdates").Return(nil, subgraph.ErrSocketUpdatesNotIndexed).Once()
+	assert.Equal(t, http.StatusServiceUnavailable, get(path).Code)
+
+	assert.Equal(t, http.StatusBadRequest, get("/v1/operators-info/socket-history?operator_id=0x1234").Code)
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+}
+
+func TestFetchRegisteredAndDeregisteredOperatorsConcurrently(t *testing.T) {
+	r := setUpRouter()
+
+	mockSubgraphApi.On("QueryRegisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorRegistered, nil)
+	mockSubgraphApi.On("QueryDeregisteredOperatorsGreaterThanBlockTimestamp").Return(subgraphOperatorDeregistered, nil)
+	mockSubgraphApi.On("QueryOperatorInfoByOperatorIdAtBlockNumber").Return(subgraphIndexedOperatorInfo1, nil)
+	testDataApiServer = dataapi.NewServer(config, blobstore, prometheusClient, dataapi.NewSubgraphClient(mockSubgraphApi, mockLogger), mockTx, nil, mockChainState, mockIndexedChainState, mockLogger, metrics, &MockGRPCConnection{}, nil, nil)
+	r.GET("/v1/operators-info/registered-operators", testDataApiServer.FetchRegisteredOperators)
+	r.GET("/v1/operators-info/deregistered-operators", testDataApiServer.FetchDeregisteredOperators)
+
+	// Each request only gets the results of its own online checks
+	expected := map[string]*subgraph.Operator{
+		"/v1/operators-info/registered-operators?days=14":   subgraphOperatorRegistered[0],
+		"/v1/operators-info/deregistered-operators?days=14": subgraphOperatorDeregistered[0],
+	}
+	var wg sync.WaitGroup
+	for i := 0; i < 10; i++ {
+		for path, operator := range expected {
+			path, operator := path, operator
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				w := httptest.NewRecorder()
+				r.ServeHTTP(w, httptest.NewRequest(http.MethodGet, path, nil))
+				assert.Equal(t, http.StatusOK, w.Code)
+				var response dataapi.QueriedStateOperatorsResponse
+				assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+				if assert.Len(t, response.Data, 1) {
+					assert.Equal(t, string(operator.OperatorId), response.Data[0].OperatorId)
+					assert.Equal(t, string(operator.BlockNumber), fmt.Sprint(response.Data[0].BlockNumber))
+				}
+			}()
+		}
+	}
+	wg.Wait()
+
+	mockSubgraphApi.ExpectedCalls = nil
+	mockSubgraphApi.Calls = nil
+}
@@ -2,6 +2,7 @@ package subgraph
 
 import (
 	"context"
+	"errors"
 	"fmt"
 	"sync"
 	"time"
@@ -15,6 +16,10 @@ var (
 	maxEntriesPerQuery = 1000
 )
 
+// ErrSocketUpdatesNotIndexed is returned by the queries of the socket updates if the operator state subgraph doesn't
+// index them
+var ErrSocketUpdatesNotIndexed = errors.New("the socket updates of the operators are not indexed by the operator state subgraph")
+
 type (
 	Api interface {
 		QueryBatches(ctx context.Context, descending bool, orderByField string, first, skip int) ([]*Batches, error)
@@ -25,6 +30,7 @@ type (
 		QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*Operator, error)
 		QueryRegisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*Operator, error)
 		QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, operatorId string, blockNumber uint32) (*IndexedOperatorInfo, error)
+		QueryOperatorSocketUpdates(ctx context.Context, operatorId string) ([]*SocketUpdate, error)
 		QueryOperatorAddedToQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*OperatorQuorum, error)
 		QueryOperatorRemovedFromQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*OperatorQuorum, error)
 	}
@@ -32,6 +38,10 @@ type (
 	api struct {
 		uiMonitoringGql  *graphql.Client
 		operatorStateGql *graphql.Client
+
+		// The schema versions of the subgraphs, which are the newest ones until DetectSchemaVersions is called
+		batchMetadataSchema SchemaVersion
+		operatorStateSchema SchemaVersion
 	}
 )
 
@@ -42,8 +52,10 @@ func NewApi(uiMonitoringSocketAddr string, operatorStateSocketAddr string) *api
 		uiMonitoringGql := graphql.NewClient(uiMonitoringSocketAddr, nil)
 		operatorStateGql := graphql.NewClient(operatorStateSocketAddr, nil)
 		instance = &api{
-			uiMonitoringGql:  uiMonitoringGql,
-			operatorStateGql: operatorStateGql,
+			uiMonitoringGql:     uiMonitoringGql,
+			operatorStateGql:    operatorStateGql,
+			batchMetadataSchema: batchMetadataSchemas[0],
+			operatorStateSchema: operatorStateSchemas[0],
 		}
 	})
 	return instance
@@ -195,13 +207,27 @@ func (a *api) QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ctx context.Co
 }
 
 func (a *api) QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, operatorId string, blockNumber uint32) (*IndexedOperatorInfo, error) {
-	var (
-		query     queryOperatorById
-		variables = map[string]any{
-			"id": graphql.String(fmt.Sprintf("0x%s", operatorId)),
+	variables := map[string]any{
+		"id": graphql.String(fmt.Sprintf("0x%s", operatorId)),
+	}
+	if a.operatorStateSchema == OperatorStateSchemaV1 {
+		// The socket of the operator isn't indexed
+		var query queryOperatorByIdWithoutSocket
+		err := a.operatorStateGql.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, err
 		}
-	)
-	err := a.operatorStateGql.Query(context.Background(), &query, variables)
+		return &IndexedOperatorInfo{
+			Id:         query.Operator.Id,
+			PubkeyG1_X: query.Operator.PubkeyG1_X,
+			PubkeyG1_Y: query.Operator.PubkeyG1_Y,
+			PubkeyG2_X: query.Operator.PubkeyG2_X,
+			PubkeyG2_Y: query.Operator.PubkeyG2_Y,
+		}, nil
+	}
+
+	var query queryOperatorById
+	err := a.operatorStateGql.Query(ctx, &query, variables)
 	if err != nil {
 		return nil, err
 	}
@@ -209,6 +235,37 @@ func (a *api) QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, op
 	return &query.Operator, nil
 }
 
+// QueryOperatorSocketUpdates finds the socket updates of the operator, ascending by block number. It returns nil if
+// the operator isn't indexed.
+func (a *api) QueryOperatorSocketUpdates(ctx context.Context, operatorId string) ([]*SocketUpdate, error) {
+	if a.operatorStateSchema == OperatorStateSchemaV1 {
+		return nil, ErrSocketUpdatesNotIndexed
+	}
+	variables := map[string]any{
+		"id": graphql.String(fmt.Sprintf("0x%s", operatorId)),
+	}
+	skip := 0
+	socketUpdates := make([]*SocketUpdate, 0)
+	for {
+		variables["first"] = graphql.Int(maxEntriesPerQuery)
+		variables["skip"] = graphql.Int(skip)
+		var query queryOperatorSocketUpdates
+		err := a.operatorStateGql.Query(ctx, &query, variables)
+		if err != nil {
+			return nil, err
+		}
+		if query.Operator == nil {
+			return nil, nil
+		}
+		if len(query.Operator.SocketUpdates) == 0 {
+			break
+		}
+		socketUpdates = append(socketUpdates, query.Operator.SocketUpdates...)
+		skip += maxEntriesPerQuery
+	}
+	return socketUpdates, nil
+}
+
 // QueryOperatorAddedToQuorum finds operators' quorum opt-in history in range [startBlock, endBlock].
 func (a *api) QueryOperatorAddedToQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*OperatorQuorum, error) {
 	if startBlock > endBlock {
@@ -0,0 +1,55 @@
+package subgraph
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/shurcooL/graphql"
+)
+
+// IndexingStatus is the latest block indexed by a subgraph, as reported by the graph node
+type IndexingStatus struct {
+	BlockNumber uint64
+	// BlockTimestamp is zero if the graph node doesn't report the timestamps of the blocks
+	BlockTimestamp uint64
+	// HasIndexingErrors is whether the subgraph skipped some blocks because of indexing errors
+	HasIndexingErrors bool
+}
+
+type queryMeta struct {
+	Meta struct {
+		Block struct {
+			Number    graphql.Int
+			Timestamp *graphql.Int
+		}
+		HasIndexingErrors graphql.Boolean
+	} `graphql:"_meta"`
+}
+
+// QueryIndexingStatus returns the indexing status of the batch metadata and the operator state subgraphs
+func (a *api) QueryIndexingStatus(ctx context.Context) (batchMetadata *IndexingStatus, operatorState *IndexingStatus, err error) {
+	batchMetadata, err = queryIndexingStatus(ctx, a.uiMonitoringGql)
+	if err != nil {
+		return nil, nil, fmt.Errorf("batch metadata subgraph: %w", err)
+	}
+	operatorState, err = queryIndexingStatus(ctx, a.operatorStateGql)
+	if err != nil {
+		return nil, nil, fmt.Errorf("operator state subgraph: %w", err)
+	}
+	return batchMetadata, operatorState, nil
+}
+
+func queryIndexingStatus(ctx context.Context, client *graphql.Client) (*IndexingStatus, error) {
+	var query queryMeta
+	if err := client.Query(ctx, &query, nil); err != nil {
+		return nil, err
+	}
+	status := &IndexingStatus{
+		BlockNumber:       uint64(query.Meta.Block.Number),
+		HasIndexingErrors: bool(query.Meta.HasIndexingErrors),
+	}
+	if query.Meta.Block.Timestamp != nil {
+		status.BlockTimestamp = uint64(*query.Meta.Block.Timestamp)
+	}
+	return status, nil
+}
@@ -140,6 +140,17 @@ func (m *MockSubgraphApi) QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context
 	return value, args.Error(1)
 }
 
+func (m *MockSubgraphApi) QueryOperatorSocketUpdates(ctx context.Context, operatorId string) ([]*subgraph.SocketUpdate, error) {
+	args := m.Called()
+
+	var value []*subgraph.SocketUpdate
+	if args.Get(0) != nil {
+		value = args.Get(0).([]*subgraph.SocketUpdate)
+	}
+
+	return value, args.Error(1)
+}
+
 func (m *MockSubgraphApi) QueryOperatorAddedToQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*subgraph.OperatorQuorum, error) {
 	args := m.Called()
 
@@ -59,6 +59,11 @@ type (
 	SocketUpdates struct {
 		Socket graphql.String
 	}
+	// SocketUpdate is an update of the socket of an operator in the registry coordinator
+	SocketUpdate struct {
+		Socket      graphql.String
+		BlockNumber graphql.String
+	}
 	IndexedOperatorInfo struct {
 		Id         graphql.String
 		PubkeyG1_X graphql.String   `graphql:"pubkeyG1_X"`
@@ -68,6 +73,14 @@ type (
 		// Socket is the socket address of the operator, in the form "host:port"
 		SocketUpdates []SocketUpdates `graphql:"socketUpdates(first: 1, orderBy: blockNumber, orderDirection: desc)"`
 	}
+	// IndexedOperatorInfoWithoutSocket is the IndexedOperatorInfo of the schemas without the socket updates
+	IndexedOperatorInfoWithoutSocket struct {
+		Id         graphql.String
+		PubkeyG1_X graphql.String   `graphql:"pubkeyG1_X"`
+		PubkeyG1_Y graphql.String   `graphql:"pubkeyG1_Y"`
+		PubkeyG2_X []graphql.String `graphql:"pubkeyG2_X"`
+		PubkeyG2_Y []graphql.String `graphql:"pubkeyG2_Y"`
+	}
 	OperatorInfo struct {
 		IndexedOperatorInfo *IndexedOperatorInfo
 		// BlockNumber is the block number at which the operator was deregistered.
@@ -99,6 +112,15 @@ type (
 	queryOperatorById struct {
 		Operator IndexedOperatorInfo `graphql:"operator(id: $id)"`
 	}
+	queryOperatorByIdWithoutSocket struct {
+		Operator IndexedOperatorInfoWithoutSocket `graphql:"operator(id: $id)"`
+	}
+	queryOperatorSocketUpdates struct {
+		// Operator is nil if the operator isn't indexed
+		Operator *struct {
+			SocketUpdates []*SocketUpdate `graphql:"socketUpdates(first: $first, skip: $skip, orderBy: blockNumber, orderDirection: asc)"`
+		} `graphql:"operator(id: $id)"`
+	}
 	queryOperatorAddedToQuorum struct {
 		OperatorAddedToQuorum []*OperatorQuorum `graphql:"operatorAddedToQuorums(first: $first, skip: $skip, orderBy: blockTimestamp, where: {and: [{blockNumber_gt: $blockNumber_gt}, {blockNumber_lt: $blockNumber_lt}]})"`
 	}
@@ -0,0 +1,142 @@
+package subgraph
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/shurcooL/graphql"
+)
+
+// SchemaVersion is a known version of the schema of a subgraph, which determines the queries sent to it
+type SchemaVersion string
+
+const (
+	// BatchMetadataSchemaV1 is the schema of the batch metadata subgraph
+	BatchMetadataSchemaV1 SchemaVersion = "batch-metadata-v1"
+	// OperatorStateSchemaV1 is the schema of the operator state subgraph before the socket updates were indexed
+	OperatorStateSchemaV1 SchemaVersion = "operator-state-v1"
+	// OperatorStateSchemaV2 is the schema of the operator state subgraph indexing the socket updates of the operators
+	OperatorStateSchemaV2 SchemaVersion = "operator-state-v2"
+)
+
+// ErrIncompatibleSchema is returned when the schema of a subgraph doesn't match any known schema version
+var ErrIncompatibleSchema = errors.New("incompatible subgraph schema")
+
+// schemaFields are the fields of each entity which are queried by a schema version
+type schemaFields map[string][]string
+
+var (
+	batchMetadataSchemas = []SchemaVersion{BatchMetadataSchemaV1}
+	// operatorStateSchemas are ordered from the newest to the oldest, so that the newest compatible version is used
+	operatorStateSchemas = []SchemaVersion{OperatorStateSchemaV2, OperatorStateSchemaV1}
+
+	operatorEventFields = []string{"operator", "operatorId", "blockNumber", "blockTimestamp", "transactionHash"}
+	quorumEventFields   = []string{"operator", "quorumNumbers", "blockNumber", "blockTimestamp"}
+
+	knownSchemas = map[SchemaVersion]schemaFields{
+		BatchMetadataSchemaV1: {
+			"Batch":       {"batchId", "batchHeaderHash", "batchHeader", "nonSigning", "gasFees", "blockNumber", "blockTimestamp", "txHash"},
+			"GasFees":     {"gasUsed", "gasPrice", "txFee"},
+			"BatchHeader": {"quorumNumbers", "referenceBlockNumber"},
+			"NonSigning":  {"nonSigners"},
+			"Operator":    {"operatorId"},
+		},
+		OperatorStateSchemaV1: {
+			"OperatorRegistered":        operatorEventFields,
+			"OperatorDeregistered":      operatorEventFields,
+			"OperatorAddedToQuorum":     quorumEventFields,
+			"OperatorRemovedFromQuorum": quorumEventFields,
+			"Operator":                  {"pubkeyG1_X", "pubkeyG1_Y", "pubkeyG2_X", "pubkeyG2_Y"},
+		},
+		OperatorStateSchemaV2: {
+			"OperatorRegistered":        operatorEventFields,
+			"OperatorDeregistered":      operatorEventFields,
+			"OperatorAddedToQuorum":     quorumEventFields,
+			"OperatorRemovedFromQuorum": quorumEventFields,
+			"Operator":                  {"pubkeyG1_X", "pubkeyG1_Y", "pubkeyG2_X", "pubkeyG2_Y", "socketUpdates"},
+			"OperatorSocketUpdate":      {"socket", "blockNumber"},
+		},
+	}
+)
+
+type queryTypeFields struct {
+	Type *struct {
+		Fields []struct {
+			Name graphql.String
+		}
+	} `graphql:"__type(name: $name)"`
+}
+
+// DetectSchemaVersions probes the schemas of the subgraphs and selects the queries matching them. It returns an error
+// wrapping ErrIncompatibleSchema if a subgraph doesn't match any known schema version, so that the dataapi fails at
+// startup instead of returning empty results.
+func (a *api) DetectSchemaVersions(ctx context.Context) error {
+	batchMetadataSchema, err := detectSchemaVersion(ctx, a.uiMonitoringGql, batchMetadataSchemas)
+	if err != nil {
+		return fmt.Errorf("batch metadata subgraph: %w", err)
+	}
+	operatorStateSchema, err := detectSchemaVersion(ctx, a.operatorStateGql, operatorStateSchemas)
+	if err != nil {
+		return fmt.Errorf("operator state subgraph: %w", err)
+	}
+	a.batchMetadataSchema = batchMetadataSchema
+	a.operatorStateSchema = operatorStateSchema
+	return nil
+}
+
+// SchemaVersions returns the schema versions of the batch metadata and the operator state subgraphs
+func (a *api) SchemaVersions() (batchMetadata SchemaVersion, operatorState SchemaVersion) {
+	return a.batchMetadataSchema, a.operatorStateSchema
+}
+
+// detectSchemaVersion returns the first of the versions whose fields all exist in the schema of the subgraph
+func detectSchemaVersion(ctx context.Context, client *graphql.Client, versions []SchemaVersion) (SchemaVersion, error) {
+	entityFields := make(map[string]map[string]struct{})
+	// The fields missing from the newest version are reported if no version is compatible
+	var missingFromNewest []string
+	for i, version := range versions {
+		var missing []string
+		for entity, fields := range knownSchemas[version] {
+			if _, ok := entityFields[entity]; !ok {
+				existing, err := queryEntityFields(ctx, client, entity)
+				if err != nil {
+					return "", fmt.Errorf("failed to query the schema of %s: %w", entity, err)
+				}
+				entityFields[entity] = existing
+			}
+			for _, field := range fields {
+				if _, ok := entityFields[entity][field]; !ok {
+					missing = append(missing, entity+"."+field)
+				}
+			}
+		}
+		if len(missing) == 0 {
+			return version, nil
+		}
+		if i == 0 {
+			missingFromNewest = missing
+		}
+	}
+	sort.Strings(missingFromNewest)
+	return "", fmt.Errorf("%w: missing %s", ErrIncompatibleSchema, strings.Join(missingFromNewest, ", "))
+}
+
+// queryEntityFields returns the fields of the entity in the schema of the subgraph, which are empty if the entity
+// doesn't exist
+func queryEntityFields(ctx context.Context, client *graphql.Client, entity string) (map[string]struct{}, error) {
+	var query queryTypeFields
+	err := client.Query(ctx, &query, map[string]any{"name": graphql.String(entity)})
+	if err != nil {
+		return nil, err
+	}
+	fields := make(map[string]struct{})
+	if query.Type != nil {
+		for _, field := range query.Type.Fields {
+			fields[string(field.Name)] = struct{}{}
+		}
+	}
+	return fields, nil
+}
@@ -0,0 +1,119 @@
+package subgraph_test
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"sync"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	"github.com/stretchr/testify/assert"
+)
+
+var (
+	batchMetadataSchema = map[string][]string{
+		"Batch":       {"id", "batchId", "batchHeaderHash", "batchHeader", "nonSigning", "gasFees", "blockNumber", "blockTimestamp", "txHash"},
+		"GasFees":     {"id", "gasUsed", "gasPrice", "txFee"},
+		"BatchHeader": {"id", "blobHeadersRoot", "quorumNumbers", "signedStakeForQuorums", "referenceBlockNumber", "batch"},
+		"NonSigning":  {"id", "nonSigners", "batch"},
+		"Operator":    {"id", "operatorId", "nonSignings"},
+	}
+	operatorStateSchema = map[string][]string{
+		"OperatorRegistered":        {"id", "operator", "operatorId", "blockNumber", "blockTimestamp", "transactionHash"},
+		"OperatorDeregistered":      {"id", "operator", "operatorId", "blockNumber", "blockTimestamp", "transactionHash"},
+		"OperatorAddedToQuorum":     {"id", "operator", "quorumNumbers", "blockNumber", "blockTimestamp", "transactionHash"},
+		"OperatorRemovedFromQuorum": {"id", "operator", "quorumNumbers", "blockNumber", "blockTimestamp", "transactionHash"},
+		"Operator":                  {"id", "operator", "pubkeyG1_X", "pubkeyG1_Y", "pubkeyG2_X", "pubkeyG2_Y", "deregistrationBlockNumber", "socketUpdates"},
+		"OperatorSocketUpdate":      {"id", "operatorId", "socket", "blockNumber", "blockTimestamp", "transactionHash"},
+	}
+)
+
+// schemaServer serves the introspection queries of the types of a subgraph schema
+type schemaServer struct {
+	mu     sync.Mutex
+	schema map[string][]string
+}
+
+func (s *schemaServer) setSchema(schema map[string][]string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.schema = schema
+}
+
+func (s *schemaServer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	var request struct {
+		Variables struct {
+			Name string `json:"name"`
+		} `json:"variables"`
+	}
+	if err := json.NewDecoder(r.Body).Decode(&request); err != nil {
+		w.WriteHeader(http.StatusBadRequest)
+		return
+	}
+	s.mu.Lock()
+	fields, ok := s.schema[request.Variables.Name]
+	s.mu.Unlock()
+
+	var typ any
+	if ok {
+		named := make([]map[string]string, len(fields))
+		for i, field := range fields {
+			named[i] = map[string]string{"name": field}
+		}
+		typ = map[string]any{"fields": named}
+	}
+	_ = json.NewEncoder(w).Encode(map[string]any{"data": map[string]any{"__type": typ}})
+}
+
+func withoutField(schema map[string][]string, entity string, field string) map[string][]string {
+	result := make(map[string][]string, len(schema))
+	for e, fields := range schema {
+		result[e] = make([]string, 0, len(fields))
+		for _, f := range fields {
+			if e != entity || f != field {
+				result[e] = append(result[e], f)
+			}
+		}
+	}
+	return result
+}
+
+func TestDetectSchemaVersions(t *testing.T) {
+	batchMetadata := &schemaServer{schema: batchMetadataSchema}
+	operatorState := &schemaServer{schema: operatorStateSchema}
+	batchMetadataServer := httptest.NewServer(batchMetadata)
+	defer batchMetadataServer.Close()
+	operatorStateServer := httptest.NewServer(operatorState)
+	defer operatorStateServer.Close()
+
+	api := subgraph.NewApi(batchMetadataServer.URL, operatorStateServer.URL)
+	ctx := context.Background()
+
+	assert.NoError(t, api.DetectSchemaVersions(ctx))
+	batchMetadataVersion, operatorStateVersion := api.SchemaVersions()
+	assert.Equal(t, subgraph.BatchMetadataSchemaV1, batchMetadataVersion)
+	assert.Equal(t, subgraph.OperatorStateSchemaV2, operatorStateVersion)
+
+	// The operator state subgraph without the socket updates is still supported
+	legacy := withoutField(operatorStateSchema, "Operator", "socketUpdates")
+	delete(legacy, "OperatorSocketUpdate")
+	operatorState.setSchema(legacy)
+	assert.NoError(t, api.DetectSchemaVersions(ctx))
+	_, operatorStateVersion = api.SchemaVersions()
+	assert.Equal(t, subgraph.OperatorStateSchemaV1, operatorStateVersion)
+
+	// A schema without the fields of any known version is rejected
+	batchMetadata.setSchema(withoutField(batchMetadataSchema, "Batch", "gasFees"))
+	err := api.DetectSchemaVersions(ctx)
+	assert.ErrorIs(t, err, subgraph.ErrIncompatibleSchema)
+	assert.ErrorContains(t, err, "missing Batch.gasFees")
+
+	operatorState.setSchema(withoutField(operatorStateSchema, "OperatorRegistered", "operatorId"))
+	batchMetadata.setSchema(batchMetadataSchema)
+	err = api.DetectSchemaVersions(ctx)
+	assert.ErrorIs(t, err, subgraph.ErrIncompatibleSchema)
+	assert.ErrorContains(t, err, "operator state subgraph")
+	assert.ErrorContains(t, err, "OperatorRegistered.operatorId")
+}
@@ -30,12 +30,14 @@ const (
 type (
 	SubgraphClient interface {
 		QueryBatchesWithLimit(ctx context.Context, limit, skip int) ([]*Batch, error)
+		QueryBatchesByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*Batch, error)
 		QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error)
 		QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) (map[string]int, error)
 		QueryBatchNonSigningInfoInInterval(ctx context.Context, startTime, endTime int64) ([]*BatchNonSigningInfo, error)
 		QueryOperatorQuorumEvent(ctx context.Context, startBlock, endBlock uint32) (*OperatorQuorumEvents, error)
 		QueryIndexedOperatorsWithStateForTimeWindow(ctx context.Context, days int32, state OperatorState) (*IndexedQueriedOperatorInfo, error)
 		QueryOperatorInfoByOperatorId(ctx context.Context, operatorId string) (*core.IndexedOperatorInfo, error)
+		QueryOperatorSocketUpdates(ctx context.Context, operatorId string) ([]*OperatorSocketUpdate, error)
 	}
 	Batch struct {
 		Id              []byte
@@ -66,6 +68,11 @@ type (
 		BlockNumber    uint32
 		BlockTimestamp uint64
 	}
+	// OperatorSocketUpdate is an update of the socket of an operator in the registry coordinator
+	OperatorSocketUpdate struct {
+		Socket      string
+		BlockNumber uint64
+	}
 	OperatorQuorumEvents struct {
 		// AddedToQuorum is mapping from operator address to a list of sorted events
 		// (ascending by BlockNumber) where the operator was added to quorums.
@@ -86,6 +93,7 @@ type (
 	}
 	NonSigner struct {
 		OperatorId string
+		QuorumId   uint8
 		Count      int
 	}
 	BatchNonSigningInfo struct {
@@ -119,6 +127,14 @@ func (sc *subgraphClient) QueryBatchesWithLimit(ctx context.Context, limit, skip
 	return batches, nil
 }
 
+func (sc *subgraphClient) QueryBatchesByBlockTimestampRange(ctx context.Context, start, end uint64) ([]*Batch, error) {
+	subgraphBatches, err := sc.api.QueryBatchesByBlockTimestampRange(ctx, start, end)
+	if err != nil {
+		return nil, err
+	}
+	return convertBatches(subgraphBatches)
+}
+
 func (sc *subgraphClient) QueryOperatorsWithLimit(ctx context.Context, limit int) ([]*Operator, error) {
 	operatorsGql, err := sc.api.QueryOperators(ctx, limit)
 	if err != nil {
@@ -151,6 +167,30 @@ func (sc *subgraphClient) QueryOperatorInfoByOperatorId(ctx context.Context, ope
 	return indexedOperatorInfo, nil
 }
 
+// QueryOperatorSocketUpdates returns the socket updates of the operator, ascending by block number. It returns nil if
+// the operator isn't indexed.
+func (sc *subgraphClient) QueryOperatorSocketUpdates(ctx context.Context, operatorId string) ([]*OperatorSocketUpdate, error) {
+	socketUpdatesGql, err := sc.api.QueryOperatorSocketUpdates(ctx, operatorId)
+	if err != nil {
+		return nil, err
+	}
+	if socketUpdatesGql == nil {
+		return nil, nil
+	}
+	socketUpdates := make([]*OperatorSocketUpdate, len(socketUpdatesGql))
+	for i, socketUpdateGql := range socketUpdatesGql {
+		blockNumber, err := strconv.ParseUint(string(socketUpdateGql.BlockNumber), 10, 64)
+		if err != nil {
+			return nil, err
+		}
+		socketUpdates[i] = &OperatorSocketUpdate{
+			Socket:      string(socketUpdateGql.Socket),
+			BlockNumber: blockNumber,
+		}
+	}
+	return socketUpdates, nil
+}
+
 func (sc *subgraphClient) QueryBatchNonSigningInfoInInterval(ctx context.Context, startTime, endTime int64) ([]*BatchNonSigningInfo, error) {
 	batchNonSigningInfoGql, err := sc.api.QueryBatchNonSigningInfo(ctx, startTime, endTime)
 	if err != nil {
@@ -0,0 +1,160 @@
+package dataapi
+
+import (
+	"context"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// instrumentedSubgraphApi is a subgraph.Api which records the latency and the outcome of each query of the
+// underlying api in the metrics, so that the failures of the graph node are visible before the dashboards break
+type instrumentedSubgraphApi struct {
+	api     subgraph.Api
+	network string
+	metrics *Metrics
+}
+
+var _ subgraph.Api = (*instrumentedSubgraphApi)(nil)
+
+// NewInstrumentedSubgraphApi wraps the api so that its queries are recorded in the metrics with the network label
+func NewInstrumentedSubgraphApi(api subgraph.Api, network string, metrics *Metrics) subgraph.Api {
+	return &instrumentedSubgraphApi{api: api, network: network, metrics: metrics}
+}
+
+func (a *instrumentedSubgraphApi) observe(query string, start time.Time, err error) {
+	a.metrics.ObserveSubgraphQuery(a.network, query, float64(time.Since(start).Milliseconds()), err)
+}
+
+func (a *instrumentedSubgraphApi) QueryBatches(ctx context.Context, descending bool, orderByField string, first, skip int) ([]*subgraph.Batches, error) {
+	start := time.Now()
+	batches, err := a.api.QueryBatches(ctx, descending, orderByField, first, skip)
+	a.observe("QueryBatches", start, err)
+	return batches, err
+}
+
+func (a *instrumentedSubgraphApi) QueryBatchesByBlockTimestampRange(ctx context.Context, startTime, endTime uint64) ([]*subgraph.Batches, error) {
+	start := time.Now()
+	batches, err := a.api.QueryBatchesByBlockTimestampRange(ctx, startTime, endTime)
+	a.observe("QueryBatchesByBlockTimestampRange", start, err)
+	return batches, err
+}
+
+func (a *instrumentedSubgraphApi) QueryOperators(ctx context.Context, first int) ([]*subgraph.Operator, error) {
+	start := time.Now()
+	operators, err := a.api.QueryOperators(ctx, first)
+	a.observe("QueryOperators", start, err)
+	return operators, err
+}
+
+func (a *instrumentedSubgraphApi) QueryBatchNonSigningOperatorIdsInInterval(ctx context.Context, intervalSeconds int64) ([]*subgraph.BatchNonSigningOperatorIds, error) {
+	start := time.Now()
+	operatorIds, err := a.api.QueryBatchNonSigningOperatorIdsInInterval(ctx, intervalSeconds)
+	a.observe("QueryBatchNonSigningOperatorIdsInInterval", start, err)
+	return operatorIds, err
+}
+
+func (a *instrumentedSubgraphApi) QueryBatchNonSigningInfo(ctx context.Context, startTime, endTime int64) ([]*subgraph.BatchNonSigningInfo, error) {
+	start := time.Now()
+	info, err := a.api.QueryBatchNonSigningInfo(ctx, startTime, endTime)
+	a.observe("QueryBatchNonSigningInfo", start, err)
+	return info, err
+}
+
+func (a *instrumentedSubgraphApi) QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*subgraph.Operator, error) {
+	start := time.Now()
+	operators, err := a.api.QueryDeregisteredOperatorsGreaterThanBlockTimestamp(ctx, blockTimestamp)
+	a.observe("QueryDeregisteredOperatorsGreaterThanBlockTimestamp", start, err)
+	return operators, err
+}
+
+func (a *instrumentedSubgraphApi) QueryRegisteredOperatorsGreaterThanBlockTimestamp(ctx context.Context, blockTimestamp uint64) ([]*subgraph.Operator, error) {
+	start := time.Now()
+	operators, err := a.api.QueryRegisteredOperatorsGreaterThanBlockTimestamp(ctx, blockTimestamp)
+	a.observe("QueryRegisteredOperatorsGreaterThanBlockTimestamp", start, err)
+	return operators, err
+}
+
+func (a *instrumentedSubgraphApi) QueryOperatorInfoByOperatorIdAtBlockNumber(ctx context.Context, operatorId string, blockNumber uint32) (*subgraph.IndexedOperatorInfo, error) {
+	start := time.Now()
+	info, err := a.api.QueryOperatorInfoByOperatorIdAtBlockNumber(ctx, operatorId, blockNumber)
+	a.observe("QueryOperatorInfoByOperatorIdAtBlockNumber", start, err)
+	return info, err
+}
+
+func (a *instrumentedSubgraphApi) QueryOperatorSocketUpdates(ctx context.Context, operatorId string) ([]*subgraph.SocketUpdate, error) {
+	start := time.Now()
+	socketUpdates, err := a.api.QueryOperatorSocketUpdates(ctx, operatorId)
+	a.observe("QueryOperatorSocketUpdates", start, err)
+	return socketUpdates, err
+}
+
+func (a *instrumentedSubgraphApi) QueryOperatorAddedToQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*subgraph.OperatorQuorum, error) {
+	start := time.Now()
+	quorums, err := a.api.QueryOperatorAddedToQuorum(ctx, startBlock, endBlock)
+	a.observe("QueryOperatorAddedToQuorum", start, err)
+	return quorums, err
+}
+
+func (a *instrumentedSubgraphApi) QueryOperatorRemovedFromQuorum(ctx context.Context, startBlock, endBlock uint32) ([]*subgraph.OperatorQuorum, error) {
+	start := time.Now()
+	quorums, err := a.api.QueryOperatorRemovedFromQuorum(ctx, startBlock, endBlock)
+	a.observe("QueryOperatorRemovedFromQuorum", start, err)
+	return quorums, err
+}
+
+// SubgraphIndexingSource reports how far the subgraphs have indexed the chain
+type SubgraphIndexingSource interface {
+	QueryIndexingStatus(ctx context.Context) (batchMetadata *subgraph.IndexingStatus, operatorState *subgraph.IndexingStatus, err error)
+}
+
+// SubgraphMonitor periodically records the indexing status of the subgraphs of a network in the metrics, so that an
+// alert fires when the indexer lags behind the chain or skips blocks
+type SubgraphMonitor struct {
+	source  SubgraphIndexingSource
+	network string
+	metrics *Metrics
+	logger  logging.Logger
+}
+
+func NewSubgraphMonitor(source SubgraphIndexingSource, network string, metrics *Metrics, logger logging.Logger) *SubgraphMonitor {
+	return &SubgraphMonitor{
+		source:  source,
+		network: network,
+		metrics: metrics,
+		logger:  logger.With("component", "SubgraphMonitor"),
+	}
+}
+
+// Start records the indexing status at each interval until the context is done
+func (m *SubgraphMonitor) Start(ctx context.Context, interval time.Duration) {
+	go func() {
+		ticker := time.NewTicker(interval)
+		defer ticker.Stop()
+		for {
+			if err := m.Update(ctx, time.Now()); err != nil {
+				m.logger.Error("failed to query the subgraph indexing status", "network", m.network, "err", err)
+			}
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+			}
+		}
+	}()
+}
+
+// Update queries the indexing status of the subgraphs and records it in the metrics. The lag is measured from now.
+// The query itself is recorded like the other subgraph queries, so that an unreachable graph node is also visible.
+func (m *SubgraphMonitor) Update(ctx context.Context, now time.Time) error {
+	start := time.Now()
+	batchMetadata, operatorState, err := m.source.QueryIndexingStatus(ctx)
+	m.metrics.ObserveSubgraphQuery(m.network, "QueryIndexingStatus", float64(time.Since(start).Milliseconds()), err)
+	if err != nil {
+		return err
+	}
+	m.metrics.UpdateSubgraphIndexingStatus(m.network, "batch_metadata", batchMetadata, now)
+	m.metrics.UpdateSubgraphIndexingStatus(m.network, "operator_state", operatorState, now)
+	return nil
+}
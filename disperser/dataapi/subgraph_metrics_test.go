@@ -0,0 +1,72 @@
+package dataapi_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser/dataapi"
+	"github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph"
+	subgraphmock "github.com/Layr-Labs/eigenda/disperser/dataapi/subgraph/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type indexingSource struct {
+	batchMetadata *subgraph.IndexingStatus
+	operatorState *subgraph.IndexingStatus
+	err           error
+}
+
+func (s *indexingSource) QueryIndexingStatus(ctx context.Context) (*subgraph.IndexingStatus, *subgraph.IndexingStatus, error) {
+	return s.batchMetadata, s.operatorState, s.err
+}
+
+func TestInstrumentedSubgraphApi(t *testing.T) {
+	metrics := dataapi.NewMetrics(nil, "9001", logging.NewNoopLogger())
+	mockApi := &subgraphmock.MockSubgraphApi{}
+	mockApi.On("QueryOperators").Return(subgraphOperatorRegistereds, nil).Once()
+	mockApi.On("QueryOperators").Return(nil, errors.New("graph node unavailable")).Once()
+	mockApi.On("QueryOperators").Return(nil, context.Canceled).Once()
+	api := dataapi.NewInstrumentedSubgraphApi(mockApi, "testnet", metrics)
+
+	operators, err := api.QueryOperators(context.Background(), 2)
+	require.NoError(t, err)
+	assert.Equal(t, subgraphOperatorRegistereds, operators)
+	_, err = api.QueryOperators(context.Background(), 2)
+	assert.ErrorContains(t, err, "graph node unavailable")
+	_, err = api.QueryOperators(context.Background(), 2)
+	assert.ErrorIs(t, err, context.Canceled)
+
+	// The queries canceled by the caller don't count against the error budget
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.SubgraphQueries.WithLabelValues("testnet", "success", "QueryOperators")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.SubgraphQueries.WithLabelValues("testnet", "failed", "QueryOperators")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.SubgraphQueries.WithLabelValues("testnet", "canceled", "QueryOperators")))
+	assert.Equal(t, 1, testutil.CollectAndCount(metrics.SubgraphQueryLatency))
+}
+
+func TestSubgraphMonitor(t *testing.T) {
+	metrics := dataapi.NewMetrics(nil, "9001", logging.NewNoopLogger())
+	now := time.Unix(1_700_000_000, 0)
+	source := &indexingSource{
+		batchMetadata: &subgraph.IndexingStatus{BlockNumber: 100, BlockTimestamp: uint64(now.Unix()) - 30},
+		operatorState: &subgraph.IndexingStatus{BlockNumber: 90, BlockTimestamp: uint64(now.Unix()) - 150, HasIndexingErrors: true},
+	}
+	monitor := dataapi.NewSubgraphMonitor(source, "testnet", metrics, logging.NewNoopLogger())
+
+	require.NoError(t, monitor.Update(context.Background(), now))
+	assert.Equal(t, 100.0, testutil.ToFloat64(metrics.SubgraphIndexedBlock.WithLabelValues("testnet", "batch_metadata")))
+	assert.Equal(t, 30.0, testutil.ToFloat64(metrics.SubgraphIndexingLag.WithLabelValues("testnet", "batch_metadata")))
+	assert.Equal(t, 0.0, testutil.ToFloat64(metrics.SubgraphIndexingErrors.WithLabelValues("testnet", "batch_metadata")))
+	assert.Equal(t, 90.0, testutil.ToFloat64(metrics.SubgraphIndexedBlock.WithLabelValues("testnet", "operator_state")))
+	assert.Equal(t, 150.0, testutil.ToFloat64(metrics.SubgraphIndexingLag.WithLabelValues("testnet", "operator_state")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.SubgraphIndexingErrors.WithLabelValues("testnet", "operator_state")))
+
+	// A graph node which can't be reached is recorded as a failed query
+	source.err = errors.New("connection refused")
+	assert.ErrorContains(t, monitor.Update(context.Background(), now), "connection refused")
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.SubgraphQueries.WithLabelValues("testnet", "failed", "QueryIndexingStatus")))
+}
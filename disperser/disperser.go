@@ -15,6 +15,7 @@ import (
 	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/crypto"
 )
 
 type BlobStatus uint
@@ -85,6 +86,9 @@ type BlobMetadata struct {
 	// This field is nil if the blob has not been confirmed
 	// This field is omitted when marshalling to DynamoDB attributevalue as this field will be flattened
 	ConfirmationInfo *ConfirmationInfo `json:"blob_confirmation_info" dynamodbav:"-"`
+	// ConfirmationSubmission records the submission of the confirmBatch transaction of the last batch of the blob
+	// This field is nil if no batch of the blob has been submitted for confirmation
+	ConfirmationSubmission *ConfirmationSubmission `json:"confirmation_submission"`
 }
 
 func (m *BlobMetadata) GetBlobKey() BlobKey {
@@ -107,10 +111,36 @@ func (m *BlobMetadata) IsConfirmed() (bool, error) {
 
 type RequestMetadata struct {
 	core.BlobRequestHeader
+	RequestOrigin
 	BlobSize    uint   `json:"blob_size"`
 	RequestedAt uint64 `json:"requested_at"`
 }
 
+// DispersalSurface is the disperser API through which a blob was dispersed
+type DispersalSurface string
+
+const (
+	// FreeDispersal is the unauthenticated DisperseBlob endpoint
+	FreeDispersal DispersalSurface = "free"
+	// AuthenticatedDispersal is the DisperseBlobAuthenticated endpoint
+	AuthenticatedDispersal DispersalSurface = "authenticated"
+	// VoucherDispersal is the DisperseBlobAuthenticated endpoint with a voucher of the disperser operator
+	VoucherDispersal DispersalSurface = "voucher"
+)
+
+// RequestOrigin describes where a dispersal request came from.
+// Blobs stored before the origin was recorded have an empty origin.
+type RequestOrigin struct {
+	// AuthenticatedAccount is the account that signed the request, empty if the request was not authenticated
+	AuthenticatedAccount string `json:"authenticated_account"`
+	// ClientVersion is the version reported by the client in the request headers
+	ClientVersion string `json:"client_version"`
+	// DispersalSurface is the API used to disperse the blob
+	DispersalSurface DispersalSurface `json:"dispersal_surface"`
+	// PrivateRetrieval is whether only the AuthenticatedAccount may retrieve the blob from the disperser
+	PrivateRetrieval bool `json:"private_retrieval"`
+}
+
 type ConfirmationInfo struct {
 	BatchHeaderHash         [32]byte                             `json:"batch_header_hash"`
 	BlobIndex               uint32                               `json:"blob_index"`
@@ -128,6 +158,16 @@ type ConfirmationInfo struct {
 	BlobQuorumInfos         []*core.BlobQuorumInfo               `json:"blob_quorum_infos"`
 }
 
+// ConfirmationSubmission is recorded on a blob before the confirmBatch transaction of its batch is submitted, so that
+// a restarted batcher can check whether the batch was confirmed onchain instead of dispersing the blob again.
+type ConfirmationSubmission struct {
+	// BlobStatus is the status of the blob once the batch is confirmed, either Confirmed or InsufficientSignatures
+	BlobStatus BlobStatus `json:"blob_status"`
+	// ConfirmationInfo is the confirmation info of the blob once the batch is confirmed
+	// The batch ID and the fields of the confirmation transaction are only set once the batch is confirmed
+	ConfirmationInfo *ConfirmationInfo `json:"confirmation_info"`
+}
+
 type BlobStoreExclusiveStartKey struct {
 	BlobHash     BlobHash
 	MetadataHash MetadataHash
@@ -144,7 +184,7 @@ type BatchIndexExclusiveStartKey struct {
 
 type BlobStore interface {
 	// StoreBlob adds a blob to the queue and returns a key that can be used to retrieve the blob later
-	StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64) (BlobKey, error)
+	StoreBlob(ctx context.Context, blob *core.Blob, requestedAt uint64, origin RequestOrigin) (BlobKey, error)
 	// GetBlobContent retrieves a blob's content
 	GetBlobContent(ctx context.Context, blobHash BlobHash) ([]byte, error)
 	// MarkBlobConfirmed updates blob metadata to Confirmed status with confirmation info
@@ -152,6 +192,8 @@ type BlobStore interface {
 	MarkBlobConfirmed(ctx context.Context, existingMetadata *BlobMetadata, confirmationInfo *ConfirmationInfo) (*BlobMetadata, error)
 	// MarkBlobDispersing updates blob metadata to Dispersing status
 	MarkBlobDispersing(ctx context.Context, blobKey BlobKey) error
+	// RecordConfirmationSubmission records on a dispersing blob that the confirmBatch transaction of its batch is submitted
+	RecordConfirmationSubmission(ctx context.Context, existingMetadata *BlobMetadata, submission *ConfirmationSubmission) error
 	// MarkBlobInsufficientSignatures updates blob metadata to InsufficientSignatures status with confirmation info
 	// Returns the updated metadata and error
 	MarkBlobInsufficientSignatures(ctx context.Context, existingMetadata *BlobMetadata, confirmationInfo *ConfirmationInfo) (*BlobMetadata, error)
@@ -178,6 +220,9 @@ type BlobStore interface {
 	GetAllBlobMetadataByBatch(ctx context.Context, batchHeaderHash [32]byte) ([]*BlobMetadata, error)
 	// GetAllBlobMetadataByBatchWithPagination returns all the blobs in the batch using pagination
 	GetAllBlobMetadataByBatchWithPagination(ctx context.Context, batchHeaderHash [32]byte, limit int32, exclusiveStartKey *BatchIndexExclusiveStartKey) ([]*BlobMetadata, *BatchIndexExclusiveStartKey, error)
+	// GetBlobMetadataByCommitmentHash returns the metadata of the confirmed blobs whose KZG commitment hashes to
+	// commitmentHash, see ComputeCommitmentHash. A blob dispersed several times is returned once per dispersal.
+	GetBlobMetadataByCommitmentHash(ctx context.Context, commitmentHash [32]byte) ([]*BlobMetadata, error)
 	// GetBlobMetadata returns a blob metadata given a metadata key
 	GetBlobMetadata(ctx context.Context, blobKey BlobKey) (*BlobMetadata, error)
 	// GetBulkBlobMetadata returns a list of blob metadata given a list of blob keys
@@ -194,6 +239,22 @@ type Dispatcher interface {
 	SendAttestBatchRequest(ctx context.Context, nodeDispersalClient node.DispersalClient, blobHeaderHashes [][32]byte, batchHeader *core.BatchHeader, op *core.IndexedOperatorInfo) (*core.Signature, error)
 }
 
+// ComputeCommitmentHash returns the hash by which the blobs are looked up by their KZG commitment, which is the
+// keccak256 hash of the ABI encoding of the commitment as a G1 point, as in the blob headers confirmed onchain
+func ComputeCommitmentHash(commitment *encoding.G1Commitment) [32]byte {
+	x := commitment.X.Bytes()
+	y := commitment.Y.Bytes()
+	return crypto.Keccak256Hash(x[:], y[:])
+}
+
+// CommitmentHash returns the hash of the KZG commitment of the blob, which is only set once the blob is confirmed
+func (c *ConfirmationInfo) CommitmentHash() ([32]byte, bool) {
+	if c.BlobCommitment == nil || c.BlobCommitment.Commitment == nil {
+		return [32]byte{}, false
+	}
+	return ComputeCommitmentHash(c.BlobCommitment.Commitment), true
+}
+
 // GenerateReverseIndexKey returns the key used to store the blob key in the reverse index
 func GenerateReverseIndexKey(batchHeaderHash [32]byte, blobIndex uint32) (string, error) {
 	blobIndexHash, err := common.Hash[uint32](blobIndex)
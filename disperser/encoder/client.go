@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/disperser"
 	pb "github.com/Layr-Labs/eigenda/disperser/api/grpc/encoder"
@@ -13,19 +14,38 @@ import (
 	"google.golang.org/grpc/credentials/insecure"
 )
 
+// unhealthyAfterFailures is the number of encoding requests in a row that fail before the encoder client is unhealthy
+const unhealthyAfterFailures = 10
+
 type client struct {
 	addr    string
 	timeout time.Duration
+	health  *healthcheck.Tracker
 }
 
-func NewEncoderClient(addr string, timeout time.Duration) (disperser.EncoderClient, error) {
-	return client{
+var _ disperser.EncoderClient = (*client)(nil)
+var _ healthcheck.HealthReporter = (*client)(nil)
+
+func NewEncoderClient(addr string, timeout time.Duration) (*client, error) {
+	return &client{
 		addr:    addr,
 		timeout: timeout,
+		health:  healthcheck.NewTracker("EncoderClient", unhealthyAfterFailures),
 	}, nil
 }
 
-func (c client) EncodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, *core.ChunksData, error) {
+func (c *client) EncodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, *core.ChunksData, error) {
+	commitments, chunks, err := c.encodeBlob(ctx, data, encodingParams)
+	c.health.Record(err)
+	return commitments, chunks, err
+}
+
+// Health returns the health of the encoder client, which is degraded once encoding requests fail
+func (c *client) Health() healthcheck.ComponentHealth {
+	return c.health.Health()
+}
+
+func (c *client) encodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, *core.ChunksData, error) {
 	conn, err := grpc.Dial(
 		c.addr,
 		grpc.WithTransportCredentials(insecure.NewCredentials()),
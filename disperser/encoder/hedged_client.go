@@ -0,0 +1,122 @@
+package encoder
+
+import (
+	"context"
+	"errors"
+	"sync/atomic"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// hedgedClient sends each encoding request to an encoder replica, picked in turn, and hedges it to the next replica
+// when the first one doesn't reply within the hedging delay or fails. The first successful result is used and the
+// other request is canceled, so that a slow or failing replica doesn't hold up the blobs queued behind it.
+type hedgedClient struct {
+	replicas     []disperser.EncoderClient
+	hedgingDelay time.Duration
+	next         atomic.Uint64
+
+	logger  logging.Logger
+	metrics *batcher.EncoderHedgingMetrics
+	health  *healthcheck.Tracker
+}
+
+var _ disperser.EncoderClient = (*hedgedClient)(nil)
+var _ healthcheck.HealthReporter = (*hedgedClient)(nil)
+
+type encodeResult struct {
+	replica     int
+	commitments *encoding.BlobCommitments
+	chunks      *core.ChunksData
+	err         error
+}
+
+func NewHedgedEncoderClient(replicas []disperser.EncoderClient, hedgingDelay time.Duration, logger logging.Logger, metrics *batcher.EncoderHedgingMetrics) (*hedgedClient, error) {
+	if len(replicas) < 2 {
+		return nil, errors.New("hedged encoding requests require at least 2 encoder replicas")
+	}
+	if hedgingDelay <= 0 {
+		return nil, errors.New("the hedging delay must be positive")
+	}
+	return &hedgedClient{
+		replicas:     replicas,
+		hedgingDelay: hedgingDelay,
+		logger:       logger.With("component", "HedgedEncoderClient"),
+		metrics:      metrics,
+		health:       healthcheck.NewTracker("EncoderClient", unhealthyAfterFailures),
+	}, nil
+}
+
+func (c *hedgedClient) EncodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, *core.ChunksData, error) {
+	commitments, chunks, err := c.encodeBlob(ctx, data, encodingParams)
+	c.health.Record(err)
+	return commitments, chunks, err
+}
+
+// Health returns the health of the encoder replicas, which is degraded once encoding requests fail on both replicas
+func (c *hedgedClient) Health() healthcheck.ComponentHealth {
+	return c.health.Health()
+}
+
+func (c *hedgedClient) encodeBlob(ctx context.Context, data []byte, encodingParams encoding.EncodingParams) (*encoding.BlobCommitments, *core.ChunksData, error) {
+	// Canceling the context on return cancels the request which didn't win
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	primary := int((c.next.Add(1) - 1) % uint64(len(c.replicas)))
+	secondary := (primary + 1) % len(c.replicas)
+	// Buffered so that the request which didn't win doesn't block once it returns
+	results := make(chan encodeResult, 2)
+	send := func(replica int) {
+		go func() {
+			commitments, chunks, err := c.replicas[replica].EncodeBlob(ctx, data, encodingParams)
+			results <- encodeResult{replica: replica, commitments: commitments, chunks: chunks, err: err}
+		}()
+	}
+	hedge := func(reason string) {
+		c.metrics.IncrementHedgedRequests(reason)
+		send(secondary)
+	}
+
+	send(primary)
+	timer := time.NewTimer(c.hedgingDelay)
+	defer timer.Stop()
+
+	pending, hedged := 1, false
+	var err error
+	for pending > 0 {
+		select {
+		case <-timer.C:
+			if !hedged {
+				hedged = true
+				pending++
+				hedge("latency")
+			}
+		case result := <-results:
+			pending--
+			if result.err == nil {
+				if result.replica == primary {
+					c.metrics.IncrementWinner("primary")
+				} else {
+					c.metrics.IncrementWinner("hedge")
+				}
+				return result.commitments, result.chunks, nil
+			}
+			c.logger.Warn("encoding request failed", "replica", result.replica, "err", result.err)
+			err = result.err
+			if !hedged && ctx.Err() == nil {
+				hedged = true
+				pending++
+				hedge("failure")
+			}
+		}
+	}
+	c.metrics.IncrementWinner("none")
+	return nil, nil, err
+}
@@ -0,0 +1,82 @@
+package encoder_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/disperser"
+	"github.com/Layr-Labs/eigenda/disperser/batcher"
+	"github.com/Layr-Labs/eigenda/disperser/encoder"
+	dmock "github.com/Layr-Labs/eigenda/disperser/mock"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+)
+
+func TestHedgedEncoderClient(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	metrics := batcher.NewMetrics("9100", logger).EncoderHedgingMetrics
+	params := encoding.EncodingParams{ChunkLength: 4, NumChunks: 8}
+	primaryCommitments := &encoding.BlobCommitments{Length: 1}
+	hedgeCommitments := &encoding.BlobCommitments{Length: 2}
+
+	_, err := encoder.NewHedgedEncoderClient([]disperser.EncoderClient{dmock.NewMockEncoderClient()}, time.Second, logger, metrics)
+	assert.Error(t, err)
+
+	// The primary replica replies within the hedging delay
+	primary, hedge := dmock.NewMockEncoderClient(), dmock.NewMockEncoderClient()
+	primary.On("EncodeBlob", mock.Anything, mock.Anything, params).Return(primaryCommitments, nil, nil).Once()
+	client, err := encoder.NewHedgedEncoderClient([]disperser.EncoderClient{primary, hedge}, time.Second, logger, metrics)
+	assert.NoError(t, err)
+	commitments, _, err := client.EncodeBlob(context.Background(), []byte{1}, params)
+	assert.NoError(t, err)
+	assert.Equal(t, primaryCommitments, commitments)
+	hedge.AssertNotCalled(t, "EncodeBlob", mock.Anything, mock.Anything, mock.Anything)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Winners.WithLabelValues("primary")))
+
+	// The primary replica is stuck, so the request is hedged and the primary request is canceled
+	primary, hedge = dmock.NewMockEncoderClient(), dmock.NewMockEncoderClient()
+	canceled := make(chan struct{})
+	primary.On("EncodeBlob", mock.Anything, mock.Anything, params).Run(func(args mock.Arguments) {
+		<-args.Get(0).(context.Context).Done()
+		close(canceled)
+	}).Return(nil, nil, context.Canceled).Once()
+	hedge.On("EncodeBlob", mock.Anything, mock.Anything, params).Return(hedgeCommitments, nil, nil).Once()
+	client, err = encoder.NewHedgedEncoderClient([]disperser.EncoderClient{primary, hedge}, 10*time.Millisecond, logger, metrics)
+	assert.NoError(t, err)
+	commitments, _, err = client.EncodeBlob(context.Background(), []byte{1}, params)
+	assert.NoError(t, err)
+	assert.Equal(t, hedgeCommitments, commitments)
+	select {
+	case <-canceled:
+	case <-time.After(5 * time.Second):
+		t.Fatal("the primary request wasn't canceled")
+	}
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.HedgedRequests.WithLabelValues("latency")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Winners.WithLabelValues("hedge")))
+
+	// The primary replica fails, so the request is hedged without waiting for the hedging delay
+	primary, hedge = dmock.NewMockEncoderClient(), dmock.NewMockEncoderClient()
+	primary.On("EncodeBlob", mock.Anything, mock.Anything, params).Return(nil, nil, errors.New("encoder failure")).Once()
+	hedge.On("EncodeBlob", mock.Anything, mock.Anything, params).Return(hedgeCommitments, nil, nil).Once()
+	client, err = encoder.NewHedgedEncoderClient([]disperser.EncoderClient{primary, hedge}, time.Minute, logger, metrics)
+	assert.NoError(t, err)
+	commitments, _, err = client.EncodeBlob(context.Background(), []byte{1}, params)
+	assert.NoError(t, err)
+	assert.Equal(t, hedgeCommitments, commitments)
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.HedgedRequests.WithLabelValues("failure")))
+
+	// The next request is sent to the other replica first, and fails on both replicas
+	hedge.On("EncodeBlob", mock.Anything, mock.Anything, params).Return(nil, nil, errors.New("encoder failure")).Once()
+	primary.On("EncodeBlob", mock.Anything, mock.Anything, params).Return(nil, nil, errors.New("encoder failure")).Once()
+	_, _, err = client.EncodeBlob(context.Background(), []byte{1}, params)
+	assert.ErrorContains(t, err, "encoder failure")
+	assert.Equal(t, 2.0, testutil.ToFloat64(metrics.HedgedRequests.WithLabelValues("failure")))
+	assert.Equal(t, 1.0, testutil.ToFloat64(metrics.Winners.WithLabelValues("none")))
+	primary.AssertExpectations(t)
+	hedge.AssertExpectations(t)
+}
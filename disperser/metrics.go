@@ -25,6 +25,8 @@ type Metrics struct {
 	NumRpcRequests  *prometheus.CounterVec
 	BlobSize        *prometheus.GaugeVec
 	Latency         *prometheus.SummaryVec
+	// SystemThroughputUtilization is the fraction of the system throughput rate limit of each quorum which is used
+	SystemThroughputUtilization *prometheus.GaugeVec
 
 	httpPort string
 	logger   logging.Logger
@@ -78,6 +80,14 @@ func NewMetrics(reg *prometheus.Registry, httpPort string, logger logging.Logger
 			},
 			[]string{"method"},
 		),
+		SystemThroughputUtilization: promauto.With(reg).NewGaugeVec(
+			prometheus.GaugeOpts{
+				Namespace: namespace,
+				Name:      "system_throughput_utilization",
+				Help:      "the fraction of the system throughput rate limit of the quorum which is used",
+			},
+			[]string{"quorum"},
+		),
 		registry: reg,
 		httpPort: httpPort,
 		logger:   logger.With("component", "DisperserMetrics"),
@@ -114,6 +124,14 @@ func (g *Metrics) HandleNotFoundRpcRequest(method string) {
 	}).Inc()
 }
 
+func (g *Metrics) HandleUnauthenticatedRpcRequest(method string) {
+	g.NumRpcRequests.With(prometheus.Labels{
+		"status_code":   codes.Unauthenticated.String(),
+		"status_detail": "",
+		"method":        method,
+	}).Inc()
+}
+
 func (g *Metrics) HandleSystemRateLimitedRpcRequest(method string) {
 	g.NumRpcRequests.With(prometheus.Labels{
 		"status_code":   codes.ResourceExhausted.String(),
@@ -259,6 +277,11 @@ func (g *Metrics) HandleAccountRateLimitedRequest(quorum string, blobBytes int,
 	}).Add(float64(blobBytes))
 }
 
+// UpdateSystemThroughputUtilization sets the fraction of the system throughput rate limit of the quorum which is used
+func (g *Metrics) UpdateSystemThroughputUtilization(quorum string, utilization float64) {
+	g.SystemThroughputUtilization.WithLabelValues(quorum).Set(utilization)
+}
+
 // Start starts the metrics server
 func (g *Metrics) Start(ctx context.Context) {
 	g.logger.Info("Starting metrics server at ", "port", g.httpPort)
@@ -9,4 +9,8 @@ const (
 type ServerConfig struct {
 	GrpcPort    string
 	GrpcTimeout time.Duration
+	// BlobStatusPollInterval is the interval at which the blob statuses are polled by the status subscriptions, and
+	// BlobStatusKeepaliveInterval the interval after which a keepalive is sent to the subscriptions without updates
+	BlobStatusPollInterval      time.Duration
+	BlobStatusKeepaliveInterval time.Duration
 }
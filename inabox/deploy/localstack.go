@@ -2,82 +2,26 @@ package deploy
 
 import (
 	"context"
-	"errors"
 	"fmt"
 	"log"
-	"net/http"
 	"path/filepath"
 	"runtime"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/aws"
+	"github.com/Layr-Labs/eigenda/common/aws/localstack"
 	"github.com/Layr-Labs/eigenda/common/store"
 	"github.com/Layr-Labs/eigenda/disperser/common/blobstore"
 	"github.com/ory/dockertest/v3"
-	"github.com/ory/dockertest/v3/docker"
 
 	test_utils "github.com/Layr-Labs/eigenda/common/aws/dynamodb/utils"
 )
 
 func StartDockertestWithLocalstackContainer(localStackPort string) (*dockertest.Pool, *dockertest.Resource, error) {
 	fmt.Println("Starting Localstack container")
-	pool, err := dockertest.NewPool("")
+	pool, resource, err := localstack.StartContainer(localStackPort)
 	if err != nil {
-		fmt.Println("Could not construct pool: %w", err)
-		return nil, nil, err
-	}
-
-	err = pool.Client.Ping()
-	if err != nil {
-		fmt.Println("Could not connect to Docker: %w", err)
-		return nil, nil, err
-	}
-
-	resource, err := pool.RunWithOptions(&dockertest.RunOptions{
-		Repository:   "localstack/localstack",
-		Tag:          "latest",
-		Name:         "localstack-test",
-		ExposedPorts: []string{localStackPort},
-		PortBindings: map[docker.Port][]docker.PortBinding{
-			docker.Port(localStackPort): {
-				{HostIP: "0.0.0.0", HostPort: localStackPort},
-			},
-		},
-		Env: []string{
-			fmt.Sprintf("GATEWAY_LISTEN=0.0.0.0:%s", localStackPort),
-			fmt.Sprintf("LOCALSTACK_HOST=localhost.localstack.cloud:%s", localStackPort),
-		},
-	}, func(config *docker.HostConfig) {
-		// set AutoRemove to true so that stopped container goes away by itself
-		config.AutoRemove = true
-		config.RestartPolicy = docker.RestartPolicy{Name: "no"}
-	})
-	if err != nil {
-		fmt.Println("Could not start resource: %w", err)
-		return nil, nil, err
-	}
-
-	pool.MaxWait = 10 * time.Second
-	if err := pool.Retry(func() error {
-		fmt.Println("Waiting for localstack to start")
-		resp, err := http.Get(fmt.Sprintf("http://0.0.0.0:%s", localStackPort))
-		if err != nil {
-			fmt.Println("Server is not running:", err)
-			return err
-		}
-		defer resp.Body.Close()
-
-		if resp.StatusCode != http.StatusOK {
-			fmt.Printf("Server returned non-OK status: %s\n", resp.Status)
-			return errors.New("non-ok status")
-
-		}
-
-		fmt.Println("Server is running and responding!")
-		return nil
-
-	}); err != nil {
-		fmt.Println("Could not connect to localstack:", err)
+		fmt.Println("Could not start localstack:", err)
 		return nil, nil, err
 	}
 
@@ -130,16 +74,8 @@ func DeployResources(pool *dockertest.Pool, localStackPort, metadataTableName, s
 
 func PurgeDockertestResources(pool *dockertest.Pool, resource *dockertest.Resource) {
 	fmt.Println("Stopping Dockertest resources")
-	if resource != nil {
-		fmt.Println("Expiring docker resource")
-		if err := resource.Expire(1); err != nil {
-			log.Fatalf("Could not expire resource: %s", err)
-		}
-	}
-
 	if resource != nil && pool != nil {
-		fmt.Println("Purging docker resource")
-		if err := pool.Purge(resource); err != nil {
+		if err := localstack.PurgeContainer(pool, resource); err != nil {
 			log.Fatalf("Could not purge resource: %s", err)
 		}
 	}
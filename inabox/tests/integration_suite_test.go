@@ -195,7 +195,7 @@ func setupRetrievalClient(testConfig *deploy.Config) error {
 		return err
 	}
 
-	retrievalClient, err = clients.NewRetrievalClient(logger, ics, agn, nodeClient, v, 10)
+	retrievalClient, err = clients.NewRetrievalClient(logger, ics, agn, nodeClient, v, 10, nil)
 	if err != nil {
 		return err
 	}
@@ -6,6 +6,7 @@ import (
 	"math"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 )
 
@@ -19,13 +20,20 @@ const (
 const (
 	maxUint       uint64 = math.MaxUint64
 	maxSyncBlocks        = 10
+	// unhealthyAfterFailures is the number of indexing iterations in a row that fail before the indexer is unhealthy
+	unhealthyAfterFailures = 10
 )
 
 type Indexer interface {
+	healthcheck.HealthReporter
+
 	Index(ctx context.Context) error
 	HandleAccumulator(acc Accumulator, f Filterer, headers Headers) error
 	GetLatestHeader(finalized bool) (*Header, error)
 	GetObject(header *Header, handlerIndex int) (AccumulatorObject, error)
+	// Subscribe returns a subscription to the events of the given types handled by the indexer, or to all of them if
+	// no type is given. At most bufferSize events are buffered for the subscriber.
+	Subscribe(bufferSize int, eventTypes ...string) *Subscription
 }
 
 type AccumulatorHandler struct {
@@ -43,6 +51,9 @@ type indexer struct {
 	UpgradeForkWatcher UpgradeForkWatcher
 
 	PullInterval time.Duration
+
+	health *healthcheck.Tracker
+	events *eventBus
 }
 
 var _ Indexer = (*indexer)(nil)
@@ -67,9 +78,16 @@ func New(
 		UpgradeForkWatcher: upgradeForkWatcher,
 		PullInterval:       config.PullInterval,
 		Logger:             logger,
+		health:             healthcheck.NewTracker("Indexer", unhealthyAfterFailures),
+		events:             newEventBus(),
 	}
 }
 
+// Health returns the health of the indexer, which is degraded once it fails to index new headers
+func (i *indexer) Health() healthcheck.ComponentHealth {
+	return i.health.Health()
+}
+
 func (i *indexer) Index(ctx context.Context) error {
 
 	// Check if any of the accumulators are uninitialized
@@ -144,6 +162,7 @@ func (i *indexer) Index(ctx context.Context) error {
 					}
 				} else if err != nil {
 					i.Logger.Error("Error getting latest header", "err", err)
+					i.health.Record(err)
 					time.Sleep(i.PullInterval)
 					continue loop
 				}
@@ -151,16 +170,19 @@ func (i *indexer) Index(ctx context.Context) error {
 				headers, isHead, err := i.HeaderService.PullNewHeaders(latestFinalizedHeader)
 				if err != nil {
 					i.Logger.Error("Error pulling new headers", "err", err)
+					i.health.Record(err)
 					time.Sleep(i.PullInterval)
 					continue loop
 				}
 
+				var accumulatorErr error
 				if len(headers) > 0 {
 					headers = i.UpgradeForkWatcher.DetectUpgrade(headers)
 
 					newHeaders, err := i.HeaderStore.AddHeaders(headers)
 					if err != nil {
 						i.Logger.Error("Error adding headers", "err", err)
+						i.health.Record(err)
 						// TODO: Properly think through error handling
 						continue loop
 					}
@@ -172,11 +194,14 @@ func (i *indexer) Index(ctx context.Context) error {
 								// TODO: Add Name() field to Accumulator interface so we can log which accumulator is broken
 								i.Logger.Error("Error handling accumulator", "err", err)
 								h.Status = Broken
+								accumulatorErr = err
 							}
 						}
 					}
 				}
 
+				i.health.Record(accumulatorErr)
+
 				if isHead {
 					time.Sleep(i.PullInterval)
 				}
@@ -243,6 +268,10 @@ func (i *indexer) HandleAccumulator(acc Accumulator, f Filterer, headers Headers
 			i.Logger.Error("Error attaching object", "err", err)
 			return err
 		}
+
+		for _, event := range item.Events {
+			i.events.publish(item.Header, event)
+		}
 	}
 
 	return nil
@@ -263,3 +292,7 @@ func (i *indexer) GetObject(header *Header, handlerIndex int) (AccumulatorObject
 	}
 	return obj, nil
 }
+
+func (i *indexer) Subscribe(bufferSize int, eventTypes ...string) *Subscription {
+	return i.events.subscribe(bufferSize, eventTypes)
+}
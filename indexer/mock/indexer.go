@@ -3,6 +3,7 @@ package mock
 import (
 	"context"
 
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/indexer"
 	"github.com/stretchr/testify/mock"
 )
@@ -32,3 +33,12 @@ func (m *MockIndexer) GetObject(header *indexer.Header, handlerIndex int) (index
 	args := m.Called(header, handlerIndex)
 	return args.Get(0).(indexer.AccumulatorObject), args.Error(1)
 }
+
+func (m *MockIndexer) Subscribe(bufferSize int, eventTypes ...string) *indexer.Subscription {
+	args := m.Called(bufferSize, eventTypes)
+	return args.Get(0).(*indexer.Subscription)
+}
+
+func (m *MockIndexer) Health() healthcheck.ComponentHealth {
+	return healthcheck.ComponentHealth{Component: "Indexer", Status: healthcheck.StatusHealthy}
+}
@@ -0,0 +1,100 @@
+package indexer
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+// IndexedEvent is an event handled by the indexer, along with the header of the block it was emitted in
+type IndexedEvent struct {
+	Header *Header
+	Event  Event
+}
+
+// Subscription receives the events of the subscribed types once the indexer has handled them. The events are
+// delivered in the order they are handled, but they are dropped rather than blocking the indexer when the buffer of the
+// subscription is full. The events of the headers skipped in fast mode are never delivered, so a subscriber should
+// read the initial state with GetObject.
+type Subscription struct {
+	bus     *eventBus
+	types   map[string]struct{}
+	events  chan IndexedEvent
+	dropped atomic.Uint64
+}
+
+// Events returns the channel of the events of the subscription, which is closed by Unsubscribe
+func (s *Subscription) Events() <-chan IndexedEvent {
+	return s.events
+}
+
+// Dropped returns the number of events dropped since the buffer of the subscription was full
+func (s *Subscription) Dropped() uint64 {
+	return s.dropped.Load()
+}
+
+// Unsubscribe stops the delivery of the events and closes the channel of the events
+func (s *Subscription) Unsubscribe() {
+	s.bus.unsubscribe(s)
+}
+
+func (s *Subscription) matches(eventType string) bool {
+	if len(s.types) == 0 {
+		return true
+	}
+	_, ok := s.types[eventType]
+	return ok
+}
+
+// eventBus delivers the events handled by the indexer to the subscriptions
+type eventBus struct {
+	mu            sync.RWMutex
+	subscriptions map[*Subscription]struct{}
+}
+
+func newEventBus() *eventBus {
+	return &eventBus{
+		subscriptions: make(map[*Subscription]struct{}),
+	}
+}
+
+func (b *eventBus) subscribe(bufferSize int, eventTypes []string) *Subscription {
+	types := make(map[string]struct{}, len(eventTypes))
+	for _, eventType := range eventTypes {
+		types[eventType] = struct{}{}
+	}
+	sub := &Subscription{
+		bus:    b,
+		types:  types,
+		events: make(chan IndexedEvent, bufferSize),
+	}
+
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	b.subscriptions[sub] = struct{}{}
+	return sub
+}
+
+func (b *eventBus) unsubscribe(sub *Subscription) {
+	b.mu.Lock()
+	defer b.mu.Unlock()
+	if _, ok := b.subscriptions[sub]; !ok {
+		return
+	}
+	delete(b.subscriptions, sub)
+	close(sub.events)
+}
+
+func (b *eventBus) publish(header *Header, event Event) {
+	b.mu.RLock()
+	defer b.mu.RUnlock()
+	for sub := range b.subscriptions {
+		if !sub.matches(event.Type) {
+			continue
+		}
+		select {
+		case sub.events <- IndexedEvent{Header: header, Event: event}:
+		default:
+			sub.dropped.Add(1)
+		}
+	}
+}
@@ -0,0 +1,111 @@
+package indexer_test
+
+import (
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/indexer"
+	"github.com/Layr-Labs/eigenda/indexer/inmem"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// countAccumulator counts the events it is updated with
+type countAccumulator struct{}
+
+func (a *countAccumulator) InitializeObject(header indexer.Header) (indexer.AccumulatorObject, error) {
+	return 0, nil
+}
+
+func (a *countAccumulator) UpdateObject(object indexer.AccumulatorObject, header *indexer.Header, event indexer.Event) (indexer.AccumulatorObject, error) {
+	return object.(int) + 1, nil
+}
+
+func (a *countAccumulator) SerializeObject(object indexer.AccumulatorObject, fork indexer.UpgradeFork) ([]byte, error) {
+	return []byte{byte(object.(int))}, nil
+}
+
+func (a *countAccumulator) DeserializeObject(data []byte, fork indexer.UpgradeFork) (indexer.AccumulatorObject, error) {
+	return int(data[0]), nil
+}
+
+// eventFilterer emits an event of each type for each header
+type eventFilterer struct {
+	types []string
+}
+
+func (f *eventFilterer) FilterHeaders(headers indexer.Headers) ([]indexer.HeaderAndEvents, error) {
+	res := make([]indexer.HeaderAndEvents, len(headers))
+	for i, header := range headers {
+		res[i].Header = header
+		for _, eventType := range f.types {
+			res[i].Events = append(res[i].Events, indexer.Event{Type: eventType, Payload: header.Number})
+		}
+	}
+	return res, nil
+}
+
+func (f *eventFilterer) GetSyncPoint(latestHeader *indexer.Header) (uint64, error) {
+	return 0, nil
+}
+
+func (f *eventFilterer) SetSyncPoint(latestHeader *indexer.Header) error {
+	return nil
+}
+
+func (f *eventFilterer) FilterFastMode(headers indexer.Headers) (*indexer.Header, indexer.Headers, error) {
+	return nil, headers, nil
+}
+
+func TestSubscribe(t *testing.T) {
+	acc := &countAccumulator{}
+	filterer := &eventFilterer{types: []string{"registered", "socket"}}
+	headerStore := inmem.NewHeaderStore()
+	idx := indexer.New(&indexer.Config{}, []indexer.AccumulatorHandler{{Acc: acc, Filterer: filterer}}, nil, headerStore, nil, logging.NewNoopLogger())
+
+	headers := indexer.Headers{
+		{BlockHash: [32]byte{1}, Number: 1, CurrentFork: "genesis"},
+		{BlockHash: [32]byte{2}, PrevBlockHash: [32]byte{1}, Number: 2, CurrentFork: "genesis"},
+	}
+	headers, err := headerStore.AddHeaders(headers)
+	require.NoError(t, err)
+	initial, err := acc.InitializeObject(*headers[0])
+	require.NoError(t, err)
+	require.NoError(t, headerStore.AttachObject(initial, headers[0], acc))
+
+	all := idx.Subscribe(10)
+	sockets := idx.Subscribe(10, "socket")
+	full := idx.Subscribe(1)
+	unsubscribed := idx.Subscribe(10)
+	unsubscribed.Unsubscribe()
+
+	require.NoError(t, idx.HandleAccumulator(acc, filterer, headers))
+
+	all.Unsubscribe()
+	var events []indexer.IndexedEvent
+	for event := range all.Events() {
+		events = append(events, event)
+	}
+	assert.Equal(t, []indexer.IndexedEvent{
+		{Header: headers[0], Event: indexer.Event{Type: "registered", Payload: uint64(1)}},
+		{Header: headers[0], Event: indexer.Event{Type: "socket", Payload: uint64(1)}},
+		{Header: headers[1], Event: indexer.Event{Type: "registered", Payload: uint64(2)}},
+		{Header: headers[1], Event: indexer.Event{Type: "socket", Payload: uint64(2)}},
+	}, events)
+	assert.Zero(t, all.Dropped())
+
+	// Only the subscribed types are delivered
+	assert.Len(t, sockets.Events(), 2)
+	event := <-sockets.Events()
+	assert.Equal(t, "socket", event.Event.Type)
+	assert.Equal(t, uint64(1), event.Header.Number)
+
+	// The events which don't fit in the buffer are dropped
+	assert.Len(t, full.Events(), 1)
+	assert.Equal(t, uint64(3), full.Dropped())
+
+	_, ok := <-unsubscribed.Events()
+	assert.False(t, ok)
+	// Unsubscribing is idempotent
+	unsubscribed.Unsubscribe()
+}
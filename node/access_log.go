@@ -0,0 +1,118 @@
+package node
+
+import (
+	"crypto/hmac"
+	"crypto/rand"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sync"
+	"time"
+
+	"gopkg.in/natefinch/lumberjack.v2"
+)
+
+// anonymizedRequesterSize is the size in bytes of the hashed identities of the requesters in the anonymized access logs
+const anonymizedRequesterSize = 16
+
+// AccessLogConfig configures the access log of the chunks served by the retrieval API
+type AccessLogConfig struct {
+	// Path is the file of the access log. The access log is disabled if it is empty.
+	Path string
+	// MaxSizeMB is the size in megabytes at which the access log is rotated
+	MaxSizeMB int
+	// MaxBackups is the number of rotated access logs which are kept. All are kept if it is 0.
+	MaxBackups int
+	// MaxAgeDays is the number of days after which the rotated access logs are deleted. They are kept if it is 0.
+	MaxAgeDays int
+	// Anonymize replaces the IP addresses of the requesters with their keyed hashes, which still identify the
+	// requesters within the logs but can't be reversed without the key
+	Anonymize bool
+	// AnonymizationKey is the key of the hashes of the anonymized requesters. A random key is generated at startup
+	// if it is empty, in which case the hashes of a requester differ across restarts.
+	AnonymizationKey string
+}
+
+// AccessLogEntry is a line of the access log, written as JSON
+type AccessLogEntry struct {
+	Time time.Time `json:"time"`
+	// Requester is the IP address of the requester, or its hash if the access log is anonymized
+	Requester       string  `json:"requester"`
+	Method          string  `json:"method"`
+	BatchHeaderHash string  `json:"batch_header_hash"`
+	BlobIndex       uint32  `json:"blob_index"`
+	QuorumID        uint32  `json:"quorum_id"`
+	Status          string  `json:"status"`
+	BytesServed     int     `json:"bytes_served"`
+	LatencyMs       float64 `json:"latency_ms"`
+}
+
+// AccessLogger writes the access log of the chunks served by the retrieval API
+type AccessLogger struct {
+	// mu serializes the writes, so that the lines of concurrent requests don't interleave
+	mu     sync.Mutex
+	writer io.WriteCloser
+	// anonymizationKey is nil if the requesters aren't anonymized
+	anonymizationKey []byte
+}
+
+// NewAccessLogger returns an AccessLogger writing to the file of the config, which is rotated once it reaches
+// MaxSizeMB. It returns nil if the access log is disabled.
+func NewAccessLogger(config AccessLogConfig) (*AccessLogger, error) {
+	if config.Path == "" {
+		return nil, nil
+	}
+	writer := &lumberjack.Logger{
+		Filename:   config.Path,
+		MaxSize:    config.MaxSizeMB,
+		MaxBackups: config.MaxBackups,
+		MaxAge:     config.MaxAgeDays,
+	}
+	return NewAccessLoggerWithWriter(writer, config.Anonymize, config.AnonymizationKey)
+}
+
+// NewAccessLoggerWithWriter returns an AccessLogger writing to the writer instead of a rotated file
+func NewAccessLoggerWithWriter(writer io.WriteCloser, anonymize bool, anonymizationKey string) (*AccessLogger, error) {
+	l := &AccessLogger{writer: writer}
+	if !anonymize {
+		return l, nil
+	}
+	l.anonymizationKey = []byte(anonymizationKey)
+	if len(l.anonymizationKey) == 0 {
+		l.anonymizationKey = make([]byte, sha256.Size)
+		if _, err := rand.Read(l.anonymizationKey); err != nil {
+			return nil, fmt.Errorf("failed to generate the access log anonymization key: %w", err)
+		}
+	}
+	return l, nil
+}
+
+// Log writes the entry to the access log, replacing its requester with its hash if the access log is anonymized
+func (l *AccessLogger) Log(entry *AccessLogEntry) error {
+	if l.anonymizationKey != nil {
+		entry.Requester = l.anonymize(entry.Requester)
+	}
+	line, err := json.Marshal(entry)
+	if err != nil {
+		return err
+	}
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	_, err = l.writer.Write(append(line, '\n'))
+	return err
+}
+
+func (l *AccessLogger) anonymize(requester string) string {
+	mac := hmac.New(sha256.New, l.anonymizationKey)
+	mac.Write([]byte(requester))
+	return hex.EncodeToString(mac.Sum(nil)[:anonymizedRequesterSize])
+}
+
+// Close closes the file of the access log
+func (l *AccessLogger) Close() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.writer.Close()
+}
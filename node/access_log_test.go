@@ -0,0 +1,64 @@
+package node_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type bufferCloser struct {
+	bytes.Buffer
+}
+
+func (b *bufferCloser) Close() error {
+	return nil
+}
+
+func readAccessLog(t *testing.T, buffer *bufferCloser) []node.AccessLogEntry {
+	entries := make([]node.AccessLogEntry, 0)
+	for _, line := range strings.Split(strings.TrimSpace(buffer.String()), "\n") {
+		var entry node.AccessLogEntry
+		require.NoError(t, json.Unmarshal([]byte(line), &entry))
+		entries = append(entries, entry)
+	}
+	return entries
+}
+
+func TestAccessLogAnonymization(t *testing.T) {
+	disabled, err := node.NewAccessLogger(node.AccessLogConfig{})
+	require.NoError(t, err)
+	assert.Nil(t, disabled)
+
+	buffer := &bufferCloser{}
+	logger, err := node.NewAccessLoggerWithWriter(buffer, false, "")
+	require.NoError(t, err)
+	require.NoError(t, logger.Log(&node.AccessLogEntry{Requester: "10.0.0.1", BytesServed: 100}))
+	entries := readAccessLog(t, buffer)
+	assert.Equal(t, "10.0.0.1", entries[0].Requester)
+	assert.Equal(t, 100, entries[0].BytesServed)
+
+	// The hashes of the requesters are stable for a key, so that the requests of a requester can still be grouped
+	buffer = &bufferCloser{}
+	logger, err = node.NewAccessLoggerWithWriter(buffer, true, "key")
+	require.NoError(t, err)
+	require.NoError(t, logger.Log(&node.AccessLogEntry{Requester: "10.0.0.1"}))
+	require.NoError(t, logger.Log(&node.AccessLogEntry{Requester: "10.0.0.1"}))
+	require.NoError(t, logger.Log(&node.AccessLogEntry{Requester: "10.0.0.2"}))
+	entries = readAccessLog(t, buffer)
+	assert.Len(t, entries[0].Requester, 32)
+	assert.NotContains(t, entries[0].Requester, "10.0.0.1")
+	assert.Equal(t, entries[0].Requester, entries[1].Requester)
+	assert.NotEqual(t, entries[0].Requester, entries[2].Requester)
+
+	// The hashes differ across keys
+	otherBuffer := &bufferCloser{}
+	logger, err = node.NewAccessLoggerWithWriter(otherBuffer, true, "")
+	require.NoError(t, err)
+	require.NoError(t, logger.Log(&node.AccessLogEntry{Requester: "10.0.0.1"}))
+	assert.NotEqual(t, entries[0].Requester, readAccessLog(t, otherBuffer)[0].Requester)
+}
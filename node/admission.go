@@ -0,0 +1,161 @@
+package node
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/api/conversion"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/consensys/gnark-crypto/ecc/bn254"
+)
+
+// The reasons for which the dispersal requests are rejected by the admission control
+const (
+	AdmissionChunkCount  = "chunk_count"
+	AdmissionChunkLength = "chunk_length"
+	AdmissionMalformed   = "malformed"
+)
+
+// AdmissionController rejects the dispersal requests whose bundles don't match the chunks assigned to the operator,
+// before the chunks are deserialized. The chunks assigned to the operator in a quorum are determined by its share
+// of the stake of the quorum at the reference block, so a disperser can't make the node hold more chunks than its
+// stake warrants, e.g. to exhaust its memory.
+type AdmissionController struct {
+	chainState core.ChainState
+	assignment core.AssignmentCoordinator
+}
+
+// AdmissionError is the error of a rejected dispersal request
+type AdmissionError struct {
+	// Reason is one of the Admission* reasons
+	Reason  string
+	Message string
+}
+
+func (e *AdmissionError) Error() string {
+	return e.Message
+}
+
+func NewAdmissionController(chainState core.ChainState, assignment core.AssignmentCoordinator) *AdmissionController {
+	return &AdmissionController{
+		chainState: chainState,
+		assignment: assignment,
+	}
+}
+
+// Admit checks that each bundle of the blobs has exactly the number of chunks assigned to the operator in its
+// quorum, and that its chunks are no larger than the chunk length of the quorum header. A bundle must be empty if
+// the operator isn't a member of its quorum or has no chunk assigned. It returns an *AdmissionError if the blobs
+// aren't admitted.
+func (a *AdmissionController) Admit(ctx context.Context, operatorID core.OperatorID, referenceBlockNumber uint, blobs []*pb.Blob) error {
+	state, err := a.chainState.GetOperatorStateByOperator(ctx, referenceBlockNumber, operatorID)
+	if err != nil {
+		return fmt.Errorf("failed to get the operator state at block %d: %w", referenceBlockNumber, err)
+	}
+
+	for i, blob := range blobs {
+		header, err := conversion.BlobHeaderFromProto(blob.GetHeader())
+		if err != nil {
+			return &AdmissionError{Reason: AdmissionMalformed, Message: fmt.Sprintf("blob %d: %v", i, err)}
+		}
+		if len(blob.GetBundles()) != len(header.QuorumInfos) {
+			return &AdmissionError{Reason: AdmissionMalformed, Message: fmt.Sprintf("blob %d: the number of bundles (%d) does not match the number of quorums (%d)", i, len(blob.GetBundles()), len(header.QuorumInfos))}
+		}
+		format := GetBundleEncodingFormat(blob)
+		for j, quorumInfo := range header.QuorumInfos {
+			assigned := uint(0)
+			if _, ok := state.Operators[quorumInfo.QuorumID]; ok {
+				assignment, _, err := a.assignment.GetOperatorAssignment(state, header, quorumInfo.QuorumID, operatorID)
+				if err != nil {
+					return &AdmissionError{Reason: AdmissionMalformed, Message: fmt.Sprintf("blob %d: failed to get the assignment of quorum %d: %v", i, quorumInfo.QuorumID, err)}
+				}
+				assigned = assignment.NumChunks
+			}
+
+			numChunks, chunkLength, err := bundleChunks(blob.GetBundles()[j], format)
+			if err != nil {
+				return &AdmissionError{Reason: AdmissionMalformed, Message: fmt.Sprintf("blob %d: invalid bundle of quorum %d: %v", i, quorumInfo.QuorumID, err)}
+			}
+			if numChunks != assigned {
+				return &AdmissionError{Reason: AdmissionChunkCount, Message: fmt.Sprintf("blob %d: the bundle of quorum %d has %d chunks while %d are assigned to the operator", i, quorumInfo.QuorumID, numChunks, assigned)}
+			}
+			switch format {
+			case core.GnarkBundleEncodingFormat:
+				if numChunks > 0 && chunkLength != quorumInfo.ChunkLength {
+					return &AdmissionError{Reason: AdmissionChunkLength, Message: fmt.Sprintf("blob %d: the bundle of quorum %d has chunks of length %d while the quorum header has %d", i, quorumInfo.QuorumID, chunkLength, quorumInfo.ChunkLength)}
+				}
+			case core.GobBundleEncodingFormat:
+				maxSize := maxGobChunkSize(quorumInfo.ChunkLength)
+				for _, chunk := range blob.GetBundles()[j].GetChunks() {
+					if len(chunk) > maxSize {
+						return &AdmissionError{Reason: AdmissionChunkLength, Message: fmt.Sprintf("blob %d: the bundle of quorum %d has a chunk of %d bytes, more than the %d bytes of a chunk of length %d", i, quorumInfo.QuorumID, len(chunk), maxSize, quorumInfo.ChunkLength)}
+					}
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// AdmitDispersal checks the blobs of a dispersal request with the admission control if it is enabled, and records
+// the rejected requests
+func (n *Node) AdmitDispersal(ctx context.Context, method string, referenceBlockNumber uint, blobs []*pb.Blob) error {
+	if n.AdmissionController == nil {
+		return nil
+	}
+	err := n.AdmissionController.Admit(ctx, n.Config.ID, referenceBlockNumber, blobs)
+	var admissionErr *AdmissionError
+	if errors.As(err, &admissionErr) {
+		n.Metrics.RecordRejectedDispersal(method, admissionErr.Reason)
+		n.Logger.Warn("Rejected dispersal request by the admission control", "method", method, "referenceBlockNumber", referenceBlockNumber, "reason", admissionErr.Reason, "err", err)
+	}
+	return err
+}
+
+// maxGobChunkSize bounds the size of a gob encoded chunk of the chunk length. Gob encodes the coefficients and the
+// coordinates of the proof as four varints of at most 9 bytes each, so a chunk is less than twice the size of its
+// gnark encoding, plus the type definitions which gob sends first.
+func maxGobChunkSize(chunkLength uint) int {
+	return 2*(bn254.SizeOfG1AffineCompressed+encoding.BYTES_PER_SYMBOL*int(chunkLength)) + 512
+}
+
+// bundleChunks returns the number of chunks of the bundle and, for the gnark encoding, their length, without
+// deserializing them
+func bundleChunks(bundle *pb.Bundle, format core.BundleEncodingFormat) (uint, uint, error) {
+	switch format {
+	case core.GobBundleEncodingFormat:
+		return uint(len(bundle.GetChunks())), 0, nil
+	case core.GnarkBundleEncodingFormat:
+		data := bundle.GetBundle()
+		if len(data) == 0 {
+			return 0, 0, nil
+		}
+		if len(data) < 8 {
+			return 0, 0, fmt.Errorf("the bundle has %d bytes, less than its header", len(data))
+		}
+		meta := binary.LittleEndian.Uint64(data)
+		if meta>>(core.NumBundleHeaderBits-core.NumBundleEncodingFormatBits) != uint64(core.GnarkBundleEncodingFormat) {
+			return 0, 0, errors.New("invalid bundle encoding format in the header")
+		}
+		chunkLength := meta << core.NumBundleEncodingFormatBits >> core.NumBundleEncodingFormatBits
+		if chunkLength == 0 {
+			return 0, 0, errors.New("the chunk length is zero")
+		}
+		// The bytes are checked against the chunk length before it's multiplied so that it can't overflow
+		size := uint64(len(data) - 8)
+		if chunkLength > size/encoding.BYTES_PER_SYMBOL {
+			return 0, 0, fmt.Errorf("the bundle has %d bytes, less than a chunk of length %d", size, chunkLength)
+		}
+		chunkSize := bn254.SizeOfG1AffineCompressed + encoding.BYTES_PER_SYMBOL*chunkLength
+		if size%chunkSize != 0 {
+			return 0, 0, fmt.Errorf("the bundle has %d bytes, not a multiple of the size of its chunks (%d)", size, chunkSize)
+		}
+		return uint(size / chunkSize), uint(chunkLength), nil
+	default:
+		return 0, 0, fmt.Errorf("invalid bundle encoding format: %d", format)
+	}
+}
@@ -0,0 +1,68 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/flags"
+	"github.com/urfave/cli"
+)
+
+const configCommandName = "config"
+
+// newConfigApp returns the app of the config command, which validates a config file of the node without running it
+func newConfigApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = fmt.Sprintf("%s %s", node.AppName, configCommandName)
+	app.Usage = "Manage the config file of the EigenDA node"
+	app.Version = fmt.Sprintf("%s-%s-%s", node.SemVer, node.GitCommit, node.GitDate)
+	app.Commands = []cli.Command{
+		{
+			Name:      "validate",
+			Usage:     "Validate a YAML or TOML config file, together with the environment variables, as the node would read it",
+			ArgsUsage: "<config file>",
+			Action:    validateConfig,
+		},
+	}
+	return app
+}
+
+func validateConfig(ctx *cli.Context) error {
+	path := ctx.Args().First()
+	if path == "" {
+		path = os.Getenv(flags.ConfigFileFlag.EnvVar)
+	}
+	if path == "" {
+		return fmt.Errorf("the path of the config file is required, as argument or with %s", flags.ConfigFileFlag.EnvVar)
+	}
+
+	overridden, err := flags.ApplyConfigFile(path)
+	if err != nil {
+		return err
+	}
+	for _, option := range overridden {
+		fmt.Printf("%s is overridden by the environment variable %s\n", option.Flag, option.EnvVar)
+	}
+	if missing := flags.MissingRequiredFlags(); len(missing) > 0 {
+		return fmt.Errorf("missing required options: %s", strings.Join(missing, ", "))
+	}
+
+	// The options are checked by the node's own parsing of its config, which also decrypts its keys
+	nodeApp := cli.NewApp()
+	nodeApp.Name = node.AppName
+	nodeApp.Flags = flags.Flags
+	nodeApp.HideHelp = true
+	nodeApp.HideVersion = true
+	nodeApp.Action = func(ctx *cli.Context) error {
+		_, err := node.NewConfig(ctx)
+		return err
+	}
+	if err := nodeApp.Run([]string{node.AppName}); err != nil {
+		return fmt.Errorf("invalid configuration in %s: %w", path, err)
+	}
+
+	fmt.Printf("%s is valid\n", path)
+	return nil
+}
@@ -5,6 +5,8 @@ import (
 	"fmt"
 	"log"
 	"os"
+	"os/signal"
+	"syscall"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common/pubip"
@@ -27,6 +29,31 @@ var (
 )
 
 func main() {
+	// The status command only reads the chain and doesn't take the node's required flags, so it runs as its own app
+	if len(os.Args) > 1 && os.Args[1] == statusCommandName {
+		if err := newStatusApp().Run(os.Args[1:]); err != nil {
+			log.Fatalf("status failed: %v", err)
+		}
+		return
+	}
+	if len(os.Args) > 1 && os.Args[1] == configCommandName {
+		if err := newConfigApp().Run(os.Args[1:]); err != nil {
+			log.Fatalf("config failed: %v", err)
+		}
+		return
+	}
+
+	// The options of the config file are passed through their environment variables, which the flags override
+	if path := flags.ConfigFilePath(os.Args[1:]); path != "" {
+		overridden, err := flags.ApplyConfigFile(path)
+		if err != nil {
+			log.Fatalf("application failed: %v", err)
+		}
+		for _, option := range overridden {
+			log.Printf("%s of the config file is overridden by the environment variable %s", option.Flag, option.EnvVar)
+		}
+	}
+
 	app := cli.NewApp()
 	app.Flags = flags.Flags
 	app.Version = fmt.Sprintf("%s-%s-%s", node.SemVer, node.GitCommit, node.GitDate)
@@ -88,5 +115,20 @@ func NodeMain(ctx *cli.Context) error {
 	server := grpc.NewServer(config, node, logger, ratelimiter)
 	server.Start()
 
+	go reloadOnSignal(node, ctx.GlobalString(flags.ConfigFileFlag.Name))
+
 	return nil
 }
+
+// reloadOnSignal reloads the TLS certificate and the socket of the node on SIGHUP, so that they can be changed
+// without restarting the node
+func reloadOnSignal(n *node.Node, configFilePath string) {
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGHUP)
+	for range signals {
+		n.Logger.Info("Received SIGHUP, reloading the TLS certificate and the socket")
+		if err := n.Reload(context.Background(), configFilePath); err != nil {
+			n.Logger.Error("Failed to reload", "err", err)
+		}
+	}
+}
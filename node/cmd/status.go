@@ -0,0 +1,173 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigenda/node/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+)
+
+const statusCommandName = "status"
+
+// newStatusApp returns the app of the status command, which prints the onchain state of an operator. It doesn't take
+// the node's flags, so it is run separately from the node.
+func newStatusApp() *cli.App {
+	app := cli.NewApp()
+	app.Name = fmt.Sprintf("%s %s", node.AppName, statusCommandName)
+	app.Usage = "Print the onchain state of an EigenDA operator"
+	app.Version = fmt.Sprintf("%s-%s-%s", node.SemVer, node.GitCommit, node.GitDate)
+	app.Flags = flags.StatusFlags
+	app.Action = statusAction(printRegistration, printQuorumStakes, printSocket, printEjectionCooldown)
+	app.Commands = []cli.Command{
+		{
+			Name:   "registration",
+			Usage:  "Print the registration status, BLS public key and registered quorums of the operator",
+			Action: statusAction(printRegistration),
+		},
+		{
+			Name:   "stake",
+			Usage:  "Print the stake of the operator in each quorum it is registered in",
+			Action: statusAction(printQuorumStakes),
+		},
+		{
+			Name:   "socket",
+			Usage:  "Print the socket on record for the operator",
+			Action: statusAction(printSocket),
+		},
+		{
+			Name:   "ejection",
+			Usage:  "Print when the operator was last ejected and when it can register again",
+			Action: statusAction(printEjectionCooldown),
+		},
+	}
+	return app
+}
+
+type operatorStatus struct {
+	reader       *node.OperatorStatusReader
+	address      gethcommon.Address
+	registration *node.OperatorRegistration
+	fromBlock    uint64
+}
+
+type statusPrinter func(ctx context.Context, status *operatorStatus) error
+
+// statusAction returns the action printing the given parts of the operator's state
+func statusAction(printers ...statusPrinter) cli.ActionFunc {
+	return func(ctx *cli.Context) error {
+		addressHex := ctx.GlobalString(flags.StatusOperatorAddressFlag.Name)
+		if !gethcommon.IsHexAddress(addressHex) {
+			return fmt.Errorf("invalid operator address %q", addressHex)
+		}
+
+		loggerConfig := common.DefaultLoggerConfig()
+		loggerConfig.OutputWriter = os.Stderr
+		logger, err := common.NewLogger(loggerConfig)
+		if err != nil {
+			return fmt.Errorf("failed to create logger: %w", err)
+		}
+		client, err := geth.NewClient(geth.EthClientConfig{
+			RPCURLs: []string{ctx.GlobalString(flags.StatusChainRpcFlag.Name)},
+		}, gethcommon.Address{}, 0, logger)
+		if err != nil {
+			return fmt.Errorf("failed to create eth client: %w", err)
+		}
+		tx, err := eth.NewTransactor(logger, client, ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name), ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name))
+		if err != nil {
+			return fmt.Errorf("failed to create EigenDA transactor: %w", err)
+		}
+
+		status := &operatorStatus{
+			reader:    node.NewOperatorStatusReader(tx, client),
+			address:   gethcommon.HexToAddress(addressHex),
+			fromBlock: ctx.GlobalUint64(flags.StatusSocketFromBlockFlag.Name),
+		}
+		// Every part of the state but the ejection cooldown is keyed by the operator ID, which comes with the registration
+		status.registration, err = status.reader.Registration(context.Background(), status.address)
+		if err != nil {
+			return err
+		}
+		fmt.Printf("Operator address: %s\n", status.address.Hex())
+		for _, printer := range printers {
+			if err := printer(context.Background(), status); err != nil {
+				return err
+			}
+		}
+		return nil
+	}
+}
+
+func printRegistration(_ context.Context, status *operatorStatus) error {
+	registration := status.registration
+	fmt.Printf("Registration status: %s\n", registration.Status)
+	if !registration.PubkeyRegistered() {
+		fmt.Println("BLS public key: not registered")
+		return nil
+	}
+	fmt.Printf("Operator ID: %s\n", registration.OperatorID.Hex())
+	fmt.Printf("BLS public key (G1): %s\n", registration.PubkeyG1.String())
+	fmt.Printf("Registered quorums: %v\n", registration.Quorums)
+	return nil
+}
+
+func printQuorumStakes(ctx context.Context, status *operatorStatus) error {
+	if status.registration.Status != node.OperatorRegistered {
+		fmt.Println("Stake: the operator is not registered in any quorum")
+		return nil
+	}
+	stakes, err := status.reader.QuorumStakes(ctx, status.registration.OperatorID)
+	if err != nil {
+		return err
+	}
+	for _, stake := range stakes {
+		fmt.Printf("Quorum %d stake: %s of %s (%.2f%%, %d operators), minimum stake %s\n",
+			stake.QuorumID, stake.Stake, stake.TotalStake, stake.StakeShare(), stake.NumOperators, stake.MinimumStake)
+		if stake.Stake.Cmp(stake.MinimumStake) < 0 {
+			fmt.Printf("Quorum %d stake is below the minimum stake\n", stake.QuorumID)
+		}
+	}
+	return nil
+}
+
+func printSocket(ctx context.Context, status *operatorStatus) error {
+	if !status.registration.PubkeyRegistered() {
+		fmt.Println("Socket: none, the operator has never registered")
+		return nil
+	}
+	socket, err := status.reader.Socket(ctx, status.registration.OperatorID, status.fromBlock)
+	if err != nil {
+		return err
+	}
+	if socket == nil {
+		fmt.Printf("Socket: no socket update since block %d\n", status.fromBlock)
+		return nil
+	}
+	fmt.Printf("Socket: %s (set at block %d)\n", socket.Socket, socket.BlockNumber)
+	return nil
+}
+
+func printEjectionCooldown(ctx context.Context, status *operatorStatus) error {
+	ejection, err := status.reader.EjectionCooldown(ctx, status.address)
+	if err != nil {
+		return err
+	}
+	if !ejection.Ejected() {
+		fmt.Printf("Ejection: never ejected (cooldown %s)\n", ejection.Cooldown)
+		return nil
+	}
+	end := ejection.CooldownEnd()
+	if time.Now().Before(end) {
+		fmt.Printf("Ejection: last ejected at %s, cannot register again until %s\n", ejection.LastEjection.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	} else {
+		fmt.Printf("Ejection: last ejected at %s, cooldown over since %s\n", ejection.LastEjection.UTC().Format(time.RFC3339), end.UTC().Format(time.RFC3339))
+	}
+	return nil
+}
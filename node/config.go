@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"os"
+	"runtime"
 	"strconv"
 	"strings"
 	"time"
@@ -15,6 +16,7 @@ import (
 	"github.com/Layr-Labs/eigenda/node/flags"
 	"github.com/Layr-Labs/eigensdk-go/crypto/bls"
 	"github.com/ethereum/go-ethereum/accounts/keystore"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/crypto"
 	"github.com/urfave/cli"
 )
@@ -50,14 +52,21 @@ type Config struct {
 	EnableMetrics                  bool
 	MetricsPort                    string
 	OnchainMetricsInterval         int64
+	MetricsPushConfig              MetricsPushConfig
 	Timeout                        time.Duration
 	RegisterNodeAtStart            bool
 	ExpirationPollIntervalSec      uint64
+	ExpirationSafetyMargin         time.Duration
+	ExpirationDryRun               bool
+	ExpirationCompaction           bool
 	EnableTestMode                 bool
 	OverrideBlockStaleMeasure      int64
 	OverrideStoreDurationBlocks    int64
 	QuorumIDList                   []core.QuorumID
 	DbPath                         string
+	StorageEncryption              StorageEncryptionConfig
+	AccessLog                      AccessLogConfig
+	TLS                            TLSConfig
 	LogPath                        string
 	PrivateBls                     string
 	ID                             core.OperatorID
@@ -74,6 +83,18 @@ type Config struct {
 	UseSecureGrpc                  bool
 	ReachabilityPollIntervalSec    uint64
 	DisableNodeInfoResources       bool
+	EnableNodeInfoConfig           bool
+	EnableDispersalAuth            bool
+	AuthorizedDisperserAddresses   []gethcommon.Address
+	DispersalAuthMaxClockSkew      time.Duration
+	EnableAdmissionControl         bool
+	EnablePartialBatchSigning      bool
+	RejectedBatchRecordDir         string
+	RejectedBatchRecordMaxFiles    int
+	DisableSRSVerification         bool
+	G1Digest                       string
+	G2Digest                       string
+	G2PowerOf2Digest               string
 
 	EthClientConfig geth.EthClientConfig
 	LoggerConfig    common.LoggerConfig
@@ -105,6 +126,18 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	if expirationPollIntervalSec < minExpirationPollIntervalSec {
 		return nil, fmt.Errorf("the expiration-poll-interval flag must be >= %d seconds", minExpirationPollIntervalSec)
 	}
+	numBatchValidators := ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name)
+	if numBatchValidators < 0 {
+		return nil, fmt.Errorf("the %s flag must not be negative", flags.NumBatchValidatorsFlag.Name)
+	}
+	if numBatchValidators == 0 {
+		numBatchValidators = runtime.NumCPU()
+	}
+
+	expirationSafetyMargin := ctx.GlobalDuration(flags.ExpirationSafetyMarginFlag.Name)
+	if expirationSafetyMargin < 0 {
+		return nil, fmt.Errorf("the %s flag must not be negative", flags.ExpirationSafetyMarginFlag.Name)
+	}
 
 	reachabilityPollIntervalSec := ctx.GlobalUint64(flags.ReachabilityPollIntervalSecFlag.Name)
 	if reachabilityPollIntervalSec != 0 && reachabilityPollIntervalSec < minReachabilityPollIntervalSec {
@@ -169,6 +202,75 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		return nil, err
 	}
 
+	metricsPushLabels, err := ParseMetricsPushLabels(ctx.GlobalStringSlice(flags.MetricsPushLabelsFlag.Name))
+	if err != nil {
+		return nil, err
+	}
+	metricsPushConfig := MetricsPushConfig{
+		GatewayURL: ctx.GlobalString(flags.MetricsPushGatewayURLFlag.Name),
+		Interval:   ctx.GlobalDuration(flags.MetricsPushIntervalFlag.Name),
+		Labels:     metricsPushLabels,
+	}
+	if metricsPushConfig.GatewayURL != "" && metricsPushConfig.Interval <= 0 {
+		return nil, fmt.Errorf("the %s flag must be positive", flags.MetricsPushIntervalFlag.Name)
+	}
+
+	storageEncryptionConfig := StorageEncryptionConfig{
+		KeySecretName:   ctx.GlobalString(flags.StorageEncryptionKeySecretNameFlag.Name),
+		KeySecretRegion: ctx.GlobalString(flags.StorageEncryptionKeySecretRegionFlag.Name),
+	}
+	if storageEncryptionConfig.KeySecretName != "" && storageEncryptionConfig.KeySecretRegion == "" {
+		return nil, fmt.Errorf("the %s flag is required to encrypt the storage", flags.StorageEncryptionKeySecretRegionFlag.Name)
+	}
+
+	accessLogConfig := AccessLogConfig{
+		Path:             ctx.GlobalString(flags.AccessLogPathFlag.Name),
+		MaxSizeMB:        ctx.GlobalInt(flags.AccessLogMaxSizeMBFlag.Name),
+		MaxBackups:       ctx.GlobalInt(flags.AccessLogMaxBackupsFlag.Name),
+		MaxAgeDays:       ctx.GlobalInt(flags.AccessLogMaxAgeDaysFlag.Name),
+		Anonymize:        ctx.GlobalBool(flags.AccessLogAnonymizeFlag.Name),
+		AnonymizationKey: ctx.GlobalString(flags.AccessLogAnonymizationKeyFlag.Name),
+	}
+	if accessLogConfig.Path != "" && accessLogConfig.MaxSizeMB <= 0 {
+		return nil, fmt.Errorf("the %s flag must be positive", flags.AccessLogMaxSizeMBFlag.Name)
+	}
+
+	tlsConfig := TLSConfig{
+		CertFile:       ctx.GlobalString(flags.TLSCertFileFlag.Name),
+		KeyFile:        ctx.GlobalString(flags.TLSKeyFileFlag.Name),
+		ReloadInterval: ctx.GlobalDuration(flags.TLSReloadIntervalFlag.Name),
+	}
+	if (tlsConfig.CertFile == "") != (tlsConfig.KeyFile == "") {
+		return nil, fmt.Errorf("the %s and %s flags must be set together", flags.TLSCertFileFlag.Name, flags.TLSKeyFileFlag.Name)
+	}
+	if tlsConfig.ReloadInterval < 0 {
+		return nil, fmt.Errorf("the %s flag must not be negative", flags.TLSReloadIntervalFlag.Name)
+	}
+
+	// The socket registered onchain must be parseable by the dispersers and the retrievers
+	socket := core.MakeOperatorSocket(ctx.GlobalString(flags.HostnameFlag.Name), ctx.GlobalString(flags.DispersalPortFlag.Name), ctx.GlobalString(flags.RetrievalPortFlag.Name))
+	if err := core.ValidateOperatorSocket(string(socket)); err != nil {
+		return nil, fmt.Errorf("invalid operator socket %s: %w", socket, err)
+	}
+
+	// The rejected batches are only recorded for the authenticated requests, so that anyone can't fill the disk
+	if ctx.GlobalString(flags.RejectedBatchRecordDirFlag.Name) != "" {
+		if !ctx.GlobalBool(flags.EnableDispersalAuthFlag.Name) {
+			return nil, fmt.Errorf("the %s flag requires %s", flags.RejectedBatchRecordDirFlag.Name, flags.EnableDispersalAuthFlag.Name)
+		}
+		if ctx.GlobalInt(flags.RejectedBatchRecordMaxFilesFlag.Name) <= 0 {
+			return nil, fmt.Errorf("the %s flag must be positive", flags.RejectedBatchRecordMaxFilesFlag.Name)
+		}
+	}
+
+	disperserAddresses := make([]gethcommon.Address, 0)
+	for _, addr := range ctx.GlobalStringSlice(flags.AuthorizedDisperserAddressesFlag.Name) {
+		if !gethcommon.IsHexAddress(addr) {
+			return nil, fmt.Errorf("invalid authorized disperser address: %s", addr)
+		}
+		disperserAddresses = append(disperserAddresses, gethcommon.HexToAddress(addr))
+	}
+
 	return &Config{
 		Hostname:                       ctx.GlobalString(flags.HostnameFlag.Name),
 		DispersalPort:                  ctx.GlobalString(flags.DispersalPortFlag.Name),
@@ -180,15 +282,22 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		EnableMetrics:                  ctx.GlobalBool(flags.EnableMetricsFlag.Name),
 		MetricsPort:                    ctx.GlobalString(flags.MetricsPortFlag.Name),
 		OnchainMetricsInterval:         ctx.GlobalInt64(flags.OnchainMetricsIntervalFlag.Name),
+		MetricsPushConfig:              metricsPushConfig,
 		Timeout:                        timeout,
 		RegisterNodeAtStart:            registerNodeAtStart,
 		ExpirationPollIntervalSec:      expirationPollIntervalSec,
+		ExpirationSafetyMargin:         expirationSafetyMargin,
+		ExpirationDryRun:               ctx.GlobalBool(flags.ExpirationDryRunFlag.Name),
+		ExpirationCompaction:           ctx.GlobalBool(flags.ExpirationCompactionFlag.Name),
 		ReachabilityPollIntervalSec:    reachabilityPollIntervalSec,
 		EnableTestMode:                 testMode,
 		OverrideBlockStaleMeasure:      ctx.GlobalInt64(flags.OverrideBlockStaleMeasureFlag.Name),
 		OverrideStoreDurationBlocks:    ctx.GlobalInt64(flags.OverrideStoreDurationBlocksFlag.Name),
 		QuorumIDList:                   ids,
 		DbPath:                         ctx.GlobalString(flags.DbPathFlag.Name),
+		StorageEncryption:              storageEncryptionConfig,
+		AccessLog:                      accessLogConfig,
+		TLS:                            tlsConfig,
 		PrivateBls:                     privateBls,
 		EthClientConfig:                ethClientConfig,
 		EncoderConfig:                  kzg.ReadCLIConfig(ctx),
@@ -199,11 +308,23 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		PubIPCheckInterval:             pubIPCheckInterval,
 		ChurnerUrl:                     ctx.GlobalString(flags.ChurnerUrlFlag.Name),
 		DataApiUrl:                     ctx.GlobalString(flags.DataApiUrlFlag.Name),
-		NumBatchValidators:             ctx.GlobalInt(flags.NumBatchValidatorsFlag.Name),
+		NumBatchValidators:             numBatchValidators,
 		NumBatchDeserializationWorkers: ctx.GlobalInt(flags.NumBatchDeserializationWorkersFlag.Name),
 		EnableGnarkBundleEncoding:      ctx.Bool(flags.EnableGnarkBundleEncodingFlag.Name),
 		ClientIPHeader:                 ctx.GlobalString(flags.ClientIPHeaderFlag.Name),
 		UseSecureGrpc:                  ctx.GlobalBoolT(flags.ChurnerUseSecureGRPC.Name),
 		DisableNodeInfoResources:       ctx.GlobalBool(flags.DisableNodeInfoResourcesFlag.Name),
+		EnableNodeInfoConfig:           ctx.GlobalBool(flags.EnableNodeInfoConfigFlag.Name),
+		EnableDispersalAuth:            ctx.GlobalBool(flags.EnableDispersalAuthFlag.Name),
+		AuthorizedDisperserAddresses:   disperserAddresses,
+		DispersalAuthMaxClockSkew:      ctx.GlobalDuration(flags.DispersalAuthMaxClockSkewFlag.Name),
+		EnableAdmissionControl:         ctx.GlobalBool(flags.EnableAdmissionControlFlag.Name),
+		EnablePartialBatchSigning:      ctx.GlobalBool(flags.EnablePartialBatchSigningFlag.Name),
+		RejectedBatchRecordDir:         ctx.GlobalString(flags.RejectedBatchRecordDirFlag.Name),
+		RejectedBatchRecordMaxFiles:    ctx.GlobalInt(flags.RejectedBatchRecordMaxFilesFlag.Name),
+		DisableSRSVerification:         ctx.GlobalBool(flags.DisableSRSVerificationFlag.Name),
+		G1Digest:                       ctx.GlobalString(flags.G1DigestFlag.Name),
+		G2Digest:                       ctx.GlobalString(flags.G2DigestFlag.Name),
+		G2PowerOf2Digest:               ctx.GlobalString(flags.G2PowerOf2DigestFlag.Name),
 	}, nil
 }
@@ -0,0 +1,446 @@
+package flags
+
+import (
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/pelletier/go-toml/v2"
+	"github.com/urfave/cli"
+	"gopkg.in/yaml.v3"
+)
+
+// maxSuggestionDistance is the largest edit distance between an unknown key of the config file and the name of the flag
+// suggested for it
+const maxSuggestionDistance = 3
+
+// ConfigFileOption is an option of the node set by the config file
+type ConfigFileOption struct {
+	// Flag is the name of the flag of the option
+	Flag string
+	// EnvVar is the environment variable through which the option is passed to the node
+	EnvVar string
+	// Value is the value of the option in the format of its environment variable
+	Value string
+}
+
+// configFileEntry is a key of the config file with its decoded value
+type configFileEntry struct {
+	key   string
+	value any
+	// position locates the key in the file, it is empty if the format doesn't report it
+	position string
+}
+
+func (e configFileEntry) String() string {
+	if e.position == "" {
+		return e.key
+	}
+	return fmt.Sprintf("%s (%s)", e.key, e.position)
+}
+
+// ConfigFilePath returns the path of the config file passed to the node with the config file flag in args, or with its
+// environment variable. It returns an empty path if there's no config file.
+func ConfigFilePath(args []string) string {
+	for i, arg := range args {
+		if arg == "--" {
+			break
+		}
+		if !strings.HasPrefix(arg, "-") {
+			continue
+		}
+		name, value, hasValue := strings.Cut(strings.TrimLeft(arg, "-"), "=")
+		if name != ConfigFileFlag.Name {
+			continue
+		}
+		if hasValue {
+			return value
+		}
+		if i+1 < len(args) {
+			return args[i+1]
+		}
+	}
+	return os.Getenv(ConfigFileFlag.EnvVar)
+}
+
+// ReadConfigFile reads the options of the node from a YAML or TOML file, which sets each option by the name of its
+// flag, e.g. node.hostname. The dots of the names may also nest the options in tables. All the unknown keys and the
+// values which don't fit the type of their flag are reported in the error.
+func ReadConfigFile(path string) ([]ConfigFileOption, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read the config file: %w", err)
+	}
+
+	var entries []configFileEntry
+	switch ext := strings.ToLower(filepath.Ext(path)); ext {
+	case ".yaml", ".yml":
+		entries, err = readYAMLConfigFile(data)
+	case ".toml":
+		entries, err = readTOMLConfigFile(data)
+	default:
+		return nil, fmt.Errorf("unsupported config file extension %q, expected .yaml, .yml or .toml", ext)
+	}
+	if err != nil {
+		return nil, fmt.Errorf("failed to parse the config file %s: %w", path, err)
+	}
+
+	flagsByName := make(map[string]cli.Flag)
+	for _, f := range Flags {
+		for _, name := range strings.Split(f.GetName(), ",") {
+			flagsByName[strings.TrimSpace(name)] = f
+		}
+	}
+
+	options := make([]ConfigFileOption, 0, len(entries))
+	seen := make(map[string]configFileEntry)
+	var errs []error
+	for _, entry := range entries {
+		f, ok := flagsByName[entry.key]
+		if !ok {
+			if suggestion := suggestFlag(entry.key); suggestion != "" {
+				errs = append(errs, fmt.Errorf("%s: unknown option, did you mean %s?", entry, suggestion))
+			} else {
+				errs = append(errs, fmt.Errorf("%s: unknown option", entry))
+			}
+			continue
+		}
+		name := flagName(f)
+		if name == ConfigFileFlag.Name {
+			errs = append(errs, fmt.Errorf("%s: the config file can't be set from a config file", entry))
+			continue
+		}
+		if previous, ok := seen[name]; ok {
+			errs = append(errs, fmt.Errorf("%s: %s is already set by %s", entry, name, previous))
+			continue
+		}
+		seen[name] = entry
+
+		envVar := flagEnvVar(f)
+		if envVar == "" {
+			errs = append(errs, fmt.Errorf("%s: the option can't be set from a config file", entry))
+			continue
+		}
+		value, err := configFileValue(f, entry.value)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", entry, err))
+			continue
+		}
+		options = append(options, ConfigFileOption{
+			Flag:   name,
+			EnvVar: envVar,
+			Value:  value,
+		})
+	}
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid config file %s:\n%w", path, errors.Join(errs...))
+	}
+	return options, nil
+}
+
+// ApplyConfigFile reads the config file and passes its options to the node through their environment variables. The
+// options whose environment variable is already set are overridden by the environment and returned, while the flags
+// override both the file and the environment.
+func ApplyConfigFile(path string) ([]ConfigFileOption, error) {
+	options, err := ReadConfigFile(path)
+	if err != nil {
+		return nil, err
+	}
+	overridden := make([]ConfigFileOption, 0)
+	for _, option := range options {
+		if _, ok := os.LookupEnv(option.EnvVar); ok {
+			overridden = append(overridden, option)
+			continue
+		}
+		if err := os.Setenv(option.EnvVar, option.Value); err != nil {
+			return nil, fmt.Errorf("failed to set %s: %w", option.EnvVar, err)
+		}
+	}
+	return overridden, nil
+}
+
+// MissingRequiredFlags returns the required flags of the node which aren't set in the environment, so that the node
+// can't be run from the config file and the environment alone
+func MissingRequiredFlags() []string {
+	missing := make([]string, 0)
+	for _, f := range Flags {
+		required, ok := f.(cli.RequiredFlag)
+		if !ok || !required.IsRequired() {
+			continue
+		}
+		envVar := flagEnvVar(f)
+		if _, ok := os.LookupEnv(envVar); !ok || envVar == "" {
+			missing = append(missing, flagName(f))
+		}
+	}
+	return missing
+}
+
+func readYAMLConfigFile(data []byte) ([]configFileEntry, error) {
+	var doc yaml.Node
+	if err := yaml.Unmarshal(data, &doc); err != nil {
+		return nil, err
+	}
+	if len(doc.Content) == 0 {
+		return nil, nil
+	}
+	root := doc.Content[0]
+	if root.Kind != yaml.MappingNode {
+		return nil, fmt.Errorf("line %d: expected the options mapped by flag name", root.Line)
+	}
+
+	entries := make([]configFileEntry, 0)
+	var walk func(prefix string, node *yaml.Node) error
+	walk = func(prefix string, node *yaml.Node) error {
+		for i := 0; i+1 < len(node.Content); i += 2 {
+			key, value := node.Content[i], node.Content[i+1]
+			name := key.Value
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			if value.Kind == yaml.MappingNode {
+				if err := walk(name, value); err != nil {
+					return err
+				}
+				continue
+			}
+			var decoded any
+			if err := value.Decode(&decoded); err != nil {
+				return fmt.Errorf("line %d: %s: %w", key.Line, name, err)
+			}
+			entries = append(entries, configFileEntry{
+				key:      name,
+				value:    decoded,
+				position: fmt.Sprintf("line %d", key.Line),
+			})
+		}
+		return nil
+	}
+	if err := walk("", root); err != nil {
+		return nil, err
+	}
+	return entries, nil
+}
+
+func readTOMLConfigFile(data []byte) ([]configFileEntry, error) {
+	var doc map[string]any
+	if err := toml.Unmarshal(data, &doc); err != nil {
+		var decodeErr *toml.DecodeError
+		if errors.As(err, &decodeErr) {
+			row, column := decodeErr.Position()
+			return nil, fmt.Errorf("line %d, column %d: %w", row, column, err)
+		}
+		return nil, err
+	}
+
+	entries := make([]configFileEntry, 0)
+	var walk func(prefix string, table map[string]any)
+	walk = func(prefix string, table map[string]any) {
+		// The tables are decoded as maps, so the keys are sorted to report the errors in a stable order
+		keys := make([]string, 0, len(table))
+		for key := range table {
+			keys = append(keys, key)
+		}
+		sort.Strings(keys)
+		for _, key := range keys {
+			name := key
+			if prefix != "" {
+				name = prefix + "." + name
+			}
+			if nested, ok := table[key].(map[string]any); ok {
+				walk(name, nested)
+				continue
+			}
+			entries = append(entries, configFileEntry{
+				key:   name,
+				value: table[key],
+			})
+		}
+	}
+	walk("", doc)
+	return entries, nil
+}
+
+// configFileValue converts the value of an option of the config file to the format of the environment variable of its
+// flag
+func configFileValue(f cli.Flag, value any) (string, error) {
+	switch f.(type) {
+	case cli.StringSliceFlag:
+		items, ok := value.([]any)
+		if !ok {
+			return scalarConfigFileValue(value)
+		}
+		values := make([]string, 0, len(items))
+		for _, item := range items {
+			v, err := scalarConfigFileValue(item)
+			if err != nil {
+				return "", fmt.Errorf("invalid list item: %w", err)
+			}
+			// The environment variable of a list is comma separated
+			if strings.Contains(v, ",") {
+				return "", fmt.Errorf("list item %q can't contain a comma", v)
+			}
+			values = append(values, v)
+		}
+		return strings.Join(values, ","), nil
+	case cli.BoolFlag, cli.BoolTFlag:
+		b, ok := value.(bool)
+		if !ok {
+			return "", fmt.Errorf("expected true or false, got %s", describeConfigFileValue(value))
+		}
+		return strconv.FormatBool(b), nil
+	case cli.IntFlag, cli.Int64Flag:
+		n, ok := integerConfigFileValue(value)
+		if !ok {
+			return "", fmt.Errorf("expected an integer, got %s", describeConfigFileValue(value))
+		}
+		return n, nil
+	case cli.UintFlag, cli.Uint64Flag:
+		n, ok := integerConfigFileValue(value)
+		if !ok || strings.HasPrefix(n, "-") {
+			return "", fmt.Errorf("expected a non-negative integer, got %s", describeConfigFileValue(value))
+		}
+		return n, nil
+	case cli.Float64Flag:
+		switch v := value.(type) {
+		case float64:
+			return strconv.FormatFloat(v, 'g', -1, 64), nil
+		default:
+			n, ok := integerConfigFileValue(value)
+			if !ok {
+				return "", fmt.Errorf("expected a number, got %s", describeConfigFileValue(value))
+			}
+			return n, nil
+		}
+	case cli.DurationFlag:
+		s, ok := value.(string)
+		if !ok {
+			return "", fmt.Errorf("expected a duration such as \"10s\", got %s", describeConfigFileValue(value))
+		}
+		if _, err := time.ParseDuration(s); err != nil {
+			return "", fmt.Errorf("expected a duration such as \"10s\", got %q", s)
+		}
+		return s, nil
+	default:
+		return scalarConfigFileValue(value)
+	}
+}
+
+// scalarConfigFileValue formats a string, number or boolean of the config file. The numbers are accepted by the string
+// flags because the ports are strings.
+func scalarConfigFileValue(value any) (string, error) {
+	switch v := value.(type) {
+	case string:
+		return v, nil
+	case bool:
+		return strconv.FormatBool(v), nil
+	case float64:
+		return strconv.FormatFloat(v, 'g', -1, 64), nil
+	default:
+		if n, ok := integerConfigFileValue(value); ok {
+			return n, nil
+		}
+		return "", fmt.Errorf("expected a string, got %s", describeConfigFileValue(value))
+	}
+}
+
+func integerConfigFileValue(value any) (string, bool) {
+	switch v := value.(type) {
+	case int:
+		return strconv.Itoa(v), true
+	case int64:
+		return strconv.FormatInt(v, 10), true
+	case uint64:
+		return strconv.FormatUint(v, 10), true
+	default:
+		return "", false
+	}
+}
+
+func describeConfigFileValue(value any) string {
+	switch v := value.(type) {
+	case nil:
+		return "no value"
+	case string:
+		return fmt.Sprintf("%q", v)
+	case []any:
+		return "a list"
+	default:
+		return fmt.Sprintf("%v", v)
+	}
+}
+
+// suggestFlag returns the name of the flag closest to an unknown key of the config file, or an empty string if no
+// flag is close enough
+func suggestFlag(key string) string {
+	suggestion, bestDistance := "", maxSuggestionDistance+1
+	for _, f := range Flags {
+		name := flagName(f)
+		// The key may miss the prefix of the flag, e.g. hostname for node.hostname
+		if strings.HasSuffix(name, "."+key) {
+			return name
+		}
+		if distance := editDistance(key, name); distance < bestDistance {
+			suggestion, bestDistance = name, distance
+		}
+	}
+	return suggestion
+}
+
+// editDistance is the Levenshtein distance between a and b
+func editDistance(a, b string) int {
+	previous := make([]int, len(b)+1)
+	current := make([]int, len(b)+1)
+	for j := range previous {
+		previous[j] = j
+	}
+	for i := 1; i <= len(a); i++ {
+		current[0] = i
+		for j := 1; j <= len(b); j++ {
+			cost := 1
+			if a[i-1] == b[j-1] {
+				cost = 0
+			}
+			current[j] = min(previous[j]+1, current[j-1]+1, previous[j-1]+cost)
+		}
+		previous, current = current, previous
+	}
+	return previous[len(b)]
+}
+
+func flagName(f cli.Flag) string {
+	return strings.TrimSpace(strings.Split(f.GetName(), ",")[0])
+}
+
+// flagEnvVar returns the first environment variable of the flag, or an empty string if the flag has none
+func flagEnvVar(f cli.Flag) string {
+	var envVar string
+	switch f := f.(type) {
+	case cli.StringFlag:
+		envVar = f.EnvVar
+	case cli.StringSliceFlag:
+		envVar = f.EnvVar
+	case cli.BoolFlag:
+		envVar = f.EnvVar
+	case cli.BoolTFlag:
+		envVar = f.EnvVar
+	case cli.IntFlag:
+		envVar = f.EnvVar
+	case cli.Int64Flag:
+		envVar = f.EnvVar
+	case cli.UintFlag:
+		envVar = f.EnvVar
+	case cli.Uint64Flag:
+		envVar = f.EnvVar
+	case cli.Float64Flag:
+		envVar = f.EnvVar
+	case cli.DurationFlag:
+		envVar = f.EnvVar
+	}
+	return strings.TrimSpace(strings.Split(envVar, ",")[0])
+}
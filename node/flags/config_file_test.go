@@ -0,0 +1,113 @@
+package flags_test
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/node/flags"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func writeConfigFile(t *testing.T, name, content string) string {
+	path := filepath.Join(t.TempDir(), name)
+	require.NoError(t, os.WriteFile(path, []byte(content), 0600))
+	return path
+}
+
+func TestReadConfigFile(t *testing.T) {
+	yamlPath := writeConfigFile(t, "node.yaml", `
+node:
+  hostname: localhost
+  dispersal-port: 32005
+  enable-metrics: true
+  public-ip-check-interval: 10s
+  authorized-disperser-addresses:
+    - "0x0000000000000000000000000000000000000001"
+    - "0x0000000000000000000000000000000000000002"
+num-batch-validators: 8
+chain.rpc: http://localhost:8545
+`)
+	tomlPath := writeConfigFile(t, "node.toml", `
+num-batch-validators = 8
+"chain.rpc" = "http://localhost:8545"
+
+[node]
+hostname = "localhost"
+dispersal-port = 32005
+enable-metrics = true
+public-ip-check-interval = "10s"
+authorized-disperser-addresses = ["0x0000000000000000000000000000000000000001", "0x0000000000000000000000000000000000000002"]
+`)
+
+	expected := map[string]string{
+		flags.HostnameFlag.EnvVar:                     "localhost",
+		flags.DispersalPortFlag.EnvVar:                "32005",
+		flags.EnableMetricsFlag.EnvVar:                "true",
+		flags.PubIPCheckIntervalFlag.EnvVar:           "10s",
+		flags.AuthorizedDisperserAddressesFlag.EnvVar: "0x0000000000000000000000000000000000000001,0x0000000000000000000000000000000000000002",
+		flags.NumBatchValidatorsFlag.EnvVar:           "8",
+		"NODE_CHAIN_RPC":                              "http://localhost:8545",
+	}
+	for _, path := range []string{yamlPath, tomlPath} {
+		options, err := flags.ReadConfigFile(path)
+		require.NoError(t, err, path)
+		values := make(map[string]string)
+		for _, option := range options {
+			values[option.EnvVar] = option.Value
+		}
+		assert.Equal(t, expected, values, path)
+	}
+}
+
+func TestReadConfigFileErrors(t *testing.T) {
+	path := writeConfigFile(t, "node.yml", `
+node:
+  hostnam: localhost
+  enable-metrics: "yes"
+  public-ip-check-interval: 10
+hostname: localhost
+node.dispersal-port: 32005
+node:
+  dispersal-port: 32006
+num-batch-validators: -1.5
+`)
+	_, err := flags.ReadConfigFile(path)
+	require.Error(t, err)
+	assert.ErrorContains(t, err, "node.hostnam (line 3): unknown option, did you mean node.hostname?")
+	assert.ErrorContains(t, err, "node.enable-metrics (line 4): expected true or false, got \"yes\"")
+	assert.ErrorContains(t, err, "node.public-ip-check-interval (line 5): expected a duration such as \"10s\"")
+	assert.ErrorContains(t, err, "hostname (line 6): unknown option, did you mean node.hostname?")
+	assert.ErrorContains(t, err, "node.dispersal-port (line 9): node.dispersal-port is already set by node.dispersal-port (line 7)")
+	assert.ErrorContains(t, err, "num-batch-validators (line 10): expected an integer")
+
+	_, err = flags.ReadConfigFile(writeConfigFile(t, "node.toml", "[node]\nhostname = \n"))
+	assert.ErrorContains(t, err, "line 2")
+
+	_, err = flags.ReadConfigFile(writeConfigFile(t, "node.json", "{}"))
+	assert.ErrorContains(t, err, "unsupported config file extension")
+}
+
+func TestApplyConfigFile(t *testing.T) {
+	path := writeConfigFile(t, "node.yaml", "node.hostname: localhost\nnode.dispersal-port: 32005\n")
+	// The variables are restored at the end of the test
+	t.Setenv(flags.HostnameFlag.EnvVar, "")
+	require.NoError(t, os.Unsetenv(flags.HostnameFlag.EnvVar))
+	t.Setenv(flags.DispersalPortFlag.EnvVar, "32006")
+
+	overridden, err := flags.ApplyConfigFile(path)
+	require.NoError(t, err)
+	require.Len(t, overridden, 1)
+	assert.Equal(t, flags.DispersalPortFlag.Name, overridden[0].Flag)
+	assert.Equal(t, "localhost", os.Getenv(flags.HostnameFlag.EnvVar))
+	assert.Equal(t, "32006", os.Getenv(flags.DispersalPortFlag.EnvVar))
+	assert.NotContains(t, flags.MissingRequiredFlags(), flags.HostnameFlag.Name)
+}
+
+func TestConfigFilePath(t *testing.T) {
+	t.Setenv(flags.ConfigFileFlag.EnvVar, "env.yaml")
+	assert.Equal(t, "a.yaml", flags.ConfigFilePath([]string{"--node.hostname", "x", "--node.config-file", "a.yaml"}))
+	assert.Equal(t, "b.toml", flags.ConfigFilePath([]string{"-node.config-file=b.toml"}))
+	assert.Equal(t, "env.yaml", flags.ConfigFilePath([]string{"--", "--node.config-file", "a.yaml"}))
+}
@@ -80,6 +80,25 @@ var (
 		Value:    "180",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ONCHAIN_METRICS_INTERVAL"),
 	}
+	MetricsPushGatewayURLFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-push-gateway-url"),
+		Usage:    "URL of a Prometheus push gateway to which the node pushes its metrics, for nodes whose metrics port can't be scraped. Only used when metrics are enabled",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "METRICS_PUSH_GATEWAY_URL"),
+	}
+	MetricsPushIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-push-interval"),
+		Usage:    "Interval at which the node pushes its metrics to the push gateway",
+		Required: false,
+		Value:    30 * time.Second,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "METRICS_PUSH_INTERVAL"),
+	}
+	MetricsPushLabelsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "metrics-push-labels"),
+		Usage:    "Grouping labels of the metrics pushed to the push gateway as key=value pairs, in addition to the operator ID",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "METRICS_PUSH_LABELS"),
+	}
 	TimeoutFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "timeout"),
 		Usage:    "Amount of time to wait for GPRC",
@@ -98,6 +117,18 @@ var (
 		Required: true,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DB_PATH"),
 	}
+	StorageEncryptionKeySecretNameFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-encryption-key-secret-name"),
+		Usage:    "Name of the AWS secrets manager secret holding the hex encoded AES-256 key with which the chunks are encrypted at rest. The chunks aren't encrypted if it is empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ENCRYPTION_KEY_SECRET_NAME"),
+	}
+	StorageEncryptionKeySecretRegionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "storage-encryption-key-secret-region"),
+		Usage:    "AWS region of the secret holding the storage encryption key",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STORAGE_ENCRYPTION_KEY_SECRET_REGION"),
+	}
 	// The files for encrypted private keys.
 	BlsKeyFileFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "bls-key-file"),
@@ -181,6 +212,25 @@ var (
 		Value:    "180",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EXPIRATION_POLL_INTERVAL"),
 	}
+	ExpirationSafetyMarginFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "expiration-safety-margin"),
+		Usage:    "How long the batches and blobs are kept past their expiration before they are removed",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EXPIRATION_SAFETY_MARGIN"),
+	}
+	ExpirationDryRunFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "expiration-dry-run"),
+		Usage:    "Find the expired batches and blobs and report the size of their chunks without removing them",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EXPIRATION_DRY_RUN"),
+	}
+	ExpirationCompactionFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "expiration-compaction"),
+		Usage:    "Compact the database after the expiration cycles which removed batches or blobs, to reclaim their disk space",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "EXPIRATION_COMPACTION"),
+	}
 	ReachabilityPollIntervalSecFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "reachability-poll-interval"),
 		Usage:    "How often (in second) to check if node is reachabile from Disperser",
@@ -196,11 +246,11 @@ var (
 		Value:    "",
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DATAAPI_URL"),
 	}
-	// NumBatchValidators is the maximum number of parallel workers used to
-	// validate a batch (defaults to 128).
+	// NumBatchValidators is the number of parallel workers shared by the
+	// validations of the batches (defaults to 128).
 	NumBatchValidatorsFlag = cli.IntFlag{
 		Name:     "num-batch-validators",
-		Usage:    "maximum number of parallel workers used to validate a batch (defaults to 128)",
+		Usage:    "number of parallel workers shared by the validations of the batches, which verify the KZG proofs of the chunks across blobs (defaults to 128, set to 0 to use the number of CPUs)",
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "NUM_BATCH_VALIDATORS"),
 		Value:    128,
@@ -272,6 +322,167 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISABLE_NODE_INFO_RESOURCES"),
 	}
+	EnableNodeInfoConfigFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-node-info-config"),
+		Usage:    "Report the non-sensitive configuration (chunk storage backend, database size, quorums and enabled features) on the NodeInfo API, so that the fleet scans can diagnose the node",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_NODE_INFO_CONFIG"),
+	}
+	EnableDispersalAuthFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-dispersal-auth"),
+		Usage:    "Reject StoreChunks, StoreBlobs and AttestBatch requests that are not signed by an authorized disperser",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_DISPERSAL_AUTH"),
+	}
+	AuthorizedDisperserAddressesFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "authorized-disperser-addresses"),
+		Usage:    "Addresses of the dispersers allowed to send dispersal requests. If empty, the batch confirmers registered on the EigenDAServiceManager are allowed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "AUTHORIZED_DISPERSER_ADDRESSES"),
+	}
+	DispersalAuthMaxClockSkewFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dispersal-auth-max-clock-skew"),
+		Usage:    "Maximum difference between the signing time of a dispersal request and the local time",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISPERSAL_AUTH_MAX_CLOCK_SKEW"),
+	}
+	EnableAdmissionControlFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-admission-control"),
+		Usage:    "Reject StoreChunks and StoreBlobs requests whose bundles don't have the number of chunks assigned to the operator by its stake share in their quorums, before the chunks are deserialized",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_ADMISSION_CONTROL"),
+	}
+	EnablePartialBatchSigningFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "enable-partial-batch-signing"),
+		Usage:    "Sign batches excluding the blobs whose chunks fail verification instead of rejecting the whole batch. The excluded blobs are reported to the disperser",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ENABLE_PARTIAL_BATCH_SIGNING"),
+	}
+	RejectedBatchRecordDirFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "rejected-batch-record-dir"),
+		Usage:    "Directory where the StoreChunks requests of the batches that the node refuses to sign are recorded, so that they can be replayed offline with the batchreplay tool. Recording is disabled if empty, and requires dispersal authentication so that only the requests of the authorized dispersers are recorded",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "REJECTED_BATCH_RECORD_DIR"),
+	}
+	RejectedBatchRecordMaxFilesFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "rejected-batch-record-max-files"),
+		Usage:    "Maximum number of requests recorded in the rejected batch record directory. The oldest ones are removed beyond it",
+		Required: false,
+		Value:    10,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "REJECTED_BATCH_RECORD_MAX_FILES"),
+	}
+	DisableSRSVerificationFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disable-srs-verification"),
+		Usage:    "Disable the verification of the SRS files at startup. When enabled, the node checks that the SRS files aren't truncated and reads them through in the background, and doesn't attest to batches until it is done",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "DISABLE_SRS_VERIFICATION"),
+	}
+	G1DigestFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "g1-sha256"),
+		Usage:    "Expected hex encoded SHA-256 digest of the G1 points file. It is not checked if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "G1_SHA256"),
+	}
+	G2DigestFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "g2-sha256"),
+		Usage:    "Expected hex encoded SHA-256 digest of the G2 points file. It is not checked if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "G2_SHA256"),
+	}
+	G2PowerOf2DigestFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "g2-power-of-2-sha256"),
+		Usage:    "Expected hex encoded SHA-256 digest of the G2 points on powers of 2 file. It is not checked if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "G2_POWER_OF_2_SHA256"),
+	}
+	AccessLogPathFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "access-log-path"),
+		Usage:    "File where the chunks served by the retrieval API are logged, one JSON line per request with the requester, the blob, the bytes served and the latency. The access log is disabled if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ACCESS_LOG_PATH"),
+	}
+	AccessLogMaxSizeMBFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "access-log-max-size-mb"),
+		Usage:    "Size in megabytes at which the access log is rotated",
+		Required: false,
+		Value:    100,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ACCESS_LOG_MAX_SIZE_MB"),
+	}
+	AccessLogMaxBackupsFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "access-log-max-backups"),
+		Usage:    "Number of rotated access logs which are kept. All are kept if 0",
+		Required: false,
+		Value:    10,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ACCESS_LOG_MAX_BACKUPS"),
+	}
+	AccessLogMaxAgeDaysFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "access-log-max-age-days"),
+		Usage:    "Number of days after which the rotated access logs are deleted. They are kept if 0",
+		Required: false,
+		Value:    30,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ACCESS_LOG_MAX_AGE_DAYS"),
+	}
+	AccessLogAnonymizeFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "access-log-anonymize"),
+		Usage:    "Log a keyed hash of the IP address of the requesters instead of the IP address",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ACCESS_LOG_ANONYMIZE"),
+	}
+	AccessLogAnonymizationKeyFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "access-log-anonymization-key"),
+		Usage:    "Key of the hashes of the anonymized requesters, so that a requester keeps the same hash across restarts. A random key is generated at startup if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "ACCESS_LOG_ANONYMIZATION_KEY"),
+	}
+	TLSCertFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-cert-file"),
+		Usage:    "PEM encoded certificate served by the dispersal and retrieval servers, which then only accept TLS connections. The servers are plaintext if empty",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_CERT_FILE"),
+	}
+	TLSKeyFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-key-file"),
+		Usage:    "PEM encoded private key of the certificate of the tls-cert-file flag",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_KEY_FILE"),
+	}
+	TLSReloadIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "tls-reload-interval"),
+		Usage:    "Interval at which the TLS certificate and key files are checked for changes and reloaded. They are only reloaded on SIGHUP if 0",
+		Required: false,
+		Value:    time.Minute,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "TLS_RELOAD_INTERVAL"),
+	}
+	ConfigFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "config-file"),
+		Usage:    "Path of a YAML or TOML file setting the options of the node by flag name, e.g. node.hostname. The flags and the environment variables take precedence over the file",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CONFIG_FILE"),
+	}
+
+	/* Status Flags */
+
+	StatusOperatorAddressFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-address"),
+		Usage:    "Address of the operator whose onchain state is printed by the status command",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "OPERATOR_ADDRESS"),
+	}
+	// The status command only needs to read the chain, so it takes a single rpc url and no private key
+	StatusChainRpcFlag = cli.StringFlag{
+		Name:     "chain.rpc",
+		Usage:    "Chain rpc",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "CHAIN_RPC"),
+	}
+	StatusSocketFromBlockFlag = cli.Uint64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "status-socket-from-block"),
+		Usage:    "Block from which the socket updates of the operator are searched to find its socket on record. Rpc providers that limit the block range of log queries require this to be recent",
+		Required: false,
+		Value:    0,
+		EnvVar:   common.PrefixEnvVar(EnvVarPrefix, "STATUS_SOCKET_FROM_BLOCK"),
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -298,6 +509,9 @@ var requiredFlags = []cli.Flag{
 var optionalFlags = []cli.Flag{
 	RegisterAtNodeStartFlag,
 	ExpirationPollIntervalSecFlag,
+	ExpirationSafetyMarginFlag,
+	ExpirationDryRunFlag,
+	ExpirationCompactionFlag,
 	ReachabilityPollIntervalSecFlag,
 	EnableTestModeFlag,
 	OverrideBlockStaleMeasureFlag,
@@ -313,7 +527,34 @@ var optionalFlags = []cli.Flag{
 	EcdsaKeyPasswordFlag,
 	DataApiUrlFlag,
 	DisableNodeInfoResourcesFlag,
+	EnableNodeInfoConfigFlag,
 	EnableGnarkBundleEncodingFlag,
+	EnableDispersalAuthFlag,
+	AuthorizedDisperserAddressesFlag,
+	DispersalAuthMaxClockSkewFlag,
+	EnableAdmissionControlFlag,
+	EnablePartialBatchSigningFlag,
+	RejectedBatchRecordDirFlag,
+	RejectedBatchRecordMaxFilesFlag,
+	DisableSRSVerificationFlag,
+	G1DigestFlag,
+	G2DigestFlag,
+	G2PowerOf2DigestFlag,
+	MetricsPushGatewayURLFlag,
+	MetricsPushIntervalFlag,
+	MetricsPushLabelsFlag,
+	StorageEncryptionKeySecretNameFlag,
+	StorageEncryptionKeySecretRegionFlag,
+	AccessLogPathFlag,
+	AccessLogMaxSizeMBFlag,
+	AccessLogMaxBackupsFlag,
+	AccessLogMaxAgeDaysFlag,
+	AccessLogAnonymizeFlag,
+	AccessLogAnonymizationKeyFlag,
+	TLSCertFileFlag,
+	TLSKeyFileFlag,
+	TLSReloadIntervalFlag,
+	ConfigFileFlag,
 }
 
 func init() {
@@ -325,3 +566,12 @@ func init() {
 
 // Flags contains the list of configuration options available to the binary.
 var Flags []cli.Flag
+
+// StatusFlags contains the list of configuration options of the status command.
+var StatusFlags = []cli.Flag{
+	StatusOperatorAddressFlag,
+	StatusChainRpcFlag,
+	BlsOperatorStateRetrieverFlag,
+	EigenDAServiceManagerFlag,
+	StatusSocketFromBlockFlag,
+}
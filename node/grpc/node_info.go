@@ -0,0 +1,57 @@
+package grpc
+
+import (
+	"sync"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/node"
+)
+
+// dbSizeRefreshInterval is how long the size of the database reported by NodeInfo is cached, since walking a large
+// database on every request would be expensive
+const dbSizeRefreshInterval = time.Minute
+
+// dbSizeCache is the size of the database directory, refreshed at most every dbSizeRefreshInterval
+type dbSizeCache struct {
+	mu        sync.Mutex
+	path      string
+	size      uint64
+	updatedAt time.Time
+}
+
+func (c *dbSizeCache) get(now time.Time) (uint64, error) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	if !c.updatedAt.IsZero() && now.Sub(c.updatedAt) < dbSizeRefreshInterval {
+		return c.size, nil
+	}
+	size, err := node.DirSize(c.path)
+	if err != nil {
+		return c.size, err
+	}
+	c.size = size
+	c.updatedAt = now
+	return size, nil
+}
+
+// nodeConfig returns the configuration of the node reported by NodeInfo
+func (s *Server) nodeConfig() *pb.NodeConfig {
+	dbSize, err := s.dbSize.get(time.Now())
+	if err != nil {
+		s.logger.Warn("failed to get the size of the database", "path", s.config.DbPath, "err", err)
+	}
+	return &pb.NodeConfig{
+		ChunkStorageBackend: s.node.Store.Backend(),
+		DbSizeBytes:         dbSize,
+	}
+}
+
+// quorumIDs returns the quorums the node is configured to serve
+func (s *Server) quorumIDs() []uint32 {
+	quorumIDs := make([]uint32, len(s.config.QuorumIDList))
+	for i, quorumID := range s.config.QuorumIDList {
+		quorumIDs[i] = uint32(quorumID)
+	}
+	return quorumIDs
+}
@@ -0,0 +1,115 @@
+package grpc
+
+import (
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"google.golang.org/protobuf/proto"
+)
+
+// rejectedBatchFileExt is the extension of the files the rejected batches are recorded to
+const rejectedBatchFileExt = ".pb"
+
+type rejectedBatch struct {
+	request         *pb.StoreChunksRequest
+	batchHeaderHash [32]byte
+}
+
+// rejectedBatchRecorder records the StoreChunks requests of the batches that the node refused to sign in a
+// directory, so that they can be replayed offline with the batchreplay tool. The requests are written in the
+// background, and the ones rejected while another is being written are dropped, so that recording doesn't slow down
+// the requests nor hold more than one of them in memory. Only the maxFiles latest requests are kept.
+type rejectedBatchRecorder struct {
+	dir      string
+	maxFiles int
+	logger   logging.Logger
+
+	batches chan rejectedBatch
+}
+
+func newRejectedBatchRecorder(dir string, maxFiles int, logger logging.Logger) *rejectedBatchRecorder {
+	r := &rejectedBatchRecorder{
+		dir:      dir,
+		maxFiles: maxFiles,
+		logger:   logger,
+		batches:  make(chan rejectedBatch, 1),
+	}
+	go r.run()
+	return r
+}
+
+// record queues the request to be recorded, unless another request is already waiting to be
+func (r *rejectedBatchRecorder) record(in *pb.StoreChunksRequest, batchHeaderHash [32]byte) {
+	select {
+	case r.batches <- rejectedBatch{request: in, batchHeaderHash: batchHeaderHash}:
+	default:
+		r.logger.Warn("Dropped rejected batch record, another one is being recorded", "batchHeaderHash", hex.EncodeToString(batchHeaderHash[:]))
+	}
+}
+
+func (r *rejectedBatchRecorder) run() {
+	for batch := range r.batches {
+		hash := hex.EncodeToString(batch.batchHeaderHash[:])
+		path, err := r.write(batch.request, hash)
+		if err != nil {
+			r.logger.Warn("Failed to record rejected batch", "batchHeaderHash", hash, "err", err)
+			continue
+		}
+		r.logger.Info("Recorded rejected batch", "batchHeaderHash", hash, "path", path)
+		if err := r.evict(); err != nil {
+			r.logger.Warn("Failed to evict the oldest rejected batch records", "dir", r.dir, "err", err)
+		}
+	}
+}
+
+func (r *rejectedBatchRecorder) write(in *pb.StoreChunksRequest, batchHeaderHash string) (string, error) {
+	data, err := proto.Marshal(in)
+	if err != nil {
+		return "", err
+	}
+	if err := os.MkdirAll(r.dir, 0755); err != nil {
+		return "", err
+	}
+	path := filepath.Join(r.dir, batchHeaderHash+rejectedBatchFileExt)
+	if err := os.WriteFile(path, data, 0644); err != nil {
+		return "", err
+	}
+	return path, nil
+}
+
+// evict removes the oldest records once there are more than maxFiles of them
+func (r *rejectedBatchRecorder) evict() error {
+	entries, err := os.ReadDir(r.dir)
+	if err != nil {
+		return err
+	}
+	records := make([]os.FileInfo, 0, len(entries))
+	for _, entry := range entries {
+		if entry.IsDir() || !strings.HasSuffix(entry.Name(), rejectedBatchFileExt) {
+			continue
+		}
+		info, err := entry.Info()
+		if err != nil {
+			// The record was removed since the directory was read
+			continue
+		}
+		records = append(records, info)
+	}
+	if len(records) <= r.maxFiles {
+		return nil
+	}
+	sort.Slice(records, func(i, j int) bool {
+		return records[i].ModTime().Before(records[j].ModTime())
+	})
+	for _, record := range records[:len(records)-r.maxFiles] {
+		if err := os.Remove(filepath.Join(r.dir, record.Name())); err != nil && !os.IsNotExist(err) {
+			return err
+		}
+	}
+	return nil
+}
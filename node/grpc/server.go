@@ -15,10 +15,12 @@ import (
 	"net"
 
 	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/conversion"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/healthcheck"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/node"
 	"github.com/Layr-Labs/eigensdk-go/logging"
@@ -30,6 +32,7 @@ import (
 	_ "go.uber.org/automaxprocs"
 
 	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials"
 	"google.golang.org/grpc/reflection"
 	"google.golang.org/protobuf/proto"
 	"google.golang.org/protobuf/types/known/wrapperspb"
@@ -37,6 +40,8 @@ import (
 
 const localhost = "0.0.0.0"
 
+var errRateLimited = errors.New("request rate limited")
+
 // Server implements the Node proto APIs.
 type Server struct {
 	pb.UnimplementedDispersalServer
@@ -47,8 +52,16 @@ type Server struct {
 	logger logging.Logger
 
 	ratelimiter common.RateLimiter
+	// authenticator verifies that dispersal requests come from an authorized disperser.
+	// It is nil if dispersal authentication is disabled.
+	authenticator core.DispersalRequestAuthenticator
+	// rejectedBatches records the authenticated requests of the batches that the node refused to sign.
+	// It is nil if recording is disabled.
+	rejectedBatches *rejectedBatchRecorder
 
 	mu *sync.Mutex
+	// dbSize is the size of the database reported by NodeInfo if the node reports its configuration
+	dbSize *dbSizeCache
 }
 
 // NewServer creates a new Server instance with the provided parameters.
@@ -56,12 +69,28 @@ type Server struct {
 // Note: The Server's chunks store will be created at config.DbPath+"/chunk".
 func NewServer(config *node.Config, node *node.Node, logger logging.Logger, ratelimiter common.RateLimiter) *Server {
 
+	var authenticator core.DispersalRequestAuthenticator
+	if config.EnableDispersalAuth {
+		authenticator = auth.NewDispersalRequestAuthenticator(config.AuthorizedDisperserAddresses, node.Transactor, config.DispersalAuthMaxClockSkew)
+		logger.Info("Dispersal request authentication enabled", "authorizedDispersers", config.AuthorizedDisperserAddresses)
+	}
+
+	// The config requires dispersal authentication to record the rejected batches, so that the recordings can't be
+	// triggered by anyone
+	var rejectedBatches *rejectedBatchRecorder
+	if config.RejectedBatchRecordDir != "" && authenticator != nil {
+		rejectedBatches = newRejectedBatchRecorder(config.RejectedBatchRecordDir, config.RejectedBatchRecordMaxFiles, logger)
+	}
+
 	return &Server{
-		config:      config,
-		logger:      logger,
-		node:        node,
-		ratelimiter: ratelimiter,
-		mu:          &sync.Mutex{},
+		config:          config,
+		logger:          logger,
+		node:            node,
+		ratelimiter:     ratelimiter,
+		authenticator:   authenticator,
+		rejectedBatches: rejectedBatches,
+		mu:              &sync.Mutex{},
+		dbSize:          &dbSizeCache{path: config.DbPath},
 	}
 }
 
@@ -99,16 +128,7 @@ func (s *Server) serveDispersal() error {
 		s.logger.Fatalf("Could not start tcp listener: %v", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(60 * 1024 * 1024 * 1024) // 60 GiB
-	gs := grpc.NewServer(opt)
-
-	// Register reflection service on gRPC server
-	// This makes "grpcurl -plaintext localhost:9000 list" command work
-	reflection.Register(gs)
-
-	pb.RegisterDispersalServer(gs, s)
-	healthcheck.RegisterHealthServer("node.Dispersal", gs)
-
+	gs := s.newDispersalServer()
 	s.logger.Info("port", s.config.InternalDispersalPort, "address", listener.Addr().String(), "GRPC Listening")
 	if err := gs.Serve(listener); err != nil {
 		return err
@@ -124,8 +144,34 @@ func (s *Server) serveRetrieval() error {
 		s.logger.Fatalf("Could not start tcp listener: %v", err)
 	}
 
-	opt := grpc.MaxRecvMsgSize(1024 * 1024 * 300) // 300 MiB
-	gs := grpc.NewServer(opt)
+	gs := s.newRetrievalServer()
+	s.logger.Info("port", s.config.InternalRetrievalPort, "address", listener.Addr().String(), "GRPC Listening")
+	if err := gs.Serve(listener); err != nil {
+		return err
+	}
+	return nil
+
+}
+
+// newDispersalServer returns the gRPC server of the dispersal service. Like the retrieval service, it serves the
+// NodeInfo of the Server, so that the node info is the same whichever service the scans request it from.
+func (s *Server) newDispersalServer() *grpc.Server {
+	opts := append(s.serverOptions(), grpc.MaxRecvMsgSize(60*1024*1024*1024)) // 60 GiB
+	gs := grpc.NewServer(opts...)
+
+	// Register reflection service on gRPC server
+	// This makes "grpcurl -plaintext localhost:9000 list" command work
+	reflection.Register(gs)
+
+	pb.RegisterDispersalServer(gs, s)
+	healthcheck.RegisterHealthServer("node.Dispersal", gs)
+	return gs
+}
+
+// newRetrievalServer returns the gRPC server of the retrieval service
+func (s *Server) newRetrievalServer() *grpc.Server {
+	opts := append(s.serverOptions(), grpc.MaxRecvMsgSize(1024*1024*300)) // 300 MiB
+	gs := grpc.NewServer(opts...)
 
 	// Register reflection service on gRPC server
 	// This makes "grpcurl -plaintext localhost:9000 list" command work
@@ -133,27 +179,55 @@ func (s *Server) serveRetrieval() error {
 
 	pb.RegisterRetrievalServer(gs, s)
 	healthcheck.RegisterHealthServer("node.Retrieval", gs)
+	return gs
+}
 
-	s.logger.Info("port", s.config.InternalRetrievalPort, "address", listener.Addr().String(), "GRPC Listening")
-	if err := gs.Serve(listener); err != nil {
-		return err
+// serverOptions are the options shared by the dispersal and retrieval servers. The servers serve the certificate of
+// the node's CertReloader if TLS is enabled, so that a reloaded certificate is served to the new connections without
+// restarting the servers.
+func (s *Server) serverOptions() []grpc.ServerOption {
+	if s.node.CertReloader == nil {
+		return nil
 	}
-	return nil
-
+	return []grpc.ServerOption{grpc.Creds(credentials.NewTLS(s.node.CertReloader.TLSConfig()))}
 }
 
 func (s *Server) NodeInfo(ctx context.Context, in *pb.NodeInfoRequest) (*pb.NodeInfoReply, error) {
-	if s.config.DisableNodeInfoResources {
-		return &pb.NodeInfoReply{Semver: node.SemVer}, nil
+	// The retention expiry isn't a resource of the node, and it is reported so that the operator can tell when the
+	// node may be shut down once deregistered. It is reported as unknown if it can't be read, rather than hiding the
+	// rest of the node info from the scans.
+	retentionExpiry, err := s.node.Store.LastExpirationTime()
+	if err != nil {
+		s.node.Logger.Error("failed to get the retention expiry", "err", err)
+		retentionExpiry = 0
 	}
 
-	memBytes := uint64(0)
-	v, err := mem.VirtualMemory()
-	if err == nil {
-		memBytes = v.Total
+	// The quorums and the features are reported whatever the configuration, so that the scans can detect the nodes
+	// whose capabilities don't match their registration
+	reply := &pb.NodeInfoReply{
+		Semver:          node.SemVer,
+		RetentionExpiry: uint64(retentionExpiry),
+		QuorumIds:       s.quorumIDs(),
+		Features:        s.config.EnabledFeatures(),
+	}
+	if s.config.EnableNodeInfoConfig {
+		reply.Config = s.nodeConfig()
 	}
 
-	return &pb.NodeInfoReply{Semver: node.SemVer, Os: runtime.GOOS, Arch: runtime.GOARCH, NumCpu: uint32(runtime.GOMAXPROCS(0)), MemBytes: memBytes}, nil
+	if !s.config.DisableNodeInfoResources {
+		memBytes := uint64(0)
+		v, err := mem.VirtualMemory()
+		if err == nil {
+			memBytes = v.Total
+		}
+		reply.Os = runtime.GOOS
+		reply.Arch = runtime.GOARCH
+		reply.NumCpu = uint32(runtime.GOMAXPROCS(0))
+		reply.MemBytes = memBytes
+	}
+
+	reply.UnixTimeMs = uint64(time.Now().UnixMilli())
+	return reply, nil
 }
 
 func (s *Server) StreamBlobHeaders(pb.Retrieval_StreamBlobHeadersServer) error {
@@ -164,7 +238,7 @@ func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunk
 	start := time.Now()
 
 	// Get batch header hash
-	batchHeader, err := node.GetBatchHeader(in.GetBatchHeader())
+	batchHeader, err := conversion.BatchHeaderFromProto(in.GetBatchHeader())
 	if err != nil {
 		return nil, err
 	}
@@ -177,14 +251,30 @@ func (s *Server) handleStoreChunksRequest(ctx context.Context, in *pb.StoreChunk
 	s.node.Metrics.ObserveLatency("StoreChunks", "deserialization", float64(time.Since(start).Milliseconds()))
 	s.node.Logger.Info("StoreChunksRequest deserialized", "duration", time.Since(start))
 
-	sig, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs())
+	sig, excludedBlobs, err := s.node.ProcessBatch(ctx, batchHeader, blobs, in.GetBlobs())
 	if err != nil {
+		s.recordRejectedBatch(in, batchHeader)
 		return nil, err
 	}
 
 	sigData := sig.Serialize()
 
-	return &pb.StoreChunksReply{Signature: sigData[:]}, nil
+	return &pb.StoreChunksReply{Signature: sigData[:], ExcludedBlobs: excludedBlobs}, nil
+}
+
+// recordRejectedBatch records the request of a batch that the node refused to sign, if recording is enabled. The
+// request has been authenticated, as the recording is only enabled with dispersal authentication. Failing to record
+// the request does not fail the request.
+func (s *Server) recordRejectedBatch(in *pb.StoreChunksRequest, batchHeader *core.BatchHeader) {
+	if s.rejectedBatches == nil {
+		return
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		s.node.Logger.Warn("Failed to record rejected batch", "err", err)
+		return
+	}
+	s.rejectedBatches.record(in, batchHeaderHash)
 }
 
 func (s *Server) validateStoreChunkRequest(in *pb.StoreChunksRequest) error {
@@ -205,8 +295,9 @@ func (s *Server) validateStoreChunkRequest(in *pb.StoreChunksRequest) error {
 		if blob.GetHeader() == nil {
 			return api.NewInvalidArgError("missing blob header in request")
 		}
-		if node.ValidatePointsFromBlobHeader(blob.GetHeader()) != nil {
-			return api.NewInvalidArgError("invalid points contained in the blob header in request")
+		// The conversion checks that the points are valid and that the quorums are valid and distinct
+		if _, err := conversion.BlobHeaderFromProto(blob.GetHeader()); err != nil {
+			return err
 		}
 		if len(blob.GetHeader().GetQuorumHeaders()) == 0 {
 			return api.NewInvalidArgError("missing quorum headers in request")
@@ -214,14 +305,33 @@ func (s *Server) validateStoreChunkRequest(in *pb.StoreChunksRequest) error {
 		if len(blob.GetHeader().GetQuorumHeaders()) != len(blob.GetBundles()) {
 			return api.NewInvalidArgError("the number of quorums must be the same as the number of bundles")
 		}
-		for _, q := range blob.GetHeader().GetQuorumHeaders() {
-			if q.GetQuorumId() > core.MaxQuorumID {
-				return api.NewInvalidArgError(fmt.Sprintf("quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, q.GetQuorumId()))
-			}
-			if err := core.ValidateSecurityParam(q.GetConfirmationThreshold(), q.GetAdversaryThreshold()); err != nil {
-				return err
-			}
-		}
+	}
+	return nil
+}
+
+// authenticateDispersalRequest rejects the request if dispersal authentication is enabled and the request
+// is not signed by an authorized disperser.
+func (s *Server) authenticateDispersalRequest(ctx context.Context, requestDigest [32]byte) error {
+	if s.authenticator == nil {
+		return nil
+	}
+	if err := auth.AuthenticateIncomingDispersalRequest(ctx, s.authenticator, requestDigest); err != nil {
+		s.logger.Warn("Rejected unauthenticated dispersal request", "err", err)
+		return api.NewUnauthenticatedError(err.Error())
+	}
+	return nil
+}
+
+// admitDispersalRequest rejects the request if the admission control is enabled and its bundles don't match the
+// chunks assigned to the operator
+func (s *Server) admitDispersalRequest(ctx context.Context, method string, referenceBlockNumber uint, blobs []*pb.Blob) error {
+	err := s.node.AdmitDispersal(ctx, method, referenceBlockNumber, blobs)
+	var admissionErr *node.AdmissionError
+	if errors.As(err, &admissionErr) {
+		return api.NewInvalidArgError(err.Error())
+	}
+	if err != nil {
+		return api.NewGRPCError(codes.Internal, err.Error())
 	}
 	return nil
 }
@@ -230,6 +340,11 @@ func (s *Server) validateStoreChunkRequest(in *pb.StoreChunksRequest) error {
 func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*pb.StoreChunksReply, error) {
 	start := time.Now()
 
+	// The node doesn't attest to batches until the SRS files are verified
+	if err := s.node.SRSReady(); err != nil {
+		return nil, api.NewGRPCError(codes.Unavailable, err.Error())
+	}
+
 	blobHeadersSize := 0
 	bundleSize := 0
 	for _, blob := range in.Blobs {
@@ -245,6 +360,21 @@ func (s *Server) StoreChunks(ctx context.Context, in *pb.StoreChunksRequest) (*p
 		return nil, err
 	}
 
+	batchHeader, err := conversion.BatchHeaderFromProto(in.GetBatchHeader())
+	if err != nil {
+		return nil, err
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authenticateDispersalRequest(ctx, batchHeaderHash); err != nil {
+		return nil, err
+	}
+	if err := s.admitDispersalRequest(ctx, "StoreChunks", batchHeader.ReferenceBlockNumber, in.GetBlobs()); err != nil {
+		return nil, err
+	}
+
 	// Process the request.
 	reply, err := s.handleStoreChunksRequest(ctx, in)
 
@@ -272,8 +402,9 @@ func (s *Server) validateStoreBlobsRequest(in *pb.StoreBlobsRequest) error {
 		if blob.GetHeader() == nil {
 			return api.NewInvalidArgError("missing blob header in request")
 		}
-		if node.ValidatePointsFromBlobHeader(blob.GetHeader()) != nil {
-			return api.NewInvalidArgError("invalid points contained in the blob header in request")
+		// The conversion checks that the points are valid and that the quorums are valid and distinct
+		if _, err := conversion.BlobHeaderFromProto(blob.GetHeader()); err != nil {
+			return err
 		}
 		if len(blob.GetHeader().GetQuorumHeaders()) == 0 {
 			return api.NewInvalidArgError("missing quorum headers in request")
@@ -281,14 +412,6 @@ func (s *Server) validateStoreBlobsRequest(in *pb.StoreBlobsRequest) error {
 		if len(blob.GetHeader().GetQuorumHeaders()) != len(blob.GetBundles()) {
 			return api.NewInvalidArgError("the number of quorums must be the same as the number of bundles")
 		}
-		for _, q := range blob.GetHeader().GetQuorumHeaders() {
-			if q.GetQuorumId() > core.MaxQuorumID {
-				return api.NewInvalidArgError(fmt.Sprintf("quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, q.GetQuorumId()))
-			}
-			if err := core.ValidateSecurityParam(q.GetConfirmationThreshold(), q.GetAdversaryThreshold()); err != nil {
-				return err
-			}
-		}
 		if in.GetReferenceBlockNumber() != blob.GetHeader().GetReferenceBlockNumber() {
 			return api.NewInvalidArgError("reference_block_number must be the same for all blobs")
 		}
@@ -296,6 +419,22 @@ func (s *Server) validateStoreBlobsRequest(in *pb.StoreBlobsRequest) error {
 	return nil
 }
 
+// storeBlobsRequestDigest returns the digest the disperser signs for the request, which commits to its blob headers
+func storeBlobsRequestDigest(in *pb.StoreBlobsRequest) ([32]byte, error) {
+	blobHeaderHashes := make([][32]byte, len(in.GetBlobs()))
+	for i, blob := range in.GetBlobs() {
+		blobHeader, err := conversion.BlobHeaderFromProto(blob.GetHeader())
+		if err != nil {
+			return [32]byte{}, err
+		}
+		blobHeaderHashes[i], err = blobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return [32]byte{}, api.NewInvalidArgError(fmt.Sprintf("failed to get blob header hash: %v", err))
+		}
+	}
+	return auth.StoreBlobsRequestDigest(in.GetReferenceBlockNumber(), blobHeaderHashes), nil
+}
+
 func (s *Server) StoreBlobs(ctx context.Context, in *pb.StoreBlobsRequest) (*pb.StoreBlobsReply, error) {
 	start := time.Now()
 
@@ -303,6 +442,16 @@ func (s *Server) StoreBlobs(ctx context.Context, in *pb.StoreBlobsRequest) (*pb.
 	if err != nil {
 		return nil, err
 	}
+	requestDigest, err := storeBlobsRequestDigest(in)
+	if err != nil {
+		return nil, err
+	}
+	if err := s.authenticateDispersalRequest(ctx, requestDigest); err != nil {
+		return nil, err
+	}
+	if err := s.admitDispersalRequest(ctx, "StoreBlobs", uint(in.GetReferenceBlockNumber()), in.GetBlobs()); err != nil {
+		return nil, err
+	}
 
 	blobHeadersSize := 0
 	bundleSize := 0
@@ -343,6 +492,10 @@ func (s *Server) StoreBlobs(ctx context.Context, in *pb.StoreBlobsRequest) (*pb.
 func (s *Server) AttestBatch(ctx context.Context, in *pb.AttestBatchRequest) (*pb.AttestBatchReply, error) {
 	start := time.Now()
 
+	if err := s.node.SRSReady(); err != nil {
+		return nil, api.NewGRPCError(codes.Unavailable, err.Error())
+	}
+
 	// Validate the batch root
 	blobHeaderHashes := make([][32]byte, len(in.GetBlobHeaderHashes()))
 	for i, hash := range in.GetBlobHeaderHashes() {
@@ -353,10 +506,17 @@ func (s *Server) AttestBatch(ctx context.Context, in *pb.AttestBatchRequest) (*p
 		copy(h[:], hash)
 		blobHeaderHashes[i] = h
 	}
-	batchHeader, err := node.GetBatchHeader(in.GetBatchHeader())
+	batchHeader, err := conversion.BatchHeaderFromProto(in.GetBatchHeader())
 	if err != nil {
 		return nil, fmt.Errorf("failed to get the batch header: %w", err)
 	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to get the batch header hash: %w", err)
+	}
+	if err := s.authenticateDispersalRequest(ctx, batchHeaderHash); err != nil {
+		return nil, err
+	}
 	err = s.node.ValidateBatchContents(ctx, blobHeaderHashes, batchHeader)
 	if err != nil {
 		return nil, fmt.Errorf("failed to validate the batch header root: %w", err)
@@ -369,10 +529,6 @@ func (s *Server) AttestBatch(ctx context.Context, in *pb.AttestBatchRequest) (*p
 	}
 
 	// Sign the batch header
-	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
-	if err != nil {
-		return nil, fmt.Errorf("failed to get the batch header hash: %w", err)
-	}
 	sig := s.node.KeyPair.SignMessage(batchHeaderHash)
 
 	s.node.Logger.Info("AttestBatch complete", "duration", time.Since(start))
@@ -381,8 +537,13 @@ func (s *Server) AttestBatch(ctx context.Context, in *pb.AttestBatchRequest) (*p
 	}, nil
 }
 
-func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksRequest) (*pb.RetrieveChunksReply, error) {
+func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksRequest) (reply *pb.RetrieveChunksReply, err error) {
 	start := time.Now()
+	if s.node.AccessLogger != nil {
+		defer func() {
+			s.logRetrieval(ctx, in, reply, err, start)
+		}()
+	}
 
 	if in.GetQuorumId() > core.MaxQuorumID {
 		return nil, fmt.Errorf("invalid request: quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, in.GetQuorumId())
@@ -423,7 +584,7 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 	}
 
 	if !allow {
-		return nil, errors.New("request rate limited")
+		return nil, errRateLimited
 	}
 
 	chunks, format, err := s.node.Store.GetChunks(ctx, batchHeaderHash, int(in.GetBlobIndex()), uint8(in.GetQuorumId()))
@@ -456,6 +617,37 @@ func (s *Server) RetrieveChunks(ctx context.Context, in *pb.RetrieveChunksReques
 	return &pb.RetrieveChunksReply{Chunks: chunks, ChunkEncodingFormat: format}, nil
 }
 
+// logRetrieval writes the RetrieveChunks request to the access log, with the number of bytes of the chunks served
+func (s *Server) logRetrieval(ctx context.Context, in *pb.RetrieveChunksRequest, reply *pb.RetrieveChunksReply, err error, start time.Time) {
+	requester, addrErr := common.GetClientAddress(ctx, s.config.ClientIPHeader, 1, false)
+	if addrErr != nil {
+		requester = "unknown"
+	}
+	entry := &node.AccessLogEntry{
+		Time:            start.UTC(),
+		Requester:       requester,
+		Method:          "RetrieveChunks",
+		BatchHeaderHash: hex.EncodeToString(in.GetBatchHeaderHash()),
+		BlobIndex:       in.GetBlobIndex(),
+		QuorumID:        in.GetQuorumId(),
+		Status:          "success",
+		LatencyMs:       float64(time.Since(start).Microseconds()) / 1000,
+	}
+	switch {
+	case errors.Is(err, errRateLimited):
+		entry.Status = "rate_limited"
+	case err != nil:
+		entry.Status = "failure"
+	default:
+		for _, chunk := range reply.GetChunks() {
+			entry.BytesServed += len(chunk)
+		}
+	}
+	if err := s.node.AccessLogger.Log(entry); err != nil {
+		s.logger.Warn("failed to write the access log", "err", err)
+	}
+}
+
 func (s *Server) GetBlobHeader(ctx context.Context, in *pb.GetBlobHeaderRequest) (*pb.GetBlobHeaderReply, error) {
 	var batchHeaderHash [32]byte
 	copy(batchHeaderHash[:], in.GetBatchHeaderHash())
@@ -518,7 +710,7 @@ func (s *Server) rebuildMerkleTree(batchHeaderHash [32]byte) (*merkletree.Merkle
 			return nil, err
 		}
 
-		blobHeader, err := node.GetBlobHeaderFromProto(&protoBlobHeader)
+		blobHeader, err := conversion.BlobHeaderFromProto(&protoBlobHeader)
 		if err != nil {
 			return nil, err
 		}
@@ -560,7 +752,7 @@ func (s *Server) getBlobHeader(ctx context.Context, batchHeaderHash [32]byte, bl
 		return nil, nil, err
 	}
 
-	blobHeader, err := node.GetBlobHeaderFromProto(&protoBlobHeader)
+	blobHeader, err := conversion.BlobHeaderFromProto(&protoBlobHeader)
 	if err != nil {
 		return nil, nil, err
 	}
@@ -0,0 +1,148 @@
+package grpc_test
+
+import (
+	"context"
+	"fmt"
+	"runtime"
+	"testing"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/gammazero/workerpool"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/require"
+)
+
+// The StoreChunks path is benchmarked with the batch shapes below. The results are compared against the baseline in
+// testdata/benchmarks_baseline.txt by `make node-benchmarks`, which fails on a regression.
+
+// benchmarkBatchShape is the shape of a batch sent to the node
+type benchmarkBatchShape struct {
+	numBlobs int
+	blobSize int
+}
+
+func (s benchmarkBatchShape) String() string {
+	return fmt.Sprintf("%dx%dKiB", s.numBlobs, s.blobSize/1024)
+}
+
+var benchmarkBatchShapes = []benchmarkBatchShape{
+	{numBlobs: 32, blobSize: 16 * 1024},
+	{numBlobs: 8, blobSize: 64 * 1024},
+	{numBlobs: 2, blobSize: 256 * 1024},
+}
+
+// benchmarkBatch is a batch of the chunks assigned to the operator of the test server
+type benchmarkBatch struct {
+	header *core.BatchHeader
+	// requests are the StoreChunks requests of the batch with the chunks encoded with gob and with the bundles
+	// encoded with gnark
+	requests map[string]*pb.StoreChunksRequest
+	// size is the size of the chunks of the batch
+	size int64
+}
+
+// benchmarkBatches caches the batches by shape, since encoding them takes longer than the benchmarks
+var benchmarkBatches = make(map[benchmarkBatchShape]*benchmarkBatch)
+
+func getBenchmarkBatch(b *testing.B, shape benchmarkBatchShape) *benchmarkBatch {
+	if batch, ok := benchmarkBatches[shape]; ok {
+		return batch
+	}
+	header, blobMessagesByOp := makeBatch(b, shape.blobSize, shape.numBlobs, 80, 100, 1)
+	batch := &benchmarkBatch{
+		header:   header,
+		requests: make(map[string]*pb.StoreChunksRequest),
+	}
+	var err error
+	batch.requests["gob"], batch.size, err = dispatcher.GetStoreChunksRequest(blobMessagesByOp[opID], header, false)
+	require.NoError(b, err)
+	batch.requests["gnark"], _, err = dispatcher.GetStoreChunksRequest(blobMessagesByOp[opID], header, true)
+	require.NoError(b, err)
+	benchmarkBatches[shape] = batch
+	return batch
+}
+
+// BenchmarkStoreChunksDeserialization benchmarks the deserialization of the chunks of a StoreChunks request
+func BenchmarkStoreChunksDeserialization(b *testing.B) {
+	for _, shape := range benchmarkBatchShapes {
+		for _, encoding := range []string{"gob", "gnark"} {
+			b.Run(fmt.Sprintf("%s/%s", shape, encoding), func(b *testing.B) {
+				batch := getBenchmarkBatch(b, shape)
+				req := batch.requests[encoding]
+				b.SetBytes(batch.size)
+				b.ReportAllocs()
+				b.ResetTimer()
+				for i := 0; i < b.N; i++ {
+					_, err := node.GetBlobMessages(req.GetBlobs(), runtime.GOMAXPROCS(0))
+					if err != nil {
+						b.Fatal(err)
+					}
+				}
+			})
+		}
+	}
+}
+
+// BenchmarkStoreChunksVerification benchmarks the verification of the proofs of the chunks of a batch
+func BenchmarkStoreChunksVerification(b *testing.B) {
+	_, v, err := makeTestComponents()
+	require.NoError(b, err)
+	validator := core.NewShardValidator(v, &core.StdAssignmentCoordinator{}, chainState, opID)
+
+	for _, shape := range benchmarkBatchShapes {
+		b.Run(shape.String(), func(b *testing.B) {
+			batch := getBenchmarkBatch(b, shape)
+			blobs, err := node.GetBlobMessages(batch.requests["gob"].GetBlobs(), runtime.GOMAXPROCS(0))
+			require.NoError(b, err)
+			operatorState, err := chainState.GetOperatorStateByOperator(context.Background(), batch.header.ReferenceBlockNumber, opID)
+			require.NoError(b, err)
+			b.SetBytes(batch.size)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				pool := workerpool.New(runtime.GOMAXPROCS(0))
+				err := validator.ValidateBatch(batch.header, blobs, operatorState, pool)
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+// BenchmarkStoreChunksStorage benchmarks the throughput at which the batches are written to the database
+func BenchmarkStoreChunksStorage(b *testing.B) {
+	for _, shape := range benchmarkBatchShapes {
+		b.Run(shape.String(), func(b *testing.B) {
+			batch := getBenchmarkBatch(b, shape)
+			req := batch.requests["gob"]
+			blobs, err := node.GetBlobMessages(req.GetBlobs(), runtime.GOMAXPROCS(0))
+			require.NoError(b, err)
+
+			logger := logging.NewNoopLogger()
+			nodeMetrics := node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), logger, ":9090", opID, -1, nil, chainState)
+			store, err := node.NewLevelDBStore(b.TempDir(), logger, nodeMetrics, 1e9, 1e9)
+			require.NoError(b, err)
+			// The store is closed before its directory is removed, which fails while the database is compacting
+			b.Cleanup(func() { require.NoError(b, store.Close()) })
+
+			b.SetBytes(batch.size)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				// Each batch is stored under another header, since a batch can only be stored once
+				header := *batch.header
+				header.ReferenceBlockNumber = uint(i + 1)
+				_, err := store.StoreBatch(context.Background(), &header, blobs, req.GetBlobs())
+				if err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
@@ -15,7 +15,7 @@ import (
 	"github.com/stretchr/testify/assert"
 )
 
-func makeBatch(t *testing.T, blobSize int, numBlobs int, advThreshold, quorumThreshold int, refBlockNumber uint) (*core.BatchHeader, map[core.OperatorID][]*core.EncodedBlobMessage) {
+func makeBatch(t testing.TB, blobSize int, numBlobs int, advThreshold, quorumThreshold int, refBlockNumber uint) (*core.BatchHeader, map[core.OperatorID][]*core.EncodedBlobMessage) {
 	p, _, err := makeTestComponents()
 	assert.NoError(t, err)
 	asn := &core.StdAssignmentCoordinator{}
@@ -68,10 +68,9 @@ func makeBatch(t *testing.T, blobSize int, numBlobs int, advThreshold, quorumThr
 		blobChunks[i] = chunks
 
 		chunkBytes := make([][]byte, len(chunks))
-		for _, c := range chunks {
-			serialized, err := c.Serialize()
-			assert.NotNil(t, err)
-			chunkBytes = append(chunkBytes, serialized)
+		for j, c := range chunks {
+			chunkBytes[j], err = c.Serialize()
+			assert.NoError(t, err)
 		}
 
 		// populate blob header
@@ -83,12 +82,15 @@ func makeBatch(t *testing.T, blobSize int, numBlobs int, advThreshold, quorumThr
 		// populate blob messages
 		for opID, assignment := range quorumInfo.Assignments {
 			blobMessagesByOp[opID] = append(blobMessagesByOp[opID], &core.EncodedBlobMessage{
-				BlobHeader:     blobHeaders[i],
-				EncodedBundles: make(core.EncodedBundles),
+				BlobHeader: blobHeaders[i],
+				EncodedBundles: core.EncodedBundles{
+					0: {
+						Format:   core.GobChunkEncodingFormat,
+						ChunkLen: int(params.ChunkLength),
+						Chunks:   chunkBytes[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks],
+					},
+				},
 			})
-			blobMessagesByOp[opID][i].EncodedBundles[0].Format = core.GobChunkEncodingFormat
-			blobMessagesByOp[opID][i].EncodedBundles[0].ChunkLen = int(params.ChunkLength)
-			blobMessagesByOp[opID][i].EncodedBundles[0].Chunks = append(blobMessagesByOp[opID][i].EncodedBundles[0].Chunks, chunkBytes[assignment.StartIndex:assignment.StartIndex+assignment.NumChunks]...)
 		}
 	}
 
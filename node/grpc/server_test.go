@@ -2,19 +2,24 @@ package grpc_test
 
 import (
 	"context"
+	"encoding/hex"
+	"encoding/json"
 	"fmt"
 	"net"
 	"os"
+	"path/filepath"
 	"runtime"
 	"strings"
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/conversion"
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common"
 	commonmock "github.com/Layr-Labs/eigenda/common/mock"
 	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/auth"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/encoding"
 	"github.com/Layr-Labs/eigenda/encoding/kzg"
@@ -24,12 +29,19 @@ import (
 	"github.com/Layr-Labs/eigenda/node/grpc"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
+	"github.com/consensys/gnark-crypto/ecc/bn254/fr"
+	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
 	"github.com/wealdtech/go-merkletree/v2"
 	"github.com/wealdtech/go-merkletree/v2/keccak256"
+	googlegrpc "google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
 	"google.golang.org/grpc/peer"
+	"google.golang.org/grpc/status"
 	"google.golang.org/protobuf/proto"
 )
 
@@ -148,14 +160,26 @@ func newTestServerWithConfig(t *testing.T, mockValidator bool, config *node.Conf
 		panic("failed to create a new levelDB store")
 	}
 
+	accessLogger, err := node.NewAccessLogger(config.AccessLog)
+	if err != nil {
+		panic("failed to create the access logger")
+	}
+
+	var admissionController *node.AdmissionController
+	if config.EnableAdmissionControl {
+		admissionController = node.NewAdmissionController(chainState, &core.StdAssignmentCoordinator{})
+	}
+
 	node := &node.Node{
-		Config:     config,
-		Logger:     logger,
-		KeyPair:    keyPair,
-		Metrics:    metrics,
-		Store:      store,
-		ChainState: chainState,
-		Validator:  val,
+		Config:              config,
+		Logger:              logger,
+		KeyPair:             keyPair,
+		Metrics:             metrics,
+		Store:               store,
+		ChainState:          chainState,
+		Validator:           val,
+		AccessLogger:        accessLogger,
+		AdmissionController: admissionController,
 	}
 	return grpc.NewServer(config, node, logger, ratelimiter)
 }
@@ -321,9 +345,80 @@ func storeChunks(t *testing.T, server *grpc.Server, useGnarkBundleEncoding bool)
 
 func TestNodeInfoRequest(t *testing.T) {
 	server := newTestServer(t, true)
+	before := uint64(time.Now().UnixMilli())
 	resp, err := server.NodeInfo(context.Background(), &pb.NodeInfoRequest{})
 	assert.True(t, resp.Semver == "0.0.0")
 	assert.True(t, err == nil)
+	// The node stores no chunks
+	assert.Equal(t, uint64(0), resp.GetRetentionExpiry())
+	assert.GreaterOrEqual(t, resp.GetUnixTimeMs(), before)
+	assert.LessOrEqual(t, resp.GetUnixTimeMs(), uint64(time.Now().UnixMilli()))
+}
+
+func TestNodeInfoConfig(t *testing.T) {
+	server := newTestServer(t, true)
+	resp, err := server.NodeInfo(context.Background(), &pb.NodeInfoRequest{})
+	assert.NoError(t, err)
+	// The configuration isn't reported unless enabled
+	assert.Nil(t, resp.GetConfig())
+
+	config := makeConfig(t)
+	config.EnableNodeInfoConfig = true
+	config.DisableNodeInfoResources = true
+	config.EnableDispersalAuth = true
+	config.QuorumIDList = []core.QuorumID{0, 2}
+	server = newTestServerWithConfig(t, true, config)
+	resp, err = server.NodeInfo(context.Background(), &pb.NodeInfoRequest{})
+	assert.NoError(t, err)
+	assert.Empty(t, resp.GetOs())
+	assert.Equal(t, node.LevelDBBackend, resp.GetConfig().GetChunkStorageBackend())
+	assert.Greater(t, resp.GetConfig().GetDbSizeBytes(), uint64(0))
+	// The quorums and the features are reported whether or not the configuration is
+	assert.Equal(t, []uint32{0, 2}, resp.GetQuorumIds())
+	assert.Equal(t, []string{node.FeatureDispersalAuth, node.FeatureSRSVerification}, resp.GetFeatures())
+}
+
+// freePort returns a local port which is free at the time of the call
+func freePort(t *testing.T) string {
+	listener, err := net.Listen("tcp", "127.0.0.1:0")
+	assert.NoError(t, err)
+	defer listener.Close()
+	_, port, err := net.SplitHostPort(listener.Addr().String())
+	assert.NoError(t, err)
+	return port
+}
+
+func TestNodeInfoOnBothServices(t *testing.T) {
+	config := makeConfig(t)
+	config.InternalDispersalPort = freePort(t)
+	config.InternalRetrievalPort = freePort(t)
+	config.QuorumIDList = []core.QuorumID{0, 1}
+	server := newTestServerWithConfig(t, true, config)
+	server.Start()
+
+	dial := func(port string) *googlegrpc.ClientConn {
+		conn, err := googlegrpc.Dial("127.0.0.1:"+port, googlegrpc.WithTransportCredentials(insecure.NewCredentials()))
+		assert.NoError(t, err)
+		t.Cleanup(func() { _ = conn.Close() })
+		return conn
+	}
+	var dispersalReply, retrievalReply *pb.NodeInfoReply
+	assert.Eventually(t, func() bool {
+		var err error
+		dispersalReply, err = pb.NewDispersalClient(dial(config.InternalDispersalPort)).NodeInfo(context.Background(), &pb.NodeInfoRequest{})
+		if err != nil {
+			return false
+		}
+		retrievalReply, err = pb.NewRetrievalClient(dial(config.InternalRetrievalPort)).NodeInfo(context.Background(), &pb.NodeInfoRequest{})
+		return err == nil
+	}, 5*time.Second, 50*time.Millisecond)
+
+	assert.Equal(t, []uint32{0, 1}, dispersalReply.GetQuorumIds())
+	assert.NotEmpty(t, dispersalReply.GetFeatures())
+	// Both services reply the same node info, but for the time of the reply
+	dispersalReply.UnixTimeMs = 0
+	retrievalReply.UnixTimeMs = 0
+	assert.True(t, proto.Equal(dispersalReply, retrievalReply), "dispersal: %v, retrieval: %v", dispersalReply, retrievalReply)
 }
 
 func TestStoreChunksRequestValidation(t *testing.T) {
@@ -413,6 +508,205 @@ func TestStoreBlobs(t *testing.T) {
 	}
 }
 
+func TestDispersalAuthentication(t *testing.T) {
+	signer, err := auth.NewLocalDispersalRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	assert.NoError(t, err)
+	config := makeConfig(t)
+	config.EnableDispersalAuth = true
+	config.AuthorizedDisperserAddresses = []gethcommon.Address{signer.Address()}
+	config.DispersalAuthMaxClockSkew = time.Minute
+	server := newTestServerWithConfig(t, true, config)
+
+	reqToCopy, _, _, _, _ := makeStoreChunksRequest(t, 66, 33)
+	req := &pb.StoreBlobsRequest{
+		Blobs:                reqToCopy.Blobs,
+		ReferenceBlockNumber: 1,
+	}
+
+	// Unsigned request
+	_, err = server.StoreBlobs(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	signedContext := func(digest [32]byte) context.Context {
+		ctx, err := auth.AppendDispersalSignatureToOutgoingContext(context.Background(), signer, digest)
+		assert.NoError(t, err)
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+
+	blobHeaderHashes := make([][32]byte, len(req.Blobs))
+	for i, blob := range req.Blobs {
+		blobHeader, err := conversion.BlobHeaderFromProto(blob.GetHeader())
+		assert.NoError(t, err)
+		blobHeaderHashes[i], err = blobHeader.GetBlobHeaderHash()
+		assert.NoError(t, err)
+	}
+
+	// Signature for another reference block number
+	_, err = server.StoreBlobs(signedContext(auth.StoreBlobsRequestDigest(2, blobHeaderHashes)), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// Signature for other blobs
+	_, err = server.StoreBlobs(signedContext(auth.StoreBlobsRequestDigest(1, blobHeaderHashes[:1])), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	reply, err := server.StoreBlobs(signedContext(auth.StoreBlobsRequestDigest(1, blobHeaderHashes)), req)
+	assert.NoError(t, err)
+	assert.Len(t, reply.GetSignatures(), len(req.Blobs))
+
+	// StoreChunks is authenticated against the batch header hash
+	chunksReq, batchHeaderHash, _, _, _ := makeStoreChunksRequest(t, 66, 33)
+	_, err = server.StoreChunks(context.Background(), chunksReq)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+	chunksReply, err := server.StoreChunks(signedContext(batchHeaderHash), chunksReq)
+	assert.NoError(t, err)
+	assert.NotNil(t, chunksReply.GetSignature())
+}
+
+func TestRejectedBatchRecording(t *testing.T) {
+	signer, err := auth.NewLocalDispersalRequestSigner("0x0123456789abcdef0123456789abcdef0123456789abcdef0123456789abcdef")
+	assert.NoError(t, err)
+	config := makeConfig(t)
+	config.EnableDispersalAuth = true
+	config.AuthorizedDisperserAddresses = []gethcommon.Address{signer.Address()}
+	config.DispersalAuthMaxClockSkew = time.Minute
+	config.RejectedBatchRecordDir = t.TempDir()
+	config.RejectedBatchRecordMaxFiles = 1
+	server := newTestServerWithConfig(t, false, config)
+
+	signedContext := func(digest [32]byte) context.Context {
+		ctx, err := auth.AppendDispersalSignatureToOutgoingContext(context.Background(), signer, digest)
+		assert.NoError(t, err)
+		md, _ := metadata.FromOutgoingContext(ctx)
+		return metadata.NewIncomingContext(context.Background(), md)
+	}
+	recorded := func() []string {
+		entries, err := os.ReadDir(config.RejectedBatchRecordDir)
+		assert.NoError(t, err)
+		names := make([]string, 0, len(entries))
+		for _, entry := range entries {
+			names = append(names, entry.Name())
+		}
+		return names
+	}
+
+	// The unauthenticated requests aren't recorded
+	req, batchHeaderHash, _, _, _ := makeStoreChunksRequest(t, 66, 33)
+	_, err = server.StoreChunks(context.Background(), req)
+	assert.Equal(t, codes.Unauthenticated, status.Code(err))
+
+	// The requests which fail the validation are recorded, and the oldest record is evicted beyond the maximum
+	_, err = server.StoreChunks(signedContext(batchHeaderHash), req)
+	assert.Error(t, err)
+	assert.Eventually(t, func() bool {
+		names := recorded()
+		return len(names) == 1 && names[0] == hex.EncodeToString(batchHeaderHash[:])+".pb"
+	}, 5*time.Second, 10*time.Millisecond)
+
+	req, batchHeaderHash, _, _, _ = makeStoreChunksRequest(t, 67, 33)
+	_, err = server.StoreChunks(signedContext(batchHeaderHash), req)
+	assert.Error(t, err)
+	assert.Eventually(t, func() bool {
+		names := recorded()
+		return len(names) == 1 && names[0] == hex.EncodeToString(batchHeaderHash[:])+".pb"
+	}, 5*time.Second, 10*time.Millisecond)
+}
+
+func TestAdmissionControl(t *testing.T) {
+	// The chunks are assigned to an operator of the quorums of the chain state
+	state, err := chainState.GetOperatorStateByOperator(context.Background(), 1, opID)
+	assert.NoError(t, err)
+	var operatorID core.OperatorID
+	for id := range state.Operators[0] {
+		operatorID = id
+		break
+	}
+	config := makeConfig(t)
+	config.ID = operatorID
+	config.EnableAdmissionControl = true
+	server := newTestServerWithConfig(t, true, config)
+
+	assignedChunks := func(blobHeaders []*core.BlobHeader) [][]uint {
+		coordinator := &core.StdAssignmentCoordinator{}
+		assigned := make([][]uint, len(blobHeaders))
+		for i, blobHeader := range blobHeaders {
+			for _, quorumInfo := range blobHeader.QuorumInfos {
+				assignment, _, err := coordinator.GetOperatorAssignment(state, blobHeader, quorumInfo.QuorumID, operatorID)
+				assert.NoError(t, err)
+				assigned[i] = append(assigned[i], assignment.NumChunks)
+			}
+		}
+		return assigned
+	}
+	gobBundle := func(numChunks uint, chunk []byte) *pb.Bundle {
+		chunks := make([][]byte, numChunks)
+		for i := range chunks {
+			chunks[i] = chunk
+		}
+		return &pb.Bundle{Chunks: chunks}
+	}
+	gnarkBundle := func(numChunks uint, chunkLength int) *pb.Bundle {
+		bundle := make(core.Bundle, numChunks)
+		for i := range bundle {
+			bundle[i] = &encoding.Frame{Coeffs: make([]fr.Element, chunkLength)}
+		}
+		data, err := bundle.Serialize()
+		assert.NoError(t, err)
+		return &pb.Bundle{Bundle: data}
+	}
+	// The chunks of the quorum headers have length 10
+	gobChunk, err := (&encoding.Frame{Coeffs: make([]fr.Element, 10)}).Serialize()
+	assert.NoError(t, err)
+	admittedRequest := func() (*pb.StoreChunksRequest, [][]uint) {
+		req, _, _, blobHeaders, _ := makeStoreChunksRequest(t, 66, 33)
+		assigned := assignedChunks(blobHeaders)
+		for i, blob := range req.Blobs {
+			for j := range blob.Bundles {
+				blob.Bundles[j] = gobBundle(assigned[i][j], gobChunk)
+			}
+		}
+		return req, assigned
+	}
+
+	req, assigned := admittedRequest()
+	assert.Greater(t, assigned[0][0], uint(0))
+	reply, err := server.StoreChunks(context.Background(), req)
+	assert.NoError(t, err)
+	assert.NotNil(t, reply.GetSignature())
+
+	// A bundle with more chunks than assigned to the operator
+	req, assigned = admittedRequest()
+	req.Blobs[1].Bundles[0] = gobBundle(assigned[1][0]+1, gobChunk)
+	_, err = server.StoreChunks(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "chunks while")
+
+	// A gob chunk larger than the chunk length of the quorum
+	req, assigned = admittedRequest()
+	req.Blobs[0].Bundles[0] = gobBundle(assigned[0][0], make([]byte, 64*1024))
+	_, err = server.StoreChunks(context.Background(), req)
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+
+	// The gnark bundles must have the chunk length of the quorum
+	req, assigned = admittedRequest()
+	for i, blob := range req.Blobs {
+		for j := range blob.Bundles {
+			blob.Bundles[j] = gnarkBundle(assigned[i][j], 10)
+		}
+	}
+	_, err = server.StoreBlobs(context.Background(), &pb.StoreBlobsRequest{Blobs: req.Blobs, ReferenceBlockNumber: 1})
+	assert.NoError(t, err)
+	req.Blobs[0].Bundles[0] = gnarkBundle(assigned[0][0], 16)
+	_, err = server.StoreBlobs(context.Background(), &pb.StoreBlobsRequest{Blobs: req.Blobs, ReferenceBlockNumber: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+	assert.Contains(t, err.Error(), "length 16")
+
+	// A truncated gnark bundle
+	req.Blobs[0].Bundles[0].Bundle = req.Blobs[0].Bundles[0].Bundle[:100]
+	_, err = server.StoreBlobs(context.Background(), &pb.StoreBlobsRequest{Blobs: req.Blobs, ReferenceBlockNumber: 1})
+	assert.Equal(t, codes.InvalidArgument, status.Code(err))
+}
+
 func TestMinibatchDispersalAndRetrieval(t *testing.T) {
 	server := newTestServer(t, true)
 
@@ -466,7 +760,7 @@ func TestMinibatchDispersalAndRetrieval(t *testing.T) {
 	})
 	assert.NoError(t, err)
 	assert.NotNil(t, blobHeaderReply)
-	blobHeader, err := node.GetBlobHeaderFromProto(blobHeaderReply.GetBlobHeader())
+	blobHeader, err := conversion.BlobHeaderFromProto(blobHeaderReply.GetBlobHeader())
 	assert.NoError(t, err)
 	assert.Equal(t, blobHeader, blobHeaders[0])
 	proof := &merkletree.Proof{
@@ -484,7 +778,7 @@ func TestMinibatchDispersalAndRetrieval(t *testing.T) {
 	})
 	assert.NoError(t, err)
 	assert.NotNil(t, blobHeaderReply)
-	blobHeader, err = node.GetBlobHeaderFromProto(blobHeaderReply.GetBlobHeader())
+	blobHeader, err = conversion.BlobHeaderFromProto(blobHeaderReply.GetBlobHeader())
 	assert.NoError(t, err)
 	assert.Equal(t, blobHeader, blobHeaders[1])
 	proof = &merkletree.Proof{
@@ -597,6 +891,51 @@ func TestRetrieveChunks(t *testing.T) {
 	assert.Empty(t, retrievalReply.GetChunks())
 }
 
+func TestRetrieveChunksAccessLog(t *testing.T) {
+	config := makeConfig(t)
+	config.AccessLog = node.AccessLogConfig{Path: filepath.Join(t.TempDir(), "access.log"), MaxSizeMB: 1}
+	server := newTestServerWithConfig(t, true, config)
+	batchHeaderHash, _, _, _ := storeChunks(t, server, false)
+
+	p := &peer.Peer{
+		Addr: &net.TCPAddr{
+			IP:   net.ParseIP("10.0.0.1"),
+			Port: 3000,
+		},
+	}
+	ctx := peer.NewContext(context.Background(), p)
+	retrievalReply, err := server.RetrieveChunks(ctx, &pb.RetrieveChunksRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       0,
+		QuorumId:        0,
+	})
+	assert.NoError(t, err)
+	_, err = server.RetrieveChunks(ctx, &pb.RetrieveChunksRequest{
+		BatchHeaderHash: batchHeaderHash[:],
+		BlobIndex:       5,
+		QuorumId:        0,
+	})
+	assert.Error(t, err)
+
+	data, err := os.ReadFile(config.AccessLog.Path)
+	assert.NoError(t, err)
+	lines := strings.Split(strings.TrimSpace(string(data)), "\n")
+	assert.Len(t, lines, 2)
+
+	var entry node.AccessLogEntry
+	assert.NoError(t, json.Unmarshal([]byte(lines[0]), &entry))
+	assert.Equal(t, "10.0.0.1", entry.Requester)
+	assert.Equal(t, "RetrieveChunks", entry.Method)
+	assert.Equal(t, hex.EncodeToString(batchHeaderHash[:]), entry.BatchHeaderHash)
+	assert.Equal(t, "success", entry.Status)
+	assert.Equal(t, len(retrievalReply.GetChunks()[0]), entry.BytesServed)
+
+	assert.NoError(t, json.Unmarshal([]byte(lines[1]), &entry))
+	assert.Equal(t, uint32(5), entry.BlobIndex)
+	assert.Equal(t, "failure", entry.Status)
+	assert.Zero(t, entry.BytesServed)
+}
+
 func TestGnarkBundleEncoding(t *testing.T) {
 	config := makeConfig(t)
 	config.EnableGnarkBundleEncoding = true
@@ -39,8 +39,14 @@ type Metrics struct {
 	AccuRemovedBatches *prometheus.CounterVec
 	// Accumulated number and size of blobs that have been removed from the Node.
 	AccuRemovedBlobs *prometheus.CounterVec
+	// Accumulated size of the chunks removed from the Node by the expiration.
+	AccuReclaimedBytes prometheus.Counter
+	// Size of the chunks of the expired batches and blobs found by the last expiration cycle in dry-run mode.
+	ReclaimableBytes prometheus.Gauge
 	// Accumulated number and size of blobs processed by quorums.
 	AccuBlobs *prometheus.CounterVec
+	// Accumulated number of dispersal requests rejected by the admission control, by their reasons.
+	AccuRejectedDispersals *prometheus.CounterVec
 	// Total number of changes in the node's socket address.
 	AccuSocketUpdates prometheus.Counter
 	// avs node spec eigen_ metrics: https://eigen.nethermind.io/docs/spec/metrics/metrics-prom-spec
@@ -49,6 +55,9 @@ type Metrics struct {
 	ReachabilityGauge *prometheus.GaugeVec
 	// The throughput (bytes per second) at which the data is written to database.
 	DBWriteThroughput prometheus.Gauge
+	// The latency (in ms) to encrypt the chunks at rest as they are stored, and to decrypt them as they are served.
+	// It is only observed if the storage encryption is enabled.
+	ChunkEncryptionLatency *prometheus.SummaryVec
 
 	registry *prometheus.Registry
 	// socketAddr is the address at which the metrics server will be listening.
@@ -136,6 +145,29 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 			},
 			[]string{"type"},
 		),
+		AccuReclaimedBytes: promauto.With(reg).NewCounter(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_expiration_reclaimed_bytes_total",
+				Help:      "the total size of the chunks removed by the expiration of the batches and blobs",
+			},
+		),
+		ReclaimableBytes: promauto.With(reg).NewGauge(
+			prometheus.GaugeOpts{
+				Namespace: Namespace,
+				Name:      "expiration_reclaimable_bytes",
+				Help:      "the size of the chunks of the expired batches and blobs which are kept since the expiration runs in dry-run mode",
+			},
+		),
+		// The "reason" label has values: chunk_count, chunk_length, malformed.
+		AccuRejectedDispersals: promauto.With(reg).NewCounterVec(
+			prometheus.CounterOpts{
+				Namespace: Namespace,
+				Name:      "eigenda_admission_rejected_requests_total",
+				Help:      "the total number of dispersal requests rejected by the admission control",
+			},
+			[]string{"method", "reason"},
+		),
 		AccuSocketUpdates: promauto.With(reg).NewCounter(
 			prometheus.CounterOpts{
 				Namespace: Namespace,
@@ -158,6 +190,16 @@ func NewMetrics(eigenMetrics eigenmetrics.Metrics, reg *prometheus.Registry, log
 				Help:      "the throughput (bytes per second) at which the data is written to database",
 			},
 		),
+		// The "operation" label has values: encrypt, decrypt.
+		ChunkEncryptionLatency: promauto.With(reg).NewSummaryVec(
+			prometheus.SummaryOpts{
+				Namespace:  Namespace,
+				Name:       "chunk_encryption_latency_ms",
+				Help:       "latency summary in milliseconds of the encryption of the chunks at rest, and of their decryption when they are served",
+				Objectives: map[float64]float64{0.5: 0.05, 0.9: 0.01, 0.95: 0.01, 0.99: 0.001},
+			},
+			[]string{"operation"},
+		),
 
 		EigenMetrics:           eigenMetrics,
 		logger:                 logger.With("component", "NodeMetrics"),
@@ -186,6 +228,10 @@ func (g *Metrics) RecordRPCRequest(method string, status string, duration time.D
 	g.ObserveLatency(method, "total", float64(duration.Milliseconds()))
 }
 
+func (g *Metrics) RecordRejectedDispersal(method string, reason string) {
+	g.AccuRejectedDispersals.WithLabelValues(method, reason).Inc()
+}
+
 func (g *Metrics) RecordSocketAddressChange() {
 	g.AccuSocketUpdates.Inc()
 }
@@ -194,11 +240,16 @@ func (g *Metrics) ObserveLatency(method, stage string, latencyMs float64) {
 	g.RequestLatency.WithLabelValues(method, stage).Observe(latencyMs)
 }
 
+func (g *Metrics) ObserveChunkEncryptionLatency(operation string, latency time.Duration) {
+	g.ChunkEncryptionLatency.WithLabelValues(operation).Observe(float64(latency.Microseconds()) / 1000)
+}
+
 func (g *Metrics) RemoveNCurrentBatch(numBatches int, totalBatchSize int64) {
 	for i := 0; i < numBatches; i++ {
 		g.AccuRemovedBatches.WithLabelValues("number").Inc()
 	}
 	g.AccuRemovedBatches.WithLabelValues("size").Add(float64(totalBatchSize))
+	g.AccuReclaimedBytes.Add(float64(totalBatchSize))
 }
 
 func (g *Metrics) RemoveNBlobs(numBlobs int, totalSize int64) {
@@ -206,6 +257,7 @@ func (g *Metrics) RemoveNBlobs(numBlobs int, totalSize int64) {
 		g.AccuRemovedBlobs.WithLabelValues("number").Inc()
 	}
 	g.AccuRemovedBatches.WithLabelValues("size").Add(float64(totalSize))
+	g.AccuReclaimedBytes.Add(float64(totalSize))
 }
 
 func (g *Metrics) AcceptBlobs(quorumId core.QuorumID, blobSize uint64) {
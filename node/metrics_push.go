@@ -0,0 +1,72 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/push"
+	"github.com/prometheus/common/model"
+)
+
+// MetricsPushConfig configures the push of the node metrics to a Prometheus push gateway, for the operators who can't
+// expose the metrics port to be scraped
+type MetricsPushConfig struct {
+	// GatewayURL is the URL of the push gateway. The metrics aren't pushed if it is empty.
+	GatewayURL string
+	Interval   time.Duration
+	// Labels are the grouping labels of the pushed metrics, in addition to the operator ID
+	Labels map[string]string
+}
+
+// ParseMetricsPushLabels parses the grouping labels of the pushed metrics given as key=value pairs
+func ParseMetricsPushLabels(pairs []string) (map[string]string, error) {
+	labels := make(map[string]string, len(pairs))
+	for _, pair := range pairs {
+		key, value, ok := strings.Cut(pair, "=")
+		if !ok || value == "" {
+			return nil, fmt.Errorf("invalid metrics push label %q: expected key=value", pair)
+		}
+		if !model.LabelName(key).IsValid() || key == "job" || key == "operator_id" {
+			return nil, fmt.Errorf("invalid metrics push label name %q", key)
+		}
+		labels[key] = value
+	}
+	return labels, nil
+}
+
+// newMetricsPusher returns the pusher of the metrics gathered by the gatherer, grouped by operator ID and by labels
+func newMetricsPusher(config MetricsPushConfig, gatherer prometheus.Gatherer, operatorId string) *push.Pusher {
+	pusher := push.New(config.GatewayURL, AppName).
+		Gatherer(gatherer).
+		Grouping("operator_id", operatorId)
+	for key, value := range config.Labels {
+		pusher = pusher.Grouping(key, value)
+	}
+	return pusher
+}
+
+// StartPushing pushes the metrics to the push gateway at the configured interval until the context is done. Each push
+// replaces the metrics of the previous one, and failed pushes are retried at the next interval.
+func (g *Metrics) StartPushing(ctx context.Context, config MetricsPushConfig) {
+	pusher := newMetricsPusher(config, g.registry, g.operatorId.Hex())
+	go pushMetrics(ctx, pusher, config.Interval, g.logger.With("component", "MetricsPusher"))
+}
+
+func pushMetrics(ctx context.Context, pusher *push.Pusher, interval time.Duration, logger logging.Logger) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		if err := pusher.PushContext(ctx); err != nil && ctx.Err() == nil {
+			logger.Warn("failed to push metrics", "err", err)
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
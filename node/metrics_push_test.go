@@ -0,0 +1,64 @@
+package node_test
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestParseMetricsPushLabels(t *testing.T) {
+	labels, err := node.ParseMetricsPushLabels([]string{"region=eu-west", "instance=node-1"})
+	assert.NoError(t, err)
+	assert.Equal(t, map[string]string{"region": "eu-west", "instance": "node-1"}, labels)
+
+	for _, pair := range []string{"region", "region=", "=eu-west", "not-a-label=x", "job=node", "operator_id=0x01"} {
+		_, err := node.ParseMetricsPushLabels([]string{pair})
+		assert.Error(t, err, pair)
+	}
+}
+
+func TestMetricsPush(t *testing.T) {
+	paths := make(chan string, 10)
+	gateway := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		paths <- r.Method + " " + r.URL.Path
+		w.WriteHeader(http.StatusOK)
+	}))
+	defer gateway.Close()
+
+	logger := logging.NewNoopLogger()
+	dat, err := coremock.MakeChainDataMock(map[uint8]int{0: 1})
+	assert.NoError(t, err)
+	m := node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), logger, ":9090", core.OperatorID{1}, -1, &coremock.MockTransactor{}, dat)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+	m.StartPushing(ctx, node.MetricsPushConfig{
+		GatewayURL: gateway.URL,
+		Interval:   10 * time.Millisecond,
+		Labels:     map[string]string{"region": "eu-west"},
+	})
+
+	operatorId := core.OperatorID{1}
+	for i := 0; i < 2; i++ {
+		select {
+		case path := <-paths:
+			// The order of the grouping labels in the path isn't deterministic
+			assert.True(t, strings.HasPrefix(path, "PUT /metrics/job/da-node/"), path)
+			assert.Contains(t, path, "/operator_id/"+operatorId.Hex())
+			assert.Contains(t, path, "/region/eu-west")
+		case <-time.After(5 * time.Second):
+			t.Fatal("metrics weren't pushed")
+		}
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"net/url"
 	"os"
 	"reflect"
+	"runtime"
 	"strings"
 	"sync"
 	"time"
@@ -26,11 +27,13 @@ import (
 	"github.com/wealdtech/go-merkletree/v2/keccak256"
 	"google.golang.org/protobuf/proto"
 
+	"github.com/Layr-Labs/eigenda/api/conversion"
 	"github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/indexer"
+	"github.com/Layr-Labs/eigenda/node/flags"
 	"github.com/Layr-Labs/eigensdk-go/logging"
 	"github.com/Layr-Labs/eigensdk-go/metrics"
 	rpccalls "github.com/Layr-Labs/eigensdk-go/metrics/collectors/rpc_calls"
@@ -42,6 +45,8 @@ import (
 const (
 	// The percentage of time in garbage collection in a GC cycle.
 	gcPercentageTime = 0.1
+	// srsServiceId is the id of the SRS verification in the services of the node api
+	srsServiceId = "srs"
 )
 
 var (
@@ -65,9 +70,22 @@ type Node struct {
 	PubIPProvider           pubip.Provider
 	OperatorSocketsFilterer indexer.OperatorSocketsFilterer
 	ChainID                 *big.Int
+	// SRSPreloader is nil if the SRS verification is disabled
+	SRSPreloader *SRSPreloader
+	// AccessLogger is nil if the access log of the retrieval API is disabled
+	AccessLogger *AccessLogger
+	// CertReloader is nil if the servers are plaintext
+	CertReloader *CertReloader
+	// AdmissionController is nil if the admission control of the dispersal requests is disabled
+	AdmissionController *AdmissionController
 
 	mu            sync.Mutex
 	CurrentSocket string
+
+	// validationPool is shared by the validations of the batches, so that the verification of the chunks of
+	// concurrent batches is bounded by the workers of the node
+	validationPool     *workerpool.WorkerPool
+	validationPoolOnce sync.Once
 }
 
 // NewNode creates a new Node with the provided config.
@@ -152,6 +170,44 @@ func NewNode(reg *prometheus.Registry, config *Config, pubIPProvider pubip.Provi
 	if err != nil {
 		return nil, fmt.Errorf("failed to create new store: %w", err)
 	}
+	if config.StorageEncryption.KeySecretName != "" {
+		key, err := ReadStorageEncryptionKey(context.Background(), config.StorageEncryption)
+		if err != nil {
+			return nil, err
+		}
+		if err := store.SetEncryptionKey(key); err != nil {
+			return nil, fmt.Errorf("failed to enable storage encryption: %w", err)
+		}
+		logger.Info("Enabled the encryption of chunks at rest", "keySecretName", config.StorageEncryption.KeySecretName)
+	}
+
+	var srsPreloader *SRSPreloader
+	if !config.DisableSRSVerification {
+		srsFiles := SRSFiles(&config.EncoderConfig, config.G1Digest, config.G2Digest, config.G2PowerOf2Digest)
+		srsPreloader = NewSRSPreloader(srsFiles, logger)
+	}
+
+	accessLogger, err := NewAccessLogger(config.AccessLog)
+	if err != nil {
+		return nil, err
+	}
+	if accessLogger != nil {
+		logger.Info("Enabled the access log of the retrieval API", "path", config.AccessLog.Path, "anonymize", config.AccessLog.Anonymize)
+	}
+
+	certReloader, err := NewCertReloader(config.TLS, logger)
+	if err != nil {
+		return nil, err
+	}
+	if certReloader != nil {
+		logger.Info("Enabled TLS on the dispersal and retrieval servers", "certFile", config.TLS.CertFile, "reloadInterval", config.TLS.ReloadInterval)
+	}
+
+	var admissionController *AdmissionController
+	if config.EnableAdmissionControl {
+		admissionController = NewAdmissionController(cst, asgn)
+		logger.Info("Enabled the admission control of the dispersal requests")
+	}
 
 	eigenDAServiceManagerAddr := gethcommon.HexToAddress(config.EigenDAServiceManagerAddr)
 	socketsFilterer, err := indexer.NewOperatorSocketsFilterer(eigenDAServiceManagerAddr, client)
@@ -177,6 +233,10 @@ func NewNode(reg *prometheus.Registry, config *Config, pubIPProvider pubip.Provi
 		PubIPProvider:           pubIPProvider,
 		OperatorSocketsFilterer: socketsFilterer,
 		ChainID:                 chainID,
+		SRSPreloader:            srsPreloader,
+		AccessLogger:            accessLogger,
+		CertReloader:            certReloader,
+		AdmissionController:     admissionController,
 	}, nil
 }
 
@@ -186,6 +246,10 @@ func (n *Node) Start(ctx context.Context) error {
 	if n.Config.EnableMetrics {
 		n.Metrics.Start()
 		n.Logger.Info("Enabled metrics", "socket", n.Metrics.socketAddr)
+		if n.Config.MetricsPushConfig.GatewayURL != "" {
+			n.Metrics.StartPushing(ctx, n.Config.MetricsPushConfig)
+			n.Logger.Info("Pushing metrics", "gateway", n.Config.MetricsPushConfig.GatewayURL, "interval", n.Config.MetricsPushConfig.Interval)
+		}
 	}
 	if n.Config.EnableNodeApi {
 		n.NodeApi.Start()
@@ -194,6 +258,12 @@ func (n *Node) Start(ctx context.Context) error {
 
 	go n.expireLoop()
 	go n.checkNodeReachability()
+	if n.SRSPreloader != nil {
+		go n.preloadSRS(ctx)
+	}
+	if n.CertReloader != nil && n.Config.TLS.ReloadInterval > 0 {
+		go n.CertReloader.Watch(ctx, n.Config.TLS.ReloadInterval)
+	}
 
 	// Build the socket based on the hostname/IP provided in the CLI
 	socket := string(core.MakeOperatorSocket(n.Config.Hostname, n.Config.DispersalPort, n.Config.RetrievalPort))
@@ -251,10 +321,40 @@ func (n *Node) Start(ctx context.Context) error {
 	return nil
 }
 
+// preloadSRS verifies the SRS files, reporting the node as initializing on the node api until it is done
+func (n *Node) preloadSRS(ctx context.Context) {
+	if n.Config.EnableNodeApi {
+		n.NodeApi.RegisterNewService(srsServiceId, "SRS", "Verification of the SRS files, until which the node doesn't attest to batches", nodeapi.ServiceStatusInitializing)
+		n.NodeApi.UpdateHealth(nodeapi.PartiallyHealthy)
+	}
+	err := n.SRSPreloader.Run(ctx)
+	if !n.Config.EnableNodeApi {
+		return
+	}
+	if err != nil {
+		_ = n.NodeApi.UpdateServiceStatus(srsServiceId, nodeapi.ServiceStatusDown)
+		n.NodeApi.UpdateHealth(nodeapi.Unhealthy)
+		return
+	}
+	_ = n.NodeApi.UpdateServiceStatus(srsServiceId, nodeapi.ServiceStatusUp)
+	n.NodeApi.UpdateHealth(nodeapi.Healthy)
+}
+
+// SRSReady returns nil if the node can attest to batches, i.e. the SRS files are verified or their verification is
+// disabled
+func (n *Node) SRSReady() error {
+	if n.SRSPreloader == nil {
+		return nil
+	}
+	return n.SRSPreloader.Ready()
+}
+
 // The expireLoop is a loop that is run once per configured second(s) while the node
 // is running. It scans for expired batches and removes them from the local database.
+// The batches are only removed once they have been expired for the configured safety margin,
+// and they are only reported, not removed, in dry-run mode.
 func (n *Node) expireLoop() {
-	n.Logger.Info("Start expireLoop goroutine in background to periodically remove expired batches on the node")
+	n.Logger.Info("Start expireLoop goroutine in background to periodically remove expired batches on the node", "safety margin", n.Config.ExpirationSafetyMargin, "dry run", n.Config.ExpirationDryRun, "compaction", n.Config.ExpirationCompaction)
 	ticker := time.NewTicker(time.Duration(n.Config.ExpirationPollIntervalSec) * time.Second)
 	defer ticker.Stop()
 
@@ -266,7 +366,13 @@ func (n *Node) expireLoop() {
 		// The heuristic is to cap the GC time to a percentage of the poll interval, but at
 		// least have 1 second.
 		timeLimitSec := uint64(math.Max(float64(n.Config.ExpirationPollIntervalSec)*gcPercentageTime, 1.0))
-		numBatchesDeleted, numMappingsDeleted, numBlobsDeleted, err := n.Store.DeleteExpiredEntries(time.Now().Unix(), timeLimitSec)
+		expirationTime := time.Now().Add(-n.Config.ExpirationSafetyMargin).Unix()
+		if n.Config.ExpirationDryRun {
+			n.scanExpiredEntries(expirationTime, timeLimitSec)
+			continue
+		}
+
+		numBatchesDeleted, numMappingsDeleted, numBlobsDeleted, err := n.Store.DeleteExpiredEntries(expirationTime, timeLimitSec)
 		n.Logger.Info("Complete an expiration cycle to remove expired batches", "num expired batches found and removed", numBatchesDeleted, "num expired mappings found and removed", numMappingsDeleted, "num expired blobs found and removed", numBlobsDeleted)
 		if err != nil {
 			if errors.Is(err, context.DeadlineExceeded) {
@@ -275,9 +381,33 @@ func (n *Node) expireLoop() {
 				n.Logger.Error("Expiration cycle encountered error when removing expired batches, which will be retried in next cycle", "err", err)
 			}
 		}
+
+		if n.Config.ExpirationCompaction && numBatchesDeleted+numMappingsDeleted+numBlobsDeleted > 0 {
+			start := time.Now()
+			if err := n.Store.Compact(); err != nil {
+				n.Logger.Error("Failed to compact the database after the expiration cycle", "err", err)
+			} else {
+				n.Logger.Info("Compacted the database after the expiration cycle", "duration", time.Since(start))
+			}
+		}
 	}
 }
 
+// scanExpiredEntries reports the expired batches and blobs which the expiration would remove, without removing them.
+func (n *Node) scanExpiredEntries(expirationTime int64, timeLimitSec uint64) {
+	expired, err := n.Store.ScanExpiredEntries(expirationTime, timeLimitSec)
+	if err != nil {
+		if errors.Is(err, context.DeadlineExceeded) {
+			n.Logger.Warn("Expiration dry run exited with ContextDeadlineExceed, so the expired entries are only partially reported", "time limit (sec)", timeLimitSec)
+		} else {
+			n.Logger.Error("Expiration dry run encountered error when scanning expired batches", "err", err)
+			return
+		}
+	}
+	n.Logger.Info("Complete an expiration dry run, nothing was removed", "num expired batches found", expired.NumBatches, "num expired blobs found", expired.NumBlobs, "reclaimable chunk bytes", expired.ChunkBytes)
+	n.Metrics.ReclaimableBytes.Set(float64(expired.ChunkBytes))
+}
+
 // ProcessBatch validates the batch is correct, stores data into the node's Store, and then returns a signature for the entire batch.
 //
 // The batch will be itemized into batch header, header and chunks of each blob in the batch. These items will
@@ -287,21 +417,21 @@ func (n *Node) expireLoop() {
 //   - If the batch is stored already, it's no-op to store it more than once
 //   - If the batch is stored, but the processing fails after that, these data items will not be rollback
 //   - These data items will be garbage collected eventually when they become stale.
-func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob) (*core.Signature, error) {
+func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage, rawBlobs []*node.Blob) (*core.Signature, core.BlobExclusionBitmap, error) {
 	start := time.Now()
 	log := n.Logger
 
 	batchHeaderHash, err := header.GetBatchHeaderHash()
 	if err != nil {
-		return nil, err
+		return nil, nil, err
 	}
 
 	if len(blobs) == 0 {
-		return nil, errors.New("number of blobs must be greater than zero")
+		return nil, nil, errors.New("number of blobs must be greater than zero")
 	}
 
 	if len(blobs) != len(rawBlobs) {
-		return nil, errors.New("number of parsed blobs must be the same as number of blobs from protobuf request")
+		return nil, nil, errors.New("number of parsed blobs must be the same as number of blobs from protobuf request")
 	}
 
 	// Measure num batches received and its size in bytes
@@ -353,7 +483,7 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 
 	// Validate batch.
 	stageTimer := time.Now()
-	err = n.ValidateBatch(ctx, header, blobs)
+	excludedBlobs, err := n.validateBatchExcludingInvalidBlobs(ctx, header, blobs)
 	if err != nil {
 		// If we have already stored the batch into database, but it's not valid, we
 		// revert all the keys for that batch.
@@ -364,7 +494,7 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 				log.Error("Failed to delete the invalid batch that should be rolled back", "batchHeaderHash", batchHeaderHashHex, "err", deleteKeysErr)
 			}
 		}
-		return nil, err
+		return nil, nil, err
 	}
 	n.Metrics.RecordStoreChunksStage("validated", batchSize, time.Since(stageTimer))
 	log.Debug("Validate batch took", "duration:", time.Since(stageTimer))
@@ -373,7 +503,7 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 	result := <-storeChan
 	if result.err != nil {
 		log.Error("Store batch failed", "batchHeaderHash", batchHeaderHashHex, "err", result.err)
-		return nil, err
+		return nil, nil, err
 	}
 	if result.keys != nil {
 		n.Metrics.RecordStoreChunksStage("stored", batchSize, result.latency)
@@ -382,14 +512,25 @@ func (n *Node) ProcessBatch(ctx context.Context, header *core.BatchHeader, blobs
 		n.Logger.Warn("Store batch skipped because the batch already exists in the store", "batchHeaderHash", batchHeaderHashHex)
 	}
 
+	if !excludedBlobs.IsEmpty() {
+		excludedIndices := excludedBlobs.ExcludedBlobIndices()
+		log.Warn("Excluding blobs that failed validation from the batch attestation", "batchHeaderHash", batchHeaderHashHex, "excludedBlobIndices", fmt.Sprint(excludedIndices))
+		// The chunks of the excluded blobs must not be served
+		if err := n.deleteBlobChunks(ctx, batchHeaderHash, blobs, excludedIndices); err != nil {
+			log.Error("Failed to delete the chunks of the excluded blobs", "batchHeaderHash", batchHeaderHashHex, "err", err)
+			return nil, nil, err
+		}
+	}
+
 	// Sign batch header hash if all validation checks pass and data items are written to database.
+	// If some blobs were excluded, the signature commits to the excluded blobs.
 	stageTimer = time.Now()
-	sig := n.KeyPair.SignMessage(batchHeaderHash)
+	sig := n.KeyPair.SignMessage(core.GetAttestationMessage(batchHeaderHash, excludedBlobs))
 	n.Metrics.RecordStoreChunksStage("signed", batchSize, time.Since(stageTimer))
 	log.Debug("Sign batch succeeded", "pubkey", hexutil.Encode(n.KeyPair.GetPubKeyG2().Serialize()), "duration", time.Since(stageTimer))
 
 	log.Debug("Exiting process batch", "duration", time.Since(start))
-	return sig, nil
+	return sig, excludedBlobs, nil
 }
 
 // ProcessBlobs validates the blobs are correct, stores data into the node's Store, and then returns a signature for each blob.
@@ -520,8 +661,7 @@ func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blob
 	}
 	getStateDuration := time.Since(start)
 
-	pool := workerpool.New(n.Config.NumBatchValidators)
-	err = n.Validator.ValidateBatch(header, blobs, operatorState, pool)
+	err = n.Validator.ValidateBatch(header, blobs, operatorState, n.batchValidationPool())
 	if err != nil {
 		h, hashErr := operatorState.Hash()
 		if hashErr != nil {
@@ -538,6 +678,86 @@ func (n *Node) ValidateBatch(ctx context.Context, header *core.BatchHeader, blob
 	return nil
 }
 
+// batchValidationPool returns the pool of the workers verifying the chunks of the batches, of NumBatchValidators
+// workers
+func (n *Node) batchValidationPool() *workerpool.WorkerPool {
+	n.validationPoolOnce.Do(func() {
+		numWorkers := n.Config.NumBatchValidators
+		if numWorkers <= 0 {
+			numWorkers = runtime.NumCPU()
+		}
+		n.validationPool = workerpool.New(numWorkers)
+	})
+	return n.validationPool
+}
+
+// validateBatchExcludingInvalidBlobs validates the batch. If partial batch signing is enabled and the batch is
+// invalid, the blobs are validated one by one and the invalid ones are returned as excluded instead of failing,
+// as long as the batch header matches the blobs and at least one blob is valid.
+func (n *Node) validateBatchExcludingInvalidBlobs(ctx context.Context, header *core.BatchHeader, blobs []*core.BlobMessage) (core.BlobExclusionBitmap, error) {
+	batchErr := n.ValidateBatch(ctx, header, blobs)
+	if batchErr == nil || !n.Config.EnablePartialBatchSigning {
+		return nil, batchErr
+	}
+
+	blobHeaders := make([]*core.BlobHeader, len(blobs))
+	for i, blob := range blobs {
+		blobHeaders[i] = blob.BlobHeader
+	}
+	if err := core.ValidateBatchHeaderRoot(header, blobHeaders); err != nil {
+		return nil, batchErr
+	}
+
+	operatorState, err := n.ChainState.GetOperatorStateByOperator(ctx, header.ReferenceBlockNumber, n.Config.ID)
+	if err != nil {
+		return nil, batchErr
+	}
+
+	// The blobs are validated concurrently, their verifications sharing the workers of the validation pool
+	pool := n.batchValidationPool()
+	blobErrs := make([]error, len(blobs))
+	var wg sync.WaitGroup
+	for i, blob := range blobs {
+		wg.Add(1)
+		go func(i int, blob *core.BlobMessage) {
+			defer wg.Done()
+			blobErrs[i] = n.Validator.ValidateBlobs([]*core.BlobMessage{blob}, operatorState, pool)
+		}(i, blob)
+	}
+	wg.Wait()
+
+	excluded := core.NewBlobExclusionBitmap(len(blobs))
+	numExcluded := 0
+	for i, err := range blobErrs {
+		if err != nil {
+			n.Logger.Warn("Blob failed validation", "blobIndex", i, "err", err)
+			excluded.Exclude(i)
+			numExcluded++
+		}
+	}
+
+	// Either the failure cannot be attributed to individual blobs, or there is nothing left to sign
+	if numExcluded == 0 || numExcluded == len(blobs) {
+		return nil, batchErr
+	}
+	return excluded, nil
+}
+
+// deleteBlobChunks deletes the chunks stored for the blobs at the given indices of a batch
+func (n *Node) deleteBlobChunks(ctx context.Context, batchHeaderHash [32]byte, blobs []*core.BlobMessage, blobIndices []int) error {
+	keys := make([][]byte, 0)
+	for _, blobIndex := range blobIndices {
+		for quorumID := range blobs[blobIndex].Bundles {
+			key, err := EncodeBlobKey(batchHeaderHash, blobIndex, quorumID)
+			if err != nil {
+				return err
+			}
+			keys = append(keys, key)
+		}
+	}
+	return n.Store.DeleteKeys(ctx, &keys)
+}
+
 // ValidateBlobs validates the blob commitments are correct
 func (n *Node) ValidateBlobs(ctx context.Context, blobs []*core.BlobMessage, referenceBlockNumber uint) error {
 	start := time.Now()
@@ -547,8 +767,7 @@ func (n *Node) ValidateBlobs(ctx context.Context, blobs []*core.BlobMessage, ref
 	}
 	getStateDuration := time.Since(start)
 
-	pool := workerpool.New(n.Config.NumBatchValidators)
-	err = n.Validator.ValidateBlobs(blobs, operatorState, pool)
+	err = n.Validator.ValidateBlobs(blobs, operatorState, n.batchValidationPool())
 	if err != nil {
 		h, hashErr := operatorState.Hash()
 		if hashErr != nil {
@@ -616,7 +835,7 @@ func (n *Node) ValidateBatchContents(ctx context.Context, blobHeaderHashes [][32
 			return errors.New("blob headers have different reference block numbers")
 		}
 
-		blobHeader, err := GetBlobHeaderFromProto(&protoBlobHeader)
+		blobHeader, err := conversion.BlobHeaderFromProto(&protoBlobHeader)
 		if err != nil {
 			return fmt.Errorf("failed to get blob header from proto: %w", err)
 		}
@@ -645,20 +864,78 @@ func (n *Node) ValidateBatchContents(ctx context.Context, blobHeaderHashes [][32
 }
 
 func (n *Node) updateSocketAddress(ctx context.Context, newSocketAddr string) {
+	if err := n.UpdateSocket(ctx, newSocketAddr); err != nil {
+		n.Logger.Error("failed to update operator's socket", "err", err)
+	}
+}
+
+// UpdateSocket registers the socket onchain if it differs from the current socket of the node, so that the
+// dispersers and the retrievers reach the node at its new address
+func (n *Node) UpdateSocket(ctx context.Context, newSocketAddr string) error {
+	if err := core.ValidateOperatorSocket(newSocketAddr); err != nil {
+		return fmt.Errorf("invalid operator socket %s: %w", newSocketAddr, err)
+	}
+
 	n.mu.Lock()
 	defer n.mu.Unlock()
 	if newSocketAddr == n.CurrentSocket {
-		return
+		return nil
 	}
 
 	if err := n.Transactor.UpdateOperatorSocket(ctx, newSocketAddr); err != nil {
-		n.Logger.Error("failed to update operator's socket", err)
-		return
+		return err
 	}
 
 	n.Logger.Info("Socket update", "old socket", n.CurrentSocket, "new socket", newSocketAddr)
 	n.Metrics.RecordSocketAddressChange()
 	n.CurrentSocket = newSocketAddr
+	return nil
+}
+
+// Reload reloads the TLS certificate of the servers, and registers the socket built from the hostname and the ports
+// of the config file onchain if they changed. The options of the socket which aren't in the config file keep their
+// value at startup. The socket isn't reloaded if the public IP check is enabled, since the check registers the socket
+// of the public IP.
+func (n *Node) Reload(ctx context.Context, configFilePath string) error {
+	var errs []error
+	if n.CertReloader != nil {
+		if err := n.CertReloader.Reload(); err != nil {
+			errs = append(errs, err)
+		} else {
+			n.Logger.Info("Reloaded the TLS certificate")
+		}
+	}
+
+	if configFilePath != "" && n.Config.PubIPCheckInterval == 0 {
+		socket, err := socketFromConfigFile(configFilePath, n.Config)
+		if err != nil {
+			errs = append(errs, err)
+		} else if err := n.UpdateSocket(ctx, socket); err != nil {
+			errs = append(errs, fmt.Errorf("failed to update the socket: %w", err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// socketFromConfigFile builds the socket of the node from the hostname and the ports of the config file, which
+// default to the ones of the config
+func socketFromConfigFile(path string, config *Config) (string, error) {
+	options, err := flags.ReadConfigFile(path)
+	if err != nil {
+		return "", err
+	}
+	hostname, dispersalPort, retrievalPort := config.Hostname, config.DispersalPort, config.RetrievalPort
+	for _, option := range options {
+		switch option.Flag {
+		case flags.HostnameFlag.Name:
+			hostname = option.Value
+		case flags.DispersalPortFlag.Name:
+			dispersalPort = option.Value
+		case flags.RetrievalPortFlag.Name:
+			retrievalPort = option.Value
+		}
+	}
+	return string(core.MakeOperatorSocket(hostname, dispersalPort, retrievalPort)), nil
 }
 
 func (n *Node) checkRegisteredNodeIpOnChain(ctx context.Context) {
@@ -0,0 +1,71 @@
+package node
+
+import (
+	"errors"
+	"io/fs"
+	"os"
+	"path/filepath"
+)
+
+// The optional features of the node reported by NodeInfo
+const (
+	FeatureDispersalAuth       = "dispersal_auth"
+	FeatureAdmissionControl    = "admission_control"
+	FeaturePartialBatchSigning = "partial_batch_signing"
+	FeatureGnarkBundleEncoding = "gnark_bundle_encoding"
+	FeatureStorageEncryption   = "storage_encryption"
+	FeatureSRSVerification     = "srs_verification"
+	FeatureAccessLog           = "access_log"
+	FeatureMetricsPush         = "metrics_push"
+)
+
+// EnabledFeatures returns the optional features enabled by the config. It only reports whether a feature is
+// enabled, never its settings, e.g. the authorized dispersers or the key of the storage encryption.
+func (c *Config) EnabledFeatures() []string {
+	features := make([]string, 0)
+	for _, feature := range []struct {
+		name    string
+		enabled bool
+	}{
+		{FeatureDispersalAuth, c.EnableDispersalAuth},
+		{FeatureAdmissionControl, c.EnableAdmissionControl},
+		{FeaturePartialBatchSigning, c.EnablePartialBatchSigning},
+		{FeatureGnarkBundleEncoding, c.EnableGnarkBundleEncoding},
+		{FeatureStorageEncryption, c.StorageEncryption.KeySecretName != ""},
+		{FeatureSRSVerification, !c.DisableSRSVerification},
+		{FeatureAccessLog, c.AccessLog.Path != ""},
+		{FeatureMetricsPush, c.MetricsPushConfig.GatewayURL != ""},
+	} {
+		if feature.enabled {
+			features = append(features, feature.name)
+		}
+	}
+	return features
+}
+
+// DirSize returns the total size of the files under the directory. The files removed while it is walked, e.g. the
+// tables compacted by the database, are skipped.
+func DirSize(path string) (uint64, error) {
+	size := uint64(0)
+	err := filepath.WalkDir(path, func(_ string, entry fs.DirEntry, err error) error {
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		if entry.IsDir() {
+			return nil
+		}
+		info, err := entry.Info()
+		if errors.Is(err, os.ErrNotExist) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		size += uint64(info.Size())
+		return nil
+	})
+	return size, err
+}
@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"os"
+	"path/filepath"
 	"runtime"
 	"testing"
 	"time"
@@ -13,8 +14,11 @@ import (
 	"github.com/Layr-Labs/eigenda/core"
 	coremock "github.com/Layr-Labs/eigenda/core/mock"
 	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/metrics"
+	"github.com/prometheus/client_golang/prometheus"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
 )
 
 var privateKey = "ac0974bec39a17e36ba4a6b4d238ff944bacb478cbed5efcae784d7bf4f2ff80"
@@ -147,3 +151,28 @@ func TestGetReachabilityURL(t *testing.T) {
 	assert.NoError(t, err)
 	assert.Equal(t, "https://dataapi.eigenda.xyz/api/v1/operators-info/port-check?operator_id=123123123", url)
 }
+
+func TestNodeReloadSocket(t *testing.T) {
+	c := newComponents(t)
+	c.node.Metrics = node.NewMetrics(metrics.NewNoopMetrics(), prometheus.NewRegistry(), c.node.Logger, ":9090", opID, -1, c.tx, c.node.ChainState)
+	c.node.Config.Hostname = "localhost"
+	c.node.Config.DispersalPort = "32005"
+	c.node.Config.RetrievalPort = "32004"
+	c.node.CurrentSocket = "localhost:32005;32004"
+	c.tx.On("UpdateOperatorSocket").Return(nil)
+
+	configFile := filepath.Join(t.TempDir(), "node.yaml")
+	require.NoError(t, os.WriteFile(configFile, []byte("node.hostname: localhost\n"), 0600))
+	require.NoError(t, c.node.Reload(context.Background(), configFile))
+	c.tx.AssertNotCalled(t, "UpdateOperatorSocket")
+
+	// The ports which aren't in the config file keep their value at startup
+	require.NoError(t, os.WriteFile(configFile, []byte("node.hostname: node.example.com\nnode.retrieval-port: 32104\n"), 0600))
+	require.NoError(t, c.node.Reload(context.Background(), configFile))
+	c.tx.AssertNumberOfCalls(t, "UpdateOperatorSocket", 1)
+	assert.Equal(t, "node.example.com:32005;32104", c.node.CurrentSocket)
+
+	require.NoError(t, os.WriteFile(configFile, []byte("node.hostname: \"\"\n"), 0600))
+	assert.ErrorContains(t, c.node.Reload(context.Background(), configFile), "invalid operator socket")
+	assert.Equal(t, "node.example.com:32005;32104", c.node.CurrentSocket)
+}
@@ -0,0 +1,227 @@
+package node
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"sort"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	regcoord "github.com/Layr-Labs/eigenda/contracts/bindings/RegistryCoordinator"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/ethereum/go-ethereum/accounts/abi/bind"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// OperatorRegistrationStatus is the status of an operator in the RegistryCoordinator
+type OperatorRegistrationStatus uint8
+
+const (
+	OperatorNeverRegistered OperatorRegistrationStatus = iota
+	OperatorRegistered
+	OperatorDeregistered
+)
+
+func (s OperatorRegistrationStatus) String() string {
+	switch s {
+	case OperatorNeverRegistered:
+		return "never registered"
+	case OperatorRegistered:
+		return "registered"
+	case OperatorDeregistered:
+		return "deregistered"
+	default:
+		return fmt.Sprintf("unknown (%d)", uint8(s))
+	}
+}
+
+// OperatorRegistration is the onchain registration of an operator
+type OperatorRegistration struct {
+	Address    gethcommon.Address
+	OperatorID core.OperatorID
+	Status     OperatorRegistrationStatus
+	// PubkeyHash is the hash of the BLS public key registered by the operator, which is its operator ID. It is zero
+	// if the operator never registered a public key.
+	PubkeyHash [32]byte
+	PubkeyG1   *core.G1Point
+	Quorums    []core.QuorumID
+}
+
+// PubkeyRegistered returns true if the operator has registered its BLS public key
+func (r *OperatorRegistration) PubkeyRegistered() bool {
+	return r.PubkeyHash != [32]byte{}
+}
+
+// QuorumStake is the stake of an operator in a quorum
+type QuorumStake struct {
+	QuorumID     core.QuorumID
+	Stake        *big.Int
+	TotalStake   *big.Int
+	MinimumStake *big.Int
+	NumOperators int
+}
+
+// StakeShare returns the share of the total stake of the quorum held by the operator, in percent
+func (s *QuorumStake) StakeShare() float64 {
+	if s.TotalStake.Sign() == 0 {
+		return 0
+	}
+	share, _ := new(big.Rat).SetFrac(new(big.Int).Mul(s.Stake, big.NewInt(100)), s.TotalStake).Float64()
+	return share
+}
+
+// OperatorSocket is the socket on record for an operator, as set by its last socket update
+type OperatorSocket struct {
+	Socket      string
+	BlockNumber uint64
+}
+
+// EjectionCooldown is the ejection state of an operator. An ejected operator cannot register again until the cooldown
+// has passed since its last ejection.
+type EjectionCooldown struct {
+	LastEjection time.Time
+	Cooldown     time.Duration
+}
+
+// Ejected returns true if the operator has ever been ejected
+func (e *EjectionCooldown) Ejected() bool {
+	return !e.LastEjection.IsZero()
+}
+
+// CooldownEnd returns the time at which the operator can register again after its last ejection
+func (e *EjectionCooldown) CooldownEnd() time.Time {
+	return e.LastEjection.Add(e.Cooldown)
+}
+
+// OperatorStatusReader reads the onchain state of an operator, so that it can be inspected without running a node
+type OperatorStatusReader struct {
+	transactor *eth.Transactor
+	client     common.EthClient
+}
+
+func NewOperatorStatusReader(transactor *eth.Transactor, client common.EthClient) *OperatorStatusReader {
+	return &OperatorStatusReader{
+		transactor: transactor,
+		client:     client,
+	}
+}
+
+// Registration returns the registration of the operator with the given address
+func (r *OperatorStatusReader) Registration(ctx context.Context, address gethcommon.Address) (*OperatorRegistration, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	info, err := r.transactor.Bindings.RegistryCoordinator.GetOperator(opts, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator: %w", err)
+	}
+	registration := &OperatorRegistration{
+		Address:    address,
+		OperatorID: info.OperatorId,
+		Status:     OperatorRegistrationStatus(info.Status),
+		Quorums:    make([]core.QuorumID, 0),
+	}
+
+	registration.PubkeyHash, err = r.transactor.Bindings.BLSApkRegistry.OperatorToPubkeyHash(opts, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get pubkey hash: %w", err)
+	}
+	if !registration.PubkeyRegistered() {
+		return registration, nil
+	}
+	// The operator ID is only recorded by the RegistryCoordinator once the operator registers
+	registration.OperatorID = registration.PubkeyHash
+	pubkey, _, err := r.transactor.Bindings.BLSApkRegistry.GetRegisteredPubkey(opts, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registered pubkey: %w", err)
+	}
+	registration.PubkeyG1 = core.NewG1Point(pubkey.X, pubkey.Y)
+
+	registration.Quorums, err = r.transactor.GetRegisteredQuorumIdsForOperator(ctx, registration.OperatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get registered quorums: %w", err)
+	}
+	return registration, nil
+}
+
+// QuorumStakes returns the current stake of the operator in each quorum it is registered in
+func (r *OperatorStatusReader) QuorumStakes(ctx context.Context, operatorID core.OperatorID) ([]*QuorumStake, error) {
+	blockNumber, err := r.transactor.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number: %w", err)
+	}
+	state, quorumIDs, err := r.transactor.GetOperatorStakes(ctx, operatorID, blockNumber)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator stakes: %w", err)
+	}
+
+	stakes := make([]*QuorumStake, 0, len(quorumIDs))
+	for _, quorumID := range quorumIDs {
+		stake := &QuorumStake{
+			QuorumID:     quorumID,
+			Stake:        big.NewInt(0),
+			TotalStake:   big.NewInt(0),
+			NumOperators: len(state[quorumID]),
+		}
+		for _, op := range state[quorumID] {
+			stake.TotalStake.Add(stake.TotalStake, op.Stake)
+			if op.OperatorID == operatorID {
+				stake.Stake.Set(op.Stake)
+			}
+		}
+		stake.MinimumStake, err = r.transactor.Bindings.StakeRegistry.MinimumStakeForQuorum(&bind.CallOpts{Context: ctx}, quorumID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get minimum stake of quorum %d: %w", quorumID, err)
+		}
+		stakes = append(stakes, stake)
+	}
+	sort.Slice(stakes, func(i, j int) bool { return stakes[i].QuorumID < stakes[j].QuorumID })
+	return stakes, nil
+}
+
+// Socket returns the socket on record for the operator, from the socket updates emitted since fromBlock.
+// It returns nil if the operator has not set a socket since then.
+func (r *OperatorStatusReader) Socket(ctx context.Context, operatorID core.OperatorID, fromBlock uint64) (*OperatorSocket, error) {
+	filterer, err := regcoord.NewContractRegistryCoordinatorFilterer(r.transactor.Bindings.RegCoordinatorAddr, r.client)
+	if err != nil {
+		return nil, err
+	}
+	it, err := filterer.FilterOperatorSocketUpdate(&bind.FilterOpts{Start: fromBlock, Context: ctx}, [][32]byte{operatorID})
+	if err != nil {
+		return nil, fmt.Errorf("failed to filter socket updates: %w", err)
+	}
+	defer it.Close()
+
+	var socket *OperatorSocket
+	for it.Next() {
+		socket = &OperatorSocket{
+			Socket:      it.Event.Socket,
+			BlockNumber: it.Event.Raw.BlockNumber,
+		}
+	}
+	if err := it.Error(); err != nil {
+		return nil, fmt.Errorf("failed to iterate socket updates: %w", err)
+	}
+	return socket, nil
+}
+
+// EjectionCooldown returns the ejection state of the operator with the given address
+func (r *OperatorStatusReader) EjectionCooldown(ctx context.Context, address gethcommon.Address) (*EjectionCooldown, error) {
+	opts := &bind.CallOpts{Context: ctx}
+	lastEjection, err := r.transactor.Bindings.RegistryCoordinator.LastEjectionTimestamp(opts, address)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get last ejection timestamp: %w", err)
+	}
+	cooldown, err := r.transactor.Bindings.RegistryCoordinator.EjectionCooldown(opts)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get ejection cooldown: %w", err)
+	}
+
+	ejection := &EjectionCooldown{
+		Cooldown: time.Duration(cooldown.Int64()) * time.Second,
+	}
+	if lastEjection.Sign() > 0 {
+		ejection.LastEjection = time.Unix(lastEjection.Int64(), 0)
+	}
+	return ejection, nil
+}
@@ -0,0 +1,37 @@
+package node_test
+
+import (
+	"math/big"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestQuorumStakeShare(t *testing.T) {
+	stake := &node.QuorumStake{
+		Stake:      big.NewInt(25),
+		TotalStake: big.NewInt(200),
+	}
+	assert.Equal(t, 12.5, stake.StakeShare())
+
+	stake.TotalStake = big.NewInt(0)
+	assert.Equal(t, 0.0, stake.StakeShare())
+}
+
+func TestEjectionCooldown(t *testing.T) {
+	ejection := &node.EjectionCooldown{Cooldown: 24 * time.Hour}
+	assert.False(t, ejection.Ejected())
+
+	ejection.LastEjection = time.Unix(1700000000, 0)
+	assert.True(t, ejection.Ejected())
+	assert.Equal(t, time.Unix(1700000000+24*3600, 0), ejection.CooldownEnd())
+}
+
+func TestOperatorRegistrationStatus(t *testing.T) {
+	assert.Equal(t, "never registered", node.OperatorNeverRegistered.String())
+	assert.Equal(t, "registered", node.OperatorRegistered.String())
+	assert.Equal(t, "deregistered", node.OperatorDeregistered.String())
+	assert.Equal(t, "unknown (3)", node.OperatorRegistrationStatus(3).String())
+}
@@ -3,8 +3,8 @@ package main
 import (
 	"context"
 	"log"
+	"net"
 	"os"
-	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
@@ -19,6 +19,9 @@ import (
 	"github.com/urfave/cli"
 )
 
+// retentionCheckTimeout is the timeout of the request of the retention of the node
+const retentionCheckTimeout = 10 * time.Second
+
 func main() {
 	app := cli.NewApp()
 	app.Flags = []cli.Flag{
@@ -35,6 +38,7 @@ func main() {
 		plugin.ChurnerUrlFlag,
 		plugin.NumConfirmationsFlag,
 		plugin.PubIPProviderFlag,
+		plugin.AcknowledgeRetentionFlag,
 	}
 	app.Name = "eigenda-node-plugin"
 	app.Usage = "EigenDA Node Plugin"
@@ -102,14 +106,18 @@ func pluginOps(ctx *cli.Context) {
 		return
 	}
 
-	_, dispersalPort, retrievalPort, err := core.ParseOperatorSocket(config.Socket)
+	host, dispersalPort, retrievalPort, err := core.ParseOperatorSocket(config.Socket)
 	if err != nil {
 		log.Printf("Error: failed to parse operator socket: %v", err)
 		return
 	}
+	if err := core.ValidateOperatorSocket(config.Socket); err != nil {
+		log.Printf("Error: invalid operator socket: %v", err)
+		return
+	}
 
 	socket := config.Socket
-	if isLocalhost(socket) {
+	if isLocalhost(host) {
 		pubIPProvider := pubip.ProviderOrDefault(config.PubIPProvider)
 		socket, err = node.SocketAddress(context.Background(), pubIPProvider, dispersalPort, retrievalPort)
 		if err != nil {
@@ -139,6 +147,9 @@ func pluginOps(ctx *cli.Context) {
 		log.Printf("Info: successfully opt-in the EigenDA, for operator ID: %x, operator address: %x, socket: %s, and quorums: %v", operatorID, sk.Address, config.Socket, config.QuorumIDList)
 	} else if config.Operation == plugin.OperationOptOut {
 		log.Printf("Info: Operator with Operator Address: %x and OperatorID: %x is opting out of EigenDA", sk.Address, operatorID)
+		if !checkRetentionBeforeOptOut(config) {
+			return
+		}
 		err = node.DeregisterOperator(context.Background(), operator, keyPair, tx)
 		if err != nil {
 			log.Printf("Error: failed to opt-out EigenDA Node Network for operator ID: %x, operator address: %x, quorums: %v, error: %v", operatorID, sk.Address, config.QuorumIDList, err)
@@ -160,11 +171,58 @@ func pluginOps(ctx *cli.Context) {
 			return
 		}
 		log.Printf("Info: operator ID: %x, operator address: %x, current quorums: %v", operatorID, sk.Address, quorumIds)
+	} else if config.Operation == plugin.OperationCheckRetention {
+		quorumIds, err := tx.GetRegisteredQuorumIdsForOperator(context.Background(), operatorID)
+		if err != nil {
+			log.Printf("Error: failed to get quorum(s) for operatorID: %x, operator address: %x, error: %v", operatorID, sk.Address, err)
+			return
+		}
+		retentionExpiry, err := plugin.GetRetentionExpiry(context.Background(), config.Socket, retentionCheckTimeout)
+		if err != nil {
+			log.Printf("Error: failed to get the retention of the node at socket %s: %v", config.Socket, err)
+			return
+		}
+		if len(quorumIds) > 0 {
+			log.Printf("Info: operator ID: %x is registered in quorums %v, so the node keeps receiving chunks and must keep running", operatorID, quorumIds)
+		} else if retentionExpiry.After(time.Now()) {
+			log.Printf("Info: operator ID: %x is deregistered, but the node must keep serving the chunks it stores until %s", operatorID, retentionExpiry.UTC().Format(time.RFC3339))
+		} else {
+			log.Printf("Info: operator ID: %x is deregistered and the node stores no unexpired chunks, so it may be shut down", operatorID)
+		}
 	} else {
 		log.Fatalf("Fatal: unsupported operation: %s", config.Operation)
 	}
 }
 
-func isLocalhost(socket string) bool {
-	return strings.Contains(socket, "localhost") || strings.Contains(socket, "127.0.0.1") || strings.Contains(socket, "0.0.0.0")
+// checkRetentionBeforeOptOut returns whether the opt-out may proceed. The node must keep serving the chunks it stores
+// until they expire once opted out, so the opt-out is refused while it stores unexpired chunks unless the operator
+// acknowledges the retention.
+func checkRetentionBeforeOptOut(config *plugin.Config) bool {
+	retentionExpiry, err := plugin.GetRetentionExpiry(context.Background(), config.Socket, retentionCheckTimeout)
+	if err != nil {
+		if config.AcknowledgeRetention {
+			log.Printf("Warning: failed to get the retention of the node at socket %s, opting out anyway: %v", config.Socket, err)
+			return true
+		}
+		log.Printf("Error: failed to get the retention of the node at socket %s: %v. Run the opt-out with --%s to opt out without checking the retention", config.Socket, err, plugin.AcknowledgeRetentionFlag.Name)
+		return false
+	}
+	if !retentionExpiry.After(time.Now()) {
+		return true
+	}
+	expiry := retentionExpiry.UTC().Format(time.RFC3339)
+	if !config.AcknowledgeRetention {
+		log.Printf("Error: the node stores chunks which expire at %s, and must keep serving them until then once opted out. Run the opt-out with --%s to opt out and keep the node running until then", expiry, plugin.AcknowledgeRetentionFlag.Name)
+		return false
+	}
+	log.Printf("Warning: the node must keep serving the chunks it stores until %s, do not shut it down before then", expiry)
+	return true
+}
+
+func isLocalhost(host string) bool {
+	if host == "localhost" {
+		return true
+	}
+	ip := net.ParseIP(host)
+	return ip != nil && (ip.IsLoopback() || ip.IsUnspecified())
 }
@@ -16,6 +16,8 @@ const (
 	OperationOptOut       = "opt-out"
 	OperationUpdateSocket = "update-socket"
 	OperationListQuorums  = "list-quorums"
+	// OperationCheckRetention reports until when the node has to keep serving the chunks it stores
+	OperationCheckRetention = "check-retention"
 )
 
 var (
@@ -32,7 +34,7 @@ var (
 	OperationFlag = cli.StringFlag{
 		Name:     "operation",
 		Required: true,
-		Usage:    "Supported operations: opt-in, opt-out, update-socket, list-quorums",
+		Usage:    "Supported operations: opt-in, opt-out, update-socket, list-quorums, check-retention",
 		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "OPERATION"),
 	}
 
@@ -110,6 +112,12 @@ var (
 		Value:    3,
 		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "NUM_CONFIRMATIONS"),
 	}
+	AcknowledgeRetentionFlag = cli.BoolFlag{
+		Name:     "acknowledge-retention",
+		Usage:    "Opt out even though the node still stores chunks which haven't expired. The node must keep serving them until they expire, which the opt-out reports",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(flags.EnvVarPrefix, "ACKNOWLEDGE_RETENTION"),
+	}
 )
 
 type Config struct {
@@ -126,6 +134,7 @@ type Config struct {
 	EigenDAServiceManagerAddr     string
 	ChurnerUrl                    string
 	NumConfirmations              int
+	AcknowledgeRetention          bool
 }
 
 func NewConfig(ctx *cli.Context) (*Config, error) {
@@ -146,7 +155,7 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	if len(op) == 0 {
 		return nil, errors.New("operation type not provided")
 	}
-	if op != OperationOptIn && op != OperationOptOut && op != OperationUpdateSocket && op != OperationListQuorums {
+	if op != OperationOptIn && op != OperationOptOut && op != OperationUpdateSocket && op != OperationListQuorums && op != OperationCheckRetention {
 		return nil, errors.New("unsupported operation type")
 	}
 
@@ -164,5 +173,6 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 		EigenDAServiceManagerAddr:     ctx.GlobalString(EigenDAServiceManagerFlag.Name),
 		ChurnerUrl:                    ctx.GlobalString(ChurnerUrlFlag.Name),
 		NumConfirmations:              ctx.GlobalInt(NumConfirmationsFlag.Name),
+		AcknowledgeRetention:          ctx.GlobalBool(AcknowledgeRetentionFlag.Name),
 	}, nil
 }
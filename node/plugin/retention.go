@@ -0,0 +1,38 @@
+package plugin
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+// GetRetentionExpiry returns the time at which the last chunks stored by the node at the socket expire, as reported
+// by the NodeInfo endpoint of its retrieval server. It is the zero time if the node stores no chunks.
+func GetRetentionExpiry(ctx context.Context, socket string, timeout time.Duration) (time.Time, error) {
+	host, _, retrievalPort, err := core.ParseOperatorSocket(socket)
+	if err != nil {
+		return time.Time{}, err
+	}
+	conn, err := grpc.Dial(net.JoinHostPort(host, retrievalPort), grpc.WithTransportCredentials(insecure.NewCredentials()))
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to connect to the node: %w", err)
+	}
+	defer conn.Close()
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	reply, err := pb.NewRetrievalClient(conn).NodeInfo(ctx, &pb.NodeInfoRequest{})
+	if err != nil {
+		return time.Time{}, fmt.Errorf("failed to get the node info: %w", err)
+	}
+	if reply.GetRetentionExpiry() == 0 {
+		return time.Time{}, nil
+	}
+	return time.Unix(int64(reply.GetRetentionExpiry()), 0), nil
+}
@@ -0,0 +1,226 @@
+package node
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io"
+	"math"
+	"os"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	srsReadBufferSize      = 4 * 1024 * 1024
+	srsProgressLogInterval = 10 * time.Second
+)
+
+var (
+	errSRSVerificationInProgress = errors.New("SRS verification is in progress")
+	errSRSVerificationFailed     = errors.New("SRS verification failed")
+)
+
+// SRSFile is a point file of the SRS, with the number of points that must be read from it by the verifier
+type SRSFile struct {
+	Path      string
+	NumPoints uint64
+	PointSize uint64
+	// Digest is the expected hex encoded SHA-256 digest of the file. It is not checked if empty.
+	Digest string
+}
+
+// SRSFiles returns the point files of the SRS read by the node at runtime. The verifier reads the G1 points up to the
+// SRS order, not only the points loaded at startup, and the G2 points on powers of 2, or all the G2 points if the
+// powers of 2 aren't set.
+func SRSFiles(config *kzg.KzgConfig, g1Digest, g2Digest, g2PowerOf2Digest string) []SRSFile {
+	files := []SRSFile{{
+		Path:      config.G1Path,
+		NumPoints: config.SRSOrder,
+		PointSize: kzg.G1PointBytes,
+		Digest:    g1Digest,
+	}}
+	if config.G2PowerOf2Path != "" {
+		numPoints := uint64(1)
+		if config.SRSOrder > 1 {
+			numPoints = uint64(math.Log2(float64(config.SRSOrder-1))) + 1
+		}
+		files = append(files, SRSFile{
+			Path:      config.G2PowerOf2Path,
+			NumPoints: numPoints,
+			PointSize: kzg.G2PointBytes,
+			Digest:    g2PowerOf2Digest,
+		})
+	} else if config.G2Path != "" {
+		files = append(files, SRSFile{
+			Path:      config.G2Path,
+			NumPoints: config.SRSOrder,
+			PointSize: kzg.G2PointBytes,
+			Digest:    g2Digest,
+		})
+	}
+	return files
+}
+
+// SRSPreloader verifies the SRS files in the background: it checks that they aren't truncated, and reads them
+// through to compare their digests with the expected ones, which also loads them into the page cache for the reads of
+// the verifier. The node doesn't attest to batches until the verification completes.
+type SRSPreloader struct {
+	files  []SRSFile
+	logger logging.Logger
+
+	mu         sync.Mutex
+	totalBytes uint64
+	readBytes  uint64
+	done       bool
+	err        error
+	startedAt  time.Time
+	finishedAt time.Time
+}
+
+var _ healthcheck.HealthReporter = (*SRSPreloader)(nil)
+
+func NewSRSPreloader(files []SRSFile, logger logging.Logger) *SRSPreloader {
+	return &SRSPreloader{
+		files:  files,
+		logger: logger.With("component", "SRSPreloader"),
+	}
+}
+
+// Run verifies the SRS files until the verification completes or the context is done, and returns the error of the
+// verification
+func (p *SRSPreloader) Run(ctx context.Context) error {
+	p.mu.Lock()
+	p.startedAt = time.Now()
+	p.mu.Unlock()
+
+	err := p.verify(ctx)
+
+	p.mu.Lock()
+	p.done = true
+	p.err = err
+	p.finishedAt = time.Now()
+	p.mu.Unlock()
+	if err != nil {
+		p.logger.Error("SRS verification failed, the node will not attest to batches", "err", err)
+	} else {
+		p.logger.Info("SRS verification completed", "duration", p.finishedAt.Sub(p.startedAt))
+	}
+	return err
+}
+
+func (p *SRSPreloader) verify(ctx context.Context) error {
+	// Check the sizes first, so that truncated files are reported without reading the others
+	var totalBytes uint64
+	for _, file := range p.files {
+		if file.Path == "" {
+			return fmt.Errorf("%w: SRS file path is empty", errSRSVerificationFailed)
+		}
+		info, err := os.Stat(file.Path)
+		if err != nil {
+			return fmt.Errorf("%w: %v", errSRSVerificationFailed, err)
+		}
+		if required := file.NumPoints * file.PointSize; uint64(info.Size()) < required {
+			return fmt.Errorf("%w: %s is truncated: %d bytes, %d points of %d bytes are required", errSRSVerificationFailed, file.Path, info.Size(), file.NumPoints, file.PointSize)
+		}
+		totalBytes += uint64(info.Size())
+	}
+	p.mu.Lock()
+	p.totalBytes = totalBytes
+	p.mu.Unlock()
+
+	for _, file := range p.files {
+		digest, err := p.digest(ctx, file.Path)
+		if err != nil {
+			return err
+		}
+		if file.Digest != "" && !strings.EqualFold(strings.TrimPrefix(file.Digest, "0x"), digest) {
+			return fmt.Errorf("%w: %s has SHA-256 digest %s, expected %s", errSRSVerificationFailed, file.Path, digest, file.Digest)
+		}
+		p.logger.Info("SRS file verified", "path", file.Path, "sha256", digest, "digestChecked", file.Digest != "")
+	}
+	return nil
+}
+
+// digest reads the file through and returns its hex encoded SHA-256 digest
+func (p *SRSPreloader) digest(ctx context.Context, path string) (string, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return "", fmt.Errorf("%w: %v", errSRSVerificationFailed, err)
+	}
+	defer f.Close()
+
+	hasher := sha256.New()
+	buf := make([]byte, srsReadBufferSize)
+	lastLog := time.Now()
+	for {
+		if err := ctx.Err(); err != nil {
+			return "", err
+		}
+		n, err := f.Read(buf)
+		hasher.Write(buf[:n])
+		p.mu.Lock()
+		p.readBytes += uint64(n)
+		readBytes, totalBytes := p.readBytes, p.totalBytes
+		p.mu.Unlock()
+		if time.Since(lastLog) > srsProgressLogInterval {
+			p.logger.Info("Verifying SRS files", "readBytes", readBytes, "totalBytes", totalBytes)
+			lastLog = time.Now()
+		}
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return "", fmt.Errorf("%w: failed to read %s: %v", errSRSVerificationFailed, path, err)
+		}
+	}
+	return hex.EncodeToString(hasher.Sum(nil)), nil
+}
+
+// Ready returns nil once the SRS is verified, and an error while the verification is in progress or if it failed
+func (p *SRSPreloader) Ready() error {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+	if !p.done {
+		return errSRSVerificationInProgress
+	}
+	return p.err
+}
+
+func (p *SRSPreloader) Health() healthcheck.ComponentHealth {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	h := healthcheck.ComponentHealth{
+		Component: "SRSPreloader",
+		Status:    healthcheck.StatusHealthy,
+		Details: map[string]string{
+			"readBytes":  fmt.Sprint(p.readBytes),
+			"totalBytes": fmt.Sprint(p.totalBytes),
+		},
+	}
+	if p.totalBytes > 0 {
+		h.Details["progress"] = fmt.Sprintf("%.1f%%", 100*float64(p.readBytes)/float64(p.totalBytes))
+	}
+	switch {
+	case !p.done:
+		h.Status = healthcheck.StatusDegraded
+		h.Details["state"] = "verifying"
+	case p.err != nil:
+		h.Status = healthcheck.StatusUnhealthy
+		h.Details["state"] = "failed"
+		h.LastError = p.err.Error()
+		finishedAt := p.finishedAt
+		h.LastErrorAt = &finishedAt
+	default:
+		h.Details["state"] = "verified"
+	}
+	return h
+}
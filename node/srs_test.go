@@ -0,0 +1,66 @@
+package node_test
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/common/healthcheck"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func writeSRSFile(t *testing.T, size int) (string, string) {
+	data := make([]byte, size)
+	for i := range data {
+		data[i] = byte(i)
+	}
+	path := filepath.Join(t.TempDir(), "g1.point")
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+	digest := sha256.Sum256(data)
+	return path, hex.EncodeToString(digest[:])
+}
+
+func TestSRSFiles(t *testing.T) {
+	files := node.SRSFiles(&kzg.KzgConfig{
+		G1Path:         "g1.point",
+		G2Path:         "g2.point",
+		G2PowerOf2Path: "g2.point.powerOf2",
+		SRSOrder:       3000,
+	}, "", "", "")
+	assert.Len(t, files, 2)
+	assert.Equal(t, uint64(3000), files[0].NumPoints)
+	assert.Equal(t, "g2.point.powerOf2", files[1].Path)
+	// [tau^1], [tau^2], ..., [tau^2048]
+	assert.Equal(t, uint64(12), files[1].NumPoints)
+}
+
+func TestSRSPreloader(t *testing.T) {
+	path, digest := writeSRSFile(t, 100*kzg.G1PointBytes)
+
+	preloader := node.NewSRSPreloader([]node.SRSFile{{Path: path, NumPoints: 100, PointSize: kzg.G1PointBytes, Digest: digest}}, logging.NewNoopLogger())
+	assert.Error(t, preloader.Ready())
+	assert.Equal(t, healthcheck.StatusDegraded, preloader.Health().Status)
+	assert.NoError(t, preloader.Run(context.Background()))
+	assert.NoError(t, preloader.Ready())
+	health := preloader.Health()
+	assert.Equal(t, healthcheck.StatusHealthy, health.Status)
+	assert.Equal(t, "100.0%", health.Details["progress"])
+
+	// Truncated file
+	preloader = node.NewSRSPreloader([]node.SRSFile{{Path: path, NumPoints: 101, PointSize: kzg.G1PointBytes}}, logging.NewNoopLogger())
+	assert.ErrorContains(t, preloader.Run(context.Background()), "truncated")
+	assert.Error(t, preloader.Ready())
+	assert.Equal(t, healthcheck.StatusUnhealthy, preloader.Health().Status)
+
+	// Digest mismatch
+	otherPath, _ := writeSRSFile(t, 101*kzg.G1PointBytes)
+	preloader = node.NewSRSPreloader([]node.SRSFile{{Path: otherPath, NumPoints: 100, PointSize: kzg.G1PointBytes, Digest: digest}}, logging.NewNoopLogger())
+	assert.ErrorContains(t, preloader.Run(context.Background()), "digest")
+	assert.Error(t, preloader.Ready())
+}
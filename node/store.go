@@ -3,6 +3,7 @@ package node
 import (
 	"bytes"
 	"context"
+	"crypto/cipher"
 	"encoding/binary"
 	"errors"
 	"fmt"
@@ -25,18 +26,26 @@ const (
 	numBatchesToDeleteAtomically = 8
 )
 
+// LevelDBBackend is the backend of the stores created with NewLevelDBStore
+const LevelDBBackend = "leveldb"
+
 var ErrBatchAlreadyExist = errors.New("batch already exists")
 
 // Store is a key-value database to store blob data (blob header, blob chunks etc).
 type Store struct {
 	db     kvstore.Store
 	logger logging.Logger
+	// backend is the database backend of db
+	backend string
 
 	blockStaleMeasure   uint32
 	storeDurationBlocks uint32
 
 	// The DA Node's metrics.
 	metrics *Metrics
+
+	// chunkCipher encrypts the chunks at rest. It is nil if the encryption isn't enabled.
+	chunkCipher cipher.AEAD
 }
 
 // NewLevelDBStore creates a new Store object with a db at the provided path and the given logger.
@@ -53,12 +62,23 @@ func NewLevelDBStore(path string, logger logging.Logger, metrics *Metrics, block
 	return &Store{
 		db:                  db,
 		logger:              logger.With("component", "NodeStore"),
+		backend:             LevelDBBackend,
 		blockStaleMeasure:   blockStaleMeasure,
 		storeDurationBlocks: storeDurationBlocks,
 		metrics:             metrics,
 	}, nil
 }
 
+// Backend returns the database backend of the store
+func (s *Store) Backend() string {
+	return s.backend
+}
+
+// Close flushes the database to disk and closes it, waiting for its background compactions
+func (s *Store) Close() error {
+	return s.db.Shutdown()
+}
+
 // Delete expired entries in the store.
 // An entry is expired if its expiry <= currentTimeUnixSec, where expiry and
 // currentTimeUnixSec are time since Unix epoch (in seconds).
@@ -106,6 +126,131 @@ func (s *Store) DeleteExpiredEntries(currentTimeUnixSec int64, timeLimitSec uint
 	}
 }
 
+// ExpiredEntries are the entries of the store which DeleteExpiredEntries would delete at a given time.
+type ExpiredEntries struct {
+	NumBatches int
+	NumBlobs   int
+	// ChunkBytes is the size of the chunks of the expired batches and blobs.
+	ChunkBytes int64
+}
+
+// ScanExpiredEntries returns the entries expired at currentTimeUnixSec without deleting them, i.e. what the next
+// expiration would reclaim. Like DeleteExpiredEntries, it exits with deadline exceeded error if it cannot finish after
+// timeLimitSec seconds, in which case the entries scanned so far are returned.
+func (s *Store) ScanExpiredEntries(currentTimeUnixSec int64, timeLimitSec uint64) (*ExpiredEntries, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), time.Duration(timeLimitSec)*time.Second)
+	defer cancel()
+
+	expired := &ExpiredEntries{}
+	// chunkBytes adds up the size of the chunks under the prefix.
+	chunkBytes := func(prefix []byte) error {
+		iter, err := s.db.NewIterator(prefix)
+		if err != nil {
+			return fmt.Errorf("failed to create an iterator for the blob chunks: %w", err)
+		}
+		defer iter.Release()
+		for iter.Next() {
+			expired.ChunkBytes += int64(len(iter.Value()))
+		}
+		return nil
+	}
+
+	// Batches.
+	iter, err := s.db.NewIterator(EncodeBatchExpirationKeyPrefix())
+	if err != nil {
+		return nil, fmt.Errorf("failed to create an iterator for the expired batches: %w", err)
+	}
+	defer iter.Release()
+	for iter.Next() {
+		if err := ctx.Err(); err != nil {
+			return expired, err
+		}
+		ts, err := DecodeBatchExpirationKey(iter.Key())
+		if err != nil {
+			s.logger.Error("Could not decode the expiration key", "key:", iter.Key(), "error", err)
+			continue
+		}
+		if currentTimeUnixSec < ts {
+			break
+		}
+		var batchHeaderHash [32]byte
+		copy(batchHeaderHash[:], iter.Value())
+		blobHeaderIter, err := s.db.NewIterator(EncodeBlobHeaderKeyPrefix(batchHeaderHash))
+		if err != nil {
+			return expired, fmt.Errorf("failed to create an iterator for the blob headers: %w", err)
+		}
+		for blobHeaderIter.Next() {
+			expired.NumBlobs++
+		}
+		blobHeaderIter.Release()
+		if err := chunkBytes(batchHeaderHash[:]); err != nil {
+			return expired, err
+		}
+		expired.NumBatches++
+	}
+
+	// Batch mappings, whose blobs expire on their own.
+	mappingIter, err := s.db.NewIterator(EncodeBatchMappingExpirationKeyPrefix())
+	if err != nil {
+		return expired, fmt.Errorf("failed to create an iterator for the expired batch mapping: %w", err)
+	}
+	defer mappingIter.Release()
+	for mappingIter.Next() {
+		ts, err := DecodeBatchMappingExpirationKey(mappingIter.Key())
+		if err != nil {
+			s.logger.Error("Could not decode the batch mapping expiration key", "key", mappingIter.Key(), "error", err)
+			continue
+		}
+		if currentTimeUnixSec < ts {
+			break
+		}
+		expired.NumBatches++
+	}
+
+	// Blobs not associated with any batch.
+	blobIter, err := s.db.NewIterator(EncodeBlobExpirationKeyPrefix())
+	if err != nil {
+		return expired, fmt.Errorf("failed to create an iterator for the expired blobs: %w", err)
+	}
+	defer blobIter.Release()
+	for blobIter.Next() {
+		if err := ctx.Err(); err != nil {
+			return expired, err
+		}
+		ts, err := DecodeBlobExpirationKey(blobIter.Key())
+		if err != nil {
+			s.logger.Error("Could not decode the expiration key", "key", blobIter.Key(), "error", err)
+			continue
+		}
+		if currentTimeUnixSec < ts {
+			break
+		}
+		blobHeaders, err := DecodeHashSlice(copyBytes(blobIter.Value()))
+		if err != nil {
+			s.logger.Error("Could not decode the blob header hashes", "error", err)
+			continue
+		}
+		for _, blobHeaderHash := range blobHeaders {
+			if err := chunkBytes(EncodeBlobKeyByHashPrefix(blobHeaderHash)); err != nil {
+				return expired, err
+			}
+		}
+		expired.NumBlobs += len(blobHeaders)
+	}
+
+	return expired, nil
+}
+
+// Compact compacts the database, which reclaims the disk space of the deleted entries. It blocks until the compaction
+// completes, so it should be called once a large number of entries were deleted, e.g. after an expiration cycle.
+func (s *Store) Compact() error {
+	compactor, ok := s.db.(kvstore.Compactor)
+	if !ok {
+		return fmt.Errorf("the %s backend doesn't support compaction", s.backend)
+	}
+	return compactor.Compact()
+}
+
 // deleteExpiredBlobs returns the number of blobs deleted and the status of deletion.
 // The number is set to -1 (invalid value) if the deletion status is an error.
 // Note that the blobs/blob headers expired by this method are those that are not associated with any batch.
@@ -433,8 +578,12 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 				rawBundle, ok := rawBundles[quorumID]
 				if ok {
 					size += int64(len(rawBundle))
+					value, err := s.encryptChunks(key, rawBundle)
+					if err != nil {
+						return nil, err
+					}
 					keys = append(keys, key)
-					values = append(values, rawBundle)
+					values = append(values, value)
 				}
 			} else if format == core.GobBundleEncodingFormat {
 				if len(rawChunks[quorumID]) != len(bundle) {
@@ -452,8 +601,12 @@ func (s *Store) StoreBatch(ctx context.Context, header *core.BatchHeader, blobs
 						return nil, err
 					}
 					size += int64(len(chunkBytes))
+					value, err := s.encryptChunks(key, chunkBytes)
+					if err != nil {
+						return nil, err
+					}
 					keys = append(keys, key)
-					values = append(values, chunkBytes)
+					values = append(values, value)
 				}
 			} else {
 				return nil, fmt.Errorf("invalid bundle encoding format: %d", format)
@@ -549,8 +702,12 @@ func (s *Store) StoreBlobs(ctx context.Context, blobs []*core.BlobMessage, blobs
 				rawBundle, ok := rawBundles[quorumID]
 				if ok {
 					size += int64(len(rawBundle))
+					value, err := s.encryptChunks(key, rawBundle)
+					if err != nil {
+						return nil, err
+					}
 					keys = append(keys, key)
-					values = append(values, rawBundle)
+					values = append(values, value)
 				}
 			} else if format == core.GobBundleEncodingFormat {
 				if len(rawChunks[quorumID]) != len(bundle) {
@@ -568,8 +725,12 @@ func (s *Store) StoreBlobs(ctx context.Context, blobs []*core.BlobMessage, blobs
 						return nil, err
 					}
 					size += int64(len(chunkBytes))
+					value, err := s.encryptChunks(key, chunkBytes)
+					if err != nil {
+						return nil, err
+					}
 					keys = append(keys, key)
-					values = append(values, chunkBytes)
+					values = append(values, value)
 				}
 			} else {
 				return nil, fmt.Errorf("invalid bundle encoding format: %d", format)
@@ -650,6 +811,38 @@ func (s *Store) expirationTime() int64 {
 	return curr + int64(timeToExpire)
 }
 
+// LastExpirationTime returns the unix time at which the last batch or blob in the store expires, or 0 if the store is
+// empty. The node has to keep serving the chunks it stores until then, even once it is deregistered.
+func (s *Store) LastExpirationTime() (int64, error) {
+	last := int64(0)
+	for _, expiration := range []struct {
+		prefix []byte
+		decode func([]byte) (int64, error)
+	}{
+		{EncodeBatchExpirationKeyPrefix(), DecodeBatchExpirationKey},
+		{EncodeBlobExpirationKeyPrefix(), DecodeBlobExpirationKey},
+		{EncodeBatchMappingExpirationKeyPrefix(), DecodeBatchMappingExpirationKey},
+	} {
+		iter, err := s.db.NewIterator(expiration.prefix)
+		if err != nil {
+			return 0, fmt.Errorf("failed to create an iterator for the expiration keys: %w", err)
+		}
+		// The expiration keys are ordered by expiration time, so the last key expires last
+		if iter.Last() {
+			ts, err := expiration.decode(iter.Key())
+			if err != nil {
+				iter.Release()
+				return 0, err
+			}
+			if ts > last {
+				last = ts
+			}
+		}
+		iter.Release()
+	}
+	return last, nil
+}
+
 // GetBatchHeader returns the batch header for the given batchHeaderHash.
 func (s *Store) GetBatchHeader(ctx context.Context, batchHeaderHash [32]byte) ([]byte, error) {
 	batchHeaderKey := EncodeBatchHeaderKey(batchHeaderHash)
@@ -716,6 +909,7 @@ func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobInd
 		return nil, node.ChunkEncodingFormat_UNKNOWN, err
 	}
 	var data []byte
+	dataKey := blobKey
 	data, err = s.db.Get(blobKey)
 	if errors.Is(err, kvstore.ErrNotFound) {
 		// If the blob is not found, try to get the blob header hash and get the blob by the hash (stored via minibatch dispersal).
@@ -729,9 +923,14 @@ func (s *Store) GetChunks(ctx context.Context, batchHeaderHash [32]byte, blobInd
 		if err != nil {
 			return nil, node.ChunkEncodingFormat_UNKNOWN, fmt.Errorf("failed to generate the key for storing blob: %w", err)
 		}
+		dataKey = key
 		data, err = s.db.Get(key)
 	}
 
+	if err != nil {
+		return nil, node.ChunkEncodingFormat_UNKNOWN, err
+	}
+	data, err = s.decryptChunks(dataKey, data)
 	if err != nil {
 		return nil, node.ChunkEncodingFormat_UNKNOWN, err
 	}
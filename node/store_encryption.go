@@ -0,0 +1,115 @@
+package node
+
+import (
+	"context"
+	"crypto/aes"
+	"crypto/cipher"
+	"crypto/rand"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common/aws/secretmanager"
+	"github.com/Layr-Labs/eigenda/core"
+)
+
+const (
+	// StorageEncryptionKeySize is the size in bytes of the AES-256 key encrypting the chunks at rest.
+	StorageEncryptionKeySize = 32
+
+	// encryptedChunksFormat is the encoding format in the header of the encrypted chunks. It is distinct from the
+	// formats of the chunks (see parseHeader), so that the chunks stored before the encryption was enabled can still
+	// be read.
+	encryptedChunksFormat core.BundleEncodingFormat = 0xff
+	encryptedHeaderSize                             = 8
+)
+
+// StorageEncryptionConfig configures the encryption of the chunks at rest, with a key read from the AWS secrets manager
+type StorageEncryptionConfig struct {
+	// KeySecretName is the name of the secret holding the hex encoded AES-256 key. The chunks aren't encrypted if it is
+	// empty.
+	KeySecretName   string
+	KeySecretRegion string
+}
+
+// ReadStorageEncryptionKey reads the key encrypting the chunks at rest from the secrets manager
+func ReadStorageEncryptionKey(ctx context.Context, config StorageEncryptionConfig) ([]byte, error) {
+	secret, err := secretmanager.ReadStringFromSecretManager(ctx, config.KeySecretName, config.KeySecretRegion)
+	if err != nil {
+		return nil, fmt.Errorf("cannot read storage encryption key %s from secret manager: %w", config.KeySecretName, err)
+	}
+	key, err := hex.DecodeString(strings.TrimPrefix(strings.TrimSpace(secret), "0x"))
+	if err != nil {
+		return nil, fmt.Errorf("storage encryption key %s isn't hex encoded: %w", config.KeySecretName, err)
+	}
+	return key, nil
+}
+
+// SetEncryptionKey enables the AES-GCM encryption of the chunks at rest with the given AES-256 key. The chunks are
+// encrypted as they are stored and decrypted as they are served, and the chunks stored without encryption are served
+// as they are.
+func (s *Store) SetEncryptionKey(key []byte) error {
+	if len(key) != StorageEncryptionKeySize {
+		return fmt.Errorf("invalid storage encryption key size %d, expected %d bytes", len(key), StorageEncryptionKeySize)
+	}
+	block, err := aes.NewCipher(key)
+	if err != nil {
+		return err
+	}
+	aead, err := cipher.NewGCM(block)
+	if err != nil {
+		return err
+	}
+	s.chunkCipher = aead
+	return nil
+}
+
+// encryptChunks encrypts the chunks stored at the key, if the encryption is enabled. The key is authenticated with the
+// chunks so that they can't be moved to another key.
+//
+// The encrypted chunks are (header, nonce, ciphertext), where the header holds encryptedChunksFormat.
+func (s *Store) encryptChunks(key []byte, data []byte) ([]byte, error) {
+	if s.chunkCipher == nil {
+		return data, nil
+	}
+	start := time.Now()
+
+	nonceSize := s.chunkCipher.NonceSize()
+	result := make([]byte, encryptedHeaderSize+nonceSize, encryptedHeaderSize+nonceSize+len(data)+s.chunkCipher.Overhead())
+	binary.LittleEndian.PutUint64(result, uint64(encryptedChunksFormat)<<(core.NumBundleHeaderBits-core.NumBundleEncodingFormatBits))
+	nonce := result[encryptedHeaderSize:]
+	if _, err := rand.Read(nonce); err != nil {
+		return nil, fmt.Errorf("failed to generate nonce: %w", err)
+	}
+	result = s.chunkCipher.Seal(result, nonce, data, key)
+
+	s.metrics.ObserveChunkEncryptionLatency("encrypt", time.Since(start))
+	return result, nil
+}
+
+// decryptChunks decrypts the chunks read at the key if they are encrypted, and returns them as they are otherwise.
+func (s *Store) decryptChunks(key []byte, data []byte) ([]byte, error) {
+	if format, _, err := parseHeader(data); err != nil || format != encryptedChunksFormat {
+		return data, nil
+	}
+	if s.chunkCipher == nil {
+		return nil, errors.New("the chunks are encrypted but no storage encryption key is configured")
+	}
+	start := time.Now()
+
+	nonceSize := s.chunkCipher.NonceSize()
+	if len(data) < encryptedHeaderSize+nonceSize {
+		return nil, errors.New("invalid encrypted chunks: too short")
+	}
+	nonce := data[encryptedHeaderSize : encryptedHeaderSize+nonceSize]
+	plaintext, err := s.chunkCipher.Open(nil, nonce, data[encryptedHeaderSize+nonceSize:], key)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decrypt chunks: %w", err)
+	}
+
+	s.metrics.ObserveChunkEncryptionLatency("decrypt", time.Since(start))
+	return plaintext, nil
+}
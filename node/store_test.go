@@ -7,6 +7,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/conversion"
 	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/core"
@@ -276,14 +277,20 @@ func TestStoreBatchSuccess(t *testing.T) {
 	// Empty store
 	blobKey := []byte{1, 2}
 	assert.False(t, s.HasKey(ctx, blobKey))
+	lastExpiration, err := s.LastExpirationTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), lastExpiration)
 
 	// Prepare data to store.
 	batchHeader, blobs, blobsProto := CreateBatch(t)
 	batchHeaderBytes, _ := batchHeader.Serialize()
 
 	// Store a batch.
-	_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	_, err = s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
 	assert.Nil(t, err)
+	lastExpiration, err = s.LastExpirationTime()
+	assert.Nil(t, err)
+	assert.InDelta(t, time.Now().Unix()+int64(staleMeasure+storeDuration)*12, lastExpiration, 5)
 
 	// Check existence: batch header.
 	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
@@ -358,6 +365,9 @@ func TestStoreBatchSuccess(t *testing.T) {
 	assert.False(t, s.HasKey(ctx, blobHeaderKey2))
 	assert.False(t, s.HasKey(ctx, blobKey1))
 	assert.False(t, s.HasKey(ctx, blobKey2))
+	lastExpiration, err = s.LastExpirationTime()
+	assert.Nil(t, err)
+	assert.Equal(t, int64(0), lastExpiration)
 }
 
 func TestStoreBlobsSuccess(t *testing.T) {
@@ -475,7 +485,7 @@ func TestStoreBatchBlobMapping(t *testing.T) {
 	assert.Nil(t, err)
 	err = proto.Unmarshal(blobHeaderBytes0, &protoBlobHeader)
 	assert.Nil(t, err)
-	blobHeader0, err := node.GetBlobHeaderFromProto(&protoBlobHeader)
+	blobHeader0, err := conversion.BlobHeaderFromProto(&protoBlobHeader)
 	assert.Nil(t, err)
 
 	assert.Equal(t, blobHeader0, blobs[0].BlobHeader)
@@ -483,7 +493,7 @@ func TestStoreBatchBlobMapping(t *testing.T) {
 	assert.Nil(t, err)
 	err = proto.Unmarshal(blobHeaderBytes1, &protoBlobHeader)
 	assert.Nil(t, err)
-	blobHeader1, err := node.GetBlobHeaderFromProto(&protoBlobHeader)
+	blobHeader1, err := conversion.BlobHeaderFromProto(&protoBlobHeader)
 	assert.Nil(t, err)
 	assert.Equal(t, blobHeader1, blobs[1].BlobHeader)
 	blobHeaderBytes2, err := s.GetBlobHeader(ctx, batchHeaderHash, 2)
@@ -508,6 +518,47 @@ func TestStoreBatchBlobMapping(t *testing.T) {
 	assert.False(t, s.HasKey(ctx, blobIndexKey1))
 }
 
+func TestScanExpiredEntries(t *testing.T) {
+	s := createStore(t)
+	ctx := context.Background()
+
+	// Store a batch and the blobs not associated with any batch.
+	batchHeader, blobs, blobsProto := CreateBatch(t)
+	_, err := s.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	_, err = s.StoreBlobs(ctx, blobs, blobsProto)
+	assert.Nil(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+	batchHeaderKey := node.EncodeBatchHeaderKey(batchHeaderHash)
+
+	curTime := time.Now().Unix() + int64(staleMeasure+storeDuration)*12
+	// Nothing is expired before the expiry.
+	expired, err := s.ScanExpiredEntries(curTime-10, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, &node.ExpiredEntries{}, expired)
+
+	// The expired entries are found, but not deleted.
+	expired, err = s.ScanExpiredEntries(curTime+10, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, expired.NumBatches)
+	assert.Equal(t, 4, expired.NumBlobs)
+	assert.Positive(t, expired.ChunkBytes)
+	assert.True(t, s.HasKey(ctx, batchHeaderKey))
+
+	numBatchesDeleted, _, numBlobsDeleted, err := s.DeleteExpiredEntries(curTime+10, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, numBatchesDeleted)
+	assert.Equal(t, 2, numBlobsDeleted)
+	expired, err = s.ScanExpiredEntries(curTime+10, 5)
+	assert.Nil(t, err)
+	assert.Equal(t, &node.ExpiredEntries{}, expired)
+
+	// The space of the deleted entries is reclaimed by the compaction.
+	assert.Nil(t, s.Compact())
+	assert.False(t, s.HasKey(ctx, batchHeaderKey))
+}
+
 func decodeChunks(t *testing.T, s *node.Store, batchHeaderHash [32]byte, blobIdx int, chunkEncoding pb.ChunkEncodingFormat) []*encoding.Frame {
 	ctx := context.Background()
 	chunks, format, err := s.GetChunks(ctx, batchHeaderHash, blobIdx, 0)
@@ -563,6 +614,54 @@ func TestBundleEncodingEquivalence(t *testing.T) {
 	checkBundleEquivalence(t, bundle1, bundle2)
 }
 
+func TestStoreEncryptedChunks(t *testing.T) {
+	ctx := context.Background()
+	key := make([]byte, node.StorageEncryptionKeySize)
+	_, _ = cryptorand.Read(key)
+
+	s := createStore(t)
+	assert.Error(t, s.SetEncryptionKey(key[:16]))
+
+	// Unencrypted gob chunks
+	s1 := createStore(t)
+	batchHeader, blobs, blobsProto := CreateBatchWith(t, false)
+	_, err := s1.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	// Encrypted gob chunks
+	s2 := createStore(t)
+	assert.Nil(t, s2.SetEncryptionKey(key))
+	_, err = s2.StoreBatch(ctx, batchHeader, blobs, blobsProto)
+	assert.Nil(t, err)
+	// Encrypted gnark chunks
+	s3 := createStore(t)
+	assert.Nil(t, s3.SetEncryptionKey(key))
+	batchHeader3, blobs3, blobsProto3 := CreateBatchWith(t, true)
+	_, err = s3.StoreBatch(ctx, batchHeader3, blobs3, blobsProto3)
+	assert.Nil(t, err)
+
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.Nil(t, err)
+	for blobIdx := 0; blobIdx < 2; blobIdx++ {
+		bundle1 := decodeChunks(t, s1, batchHeaderHash, blobIdx, pb.ChunkEncodingFormat_GOB)
+		bundle2 := decodeChunks(t, s2, batchHeaderHash, blobIdx, pb.ChunkEncodingFormat_GOB)
+		bundle3 := decodeChunks(t, s3, batchHeaderHash, blobIdx, pb.ChunkEncodingFormat_GNARK)
+		checkBundleEquivalence(t, bundle1, bundle2)
+		checkBundleEquivalence(t, bundle1, bundle3)
+	}
+
+	// The chunks stored before the encryption is enabled are still served
+	assert.Nil(t, s1.SetEncryptionKey(key))
+	bundle := decodeChunks(t, s1, batchHeaderHash, 0, pb.ChunkEncodingFormat_GOB)
+	assert.Len(t, bundle, 1)
+
+	// The encrypted chunks can't be served with another key
+	otherKey := make([]byte, node.StorageEncryptionKeySize)
+	_, _ = cryptorand.Read(otherKey)
+	assert.Nil(t, s2.SetEncryptionKey(otherKey))
+	_, _, err = s2.GetChunks(ctx, batchHeaderHash, 0, 0)
+	assert.ErrorContains(t, err, "failed to decrypt chunks")
+}
+
 func BenchmarkEncodeChunks(b *testing.B) {
 	numSamples := 32
 	numChunks := 10
@@ -0,0 +1,146 @@
+package node
+
+import (
+	"context"
+	"crypto/tls"
+	"errors"
+	"fmt"
+	"os"
+	"sync"
+	"time"
+
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+// TLSConfig configures the TLS of the dispersal and retrieval gRPC servers
+type TLSConfig struct {
+	// CertFile and KeyFile are the PEM encoded certificate and private key of the servers. The servers are plaintext
+	// if they are empty.
+	CertFile string
+	KeyFile  string
+	// ReloadInterval is the interval at which the files are checked for changes. They are only reloaded on SIGHUP
+	// if it is 0.
+	ReloadInterval time.Duration
+}
+
+// CertReloader serves the certificate of the gRPC servers, and reloads it when its files change so that the
+// certificate can be rotated without restarting the node. The connections which are open keep their certificate,
+// while the new connections get the reloaded one.
+type CertReloader struct {
+	certFile string
+	keyFile  string
+	logger   logging.Logger
+
+	mu   sync.RWMutex
+	cert *tls.Certificate
+	// certModTime and keyModTime are the modification times of the files of the loaded certificate
+	certModTime time.Time
+	keyModTime  time.Time
+}
+
+// NewCertReloader returns a CertReloader which has loaded the certificate of the config. It returns nil if TLS is
+// disabled.
+func NewCertReloader(config TLSConfig, logger logging.Logger) (*CertReloader, error) {
+	if config.CertFile == "" && config.KeyFile == "" {
+		return nil, nil
+	}
+	if config.CertFile == "" || config.KeyFile == "" {
+		return nil, errors.New("both the TLS certificate and key files are required")
+	}
+	r := &CertReloader{
+		certFile: config.CertFile,
+		keyFile:  config.KeyFile,
+		logger:   logger.With("component", "CertReloader"),
+	}
+	if err := r.Reload(); err != nil {
+		return nil, err
+	}
+	return r, nil
+}
+
+// Reload loads the certificate from its files. The previous certificate is kept if they can't be loaded, e.g. when
+// only one of them has been replaced yet.
+func (r *CertReloader) Reload() error {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return err
+	}
+	cert, err := tls.LoadX509KeyPair(r.certFile, r.keyFile)
+	if err != nil {
+		return fmt.Errorf("failed to load the TLS certificate: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	r.cert = &cert
+	r.certModTime = certModTime
+	r.keyModTime = keyModTime
+	return nil
+}
+
+// ReloadIfChanged reloads the certificate if either of its files has been modified since it was loaded, and returns
+// whether it was reloaded
+func (r *CertReloader) ReloadIfChanged() (bool, error) {
+	certModTime, keyModTime, err := r.modTimes()
+	if err != nil {
+		return false, err
+	}
+	r.mu.RLock()
+	changed := !certModTime.Equal(r.certModTime) || !keyModTime.Equal(r.keyModTime)
+	r.mu.RUnlock()
+	if !changed {
+		return false, nil
+	}
+	if err := r.Reload(); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// Watch checks the files of the certificate for changes every interval until the context is done
+func (r *CertReloader) Watch(ctx context.Context, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			reloaded, err := r.ReloadIfChanged()
+			if err != nil {
+				r.logger.Error("Failed to reload the TLS certificate, the previous one is still served", "err", err)
+				continue
+			}
+			if reloaded {
+				r.logger.Info("Reloaded the TLS certificate", "certFile", r.certFile)
+			}
+		}
+	}
+}
+
+// GetCertificate returns the loaded certificate, it is meant for tls.Config.GetCertificate
+func (r *CertReloader) GetCertificate(*tls.ClientHelloInfo) (*tls.Certificate, error) {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.cert, nil
+}
+
+// TLSConfig returns the TLS config of the servers, which always serves the last loaded certificate
+func (r *CertReloader) TLSConfig() *tls.Config {
+	return &tls.Config{
+		GetCertificate: r.GetCertificate,
+		MinVersion:     tls.VersionTLS12,
+	}
+}
+
+func (r *CertReloader) modTimes() (time.Time, time.Time, error) {
+	certInfo, err := os.Stat(r.certFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat the TLS certificate file: %w", err)
+	}
+	keyInfo, err := os.Stat(r.keyFile)
+	if err != nil {
+		return time.Time{}, time.Time{}, fmt.Errorf("failed to stat the TLS key file: %w", err)
+	}
+	return certInfo.ModTime(), keyInfo.ModTime(), nil
+}
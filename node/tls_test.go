@@ -0,0 +1,84 @@
+package node_test
+
+import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/pem"
+	"math/big"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// writeCert writes a self-signed certificate with the serial number to the files, and sets their modification time
+func writeCert(t *testing.T, certFile, keyFile string, serial int64, modTime time.Time) {
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	require.NoError(t, err)
+	template := &x509.Certificate{
+		SerialNumber: big.NewInt(serial),
+		Subject:      pkix.Name{CommonName: "localhost"},
+		NotBefore:    time.Now().Add(-time.Hour),
+		NotAfter:     time.Now().Add(time.Hour),
+	}
+	der, err := x509.CreateCertificate(rand.Reader, template, template, &key.PublicKey, key)
+	require.NoError(t, err)
+	keyDer, err := x509.MarshalECPrivateKey(key)
+	require.NoError(t, err)
+
+	require.NoError(t, os.WriteFile(certFile, pem.EncodeToMemory(&pem.Block{Type: "CERTIFICATE", Bytes: der}), 0600))
+	require.NoError(t, os.WriteFile(keyFile, pem.EncodeToMemory(&pem.Block{Type: "EC PRIVATE KEY", Bytes: keyDer}), 0600))
+	require.NoError(t, os.Chtimes(certFile, modTime, modTime))
+	require.NoError(t, os.Chtimes(keyFile, modTime, modTime))
+}
+
+func servedSerial(t *testing.T, r *node.CertReloader) int64 {
+	cert, err := r.GetCertificate(nil)
+	require.NoError(t, err)
+	parsed, err := x509.ParseCertificate(cert.Certificate[0])
+	require.NoError(t, err)
+	return parsed.SerialNumber.Int64()
+}
+
+func TestCertReloader(t *testing.T) {
+	logger := logging.NewNoopLogger()
+	dir := t.TempDir()
+	certFile, keyFile := filepath.Join(dir, "cert.pem"), filepath.Join(dir, "key.pem")
+	modTime := time.Now().Add(-time.Minute)
+	writeCert(t, certFile, keyFile, 1, modTime)
+
+	r, err := node.NewCertReloader(node.TLSConfig{}, logger)
+	require.NoError(t, err)
+	assert.Nil(t, r)
+	_, err = node.NewCertReloader(node.TLSConfig{CertFile: certFile}, logger)
+	assert.Error(t, err)
+
+	r, err = node.NewCertReloader(node.TLSConfig{CertFile: certFile, KeyFile: keyFile}, logger)
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), servedSerial(t, r))
+	reloaded, err := r.ReloadIfChanged()
+	require.NoError(t, err)
+	assert.False(t, reloaded)
+
+	// The rotated certificate is served once its files change
+	writeCert(t, certFile, keyFile, 2, modTime.Add(time.Second))
+	reloaded, err = r.ReloadIfChanged()
+	require.NoError(t, err)
+	assert.True(t, reloaded)
+	assert.Equal(t, int64(2), servedSerial(t, r))
+
+	// The previous certificate is kept if the new files are invalid
+	require.NoError(t, os.WriteFile(certFile, []byte("not a certificate"), 0600))
+	_, err = r.ReloadIfChanged()
+	assert.Error(t, err)
+	assert.Error(t, r.Reload())
+	assert.Equal(t, int64(2), servedSerial(t, r))
+}
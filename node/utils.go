@@ -2,35 +2,16 @@ package node
 
 import (
 	"context"
-	"errors"
 	"fmt"
 
-	"github.com/Layr-Labs/eigenda/api"
+	"github.com/Layr-Labs/eigenda/api/conversion"
 	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
 	"github.com/Layr-Labs/eigenda/common/pubip"
 	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/encoding"
-	"github.com/consensys/gnark-crypto/ecc/bn254"
-	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 	"github.com/gammazero/workerpool"
 )
 
-// GetBatchHeader constructs a core.BatchHeader from a proto of pb.StoreChunksRequest.
-// Note the StoreChunksRequest is validated as soon as it enters the node gRPC
-// interface, see grpc.Server.validateStoreChunkRequest.
-func GetBatchHeader(in *pb.BatchHeader) (*core.BatchHeader, error) {
-	if in == nil || len(in.GetBatchRoot()) == 0 {
-		return nil, api.NewInvalidArgError("batch header is nil or empty")
-	}
-	var batchRoot [32]byte
-	copy(batchRoot[:], in.GetBatchRoot())
-	batchHeader := core.BatchHeader{
-		ReferenceBlockNumber: uint(in.GetReferenceBlockNumber()),
-		BatchRoot:            batchRoot,
-	}
-	return &batchHeader, nil
-}
-
 // GetBlobMessages constructs a core.BlobMessage array from blob protobufs.
 // Note the proto request is validated as soon as it enters the node gRPC
 // interface. This method assumes the blobs are valid.
@@ -42,7 +23,7 @@ func GetBlobMessages(pbBlobs []*pb.Blob, numWorkers int) ([]*core.BlobMessage, e
 		i := i
 		blob := blob
 		pool.Submit(func() {
-			blobHeader, err := GetBlobHeaderFromProto(blob.GetHeader())
+			blobHeader, err := conversion.BlobHeaderFromProto(blob.GetHeader())
 
 			if err != nil {
 				resultChan <- err
@@ -102,117 +83,6 @@ func GetBlobMessages(pbBlobs []*pb.Blob, numWorkers int) ([]*core.BlobMessage, e
 	return blobs, nil
 }
 
-func ValidatePointsFromBlobHeader(h *pb.BlobHeader) error {
-	commitX := new(fp.Element).SetBytes(h.GetCommitment().GetX())
-	commitY := new(fp.Element).SetBytes(h.GetCommitment().GetY())
-	commitment := &encoding.G1Commitment{
-		X: *commitX,
-		Y: *commitY,
-	}
-
-	if !(*bn254.G1Affine)(commitment).IsInSubGroup() {
-		return errors.New("commitment is not in the subgroup")
-	}
-
-	var lengthCommitment, lengthProof encoding.G2Commitment
-	if h.GetLengthCommitment() != nil {
-		lengthCommitment.X.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA0())
-		lengthCommitment.X.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA1())
-		lengthCommitment.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA0())
-		lengthCommitment.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA1())
-	}
-
-	if !(*bn254.G2Affine)(&lengthCommitment).IsInSubGroup() {
-		return errors.New("lengthCommitment is not in the subgroup")
-	}
-
-	if h.GetLengthProof() != nil {
-		lengthProof.X.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA0())
-		lengthProof.X.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA1())
-		lengthProof.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA0())
-		lengthProof.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA1())
-	}
-
-	if !(*bn254.G2Affine)(&lengthProof).IsInSubGroup() {
-		return errors.New("lengthProof is not in the subgroup")
-	}
-	return nil
-}
-
-// GetBlobHeaderFromProto constructs a core.BlobHeader from a proto of pb.BlobHeader.
-func GetBlobHeaderFromProto(h *pb.BlobHeader) (*core.BlobHeader, error) {
-
-	if h == nil {
-		return nil, api.NewInvalidArgError("GetBlobHeaderFromProto: blob header is nil")
-
-	}
-
-	commitX := new(fp.Element).SetBytes(h.GetCommitment().GetX())
-	commitY := new(fp.Element).SetBytes(h.GetCommitment().GetY())
-	commitment := &encoding.G1Commitment{
-		X: *commitX,
-		Y: *commitY,
-	}
-
-	if !(*bn254.G1Affine)(commitment).IsInSubGroup() {
-		return nil, errors.New("commitment is not in the subgroup")
-	}
-
-	var lengthCommitment, lengthProof encoding.G2Commitment
-	if h.GetLengthCommitment() != nil {
-		lengthCommitment.X.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA0())
-		lengthCommitment.X.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetXA1())
-		lengthCommitment.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA0())
-		lengthCommitment.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthCommitment().GetYA1())
-	}
-
-	if !(*bn254.G2Affine)(&lengthCommitment).IsInSubGroup() {
-		return nil, errors.New("lengthCommitment is not in the subgroup")
-	}
-
-	if h.GetLengthProof() != nil {
-		lengthProof.X.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA0())
-		lengthProof.X.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetXA1())
-		lengthProof.Y.A0 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA0())
-		lengthProof.Y.A1 = *new(fp.Element).SetBytes(h.GetLengthProof().GetYA1())
-	}
-
-	if !(*bn254.G2Affine)(&lengthProof).IsInSubGroup() {
-		return nil, errors.New("lengthProof is not in the subgroup")
-	}
-
-	quorumHeaders := make([]*core.BlobQuorumInfo, len(h.GetQuorumHeaders()))
-	for i, header := range h.GetQuorumHeaders() {
-		if header.GetQuorumId() > core.MaxQuorumID {
-			return nil, api.NewInvalidArgError(fmt.Sprintf("quorum ID must be in range [0, %d], but found %d", core.MaxQuorumID, header.GetQuorumId()))
-		}
-		if err := core.ValidateSecurityParam(header.GetConfirmationThreshold(), header.GetAdversaryThreshold()); err != nil {
-			return nil, err
-		}
-
-		quorumHeaders[i] = &core.BlobQuorumInfo{
-			SecurityParam: core.SecurityParam{
-				QuorumID:              core.QuorumID(header.GetQuorumId()),
-				AdversaryThreshold:    uint8(header.GetAdversaryThreshold()),
-				ConfirmationThreshold: uint8(header.GetConfirmationThreshold()),
-				QuorumRate:            header.GetRatelimit(),
-			},
-			ChunkLength: uint(header.GetChunkLength()),
-		}
-	}
-
-	return &core.BlobHeader{
-		BlobCommitments: encoding.BlobCommitments{
-			Commitment:       commitment,
-			LengthCommitment: &lengthCommitment,
-			LengthProof:      &lengthProof,
-			Length:           uint(h.GetLength()),
-		},
-		QuorumInfos: quorumHeaders,
-		AccountID:   h.AccountId,
-	}, nil
-}
-
 func SocketAddress(ctx context.Context, provider pubip.Provider, dispersalPort string, retrievalPort string) (string, error) {
 	ip, err := provider.PublicIPAddress(ctx)
 	if err != nil {
@@ -0,0 +1,171 @@
+package retriever
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"math/big"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/retriever/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+)
+
+// BlobAudit is the result of reconstructing a blob of a batch from the chunks of the operators of one quorum
+type BlobAudit struct {
+	BlobIndex uint32        `json:"blobIndex"`
+	QuorumID  core.QuorumID `json:"quorumId"`
+	// Recoverable is true if the blob was reconstructed from the chunks served by the operators
+	Recoverable bool `json:"recoverable"`
+	// NumOperators is the number of operators assigned chunks of the blob
+	NumOperators int `json:"numOperators"`
+	// NumResponded is the number of operators that served valid chunks
+	NumResponded    int    `json:"numResponded"`
+	ChunksRequired  uint64 `json:"chunksRequired"`
+	ChunksRetrieved uint64 `json:"chunksRetrieved"`
+	// Margin is the number of operators that served valid chunks which could fail, in any combination,
+	// while the blob remains recoverable
+	Margin int    `json:"margin"`
+	Err    string `json:"error,omitempty"`
+}
+
+// AuditReport is the data availability audit of a batch
+type AuditReport struct {
+	BatchHeaderHash      string       `json:"batchHeaderHash"`
+	ReferenceBlockNumber uint32       `json:"referenceBlockNumber"`
+	NumBlobs             int          `json:"numBlobs"`
+	Blobs                []*BlobAudit `json:"blobs"`
+}
+
+// NumUnrecoverable returns the number of blob audits for which the blob could not be reconstructed
+func (r *AuditReport) NumUnrecoverable() int {
+	n := 0
+	for _, blob := range r.Blobs {
+		if !blob.Recoverable {
+			n++
+		}
+	}
+	return n
+}
+
+// Auditor checks that the blobs of a batch can be reconstructed from the chunks held by the operators
+type Auditor struct {
+	retrievalClient           clients.RetrievalClient
+	chainClient               eth.ChainClient
+	eigenDAServiceManagerAddr gcommon.Address
+	logger                    logging.Logger
+}
+
+func NewAuditor(
+	retrievalClient clients.RetrievalClient,
+	chainClient eth.ChainClient,
+	eigenDAServiceManagerAddr gcommon.Address,
+	logger logging.Logger,
+) *Auditor {
+	return &Auditor{
+		retrievalClient:           retrievalClient,
+		chainClient:               chainClient,
+		eigenDAServiceManagerAddr: eigenDAServiceManagerAddr,
+		logger:                    logger.With("component", "Auditor"),
+	}
+}
+
+// Audit attempts to reconstruct every blob of the batch from the operators of each of the given quorums, or of all
+// the quorums of the batch if none are given. The number of blobs in a batch is not recorded onchain, so blobs are
+// audited in order of index until no operator serves a header for the next index.
+func (a *Auditor) Audit(ctx context.Context, batchHeaderHash [32]byte, fromBlock *big.Int, quorumIDs []core.QuorumID) (*AuditReport, error) {
+	batchHeader, err := a.chainClient.FetchBatchHeader(ctx, a.eigenDAServiceManagerAddr, batchHeaderHash[:], fromBlock, nil)
+	if err != nil {
+		return nil, fmt.Errorf("failed to fetch batch header: %w", err)
+	}
+	if len(quorumIDs) == 0 {
+		for _, quorumID := range batchHeader.QuorumNumbers {
+			quorumIDs = append(quorumIDs, core.QuorumID(quorumID))
+		}
+	}
+
+	report := &AuditReport{
+		BatchHeaderHash:      hexutil.Encode(batchHeaderHash[:]),
+		ReferenceBlockNumber: batchHeader.ReferenceBlockNumber,
+		Blobs:                make([]*BlobAudit, 0),
+	}
+	for blobIndex := uint32(0); ; blobIndex++ {
+		found := false
+		for _, quorumID := range quorumIDs {
+			audit := &BlobAudit{
+				BlobIndex: blobIndex,
+				QuorumID:  quorumID,
+			}
+			chunks, err := a.retrievalClient.RetrieveBlobChunks(ctx, batchHeaderHash, blobIndex, uint(batchHeader.ReferenceBlockNumber), batchHeader.BlobHeadersRoot, quorumID)
+			if errors.Is(err, clients.ErrBlobHeaderNotFound) {
+				continue
+			}
+			found = true
+			if errors.Is(err, clients.ErrQuorumNotInBlob) {
+				continue
+			}
+			if err != nil {
+				audit.Err = err.Error()
+			} else {
+				auditChunks(audit, chunks)
+				if _, err := a.retrievalClient.CombineChunks(chunks); err != nil {
+					audit.Recoverable = false
+					audit.Err = err.Error()
+				} else {
+					audit.Recoverable = true
+				}
+			}
+			a.logger.Info("audited blob", "blobIndex", blobIndex, "quorum", quorumID, "recoverable", audit.Recoverable, "margin", audit.Margin, "err", audit.Err)
+			report.Blobs = append(report.Blobs, audit)
+		}
+		if !found {
+			break
+		}
+		report.NumBlobs++
+	}
+	if report.NumBlobs == 0 {
+		return nil, fmt.Errorf("no operator serves the blobs of batch %s", report.BatchHeaderHash)
+	}
+
+	return report, nil
+}
+
+// auditChunks fills in which operators served valid chunks and how many of them could fail before the blob becomes
+// unrecoverable. The operators holding the most chunks are assumed to fail first.
+func auditChunks(audit *BlobAudit, chunks *clients.BlobChunks) {
+	retrieved := make(map[encoding.ChunkNumber]struct{}, len(chunks.Indices))
+	for _, index := range chunks.Indices {
+		retrieved[index] = struct{}{}
+	}
+
+	respondedChunks := make([]uint64, 0, len(chunks.Assignments))
+	for _, assignment := range chunks.Assignments {
+		if assignment.NumChunks == 0 {
+			continue
+		}
+		audit.NumOperators++
+		if _, ok := retrieved[assignment.StartIndex]; ok {
+			respondedChunks = append(respondedChunks, uint64(assignment.NumChunks))
+		}
+	}
+	audit.NumResponded = len(respondedChunks)
+	audit.ChunksRetrieved = uint64(len(chunks.Indices))
+	if chunks.EncodingParams.ChunkLength > 0 {
+		audit.ChunksRequired = (uint64(chunks.BlobHeaderLength) + chunks.EncodingParams.ChunkLength - 1) / chunks.EncodingParams.ChunkLength
+	}
+
+	sort.Slice(respondedChunks, func(i, j int) bool { return respondedChunks[i] > respondedChunks[j] })
+	remaining := audit.ChunksRetrieved
+	for _, numChunks := range respondedChunks {
+		if remaining < audit.ChunksRequired+numChunks {
+			break
+		}
+		remaining -= numChunks
+		audit.Margin++
+	}
+}
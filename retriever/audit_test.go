@@ -0,0 +1,116 @@
+package retriever_test
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigenda/retriever/mock"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/stretchr/testify/assert"
+)
+
+func makeBlobChunks(respondedOperators ...int) *clients.BlobChunks {
+	assignments := map[core.OperatorID]core.Assignment{
+		{0}: {StartIndex: 0, NumChunks: 2},
+		{1}: {StartIndex: 2, NumChunks: 2},
+		{2}: {StartIndex: 4, NumChunks: 4},
+		{3}: {StartIndex: 8, NumChunks: 0},
+	}
+	chunks := &clients.BlobChunks{
+		EncodingParams:   encoding.EncodingParams{ChunkLength: 1, NumChunks: 8},
+		BlobHeaderLength: 4,
+		Assignments:      assignments,
+	}
+	for _, op := range respondedOperators {
+		assignment := assignments[core.OperatorID{byte(op)}]
+		chunks.Indices = append(chunks.Indices, assignment.GetIndices()...)
+		for range assignment.GetIndices() {
+			chunks.Chunks = append(chunks.Chunks, &encoding.Frame{})
+		}
+	}
+	return chunks
+}
+
+func TestAudit(t *testing.T) {
+	retrievalClient := clientsmock.NewRetrievalClient()
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:      batchRoot,
+		QuorumNumbers:        []byte{0, 1},
+		ReferenceBlockNumber: 10,
+	}, nil)
+
+	allResponded := makeBlobChunks(0, 1, 2)
+	retrievalClient.On("RetrieveBlobChunks", batchHeaderHash, uint32(0), uint(10), batchRoot, core.QuorumID(0)).Return(allResponded, nil)
+	retrievalClient.On("CombineChunks", allResponded).Return(gettysburgAddressBytes, nil)
+	retrievalClient.On("RetrieveBlobChunks", batchHeaderHash, uint32(0), uint(10), batchRoot, core.QuorumID(1)).Return((*clients.BlobChunks)(nil), errors.New("failed to get assignments"))
+
+	largestFailed := makeBlobChunks(0, 1)
+	retrievalClient.On("RetrieveBlobChunks", batchHeaderHash, uint32(1), uint(10), batchRoot, core.QuorumID(0)).Return(largestFailed, nil)
+	retrievalClient.On("CombineChunks", largestFailed).Return(gettysburgAddressBytes, nil)
+	retrievalClient.On("RetrieveBlobChunks", batchHeaderHash, uint32(1), uint(10), batchRoot, core.QuorumID(1)).Return((*clients.BlobChunks)(nil), clients.ErrQuorumNotInBlob)
+
+	for _, quorumID := range []core.QuorumID{0, 1} {
+		retrievalClient.On("RetrieveBlobChunks", batchHeaderHash, uint32(2), uint(10), batchRoot, quorumID).Return((*clients.BlobChunks)(nil), clients.ErrBlobHeaderNotFound)
+	}
+
+	auditor := retriever.NewAuditor(retrievalClient, chainClient, gcommon.Address{}, logging.NewNoopLogger())
+	report, err := auditor.Audit(context.Background(), batchHeaderHash, nil, nil)
+	assert.NoError(t, err)
+	assert.Equal(t, uint32(10), report.ReferenceBlockNumber)
+	assert.Equal(t, 2, report.NumBlobs)
+	assert.Len(t, report.Blobs, 3)
+	assert.Equal(t, 1, report.NumUnrecoverable())
+
+	assert.Equal(t, &retriever.BlobAudit{
+		BlobIndex:       0,
+		QuorumID:        0,
+		Recoverable:     true,
+		NumOperators:    3,
+		NumResponded:    3,
+		ChunksRequired:  4,
+		ChunksRetrieved: 8,
+		Margin:          1,
+	}, report.Blobs[0])
+	assert.False(t, report.Blobs[1].Recoverable)
+	assert.Equal(t, core.QuorumID(1), report.Blobs[1].QuorumID)
+	assert.Equal(t, "failed to get assignments", report.Blobs[1].Err)
+	assert.Equal(t, &retriever.BlobAudit{
+		BlobIndex:       1,
+		QuorumID:        0,
+		Recoverable:     true,
+		NumOperators:    3,
+		NumResponded:    2,
+		ChunksRequired:  4,
+		ChunksRetrieved: 4,
+		Margin:          0,
+	}, report.Blobs[2])
+
+	// Auditing a single quorum
+	report, err = auditor.Audit(context.Background(), batchHeaderHash, nil, []core.QuorumID{0})
+	assert.NoError(t, err)
+	assert.Len(t, report.Blobs, 2)
+	assert.Equal(t, 0, report.NumUnrecoverable())
+}
+
+func TestAuditBatchNotServed(t *testing.T) {
+	retrievalClient := clientsmock.NewRetrievalClient()
+	chainClient := mock.NewMockChainClient()
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot: batchRoot,
+		QuorumNumbers:   []byte{0},
+	}, nil)
+	retrievalClient.On("RetrieveBlobChunks", batchHeaderHash, uint32(0), uint(0), batchRoot, core.QuorumID(0)).Return((*clients.BlobChunks)(nil), clients.ErrBlobHeaderNotFound)
+
+	auditor := retriever.NewAuditor(retrievalClient, chainClient, gcommon.Address{}, logging.NewNoopLogger())
+	_, err := auditor.Audit(context.Background(), batchHeaderHash, nil, nil)
+	assert.Error(t, err)
+}
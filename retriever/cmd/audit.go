@@ -0,0 +1,83 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"math/big"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigenda/retriever/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/ethereum/go-ethereum/common/hexutil"
+	"github.com/urfave/cli"
+)
+
+// AuditMain reconstructs every blob of a batch directly from the operators and writes the audit report.
+// It fails if any blob cannot be reconstructed.
+func AuditMain(ctx *cli.Context) error {
+	// The retriever options are set on the application rather than on the command
+	config, err := retriever.NewConfig(ctx.Parent())
+	if err != nil {
+		return fmt.Errorf("failed to parse the command line flags: %w", err)
+	}
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return fmt.Errorf("failed to create logger: %w", err)
+	}
+
+	hash, err := hexutil.Decode(ctx.String(flags.AuditBatchHeaderHashFlag.Name))
+	if err != nil || len(hash) != 32 {
+		return fmt.Errorf("invalid batch header hash %q", ctx.String(flags.AuditBatchHeaderHashFlag.Name))
+	}
+	var batchHeaderHash [32]byte
+	copy(batchHeaderHash[:], hash)
+
+	quorumIDs := make([]core.QuorumID, 0)
+	for _, quorumID := range ctx.IntSlice(flags.AuditQuorumIDsFlag.Name) {
+		if quorumID < 0 || quorumID > core.MaxQuorumID {
+			return fmt.Errorf("invalid quorum ID %d", quorumID)
+		}
+		quorumIDs = append(quorumIDs, core.QuorumID(quorumID))
+	}
+	var fromBlock *big.Int
+	if ctx.IsSet(flags.AuditFromBlockFlag.Name) {
+		fromBlock = new(big.Int).SetUint64(ctx.Uint64(flags.AuditFromBlockFlag.Name))
+	}
+
+	retrievalClient, ics, chainClient, err := newRetrievalClient(config, logger)
+	if err != nil {
+		return fmt.Errorf("failed to create retrieval client: %w", err)
+	}
+	if err := ics.Start(context.Background()); err != nil {
+		return fmt.Errorf("failed to start indexed chain state: %w", err)
+	}
+
+	auditor := retriever.NewAuditor(retrievalClient, chainClient, gethcommon.HexToAddress(config.EigenDAServiceManagerAddr), logger)
+	report, err := auditor.Audit(context.Background(), batchHeaderHash, fromBlock, quorumIDs)
+	if err != nil {
+		return err
+	}
+
+	out := os.Stdout
+	if path := ctx.String(flags.AuditOutputFlag.Name); path != "" {
+		out, err = os.Create(path)
+		if err != nil {
+			return fmt.Errorf("failed to create audit report file: %w", err)
+		}
+		defer out.Close()
+	}
+	encoder := json.NewEncoder(out)
+	encoder.SetIndent("", "  ")
+	if err := encoder.Encode(report); err != nil {
+		return fmt.Errorf("failed to write audit report: %w", err)
+	}
+
+	if n := report.NumUnrecoverable(); n > 0 {
+		return fmt.Errorf("%d of the audited blobs of batch %s are not recoverable", n, report.BatchHeaderHash)
+	}
+	return nil
+}
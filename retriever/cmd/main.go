@@ -20,6 +20,7 @@ import (
 	"github.com/Layr-Labs/eigenda/retriever"
 	retrivereth "github.com/Layr-Labs/eigenda/retriever/eth"
 	"github.com/Layr-Labs/eigenda/retriever/flags"
+	"github.com/Layr-Labs/eigensdk-go/logging"
 	gethcommon "github.com/ethereum/go-ethereum/common"
 	"github.com/ethereum/go-ethereum/rpc"
 	"github.com/urfave/cli"
@@ -41,17 +42,27 @@ func main() {
 	app.Description = "Service for collecting coded chunks and decode the original data"
 	app.Flags = flags.Flags
 	app.Action = RetrieverMain
+	app.Commands = []cli.Command{
+		{
+			Name:      "audit",
+			Usage:     "Reconstruct every blob of a batch from the operators and report the data availability margin",
+			ArgsUsage: " ",
+			Flags:     flags.AuditFlags,
+			Action:    AuditMain,
+		},
+	}
 	if err := app.Run(os.Args); err != nil {
 		log.Fatalf("application failed: %v", err)
 	}
-
-	select {}
 }
 
 func RetrieverMain(ctx *cli.Context) error {
 	log.Println("Initializing Retriever")
 	hostname := ctx.String(flags.HostnameFlag.Name)
 	port := ctx.String(flags.GrpcPortFlag.Name)
+	if hostname == "" || port == "" {
+		return fmt.Errorf("flags %s and %s are required to run the retriever service", flags.HostnameFlag.Name, flags.GrpcPortFlag.Name)
+	}
 	addr := fmt.Sprintf("%s:%s", hostname, port)
 	listener, err := net.Listen("tcp", addr)
 	if err != nil {
@@ -77,14 +88,50 @@ func RetrieverMain(ctx *cli.Context) error {
 		log.Fatalf("failed to create logger: %v", err)
 	}
 
+	retrievalClient, ics, chainClient, err := newRetrievalClient(config, logger)
+	if err != nil {
+		log.Fatalln("could not create retrieval client", err)
+	}
+
+	retrieverServiceServer := retriever.NewServer(config, logger, retrievalClient, ics, chainClient)
+	if err = retrieverServiceServer.Start(context.Background()); err != nil {
+		log.Fatalln("failed to start retriever service server", err)
+	}
+
+	// Register reflection service on gRPC server
+	// This makes "grpcurl -plaintext localhost:9000 list" command work
+	reflection.Register(gs)
+
+	pb.RegisterRetrieverServer(gs, retrieverServiceServer)
+
+	if config.HTTPPort != "" {
+		gateway := retriever.NewGateway(retrieverServiceServer, config.GatewayConfig, logger)
+		go func() {
+			if err := gateway.Serve(context.Background(), fmt.Sprintf("%s:%s", hostname, config.HTTPPort)); err != nil {
+				log.Fatalln("retriever gateway failed", err)
+			}
+		}()
+	}
+
+	// Register Server for Health Checks
+	name := pb.Retriever_ServiceDesc.ServiceName
+	healthcheck.RegisterHealthServer(name, gs)
+
+	log.Printf("server listening at %s", addr)
+	return gs.Serve(listener)
+}
+
+// newRetrievalClient creates the client retrieving the chunks of the blobs from the operators,
+// along with the indexed chain state it uses and the client fetching the batch headers from the chain
+func newRetrievalClient(config *retriever.Config, logger logging.Logger) (clients.RetrievalClient, core.IndexedChainState, retrivereth.ChainClient, error) {
 	nodeClient := clients.NewNodeClient(config.Timeout)
 	v, err := verifier.NewVerifier(&config.EncoderConfig, true)
 	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+		return nil, nil, nil, err
 	}
 	gethClient, err := geth.NewMultiHomingClient(config.EthClientConfig, gethcommon.Address{}, logger)
 	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+		return nil, nil, nil, err
 	}
 
 	// TODO(ian-shim): uncomment when https://github.com/Layr-Labs/eigenda-internal/issues/77 is done
@@ -95,12 +142,12 @@ func RetrieverMain(ctx *cli.Context) error {
 
 	tx, err := eth.NewTransactor(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
 	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+		return nil, nil, nil, err
 	}
 	cs := eth.NewChainState(tx, gethClient)
 	rpcClient, err := rpc.Dial(config.EthClientConfig.RPCURLs[0])
 	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+		return nil, nil, nil, err
 	}
 
 	var ics core.IndexedChainState
@@ -120,36 +167,24 @@ func RetrieverMain(ctx *cli.Context) error {
 			logger,
 		)
 		if err != nil {
-			return err
+			return nil, nil, nil, err
 		}
 		ics, err = coreindexer.NewIndexedChainState(cs, indexer)
 		if err != nil {
-			return err
+			return nil, nil, nil, err
 		}
 	}
 
-	agn := &core.StdAssignmentCoordinator{}
-	retrievalClient, err := clients.NewRetrievalClient(logger, ics, agn, nodeClient, v, config.NumConnections)
+	selectionPolicy, err := retriever.NewOperatorSelectionPolicy(config)
 	if err != nil {
-		log.Fatalln("could not start tcp listener", err)
+		return nil, nil, nil, err
 	}
 
-	chainClient := retrivereth.NewChainClient(gethClient, logger)
-	retrieverServiceServer := retriever.NewServer(config, logger, retrievalClient, ics, chainClient)
-	if err = retrieverServiceServer.Start(context.Background()); err != nil {
-		log.Fatalln("failed to start retriever service server", err)
+	agn := &core.StdAssignmentCoordinator{}
+	retrievalClient, err := clients.NewRetrievalClient(logger, ics, agn, nodeClient, v, config.NumConnections, selectionPolicy)
+	if err != nil {
+		return nil, nil, nil, err
 	}
 
-	// Register reflection service on gRPC server
-	// This makes "grpcurl -plaintext localhost:9000 list" command work
-	reflection.Register(gs)
-
-	pb.RegisterRetrieverServer(gs, retrieverServiceServer)
-
-	// Register Server for Health Checks
-	name := pb.Retriever_ServiceDesc.ServiceName
-	healthcheck.RegisterHealthServer(name, gs)
-
-	log.Printf("server listening at %s", addr)
-	return gs.Serve(listener)
+	return retrievalClient, ics, retrivereth.NewChainClient(gethClient, logger), nil
 }
@@ -1,8 +1,10 @@
 package retriever
 
 import (
+	"fmt"
 	"time"
 
+	"github.com/Layr-Labs/eigenda/api/clients"
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
@@ -26,6 +28,12 @@ type Config struct {
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
 	UseGraph                      bool
+	// OperatorSelection is the policy ordering the operators the chunks are requested from, adaptive or stake
+	OperatorSelection      string
+	OperatorStatsSmoothing float64
+	// HTTPPort is the port of the HTTP gateway, which is disabled if it is empty
+	HTTPPort      string
+	GatewayConfig GatewayConfig
 }
 
 func ReadRetrieverConfig(ctx *cli.Context) *Config {
@@ -43,6 +51,15 @@ func ReadRetrieverConfig(ctx *cli.Context) *Config {
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
 		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
 		UseGraph:                      ctx.GlobalBool(flags.UseGraphFlag.Name),
+		OperatorSelection:             ctx.GlobalString(flags.OperatorSelectionFlag.Name),
+		OperatorStatsSmoothing:        ctx.GlobalFloat64(flags.OperatorStatsSmoothingFlag.Name),
+		HTTPPort:                      ctx.GlobalString(flags.HTTPPortFlag.Name),
+		GatewayConfig: GatewayConfig{
+			CacheMaxAge: ctx.GlobalDuration(flags.HTTPCacheMaxAgeFlag.Name),
+			EnableGzip:  ctx.GlobalBool(flags.HTTPGzipFlag.Name),
+			RateLimit:   ctx.GlobalFloat64(flags.HTTPRateLimitFlag.Name),
+			RateBurst:   ctx.GlobalInt(flags.HTTPRateBurstFlag.Name),
+		},
 	}
 }
 
@@ -55,5 +72,28 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	config := ReadRetrieverConfig(ctx)
 	config.LoggerConfig = *loggerConfig
 
+	if _, err := NewOperatorSelectionPolicy(config); err != nil {
+		return nil, err
+	}
+	if config.GatewayConfig.RateLimit < 0 {
+		return nil, fmt.Errorf("%s must not be negative", flags.HTTPRateLimitFlag.Name)
+	}
+
 	return config, nil
 }
+
+// NewOperatorSelectionPolicy returns the policy ordering the operators the chunks are requested from. The operators
+// are ordered by stake if no policy is configured.
+func NewOperatorSelectionPolicy(config *Config) (clients.OperatorSelectionPolicy, error) {
+	switch config.OperatorSelection {
+	case "adaptive":
+		if config.OperatorStatsSmoothing <= 0 || config.OperatorStatsSmoothing > 1 {
+			return nil, fmt.Errorf("operator stats smoothing must be in (0, 1], got %v", config.OperatorStatsSmoothing)
+		}
+		return clients.NewAdaptiveSelectionPolicy(config.OperatorStatsSmoothing), nil
+	case "stake", "":
+		return clients.NewStakeWeightedSelectionPolicy(), nil
+	default:
+		return nil, fmt.Errorf("unknown operator selection policy %q", config.OperatorSelection)
+	}
+}
@@ -1,6 +1,8 @@
 package flags
 
 import (
+	"time"
+
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
@@ -16,16 +18,18 @@ const (
 
 var (
 	/* Required Flags */
+
+	// HostnameFlag and GrpcPortFlag are only required to run the retriever service, which checks that they are set
 	HostnameFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "hostname"),
-		Usage:    "Hostname at which retriever service is available",
-		Required: true,
+		Usage:    "Hostname at which retriever service is available. Required unless running the audit command",
+		Required: false,
 		EnvVar:   common.PrefixEnvVar(envPrefix, "HOSTNAME"),
 	}
 	GrpcPortFlag = cli.StringFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "grpc-port"),
-		Usage:    "Port at which a retriever listens for grpc calls",
-		Required: true,
+		Usage:    "Port at which a retriever listens for grpc calls. Required unless running the audit command",
+		Required: false,
 		EnvVar:   common.PrefixEnvVar(envPrefix, "GRPC_PORT"),
 	}
 	TimeoutFlag = cli.DurationFlag{
@@ -74,8 +78,91 @@ var (
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envPrefix, "USE_GRAPH"),
 	}
+	OperatorSelectionFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-selection"),
+		Usage:    "Policy ordering the operators the chunks are requested from: adaptive, to prefer the operators with the lowest latency and error rate, or stake, to order them by stake",
+		Required: false,
+		Value:    "adaptive",
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_SELECTION"),
+	}
+	OperatorStatsSmoothingFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-stats-smoothing"),
+		Usage:    "Weight, in (0, 1], of the latest request in the moving averages of the latency and error rate of an operator used by the adaptive operator selection",
+		Required: false,
+		Value:    0.2,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_STATS_SMOOTHING"),
+	}
+	HTTPPortFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "http-port"),
+		Usage:    "Port at which the HTTP gateway serves the blobs to the clients without gRPC. The gateway is disabled if it is not set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HTTP_PORT"),
+	}
+	HTTPCacheMaxAgeFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "http-cache-max-age"),
+		Usage:    "How long the clients of the HTTP gateway may cache a blob",
+		Required: false,
+		Value:    time.Hour,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HTTP_CACHE_MAX_AGE"),
+	}
+	HTTPGzipFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "http-gzip"),
+		Usage:    "Whether the HTTP gateway compresses the blobs for the clients which accept gzip",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HTTP_GZIP"),
+	}
+	HTTPRateLimitFlag = cli.Float64Flag{
+		Name:     common.PrefixFlag(FlagPrefix, "http-rate-limit"),
+		Usage:    "Average number of requests per second each client IP may make to the HTTP gateway, 0 for no limit",
+		Required: false,
+		Value:    1,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HTTP_RATE_LIMIT"),
+	}
+	HTTPRateBurstFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "http-rate-burst"),
+		Usage:    "Number of requests each client IP may make to the HTTP gateway in a burst",
+		Required: false,
+		Value:    5,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "HTTP_RATE_BURST"),
+	}
+)
+
+var (
+	/* Audit Command Flags */
+	AuditBatchHeaderHashFlag = cli.StringFlag{
+		Name:     "batch-header-hash",
+		Usage:    "Hex encoded hash of the header of the batch to audit",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_BATCH_HEADER_HASH"),
+	}
+	AuditQuorumIDsFlag = cli.IntSliceFlag{
+		Name:     "quorum-id",
+		Usage:    "Quorum whose operators the blobs are reconstructed from. Defaults to all the quorums of the batch",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_QUORUM_IDS"),
+	}
+	AuditFromBlockFlag = cli.Uint64Flag{
+		Name:     "from-block",
+		Usage:    "Block from which to search for the confirmation of the batch. Defaults to the genesis block",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_FROM_BLOCK"),
+	}
+	AuditOutputFlag = cli.StringFlag{
+		Name:     "output",
+		Usage:    "Path of the file the JSON audit report is written to. Defaults to stdout",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "AUDIT_OUTPUT"),
+	}
 )
 
+// AuditFlags contains the options of the audit command
+var AuditFlags = []cli.Flag{
+	AuditBatchHeaderHashFlag,
+	AuditQuorumIDsFlag,
+	AuditFromBlockFlag,
+	AuditOutputFlag,
+}
+
 func RetrieverFlags(envPrefix string) []cli.Flag {
 	return []cli.Flag{
 		HostnameFlag,
@@ -87,6 +174,13 @@ func RetrieverFlags(envPrefix string) []cli.Flag {
 		IndexerDataDirFlag,
 		MetricsHTTPPortFlag,
 		UseGraphFlag,
+		OperatorSelectionFlag,
+		OperatorStatsSmoothingFlag,
+		HTTPPortFlag,
+		HTTPCacheMaxAgeFlag,
+		HTTPGzipFlag,
+		HTTPRateLimitFlag,
+		HTTPRateBurstFlag,
 	}
 }
 
@@ -0,0 +1,303 @@
+package retriever
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"net"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/retriever"
+	"github.com/Layr-Labs/eigenda/common/ratelimit"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+)
+
+const (
+	// blobsPath is the prefix of the path of the blobs, followed by the batch header hash and the blob index
+	blobsPath = "/blobs/"
+
+	contentTypeJSON  = "application/json"
+	contentTypeOctet = "application/octet-stream"
+)
+
+// representationTags distinguish the ETags of the representations of a blob by content type
+var representationTags = map[string]string{
+	contentTypeJSON:  "json",
+	contentTypeOctet: "raw",
+}
+
+// GatewayConfig configures the HTTP gateway of the retriever
+type GatewayConfig struct {
+	// CacheMaxAge is how long the clients and proxies may cache a blob. The blobs are immutable, but they are only
+	// retrievable until they expire on the operators.
+	CacheMaxAge time.Duration
+	// EnableGzip compresses the blobs for the clients which accept gzip
+	EnableGzip bool
+	// RateLimit is the number of requests per second each client IP may make on average, with bursts of up to
+	// RateBurst requests. The clients aren't rate limited if it is 0.
+	RateLimit float64
+	RateBurst int
+}
+
+// BlobResponse is the JSON response of the gateway
+type BlobResponse struct {
+	// Data is the blob, base64 encoded
+	Data []byte `json:"data"`
+}
+
+// ErrorResponse is the JSON response of the gateway to a failed request
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Gateway serves the blobs of the retriever over HTTP, for the clients without gRPC:
+//
+//	GET /blobs/{batchHeaderHash}/{blobIndex}?reference_block_number={n}&quorum_id={q}
+//
+// The batch header hash is hex encoded. The reference block number of the batch, from which the batch is searched
+// onchain, is required so that the requests never search the chain from genesis, and the quorum defaults to 0. The
+// blob is returned as JSON, or as raw bytes if the request accepts application/octet-stream.
+type Gateway struct {
+	retriever pb.RetrieverServer
+	config    GatewayConfig
+	limiter   *ratelimit.ClientRateLimiter
+	logger    logging.Logger
+}
+
+var _ http.Handler = (*Gateway)(nil)
+
+// NewGateway returns a gateway retrieving the blobs from the given retriever service
+func NewGateway(retriever pb.RetrieverServer, config GatewayConfig, logger logging.Logger) *Gateway {
+	g := &Gateway{
+		retriever: retriever,
+		config:    config,
+		logger:    logger.With("component", "RetrieverGateway"),
+	}
+	if config.RateLimit > 0 {
+		g.limiter = ratelimit.NewClientRateLimiter(config.RateLimit, config.RateBurst)
+	}
+	return g
+}
+
+// Serve serves the gateway on the given address until the context is done
+func (g *Gateway) Serve(ctx context.Context, addr string) error {
+	srv := &http.Server{
+		Addr:              addr,
+		Handler:           g,
+		ReadHeaderTimeout: 5 * time.Second,
+	}
+	go func() {
+		<-ctx.Done()
+		if err := srv.Shutdown(context.Background()); err != nil {
+			g.logger.Error("Failed to shut down the gateway", "err", err)
+		}
+	}()
+	g.logger.Info("Gateway listening", "addr", addr)
+	if err := srv.ListenAndServe(); err != http.ErrServerClosed {
+		return err
+	}
+	return nil
+}
+
+func (g *Gateway) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	if r.Method != http.MethodGet && r.Method != http.MethodHead {
+		w.Header().Set("Allow", "GET, HEAD")
+		g.writeError(w, http.StatusMethodNotAllowed, "method not allowed")
+		return
+	}
+	params, ok := strings.CutPrefix(r.URL.Path, blobsPath)
+	if !ok {
+		g.writeError(w, http.StatusNotFound, "not found")
+		return
+	}
+	req, err := parseBlobRequest(params, r.URL.Query())
+	if err != nil {
+		g.writeError(w, http.StatusBadRequest, err.Error())
+		return
+	}
+
+	if g.limiter != nil {
+		if delay, ok := g.limiter.Reserve(clientIP(r), time.Now()); !ok {
+			w.Header().Set("Retry-After", strconv.Itoa(int(math.Ceil(delay.Seconds()))))
+			g.writeError(w, http.StatusTooManyRequests, "rate limit exceeded")
+			return
+		}
+	}
+
+	reply, err := g.retriever.RetrieveBlob(r.Context(), req)
+	if err != nil {
+		g.logger.Warn("Failed to retrieve blob", "batchHeaderHash", hex.EncodeToString(req.GetBatchHeaderHash()), "blobIndex", req.GetBlobIndex(), "err", err)
+		g.writeError(w, http.StatusBadGateway, fmt.Sprintf("failed to retrieve blob: %v", err))
+		return
+	}
+
+	contentType := contentTypeJSON
+	body := reply.GetData()
+	if acceptsOctetStream(r.Header.Get("Accept")) {
+		contentType = contentTypeOctet
+	} else {
+		body, err = json.Marshal(BlobResponse{Data: reply.GetData()})
+		if err != nil {
+			g.writeError(w, http.StatusInternalServerError, "failed to encode blob")
+			return
+		}
+	}
+
+	w.Header().Add("Vary", "Accept")
+	contentEncoding := ""
+	if g.config.EnableGzip {
+		w.Header().Add("Vary", "Accept-Encoding")
+		if acceptsGzip(r.Header.Get("Accept-Encoding")) {
+			contentEncoding = "gzip"
+		}
+	}
+
+	// The blob of a batch never changes, so the request identifies each representation of it
+	etag := fmt.Sprintf(`"%x-%d-%d-%s%s"`, req.GetBatchHeaderHash(), req.GetBlobIndex(), req.GetQuorumId(), representationTags[contentType], contentEncoding)
+	g.setCacheHeaders(w, etag)
+	if etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	if contentEncoding != "" {
+		body, err = gzipBytes(body)
+		if err != nil {
+			g.writeError(w, http.StatusInternalServerError, "failed to compress blob")
+			return
+		}
+		w.Header().Set("Content-Encoding", contentEncoding)
+	}
+	w.Header().Set("Content-Type", contentType)
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(http.StatusOK)
+	if r.Method == http.MethodGet {
+		_, _ = w.Write(body)
+	}
+}
+
+func (g *Gateway) setCacheHeaders(w http.ResponseWriter, etag string) {
+	w.Header().Set("ETag", etag)
+	w.Header().Set("Cache-Control", fmt.Sprintf("public, max-age=%d, immutable", int(g.config.CacheMaxAge.Seconds())))
+}
+
+func (g *Gateway) writeError(w http.ResponseWriter, code int, message string) {
+	body, _ := json.Marshal(ErrorResponse{Error: message})
+	w.Header().Set("Content-Type", contentTypeJSON)
+	w.Header().Set("Cache-Control", "no-store")
+	w.Header().Set("Content-Length", strconv.Itoa(len(body)))
+	w.WriteHeader(code)
+	_, _ = w.Write(body)
+}
+
+// parseBlobRequest parses the request of the blob identified by the path {batchHeaderHash}/{blobIndex} and the query
+func parseBlobRequest(path string, query map[string][]string) (*pb.BlobRequest, error) {
+	parts := strings.Split(path, "/")
+	if len(parts) != 2 {
+		return nil, fmt.Errorf("the path must be %s{batchHeaderHash}/{blobIndex}", blobsPath)
+	}
+	batchHeaderHash, err := hex.DecodeString(strings.TrimPrefix(parts[0], "0x"))
+	if err != nil || len(batchHeaderHash) != 32 {
+		return nil, fmt.Errorf("invalid batch header hash %q", parts[0])
+	}
+	blobIndex, err := strconv.ParseUint(parts[1], 10, 32)
+	if err != nil {
+		return nil, fmt.Errorf("invalid blob index %q", parts[1])
+	}
+	referenceBlockNumber, err := parseUint32Param(query, "reference_block_number")
+	if err != nil {
+		return nil, err
+	}
+	if referenceBlockNumber == 0 {
+		return nil, errors.New("reference_block_number is required")
+	}
+	quorumID, err := parseUint32Param(query, "quorum_id")
+	if err != nil {
+		return nil, err
+	}
+	return &pb.BlobRequest{
+		BatchHeaderHash:      batchHeaderHash,
+		BlobIndex:            uint32(blobIndex),
+		ReferenceBlockNumber: referenceBlockNumber,
+		QuorumId:             quorumID,
+	}, nil
+}
+
+// parseUint32Param parses the query parameter, 0 if it isn't set
+func parseUint32Param(query map[string][]string, name string) (uint32, error) {
+	values := query[name]
+	if len(values) == 0 || values[0] == "" {
+		return 0, nil
+	}
+	value, err := strconv.ParseUint(values[0], 10, 32)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s %q", name, values[0])
+	}
+	return uint32(value), nil
+}
+
+// etagMatches returns whether the If-None-Match header lists the ETag
+func etagMatches(ifNoneMatch string, etag string) bool {
+	for _, candidate := range strings.Split(ifNoneMatch, ",") {
+		candidate = strings.TrimPrefix(strings.TrimSpace(candidate), "W/")
+		if candidate == etag || candidate == "*" {
+			return true
+		}
+	}
+	return false
+}
+
+// clientIP returns the IP of the connection of the request
+func clientIP(r *http.Request) string {
+	host, _, err := net.SplitHostPort(r.RemoteAddr)
+	if err != nil {
+		return r.RemoteAddr
+	}
+	return host
+}
+
+// acceptsOctetStream returns whether the Accept header explicitly accepts raw bytes
+func acceptsOctetStream(accept string) bool {
+	return hasMediaType(accept, contentTypeOctet)
+}
+
+func acceptsGzip(acceptEncoding string) bool {
+	return hasMediaType(acceptEncoding, "gzip")
+}
+
+// hasMediaType returns whether the comma separated header lists the value without q=0
+func hasMediaType(header string, value string) bool {
+	for _, part := range strings.Split(header, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		if !strings.EqualFold(strings.TrimSpace(name), value) {
+			continue
+		}
+		q, ok := strings.CutPrefix(strings.TrimSpace(params), "q=")
+		if !ok {
+			return true
+		}
+		parsed, err := strconv.ParseFloat(q, 64)
+		return err != nil || parsed > 0
+	}
+	return false
+}
+
+func gzipBytes(data []byte) ([]byte, error) {
+	var buf bytes.Buffer
+	w := gzip.NewWriter(&buf)
+	if _, err := w.Write(data); err != nil {
+		return nil, err
+	}
+	if err := w.Close(); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
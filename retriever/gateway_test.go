@@ -0,0 +1,122 @@
+package retriever_test
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"testing"
+	"time"
+
+	binding "github.com/Layr-Labs/eigenda/contracts/bindings/EigenDAServiceManager"
+	"github.com/Layr-Labs/eigenda/retriever"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestGateway(t *testing.T) {
+	server := newTestServer(t)
+	chainClient.On("FetchBatchHeader").Return(&binding.IEigenDAServiceManagerBatchHeader{
+		BlobHeadersRoot:       batchRoot,
+		QuorumNumbers:         []byte{0},
+		SignedStakeForQuorums: []byte{90},
+		ReferenceBlockNumber:  0,
+	}, nil)
+	retrievalClient.On("RetrieveBlob").Return(gettysburgAddressBytes, nil)
+
+	gateway := retriever.NewGateway(server, retriever.GatewayConfig{CacheMaxAge: time.Hour, EnableGzip: true}, logging.NewNoopLogger())
+	get := func(path string, headers map[string]string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		for name, value := range headers {
+			req.Header.Set(name, value)
+		}
+		gateway.ServeHTTP(w, req)
+		return w
+	}
+	path := "/blobs/" + hex.EncodeToString(batchHeaderHash[:]) + "/0?reference_block_number=10"
+
+	w := get(path, nil)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/json", w.Header().Get("Content-Type"))
+	assert.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+	assert.Equal(t, "public, max-age=3600, immutable", w.Header().Get("Cache-Control"))
+	var response retriever.BlobResponse
+	assert.NoError(t, json.Unmarshal(w.Body.Bytes(), &response))
+	assert.Equal(t, gettysburgAddressBytes, response.Data)
+
+	// The raw blob is returned to the clients which accept it, compressed if they accept gzip
+	w = get(path, map[string]string{"Accept": "application/octet-stream", "Accept-Encoding": "gzip"})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Equal(t, "application/octet-stream", w.Header().Get("Content-Type"))
+	assert.Equal(t, "gzip", w.Header().Get("Content-Encoding"))
+	assert.Equal(t, strconv.Itoa(w.Body.Len()), w.Header().Get("Content-Length"))
+	reader, err := gzip.NewReader(bytes.NewReader(w.Body.Bytes()))
+	assert.NoError(t, err)
+	data, err := io.ReadAll(reader)
+	assert.NoError(t, err)
+	assert.Equal(t, gettysburgAddressBytes, data)
+
+	// Each representation of the blob has its own ETag, which is only matched by the same representation
+	etag := w.Header().Get("ETag")
+	assert.NotEmpty(t, etag)
+	w = get(path, map[string]string{"Accept": "application/octet-stream", "Accept-Encoding": "gzip", "If-None-Match": etag})
+	assert.Equal(t, http.StatusNotModified, w.Code)
+	assert.Empty(t, w.Body.Bytes())
+	w = get(path, map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+	w = get(path, map[string]string{"Accept": "application/octet-stream", "If-None-Match": etag})
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.NotEqual(t, etag, w.Header().Get("ETag"))
+
+	for _, invalidPath := range []string{
+		"/blobs/" + hex.EncodeToString(batchHeaderHash[:]),
+		"/blobs/0102/0",
+		"/blobs/" + hex.EncodeToString(batchHeaderHash[:]) + "/x",
+		path + "&quorum_id=-1",
+		// The batch is never searched from genesis
+		"/blobs/" + hex.EncodeToString(batchHeaderHash[:]) + "/0",
+		"/blobs/" + hex.EncodeToString(batchHeaderHash[:]) + "/0?reference_block_number=0",
+	} {
+		w = get(invalidPath, nil)
+		assert.Equal(t, http.StatusBadRequest, w.Code, invalidPath)
+		assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	}
+	assert.Equal(t, http.StatusNotFound, get("/batches", nil).Code)
+
+	w = httptest.NewRecorder()
+	gateway.ServeHTTP(w, httptest.NewRequest(http.MethodPost, path, nil))
+	assert.Equal(t, http.StatusMethodNotAllowed, w.Code)
+
+	// The failures to retrieve the blob aren't cached
+	server = newTestServer(t)
+	chainClient.On("FetchBatchHeader").Return((*binding.IEigenDAServiceManagerBatchHeader)(nil), errors.New("batch not found"))
+	gateway = retriever.NewGateway(server, retriever.GatewayConfig{}, logging.NewNoopLogger())
+	w = get(path, nil)
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+	assert.Equal(t, "no-store", w.Header().Get("Cache-Control"))
+	// A blob which can't be retrieved is never reported as not modified
+	w = get(path, map[string]string{"If-None-Match": etag})
+	assert.Equal(t, http.StatusBadGateway, w.Code)
+
+	// The clients are rate limited separately, once their burst is spent
+	gateway = retriever.NewGateway(server, retriever.GatewayConfig{RateLimit: 1, RateBurst: 1}, logging.NewNoopLogger())
+	request := func(clientIP string) *httptest.ResponseRecorder {
+		w := httptest.NewRecorder()
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		req.RemoteAddr = clientIP + ":1234"
+		gateway.ServeHTTP(w, req)
+		return w
+	}
+	assert.Equal(t, http.StatusBadGateway, request("10.0.0.1").Code)
+	w = request("10.0.0.1")
+	assert.Equal(t, http.StatusTooManyRequests, w.Code)
+	assert.Equal(t, "1", w.Header().Get("Retry-After"))
+	assert.Equal(t, http.StatusBadGateway, request("10.0.0.2").Code)
+}
@@ -23,6 +23,7 @@ import (
 	"github.com/consensys/gnark-crypto/ecc/bn254/fp"
 
 	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
+	"github.com/Layr-Labs/eigenda/api/conversion"
 	"github.com/Layr-Labs/eigenda/disperser/apiserver"
 	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
 	"github.com/Layr-Labs/eigenda/disperser/encoder"
@@ -394,7 +395,7 @@ func TestDispersalAndRetrieval(t *testing.T) {
 
 	blob := mustMakeTestBlob()
 	requestedAt := uint64(time.Now().UnixNano())
-	metadataKey, err := store.StoreBlob(ctx, &blob, requestedAt)
+	metadataKey, err := store.StoreBlob(ctx, &blob, requestedAt, disperser.RequestOrigin{})
 	assert.NoError(t, err)
 	out := make(chan batcher.EncodingResultOrStatus)
 	err = dis.batcher.EncodingStreamer.RequestEncoding(context.Background(), out)
@@ -544,7 +545,7 @@ func TestDispersalAndRetrieval(t *testing.T) {
 		assert.Greater(t, headerReply.GetBlobHeader().GetQuorumHeaders()[0].GetChunkLength(), uint32(0))
 
 		if blobHeader == nil {
-			blobHeader, err = node.GetBlobHeaderFromProto(headerReply.GetBlobHeader())
+			blobHeader, err = conversion.BlobHeaderFromProto(headerReply.GetBlobHeader())
 			assert.NoError(t, err)
 		}
 
@@ -0,0 +1,92 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/tools/batchreplay"
+	"github.com/Layr-Labs/eigenda/tools/batchreplay/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "batchreplay"
+	app.Description = "replays a recorded StoreChunks request through the validation of the node"
+	app.Usage = ""
+	app.Flags = flags.Flags
+	app.Action = RunReplay
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func RunReplay(ctx *cli.Context) error {
+	config, err := batchreplay.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+
+	operatorID, err := core.OperatorIDFromHex(config.OperatorId)
+	if err != nil {
+		return fmt.Errorf("invalid operator id %s: %w", config.OperatorId, err)
+	}
+
+	request, err := batchreplay.LoadStoreChunksRequest(config.RequestFile)
+	if err != nil {
+		return err
+	}
+
+	gethClient, err := geth.NewClient(config.EthClientConfig, gethcommon.Address{}, 0, logger)
+	if err != nil {
+		logger.Error("Cannot create chain.Client", "err", err)
+		return err
+	}
+
+	tx, err := eth.NewTransactor(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create transactor: %w", err)
+	}
+	cs := eth.NewChainState(tx, gethClient)
+
+	v, err := verifier.NewVerifier(&config.EncoderConfig, false)
+	if err != nil {
+		return fmt.Errorf("failed to create verifier: %w", err)
+	}
+
+	replayer := &batchreplay.Replayer{
+		ChainState: cs,
+		Validator:  core.NewShardValidator(v, &core.StdAssignmentCoordinator{}, cs, operatorID),
+		OperatorID: operatorID,
+		NumWorkers: config.Workers,
+		Out:        os.Stdout,
+	}
+	report, err := replayer.Replay(context.Background(), request)
+	if err != nil {
+		return err
+	}
+	if !report.Valid() {
+		return fmt.Errorf("node would refuse to sign batch %x", report.BatchHeaderHash)
+	}
+	return nil
+}
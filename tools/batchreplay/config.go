@@ -0,0 +1,44 @@
+package batchreplay
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/tools/batchreplay/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	LoggerConfig    common.LoggerConfig
+	RequestFile     string
+	OperatorId      string
+	Workers         int
+	EthClientConfig geth.EthClientConfig
+	EncoderConfig   kzg.KzgConfig
+
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+}
+
+func ReadConfig(ctx *cli.Context) *Config {
+	return &Config{
+		RequestFile:                   ctx.GlobalString(flags.RequestFileFlag.Name),
+		OperatorId:                    ctx.GlobalString(flags.OperatorIdFlag.Name),
+		Workers:                       ctx.GlobalInt(flags.WorkersFlag.Name),
+		EthClientConfig:               geth.ReadEthClientConfig(ctx),
+		EncoderConfig:                 kzg.ReadCLIConfig(ctx),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+	}
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ReadConfig(ctx)
+	config.LoggerConfig = *loggerConfig
+	return config, nil
+}
@@ -0,0 +1,70 @@
+package flags
+
+import (
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "BATCHREPLAY"
+)
+
+var (
+	/* Required Flags*/
+	RequestFileFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "request-file"),
+		Usage:    "Path to the StoreChunks request to replay, as recorded by a node with the rejected-batch-record-dir flag",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "REQUEST_FILE"),
+	}
+	OperatorIdFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-id"),
+		Usage:    "ID of the operator that received the request",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_ID"),
+	}
+	BlsOperatorStateRetrieverFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
+		Usage:    "Address of the BLS Operator State Retriever",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+	}
+	EigenDAServiceManagerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-service-manager"),
+		Usage:    "Address of the EigenDA Service Manager",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_SERVICE_MANAGER"),
+	}
+	/* Optional Flags*/
+	WorkersFlag = cli.IntFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "workers"),
+		Usage:    "number of workers used to deserialize and validate the blobs",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "WORKERS"),
+		Value:    8,
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	RequestFileFlag,
+	OperatorIdFlag,
+	BlsOperatorStateRetrieverFlag,
+	EigenDAServiceManagerFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	WorkersFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
+	Flags = append(Flags, kzg.CLIFlags(envPrefix)...)
+}
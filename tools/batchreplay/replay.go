@@ -0,0 +1,164 @@
+package batchreplay
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+
+	"github.com/Layr-Labs/eigenda/api/conversion"
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/node"
+	"github.com/gammazero/workerpool"
+	"google.golang.org/protobuf/proto"
+)
+
+// LoadStoreChunksRequest reads a StoreChunks request recorded by a node with the rejected-batch-record-dir flag
+func LoadStoreChunksRequest(path string) (*pb.StoreChunksRequest, error) {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read request %s: %w", path, err)
+	}
+	request := &pb.StoreChunksRequest{}
+	if err := proto.Unmarshal(data, request); err != nil {
+		return nil, fmt.Errorf("failed to decode request %s: %w", path, err)
+	}
+	return request, nil
+}
+
+// BlobResult is the outcome of validating one blob of the batch on its own
+type BlobResult struct {
+	BlobIndex      int
+	BlobHeaderHash [32]byte
+	Err            error
+}
+
+// Report is the outcome of replaying a batch through the validation path of the node
+type Report struct {
+	BatchHeaderHash      [32]byte
+	ReferenceBlockNumber uint
+	// BatchRootErr is set if the batch root does not match the blob headers of the request
+	BatchRootErr error
+	Blobs        []*BlobResult
+	// BatchErr is the error of the validation of the whole batch, which is what makes the node refuse to sign
+	BatchErr error
+}
+
+// Valid returns true if the node would sign the batch
+func (r *Report) Valid() bool {
+	return r.BatchErr == nil
+}
+
+// Replayer runs a StoreChunks request through the same validation as the node, printing each step to Out.
+// It does not store the chunks nor sign the batch.
+type Replayer struct {
+	ChainState core.ChainState
+	Validator  core.ShardValidator
+	OperatorID core.OperatorID
+	NumWorkers int
+	Out        io.Writer
+}
+
+// Replay validates the batch of the request. The returned error is only set if the request cannot be
+// replayed at all, e.g. because it cannot be decoded, while validation failures are recorded in the report.
+func (r *Replayer) Replay(ctx context.Context, request *pb.StoreChunksRequest) (*Report, error) {
+	batchHeader, err := conversion.BatchHeaderFromProto(request.GetBatchHeader())
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode batch header: %w", err)
+	}
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	if err != nil {
+		return nil, fmt.Errorf("failed to hash batch header: %w", err)
+	}
+	report := &Report{
+		BatchHeaderHash:      batchHeaderHash,
+		ReferenceBlockNumber: batchHeader.ReferenceBlockNumber,
+	}
+	r.printf("batch header hash: %x\n", batchHeaderHash)
+	r.printf("batch root: %x\n", batchHeader.BatchRoot)
+	r.printf("reference block number: %d\n", batchHeader.ReferenceBlockNumber)
+
+	blobs, err := node.GetBlobMessages(request.GetBlobs(), r.NumWorkers)
+	if err != nil {
+		return nil, fmt.Errorf("failed to decode blobs: %w", err)
+	}
+	r.printf("blobs: %d\n", len(blobs))
+
+	operatorState, err := r.ChainState.GetOperatorStateByOperator(ctx, batchHeader.ReferenceBlockNumber, r.OperatorID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", batchHeader.ReferenceBlockNumber, err)
+	}
+	r.printOperatorState(operatorState)
+
+	blobHeaders := make([]*core.BlobHeader, len(blobs))
+	for i, blob := range blobs {
+		blobHeaders[i] = blob.BlobHeader
+	}
+	report.BatchRootErr = core.ValidateBatchHeaderRoot(batchHeader, blobHeaders)
+	if report.BatchRootErr != nil {
+		r.printf("batch root: INVALID: %v\n", report.BatchRootErr)
+	} else {
+		r.printf("batch root: OK\n")
+	}
+
+	pool := workerpool.New(r.NumWorkers)
+	defer pool.StopWait()
+	report.Blobs = make([]*BlobResult, len(blobs))
+	for i, blob := range blobs {
+		result := &BlobResult{BlobIndex: i}
+		result.BlobHeaderHash, err = blob.BlobHeader.GetBlobHeaderHash()
+		if err != nil {
+			return nil, fmt.Errorf("failed to hash header of blob %d: %w", i, err)
+		}
+		r.printf("blob %d: header hash %x, length %d\n", i, result.BlobHeaderHash, blob.BlobHeader.Length)
+		for _, quorumInfo := range blob.BlobHeader.QuorumInfos {
+			r.printf("  quorum %d: adversary threshold %d%%, confirmation threshold %d%%, chunk length %d, chunks received %d\n",
+				quorumInfo.QuorumID, quorumInfo.AdversaryThreshold, quorumInfo.ConfirmationThreshold, quorumInfo.ChunkLength, len(blob.Bundles[quorumInfo.QuorumID]))
+		}
+
+		result.Err = r.Validator.ValidateBlobs([]*core.BlobMessage{blob}, operatorState, pool)
+		if result.Err != nil {
+			r.printf("  validation: INVALID: %v\n", result.Err)
+		} else {
+			r.printf("  validation: OK\n")
+		}
+		report.Blobs[i] = result
+	}
+
+	report.BatchErr = r.Validator.ValidateBatch(batchHeader, blobs, operatorState, pool)
+	if report.BatchErr != nil {
+		r.printf("batch validation: INVALID: %v\n", report.BatchErr)
+	} else {
+		r.printf("batch validation: OK\n")
+	}
+
+	return report, nil
+}
+
+func (r *Replayer) printOperatorState(operatorState *core.OperatorState) {
+	r.printf("operator %s state at block %d:\n", r.OperatorID.Hex(), operatorState.BlockNumber)
+	quorums := make([]core.QuorumID, 0, len(operatorState.Operators))
+	for quorumID := range operatorState.Operators {
+		quorums = append(quorums, quorumID)
+	}
+	sort.Slice(quorums, func(i, j int) bool { return quorums[i] < quorums[j] })
+
+	for _, quorumID := range quorums {
+		info, ok := operatorState.Operators[quorumID][r.OperatorID]
+		if !ok {
+			r.printf("  quorum %d: not registered\n", quorumID)
+			continue
+		}
+		total := operatorState.Totals[quorumID]
+		r.printf("  quorum %d: index %d of %d, stake %s of %s\n", quorumID, info.Index, total.Index, info.Stake.String(), total.Stake.String())
+	}
+}
+
+func (r *Replayer) printf(format string, args ...any) {
+	if r.Out == nil {
+		return
+	}
+	fmt.Fprintf(r.Out, format, args...)
+}
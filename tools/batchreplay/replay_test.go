@@ -0,0 +1,130 @@
+package batchreplay_test
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"os"
+	"path/filepath"
+	"testing"
+
+	pb "github.com/Layr-Labs/eigenda/api/grpc/node"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	dispatcher "github.com/Layr-Labs/eigenda/disperser/batcher/grpc"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/tools/batchreplay"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"google.golang.org/protobuf/proto"
+)
+
+func makeRequest(t *testing.T, numBlobs int) (*pb.StoreChunksRequest, [32]byte) {
+	blobHeaders := make([]*core.BlobHeader, numBlobs)
+	blobMessages := make([]*core.EncodedBlobMessage, numBlobs)
+	for i := range blobHeaders {
+		blobHeaders[i] = &core.BlobHeader{
+			BlobCommitments: encoding.BlobCommitments{
+				Commitment:       &encoding.G1Commitment{},
+				LengthCommitment: &encoding.G2Commitment{},
+				LengthProof:      &encoding.G2Commitment{},
+				Length:           uint(48 + i),
+			},
+			QuorumInfos: []*core.BlobQuorumInfo{
+				{
+					SecurityParam: core.SecurityParam{QuorumID: 0, AdversaryThreshold: 55, ConfirmationThreshold: 80},
+					ChunkLength:   10,
+				},
+			},
+		}
+		blobMessages[i] = &core.EncodedBlobMessage{BlobHeader: blobHeaders[i]}
+	}
+	batchHeader := &core.BatchHeader{ReferenceBlockNumber: 10}
+	_, err := batchHeader.SetBatchRoot(blobHeaders)
+	assert.NoError(t, err)
+	batchHeaderHash, err := batchHeader.GetBatchHeaderHash()
+	assert.NoError(t, err)
+
+	request, _, err := dispatcher.GetStoreChunksRequest(blobMessages, batchHeader, true)
+	assert.NoError(t, err)
+	return request, batchHeaderHash
+}
+
+func newReplayer(t *testing.T, validator core.ShardValidator, out *bytes.Buffer) *batchreplay.Replayer {
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 4})
+	assert.NoError(t, err)
+	return &batchreplay.Replayer{
+		ChainState: chainState,
+		Validator:  validator,
+		OperatorID: coremock.MakeOperatorId(0),
+		NumWorkers: 2,
+		Out:        out,
+	}
+}
+
+func TestLoadStoreChunksRequest(t *testing.T) {
+	request, _ := makeRequest(t, 2)
+	data, err := proto.Marshal(request)
+	assert.NoError(t, err)
+	path := filepath.Join(t.TempDir(), "request.pb")
+	assert.NoError(t, os.WriteFile(path, data, 0644))
+
+	loaded, err := batchreplay.LoadStoreChunksRequest(path)
+	assert.NoError(t, err)
+	assert.True(t, proto.Equal(request, loaded))
+
+	_, err = batchreplay.LoadStoreChunksRequest(filepath.Join(t.TempDir(), "missing.pb"))
+	assert.Error(t, err)
+}
+
+func TestReplay(t *testing.T) {
+	request, batchHeaderHash := makeRequest(t, 2)
+	validator := coremock.NewMockShardValidator()
+	validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+	validator.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(nil)
+
+	out := &bytes.Buffer{}
+	report, err := newReplayer(t, validator, out).Replay(context.Background(), request)
+	assert.NoError(t, err)
+	assert.True(t, report.Valid())
+	assert.Equal(t, batchHeaderHash, report.BatchHeaderHash)
+	assert.Equal(t, uint(10), report.ReferenceBlockNumber)
+	assert.NoError(t, report.BatchRootErr)
+	assert.Len(t, report.Blobs, 2)
+	for i, blob := range report.Blobs {
+		assert.Equal(t, i, blob.BlobIndex)
+		assert.NoError(t, blob.Err)
+	}
+	assert.Contains(t, out.String(), "batch validation: OK")
+}
+
+func TestReplayInvalidBatch(t *testing.T) {
+	request, _ := makeRequest(t, 2)
+	// Tamper with the second blob so that it no longer matches the batch root
+	request.Blobs[1].Header.Length = 1000
+
+	blobErr := errors.New("invalid chunks")
+	validator := coremock.NewMockShardValidator()
+	validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(nil).Once()
+	validator.On("ValidateBlobs", mock.Anything, mock.Anything, mock.Anything).Return(blobErr).Once()
+	validator.On("ValidateBatch", mock.Anything, mock.Anything, mock.Anything).Return(blobErr)
+
+	out := &bytes.Buffer{}
+	report, err := newReplayer(t, validator, out).Replay(context.Background(), request)
+	assert.NoError(t, err)
+	assert.False(t, report.Valid())
+	assert.ErrorIs(t, report.BatchErr, blobErr)
+	assert.Error(t, report.BatchRootErr)
+	assert.NoError(t, report.Blobs[0].Err)
+	assert.ErrorIs(t, report.Blobs[1].Err, blobErr)
+	assert.Contains(t, out.String(), "blob 1")
+	assert.Contains(t, out.String(), "batch validation: INVALID: invalid chunks")
+}
+
+func TestReplayUndecodableRequest(t *testing.T) {
+	request, _ := makeRequest(t, 1)
+	request.BatchHeader = nil
+
+	_, err := newReplayer(t, coremock.NewMockShardValidator(), &bytes.Buffer{}).Replay(context.Background(), request)
+	assert.Error(t, err)
+}
@@ -0,0 +1,132 @@
+package benchcheck
+
+import (
+	"bufio"
+	"io"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// The metrics compared against the baseline. Lower is better for both.
+const (
+	NsPerOp     = "ns/op"
+	AllocsPerOp = "allocs/op"
+)
+
+// gomaxprocsSuffix is the -N suffix that go test appends to the names of the benchmarks when GOMAXPROCS > 1. It is
+// trimmed so that the results of machines with a different number of CPUs can be compared.
+var gomaxprocsSuffix = regexp.MustCompile(`-\d+$`)
+
+// Results are the values of the metrics of each run of each benchmark
+type Results map[string]map[string][]float64
+
+// Parse parses the output of go test -bench. The lines which aren't the results of a benchmark are ignored.
+//
+// go test prints the name of a benchmark before running it, so the output of the benchmark, e.g. the logs of the code
+// under benchmark, is printed between the name and the results. The results on their own line are the results of
+// the last benchmark named.
+func Parse(r io.Reader) (Results, error) {
+	results := make(Results)
+	name := ""
+	scanner := bufio.NewScanner(r)
+	for scanner.Scan() {
+		fields := strings.Fields(scanner.Text())
+		if len(fields) > 0 && strings.HasPrefix(fields[0], "Benchmark") {
+			name = gomaxprocsSuffix.ReplaceAllString(fields[0], "")
+			fields = fields[1:]
+		}
+		// The number of iterations, and pairs of value and unit
+		if name == "" || len(fields) < 3 || len(fields)%2 != 1 {
+			continue
+		}
+		if _, err := strconv.ParseUint(fields[0], 10, 64); err != nil {
+			continue
+		}
+		values := make(map[string]float64)
+		for i := 1; i < len(fields); i += 2 {
+			value, err := strconv.ParseFloat(fields[i], 64)
+			if err != nil {
+				// A log line which happens to start with a number
+				values = nil
+				break
+			}
+			values[fields[i+1]] = value
+		}
+		if len(values) == 0 {
+			continue
+		}
+		if results[name] == nil {
+			results[name] = make(map[string][]float64)
+		}
+		for unit, value := range values {
+			results[name][unit] = append(results[name][unit], value)
+		}
+	}
+	return results, scanner.Err()
+}
+
+// Comparison is the comparison of a metric of a benchmark with its baseline
+type Comparison struct {
+	Benchmark string
+	Metric    string
+	// Baseline and Current are the medians of the runs
+	Baseline float64
+	Current  float64
+	// Delta is the relative change from the baseline, e.g. 0.1 if the current value is 10% higher
+	Delta float64
+	// Regression is whether the delta exceeds the threshold
+	Regression bool
+}
+
+// Compare compares the medians of the metrics of the current results with the baseline. A metric regresses if it is
+// higher than the baseline by more than threshold, e.g. 0.2 for 20%. The benchmarks missing from the baseline or from
+// the current results are skipped, and returned as missing.
+func Compare(baseline, current Results, threshold float64) (comparisons []Comparison, missing []string) {
+	names := make([]string, 0, len(baseline))
+	for name := range baseline {
+		names = append(names, name)
+	}
+	for name := range current {
+		if _, ok := baseline[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		if baseline[name] == nil || current[name] == nil {
+			missing = append(missing, name)
+			continue
+		}
+		for _, metric := range []string{NsPerOp, AllocsPerOp} {
+			base, cur := baseline[name][metric], current[name][metric]
+			if len(base) == 0 || len(cur) == 0 {
+				continue
+			}
+			comparison := Comparison{
+				Benchmark: name,
+				Metric:    metric,
+				Baseline:  median(base),
+				Current:   median(cur),
+			}
+			if comparison.Baseline > 0 {
+				comparison.Delta = (comparison.Current - comparison.Baseline) / comparison.Baseline
+			}
+			comparison.Regression = comparison.Delta > threshold
+			comparisons = append(comparisons, comparison)
+		}
+	}
+	return comparisons, missing
+}
+
+func median(values []float64) float64 {
+	sorted := append([]float64(nil), values...)
+	sort.Float64s(sorted)
+	mid := len(sorted) / 2
+	if len(sorted)%2 == 0 {
+		return (sorted[mid-1] + sorted[mid]) / 2
+	}
+	return sorted[mid]
+}
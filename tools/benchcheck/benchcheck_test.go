@@ -0,0 +1,75 @@
+package benchcheck_test
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/Layr-Labs/eigenda/tools/benchcheck"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const baselineOutput = `goos: linux
+goarch: amd64
+pkg: github.com/Layr-Labs/eigenda/node/grpc
+BenchmarkStoreChunksStorage/8x128KiB-8         	     100	   1000000 ns/op	 500.00 MB/s	  20000 B/op	     100 allocs/op
+BenchmarkStoreChunksStorage/8x128KiB-8         	     100	   1200000 ns/op	 400.00 MB/s	  20000 B/op	     100 allocs/op
+BenchmarkStoreChunksStorage/8x128KiB-8         	     100	   1100000 ns/op	 450.00 MB/s	  20000 B/op	     100 allocs/op
+BenchmarkStoreChunksVerification/8x128KiB-8    	Batch verify 8 frames of 4096 symbols out of 8 blobs
+Batch verify 8 frames of 4096 symbols out of 8 blobs
+      10	  50000000 ns/op
+BenchmarkRemoved-8                             	      10	      1000 ns/op
+PASS
+ok  	github.com/Layr-Labs/eigenda/node/grpc	12.345s
+`
+
+// The current results are from a machine with another number of CPUs
+const currentOutput = `BenchmarkStoreChunksStorage/8x128KiB-16        	     100	   1500000 ns/op	 300.00 MB/s	  20000 B/op	     100 allocs/op
+BenchmarkStoreChunksVerification/8x128KiB-16   	      10	  40000000 ns/op
+BenchmarkAdded-16                              	      10	      1000 ns/op
+`
+
+func TestParse(t *testing.T) {
+	results, err := benchcheck.Parse(strings.NewReader(baselineOutput))
+	require.NoError(t, err)
+	assert.Len(t, results, 3)
+	assert.Equal(t, []float64{1000000, 1200000, 1100000}, results["BenchmarkStoreChunksStorage/8x128KiB"][benchcheck.NsPerOp])
+	assert.Equal(t, []float64{500, 400, 450}, results["BenchmarkStoreChunksStorage/8x128KiB"]["MB/s"])
+	assert.Equal(t, []float64{50000000}, results["BenchmarkStoreChunksVerification/8x128KiB"][benchcheck.NsPerOp])
+
+	// A log line starting with a number isn't a result
+	results, err = benchcheck.Parse(strings.NewReader("BenchmarkLogs-8 \n 10 blobs verified\n"))
+	require.NoError(t, err)
+	assert.Empty(t, results)
+}
+
+func TestCompare(t *testing.T) {
+	baseline, err := benchcheck.Parse(strings.NewReader(baselineOutput))
+	require.NoError(t, err)
+	current, err := benchcheck.Parse(strings.NewReader(currentOutput))
+	require.NoError(t, err)
+
+	comparisons, missing := benchcheck.Compare(baseline, current, 0.2)
+	assert.Equal(t, []string{"BenchmarkAdded", "BenchmarkRemoved"}, missing)
+	require.Len(t, comparisons, 3)
+
+	// The median of the baseline is compared
+	assert.Equal(t, benchcheck.Comparison{
+		Benchmark:  "BenchmarkStoreChunksStorage/8x128KiB",
+		Metric:     benchcheck.NsPerOp,
+		Baseline:   1100000,
+		Current:    1500000,
+		Delta:      (1500000.0 - 1100000) / 1100000,
+		Regression: true,
+	}, comparisons[0])
+	assert.Equal(t, benchcheck.AllocsPerOp, comparisons[1].Metric)
+	assert.False(t, comparisons[1].Regression)
+	// An improvement isn't a regression
+	assert.Equal(t, -0.2, comparisons[2].Delta)
+	assert.False(t, comparisons[2].Regression)
+
+	comparisons, _ = benchcheck.Compare(baseline, current, 0.5)
+	for _, c := range comparisons {
+		assert.False(t, c.Regression)
+	}
+}
@@ -0,0 +1,87 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"os"
+	"text/tabwriter"
+
+	"github.com/Layr-Labs/eigenda/tools/benchcheck"
+)
+
+// benchcheck compares the output of go test -bench with a stored baseline, and exits with a non-zero status if any
+// benchmark regressed by more than the threshold. The baseline is itself the output of go test -bench, so it is
+// updated by saving the output of a run on the reference machine.
+//
+// An example:
+//
+//	go test -run '^$' -bench BenchmarkStoreChunks -benchmem -count 5 ./node/grpc/ > current.txt
+//	go run ./tools/benchcheck/cmd -baseline node/grpc/testdata/benchmarks_baseline.txt current.txt
+func main() {
+	baselinePath := flag.String("baseline", "", "Path of the output of go test -bench to compare with")
+	threshold := flag.Float64("threshold", 0.2, "Relative increase of ns/op or allocs/op over the baseline which is a regression, e.g. 0.2 for 20%")
+	flag.Usage = func() {
+		fmt.Fprintln(os.Stderr, "Usage: benchcheck -baseline <file> [-threshold <ratio>] <current results file>")
+		flag.PrintDefaults()
+	}
+	flag.Parse()
+	if *baselinePath == "" || flag.NArg() != 1 {
+		flag.Usage()
+		os.Exit(2)
+	}
+
+	baseline, err := parseFile(*baselinePath)
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading baseline:", err)
+		os.Exit(1)
+	}
+	current, err := parseFile(flag.Arg(0))
+	if err != nil {
+		fmt.Fprintln(os.Stderr, "Error reading current results:", err)
+		os.Exit(1)
+	}
+
+	comparisons, missing := benchcheck.Compare(baseline, current, *threshold)
+	regressions := 0
+	tw := tabwriter.NewWriter(os.Stdout, 0, 0, 2, ' ', 0)
+	fmt.Fprintln(tw, "benchmark\tmetric\tbaseline\tcurrent\tdelta\t")
+	for _, c := range comparisons {
+		status := ""
+		if c.Regression {
+			status = "REGRESSION"
+			regressions++
+		}
+		fmt.Fprintf(tw, "%s\t%s\t%.0f\t%.0f\t%+.1f%%\t%s\n", c.Benchmark, c.Metric, c.Baseline, c.Current, c.Delta*100, status)
+	}
+	_ = tw.Flush()
+	failed := false
+	for _, name := range missing {
+		if current[name] == nil {
+			// The benchmark failed, or it was renamed without updating the baseline
+			fmt.Fprintf(os.Stderr, "%s is missing from the current results\n", name)
+			failed = true
+		} else {
+			fmt.Printf("%s is missing from the baseline, so it isn't compared\n", name)
+		}
+	}
+	if len(comparisons) == 0 {
+		fmt.Fprintln(os.Stderr, "No benchmark was compared with the baseline")
+		failed = true
+	}
+	if regressions > 0 {
+		fmt.Fprintf(os.Stderr, "%d metrics regressed by more than %.0f%% over the baseline\n", regressions, *threshold*100)
+		failed = true
+	}
+	if failed {
+		os.Exit(1)
+	}
+}
+
+func parseFile(path string) (benchcheck.Results, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	return benchcheck.Parse(f)
+}
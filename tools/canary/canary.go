@@ -0,0 +1,252 @@
+package canary
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"io"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/api/clients/codecs"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/wealdtech/go-merkletree/v2"
+	"github.com/wealdtech/go-merkletree/v2/keccak256"
+)
+
+var (
+	// ErrCanaryNotConfirmed is the dispersal error of the report if the canary blob isn't confirmed
+	ErrCanaryNotConfirmed = errors.New("canary blob not confirmed")
+	// ErrOperatorNotRegistered is the error of the operators which aren't registered at the reference block of the
+	// batch of the canary blob
+	ErrOperatorNotRegistered = errors.New("operator not registered at the reference block")
+	// ErrOperatorNotInQuorums is the error of the operators which aren't in any of the quorums of the canary blob, so
+	// they have no chunk of it
+	ErrOperatorNotInQuorums = errors.New("operator not in the quorums of the canary blob")
+)
+
+// Disperser disperses the canary blob and reports its status, as implemented by clients.DispersalClient
+type Disperser interface {
+	DisperseBlob(ctx context.Context, data []byte, customQuorums []uint8) ([]byte, error)
+	GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error)
+}
+
+// OperatorResult is the outcome of the retrieval of the chunks of the canary blob from an operator. There is a result
+// for each quorum of the blob the operator is in.
+type OperatorResult struct {
+	OperatorID core.OperatorID
+	QuorumID   core.QuorumID
+	NumChunks  int
+	Err        error
+}
+
+// Report is the outcome of a canary run
+type Report struct {
+	RequestID            []byte
+	BatchHeaderHash      [32]byte
+	BlobIndex            uint32
+	ReferenceBlockNumber uint
+	// DispersalErr is set if the canary blob couldn't be dispersed and confirmed, in which case no operator is checked
+	DispersalErr error
+	Operators    []*OperatorResult
+}
+
+// Passed returns true if the canary blob was confirmed and all the operators served valid chunks of it
+func (r *Report) Passed() bool {
+	if r.DispersalErr != nil || len(r.Operators) == 0 {
+		return false
+	}
+	for _, result := range r.Operators {
+		if result.Err != nil {
+			return false
+		}
+	}
+	return true
+}
+
+// NewCanaryBlob returns the data of a canary blob labeled with the release under test and the time of the run, so
+// that each run disperses a new blob
+func NewCanaryBlob(label string, at time.Time) ([]byte, error) {
+	return codecs.NewDefaultBlobCodec().EncodeBlob([]byte(fmt.Sprintf("eigenda release canary %s %d", label, at.UnixNano())))
+}
+
+// Canary disperses a canary blob, waits for it to be confirmed, then retrieves its chunks from each of the chosen
+// operators and verifies them against the commitment of the blob, printing each step to Out. It is used to check that
+// the operators running a new release store and serve the chunks.
+type Canary struct {
+	Disperser             Disperser
+	ChainState            core.IndexedChainState
+	AssignmentCoordinator core.AssignmentCoordinator
+	NodeClient            clients.NodeClient
+	Verifier              encoding.Verifier
+	// PollInterval is the interval between the status requests while waiting for the confirmation
+	PollInterval time.Duration
+	Out          io.Writer
+}
+
+// Run disperses the data to the quorums and checks the chunks served by the operators. The returned error is only
+// set if the operators cannot be checked at all, e.g. because the operator state cannot be read, while the failures
+// of the dispersal and of the operators are recorded in the report.
+func (c *Canary) Run(ctx context.Context, data []byte, quorums []core.QuorumID, operatorIDs []core.OperatorID) (*Report, error) {
+	report := &Report{}
+	customQuorums := make([]uint8, len(quorums))
+	for i, quorum := range quorums {
+		customQuorums[i] = uint8(quorum)
+	}
+	requestID, err := c.Disperser.DisperseBlob(ctx, data, customQuorums)
+	if err != nil {
+		report.DispersalErr = fmt.Errorf("failed to disperse canary blob: %w", err)
+		return report, nil
+	}
+	report.RequestID = requestID
+	c.printf("dispersed canary blob, request ID: %x\n", requestID)
+
+	info, err := c.waitForConfirmation(ctx, requestID)
+	if err != nil {
+		report.DispersalErr = err
+		return report, nil
+	}
+	batchMetadata := info.GetBlobVerificationProof().GetBatchMetadata()
+	copy(report.BatchHeaderHash[:], batchMetadata.GetBatchHeaderHash())
+	report.BlobIndex = info.GetBlobVerificationProof().GetBlobIndex()
+	report.ReferenceBlockNumber = uint(batchMetadata.GetBatchHeader().GetReferenceBlockNumber())
+	c.printf("canary blob confirmed, batch header hash: %x, blob index: %d, reference block number: %d\n", report.BatchHeaderHash, report.BlobIndex, report.ReferenceBlockNumber)
+
+	blobQuorums := make([]core.QuorumID, 0)
+	for _, param := range info.GetBlobHeader().GetBlobQuorumParams() {
+		blobQuorums = append(blobQuorums, core.QuorumID(param.GetQuorumNumber()))
+	}
+	state, err := c.ChainState.GetIndexedOperatorState(ctx, report.ReferenceBlockNumber, blobQuorums)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get operator state at block %d: %w", report.ReferenceBlockNumber, err)
+	}
+
+	for _, operatorID := range operatorIDs {
+		results := c.checkOperator(ctx, report, info, state, operatorID)
+		for _, result := range results {
+			if result.Err != nil {
+				c.printf("FAIL operator %s quorum %d: %v\n", operatorID.Hex(), result.QuorumID, result.Err)
+			} else {
+				c.printf("PASS operator %s quorum %d: %d chunks verified\n", operatorID.Hex(), result.QuorumID, result.NumChunks)
+			}
+		}
+		report.Operators = append(report.Operators, results...)
+	}
+	return report, nil
+}
+
+// waitForConfirmation polls the status of the canary blob until it is confirmed, and returns its blob info
+func (c *Canary) waitForConfirmation(ctx context.Context, requestID []byte) (*disperser_rpc.BlobInfo, error) {
+	ticker := time.NewTicker(c.PollInterval)
+	defer ticker.Stop()
+	for {
+		reply, err := c.Disperser.GetBlobStatus(ctx, requestID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to get status of canary blob: %w", err)
+		}
+		switch reply.GetStatus() {
+		case disperser_rpc.BlobStatus_CONFIRMED, disperser_rpc.BlobStatus_FINALIZED:
+			return reply.GetInfo(), nil
+		case disperser_rpc.BlobStatus_FAILED, disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES:
+			return nil, fmt.Errorf("%w: status %s", ErrCanaryNotConfirmed, reply.GetStatus())
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("%w: %w", ErrCanaryNotConfirmed, ctx.Err())
+		case <-ticker.C:
+		}
+	}
+}
+
+// checkOperator retrieves the blob header and the chunks of the canary blob from the operator, in each quorum of the
+// blob the operator is in, and verifies them
+func (c *Canary) checkOperator(ctx context.Context, report *Report, info *disperser_rpc.BlobInfo, state *core.IndexedOperatorState, operatorID core.OperatorID) []*OperatorResult {
+	failed := func(err error) []*OperatorResult {
+		return []*OperatorResult{{OperatorID: operatorID, Err: err}}
+	}
+	operatorInfo, ok := state.IndexedOperators[operatorID]
+	if !ok {
+		return failed(ErrOperatorNotRegistered)
+	}
+
+	blobHeader, proof, err := c.NodeClient.GetBlobHeader(ctx, operatorInfo.Socket, report.BatchHeaderHash, report.BlobIndex)
+	if err != nil {
+		return failed(fmt.Errorf("failed to get blob header: %w", err))
+	}
+	if err := verifyBlobHeader(blobHeader, proof, info); err != nil {
+		return failed(err)
+	}
+
+	results := make([]*OperatorResult, 0)
+	for _, quorumInfo := range blobHeader.QuorumInfos {
+		if _, ok := state.Operators[quorumInfo.QuorumID][operatorID]; !ok {
+			continue
+		}
+		result := &OperatorResult{OperatorID: operatorID, QuorumID: quorumInfo.QuorumID}
+		result.NumChunks, result.Err = c.checkChunks(ctx, report, blobHeader, quorumInfo, state, operatorInfo, operatorID)
+		results = append(results, result)
+	}
+	if len(results) == 0 {
+		return failed(ErrOperatorNotInQuorums)
+	}
+	return results
+}
+
+// checkChunks retrieves the chunks of the canary blob in the quorum from the operator, verifies them against the
+// commitment of the blob and returns their number
+func (c *Canary) checkChunks(ctx context.Context, report *Report, blobHeader *core.BlobHeader, quorumInfo *core.BlobQuorumInfo, state *core.IndexedOperatorState, operatorInfo *core.IndexedOperatorInfo, operatorID core.OperatorID) (int, error) {
+	assignments, info, err := c.AssignmentCoordinator.GetAssignments(state.OperatorState, blobHeader.Length, quorumInfo)
+	if err != nil {
+		return 0, fmt.Errorf("failed to get assignments: %w", err)
+	}
+	assignment, ok := assignments[operatorID]
+	if !ok || assignment.NumChunks == 0 {
+		return 0, fmt.Errorf("no chunk assigned to the operator")
+	}
+
+	chunksChan := make(chan clients.RetrievedChunks, 1)
+	c.NodeClient.GetChunks(ctx, operatorID, operatorInfo, report.BatchHeaderHash, report.BlobIndex, quorumInfo.QuorumID, chunksChan)
+	reply := <-chunksChan
+	if reply.Err != nil {
+		return 0, fmt.Errorf("failed to get chunks: %w", reply.Err)
+	}
+	if len(reply.Chunks) != int(assignment.NumChunks) {
+		return 0, fmt.Errorf("got %d chunks, expected %d", len(reply.Chunks), assignment.NumChunks)
+	}
+	params := encoding.ParamsFromMins(quorumInfo.ChunkLength, info.TotalChunks)
+	if err := c.Verifier.VerifyFrames(reply.Chunks, assignment.GetIndices(), blobHeader.BlobCommitments, params); err != nil {
+		return 0, fmt.Errorf("invalid chunks: %w", err)
+	}
+	return len(reply.Chunks), nil
+}
+
+// verifyBlobHeader checks that the blob header served by an operator is the header of the canary blob, with a proof
+// of its inclusion in the batch
+func verifyBlobHeader(blobHeader *core.BlobHeader, proof *merkletree.Proof, info *disperser_rpc.BlobInfo) error {
+	blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+	if err != nil {
+		return fmt.Errorf("invalid blob header: %w", err)
+	}
+	batchRoot := info.GetBlobVerificationProof().GetBatchMetadata().GetBatchHeader().GetBatchRoot()
+	verified, err := merkletree.VerifyProofUsing(blobHeaderHash[:], false, proof, [][]byte{batchRoot}, keccak256.New())
+	if err != nil || !verified {
+		return fmt.Errorf("blob header not included in the batch root %x", batchRoot)
+	}
+	commitment := info.GetBlobHeader().GetCommitment()
+	if blobHeader.BlobCommitments.Commitment == nil ||
+		!bytes.Equal(blobHeader.BlobCommitments.Commitment.X.Marshal(), commitment.GetX()) ||
+		!bytes.Equal(blobHeader.BlobCommitments.Commitment.Y.Marshal(), commitment.GetY()) {
+		return errors.New("blob header commitment doesn't match the canary blob")
+	}
+	return nil
+}
+
+func (c *Canary) printf(format string, args ...any) {
+	if c.Out != nil {
+		fmt.Fprintf(c.Out, format, args...)
+	}
+}
@@ -0,0 +1,157 @@
+package canary_test
+
+import (
+	"context"
+	"runtime"
+	"testing"
+	"time"
+
+	clientsmock "github.com/Layr-Labs/eigenda/api/clients/mock"
+	commonpb "github.com/Layr-Labs/eigenda/api/grpc/common"
+	disperser_rpc "github.com/Layr-Labs/eigenda/api/grpc/disperser"
+	"github.com/Layr-Labs/eigenda/core"
+	coremock "github.com/Layr-Labs/eigenda/core/mock"
+	"github.com/Layr-Labs/eigenda/encoding"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/prover"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/tools/canary"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/mock"
+	"github.com/stretchr/testify/require"
+	"github.com/wealdtech/go-merkletree/v2"
+	"github.com/wealdtech/go-merkletree/v2/keccak256"
+)
+
+type mockDisperser struct {
+	status disperser_rpc.BlobStatus
+	info   *disperser_rpc.BlobInfo
+}
+
+func (d *mockDisperser) DisperseBlob(ctx context.Context, data []byte, customQuorums []uint8) ([]byte, error) {
+	return []byte{1}, nil
+}
+
+func (d *mockDisperser) GetBlobStatus(ctx context.Context, requestID []byte) (*disperser_rpc.BlobStatusReply, error) {
+	return &disperser_rpc.BlobStatusReply{Status: d.status, Info: d.info}, nil
+}
+
+func TestCanary(t *testing.T) {
+	kzgConfig := &kzg.KzgConfig{
+		G1Path:          "../../inabox/resources/kzg/g1.point",
+		G2Path:          "../../inabox/resources/kzg/g2.point",
+		CacheDir:        "../../inabox/resources/kzg/SRSTables",
+		SRSOrder:        3000,
+		SRSNumberToLoad: 3000,
+		NumWorker:       uint64(runtime.GOMAXPROCS(0)),
+	}
+	p, err := prover.NewProver(kzgConfig, true)
+	require.NoError(t, err)
+	v, err := verifier.NewVerifier(kzgConfig, true)
+	require.NoError(t, err)
+
+	chainState, err := coremock.MakeChainDataMock(map[uint8]int{0: 4})
+	require.NoError(t, err)
+	state, err := chainState.GetIndexedOperatorState(context.Background(), 0, []core.QuorumID{0})
+	require.NoError(t, err)
+	coordinator := &core.StdAssignmentCoordinator{}
+
+	// Encode the canary blob as the disperser does
+	data, err := canary.NewCanaryBlob("v1.0.0", time.Unix(1000, 0))
+	require.NoError(t, err)
+	blobLength := encoding.GetBlobLength(uint(len(data)))
+	quorumInfo := &core.BlobQuorumInfo{
+		SecurityParam: core.SecurityParam{QuorumID: 0, AdversaryThreshold: 80, ConfirmationThreshold: 90},
+	}
+	quorumInfo.ChunkLength, err = coordinator.CalculateChunkLength(state.OperatorState, blobLength, 0, &quorumInfo.SecurityParam)
+	require.NoError(t, err)
+	assignments, info, err := coordinator.GetAssignments(state.OperatorState, blobLength, quorumInfo)
+	require.NoError(t, err)
+	commitments, chunks, err := p.EncodeAndProve(data, encoding.ParamsFromMins(quorumInfo.ChunkLength, info.TotalChunks))
+	require.NoError(t, err)
+	blobHeader := &core.BlobHeader{BlobCommitments: commitments, QuorumInfos: []*core.BlobQuorumInfo{quorumInfo}}
+	encodedBlob := core.EncodedBlob{BlobHeader: blobHeader, EncodedBundlesByOperator: make(map[core.OperatorID]core.EncodedBundles)}
+	for id, assignment := range assignments {
+		bundles, err := core.Bundles{0: chunks[assignment.StartIndex : assignment.StartIndex+assignment.NumChunks]}.ToEncodedBundles()
+		require.NoError(t, err)
+		encodedBlob.EncodedBundlesByOperator[id] = bundles
+	}
+
+	blobHeaderHash, err := blobHeader.GetBlobHeaderHash()
+	require.NoError(t, err)
+	tree, err := merkletree.NewTree(merkletree.WithData([][]byte{blobHeaderHash[:]}), merkletree.WithHashType(keccak256.New()))
+	require.NoError(t, err)
+	proof, err := tree.GenerateProof(blobHeaderHash[:], 0)
+	require.NoError(t, err)
+	batchHeaderHash := [32]byte{2}
+
+	disperser := &mockDisperser{
+		status: disperser_rpc.BlobStatus_CONFIRMED,
+		info: &disperser_rpc.BlobInfo{
+			BlobHeader: &disperser_rpc.BlobHeader{
+				Commitment: &commonpb.G1Commitment{
+					X: commitments.Commitment.X.Marshal(),
+					Y: commitments.Commitment.Y.Marshal(),
+				},
+				BlobQuorumParams: []*disperser_rpc.BlobQuorumParam{{QuorumNumber: 0}},
+			},
+			BlobVerificationProof: &disperser_rpc.BlobVerificationProof{
+				BlobIndex: 3,
+				BatchMetadata: &disperser_rpc.BatchMetadata{
+					BatchHeader:     &disperser_rpc.BatchHeader{BatchRoot: tree.Root()},
+					BatchHeaderHash: batchHeaderHash[:],
+				},
+			},
+		},
+	}
+	nodeClient := clientsmock.NewNodeClient()
+	nodeClient.On("GetBlobHeader", mock.Anything, batchHeaderHash, uint32(3)).Return(blobHeader, proof.Hashes, proof.Index, nil)
+	nodeClient.On("GetChunks", mock.Anything, mock.Anything, batchHeaderHash, uint32(3)).Return(encodedBlob)
+
+	c := &canary.Canary{
+		Disperser:             disperser,
+		ChainState:            chainState,
+		AssignmentCoordinator: coordinator,
+		NodeClient:            nodeClient,
+		Verifier:              v,
+		PollInterval:          time.Millisecond,
+	}
+	operatorIDs := make([]core.OperatorID, 0)
+	for id := range state.IndexedOperators {
+		operatorIDs = append(operatorIDs, id)
+	}
+
+	report, err := c.Run(context.Background(), data, nil, operatorIDs[:2])
+	require.NoError(t, err)
+	assert.True(t, report.Passed())
+	assert.Equal(t, batchHeaderHash, report.BatchHeaderHash)
+	assert.Len(t, report.Operators, 2)
+	for _, result := range report.Operators {
+		assert.Equal(t, int(assignments[result.OperatorID].NumChunks), result.NumChunks)
+	}
+
+	// An operator which isn't registered fails the canary
+	report, err = c.Run(context.Background(), data, nil, []core.OperatorID{operatorIDs[0], {0xff}})
+	require.NoError(t, err)
+	assert.False(t, report.Passed())
+	assert.NoError(t, report.Operators[0].Err)
+	assert.ErrorIs(t, report.Operators[1].Err, canary.ErrOperatorNotRegistered)
+
+	// A blob header which isn't the canary's fails the canary
+	otherHeader := &core.BlobHeader{BlobCommitments: commitments, QuorumInfos: []*core.BlobQuorumInfo{quorumInfo, quorumInfo}}
+	otherNodeClient := clientsmock.NewNodeClient()
+	otherNodeClient.On("GetBlobHeader", mock.Anything, batchHeaderHash, uint32(3)).Return(otherHeader, proof.Hashes, proof.Index, nil)
+	c.NodeClient = otherNodeClient
+	report, err = c.Run(context.Background(), data, nil, operatorIDs[:1])
+	require.NoError(t, err)
+	assert.False(t, report.Passed())
+	assert.ErrorContains(t, report.Operators[0].Err, "not included in the batch root")
+
+	// The operators aren't checked if the canary blob isn't confirmed
+	disperser.status = disperser_rpc.BlobStatus_INSUFFICIENT_SIGNATURES
+	report, err = c.Run(context.Background(), data, nil, operatorIDs[:1])
+	require.NoError(t, err)
+	assert.False(t, report.Passed())
+	assert.ErrorIs(t, report.DispersalErr, canary.ErrCanaryNotConfirmed)
+	assert.Empty(t, report.Operators)
+}
@@ -0,0 +1,108 @@
+package main
+
+import (
+	"context"
+	"fmt"
+	"log"
+	"os"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/api/clients"
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/tools/canary"
+	"github.com/Layr-Labs/eigenda/tools/canary/flags"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "canary"
+	app.Description = "disperses a canary blob and checks that the chosen operators serve valid chunks of it"
+	app.Usage = ""
+	app.Flags = flags.Flags
+	app.Action = RunCanary
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func RunCanary(ctx *cli.Context) error {
+	config, err := canary.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+
+	gethClient, err := geth.NewClient(config.EthClientConfig, gethcommon.Address{}, 0, logger)
+	if err != nil {
+		logger.Error("Cannot create chain.Client", "err", err)
+		return err
+	}
+
+	tx, err := eth.NewTransactor(logger, gethClient, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return fmt.Errorf("failed to create transactor: %w", err)
+	}
+	cs := eth.NewChainState(tx, gethClient)
+	ics := thegraph.MakeIndexedChainState(config.ChainStateConfig, cs, logger)
+
+	v, err := verifier.NewVerifier(&config.EncoderConfig, true)
+	if err != nil {
+		return fmt.Errorf("failed to create verifier: %w", err)
+	}
+
+	dispersalClient, err := clients.NewDispersalClient(clients.DispersalClientConfig{
+		Disperser:  config.DisperserRPC,
+		DisableTLS: config.DisableTLS,
+	}, nil)
+	if err != nil {
+		return err
+	}
+	defer dispersalClient.Close()
+
+	data, err := canary.NewCanaryBlob(config.Label, time.Now())
+	if err != nil {
+		return fmt.Errorf("failed to encode canary blob: %w", err)
+	}
+
+	c := &canary.Canary{
+		Disperser:             dispersalClient,
+		ChainState:            ics,
+		AssignmentCoordinator: &core.StdAssignmentCoordinator{},
+		NodeClient:            clients.NewNodeClient(config.RetrievalTimeout),
+		Verifier:              v,
+		PollInterval:          config.PollInterval,
+		Out:                   os.Stdout,
+	}
+	runCtx, cancel := context.WithTimeout(context.Background(), config.ConfirmationTimeout+config.RetrievalTimeout*time.Duration(len(config.OperatorIds)))
+	defer cancel()
+	report, err := c.Run(runCtx, data, config.CustomQuorums, config.OperatorIds)
+	if err != nil {
+		return err
+	}
+	if report.DispersalErr != nil {
+		return report.DispersalErr
+	}
+	if !report.Passed() {
+		return fmt.Errorf("canary failed on batch %x blob %d", report.BatchHeaderHash, report.BlobIndex)
+	}
+	fmt.Printf("canary passed on %d operators\n", len(config.OperatorIds))
+	return nil
+}
@@ -0,0 +1,72 @@
+package canary
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/Layr-Labs/eigenda/tools/canary/flags"
+	"github.com/urfave/cli"
+)
+
+type Config struct {
+	LoggerConfig        common.LoggerConfig
+	DisperserRPC        string
+	DisableTLS          bool
+	OperatorIds         []core.OperatorID
+	CustomQuorums       []core.QuorumID
+	Label               string
+	ConfirmationTimeout time.Duration
+	PollInterval        time.Duration
+	RetrievalTimeout    time.Duration
+	EthClientConfig     geth.EthClientConfig
+	ChainStateConfig    thegraph.Config
+	EncoderConfig       kzg.KzgConfig
+
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+}
+
+func ReadConfig(ctx *cli.Context) *Config {
+	return &Config{
+		DisperserRPC:                  ctx.GlobalString(flags.DisperserRPCFlag.Name),
+		DisableTLS:                    ctx.GlobalBool(flags.DisableTLSFlag.Name),
+		Label:                         ctx.GlobalString(flags.LabelFlag.Name),
+		ConfirmationTimeout:           ctx.GlobalDuration(flags.ConfirmationTimeoutFlag.Name),
+		PollInterval:                  ctx.GlobalDuration(flags.PollIntervalFlag.Name),
+		RetrievalTimeout:              ctx.GlobalDuration(flags.RetrievalTimeoutFlag.Name),
+		EthClientConfig:               geth.ReadEthClientConfig(ctx),
+		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
+		EncoderConfig:                 kzg.ReadCLIConfig(ctx),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(flags.EigenDAServiceManagerFlag.Name),
+	}
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config := ReadConfig(ctx)
+	for _, id := range ctx.GlobalStringSlice(flags.OperatorIdsFlag.Name) {
+		operatorID, err := core.OperatorIDFromHex(id)
+		if err != nil {
+			return nil, fmt.Errorf("invalid operator id %s: %w", id, err)
+		}
+		config.OperatorIds = append(config.OperatorIds, operatorID)
+	}
+	for _, quorum := range ctx.GlobalIntSlice(flags.CustomQuorumsFlag.Name) {
+		if quorum < 0 || quorum > core.MaxQuorumID {
+			return nil, fmt.Errorf("invalid quorum ID %d", quorum)
+		}
+		config.CustomQuorums = append(config.CustomQuorums, core.QuorumID(quorum))
+	}
+	config.LoggerConfig = *loggerConfig
+	return config, nil
+}
@@ -0,0 +1,111 @@
+package flags
+
+import (
+	"time"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core/thegraph"
+	"github.com/Layr-Labs/eigenda/encoding/kzg"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "CANARY"
+)
+
+var (
+	/* Required Flags*/
+	DisperserRPCFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disperser-rpc"),
+		Usage:    "host:port of the disperser the canary blob is dispersed to",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISPERSER_RPC"),
+	}
+	OperatorIdsFlag = cli.StringSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "operator-ids"),
+		Usage:    "IDs of the operators the chunks of the canary blob are retrieved from, e.g. the operators running the release under test",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_IDS"),
+	}
+	BlsOperatorStateRetrieverFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "bls-operator-state-retriever"),
+		Usage:    "Address of the BLS Operator State Retriever",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+	}
+	EigenDAServiceManagerFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "eigenda-service-manager"),
+		Usage:    "Address of the EigenDA Service Manager",
+		Required: true,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "EIGENDA_SERVICE_MANAGER"),
+	}
+	/* Optional Flags*/
+	DisableTLSFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "disable-tls"),
+		Usage:    "connect to the disperser without TLS",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISABLE_TLS"),
+	}
+	CustomQuorumsFlag = cli.IntSliceFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "custom-quorums"),
+		Usage:    "IDs of the quorums the canary blob is dispersed to in addition to the required quorums",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CUSTOM_QUORUMS"),
+	}
+	LabelFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "label"),
+		Usage:    "label of the release under test, which is written in the canary blob",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "LABEL"),
+	}
+	ConfirmationTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "confirmation-timeout"),
+		Usage:    "maximum time to wait for the canary blob to be confirmed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CONFIRMATION_TIMEOUT"),
+		Value:    30 * time.Minute,
+	}
+	PollIntervalFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "poll-interval"),
+		Usage:    "interval between the status requests while waiting for the canary blob to be confirmed",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "POLL_INTERVAL"),
+		Value:    5 * time.Second,
+	}
+	RetrievalTimeoutFlag = cli.DurationFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "retrieval-timeout"),
+		Usage:    "maximum time to wait for the blob header and the chunks from each operator",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "RETRIEVAL_TIMEOUT"),
+		Value:    30 * time.Second,
+	}
+)
+
+var requiredFlags = []cli.Flag{
+	DisperserRPCFlag,
+	OperatorIdsFlag,
+	BlsOperatorStateRetrieverFlag,
+	EigenDAServiceManagerFlag,
+}
+
+var optionalFlags = []cli.Flag{
+	DisableTLSFlag,
+	CustomQuorumsFlag,
+	LabelFlag,
+	ConfirmationTimeoutFlag,
+	PollIntervalFlag,
+	RetrievalTimeoutFlag,
+}
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(requiredFlags, optionalFlags...)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+	Flags = append(Flags, geth.EthClientFlags(envPrefix)...)
+	Flags = append(Flags, thegraph.CLIFlags(envPrefix)...)
+	Flags = append(Flags, kzg.CLIFlags(envPrefix)...)
+}
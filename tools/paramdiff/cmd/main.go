@@ -0,0 +1,98 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"log"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/tools/paramdiff"
+	"github.com/Layr-Labs/eigenda/tools/paramdiff/flags"
+	"github.com/jedib0t/go-pretty/v6/table"
+	"github.com/urfave/cli"
+)
+
+var (
+	version   = ""
+	gitCommit = ""
+	gitDate   = ""
+)
+
+func main() {
+	app := cli.NewApp()
+	app.Version = fmt.Sprintf("%s,%s,%s", version, gitCommit, gitDate)
+	app.Name = "paramdiff"
+	app.Description = "compare the protocol parameters of two environments"
+	app.Usage = ""
+	app.Flags = flags.Flags
+	app.Action = RunDiff
+	if err := app.Run(os.Args); err != nil {
+		log.Fatal(err)
+	}
+}
+
+func RunDiff(ctx *cli.Context) error {
+	config, err := paramdiff.NewConfig(ctx)
+	if err != nil {
+		return err
+	}
+
+	logger, err := common.NewLogger(config.LoggerConfig)
+	if err != nil {
+		return err
+	}
+
+	if config.Dump {
+		params, err := paramdiff.ReadEnvParams(context.Background(), config.From, logger)
+		if err != nil {
+			return err
+		}
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		return encoder.Encode(params)
+	}
+
+	report, err := paramdiff.Diff(context.Background(), config.From, config.To, logger)
+	if err != nil {
+		return err
+	}
+	if config.Output == flags.JSONOutput {
+		encoder := json.NewEncoder(os.Stdout)
+		encoder.SetIndent("", "  ")
+		if err := encoder.Encode(report); err != nil {
+			return err
+		}
+	} else {
+		displayReport(report)
+	}
+
+	// Drift fails the command, so that it can gate a deployment
+	if len(report.Diffs) > 0 {
+		return fmt.Errorf("found %d protocol parameters differing between %s and %s", len(report.Diffs), report.From, report.To)
+	}
+	return nil
+}
+
+func displayReport(report *paramdiff.Report) {
+	if len(report.Diffs) == 0 {
+		fmt.Printf("The protocol parameters of %s and %s are identical\n", report.From, report.To)
+		return
+	}
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"param", report.From, report.To})
+	for _, diff := range report.Diffs {
+		tw.AppendRow(table.Row{diff.Param, displayValue(diff.From), displayValue(diff.To)})
+	}
+	tw.SetTitle(fmt.Sprintf("Protocol parameters differing between %s and %s", report.From, report.To))
+	fmt.Println(tw.Render())
+}
+
+// displayValue marks the parameters missing from an environment
+func displayValue(value string) string {
+	if value == "" {
+		return "(missing)"
+	}
+	return value
+}
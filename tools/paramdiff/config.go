@@ -0,0 +1,87 @@
+package paramdiff
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/tools/paramdiff/flags"
+	"github.com/urfave/cli"
+)
+
+// EnvConfig is the configuration of an environment whose parameters are compared. They are read from ParamsFile if
+// it is set, and onchain otherwise.
+type EnvConfig struct {
+	Name            string
+	ParamsFile      string
+	EthClientConfig geth.EthClientConfig
+
+	BLSOperatorStateRetrieverAddr string
+	EigenDAServiceManagerAddr     string
+}
+
+type Config struct {
+	LoggerConfig common.LoggerConfig
+	From         EnvConfig
+	To           EnvConfig
+	Output       string
+	Dump         bool
+}
+
+func readEnvConfig(ctx *cli.Context, envFlags flags.EnvFlags) (EnvConfig, error) {
+	config := EnvConfig{
+		Name:                          ctx.GlobalString(envFlags.Name.Name),
+		ParamsFile:                    ctx.GlobalString(envFlags.ParamsFile.Name),
+		BLSOperatorStateRetrieverAddr: ctx.GlobalString(envFlags.BlsOperatorStateRetriever.Name),
+		EigenDAServiceManagerAddr:     ctx.GlobalString(envFlags.EigenDAServiceManager.Name),
+	}
+	if config.ParamsFile != "" {
+		return config, nil
+	}
+	rpcURL := ctx.GlobalString(envFlags.RPCURL.Name)
+	if rpcURL == "" || config.BLSOperatorStateRetrieverAddr == "" || config.EigenDAServiceManagerAddr == "" {
+		return EnvConfig{}, fmt.Errorf("either --%s, or --%s, --%s and --%s must be set", envFlags.ParamsFile.Name, envFlags.RPCURL.Name, envFlags.BlsOperatorStateRetriever.Name, envFlags.EigenDAServiceManager.Name)
+	}
+	config.EthClientConfig = geth.EthClientConfig{
+		RPCURLs:          []string{rpcURL},
+		NumConfirmations: 0,
+		NumRetries:       2,
+	}
+	return config, nil
+}
+
+func NewConfig(ctx *cli.Context) (*Config, error) {
+	loggerConfig, err := common.ReadLoggerCLIConfig(ctx, flags.FlagPrefix)
+	if err != nil {
+		return nil, err
+	}
+
+	config := &Config{
+		Output: ctx.GlobalString(flags.OutputFlag.Name),
+		Dump:   ctx.GlobalBool(flags.DumpFlag.Name),
+	}
+	config.From, err = readEnvConfig(ctx, flags.FromFlags)
+	if err != nil {
+		return nil, err
+	}
+	if !config.Dump {
+		config.To, err = readEnvConfig(ctx, flags.ToFlags)
+		if err != nil {
+			return nil, err
+		}
+	}
+	switch config.Output {
+	case flags.TableOutput:
+	case flags.JSONOutput:
+		// Keep stdout for the report, so that it can be piped
+		loggerConfig.OutputWriter = os.Stderr
+	default:
+		return nil, fmt.Errorf("invalid output format %s", config.Output)
+	}
+	if config.Dump {
+		loggerConfig.OutputWriter = os.Stderr
+	}
+	config.LoggerConfig = *loggerConfig
+	return config, nil
+}
@@ -0,0 +1,99 @@
+package flags
+
+import (
+	"strings"
+
+	"github.com/Layr-Labs/eigenda/common"
+	"github.com/urfave/cli"
+)
+
+const (
+	FlagPrefix = ""
+	envPrefix  = "PARAMDIFF"
+
+	// FromEnv and ToEnv are the prefixes of the flags of the environments which are compared
+	FromEnv = "from"
+	ToEnv   = "to"
+
+	TableOutput = "table"
+	JSONOutput  = "json"
+)
+
+// EnvFlags are the flags of an environment, whose parameters are read onchain from its RPC and contracts, or from a
+// params file
+type EnvFlags struct {
+	Name                      cli.StringFlag
+	RPCURL                    cli.StringFlag
+	BlsOperatorStateRetriever cli.StringFlag
+	EigenDAServiceManager     cli.StringFlag
+	ParamsFile                cli.StringFlag
+}
+
+func newEnvFlags(env string, defaultName string) EnvFlags {
+	envVarPrefix := common.PrefixEnvVar(envPrefix, strings.ToUpper(env))
+	return EnvFlags{
+		Name: cli.StringFlag{
+			Name:     common.PrefixFlag(FlagPrefix, env+"-name"),
+			Usage:    "name of the environment in the report, e.g. testnet",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envVarPrefix, "NAME"),
+			Value:    defaultName,
+		},
+		RPCURL: cli.StringFlag{
+			Name:     common.PrefixFlag(FlagPrefix, env+"-rpc-url"),
+			Usage:    "URL of the RPC of the chain of the environment",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envVarPrefix, "RPC_URL"),
+		},
+		BlsOperatorStateRetriever: cli.StringFlag{
+			Name:     common.PrefixFlag(FlagPrefix, env+"-bls-operator-state-retriever"),
+			Usage:    "Address of the BLS Operator State Retriever of the environment",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envVarPrefix, "BLS_OPERATOR_STATE_RETRIVER"),
+		},
+		EigenDAServiceManager: cli.StringFlag{
+			Name:     common.PrefixFlag(FlagPrefix, env+"-eigenda-service-manager"),
+			Usage:    "Address of the EigenDA Service Manager of the environment",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envVarPrefix, "EIGENDA_SERVICE_MANAGER"),
+		},
+		ParamsFile: cli.StringFlag{
+			Name:     common.PrefixFlag(FlagPrefix, env+"-params-file"),
+			Usage:    "path of a JSON file of the parameters of the environment, e.g. the ones a service is configured with, read instead of the chain. The output of --dump has this format",
+			Required: false,
+			EnvVar:   common.PrefixEnvVar(envVarPrefix, "PARAMS_FILE"),
+		},
+	}
+}
+
+func (f EnvFlags) flags() []cli.Flag {
+	return []cli.Flag{f.Name, f.RPCURL, f.BlsOperatorStateRetriever, f.EigenDAServiceManager, f.ParamsFile}
+}
+
+var (
+	FromFlags = newEnvFlags(FromEnv, FromEnv)
+	ToFlags   = newEnvFlags(ToEnv, ToEnv)
+
+	OutputFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "output"),
+		Usage:    "format of the report: table or json. Logs are written to stderr for json",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OUTPUT"),
+		Value:    TableOutput,
+	}
+	DumpFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "dump"),
+		Usage:    "instead of comparing the environments, print the parameters of the from environment as JSON, in the format of the params files",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DUMP"),
+	}
+)
+
+// Flags contains the list of configuration options available to the binary.
+var Flags []cli.Flag
+
+func init() {
+	Flags = append(FromFlags.flags(), ToFlags.flags()...)
+	Flags = append(Flags, OutputFlag, DumpFlag)
+	Flags = append(Flags, common.LoggerCLIFlags(envPrefix, FlagPrefix)...)
+}
@@ -0,0 +1,64 @@
+package paramdiff
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
+	"github.com/Layr-Labs/eigenda/core/eth"
+	"github.com/Layr-Labs/eigensdk-go/logging"
+	gethcommon "github.com/ethereum/go-ethereum/common"
+)
+
+// Report is the difference of the protocol parameters between two environments
+type Report struct {
+	From       string                   `json:"from"`
+	To         string                   `json:"to"`
+	FromParams *core.ProtocolParams     `json:"fromParams"`
+	ToParams   *core.ProtocolParams     `json:"toParams"`
+	Diffs      []core.ProtocolParamDiff `json:"diffs"`
+}
+
+// ReadEnvParams reads the protocol parameters of the environment from its params file, or onchain at the latest
+// block
+func ReadEnvParams(ctx context.Context, config EnvConfig, logger logging.Logger) (*core.ProtocolParams, error) {
+	if config.ParamsFile != "" {
+		logger.Info("Reading protocol params from file", "env", config.Name, "path", config.ParamsFile)
+		return core.ReadProtocolParamsFromFile(config.ParamsFile)
+	}
+
+	client, err := geth.NewClient(config.EthClientConfig, gethcommon.Address{}, 0, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create eth client of %s: %w", config.Name, err)
+	}
+	tx, err := eth.NewTransactor(logger, client, config.BLSOperatorStateRetrieverAddr, config.EigenDAServiceManagerAddr)
+	if err != nil {
+		return nil, fmt.Errorf("failed to create transactor of %s: %w", config.Name, err)
+	}
+	blockNumber, err := tx.GetCurrentBlockNumber(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to get current block number of %s: %w", config.Name, err)
+	}
+	logger.Info("Reading protocol params onchain", "env", config.Name, "block", blockNumber)
+	return core.ReadProtocolParams(ctx, tx, blockNumber)
+}
+
+// Diff reads the protocol parameters of both environments and returns their differences
+func Diff(ctx context.Context, from, to EnvConfig, logger logging.Logger) (*Report, error) {
+	fromParams, err := ReadEnvParams(ctx, from, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol params of %s: %w", from.Name, err)
+	}
+	toParams, err := ReadEnvParams(ctx, to, logger)
+	if err != nil {
+		return nil, fmt.Errorf("failed to read protocol params of %s: %w", to.Name, err)
+	}
+	return &Report{
+		From:       from.Name,
+		To:         to.Name,
+		FromParams: fromParams,
+		ToParams:   toParams,
+		Diffs:      core.DiffProtocolParams(fromParams, toParams),
+	}, nil
+}
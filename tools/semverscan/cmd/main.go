@@ -2,9 +2,18 @@ package main
 
 import (
 	"context"
+	"encoding/csv"
+	"encoding/json"
 	"fmt"
+	"io"
 	"log"
 	"os"
+	"os/signal"
+	"sort"
+	"strconv"
+	"strings"
+	"syscall"
+	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
@@ -68,17 +77,239 @@ func RunScan(ctx *cli.Context) error {
 	if err != nil {
 		return fmt.Errorf("failed to fetch current block number - %s", err)
 	}
-	operatorState, err := ics.GetIndexedOperatorState(context.Background(), currentBlock, []core.QuorumID{0, 1, 2})
+	// Interrupting the scan reports the operators scanned so far
+	scanCtx, stop := signal.NotifyContext(context.Background(), os.Interrupt, syscall.SIGTERM)
+	defer stop()
+
+	if config.CheckConsistency {
+		report, err := ics.CheckConsistency(scanCtx, currentBlock, config.Quorums)
+		if err != nil {
+			return fmt.Errorf("failed to check the consistency of the operator state - %s", err)
+		}
+		if config.Output == flags.JSONOutput {
+			if err := writeJSONConsistencyReport(os.Stdout, report); err != nil {
+				return err
+			}
+		} else {
+			displayConsistencyReport(report, config.Quorums)
+		}
+		if len(report.Discrepancies) > 0 {
+			return fmt.Errorf("found %d discrepancies between the subgraph and the onchain operator state at block %d", len(report.Discrepancies), report.BlockNumber)
+		}
+		return nil
+	}
+
+	operatorState, err := ics.GetIndexedOperatorState(scanCtx, currentBlock, config.Quorums)
 	if err != nil {
 		return fmt.Errorf("failed to fetch indexed operator state - %s", err)
 	}
-	logger.Info("Queried operator state", "count", len(operatorState.IndexedOperators))
+	// Only the operators registered in the quorums are scanned
+	operators := make(map[core.OperatorID]*core.IndexedOperatorInfo)
+	for _, quorumOperators := range operatorState.Operators {
+		for operatorId := range quorumOperators {
+			if info, ok := operatorState.IndexedOperators[operatorId]; ok {
+				operators[operatorId] = info
+			}
+		}
+	}
+	logger.Info("Queried operator state", "count", len(operators), "quorums", fmt.Sprint(config.Quorums))
+
+	// Load the database before the scan, so that an invalid one fails fast
+	var geoIPProvider semver.GeoIPProvider
+	if config.GeoIPDB != "" {
+		geoIPProvider, err = semver.NewIP2ASNProvider(config.GeoIPDB)
+		if err != nil {
+			return fmt.Errorf("failed to load the IP to ASN database - %s", err)
+		}
+	}
 
-	semvers := semver.ScanOperators(operatorState.IndexedOperators, config.Workers, config.Timeout, logger)
-	displayResults(semvers)
+	results := semver.ScanOperatorSemvers(scanCtx, operators, config.Workers, config.Timeout, config.CheckPorts, logger)
+	if geoIPProvider != nil {
+		semver.LocateOperators(scanCtx, results, geoIPProvider, logger)
+	}
+	if mismatches := semver.CheckRegisteredQuorums(results, operatorState.OperatorState); mismatches > 0 {
+		logger.Warn("Operators don't serve all the quorums they're registered in", "count", mismatches)
+	}
+	var distribution *distributionReport
+	if config.DistributionReport {
+		distribution = newDistributionReport(results, geoIPProvider != nil)
+	}
+	var stakeShares map[core.QuorumID]map[string]float64
+	if config.WeightByStake {
+		stakeShares = semver.StakeShares(results, operatorState.OperatorState)
+	}
+	switch config.Output {
+	case flags.JSONOutput:
+		return writeJSONResults(os.Stdout, results, stakeShares, distribution)
+	case flags.CSVOutput:
+		return writeCSVResults(os.Stdout, results)
+	}
+	if config.WeightByStake {
+		displayStakeResults(semver.CountSemvers(results), stakeShares, config.Quorums)
+	} else {
+		displayResults(semver.CountSemvers(results))
+	}
+	if config.CheckPorts {
+		displayPortResults(results)
+	}
+	if distribution != nil {
+		displayDistributionReport(distribution)
+	}
 	return nil
 }
 
+type operatorResult struct {
+	OperatorId      string  `json:"operator_id"`
+	Socket          string  `json:"socket"`
+	RetrievalSocket string  `json:"retrieval_socket"`
+	Semver          string  `json:"semver"`
+	LatencyMs       float64 `json:"latency_ms"`
+	DispersalPort   string  `json:"dispersal_port,omitempty"`
+	RetrievalPort   string  `json:"retrieval_port,omitempty"`
+	IP              string  `json:"ip,omitempty"`
+	ASN             uint32  `json:"asn,omitempty"`
+	ASOrg           string  `json:"as_org,omitempty"`
+	Country         string  `json:"country,omitempty"`
+	// The configuration reported by the operators which enable it
+	StorageBackend string `json:"storage_backend,omitempty"`
+	DbSizeBytes    uint64 `json:"db_size_bytes,omitempty"`
+	// The capabilities reported by the operators, and the quorums they're registered in but don't serve. The quorums
+	// are uint32 rather than core.QuorumID, which would encode to base64 like bytes.
+	QuorumIDs      []uint32 `json:"quorum_ids,omitempty"`
+	Features       []string `json:"features,omitempty"`
+	MissingQuorums []uint32 `json:"missing_quorums,omitempty"`
+}
+
+func quorumIDsToUint32(quorums []core.QuorumID) []uint32 {
+	if len(quorums) == 0 {
+		return nil
+	}
+	ids := make([]uint32, len(quorums))
+	for i, quorumID := range quorums {
+		ids[i] = uint32(quorumID)
+	}
+	return ids
+}
+
+func newOperatorResult(result *semver.OperatorSemver) operatorResult {
+	operatorResult := operatorResult{
+		OperatorId:      result.OperatorId.Hex(),
+		Socket:          result.Socket,
+		RetrievalSocket: result.RetrievalSocket,
+		Semver:          result.Semver,
+		LatencyMs:       float64(result.Latency.Microseconds()) / 1000,
+		DispersalPort:   result.DispersalPort,
+		RetrievalPort:   result.RetrievalPort,
+		IP:              result.IP,
+	}
+	if result.Location != nil {
+		operatorResult.ASN = result.Location.ASN
+		operatorResult.ASOrg = result.Location.ASOrg
+		operatorResult.Country = result.Location.Country
+	}
+	if result.Config != nil {
+		operatorResult.StorageBackend = result.Config.ChunkStorageBackend
+		operatorResult.DbSizeBytes = result.Config.DbSizeBytes
+	}
+	if result.Capabilities != nil {
+		operatorResult.QuorumIDs = quorumIDsToUint32(result.Capabilities.QuorumIDs)
+		operatorResult.Features = result.Capabilities.Features
+	}
+	operatorResult.MissingQuorums = quorumIDsToUint32(result.MissingQuorums)
+	return operatorResult
+}
+
+// distributionReport is the distribution of the latencies of the operators, and of their locations if they are
+// located
+type distributionReport struct {
+	Latency   latencyResult  `json:"latency"`
+	Countries map[string]int `json:"countries,omitempty"`
+	ASNs      map[string]int `json:"asns,omitempty"`
+}
+
+type latencyResult struct {
+	Count int     `json:"count"`
+	MinMs float64 `json:"min_ms"`
+	P50Ms float64 `json:"p50_ms"`
+	P90Ms float64 `json:"p90_ms"`
+	P99Ms float64 `json:"p99_ms"`
+	MaxMs float64 `json:"max_ms"`
+}
+
+func newDistributionReport(results []*semver.OperatorSemver, located bool) *distributionReport {
+	latency := semver.GetLatencyDistribution(results)
+	ms := func(d time.Duration) float64 {
+		return float64(d.Microseconds()) / 1000
+	}
+	report := &distributionReport{
+		Latency: latencyResult{
+			Count: latency.Count,
+			MinMs: ms(latency.Min),
+			P50Ms: ms(latency.P50),
+			P90Ms: ms(latency.P90),
+			P99Ms: ms(latency.P99),
+			MaxMs: ms(latency.Max),
+		},
+	}
+	if located {
+		report.Countries, report.ASNs = semver.CountLocations(results)
+	}
+	return report
+}
+
+func writeJSONResults(w io.Writer, results []*semver.OperatorSemver, stakeShares map[core.QuorumID]map[string]float64, distribution *distributionReport) error {
+	operators := make([]operatorResult, len(results))
+	for i, result := range results {
+		operators[i] = newOperatorResult(result)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		Semvers map[string]int `json:"semvers"`
+		// StakeShares are the percentages of the stake of each quorum by semver, if weighted by stake
+		StakeShares  map[core.QuorumID]map[string]float64 `json:"stake_shares,omitempty"`
+		Distribution *distributionReport                  `json:"distribution,omitempty"`
+		Total        int                                  `json:"total"`
+		Operators    []operatorResult                     `json:"operators"`
+	}{
+		Semvers:      semver.CountSemvers(results),
+		StakeShares:  stakeShares,
+		Distribution: distribution,
+		Total:        len(results),
+		Operators:    operators,
+	})
+}
+
+func writeCSVResults(w io.Writer, results []*semver.OperatorSemver) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write([]string{"operator_id", "socket", "retrieval_socket", "semver", "latency_ms", "dispersal_port", "retrieval_port", "ip", "asn", "as_org", "country", "storage_backend", "db_size_bytes", "quorum_ids", "features", "missing_quorums"}); err != nil {
+		return err
+	}
+	for _, result := range results {
+		row := newOperatorResult(result)
+		asn := ""
+		if row.ASN != 0 {
+			asn = strconv.FormatUint(uint64(row.ASN), 10)
+		}
+		dbSize := ""
+		if row.DbSizeBytes != 0 {
+			dbSize = strconv.FormatUint(row.DbSizeBytes, 10)
+		}
+		formatQuorums := func(quorums []uint32) string {
+			quorumIDs := make([]string, len(quorums))
+			for i, quorumID := range quorums {
+				quorumIDs[i] = strconv.FormatUint(uint64(quorumID), 10)
+			}
+			return strings.Join(quorumIDs, ";")
+		}
+		if err := cw.Write([]string{row.OperatorId, row.Socket, row.RetrievalSocket, row.Semver, strconv.FormatFloat(row.LatencyMs, 'f', 3, 64), row.DispersalPort, row.RetrievalPort, row.IP, asn, row.ASOrg, row.Country, row.StorageBackend, dbSize, formatQuorums(row.QuorumIDs), strings.Join(row.Features, ";"), formatQuorums(row.MissingQuorums)}); err != nil {
+			return err
+		}
+	}
+	cw.Flush()
+	return cw.Error()
+}
+
 func displayResults(results map[string]int) {
 	tw := table.NewWriter()
 
@@ -94,3 +325,167 @@ func displayResults(results map[string]int) {
 
 	fmt.Println(tw.Render())
 }
+
+type discrepancyResult struct {
+	Kind       string `json:"kind"`
+	Quorum     *uint8 `json:"quorum,omitempty"`
+	OperatorId string `json:"operator_id,omitempty"`
+	Detail     string `json:"detail,omitempty"`
+}
+
+func newDiscrepancyResult(discrepancy *thegraph.StateDiscrepancy) discrepancyResult {
+	result := discrepancyResult{
+		Kind:   discrepancy.Kind,
+		Quorum: discrepancy.Quorum,
+		Detail: discrepancy.Detail,
+	}
+	if discrepancy.OperatorId != nil {
+		result.OperatorId = discrepancy.OperatorId.Hex()
+	}
+	return result
+}
+
+func writeJSONConsistencyReport(w io.Writer, report *thegraph.ConsistencyReport) error {
+	discrepancies := make([]discrepancyResult, len(report.Discrepancies))
+	for i, discrepancy := range report.Discrepancies {
+		discrepancies[i] = newDiscrepancyResult(discrepancy)
+	}
+	onchainOperators := make(map[core.QuorumID]int)
+	for quorum, operators := range report.OnchainState.Operators {
+		onchainOperators[quorum] = len(operators)
+	}
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(struct {
+		BlockNumber          uint                  `json:"block_number"`
+		OnchainOperators     map[core.QuorumID]int `json:"onchain_operators"`
+		NumSubgraphOperators int                   `json:"subgraph_operators"`
+		Discrepancies        []discrepancyResult   `json:"discrepancies"`
+	}{
+		BlockNumber:          report.BlockNumber,
+		OnchainOperators:     onchainOperators,
+		NumSubgraphOperators: report.NumSubgraphOperators,
+		Discrepancies:        discrepancies,
+	})
+}
+
+// displayConsistencyReport renders the number of operators of each quorum onchain and the discrepancies with the
+// subgraph
+func displayConsistencyReport(report *thegraph.ConsistencyReport, quorums []core.QuorumID) {
+	tw := table.NewWriter()
+	tw.SetTitle(fmt.Sprintf("operator state at block %d", report.BlockNumber))
+	tw.AppendHeader(table.Row{"quorum", "onchain operators", "onchain stake"})
+	for _, quorum := range quorums {
+		stake := "0"
+		if total, ok := report.OnchainState.Totals[quorum]; ok && total.Stake != nil {
+			stake = total.Stake.String()
+		}
+		tw.AppendRow(table.Row{quorum, len(report.OnchainState.Operators[quorum]), stake})
+	}
+	tw.AppendFooter(table.Row{"subgraph", report.NumSubgraphOperators, ""})
+	fmt.Println(tw.Render())
+
+	tw = table.NewWriter()
+	tw.AppendHeader(table.Row{"discrepancy", "quorum", "operator", "detail"})
+	for _, discrepancy := range report.Discrepancies {
+		result := newDiscrepancyResult(discrepancy)
+		quorum := ""
+		if result.Quorum != nil {
+			quorum = fmt.Sprint(*result.Quorum)
+		}
+		tw.AppendRow(table.Row{result.Kind, quorum, result.OperatorId, result.Detail})
+	}
+	tw.AppendFooter(table.Row{"total", "", len(report.Discrepancies), ""})
+	fmt.Println(tw.Render())
+}
+
+// displayStakeResults renders the number of operators and the percentage of the stake of each quorum by semver
+func displayStakeResults(counts map[string]int, stakeShares map[core.QuorumID]map[string]float64, quorums []core.QuorumID) {
+	tw := table.NewWriter()
+
+	rowHeader := table.Row{"semver", "count"}
+	for _, quorum := range quorums {
+		rowHeader = append(rowHeader, fmt.Sprintf("quorum %d stake", quorum))
+	}
+	tw.AppendHeader(rowHeader)
+
+	total := 0
+	totalShares := make([]float64, len(quorums))
+	for semver, count := range counts {
+		row := table.Row{semver, count}
+		for i, quorum := range quorums {
+			share := stakeShares[quorum][semver]
+			row = append(row, fmt.Sprintf("%.2f%%", share))
+			totalShares[i] += share
+		}
+		tw.AppendRow(row)
+		total += count
+	}
+	rowFooter := table.Row{"total", total}
+	for _, share := range totalShares {
+		rowFooter = append(rowFooter, fmt.Sprintf("%.2f%%", share))
+	}
+	tw.AppendFooter(rowFooter)
+
+	fmt.Println(tw.Render())
+}
+
+// displayPortResults renders the number of operators by status of their dispersal and retrieval ports, which tells
+// apart the operators with asymmetric firewall configs
+func displayPortResults(results []*semver.OperatorSemver) {
+	counts := make(map[[2]string]int)
+	for _, result := range results {
+		counts[[2]string{result.DispersalPort, result.RetrievalPort}]++
+	}
+
+	tw := table.NewWriter()
+	tw.AppendHeader(table.Row{"dispersal port", "retrieval port", "count"})
+	for ports, count := range counts {
+		tw.AppendRow(table.Row{ports[0], ports[1], count})
+	}
+	tw.AppendFooter(table.Row{"total", "", len(results)})
+
+	fmt.Println(tw.Render())
+}
+
+// displayDistributionReport renders the percentiles of the node info latencies of the operators which responded, and
+// the number of operators by country and by ASN if they are located, which shows how concentrated the network is
+func displayDistributionReport(report *distributionReport) {
+	tw := table.NewWriter()
+	tw.SetTitle("node info latency (ms)")
+	tw.AppendHeader(table.Row{"responded", "min", "p50", "p90", "p99", "max"})
+	tw.AppendRow(table.Row{report.Latency.Count, report.Latency.MinMs, report.Latency.P50Ms, report.Latency.P90Ms, report.Latency.P99Ms, report.Latency.MaxMs})
+	fmt.Println(tw.Render())
+
+	for _, location := range []struct {
+		name   string
+		counts map[string]int
+	}{{"country", report.Countries}, {"asn", report.ASNs}} {
+		if location.counts == nil {
+			continue
+		}
+		total := 0
+		for _, count := range location.counts {
+			total += count
+		}
+		keys := make([]string, 0, len(location.counts))
+		for key := range location.counts {
+			keys = append(keys, key)
+		}
+		// the largest shares first
+		sort.Slice(keys, func(i, j int) bool {
+			if location.counts[keys[i]] != location.counts[keys[j]] {
+				return location.counts[keys[i]] > location.counts[keys[j]]
+			}
+			return keys[i] < keys[j]
+		})
+
+		tw = table.NewWriter()
+		tw.AppendHeader(table.Row{location.name, "count", "share"})
+		for _, key := range keys {
+			tw.AppendRow(table.Row{key, location.counts[key], fmt.Sprintf("%.2f%%", 100*float64(location.counts[key])/float64(total))})
+		}
+		tw.AppendFooter(table.Row{"total", total, ""})
+		fmt.Println(tw.Render())
+	}
+}
@@ -1,10 +1,16 @@
 package semverscan
 
 import (
+	"fmt"
+	"io"
+	"os"
+	"strconv"
+	"strings"
 	"time"
 
 	"github.com/Layr-Labs/eigenda/common"
 	"github.com/Layr-Labs/eigenda/common/geth"
+	"github.com/Layr-Labs/eigenda/core"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/tools/semverscan/flags"
 	"github.com/urfave/cli"
@@ -15,8 +21,16 @@ type Config struct {
 	Workers          int
 	OperatorId       string
 	Timeout          time.Duration
-	ChainStateConfig thegraph.Config
-	EthClientConfig  geth.EthClientConfig
+	Quorums          []core.QuorumID
+	WeightByStake    bool
+	CheckConsistency bool
+	CheckPorts       bool
+	// DistributionReport reports the distribution of the latencies, and of the locations if GeoIPDB is set
+	DistributionReport bool
+	GeoIPDB            string
+	Output             string
+	ChainStateConfig   thegraph.Config
+	EthClientConfig    geth.EthClientConfig
 
 	BLSOperatorStateRetrieverAddr string
 	EigenDAServiceManagerAddr     string
@@ -27,6 +41,12 @@ func ReadConfig(ctx *cli.Context) *Config {
 		Timeout:                       ctx.Duration(flags.TimeoutFlag.Name),
 		Workers:                       ctx.Int(flags.WorkersFlag.Name),
 		OperatorId:                    ctx.String(flags.OperatorIdFlag.Name),
+		WeightByStake:                 ctx.Bool(flags.WeightByStakeFlag.Name),
+		CheckConsistency:              ctx.Bool(flags.CheckConsistencyFlag.Name),
+		CheckPorts:                    ctx.Bool(flags.CheckPortsFlag.Name),
+		DistributionReport:            ctx.Bool(flags.DistributionReportFlag.Name),
+		GeoIPDB:                       ctx.String(flags.GeoIPDBFlag.Name),
+		Output:                        ctx.String(flags.OutputFlag.Name),
 		ChainStateConfig:              thegraph.ReadCLIConfig(ctx),
 		EthClientConfig:               geth.ReadEthClientConfig(ctx),
 		BLSOperatorStateRetrieverAddr: ctx.GlobalString(flags.BlsOperatorStateRetrieverFlag.Name),
@@ -41,6 +61,28 @@ func NewConfig(ctx *cli.Context) (*Config, error) {
 	}
 
 	config := ReadConfig(ctx)
+	for _, id := range strings.Split(ctx.String(flags.QuorumFlag.Name), ",") {
+		quorum, err := strconv.ParseUint(strings.TrimSpace(id), 10, 8)
+		if err != nil {
+			return nil, fmt.Errorf("invalid quorum ID %q: %w", id, err)
+		}
+		config.Quorums = append(config.Quorums, core.QuorumID(quorum))
+	}
+	switch config.Output {
+	case flags.TableOutput:
+	case flags.JSONOutput, flags.CSVOutput:
+		// Keep stdout for the results, so they can be piped
+		loggerConfig.OutputWriter = os.Stderr
+		if path := ctx.GlobalString(common.PrefixFlag(flags.FlagPrefix, common.PathFlagName)); path != "" {
+			f, err := os.OpenFile(path, os.O_CREATE|os.O_APPEND|os.O_WRONLY, 0644)
+			if err != nil {
+				return nil, err
+			}
+			loggerConfig.OutputWriter = io.MultiWriter(os.Stderr, f)
+		}
+	default:
+		return nil, fmt.Errorf("invalid output format %s", config.Output)
+	}
 	config.LoggerConfig = *loggerConfig
 	return config, nil
 }
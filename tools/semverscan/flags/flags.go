@@ -12,6 +12,10 @@ import (
 const (
 	FlagPrefix = ""
 	envPrefix  = "SEMVERSCAN"
+
+	TableOutput = "table"
+	JSONOutput  = "json"
+	CSVOutput   = "csv"
 )
 
 var (
@@ -31,7 +35,7 @@ var (
 	/* Optional Flags*/
 	TimeoutFlag = cli.DurationFlag{
 		Name:     common.PrefixFlag(FlagPrefix, "timeout"),
-		Usage:    "seconds to wait for GPRC response",
+		Usage:    "maximum time to wait for the node info response of each operator",
 		Required: false,
 		EnvVar:   common.PrefixEnvVar(envPrefix, "TIMEOUT"),
 		Value:    3 * time.Second,
@@ -50,6 +54,50 @@ var (
 		EnvVar:   common.PrefixEnvVar(envPrefix, "OPERATOR_ID"),
 		Value:    "",
 	}
+	QuorumFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "quorum"),
+		Usage:    "comma separated IDs of the quorums whose operators are scanned",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "QUORUM"),
+		Value:    "0,1,2",
+	}
+	WeightByStakeFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "weight-by-stake"),
+		Usage:    "report the percentage of the stake of each quorum running each semver, not only the number of operators",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "WEIGHT_BY_STAKE"),
+	}
+	CheckConsistencyFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "check-consistency"),
+		Usage:    "instead of scanning the operators, compare the operators registered in the quorums as indexed by the subgraph and as read onchain at the same block, and fail if they differ",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHECK_CONSISTENCY"),
+	}
+	CheckPortsFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "check-ports"),
+		Usage:    "probe both the dispersal and retrieval sockets of the operators and report the status of each port",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "CHECK_PORTS"),
+	}
+	DistributionReportFlag = cli.BoolFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "distribution-report"),
+		Usage:    "report the distribution of the node info latencies of the operators, and of their countries and ASNs if --geoip-db is set",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "DISTRIBUTION_REPORT"),
+	}
+	GeoIPDBFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "geoip-db"),
+		Usage:    "path of an IP to ASN database in the TSV format of iptoasn.com, used to locate the IP addresses of the operators",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "GEOIP_DB"),
+	}
+	OutputFlag = cli.StringFlag{
+		Name:     common.PrefixFlag(FlagPrefix, "output"),
+		Usage:    "format of the results: table (semver counts), json or csv (per-operator results). Logs are written to stderr for json and csv",
+		Required: false,
+		EnvVar:   common.PrefixEnvVar(envPrefix, "OUTPUT"),
+		Value:    TableOutput,
+	}
 )
 
 var requiredFlags = []cli.Flag{
@@ -61,6 +109,13 @@ var optionalFlags = []cli.Flag{
 	TimeoutFlag,
 	WorkersFlag,
 	OperatorIdFlag,
+	QuorumFlag,
+	WeightByStakeFlag,
+	CheckConsistencyFlag,
+	CheckPortsFlag,
+	DistributionReportFlag,
+	GeoIPDBFlag,
+	OutputFlag,
 }
 
 // Flags contains the list of configuration options available to the binary.
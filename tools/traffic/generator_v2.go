@@ -8,6 +8,7 @@ import (
 	"github.com/Layr-Labs/eigenda/core/eth"
 	"github.com/Layr-Labs/eigenda/core/thegraph"
 	"github.com/Layr-Labs/eigenda/encoding/kzg/verifier"
+	"github.com/Layr-Labs/eigenda/retriever"
 	retrivereth "github.com/Layr-Labs/eigenda/retriever/eth"
 	"github.com/Layr-Labs/eigenda/tools/traffic/config"
 	"github.com/Layr-Labs/eigenda/tools/traffic/metrics"
@@ -174,13 +175,19 @@ func buildRetriever(config *config.Config) (clients.RetrievalClient, retrivereth
 		panic(fmt.Sprintf("Unable to build statusTracker: %s", err))
 	}
 
+	selectionPolicy, err := retriever.NewOperatorSelectionPolicy(config.RetrievalClientConfig)
+	if err != nil {
+		panic(fmt.Sprintf("Unable to build operator selection policy: %s", err))
+	}
+
 	retriever, err := clients.NewRetrievalClient(
 		logger,
 		chainState,
 		assignmentCoordinator,
 		nodeClient,
 		v,
-		config.RetrievalClientConfig.NumConnections)
+		config.RetrievalClientConfig.NumConnections,
+		selectionPolicy)
 
 	if err != nil {
 		panic(fmt.Sprintf("Unable to build retriever: %s", err))